@@ -0,0 +1,73 @@
+// Builds the library entry (src/api.ts) that package.json `exports` points at:
+// a bundled ESM module for Bun plus its .d.ts declarations.
+//
+// Usage: bun scripts/build-lib.ts [outdir]   (default: dist/lib)
+
+import { readdir, readFile, writeFile } from "node:fs/promises";
+import { dirname, join, relative, resolve } from "node:path";
+
+const ROOT = resolve(import.meta.dir, "..");
+
+/**
+ * Rewrite the `@/…` specifiers tsc leaves in declarations (tsconfig `paths`
+ * are not rewritten) into relative `.js` ones, which resolve to the emitted
+ * `.d.ts` under any moduleResolution.
+ */
+export function rewriteAliases(
+  source: string,
+  fromDir: string,
+  typesDir: string
+): string {
+  const alias = /(["'])@\/([^"']+)\1/g;
+  return source.replace(alias, (_match, quote: string, path: string) => {
+    const target = relative(fromDir, join(typesDir, path));
+    const specifier = target.startsWith(".") ? target : `./${target}`;
+    return `${quote}${specifier}.js${quote}`;
+  });
+}
+
+async function declarationFiles(dir: string): Promise<string[]> {
+  const entries = await readdir(dir, { recursive: true });
+  return entries.filter((entry) => entry.endsWith(".d.ts")).map((e) => join(dir, e));
+}
+
+/** Bundle src/api.ts into `outdir`/api.js, with declarations in `outdir`/types */
+export async function buildLibrary(
+  outdir = join(ROOT, "dist", "lib")
+): Promise<void> {
+  const built = await Bun.build({
+    entrypoints: [join(ROOT, "src", "api.ts")],
+    outdir,
+    target: "bun",
+    format: "esm",
+    // Dependencies are installed alongside the package, not bundled into it
+    packages: "external",
+  });
+  if (!built.success) {
+    throw new Error(`bun build failed:\n${built.logs.join("\n")}`);
+  }
+
+  const typesDir = join(outdir, "types");
+  const tsc = Bun.spawnSync(
+    [
+      join(ROOT, "node_modules", ".bin", "tsc"),
+      "-p",
+      join(ROOT, "tsconfig.lib.json"),
+      "--outDir",
+      typesDir,
+    ],
+    { cwd: ROOT, stdout: "pipe", stderr: "pipe" }
+  );
+  if (tsc.exitCode !== 0) {
+    throw new Error(`tsc failed:\n${tsc.stdout.toString()}${tsc.stderr.toString()}`);
+  }
+  for (const file of await declarationFiles(typesDir)) {
+    const source = await readFile(file, "utf8");
+    await writeFile(file, rewriteAliases(source, dirname(file), typesDir));
+  }
+}
+
+if (import.meta.main) {
+  const outdir = process.argv[2];
+  await buildLibrary(outdir !== undefined ? resolve(outdir) : undefined);
+}
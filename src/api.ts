@@ -0,0 +1,143 @@
+import type { CommandRunner } from "@/infra/command-runner";
+import { RealCommandRunner } from "@/infra/command-runner";
+import type { Console } from "@/infra/console";
+import { SilentConsole } from "@/infra/console";
+import type { FileManager } from "@/infra/file-manager";
+import { RealFileManager } from "@/infra/file-manager";
+import type { ExitCode } from "@/models/exit-code";
+import { prepareCheck } from "@/pipelines/check-setup";
+import type { CheckStepOptions } from "@/steps/check-step";
+import { checkStep } from "@/steps/check-step";
+import { loadIgnoreMatcher } from "@/utils/ignore-file";
+import type { JsonReport } from "@/writers/json";
+import { issuesToJson } from "@/writers/json";
+
+export {
+  EXIT_CLEAN,
+  EXIT_FINDINGS,
+  EXIT_MISSING_TOOL,
+  EXIT_RUNNER_ERROR,
+  EXIT_USAGE,
+  type ExitCode,
+} from "@/models/exit-code";
+export type { JsonReport } from "@/writers/json";
+
+export interface GuardrailsOptions {
+  /** Progress and warnings (default: discarded) */
+  console?: Console;
+  /** Runs the linters (default: spawns real processes) */
+  commandRunner?: CommandRunner;
+  /** Reads sources, config and baseline (default: the real filesystem) */
+  fileManager?: FileManager;
+}
+
+export interface CheckOptions
+  extends Pick<
+    CheckStepOptions,
+    | "jobs"
+    | "maxProcs"
+    | "useCache"
+    | "files"
+    | "module"
+    | "baselinePath"
+    | "failOn"
+    | "timeout"
+    | "includeGenerated"
+    | "checkExternal"
+    | "failFast"
+    | "batchSize"
+    | "requireTools"
+  > {
+  /** Config file to use instead of the project's, like `--config` */
+  configPath?: string;
+  /** Run exactly these runner ids, like `--only`; not with enable/disable */
+  only?: readonly string[];
+  /** Runner ids to run even if disabled in config or undetected */
+  enable?: readonly string[];
+  /** Runner ids to skip */
+  disable?: readonly string[];
+  /** Also check paths listed in .gitignore and .guardrailsignore */
+  noIgnore?: boolean;
+}
+
+export interface CheckReport {
+  /** Same as `ai-guardrails check`; usage errors throw instead of returning 3 */
+  exitCode: ExitCode;
+  message: string;
+  newIssueCount: number;
+  failingIssueCount: number;
+  /** Per-runner status and findings, in the `check --format json` shape */
+  report: JsonReport;
+}
+
+/**
+ * Programmatic entry point: runs the same steps as `ai-guardrails check`
+ * without the CLI, so editors and CI tooling can embed it. Nothing is
+ * printed unless a console is injected.
+ */
+export class Guardrails {
+  private readonly console: Console;
+  private readonly commandRunner: CommandRunner;
+  private readonly fileManager: FileManager;
+
+  constructor(options: GuardrailsOptions = {}) {
+    this.console = options.console ?? new SilentConsole();
+    this.commandRunner = options.commandRunner ?? new RealCommandRunner();
+    this.fileManager = options.fileManager ?? new RealFileManager();
+  }
+
+  /**
+   * Check `projectDir` against its baseline, with the config layers and runner
+   * selection of `ai-guardrails check`. Throws when languages cannot be
+   * detected, the config does not load, or the runner ids are invalid.
+   */
+  async check(projectDir: string, options: CheckOptions = {}): Promise<CheckReport> {
+    const { configPath, only, enable, disable, noIgnore = false, ...stepOptions } =
+      options;
+
+    const prepared = await prepareCheck({
+      projectDir,
+      fileManager: this.fileManager,
+      console: this.console,
+      ...(configPath !== undefined && { configPath }),
+      ...(only !== undefined && { only }),
+      ...(enable !== undefined && { enable }),
+      ...(disable !== undefined && { disable }),
+    });
+    if (prepared.status === "error") throw new Error(prepared.message);
+    const { languages, config, loaded } = prepared.setup;
+
+    const ignore = noIgnore
+      ? null
+      : await loadIgnoreMatcher(projectDir, this.fileManager);
+    // The global config's jobs default applies as it does on the command line
+    const jobs = stepOptions.jobs ?? loaded.global?.config.jobs;
+
+    const checked = await checkStep(
+      projectDir,
+      languages,
+      config,
+      this.commandRunner,
+      this.fileManager,
+      this.console,
+      {
+        ...stepOptions,
+        ...(jobs !== undefined && { jobs }),
+        ...(ignore !== null && { ignore }),
+      }
+    );
+
+    const { result, failingIssueCount } = checked;
+    return {
+      exitCode: checked.exitCode,
+      message: result.message,
+      newIssueCount: checked.newIssueCount,
+      failingIssueCount,
+      report: issuesToJson(checked.issues, checked.runners, checked.baselined),
+    };
+  }
+}
+
+export function createGuardrails(options: GuardrailsOptions = {}): Guardrails {
+  return new Guardrails(options);
+}
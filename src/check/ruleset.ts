@@ -1,13 +1,13 @@
 import { readFile } from "node:fs/promises";
 import { join } from "node:path";
-import { parse as parseToml } from "smol-toml";
 import { recurseRule } from "@/check/builder-cmd";
 import { protectRead, protectWrite } from "@/check/builder-path";
 import { ALL_RULE_GROUPS, collectCommandRules } from "@/check/rules/groups";
 import { DEFAULT_MANAGED_FILES, DEFAULT_PATH_RULES } from "@/check/rules/paths";
 import type { CommandRule, HooksConfig, RuleSet } from "@/check/types";
+import { parseConfigText } from "@/config/config-file";
 import { ProjectConfigSchema } from "@/config/schema";
-import { PROJECT_CONFIG_PATH } from "@/models/paths";
+import { PROJECT_CONFIG_PATH, PROJECT_CONFIG_YAML_PATH } from "@/models/paths";
 import { isEnoent } from "@/utils/errors";
 
 export function buildRuleSet(config: HooksConfig): RuleSet {
@@ -56,11 +56,24 @@ function escapeRegExp(s: string): string {
   return s.replace(/[.*+?^${}()|[\]\\]/g, "\\$&");
 }
 
+/**
+ * The project config under `dir`, config.toml before config.yaml. Hooks must
+ * not fail over a config `check` rejects, so having both is not an error here.
+ */
+async function readProjectConfig(dir: string): Promise<Record<string, unknown>> {
+  try {
+    const text = await readFile(join(dir, PROJECT_CONFIG_PATH), "utf8");
+    return parseConfigText(text, "toml");
+  } catch (e: unknown) {
+    if (!isEnoent(e)) throw e;
+  }
+  const text = await readFile(join(dir, PROJECT_CONFIG_YAML_PATH), "utf8");
+  return parseConfigText(text, "yaml");
+}
+
 export async function loadHookConfig(): Promise<HooksConfig> {
   try {
-    const configPath = join(process.cwd(), PROJECT_CONFIG_PATH);
-    const text = await readFile(configPath, "utf8");
-    const raw = parseToml(text);
+    const raw = await readProjectConfig(process.cwd());
     const config = ProjectConfigSchema.parse(raw);
     const hooks = config.hooks;
     if (hooks === undefined) return {};
@@ -76,9 +89,10 @@ export async function loadHookConfig(): Promise<HooksConfig> {
     };
   } catch (e: unknown) {
     // ENOENT is expected — no config file means use defaults.
-    // Other errors (bad TOML, Zod mismatch, permissions) deserve a warning so
-    // users know their custom config isn't active. This function is only called
-    // from hook processes (not pipeline domain code), so stderr is acceptable.
+    // Other errors (bad TOML or YAML, Zod mismatch, permissions) deserve a
+    // warning so users know their custom config isn't active. This function is
+    // only called from hook processes (not pipeline domain code), so stderr is
+    // acceptable.
     const isNotFound = isEnoent(e);
     if (!isNotFound) {
       process.stderr.write(`[ai-guardrails] config load error: ${String(e)}\n`);
@@ -2,34 +2,80 @@ import { Command, Option } from "@commander-js/extra-typings";
 import { runAllow } from "@/commands/allow";
 import { runCheck } from "@/commands/check";
 import { getCompletionScript } from "@/commands/completion";
+import { runConfigSchema, runConfigShow, runConfigValidate } from "@/commands/config";
+import { runDoctor } from "@/commands/doctor";
 import { runGenerate } from "@/commands/generate";
 import { runHook } from "@/commands/hook";
+import { runHooks } from "@/commands/hooks";
 import { runInit } from "@/commands/init";
 import { runInstall } from "@/commands/install";
+import { runList } from "@/commands/list";
 import { runQuery } from "@/commands/query";
 import { runReport } from "@/commands/report";
 import { runSnapshot } from "@/commands/snapshot";
 import { runStatus } from "@/commands/status";
+import { runUpdate } from "@/commands/update";
+import { runVersion } from "@/commands/version";
+import { runWatch } from "@/commands/watch";
+import { loadGlobalConfig } from "@/config/loader";
+import { RealFileManager } from "@/infra/file-manager";
+import { EXIT_RUNNER_ERROR, EXIT_USAGE } from "@/models/exit-code";
 import pkg from "../package.json";
 
 const program = new Command()
   .name("ai-guardrails")
   .description("Pedantic code quality enforcement for AI-maintained repositories")
-  .version(pkg.version);
+  .version(pkg.version)
+  // Subcommands inherit this: a bad flag or argument exits 3, not commander's 1,
+  // which `check` keeps for findings
+  .exitOverride((err) => process.exit(err.exitCode === 0 ? 0 : EXIT_USAGE));
 
 // ---------------------------------------------------------------------------
 // Global options (inherited by subcommands via .optsWithGlobals())
 // ---------------------------------------------------------------------------
 program
   .option("--project-dir <dir>", "Override working directory", process.cwd())
-  .option("--quiet", "Suppress info/success output")
-  .option("--no-color", "Disable ANSI color output");
+  .option("--config <path>", "Read the project config from this file instead")
+  .option("-q, --quiet", "Print only failures: findings, errors and a summary")
+  .addOption(
+    new Option("--color <when>", "Colorize output (default: auto)").choices([
+      "auto",
+      "always",
+      "never",
+    ])
+  )
+  .option("--no-color", "Same as --color never")
+  .option("-v, --verbose", "Log detection evidence and runner timings to stderr")
+  .option("--debug", "Also log every command run, with exit code and duration");
 
 function getProjectDir(): string {
   const projectDir: unknown = program.getOptionValue("projectDir");
   return typeof projectDir === "string" ? projectDir : process.cwd();
 }
 
+/** The global config's color, read before each command; --color wins */
+let globalColor: string | undefined;
+
+program.hook("preAction", async () => {
+  // A malformed global config is reported once the command loads its config
+  const global = await loadGlobalConfig(new RealFileManager()).catch(() => undefined);
+  globalColor = global?.config.color;
+});
+
+/** Global config, logging and color flags, merged into each command's own flags */
+function globalFlags(): Record<string, unknown> {
+  return {
+    config: program.getOptionValue("config"),
+    quiet: program.getOptionValue("quiet"),
+    verbose: program.getOptionValue("verbose"),
+    debug: program.getOptionValue("debug"),
+    color: program.getOptionValue("color") ?? globalColor,
+  };
+}
+
+/** Shared by every command that acts on the detected languages */
+const STRICT_DETECTION_HELP = "Act only on languages a manifest confirms, e.g. go.mod";
+
 // ---------------------------------------------------------------------------
 // install
 // ---------------------------------------------------------------------------
@@ -37,8 +83,9 @@ program
   .command("install")
   .description("One-time machine setup")
   .option("--upgrade", "Overwrite existing machine config")
+  .option("--dry-run", "Print the tool install commands without running them")
   .action(async (opts) => {
-    await runInstall(getProjectDir(), { ...opts });
+    await runInstall(getProjectDir(), { ...globalFlags(), ...opts });
   });
 
 // ---------------------------------------------------------------------------
@@ -47,12 +94,27 @@ program
 program
   .command("init")
   .description("Per-project setup")
-  .option("--profile <profile>", "Profile: strict | standard | minimal")
+  .option("--profile <profile>", "Profile: strict | standard | lenient")
   .option("--force", "Overwrite existing managed files")
   .option("--upgrade", "Refresh all generated files, preserve config.toml")
+  .option("--dry-run", "Print what init would write, with diffs, and write nothing")
+  .option("--merge", "Merge our rules into an existing ruff.toml (on with --upgrade)")
   .option("--yes", "Accept all defaults (non-interactive)")
   .option("--no-hooks", "Skip lefthook install")
   .option("--no-ci", "Skip CI workflow generation")
+  .addOption(
+    new Option("--ci <provider>", "CI provider (default: detected)").choices([
+      "github",
+      "gitlab",
+      "circleci",
+      "azure",
+      "none",
+    ])
+  )
+  .addOption(
+    new Option("--config-format <format>", "Project config format (default: toml)")
+      .choices(["toml", "yaml"])
+  )
   .option("--no-agent-rules", "Skip AGENTS.md and IDE rule files")
   .option("--no-baseline", "Skip baseline snapshot")
   .option("--no-editorconfig", "Skip .editorconfig generation")
@@ -60,6 +122,13 @@ program
   .option("--no-codespell", "Skip .codespellrc generation")
   .option("--no-ruff", "Skip ruff.toml generation")
   .option("--no-staticcheck", "Skip staticcheck.conf generation")
+  .option("--no-hadolint", "Skip .hadolint.yaml generation")
+  .option("--no-yamllint", "Skip .yamllint.yaml generation")
+  .option("--no-rubocop", "Skip .rubocop.yml generation")
+  .option("--no-stylelint", "Skip .stylelintrc.yaml generation")
+  .option("--no-golangci", "Skip .golangci.yml generation")
+  .option("--no-rustfmt", "Skip rustfmt.toml generation")
+  .option("--no-clippy", "Skip clippy.toml generation")
   .option("--no-biome", "Skip biome.jsonc generation")
   .option("--no-agent-hooks", "Skip .claude/settings.json generation")
   .option("--no-branch-protection", "Skip GitHub branch protection setup")
@@ -71,17 +140,24 @@ program
   .option("--no-nvim", "Skip Neovim conform.nvim config generation")
   .option("--no-zed", "Skip Zed on-save configuration")
   .option("--interactive", "Prompt for each optional step")
+  .option("--strict-detection", STRICT_DETECTION_HELP)
   .option(
     "--min-version <version>",
     "Pin a specific min_version (defaults to installed version)"
   )
+  .addOption(
+    new Option(
+      "--init-from <source>",
+      "Adopt the project's existing tool configs instead of generating ours"
+    ).choices(["existing"])
+  )
   .addOption(
     new Option("--config-strategy <strategy>", "How to handle existing lang configs")
       .choices(["merge", "replace", "skip"])
       .default("merge")
   )
   .action(async (opts) => {
-    await runInit(getProjectDir(), { ...opts });
+    await runInit(getProjectDir(), { ...globalFlags(), ...opts });
   });
 
 // ---------------------------------------------------------------------------
@@ -91,8 +167,10 @@ program
   .command("generate")
   .description("Regenerate all managed config files")
   .option("--check", "Verify files are up-to-date (CI mode)")
+  .option("--force", "Also write configs generated only on request (.golangci.yml)")
+  .option("--strict-detection", STRICT_DETECTION_HELP)
   .action(async (opts) => {
-    await runGenerate(getProjectDir(), { ...opts });
+    await runGenerate(getProjectDir(), { ...globalFlags(), ...opts });
   });
 
 // ---------------------------------------------------------------------------
@@ -101,11 +179,87 @@ program
 program
   .command("check")
   .description("Hold-the-line enforcement: fail if new issues found")
+  .argument("[paths...]", "Only check these directories (like --path)")
   .option("--baseline <path>", "Custom baseline path")
-  .option("--format <format>", "Output format: text | sarif", "text")
+  .option("--update-baseline", "Rewrite the baseline from the current findings")
+  .option(
+    "--format <format>",
+    "Output format: text | sarif | json | junit | github | gitlab | summary | auto (default: text)"
+  )
+  .option("--output <path>", "Write the report to a file instead of stdout")
+  .option("--tee", "With --output, print the report to stdout as well")
   .option("--strict", "Ignore baseline — all issues are new")
+  .option("--fail-on <level>", "Lowest severity that fails: error | warning | info")
+  .option("--enable <runners>", "Comma-separated runner ids to force on")
+  .option("--disable <runners>", "Comma-separated runner ids to skip")
+  .option("--only <runners>", "Run just these comma-separated runner ids")
+  .option("--require <runners>", "Fail, not skip, when these runners' tool is missing")
+  .option("--require-all", "Fail, not skip, when any enabled runner's tool is missing")
+  .option("--fix", "Apply safe autofixes, then report what remains")
+  .option("--diff", "Print what --fix would change as a diff, writing nothing")
+  .option("--staged", "Only check staged files with file-scoped runners (pre-commit)")
+  .option("--module <path>", "Only run the Go runners, on the module(s) under path")
+  .option("--path <dirs>", "Only check these comma-separated directories")
+  .option("--timeout <seconds>", "Kill a runner after this many seconds (default: 120)")
+  .option("--jobs <n>", "Max runners in parallel (default: CPU count; 1 = sequential)")
+  .option(
+    "--max-procs <n>",
+    "Max tool processes at once across all runners (default: GOMAXPROCS or CPU count)"
+  )
+  .option("--fail-fast", "Stop at the first runner that fails, cancelling the rest")
+  .option("--strict-detection", STRICT_DETECTION_HELP)
+  .option("--batch-size <n>", "Max files per tool invocation (default: 500)")
+  .option("--no-cache", "Re-run every runner, ignoring cached results")
+  .option("--no-ignore", "Also check paths in .gitignore and .guardrailsignore")
+  .option("--include-generated", "Also check generated (DO NOT EDIT) files")
+  .option("--no-dedup", "Keep every runner's copy of a finding several runners report")
+  .option("--check-external", "Also check external links in markdown (network)")
+  .option("--timings", "Show the per-runner timing table, even with --quiet")
+  .option("--quiet-skips", "Log missing tools' skip lines only with --verbose")
+  .option("--explain", "Print each finding's rule rationale and fix hint")
+  .option("--max-findings <n>", "Print at most n findings (0 = all); exit code is kept")
+  .option("--max-per-runner <n>", "Print at most n findings per runner (0 = all)")
+  .addOption(
+    new Option("--group-by <key>", "Group printed findings (default: runner)").choices([
+      "runner",
+      "file",
+      "severity",
+    ])
+  )
+  .option("--metrics <path>", "Append a JSON-lines metrics record for this run")
+  .option("--clear-cache", "Delete cached runner results and exit")
+  .option("--report-suppressions", "List every inline suppression comment and exit")
+  .option(
+    "--changed-since [ref]",
+    "Only check files changed since a git ref (default: origin/main)"
+  )
+  .option("--since-last-run", "Only check files changed since the last such run")
+  .option("--stdin", "Check content piped to stdin, as the --stdin-filename file")
+  .option("--stdin-filename <path>", "Project path the --stdin content belongs to")
+  .addOption(
+    new Option("--profile-cpu <file>", "Write a CPU profile of the run").hideHelp()
+  )
+  .addOption(
+    new Option("--trace <file>", "Write a trace of the run's phases").hideHelp()
+  )
+  .addOption(
+    new Option("--repeat <n>", "Time n runs of the check, cache off").hideHelp()
+  )
+  .action(async (paths, opts) => {
+    await runCheck(getProjectDir(), { ...globalFlags(), ...opts, paths });
+  });
+
+// ---------------------------------------------------------------------------
+// watch
+// ---------------------------------------------------------------------------
+program
+  .command("watch")
+  .description("Re-run affected runners on every file change")
+  .option("--debounce <ms>", "Wait for this long without changes (default: 300)")
+  .option("--no-ignore", "Also watch paths in .gitignore and .guardrailsignore")
+  .option("--include-generated", "Also check generated (DO NOT EDIT) files")
   .action(async (opts) => {
-    await runCheck(getProjectDir(), { ...opts });
+    await runWatch(getProjectDir(), { ...globalFlags(), ...opts });
   });
 
 // ---------------------------------------------------------------------------
@@ -116,7 +270,7 @@ program
   .description("Capture current lint state as baseline")
   .option("--baseline <path>", "Custom output path")
   .action(async (opts) => {
-    await runSnapshot(getProjectDir(), { ...opts });
+    await runSnapshot(getProjectDir(), { ...globalFlags(), ...opts });
   });
 
 // ---------------------------------------------------------------------------
@@ -126,7 +280,84 @@ program
   .command("status")
   .description("Project health dashboard")
   .action(async () => {
-    await runStatus(getProjectDir(), {});
+    await runStatus(getProjectDir(), globalFlags());
+  });
+
+// ---------------------------------------------------------------------------
+// doctor
+// ---------------------------------------------------------------------------
+program
+  .command("doctor")
+  .description("Report tool availability and versions for detected languages")
+  .option("--strict", "Exit 1 when any tool is missing or outdated")
+  .action(async (opts) => {
+    await runDoctor(getProjectDir(), { ...globalFlags(), ...opts });
+  });
+
+// ---------------------------------------------------------------------------
+// version / update
+// ---------------------------------------------------------------------------
+program
+  .command("version")
+  .description("Print the build version, commit and date")
+  .option("--check-update", "Also ask GitHub whether a newer release exists")
+  .action(async (opts) => {
+    await runVersion(getProjectDir(), { ...globalFlags(), ...opts });
+  });
+
+program
+  .command("update")
+  .description("Replace this binary with the latest release, checksum-verified")
+  .option("-y, --yes", "Update without asking for confirmation")
+  .action(async (opts) => {
+    await runUpdate(getProjectDir(), { ...globalFlags(), ...opts });
+  });
+
+// ---------------------------------------------------------------------------
+// list
+// ---------------------------------------------------------------------------
+program
+  .command("list")
+  .description("Show detected languages and which runners will run")
+  .addOption(
+    new Option("--format <format>", "Output format (default: text)").choices([
+      "text",
+      "json",
+    ])
+  )
+  .action(async (opts) => {
+    await runList(getProjectDir(), { ...globalFlags(), ...opts });
+  });
+
+// ---------------------------------------------------------------------------
+// config
+// ---------------------------------------------------------------------------
+const config = program
+  .command("config")
+  .description("Validate, show or describe .ai-guardrails/config.toml");
+
+config
+  .command("validate")
+  .description("Report syntax errors, unknown keys, and bad values with line numbers")
+  .action(async () => {
+    await runConfigValidate(getProjectDir(), globalFlags());
+  });
+
+config
+  .command("show")
+  .description("Print the effective config after merging defaults, files, and flags")
+  .option("--enable <runners>", "Comma-separated runner ids to force on")
+  .option("--disable <runners>", "Comma-separated runner ids to skip")
+  .option("--path <dir>", "Show the config that applies under a directory")
+  .action(async (opts) => {
+    await runConfigShow(getProjectDir(), { ...globalFlags(), ...opts });
+  });
+
+config
+  .command("schema")
+  .description("Print the JSON Schema of config.toml for editor completion")
+  .action(() => {
+    runConfigSchema();
   });
 
 // ---------------------------------------------------------------------------
@@ -137,7 +368,7 @@ program
   .description("Show recent check run history")
   .option("--last <n>", "Number of runs to show", (v) => Number.parseInt(v, 10), 10)
   .action(async (opts) => {
-    await runReport(getProjectDir(), { last: opts.last });
+    await runReport(getProjectDir(), { ...globalFlags(), last: opts.last });
   });
 
 // ---------------------------------------------------------------------------
@@ -155,6 +386,27 @@ program
     await runHook(hookName, args);
   });
 
+// ---------------------------------------------------------------------------
+// hooks
+// ---------------------------------------------------------------------------
+const hooks = program
+  .command("hooks")
+  .description("Manage the git pre-commit hook that runs `check --staged`");
+
+hooks
+  .command("install")
+  .description("Add the ai-guardrails block to .git/hooks/pre-commit")
+  .action(async () => {
+    await runHooks(getProjectDir(), "install");
+  });
+
+hooks
+  .command("uninstall")
+  .description("Remove the ai-guardrails block from .git/hooks/pre-commit")
+  .action(async () => {
+    await runHooks(getProjectDir(), "uninstall");
+  });
+
 // ---------------------------------------------------------------------------
 // allow
 // ---------------------------------------------------------------------------
@@ -192,12 +444,12 @@ program
     } catch (e: unknown) {
       const msg = e instanceof Error ? e.message : String(e);
       process.stderr.write(`${msg}\n`);
-      process.exit(1);
+      process.exit(EXIT_USAGE);
     }
   });
 
 program.parseAsync(process.argv).catch((err: unknown) => {
   const msg = err instanceof Error ? err.message : String(err);
   process.stderr.write(`Fatal: ${msg}\n`);
-  process.exit(2);
+  process.exit(EXIT_RUNNER_ERROR);
 });
@@ -1,8 +1,14 @@
 import { join } from "node:path";
 import { buildContext } from "@/commands/context";
+import {
+  configFormatOf,
+  parseConfigText,
+  projectConfigTarget,
+  stringifyConfig,
+} from "@/config/config-file";
 import type { Console } from "@/infra/console";
 import type { FileManager } from "@/infra/file-manager";
-import { PROJECT_CONFIG_PATH } from "@/models/paths";
+import { EXIT_USAGE } from "@/models/exit-code";
 
 /**
  * Escape a value for use inside a TOML basic string (double-quoted).
@@ -23,7 +29,19 @@ export async function appendAllowEntry(
   fileManager: FileManager,
   cons: Console
 ): Promise<void> {
-  const configPath = join(projectDir, PROJECT_CONFIG_PATH);
+  const file = await projectConfigTarget(projectDir, fileManager);
+  const configPath = join(projectDir, file);
+
+  if (configFormatOf(file) === "yaml") {
+    // A YAML list cannot grow by appending text the way TOML tables can, so
+    // the file is rewritten — losing its comments
+    const data = parseConfigText(await fileManager.readText(configPath), "yaml");
+    const allow = Array.isArray(data.allow) ? data.allow : [];
+    const updated = { ...data, allow: [...allow, { rule, glob, reason }] };
+    await fileManager.writeText(configPath, stringifyConfig(updated, "yaml"));
+    cons.success(`Added allow rule: ${rule} for ${glob}`);
+    return;
+  }
 
   // Append the new [[allow]] block — TOML array-of-tables syntax.
   // Escape backslashes and double-quotes so values are safe inside TOML
@@ -44,7 +62,8 @@ export async function appendAllowEntry(
 /**
  * ai-guardrails allow <rule> <glob> "<reason>"
  *
- * Appends an [[allow]] entry to .ai-guardrails/config.toml.
+ * Appends an [[allow]] entry to .ai-guardrails/config.toml, or an `allow`
+ * item to .ai-guardrails/config.yaml.
  */
 export async function runAllow(
   projectDir: string,
@@ -57,17 +76,17 @@ export async function runAllow(
     process.stderr.write(
       `Error: rule must be in the format "linter/RULE_CODE" (e.g. biome/noConsole)\n`
     );
-    process.exit(1);
+    process.exit(EXIT_USAGE);
   }
 
   if (glob.trim() === "") {
     process.stderr.write(`Error: glob must not be empty\n`);
-    process.exit(1);
+    process.exit(EXIT_USAGE);
   }
 
   if (reason.trim() === "") {
     process.stderr.write(`Error: reason must not be empty\n`);
-    process.exit(1);
+    process.exit(EXIT_USAGE);
   }
 
   const ctx = buildContext(projectDir, {});
@@ -1,19 +1,81 @@
-import { buildContext } from "@/commands/context";
+import { dirname } from "node:path";
+import {
+  buildContext,
+  colorModeFromFlags,
+  logLevelFromFlags,
+} from "@/commands/context";
+import { withEnvOverrides } from "@/commands/env-overrides";
+import { RealConsole } from "@/infra/console";
+import type { FileManager } from "@/infra/file-manager";
+import { killProcessGroupsOnShutdown } from "@/infra/process-groups";
+import { EXIT_USAGE } from "@/models/exit-code";
 import { checkPipeline } from "@/pipelines/check";
+import { parseReportFormat } from "@/steps/report-step";
+import { type CpuProfile, startCpuProfile } from "@/utils/cpu-profile";
+import { Tracer } from "@/utils/trace";
+
+async function writeDiagnostic(
+  fileManager: FileManager,
+  path: string,
+  content: string
+): Promise<void> {
+  await fileManager.mkdir(dirname(path), { parents: true });
+  await fileManager.writeText(path, content);
+}
 
 export async function runCheck(
   projectDir: string,
-  flags: Record<string, unknown>
+  cliFlags: Record<string, unknown>
 ): Promise<void> {
-  const ctx = buildContext(projectDir, flags);
-  const result = await checkPipeline.run(ctx);
+  // Runner commands with a timeout are detached; an interrupt must kill them
+  killProcessGroupsOnShutdown();
+  const flags = withEnvOverrides(cliFlags);
+  const baseCtx = buildContext(projectDir, flags);
+  // Machine-readable reports own stdout; progress and warnings move to stderr
+  const isMachineFormat = parseReportFormat(flags.format) !== "text";
+  const ctx = isMachineFormat
+    ? {
+        ...baseCtx,
+        console: new RealConsole({
+          statusToStderr: true,
+          logLevel: logLevelFromFlags(flags),
+          color: colorModeFromFlags(flags),
+          quiet: flags.quiet === true,
+        }),
+      }
+    : baseCtx;
+  const tracePath = typeof flags.trace === "string" ? flags.trace : undefined;
+  const profilePath =
+    typeof flags.profileCpu === "string" ? flags.profileCpu : undefined;
+  let profile: CpuProfile | undefined;
+  if (profilePath !== undefined) {
+    try {
+      profile = await startCpuProfile();
+    } catch (err) {
+      const detail = err instanceof Error ? err.message : String(err);
+      process.stderr.write(`Error: --profile-cpu is unavailable: ${detail}\n`);
+      process.exit(EXIT_USAGE);
+    }
+  }
+  const tracer = tracePath !== undefined ? new Tracer() : undefined;
+  const result = await checkPipeline.run({
+    ...ctx,
+    ...(tracer !== undefined && { tracer }),
+  });
+  // Written before exiting so a failing run — often the slow one — still
+  // leaves its profile and trace behind
+  if (profile !== undefined && profilePath !== undefined) {
+    await writeDiagnostic(ctx.fileManager, profilePath, await profile.stop());
+  }
+  if (tracer !== undefined && tracePath !== undefined) {
+    await writeDiagnostic(ctx.fileManager, tracePath, JSON.stringify(tracer));
+  }
   if (result.status === "error") {
-    // Exit 1 = issues found, exit 2 = config/tool error
-    const issueCount = result.issueCount ?? 0;
-    if (issueCount === 0) {
+    // Failing findings were printed already; anything else says what broke
+    if ((result.issueCount ?? 0) === 0) {
       process.stderr.write(`Error: ${result.message ?? "unknown error"}\n`);
-      process.exit(2);
     }
-    process.exit(1);
+    // Without an exit code the run never started: bad flags or config
+    process.exit(result.exitCode ?? EXIT_USAGE);
   }
 }
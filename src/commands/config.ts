@@ -0,0 +1,100 @@
+import { isAbsolute, relative, resolve } from "node:path";
+import { buildContext } from "@/commands/context";
+import { envOverrides, formatEnvOptions } from "@/commands/env-overrides";
+import { configPathFromFlags } from "@/config/config-file";
+import { projectConfigJsonSchema } from "@/config/json-schema";
+import { configForPath } from "@/config/schema";
+import { validateRunnerOverrides, withCustomRunners } from "@/languages/registry";
+import { EXIT_FINDINGS, EXIT_USAGE } from "@/models/exit-code";
+import {
+  formatConfigProblem,
+  formatEffectiveConfig,
+  validateConfigStep,
+} from "@/steps/config-step";
+import { detectLanguagesStep } from "@/steps/detect-languages";
+import { loadConfigStep } from "@/steps/load-config";
+import { parseRunnerList } from "@/utils/parse";
+
+export async function runConfigValidate(
+  projectDir: string,
+  flags: Record<string, unknown>
+): Promise<void> {
+  const { fileManager, console: cons } = buildContext(projectDir, flags);
+  const { result, problems } = await validateConfigStep(
+    projectDir,
+    fileManager,
+    configPathFromFlags(flags)
+  );
+  for (const problem of problems) cons.error(formatConfigProblem(problem));
+  if (result.status === "error") {
+    process.stderr.write(`Error: ${result.message}\n`);
+    process.exit(problems.length > 0 ? EXIT_FINDINGS : EXIT_USAGE);
+  }
+  cons.success(result.message);
+}
+
+export async function runConfigShow(
+  projectDir: string,
+  flags: Record<string, unknown>
+): Promise<void> {
+  const ctx = buildContext(projectDir, flags);
+  const { fileManager, console: cons } = ctx;
+
+  const { result: detectResult, languages } = await detectLanguagesStep(
+    projectDir,
+    fileManager,
+    undefined,
+    cons
+  );
+  if (detectResult.status === "error") {
+    process.stderr.write(`Error: ${detectResult.message}\n`);
+    process.exit(EXIT_USAGE);
+  }
+
+  const { result: configResult, config } = await loadConfigStep(
+    projectDir,
+    fileManager,
+    configPathFromFlags(flags)
+  );
+  if (configResult.status === "error" || config === null) {
+    process.stderr.write(`Error: ${configResult.message ?? "config load failed"}\n`);
+    process.stderr.write("Run `ai-guardrails config validate` for details\n");
+    process.exit(EXIT_USAGE);
+  }
+
+  const fromEnv = envOverrides(flags);
+  const enable = parseRunnerList(flags.enable);
+  const disable = parseRunnerList(fromEnv.disable ?? flags.disable);
+  const overrideError = validateRunnerOverrides(enable, disable, config);
+  if (overrideError !== null) {
+    process.stderr.write(`Error: ${overrideError}\n`);
+    process.exit(EXIT_USAGE);
+  }
+
+  // --path shows what applies under a directory, nested configs included
+  const path =
+    typeof flags.path === "string"
+      ? relative(projectDir, resolve(projectDir, flags.path))
+      : "";
+  if (path.startsWith("..") || isAbsolute(path)) {
+    process.stderr.write("Error: --path must be inside the project\n");
+    process.exit(EXIT_USAGE);
+  }
+
+  const runners = withCustomRunners(languages, config).flatMap((plugin) =>
+    plugin.runners()
+  );
+  const overrides = {
+    enable,
+    disable,
+    ...(fromEnv.disable !== undefined && { disableSource: "GUARDRAILS_DISABLE" }),
+  };
+  const lines = formatEffectiveConfig(config, runners, overrides, path);
+  const { profile } = configForPath(config, path);
+  const env = formatEnvOptions(profile, process.env, config.global);
+  for (const line of [...lines, ...env]) cons.info(line);
+}
+
+export function runConfigSchema(): void {
+  process.stdout.write(`${JSON.stringify(projectConfigJsonSchema(), null, 2)}\n`);
+}
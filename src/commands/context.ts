@@ -4,11 +4,32 @@ import {
   MachineConfigSchema,
   ProjectConfigSchema,
 } from "@/config/schema";
-import { RealCommandRunner } from "@/infra/command-runner";
+import { LoggingCommandRunner, RealCommandRunner } from "@/infra/command-runner";
+import type { ColorMode, LogLevel } from "@/infra/console";
 import { RealConsole } from "@/infra/console";
 import { RealFileManager } from "@/infra/file-manager";
 import type { PipelineContext } from "@/pipelines/types";
 
+/** --debug wins over -v/--verbose; neither keeps diagnostics off */
+export function logLevelFromFlags(flags: Record<string, unknown>): LogLevel {
+  if (flags.debug === true) return "debug";
+  return flags.verbose === true ? "verbose" : "normal";
+}
+
+/** --color <when>, with --no-color as "never"; anything else is "auto" */
+export function colorModeFromFlags(flags: Record<string, unknown>): ColorMode {
+  if (flags.color === false) return "never";
+  return flags.color === "always" || flags.color === "never" ? flags.color : "auto";
+}
+
+async function readStdin(): Promise<string> {
+  const chunks: Buffer[] = [];
+  for await (const chunk of process.stdin) {
+    chunks.push(Buffer.isBuffer(chunk) ? chunk : Buffer.from(String(chunk)));
+  }
+  return Buffer.concat(chunks).toString("utf8");
+}
+
 export function buildContext(
   projectDir: string,
   flags: Record<string, unknown> = {}
@@ -16,16 +37,27 @@ export function buildContext(
   const machine = MachineConfigSchema.parse({});
   const project = ProjectConfigSchema.parse({});
   const config = buildResolvedConfig(machine, project);
+  const logLevel = logLevelFromFlags(flags);
+  const cons = new RealConsole({
+    logLevel,
+    color: colorModeFromFlags(flags),
+    quiet: flags.quiet === true,
+  });
+  const commandRunner = new RealCommandRunner();
 
   return {
     projectDir,
     config,
     fileManager: new RealFileManager(),
-    commandRunner: new RealCommandRunner(),
-    console: new RealConsole(),
+    commandRunner:
+      logLevel === "debug"
+        ? new LoggingCommandRunner(commandRunner, cons)
+        : commandRunner,
+    console: cons,
     flags,
     isTTY: process.stdin.isTTY === true,
     createReadline: () =>
       createInterface({ input: process.stdin, output: process.stdout }),
+    readStdin,
   };
 }
@@ -0,0 +1,52 @@
+import { buildContext } from "@/commands/context";
+import { configPathFromFlags } from "@/config/config-file";
+import { withCustomRunners } from "@/languages/registry";
+import { EXIT_MISSING_TOOL, EXIT_USAGE } from "@/models/exit-code";
+import { detectLanguagesStep } from "@/steps/detect-languages";
+import { doctorStep, formatDoctorTable } from "@/steps/doctor-step";
+import { loadConfigStep } from "@/steps/load-config";
+
+export async function runDoctor(
+  projectDir: string,
+  flags: Record<string, unknown>
+): Promise<void> {
+  const ctx = buildContext(projectDir, flags);
+  const { fileManager, commandRunner, console: cons } = ctx;
+
+  const { result: detectResult, languages } = await detectLanguagesStep(
+    projectDir,
+    fileManager,
+    undefined,
+    cons
+  );
+  if (detectResult.status === "error") {
+    process.stderr.write(`Error: ${detectResult.message}\n`);
+    process.exit(EXIT_USAGE);
+  }
+
+  const { result: configResult, config } = await loadConfigStep(
+    projectDir,
+    fileManager,
+    configPathFromFlags(flags)
+  );
+  if (configResult.status === "error" || config === null) {
+    process.stderr.write(`Error: ${configResult.message ?? "config load failed"}\n`);
+    process.exit(EXIT_USAGE);
+  }
+
+  const { result, tools } = await doctorStep(
+    projectDir,
+    withCustomRunners(languages, config),
+    config,
+    commandRunner
+  );
+  for (const line of formatDoctorTable(tools)) cons.info(line);
+
+  if (result.status === "ok") {
+    cons.success(result.message);
+    return;
+  }
+  cons.warning(result.message);
+  // Informational by default; --strict turns missing/outdated tools into a CI gate
+  if (flags.strict === true) process.exit(EXIT_MISSING_TOOL);
+}
@@ -0,0 +1,89 @@
+import type { GlobalConfigFile, Profile } from "@/config/schema";
+import { PROFILE_DEFAULTS } from "@/config/schema";
+import { parseRunnerList } from "@/utils/parse";
+import { defaultJobs } from "@/utils/pool";
+
+type Env = Readonly<Record<string, string | undefined>>;
+
+/** The `check` options CI can set through the environment instead of flags */
+export const ENV_OVERRIDES = [
+  { name: "GUARDRAILS_FORMAT", flag: "format" },
+  { name: "GUARDRAILS_FAIL_ON", flag: "failOn" },
+  { name: "GUARDRAILS_JOBS", flag: "jobs" },
+  { name: "GUARDRAILS_NO_CACHE", flag: "cache" },
+  { name: "GUARDRAILS_DISABLE", flag: "disable" },
+] as const;
+
+const TRUTHY = new Set(["1", "true", "yes", "on"]);
+
+/**
+ * The flags the GUARDRAILS_* env vars set, skipping any the command line
+ * already gave — a flag wins over the env, which wins over config and
+ * defaults. GUARDRAILS_NO_CACHE takes 1/true/yes/on. GUARDRAILS_DISABLE
+ * steps aside for `--only` and never disables a runner `--enable` names.
+ */
+export function envOverrides(
+  flags: Record<string, unknown>,
+  env: Env = process.env
+): Record<string, unknown> {
+  const overrides: Record<string, unknown> = {};
+  for (const { name, flag } of ENV_OVERRIDES) {
+    const value = env[name]?.trim();
+    if (value === undefined || value === "") continue;
+    if (flag === "cache") {
+      // commander maps --no-cache to cache: false, and leaves it true otherwise
+      if (flags.cache !== false && TRUTHY.has(value.toLowerCase())) {
+        overrides.cache = false;
+      }
+    } else if (flag === "disable") {
+      if (flags.disable !== undefined || flags.only !== undefined) continue;
+      const enabled = parseRunnerList(flags.enable);
+      const ids = parseRunnerList(value).filter((id) => !enabled.includes(id));
+      if (ids.length > 0) overrides.disable = ids.join(",");
+    } else if (flags[flag] === undefined) {
+      overrides[flag] = value;
+    }
+  }
+  return overrides;
+}
+
+/** `flags` with the GUARDRAILS_* env vars filled in where no flag was given */
+export function withEnvOverrides(
+  flags: Record<string, unknown>,
+  env: Env = process.env
+): Record<string, unknown> {
+  return { ...flags, ...envOverrides(flags, env) };
+}
+
+/**
+ * The `check` options the environment resolves to, for `config show`: one
+ * commented line each, with the env var it came from, the `global` config's
+ * path or "default" — for fail_on, the default of `profile`.
+ */
+export function formatEnvOptions(
+  profile: Profile,
+  env: Env = process.env,
+  global?: GlobalConfigFile
+): string[] {
+  const overrides = envOverrides({}, env);
+  const globalJobs = global?.config.jobs;
+  const source = (flag: string) => {
+    const entry = ENV_OVERRIDES.find((o) => o.flag === flag);
+    if (flag in overrides && entry !== undefined) return entry.name;
+    return flag === "jobs" && global !== undefined && globalJobs !== undefined
+      ? global.path
+      : "default";
+  };
+  const show = (key: string, flag: string, value: string) =>
+    `# ${key} = ${value}  # ${source(flag)}`;
+  const text = (flag: string, fallback: string) =>
+    JSON.stringify(String(overrides[flag] ?? fallback));
+  return [
+    "",
+    "# check options: default < global config < GUARDRAILS_* env < command-line flag",
+    show("format", "format", text("format", "text")),
+    show("fail_on", "failOn", text("failOn", PROFILE_DEFAULTS[profile].failOn)),
+    show("jobs", "jobs", String(overrides.jobs ?? globalJobs ?? defaultJobs())),
+    show("cache", "cache", String(overrides.cache ?? true)),
+  ];
+}
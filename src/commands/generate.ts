@@ -1,4 +1,5 @@
 import { buildContext } from "@/commands/context";
+import { EXIT_USAGE } from "@/models/exit-code";
 import { generatePipeline } from "@/pipelines/generate";
 
 export async function runGenerate(
@@ -9,6 +10,6 @@ export async function runGenerate(
   const result = await generatePipeline.run(ctx);
   if (result.status === "error") {
     process.stderr.write(`Error: ${result.message ?? "generate failed"}\n`);
-    process.exit(1);
+    process.exit(result.exitCode ?? EXIT_USAGE);
   }
 }
@@ -0,0 +1,17 @@
+import { buildContext } from "@/commands/context";
+import { EXIT_RUNNER_ERROR } from "@/models/exit-code";
+import { installGitHookStep, uninstallGitHookStep } from "@/steps/git-hook";
+
+export async function runHooks(
+  projectDir: string,
+  action: "install" | "uninstall"
+): Promise<void> {
+  const { commandRunner, fileManager, console: cons } = buildContext(projectDir);
+  const step = action === "install" ? installGitHookStep : uninstallGitHookStep;
+  const result = await step(projectDir, commandRunner, fileManager);
+  if (result.status === "error") {
+    process.stderr.write(`Error: ${result.message}\n`);
+    process.exit(EXIT_RUNNER_ERROR);
+  }
+  cons.success(result.message);
+}
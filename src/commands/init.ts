@@ -1,4 +1,5 @@
 import { buildContext } from "@/commands/context";
+import { EXIT_USAGE } from "@/models/exit-code";
 import { initPipeline } from "@/pipelines/init";
 
 export async function runInit(
@@ -9,6 +10,6 @@ export async function runInit(
   const result = await initPipeline.run(ctx);
   if (result.status === "error") {
     process.stderr.write(`Error: ${result.message ?? "init failed"}\n`);
-    process.exit(2);
+    process.exit(result.exitCode ?? EXIT_USAGE);
   }
 }
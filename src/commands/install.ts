@@ -1,4 +1,5 @@
 import { buildContext } from "@/commands/context";
+import { EXIT_USAGE } from "@/models/exit-code";
 import { installPipeline } from "@/pipelines/install";
 
 export async function runInstall(
@@ -9,6 +10,6 @@ export async function runInstall(
   const result = await installPipeline.run(ctx);
   if (result.status === "error") {
     process.stderr.write(`Error: ${result.message ?? "install failed"}\n`);
-    process.exit(2);
+    process.exit(result.exitCode ?? EXIT_USAGE);
   }
 }
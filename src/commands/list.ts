@@ -0,0 +1,62 @@
+import { buildContext } from "@/commands/context";
+import { configPathFromFlags } from "@/config/config-file";
+import { withCustomRunners } from "@/languages/registry";
+import { EXIT_RUNNER_ERROR, EXIT_USAGE } from "@/models/exit-code";
+import { PROJECT_CONFIG_PATH } from "@/models/paths";
+import { detectLanguagesStep } from "@/steps/detect-languages";
+import { formatListText, listStep, listToJson } from "@/steps/list-step";
+import { loadConfigStep } from "@/steps/load-config";
+
+export async function runList(
+  projectDir: string,
+  flags: Record<string, unknown>
+): Promise<void> {
+  const ctx = buildContext(projectDir, flags);
+  const { fileManager, commandRunner, console: cons } = ctx;
+  const json = flags.format === "json";
+
+  const {
+    result: detectResult,
+    languages,
+    evidence,
+  } = await detectLanguagesStep(projectDir, fileManager, undefined, cons);
+  if (detectResult.status === "error") {
+    process.stderr.write(`Error: ${detectResult.message}\n`);
+    process.exit(EXIT_USAGE);
+  }
+
+  const { result: configResult, config } = await loadConfigStep(
+    projectDir,
+    fileManager,
+    configPathFromFlags(flags)
+  );
+  if (configResult.status === "error" || config === null) {
+    process.stderr.write(`Error: ${configResult.message ?? "config load failed"}\n`);
+    process.exit(EXIT_USAGE);
+  }
+
+  // Custom runners come from the config rather than from detection
+  const sources = new Map<string, readonly string[]>([
+    ...evidence,
+    ["custom", [PROJECT_CONFIG_PATH]],
+  ]);
+  const { result, languages: plans } = await listStep(
+    projectDir,
+    withCustomRunners(languages, config),
+    sources,
+    config,
+    commandRunner,
+    fileManager
+  );
+  if (result.status === "error") {
+    process.stderr.write(`Error: ${result.message}\n`);
+    process.exit(EXIT_RUNNER_ERROR);
+  }
+
+  if (json) {
+    cons.info(JSON.stringify(listToJson(plans), null, 2));
+    return;
+  }
+  for (const line of formatListText(plans)) cons.info(line);
+  cons.success(result.message);
+}
@@ -3,7 +3,7 @@ import { buildContext } from "@/commands/context";
 import { loadProjectConfig } from "@/config/loader";
 import type { Console } from "@/infra/console";
 import type { FileManager } from "@/infra/file-manager";
-import { ALLOW_COMMENT_RE } from "@/utils/allow-comment-re";
+import { parseSuppressions } from "@/utils/allow-comment-re";
 
 /**
  * Core logic for querying allow entries — injectable for testing.
@@ -45,15 +45,11 @@ export async function queryAllowEntries(
       } catch {
         return;
       }
-      const lines = source.split("\n");
-      for (let i = 0; i < lines.length; i++) {
-        const line = lines[i];
-        if (line === undefined) continue;
-        const match = ALLOW_COMMENT_RE.exec(line);
-        if (match !== null && match[1] === rule) {
-          const reason = match[2] ?? "";
-          inlineMatches.push({ file: relativePath, line: i + 1, reason });
-        }
+      for (const directive of parseSuppressions(source)) {
+        // A guardrails:ignore without a reason suppresses nothing
+        if (directive.rule !== rule || directive.reason === "") continue;
+        const { line, reason } = directive;
+        inlineMatches.push({ file: relativePath, line, reason });
       }
     })
   );
@@ -77,7 +73,7 @@ export async function queryAllowEntries(
  *
  * Shows all files where the given rule is allowed:
  *   - Config-level: [[allow]] entries in config.toml
- *   - Inline: ai-guardrails-allow comments in source files
+ *   - Inline: ai-guardrails-allow and guardrails:ignore comments in source files
  */
 export async function runQuery(projectDir: string, rule: string): Promise<void> {
   const ctx = buildContext(projectDir, {});
@@ -1,4 +1,7 @@
 import { buildContext } from "@/commands/context";
+import { configPathFromFlags } from "@/config/config-file";
+import { withCustomRunners } from "@/languages/registry";
+import { EXIT_RUNNER_ERROR, EXIT_USAGE } from "@/models/exit-code";
 import { detectLanguagesStep } from "@/steps/detect-languages";
 import { loadConfigStep } from "@/steps/load-config";
 import { snapshotStep } from "@/steps/snapshot-step";
@@ -13,22 +16,25 @@ export async function runSnapshot(
   cons.step("Detecting languages...");
   const { result: detectResult, languages } = await detectLanguagesStep(
     projectDir,
-    fileManager
+    fileManager,
+    undefined,
+    cons
   );
   if (detectResult.status === "error") {
     process.stderr.write(`Error: ${detectResult.message}\n`);
-    process.exit(2);
+    process.exit(EXIT_USAGE);
   }
   cons.success(detectResult.message);
 
   cons.step("Loading config...");
   const { result: configResult, config } = await loadConfigStep(
     projectDir,
-    fileManager
+    fileManager,
+    configPathFromFlags(flags)
   );
   if (configResult.status === "error" || config === null) {
     process.stderr.write(`Error: ${configResult.message ?? "config load failed"}\n`);
-    process.exit(2);
+    process.exit(EXIT_USAGE);
   }
   cons.success(configResult.message);
 
@@ -36,7 +42,7 @@ export async function runSnapshot(
   const baselinePath = typeof flags.baseline === "string" ? flags.baseline : undefined;
   const result = await snapshotStep(
     projectDir,
-    languages,
+    withCustomRunners(languages, config),
     config,
     commandRunner,
     fileManager,
@@ -44,7 +50,7 @@ export async function runSnapshot(
   );
   if (result.status === "error") {
     process.stderr.write(`Error: ${result.message}\n`);
-    process.exit(2);
+    process.exit(EXIT_RUNNER_ERROR);
   }
   cons.success(result.message);
 }
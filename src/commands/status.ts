@@ -1,5 +1,8 @@
 import { buildContext } from "@/commands/context";
+import { configPathFromFlags } from "@/config/config-file";
 import type { Console } from "@/infra/console";
+import { withCustomRunners } from "@/languages/registry";
+import { EXIT_USAGE } from "@/models/exit-code";
 import { detectLanguagesStep } from "@/steps/detect-languages";
 import { loadConfigStep } from "@/steps/load-config";
 import { statusStep } from "@/steps/status-step";
@@ -40,27 +43,37 @@ export async function runStatus(
   cons.step("Detecting languages...");
   const { result: detectResult, languages } = await detectLanguagesStep(
     projectDir,
-    fileManager
+    fileManager,
+    undefined,
+    cons
   );
   if (detectResult.status === "error") {
     process.stderr.write(`Error: ${detectResult.message}\n`);
-    process.exit(2);
+    process.exit(EXIT_USAGE);
   }
   cons.success(detectResult.message);
 
   cons.step("Loading config...");
   const { result: configResult, config } = await loadConfigStep(
     projectDir,
-    fileManager
+    fileManager,
+    configPathFromFlags(flags)
   );
   if (configResult.status === "error" || config === null) {
     process.stderr.write(`Error: ${configResult.message ?? "config load failed"}\n`);
-    process.exit(2);
+    process.exit(EXIT_USAGE);
   }
   cons.success(configResult.message);
 
   printVersionStatus(getVersion(), config.minVersion, cons);
 
-  await statusStep(projectDir, languages, config, commandRunner, fileManager, cons);
+  await statusStep(
+    projectDir,
+    withCustomRunners(languages, config),
+    config,
+    commandRunner,
+    fileManager,
+    cons
+  );
   // status never exits 1 — informational only
 }
@@ -0,0 +1,65 @@
+import { buildContext } from "@/commands/context";
+import { askYesNo } from "@/init/prompt";
+import { EXIT_RUNNER_ERROR, EXIT_USAGE, type ExitCode } from "@/models/exit-code";
+import {
+  checkForUpdateStep,
+  isReleaseBinary,
+  packageManagerUpdate,
+  releaseAssetName,
+  selfUpdateStep,
+} from "@/steps/self-update";
+
+/** Print `message` and exit; a usage error unless `code` says otherwise */
+function fail(message: string, code: ExitCode = EXIT_USAGE): never {
+  process.stderr.write(`Error: ${message}\n`);
+  process.exit(code);
+}
+
+export async function runUpdate(
+  projectDir: string,
+  flags: Record<string, unknown>
+): Promise<void> {
+  const ctx = buildContext(projectDir, flags);
+  const { commandRunner, fileManager, console: cons } = ctx;
+  const target = process.execPath;
+  const managed = packageManagerUpdate(target);
+  if (managed !== null) {
+    fail(`${target} belongs to a package manager — update it with \`${managed}\``);
+  }
+  if (!isReleaseBinary(target)) {
+    fail(`${target} is not a release binary — update it with its package manager`);
+  }
+  const asset = releaseAssetName();
+  if (asset === null) {
+    fail(`No release binary is published for ${process.platform}-${process.arch}`);
+  }
+
+  cons.step("Checking for updates...");
+  const { result, latest } = await checkForUpdateStep(commandRunner);
+  if (result.status === "error") fail(result.message, EXIT_RUNNER_ERROR);
+  if (latest === null) {
+    cons.success(result.message);
+    return;
+  }
+  cons.info(result.message);
+
+  if (flags.yes !== true) {
+    if (!ctx.isTTY) fail("Not a terminal — pass --yes to update without asking");
+    const question = `Replace ${target} with ${latest.tag}?`;
+    if (!(await askYesNo(ctx.createReadline, question, false))) {
+      cons.warning("Update cancelled");
+      return;
+    }
+  }
+
+  cons.step(`Downloading ${asset} ${latest.tag}...`);
+  const update = await selfUpdateStep(
+    latest,
+    asset,
+    target,
+    commandRunner,
+    fileManager
+  );
+  if (update.status === "error") fail(update.message, EXIT_RUNNER_ERROR);
+  cons.success(update.message);
+}
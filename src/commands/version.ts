@@ -0,0 +1,29 @@
+import { buildContext } from "@/commands/context";
+import { EXIT_RUNNER_ERROR } from "@/models/exit-code";
+import { checkForUpdateStep } from "@/steps/self-update";
+import { getBuildInfo } from "@/utils/version";
+
+export async function runVersion(
+  projectDir: string,
+  flags: Record<string, unknown>
+): Promise<void> {
+  const { version, commit, date } = getBuildInfo();
+  process.stdout.write(
+    `ai-guardrails ${version}\ncommit: ${commit}\nbuilt: ${date}\n` +
+      `platform: ${process.platform}-${process.arch}\n`
+  );
+  // Opt-in only: no other command checks for updates, so CI stays offline-safe
+  if (flags.checkUpdate !== true) return;
+
+  const { commandRunner, console: cons } = buildContext(projectDir, flags);
+  const { result, latest } = await checkForUpdateStep(commandRunner);
+  if (result.status === "error") {
+    process.stderr.write(`Error: ${result.message}\n`);
+    process.exit(EXIT_RUNNER_ERROR);
+  }
+  if (latest === null) {
+    cons.success(result.message);
+    return;
+  }
+  cons.warning(`${result.message} — run: ai-guardrails update`);
+}
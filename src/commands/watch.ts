@@ -0,0 +1,72 @@
+import { buildContext } from "@/commands/context";
+import { configPathFromFlags } from "@/config/config-file";
+import { RealFileWatcher } from "@/infra/file-watcher";
+import { killProcessGroupsOnShutdown, liveProcessGroups } from "@/infra/process-groups";
+import { withCustomRunners } from "@/languages/registry";
+import { EXIT_CLEAN, EXIT_USAGE } from "@/models/exit-code";
+import { detectLanguagesStep } from "@/steps/detect-languages";
+import { loadConfigStep } from "@/steps/load-config";
+import { DEFAULT_DEBOUNCE_MS, watchStep } from "@/steps/watch-step";
+import { loadIgnoreMatcher } from "@/utils/ignore-file";
+
+export async function runWatch(
+  projectDir: string,
+  flags: Record<string, unknown>
+): Promise<void> {
+  const ctx = buildContext(projectDir, flags);
+  const { fileManager, commandRunner, console: cons } = ctx;
+
+  const debounceMs =
+    flags.debounce === undefined ? DEFAULT_DEBOUNCE_MS : Number(flags.debounce);
+  if (!Number.isInteger(debounceMs) || debounceMs < 0) {
+    process.stderr.write("Error: --debounce must be a non-negative integer\n");
+    process.exit(EXIT_USAGE);
+  }
+
+  const { result: detectResult, languages } = await detectLanguagesStep(
+    projectDir,
+    fileManager,
+    undefined,
+    cons
+  );
+  if (detectResult.status === "error") {
+    process.stderr.write(`Error: ${detectResult.message}\n`);
+    process.exit(EXIT_USAGE);
+  }
+
+  const { result: configResult, config } = await loadConfigStep(
+    projectDir,
+    fileManager,
+    configPathFromFlags(flags)
+  );
+  if (configResult.status === "error" || config === null) {
+    process.stderr.write(`Error: ${configResult.message ?? "config load failed"}\n`);
+    process.exit(EXIT_USAGE);
+  }
+
+  // commander maps --no-ignore to ignore: false
+  const ignore =
+    flags.ignore === false ? null : await loadIgnoreMatcher(projectDir, fileManager);
+
+  const controller = new AbortController();
+  // SIGINT/SIGTERM kill the commands of a run in flight, then stop watching
+  killProcessGroupsOnShutdown(liveProcessGroups, () => controller.abort());
+  await watchStep(
+    projectDir,
+    withCustomRunners(languages, config),
+    config,
+    commandRunner,
+    fileManager,
+    cons,
+    new RealFileWatcher(),
+    {
+      signal: controller.signal,
+      debounceMs,
+      clearScreen: process.stdout.isTTY === true,
+      ...(ignore !== null && { ignore }),
+      ...(flags.includeGenerated === true && { includeGenerated: true }),
+    }
+  );
+  // Exit at once rather than waiting for a run still in flight
+  process.exit(EXIT_CLEAN);
+}
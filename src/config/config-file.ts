@@ -0,0 +1,153 @@
+import { homedir } from "node:os";
+import { join, resolve } from "node:path";
+import { parse as parseToml, stringify as stringifyToml } from "smol-toml";
+import { withSchemaDirective, withYamlSchemaDirective } from "@/config/json-schema";
+import type { FileManager } from "@/infra/file-manager";
+import { PROJECT_CONFIG_PATH, PROJECT_CONFIG_YAML_PATH } from "@/models/paths";
+import { isPlainObject } from "@/utils/deep-merge";
+
+export const CONFIG_FORMATS = ["toml", "yaml"] as const;
+export type ConfigFormat = (typeof CONFIG_FORMATS)[number];
+
+/** The project config of each format, relative to the project root */
+export const PROJECT_CONFIG_PATHS: Readonly<Record<ConfigFormat, string>> = {
+  toml: PROJECT_CONFIG_PATH,
+  yaml: PROJECT_CONFIG_YAML_PATH,
+};
+
+/** The format a config file is written in, told by its extension */
+export function configFormatOf(path: string): ConfigFormat {
+  return /\.ya?ml$/.test(path) ? "yaml" : "toml";
+}
+
+/** The format --config-format names, undefined when absent or unknown */
+export function configFormatFromFlags(
+  flags: Record<string, unknown>
+): ConfigFormat | undefined {
+  return CONFIG_FORMATS.find((format) => format === flags.configFormat);
+}
+
+/**
+ * The project config --config names, resolved from the working directory, or
+ * undefined when the flag is absent
+ */
+export function configPathFromFlags(
+  flags: Record<string, unknown>
+): string | undefined {
+  return typeof flags.config === "string" ? resolve(flags.config) : undefined;
+}
+
+/**
+ * Parse config text as `format`, letting syntax errors propagate. An empty
+ * YAML document is an empty config; any other non-mapping is an error.
+ */
+export function parseConfigText(
+  text: string,
+  format: ConfigFormat
+): Record<string, unknown> {
+  if (format === "toml") return parseToml(text);
+  const parsed: unknown = Bun.YAML.parse(text);
+  if (parsed === null || parsed === undefined) return {};
+  if (!isPlainObject(parsed)) throw new Error("expected a mapping of config keys");
+  return parsed;
+}
+
+/**
+ * The key paths `raw` writes, dotted down through tables to the values in
+ * them (an array is one value; an empty table sets nothing), e.g.
+ * ["profile", "config.line_length"]
+ */
+export function configKeys(raw: Record<string, unknown>, prefix = ""): string[] {
+  return Object.entries(raw).flatMap(([key, value]) => {
+    if (value === undefined) return [];
+    const path = `${prefix}${key}`;
+    return isPlainObject(value) ? configKeys(value, `${path}.`) : [path];
+  });
+}
+
+/** Serialize config as `format`, headed by the directive editors read */
+export function stringifyConfig(
+  data: Record<string, unknown>,
+  format: ConfigFormat
+): string {
+  if (format === "toml") return withSchemaDirective(stringifyToml(data));
+  return withYamlSchemaDirective(Bun.YAML.stringify(data, null, 2));
+}
+
+/**
+ * The project-relative path of the config under `dir`, in whichever format
+ * exists, or undefined when there is none. Both at once is ambiguous — the
+ * schema is the same, so neither could win silently — and throws.
+ */
+export async function findProjectConfig(
+  dir: string,
+  fm: FileManager
+): Promise<string | undefined> {
+  const found: string[] = [];
+  for (const path of Object.values(PROJECT_CONFIG_PATHS)) {
+    if (await fm.exists(join(dir, path))) found.push(path);
+  }
+  if (found.length > 1) {
+    throw new Error(`both ${found.join(" and ")} exist — keep only one`);
+  }
+  return found[0];
+}
+
+/**
+ * Where init writes the project config: the existing file, so its format is
+ * kept, else the file for `format` (TOML by default).
+ */
+export async function projectConfigTarget(
+  projectDir: string,
+  fm: FileManager,
+  format: ConfigFormat = "toml"
+): Promise<string> {
+  return (await findProjectConfig(projectDir, fm)) ?? PROJECT_CONFIG_PATHS[format];
+}
+
+type Env = Readonly<Record<string, string | undefined>>;
+
+/** Points at a global config to read, CI included */
+export const GLOBAL_CONFIG_ENV = "GUARDRAILS_GLOBAL_CONFIG";
+
+/** `$XDG_CONFIG_HOME/ai-guardrails`, or `~/.config/ai-guardrails` without it */
+export function globalConfigDir(env: Env = process.env, home = homedir()): string {
+  const xdg = env.XDG_CONFIG_HOME?.trim();
+  const base = xdg !== undefined && xdg !== "" ? xdg : join(home, ".config");
+  return join(base, "ai-guardrails");
+}
+
+function isCI(env: Env): boolean {
+  const ci = env.CI?.trim().toLowerCase();
+  return ci !== undefined && ci !== "" && ci !== "false" && ci !== "0";
+}
+
+/**
+ * The global config to read: the file GUARDRAILS_GLOBAL_CONFIG names, else
+ * `config.yaml` or `config.toml` in globalConfigDir — undefined when there is
+ * none, and always in CI (CI set), where only the repo's config counts unless
+ * the variable says otherwise. Both formats at once throws.
+ */
+export async function findGlobalConfig(
+  fm: FileManager,
+  env: Env = process.env,
+  home = homedir()
+): Promise<string | undefined> {
+  const pointed = env[GLOBAL_CONFIG_ENV]?.trim();
+  if (pointed !== undefined && pointed !== "") {
+    if (!(await fm.exists(pointed))) {
+      throw new Error(`${GLOBAL_CONFIG_ENV}: ${pointed} does not exist`);
+    }
+    return pointed;
+  }
+  if (isCI(env)) return undefined;
+  const dir = globalConfigDir(env, home);
+  const found: string[] = [];
+  for (const file of ["config.yaml", "config.toml"]) {
+    if (await fm.exists(join(dir, file))) found.push(join(dir, file));
+  }
+  if (found.length > 1) {
+    throw new Error(`both ${found.join(" and ")} exist — keep only one`);
+  }
+  return found[0];
+}
@@ -0,0 +1,121 @@
+import { z } from "zod";
+import { ProjectConfigSchema } from "@/config/schema";
+
+export type JsonSchema = Record<string, unknown>;
+
+/** Where editors can fetch the schema printed by `ai-guardrails config schema` */
+export const CONFIG_SCHEMA_ID =
+  "https://raw.githubusercontent.com/Questi0nM4rk/ai-guardrails/main/schema/config.schema.json";
+
+/** Taplo (Even Better TOML) directive pointing editors at the schema */
+export const CONFIG_SCHEMA_DIRECTIVE = `#:schema ${CONFIG_SCHEMA_ID}`;
+
+/** Prefix TOML text with the schema directive, replacing a stale one */
+export function withSchemaDirective(toml: string): string {
+  const body = toml.replace(/^#:schema .*\n/, "");
+  return `${CONFIG_SCHEMA_DIRECTIVE}\n${body}`;
+}
+
+/** yaml-language-server's equivalent of the Taplo directive */
+export const YAML_SCHEMA_DIRECTIVE = `# yaml-language-server: $schema=${CONFIG_SCHEMA_ID}`;
+
+/** Prefix YAML text with the schema directive, replacing a stale one */
+export function withYamlSchemaDirective(yaml: string): string {
+  const body = yaml.replace(/^# yaml-language-server: \$schema=.*\n/, "");
+  return `${YAML_SCHEMA_DIRECTIVE}\n${body}`;
+}
+
+function stringSchema(schema: z.ZodString): JsonSchema {
+  const out: JsonSchema = { type: "string" };
+  for (const check of schema._def.checks) {
+    if (check.kind === "min") out.minLength = check.value;
+    if (check.kind === "max") out.maxLength = check.value;
+    // JSON Schema patterns take no flags; drop a case-insensitive one rather than
+    // reject values zod accepts
+    if (check.kind === "regex" && check.regex.flags === "") {
+      out.pattern = check.regex.source;
+    }
+  }
+  return out;
+}
+
+function numberSchema(schema: z.ZodNumber): JsonSchema {
+  const out: JsonSchema = { type: schema.isInt ? "integer" : "number" };
+  for (const check of schema._def.checks) {
+    if (check.kind === "min") {
+      out[check.inclusive ? "minimum" : "exclusiveMinimum"] = check.value;
+    }
+    if (check.kind === "max") {
+      out[check.inclusive ? "maximum" : "exclusiveMaximum"] = check.value;
+    }
+  }
+  return out;
+}
+
+function objectSchema(schema: z.AnyZodObject): JsonSchema {
+  const properties: Record<string, JsonSchema> = {};
+  const required: string[] = [];
+  for (const [key, value] of Object.entries(schema.shape)) {
+    properties[key] = toJsonSchema(value);
+    if (!value.isOptional()) required.push(key);
+  }
+  return {
+    type: "object",
+    properties,
+    ...(required.length > 0 && { required }),
+    // Unknown keys are dropped on load; `config validate` reports them
+    additionalProperties: schema._def.unknownKeys === "passthrough",
+  };
+}
+
+function convert(schema: z.ZodTypeAny): JsonSchema {
+  if (schema instanceof z.ZodOptional) return toJsonSchema(schema.unwrap());
+  if (schema instanceof z.ZodDefault) {
+    const inner = toJsonSchema(schema.removeDefault());
+    return { ...inner, default: schema._def.defaultValue() };
+  }
+  // Refinements such as indent_width ∈ {2, 4} are left to `config validate`
+  if (schema instanceof z.ZodEffects) return toJsonSchema(schema.innerType());
+  if (schema instanceof z.ZodString) return stringSchema(schema);
+  if (schema instanceof z.ZodNumber) return numberSchema(schema);
+  if (schema instanceof z.ZodBoolean) return { type: "boolean" };
+  if (schema instanceof z.ZodEnum) {
+    return { type: "string", enum: [...schema.options] };
+  }
+  if (schema instanceof z.ZodArray) {
+    const minItems = schema._def.minLength?.value;
+    return {
+      type: "array",
+      items: toJsonSchema(schema.element),
+      ...(minItems !== undefined && { minItems }),
+    };
+  }
+  if (schema instanceof z.ZodRecord) {
+    const values = toJsonSchema(schema.valueSchema);
+    return { type: "object", additionalProperties: values };
+  }
+  if (schema instanceof z.ZodObject) return objectSchema(schema);
+  return {};
+}
+
+/**
+ * JSON Schema for a zod schema, covering the types config.toml uses.
+ * Anything else becomes `{}` (accept all), so the result is never stricter
+ * than the zod schema it came from.
+ */
+export function toJsonSchema(schema: z.ZodTypeAny): JsonSchema {
+  const out = convert(schema);
+  return schema.description !== undefined
+    ? { description: schema.description, ...out }
+    : out;
+}
+
+/** JSON Schema of .ai-guardrails/config.toml, derived from ProjectConfigSchema */
+export function projectConfigJsonSchema(): JsonSchema {
+  return {
+    $schema: "http://json-schema.org/draft-07/schema#",
+    $id: CONFIG_SCHEMA_ID,
+    title: "ai-guardrails project config (.ai-guardrails/config.toml)",
+    ...toJsonSchema(ProjectConfigSchema),
+  };
+}
@@ -1,18 +1,34 @@
-import { join } from "node:path";
-import { parse as parseToml } from "smol-toml";
+import { dirname, join } from "node:path";
+import type { ZodError } from "zod";
+import {
+  configFormatOf,
+  configKeys,
+  findGlobalConfig,
+  findProjectConfig,
+  PROJECT_CONFIG_PATHS,
+  parseConfigText,
+} from "@/config/config-file";
 import {
   buildResolvedConfig,
+  type ConfigLayerKeys,
+  type GlobalConfigFile,
+  GlobalConfigSchema,
   type MachineConfig,
   MachineConfigSchema,
+  type NestedConfig,
+  NestedConfigSchema,
   type ProjectConfig,
   ProjectConfigSchema,
   type ResolvedConfig,
 } from "@/config/schema";
 import type { FileManager } from "@/infra/file-manager";
+import { DEFAULT_IGNORE } from "@/languages/constants";
+import { validateCustomRunners } from "@/languages/registry";
+import { PROJECT_CONFIG_PATH } from "@/models/paths";
 
 export type { MachineConfig, ProjectConfig, ResolvedConfig };
 
-async function readTomlSafe(
+async function readConfigSafe(
   path: string,
   fm: FileManager
 ): Promise<Record<string, unknown>> {
@@ -24,30 +40,141 @@ async function readTomlSafe(
   }
   if (!text.trim()) return {};
   // Let parse errors propagate so callers surface malformed config to the user
-  const parsed: Record<string, unknown> = parseToml(text);
-  return parsed;
+  return parseConfigText(text, configFormatOf(path));
 }
 
 export async function loadMachineConfig(
   path: string,
   fm: FileManager
 ): Promise<MachineConfig> {
-  const raw = await readTomlSafe(path, fm);
-  return MachineConfigSchema.parse(raw);
+  return (await loadMachineLayer(path, fm)).machine;
+}
+
+/** The machine config and the keys its file wrote */
+export async function loadMachineLayer(
+  path: string,
+  fm: FileManager
+): Promise<{ machine: MachineConfig; written: ConfigLayerKeys }> {
+  const raw = await readConfigSafe(path, fm);
+  const machine = MachineConfigSchema.parse(raw);
+  return { machine, written: { path, keys: configKeys(raw) } };
 }
 
+/** Parse a project config, rejecting custom runners that reuse a built-in id */
+function parseProjectConfig(raw: Record<string, unknown>): ProjectConfig {
+  const project = ProjectConfigSchema.parse(raw);
+  const clash = validateCustomRunners(project.custom_runners);
+  if (clash !== null) throw new Error(clash);
+  return project;
+}
+
+/**
+ * The project config, `.ai-guardrails/config.toml` or `config.yaml` — or the
+ * file at `configPath` (`--config`) instead, which must exist
+ */
 export async function loadProjectConfig(
   projectDir: string,
-  fm: FileManager
+  fm: FileManager,
+  configPath?: string
 ): Promise<ProjectConfig> {
-  const path = join(projectDir, ".ai-guardrails", "config.toml");
-  const raw = await readTomlSafe(path, fm);
-  return ProjectConfigSchema.parse(raw);
+  return (await loadProjectLayer(projectDir, fm, configPath)).project;
+}
+
+/**
+ * The project config (see loadProjectConfig) and the keys its file wrote,
+ * under the path it was read from
+ */
+export async function loadProjectLayer(
+  projectDir: string,
+  fm: FileManager,
+  configPath?: string
+): Promise<{ project: ProjectConfig; written: ConfigLayerKeys }> {
+  if (configPath !== undefined && !(await fm.exists(configPath))) {
+    throw new Error(`--config ${configPath} does not exist`);
+  }
+  const path = configPath ?? (await findProjectConfig(projectDir, fm));
+  if (path === undefined) {
+    const project = ProjectConfigSchema.parse({});
+    return { project, written: { path: PROJECT_CONFIG_PATH, keys: [] } };
+  }
+  const raw = await readConfigSafe(configPath ?? join(projectDir, path), fm);
+  const project = parseProjectConfig(raw);
+  return { project, written: { path, keys: configKeys(raw) } };
+}
+
+/** `path: key: problem; ...` for a config file that fails `schema` */
+function schemaError(path: string, error: ZodError): Error {
+  const problems = error.issues.map(({ path: key, message }) =>
+    key.length > 0 ? `${key.join(".")}: ${message}` : message
+  );
+  return new Error(`${path}: ${problems.join("; ")}`);
+}
+
+/**
+ * The developer's global config (see findGlobalConfig), or undefined when
+ * none is read. A malformed one throws, naming its path.
+ */
+export async function loadGlobalConfig(
+  fm: FileManager,
+  env?: Readonly<Record<string, string | undefined>>,
+  home?: string
+): Promise<GlobalConfigFile | undefined> {
+  const path = await findGlobalConfig(fm, env, home);
+  if (path === undefined) return undefined;
+  const raw = await readConfigSafe(path, fm).catch((err) => {
+    const message = err instanceof Error ? err.message : String(err);
+    throw new Error(`${path}: ${message}`);
+  });
+  const parsed = GlobalConfigSchema.safeParse(raw);
+  if (!parsed.success) throw schemaError(path, parsed.error);
+  return { path, config: parsed.data };
+}
+
+/**
+ * Every `.ai-guardrails/config.toml` (or `config.yaml`) below the project
+ * root, keyed by the project-relative directory it applies to. Dependencies
+ * and `ignorePaths` are not searched. A malformed file throws, naming its
+ * path, and so does a directory holding both formats.
+ */
+export async function loadNestedConfigs(
+  projectDir: string,
+  fm: FileManager,
+  ignorePaths: readonly string[]
+): Promise<Array<{ dir: string; project: NestedConfig }>> {
+  const ignore = [...DEFAULT_IGNORE, "**/node_modules/**", ...ignorePaths];
+  const found = await Promise.all(
+    Object.values(PROJECT_CONFIG_PATHS).map((file) =>
+      fm.glob(`**/${file}`, projectDir, ignore)
+    )
+  );
+  const root = new Set(Object.values(PROJECT_CONFIG_PATHS));
+  const nested = found
+    .flat()
+    .filter((path) => !root.has(path))
+    .toSorted();
+  for (const [i, path] of nested.entries()) {
+    const next = nested[i + 1];
+    if (next !== undefined && dirname(next) === dirname(path)) {
+      throw new Error(`both ${path} and ${next} exist — keep only one`);
+    }
+  }
+  return Promise.all(
+    nested.map(async (path) => {
+      const raw = await readConfigSafe(join(projectDir, path), fm).catch((err) => {
+        const message = err instanceof Error ? err.message : String(err);
+        throw new Error(`${path}: ${message}`);
+      });
+      const parsed = NestedConfigSchema.safeParse(raw);
+      if (!parsed.success) throw schemaError(path, parsed.error);
+      return { dir: dirname(dirname(path)), project: parsed.data };
+    })
+  );
 }
 
 export function resolveConfig(
   machine: MachineConfig,
-  project: ProjectConfig
+  project: ProjectConfig,
+  global?: GlobalConfigFile
 ): ResolvedConfig {
-  return buildResolvedConfig(machine, project);
+  return buildResolvedConfig(machine, project, global);
 }
@@ -1,6 +1,8 @@
+import { posix } from "node:path";
 import { minimatch } from "minimatch";
 import { z } from "zod";
 
+import type { Severity } from "@/models/lint-issue";
 import type { NoConsoleLevel } from "@/utils/detect-project-type";
 
 const ConfigStrategySchema = z.enum(["merge", "replace", "skip"]);
@@ -12,36 +14,134 @@ const IgnoreEntrySchema = z.object({
   reason: z.string().min(1, "Reason is required"),
 });
 
-const MachineConfigSchema = z.object({
-  profile: z.enum(["strict", "standard", "minimal"]).default("standard"),
-  ignore: z.array(IgnoreEntrySchema).default([]),
-});
-
-export type Profile = "strict" | "standard" | "minimal";
+export type Profile = "strict" | "standard" | "lenient";
 export const PROFILES = [
   "strict",
   "standard",
-  "minimal",
+  "lenient",
 ] as const satisfies readonly Profile[];
 
+/** `lenient` used to be called `minimal`; configs with the old name still load */
+export function normalizeProfile(profile: Profile | "minimal"): Profile {
+  return profile === "minimal" ? "lenient" : profile;
+}
+
+const ProfileSchema = z
+  .enum(["strict", "standard", "lenient", "minimal"])
+  .transform((profile) => normalizeProfile(profile));
+
+/**
+ * What a profile changes beyond the tool configs `init` generates from it:
+ * the `--fail-on` default, and opt-in runners it turns on. [runners.<id>]
+ * `enabled` and the flags still win.
+ */
+export const PROFILE_DEFAULTS = {
+  strict: { failOn: "warning", runners: ["gofumpt"] },
+  standard: { failOn: "error", runners: [] },
+  lenient: { failOn: "error", runners: [] },
+} as const satisfies Record<Profile, { failOn: Severity; runners: readonly string[] }>;
+
+const MachineConfigSchema = z.object({
+  profile: ProfileSchema.default("standard"),
+  ignore: z.array(IgnoreEntrySchema).default([]),
+});
+
 export type MachineConfig = z.infer<typeof MachineConfigSchema>;
 
 export { MachineConfigSchema };
 
 const ConfigValuesSchema = z
   .object({
-    line_length: z.number().int().min(60).max(200).default(88),
+    line_length: z
+      .number()
+      .int()
+      .min(60)
+      .max(200)
+      .default(88)
+      .describe("Maximum line length for formatters and linters"),
     indent_width: z
       .number()
       .int()
       .refine((v) => v === 2 || v === 4, {
         message: "indent_width must be 2 or 4",
       })
-      .default(2),
+      .default(2)
+      .describe("Indent width in spaces: 2 or 4"),
     python_version: z
       .string()
       .regex(/^\d+\.\d+$/)
-      .optional(),
+      .optional()
+      .describe('Target Python version, e.g. "3.12"'),
+    go_local_prefix: z
+      .string()
+      .regex(/^[^\s,]+(,[^\s,]+)*$/, {
+        message: "go_local_prefix must be comma-separated import paths",
+      })
+      .optional()
+      .describe("goimports -local: comma-separated import path prefixes grouped last"),
+    errcheck_exclude: z
+      .array(z.string().min(1))
+      .optional()
+      .describe('errcheck -exclude entries, e.g. "(*bytes.Buffer).Write"'),
+    codespell_ignore_words: z
+      .array(z.string().min(1))
+      .optional()
+      .describe("Words codespell never flags (its -L / ignore-words-list)"),
+    codespell_dictionaries: z
+      .array(z.string().min(1))
+      .optional()
+      .describe("Extra codespell dictionary files, relative to the project root"),
+    shebang_shells: z
+      .array(z.string().regex(/^[\w.+-]+$/))
+      .optional()
+      .describe('Shebang interpreters beyond sh, bash, zsh, dash, e.g. "ksh"'),
+    coverage_min: z
+      .number()
+      .min(0)
+      .max(100)
+      .optional()
+      .describe("go-coverage: minimum total coverage of each Go module, in percent"),
+    coverage_packages: z
+      .record(z.number().min(0).max(100))
+      .optional()
+      .describe("go-coverage: minimum coverage percent per package import path"),
+    license_header: z
+      .string()
+      .min(1)
+      .optional()
+      .describe("license-header: the header template; {year} matches any year"),
+    license_header_extensions: z
+      .array(z.string().regex(/^\w+$/))
+      .optional()
+      .describe('license-header: file extensions to check, e.g. ["go", "ts"]'),
+    js_linter: z
+      .enum(["biome", "eslint", "both"])
+      .optional()
+      .describe("JS/TS linter to run; default: eslint where configured, else biome"),
+    js_formatter: z
+      .enum(["biome", "prettier"])
+      .optional()
+      .describe("JS/TS formatter; default: prettier where configured, else biome"),
+    sqlfluff_dialect: z
+      .string()
+      .regex(/^[\w-]+$/)
+      .optional()
+      .describe('sqlfluff --dialect, e.g. "postgres"; default: .sqlfluff, else ansi'),
+    buf_breaking_against: z
+      .string()
+      .min(1)
+      .optional()
+      .describe('buf-breaking: the buf input to compare with, e.g. ".git#branch=main"'),
+    file_hygiene_max_kb: z
+      .number()
+      .int()
+      .positive()
+      .optional()
+      .describe("file-hygiene: largest file size in KiB (default: 500)"),
+    file_hygiene_allow: z
+      .array(z.string().min(1))
+      .optional()
+      .describe('file-hygiene: globs of known large or binary files, e.g. "assets/**"'),
   })
   .passthrough();
 
@@ -60,25 +160,335 @@ const HooksConfigSchema = z.object({
 
 export type HooksSchemaConfig = z.infer<typeof HooksConfigSchema>;
 
+export { HooksConfigSchema };
+
+function isRegex(pattern: string): boolean {
+  try {
+    new RegExp(pattern);
+    return true;
+  } catch {
+    return false;
+  }
+}
+
+const RetryConfigSchema = z.object({
+  attempts: z
+    .number()
+    .int()
+    .min(1)
+    .max(10)
+    .optional()
+    .describe("Runs in total, the first included (default: 1; govulncheck: 3)"),
+  backoff: z
+    .number()
+    .nonnegative()
+    .optional()
+    .describe("Seconds before the first retry, doubling after each (default: 2)"),
+  match: z
+    .array(z.string().refine(isRegex, { message: "match entries must be regexes" }))
+    .optional()
+    .describe("Regexes marking a failure as transient, on top of network errors"),
+});
+
+export type RetryConfig = z.infer<typeof RetryConfigSchema>;
+
+export { RetryConfigSchema };
+
+/** A runner's built-in retry defaults (`LinterRunner.retry`) */
+export interface RetryPolicy {
+  /** Runs in total, the first included */
+  readonly attempts: number;
+  /** Seconds before the first retry; doubles after each */
+  readonly backoff: number;
+}
+
+/** Pure lint runners fail on the first error */
+export const NO_RETRY: RetryPolicy = { attempts: 1, backoff: 2 };
+
+const RunnerConfigSchema = z.object({
+  enabled: z.boolean().optional().describe("Run this runner (default: true)"),
+  timeout: z
+    .number()
+    .positive()
+    .optional()
+    .describe("Seconds before the runner is killed and reported as failed"),
+  retry: RetryConfigSchema.optional().describe(
+    "Re-run the runner when it fails with a transient (network) error"
+  ),
+  user_config: z
+    .string()
+    .min(1)
+    .optional()
+    .describe("The project's own config file for this tool; ours is never generated"),
+});
+
+export type RunnerConfig = z.infer<typeof RunnerConfigSchema>;
+
+export { RunnerConfigSchema };
+
+/** Named groups a custom runner's `pattern` must capture */
+export const CUSTOM_RUNNER_GROUPS = ["file", "line", "message"] as const;
+
+function isOutputPattern(pattern: string): boolean {
+  let groups: string[];
+  try {
+    // The empty alternative always matches, listing every named group
+    groups = Object.keys(new RegExp(`${pattern}|`).exec("")?.groups ?? {});
+  } catch {
+    return false;
+  }
+  return CUSTOM_RUNNER_GROUPS.every((group) => groups.includes(group));
+}
+
+const CustomRunnerSchema = z.object({
+  id: z.string().regex(/^[a-z0-9][\w-]*$/i, "Use letters, digits, - and _"),
+  name: z.string().min(1).optional(),
+  files: z
+    .array(z.string().min(1))
+    .min(1)
+    .describe("Globs (relative to project root) of the files the tool checks"),
+  command: z
+    .array(z.string())
+    .min(1)
+    .describe("argv; `{files}` expands to the matching files, `{projectDir}` to root"),
+  pattern: z
+    .string()
+    .refine(isOutputPattern, {
+      message: "pattern must be a valid regex with (?<file>), (?<line>), (?<message>)",
+    })
+    .describe("Regex applied per output line, with named groups file, line, message"),
+  version: z
+    .array(z.string())
+    .min(1)
+    .optional()
+    .describe("Command printing the tool version; also enables result caching"),
+  ok_exit_codes: z
+    .array(z.number().int())
+    .default([0, 1])
+    .describe("Exit codes of a completed run — with no parsed findings, others fail"),
+  install: z
+    .string()
+    .min(1)
+    .optional()
+    .describe("Shown by `doctor` and `install` when the tool is missing"),
+});
+
+export type CustomRunnerConfig = z.infer<typeof CustomRunnerSchema>;
+
+const PostRunSchema = z
+  .object({
+    command: z
+      .array(z.string())
+      .min(1)
+      .optional()
+      .describe(
+        "argv run with the JSON report on stdin; `{status}`, `{errors}`, `{warnings}` and `{projectDir}` are substituted"
+      ),
+    url: z
+      .string()
+      .url()
+      .optional()
+      .describe("Webhook the JSON report is POSTed to, with curl"),
+    required: z
+      .boolean()
+      .default(false)
+      .describe("Fail the check when the hook fails (default: only a warning)"),
+    timeout: z
+      .number()
+      .positive()
+      .default(30)
+      .describe("Seconds before the hook is killed"),
+  })
+  .refine((hook) => (hook.command === undefined) !== (hook.url === undefined), {
+    message: "post_run needs exactly one of command or url",
+  });
+
+export type PostRunConfig = z.infer<typeof PostRunSchema>;
+
+/**
+ * A `.ai-guardrails/config.toml` in a subdirectory. It holds only what can
+ * differ per file; values the generated tool configs are built from, hooks
+ * and custom runners stay project-wide, so they are rejected here.
+ */
+const NestedConfigSchema = z
+  .object({
+    profile: ProfileSchema.optional(),
+    ignore: z.array(IgnoreEntrySchema).default([]),
+    allow: z.array(AllowEntrySchema).default([]),
+    ignore_paths: z.array(z.string()).default([]),
+    runners: z
+      .record(z.object({ enabled: z.boolean().optional() }).strict())
+      .default({}),
+  })
+  .strict();
+
+export type NestedConfig = z.infer<typeof NestedConfigSchema>;
+
+export { NestedConfigSchema };
+
+/**
+ * Globs of the built-in file classifications. A [classifications.<name>]
+ * table with one of these names starts from them; its `files` add more.
+ */
+export const DEFAULT_CLASSIFICATIONS: Readonly<Record<string, readonly string[]>> = {
+  test: [
+    "**/*_test.go",
+    "**/test_*.py",
+    "**/*_test.py",
+    "**/conftest.py",
+    "**/*.{test,spec}.{ts,tsx,mts,cts,js,jsx,mjs,cjs}",
+    "**/__tests__/**",
+    "**/tests/**/*.rs",
+    "**/*_spec.rb",
+    "**/*_test.rb",
+    "**/src/test/**",
+    "**/*Test.{java,kt,php}",
+    "**/*Tests.{cs,swift}",
+    "**/*_spec.lua",
+    "**/*.bats",
+  ],
+};
+
+/**
+ * A [classifications.<name>] table: the files it covers, plus the keys of a
+ * nested config, applied to them with globs relative to the project root.
+ */
+const ClassificationSchema = NestedConfigSchema.extend({
+  files: z
+    .array(z.string().min(1))
+    .default([])
+    .describe("Globs (relative to project root); added to a built-in one's"),
+});
+
+export type ClassificationConfig = z.infer<typeof ClassificationSchema>;
+
 const ProjectConfigSchema = z.object({
-  profile: z.enum(["strict", "standard", "minimal"]).optional(),
+  profile: ProfileSchema.optional().describe(
+    "Strictness profile; overrides ~/.ai-guardrails/config.toml"
+  ),
   min_version: z
     .string()
     .regex(/^\d+\.\d+\.\d+$/)
-    .optional(),
-  config: ConfigValuesSchema.default({}),
-  ignore: z.array(IgnoreEntrySchema).default([]),
-  allow: z.array(AllowEntrySchema).default([]),
+    .optional()
+    .describe("Minimum ai-guardrails version this project requires"),
+  config: ConfigValuesSchema.default({}).describe(
+    "Values the generated linter configs are built from"
+  ),
+  ignore: z
+    .array(IgnoreEntrySchema)
+    .default([])
+    .describe("Rules ignored everywhere, each with a reason"),
+  allow: z
+    .array(AllowEntrySchema)
+    .default([])
+    .describe("Rules allowed in the files matching a glob, each with a reason"),
   hooks: HooksConfigSchema.optional(),
-  ignore_paths: z.array(z.string()).default([]),
+  ignore_paths: z
+    .array(z.string())
+    .default([])
+    .describe("Globs (relative to project root) that no runner checks"),
+  runners: z
+    .record(RunnerConfigSchema)
+    .default({})
+    .describe("Per-runner settings keyed by runner id (see `ai-guardrails list`)"),
+  custom_runners: z
+    .array(CustomRunnerSchema)
+    .default([])
+    .refine((specs) => new Set(specs.map((s) => s.id)).size === specs.length, {
+      message: "custom_runners ids must be unique",
+    })
+    .describe("Project-defined runners wrapping any line-oriented tool"),
+  classifications: z
+    .record(ClassificationSchema)
+    .default({})
+    .superRefine((tables, ctx) => {
+      for (const [name, table] of Object.entries(tables)) {
+        if (name in DEFAULT_CLASSIFICATIONS || table.files.length > 0) continue;
+        ctx.addIssue({
+          code: z.ZodIssueCode.custom,
+          path: [name, "files"],
+          message: `classification "${name}" needs files; only built-in ones have defaults`,
+        });
+      }
+    })
+    .describe("Rules for kinds of files, e.g. tests, keyed by name (built-in: test)"),
+  post_run: PostRunSchema.optional().describe(
+    "Command or webhook given the JSON report after every `check`"
+  ),
 });
 
 export type ProjectConfig = z.infer<typeof ProjectConfigSchema>;
 
 export { ProjectConfigSchema };
 
+/**
+ * The developer's own config, shared by every repo they work in. It holds
+ * only what is personal; values the generated tool configs are built from
+ * stay in the repo, so every clone generates the same files.
+ */
+const GlobalConfigSchema = z
+  .object({
+    profile: ProfileSchema.optional().describe("Strictness profile; the repo's wins"),
+    color: z
+      .enum(["auto", "always", "never"])
+      .optional()
+      .describe("Colorize output, unless --color is given (default: auto)"),
+    jobs: z
+      .number()
+      .int()
+      .positive()
+      .optional()
+      .describe("Max runners in parallel, unless --jobs or GUARDRAILS_JOBS is given"),
+    runners: z
+      .record(
+        RunnerConfigSchema.pick({ enabled: true, timeout: true, retry: true }).strict()
+      )
+      .default({})
+      .describe("Per-runner enabled, timeout and retry; the repo's win key by key"),
+  })
+  .strict();
+
+export type GlobalConfig = z.infer<typeof GlobalConfigSchema>;
+
+export { GlobalConfigSchema };
+
+/** A loaded global config and the path it was read from */
+export interface GlobalConfigFile {
+  path: string;
+  config: GlobalConfig;
+}
+
+export interface ConfigScope {
+  /** Project-relative directory the nested config sits in, e.g. "services/api" */
+  dir: string;
+  /** The nested config as written */
+  project: NestedConfig;
+  /** Effective config for files under `dir`: the parent's, overridden by `project` */
+  config: ResolvedConfig;
+}
+
+export interface FileClassification {
+  /** Its [classifications.<name>] name, e.g. "test" */
+  name: string;
+  /** Globs (relative to project root) of the files it covers, built-in ones first */
+  files: readonly string[];
+  /** The table as written */
+  project: ClassificationConfig;
+}
+
+export interface GlobalConfigLayer extends GlobalConfigFile {
+  /** Keys whose effective value it set, e.g. "profile", "runners.ruff.enabled" */
+  applied: readonly string[];
+}
+
+/** A config file and the key paths it wrote, e.g. "config.line_length" */
+export interface ConfigLayerKeys {
+  path: string;
+  keys: readonly string[];
+}
+
 export interface ResolvedConfig {
-  profile: "strict" | "standard" | "minimal";
+  profile: Profile;
   minVersion?: string;
   ignore: ReadonlyArray<{ rule: string; reason: string }>;
   allow: ReadonlyArray<{ rule: string; glob: string; reason: string }>;
@@ -86,20 +496,100 @@ export interface ResolvedConfig {
     line_length: number;
     indent_width: number;
     python_version?: string;
+    go_local_prefix?: string;
+    errcheck_exclude?: readonly string[];
+    codespell_ignore_words?: readonly string[];
+    codespell_dictionaries?: readonly string[];
+    shebang_shells?: readonly string[];
+    coverage_min?: number;
+    coverage_packages?: Readonly<Record<string, number>>;
+    license_header?: string;
+    license_header_extensions?: readonly string[];
+    js_linter?: "biome" | "eslint" | "both";
+    js_formatter?: "biome" | "prettier";
+    sqlfluff_dialect?: string;
+    buf_breaking_against?: string;
+    file_hygiene_max_kb?: number;
+    file_hygiene_allow?: readonly string[];
     [key: string]: unknown;
   };
   hooks?: HooksSchemaConfig;
   ignoredRules: ReadonlySet<string>;
   ignorePaths: readonly string[];
+  /** Per-runner settings keyed by runner id, from the [runners.<id>] tables */
+  runners?: Readonly<Record<string, RunnerConfig>>;
+  /** Project-defined runners from the [[custom_runners]] tables */
+  customRunners?: readonly CustomRunnerConfig[];
   noConsoleLevel: NoConsoleLevel;
+  /** Nested per-directory configs, deepest first (see configForPath) */
+  scopes?: readonly ConfigScope[];
+  /** File classifications in the order written; a file takes the first match */
+  classifications?: readonly FileClassification[];
+  /** The [post_run] hook `check` ends with */
+  postRun?: PostRunConfig;
+  /** The file --config named, read instead of the project's own config */
+  configPath?: string;
+  /** The global config merged beneath the project's, when one was read */
+  global?: GlobalConfigLayer;
+  /** What the machine and project config files wrote, for `config show` */
+  written?: { machine?: ConfigLayerKeys; project?: ConfigLayerKeys };
   isAllowed(rule: string, filePath: string): boolean;
 }
 
+function allowChecker(
+  ignoredRules: ReadonlySet<string>,
+  allow: ResolvedConfig["allow"]
+): ResolvedConfig["isAllowed"] {
+  return (rule, filePath) => {
+    if (ignoredRules.has(rule)) return true;
+    return allow.some(
+      (entry) => entry.rule === rule && minimatch(filePath, entry.glob)
+    );
+  };
+}
+
+/**
+ * The project's [runners.<id>] tables over the global config's, key by key,
+ * and the global keys no project key replaced
+ */
+function mergeGlobalRunners(
+  project: ProjectConfig,
+  global: GlobalConfig
+): { runners: Record<string, RunnerConfig>; applied: string[] } {
+  const runners: Record<string, RunnerConfig> = { ...project.runners };
+  const applied: string[] = [];
+  for (const [id, settings] of Object.entries(global.runners)) {
+    const own = project.runners[id] ?? {};
+    for (const key of Object.keys(settings)) {
+      if (!(key in own)) applied.push(`runners.${id}.${key}`);
+    }
+    runners[id] = { ...settings, ...own };
+  }
+  return { runners, applied };
+}
+
+/** What the global config adds beneath `project`, recording what it set */
+function globalLayer(
+  project: ProjectConfig,
+  global: GlobalConfigFile
+): { layer: GlobalConfigLayer; runners: Record<string, RunnerConfig> } {
+  const { runners, applied } = mergeGlobalRunners(project, global.config);
+  const { profile, color, jobs } = global.config;
+  const own = [
+    ...(profile !== undefined && project.profile === undefined ? ["profile"] : []),
+    ...(color !== undefined ? ["color"] : []),
+    ...(jobs !== undefined ? ["jobs"] : []),
+  ];
+  return { layer: { ...global, applied: [...own, ...applied] }, runners };
+}
+
 export function buildResolvedConfig(
   machine: MachineConfig,
-  project: ProjectConfig
+  project: ProjectConfig,
+  global?: GlobalConfigFile
 ): ResolvedConfig {
-  const profile = project.profile ?? machine.profile;
+  const profile = project.profile ?? global?.config.profile ?? machine.profile;
+  const merged = global !== undefined ? globalLayer(project, global) : undefined;
 
   // Merge ignores: machine → project, deduped by rule
   const ignoreMap = new Map<string, string>();
@@ -112,16 +602,49 @@ export function buildResolvedConfig(
   }));
   const ignoredRules = new Set(ignore.map((e) => e.rule));
   const allow = project.allow;
-  const { line_length, indent_width, python_version, ...passthroughRest } =
-    project.config;
+  const {
+    line_length,
+    indent_width,
+    python_version,
+    go_local_prefix,
+    errcheck_exclude,
+    codespell_ignore_words,
+    codespell_dictionaries,
+    shebang_shells,
+    coverage_min,
+    coverage_packages,
+    license_header,
+    license_header_extensions,
+    js_linter,
+    js_formatter,
+    ...passthroughRest
+  } = project.config;
   const values: ResolvedConfig["values"] = {
     ...passthroughRest,
     line_length,
     indent_width,
     ...(python_version !== undefined && { python_version }),
+    ...(go_local_prefix !== undefined && { go_local_prefix }),
+    ...(errcheck_exclude !== undefined && { errcheck_exclude }),
+    ...(codespell_ignore_words !== undefined && { codespell_ignore_words }),
+    ...(codespell_dictionaries !== undefined && { codespell_dictionaries }),
+    ...(shebang_shells !== undefined && { shebang_shells }),
+    ...(coverage_min !== undefined && { coverage_min }),
+    ...(coverage_packages !== undefined && { coverage_packages }),
+    ...(license_header !== undefined && { license_header }),
+    ...(license_header_extensions !== undefined && { license_header_extensions }),
+    ...(js_linter !== undefined && { js_linter }),
+    ...(js_formatter !== undefined && { js_formatter }),
   };
 
   const ignorePaths = project.ignore_paths;
+  const classifications = Object.entries(project.classifications).map(
+    ([name, table]): FileClassification => ({
+      name,
+      files: [...(DEFAULT_CLASSIFICATIONS[name] ?? []), ...table.files],
+      project: table,
+    })
+  );
 
   return {
     profile,
@@ -132,12 +655,230 @@ export function buildResolvedConfig(
     ...(project.hooks !== undefined && { hooks: project.hooks }),
     ignoredRules,
     ignorePaths,
+    runners: merged?.runners ?? project.runners,
+    customRunners: project.custom_runners,
     noConsoleLevel: "warn" as const,
-    isAllowed(rule: string, filePath: string): boolean {
-      if (ignoredRules.has(rule)) return true;
-      return allow.some(
-        (entry) => entry.rule === rule && minimatch(filePath, entry.glob)
-      );
-    },
+    ...(classifications.length > 0 && { classifications }),
+    ...(project.post_run !== undefined && { postRun: project.post_run }),
+    ...(merged !== undefined && { global: merged.layer }),
+    isAllowed: allowChecker(ignoredRules, allow),
+  };
+}
+
+/**
+ * `parent` overridden by the nested config in `dir`: its profile wins, its
+ * ignores and allows add to the parent's, and its [runners.<id>] `enabled`
+ * replaces the parent's. Globs in it are relative to `dir`.
+ */
+function scopedConfig(
+  parent: ResolvedConfig,
+  dir: string,
+  project: NestedConfig
+): ResolvedConfig {
+  const ignoreMap = new Map(parent.ignore.map((entry) => [entry.rule, entry.reason]));
+  for (const entry of project.ignore) ignoreMap.set(entry.rule, entry.reason);
+  const ignore = Array.from(ignoreMap.entries()).map(([rule, reason]) => ({
+    rule,
+    reason,
+  }));
+  const ignoredRules = new Set(ignore.map((e) => e.rule));
+  const allow = [
+    ...parent.allow,
+    ...project.allow.map((entry) => ({ ...entry, glob: posix.join(dir, entry.glob) })),
+  ];
+  const runners: Record<string, RunnerConfig> = { ...parent.runners };
+  for (const [id, settings] of Object.entries(project.runners)) {
+    runners[id] = { ...runners[id], ...settings };
+  }
+  return {
+    ...parent,
+    profile: project.profile ?? parent.profile,
+    ignore,
+    allow,
+    ignoredRules,
+    ignorePaths: [
+      ...parent.ignorePaths,
+      ...project.ignore_paths.map((glob) => posix.join(dir, glob)),
+    ],
+    runners,
+    isAllowed: allowChecker(ignoredRules, allow),
   };
 }
+
+function isUnder(relPath: string, dir: string): boolean {
+  return relPath === dir || relPath.startsWith(`${dir}/`);
+}
+
+/**
+ * Attach nested configs to `root`. Each applies on top of the nearest config
+ * above it, so `services/api` inherits from `services`, which inherits from
+ * the project config.
+ */
+export function withNestedConfigs(
+  root: ResolvedConfig,
+  nested: ReadonlyArray<{ dir: string; project: NestedConfig }>
+): ResolvedConfig {
+  if (nested.length === 0) return root;
+  // Shallow first, so each config's parent is built before it
+  const depth = (dir: string) => dir.split("/").length;
+  const shallowFirst = nested.toSorted((a, b) => depth(a.dir) - depth(b.dir));
+  const built: ConfigScope[] = [];
+  for (const { dir, project } of shallowFirst) {
+    const parent = built.findLast((scope) => isUnder(dir, scope.dir))?.config ?? root;
+    built.push({ dir, project, config: scopedConfig(parent, dir, project) });
+  }
+  return { ...root, scopes: built.toReversed() };
+}
+
+/** The configs that apply to `relPath`, nearest first; empty outside every scope */
+export function scopesFor(config: ResolvedConfig, relPath: string): ConfigScope[] {
+  return (config.scopes ?? []).filter((scope) => isUnder(relPath, scope.dir));
+}
+
+/** The first classification covering a project-relative path, if any */
+export function classificationFor(
+  config: ResolvedConfig,
+  relPath: string
+): FileClassification | undefined {
+  return config.classifications?.find((classification) =>
+    classification.files.some((glob) => minimatch(relPath, glob, { dot: true }))
+  );
+}
+
+// Built once per config and classification; configForPath runs per finding
+const classifiedConfigs = new WeakMap<
+  ResolvedConfig,
+  Map<FileClassification, ResolvedConfig>
+>();
+
+function classifiedConfig(
+  base: ResolvedConfig,
+  classification: FileClassification
+): ResolvedConfig {
+  const built =
+    classifiedConfigs.get(base) ?? new Map<FileClassification, ResolvedConfig>();
+  classifiedConfigs.set(base, built);
+  const known = built.get(classification);
+  if (known !== undefined) return known;
+  const config = scopedConfig(base, ".", classification.project);
+  built.set(classification, config);
+  return config;
+}
+
+/**
+ * The effective config for a project-relative path: its nearest nested
+ * config, with the path's classification, if any, applied on top.
+ */
+export function configForPath(config: ResolvedConfig, relPath: string): ResolvedConfig {
+  const base = scopesFor(config, relPath)[0]?.config ?? config;
+  const classification = classificationFor(config, relPath);
+  return classification !== undefined ? classifiedConfig(base, classification) : base;
+}
+
+/** The `--fail-on` default for a project-relative path, from its nearest profile */
+export function failOnAt(config: ResolvedConfig, relPath: string): Severity {
+  return PROFILE_DEFAULTS[configForPath(config, relPath).profile].failOn;
+}
+
+/**
+ * Apply `check --enable/--disable` on top of the [runners.<id>] tables.
+ * The CLI wins over the config file; `disable` wins over `enable`.
+ */
+export function withRunnerOverrides(
+  config: ResolvedConfig,
+  enable: readonly string[],
+  disable: readonly string[]
+): ResolvedConfig {
+  const runners: Record<string, RunnerConfig> = { ...config.runners };
+  for (const id of enable) runners[id] = { ...runners[id], enabled: true };
+  for (const id of disable) runners[id] = { ...runners[id], enabled: false };
+  const scopes = config.scopes?.map((scope) => ({
+    ...scope,
+    config: withRunnerOverrides(scope.config, enable, disable),
+  }));
+  const classifications = config.classifications?.map((classification) => {
+    const tables = { ...classification.project.runners };
+    for (const id of enable) tables[id] = { ...tables[id], enabled: true };
+    for (const id of disable) tables[id] = { ...tables[id], enabled: false };
+    const project = { ...classification.project, runners: tables };
+    return { ...classification, project };
+  });
+  return {
+    ...config,
+    runners,
+    ...(scopes !== undefined && { scopes }),
+    ...(classifications !== undefined && { classifications }),
+  };
+}
+
+/** Seconds a runner may take: its [runners.<id>] timeout, else `fallback`. */
+export function runnerTimeout(
+  config: ResolvedConfig,
+  runnerId: string,
+  fallback: number
+): number {
+  return config.runners?.[runnerId]?.timeout ?? fallback;
+}
+
+/**
+ * The project's own config file for a runner ([runners.<id>] user_config, set
+ * by `init --init-from existing`), or undefined when ai-guardrails manages it.
+ */
+export function userConfigFile(
+  config: ResolvedConfig,
+  runnerId: string
+): string | undefined {
+  return config.runners?.[runnerId]?.user_config;
+}
+
+/** How often to re-run a failed runner: its [runners.<id>] retry over `fallback`. */
+export function runnerRetry(
+  config: ResolvedConfig,
+  runnerId: string,
+  fallback: RetryPolicy = NO_RETRY
+): Required<RetryConfig> {
+  const retry = config.runners?.[runnerId]?.retry;
+  return {
+    attempts: retry?.attempts ?? fallback.attempts,
+    backoff: retry?.backoff ?? fallback.backoff,
+    match: retry?.match ?? [],
+  };
+}
+
+/** Opt-in runners the profile turns on (see PROFILE_DEFAULTS) */
+export function profileEnables(config: ResolvedConfig, runnerId: string): boolean {
+  const enabled: readonly string[] = PROFILE_DEFAULTS[config.profile].runners;
+  return enabled.includes(runnerId);
+}
+
+/**
+ * A runner's [runners.<id>] `enabled`, else on when the profile enables it,
+ * else `byDefault` — false for opt-in runners (`LinterRunner.defaultEnabled`).
+ */
+export function isRunnerEnabled(
+  config: ResolvedConfig,
+  runnerId: string,
+  byDefault = true
+): boolean {
+  const enabled = config.runners?.[runnerId]?.enabled;
+  if (enabled !== undefined) return enabled;
+  return profileEnables(config, runnerId) || byDefault;
+}
+
+/**
+ * False where a nested config covering `relPath` turns the runner off, or the
+ * path's classification does — that wins over the directories. A runner still
+ * runs project-wide; check drops its findings there.
+ */
+export function isRunnerEnabledAt(
+  config: ResolvedConfig,
+  runnerId: string,
+  relPath: string
+): boolean {
+  const classified = classificationFor(config, relPath)?.project.runners[runnerId];
+  if (classified?.enabled !== undefined) return classified.enabled;
+  const chain = scopesFor(config, relPath);
+  const sets = (scope: ConfigScope) => scope.project.runners[runnerId] !== undefined;
+  if (!chain.some(sets)) return true;
+  return chain[0]?.config.runners?.[runnerId]?.enabled !== false;
+}
@@ -20,7 +20,7 @@ type BiomeLinterRules =
     };
 
 function buildLinterRules(config: ResolvedConfig): BiomeLinterRules {
-  if (config.profile === "minimal") {
+  if (config.profile === "lenient") {
     return {
       recommended: false,
       correctness: {
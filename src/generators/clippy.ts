@@ -0,0 +1,30 @@
+import type { Profile, ResolvedConfig } from "@/config/schema";
+import type { ConfigGenerator } from "@/generators/types";
+import { withHashHeader } from "@/utils/hash";
+
+const CLIPPY_THRESHOLDS_BY_PROFILE = {
+  strict: { cognitiveComplexity: 15, tooManyArguments: 5, tooManyLines: 80 },
+  standard: { cognitiveComplexity: 25, tooManyArguments: 7, tooManyLines: 100 },
+  lenient: { cognitiveComplexity: 30, tooManyArguments: 9, tooManyLines: 150 },
+} as const satisfies Record<
+  Profile,
+  { cognitiveComplexity: number; tooManyArguments: number; tooManyLines: number }
+>;
+
+function renderClippyToml(config: ResolvedConfig): string {
+  const t = CLIPPY_THRESHOLDS_BY_PROFILE[config.profile];
+  const content = `cognitive-complexity-threshold = ${t.cognitiveComplexity}
+too-many-arguments-threshold = ${t.tooManyArguments}
+too-many-lines-threshold = ${t.tooManyLines}
+`;
+  return withHashHeader(content);
+}
+
+export const clippyGenerator: ConfigGenerator = {
+  id: "clippy",
+  configFile: "clippy.toml",
+  languages: ["rust"],
+  generate(config: ResolvedConfig): string {
+    return renderClippyToml(config);
+  },
+};
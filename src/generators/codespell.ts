@@ -1,12 +1,21 @@
 import type { ResolvedConfig } from "@/config/schema";
 import type { ConfigGenerator } from "@/generators/types";
+import { codespellDictionaryValue } from "@/runners/codespell";
 import { withHashHeader } from "@/utils/hash";
 
-function renderCodespellrc(_config: ResolvedConfig): string {
+function renderCodespellrc(config: ResolvedConfig): string {
+  const words = config.values.codespell_ignore_words ?? [];
+  const dictionaries = config.values.codespell_dictionaries ?? [];
+  const tuning = [
+    ...(words.length > 0 ? [`ignore-words-list = ${words.join(",")}`] : []),
+    ...(dictionaries.length > 0
+      ? [`dictionary = ${codespellDictionaryValue(dictionaries)}`]
+      : []),
+  ];
   const content = `[codespell]
 skip = .git,*.lock,*.baseline,node_modules,.venv,venv,dist,build,*/tests/fixtures/*
 quiet-level = 2
-`;
+${tuning.map((line) => `${line}\n`).join("")}`;
   return withHashHeader(content);
 }
 
@@ -0,0 +1,59 @@
+import type { Profile, ResolvedConfig } from "@/config/schema";
+import type { ConfigGenerator } from "@/generators/types";
+import { withHashHeader } from "@/utils/hash";
+
+const GOLANGCI_LINTERS_BY_PROFILE = {
+  strict: [
+    "errcheck",
+    "govet",
+    "ineffassign",
+    "staticcheck",
+    "unused",
+    "gosec",
+    "revive",
+    "unconvert",
+    "misspell",
+    "lll",
+  ],
+  standard: ["errcheck", "govet", "ineffassign", "staticcheck", "unused", "gosec"],
+  lenient: ["errcheck", "govet", "staticcheck"],
+} as const satisfies Record<Profile, readonly string[]>;
+
+/**
+ * The golangci-lint v2 schema: current installers ship v2, which rejects a
+ * config without `version: "2"`
+ */
+function renderGolangciYml(config: ResolvedConfig): string {
+  const linters = GOLANGCI_LINTERS_BY_PROFILE[config.profile];
+  const enable = linters.map((l) => `    - ${l}`).join("\n");
+  const lll = linters.some((l) => l === "lll")
+    ? `  settings:
+    lll:
+      line-length: ${config.values.line_length}
+`
+    : "";
+
+  const content = `version: "2"
+
+linters:
+  default: none
+  enable:
+${enable}
+${lll}
+issues:
+  max-issues-per-linter: 0
+  max-same-issues: 0
+`;
+  return withHashHeader(content);
+}
+
+export const golangciGenerator: ConfigGenerator = {
+  id: "golangci",
+  configFile: ".golangci.yml",
+  languages: ["go"],
+  honours: [".golangci.yml", ".golangci.yaml", ".golangci.toml", ".golangci.json"],
+  onRequest: true,
+  generate(config: ResolvedConfig): string {
+    return renderGolangciYml(config);
+  },
+};
@@ -0,0 +1,20 @@
+import type { ResolvedConfig } from "@/config/schema";
+import type { ConfigGenerator } from "@/generators/types";
+import { withHashHeader } from "@/utils/hash";
+
+function renderHadolintYaml(_config: ResolvedConfig): string {
+  const content = `failure-threshold: warning
+ignored: []
+`;
+  return withHashHeader(content);
+}
+
+export const hadolintGenerator: ConfigGenerator = {
+  id: "hadolint",
+  configFile: ".hadolint.yaml",
+  languages: ["docker"],
+  honours: [".hadolint.yaml", ".hadolint.yml"],
+  generate(config: ResolvedConfig): string {
+    return renderHadolintYaml(config);
+  },
+};
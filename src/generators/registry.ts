@@ -1,17 +1,22 @@
+import { join } from "node:path";
 import { agentRulesGenerator } from "@/generators/agent-rules";
 import { biomeGenerator } from "@/generators/biome";
 import { claudeSettingsGenerator } from "@/generators/claude-settings";
 import { codespellGenerator } from "@/generators/codespell";
 import { editorconfigGenerator } from "@/generators/editorconfig";
+import { golangciGenerator } from "@/generators/golangci";
 import { lefthookGenerator } from "@/generators/lefthook";
 import { markdownlintGenerator } from "@/generators/markdownlint";
 import { ruffGenerator } from "@/generators/ruff";
 import type { ConfigGenerator } from "@/generators/types";
+import type { FileManager } from "@/infra/file-manager";
+import { hasHashHeader } from "@/utils/hash";
 
 /** All built-in config generators */
 export const ALL_GENERATORS: readonly ConfigGenerator[] = [
   ruffGenerator,
   biomeGenerator,
+  golangciGenerator,
   editorconfigGenerator,
   markdownlintGenerator,
   codespellGenerator,
@@ -29,3 +34,20 @@ export function applicableGenerators(
       g.languages === undefined || g.languages.some((id) => activeLanguageIds.has(id))
   );
 }
+
+/**
+ * The first of the generator's `honours` files the project keeps itself,
+ * without our hash header; null when there is none to honour.
+ */
+export async function findHonouredConfig(
+  generator: ConfigGenerator,
+  projectDir: string,
+  fileManager: FileManager
+): Promise<string | null> {
+  for (const file of generator.honours ?? []) {
+    const path = join(projectDir, file);
+    if (!(await fileManager.exists(path))) continue;
+    if (!hasHashHeader(await fileManager.readText(path))) return file;
+  }
+  return null;
+}
@@ -0,0 +1,29 @@
+import type { ResolvedConfig } from "@/config/schema";
+import type { ConfigGenerator } from "@/generators/types";
+import { withHashHeader } from "@/utils/hash";
+
+function renderRubocopYaml(config: ResolvedConfig): string {
+  // SuggestExtensions off: rubocop-rails etc. are the project's choice, not ours
+  const content = `AllCops:
+  NewCops: enable
+  SuggestExtensions: false
+  Exclude:
+    - "bin/**/*"
+    - "db/schema.rb"
+    - "node_modules/**/*"
+    - "vendor/**/*"
+
+Layout/LineLength:
+  Max: ${config.values.line_length}
+`;
+  return withHashHeader(content);
+}
+
+export const rubocopGenerator: ConfigGenerator = {
+  id: "rubocop",
+  configFile: ".rubocop.yml",
+  languages: ["ruby"],
+  generate(config: ResolvedConfig): string {
+    return renderRubocopYaml(config);
+  },
+};
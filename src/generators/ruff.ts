@@ -1,12 +1,14 @@
-import type { ResolvedConfig } from "@/config/schema";
+import { parse as parseToml, stringify as stringifyToml } from "smol-toml";
+import type { Profile, ResolvedConfig } from "@/config/schema";
 import type { ConfigGenerator } from "@/generators/types";
+import { deepMerge, isPlainObject } from "@/utils/deep-merge";
 import { withHashHeader } from "@/utils/hash";
 
 const RUFF_SELECT_BY_PROFILE = {
   strict: `["ALL"]`,
   standard: `["E", "F", "W", "I", "UP", "S", "B", "A", "C4", "ICN", "PIE", "PT", "RSE", "SIM", "TID"]`,
-  minimal: `["E", "F", "S"]`,
-} as const satisfies Record<"strict" | "standard" | "minimal", string>;
+  lenient: `["E", "F", "S"]`,
+} as const satisfies Record<Profile, string>;
 
 /**
  * Rules removed from the ignore list for strict profile — they are enforced
@@ -57,7 +59,7 @@ const BASE_IGNORE_CATEGORIES: readonly IgnoreCategory[] = [
   },
 ] as const;
 
-function buildIgnoreList(profile: "strict" | "standard" | "minimal"): string {
+function buildIgnoreList(profile: Profile): string {
   const lines: string[] = ["["];
   for (const category of BASE_IGNORE_CATEGORIES) {
     const filteredRules =
@@ -158,6 +160,57 @@ docstring-code-line-length = 80
   return withHashHeader(content);
 }
 
+function parseTable(content: string): Record<string, unknown> {
+  const parsed: unknown = parseToml(content);
+  return isPlainObject(parsed) ? parsed : {};
+}
+
+function lintTable(data: Record<string, unknown>): Record<string, unknown> {
+  return isPlainObject(data.lint) ? data.lint : {};
+}
+
+function stringList(value: unknown): string[] {
+  if (!Array.isArray(value)) return [];
+  return value.filter((item): item is string => typeof item === "string");
+}
+
+/**
+ * Merge a generated ruff.toml into the user's. Every user key is kept and
+ * missing keys come from ours. When the user has their own `lint.select`, our
+ * recommended rules that it does not cover are appended to
+ * `lint.extend-select` instead of replacing it. The result carries a fresh
+ * hash header over the merged body.
+ */
+export function mergeRuffToml(existing: string, generated: string): string {
+  let user: Record<string, unknown>;
+  try {
+    user = parseTable(existing);
+  } catch {
+    throw new Error(
+      "Cannot merge ruff.toml: existing file has syntax errors. Fix the file or use --config-strategy=replace."
+    );
+  }
+  const ours = parseTable(generated);
+  const merged = deepMerge(ours, user);
+
+  const userLint = lintTable(user);
+  if (userLint.select !== undefined) {
+    const extendSelect = stringList(userLint["extend-select"]);
+    const selected = new Set([...stringList(userLint.select), ...extendSelect]);
+    const missing = selected.has("ALL")
+      ? []
+      : stringList(lintTable(ours).select).filter((rule) => !selected.has(rule));
+    if (missing.length > 0) {
+      merged.lint = {
+        ...lintTable(merged),
+        "extend-select": [...extendSelect, ...missing],
+      };
+    }
+  }
+
+  return withHashHeader(stringifyToml(merged));
+}
+
 export const ruffGenerator: ConfigGenerator = {
   id: "ruff",
   configFile: "ruff.toml",
@@ -165,4 +218,5 @@ export const ruffGenerator: ConfigGenerator = {
   generate(config: ResolvedConfig): string {
     return renderRuffToml(config);
   },
+  merge: mergeRuffToml,
 };
@@ -0,0 +1,21 @@
+import type { ResolvedConfig } from "@/config/schema";
+import type { ConfigGenerator } from "@/generators/types";
+import { withHashHeader } from "@/utils/hash";
+
+function renderRustfmtToml(config: ResolvedConfig): string {
+  const content = `edition = "2021"
+max_width = ${config.values.line_length}
+tab_spaces = ${config.values.indent_width}
+newline_style = "Unix"
+`;
+  return withHashHeader(content);
+}
+
+export const rustfmtGenerator: ConfigGenerator = {
+  id: "rustfmt",
+  configFile: "rustfmt.toml",
+  languages: ["rust"],
+  generate(config: ResolvedConfig): string {
+    return renderRustfmtToml(config);
+  },
+};
@@ -0,0 +1,71 @@
+import type { ResolvedConfig } from "@/config/schema";
+import type { ConfigGenerator } from "@/generators/types";
+import { withHashHeader } from "@/utils/hash";
+
+/** Core rules that catch mistakes rather than style, so no shared config is needed */
+const CORE_RULES = [
+  "annotation-no-unknown",
+  "at-rule-no-unknown",
+  "block-no-empty",
+  "color-no-invalid-hex",
+  "comment-no-empty",
+  "declaration-block-no-shorthand-property-overrides",
+  "font-family-no-duplicate-names",
+  "font-family-no-missing-generic-family-keyword",
+  "function-calc-no-unspaced-operator",
+  "keyframe-block-no-duplicate-selectors",
+  "keyframe-declaration-no-important",
+  "media-feature-name-no-unknown",
+  "named-grid-areas-no-invalid",
+  "no-duplicate-at-import-rules",
+  "no-duplicate-selectors",
+  "no-invalid-double-slash-comments",
+  "no-invalid-position-at-import-rule",
+  "property-no-unknown",
+  "selector-pseudo-class-no-unknown",
+  "selector-pseudo-element-no-unknown",
+  "string-no-newline",
+  "unit-no-unknown",
+];
+
+function renderStylelintYaml(config: ResolvedConfig): string {
+  const rules = CORE_RULES.map((rule) => `  ${rule}: true`).join("\n");
+  const ignoreFiles = ["**/dist/**", "**/*.min.css", ...config.ignorePaths]
+    .map((glob) => `  - ${JSON.stringify(glob)}`)
+    .join("\n");
+  // SCSS and Less need their own parsers; their at-rules and `//` comments are valid
+  const content = `rules:
+${rules}
+  declaration-block-no-duplicate-properties:
+    - true
+    - ignore: ["consecutive-duplicates-with-different-values"]
+  selector-type-no-unknown:
+    - true
+    - ignore: ["custom-elements"]
+
+overrides:
+  - files: ["**/*.scss"]
+    customSyntax: postcss-scss
+    rules:
+      at-rule-no-unknown: null
+      no-invalid-double-slash-comments: null
+  - files: ["**/*.less"]
+    customSyntax: postcss-less
+    rules:
+      at-rule-no-unknown: null
+      no-invalid-double-slash-comments: null
+
+ignoreFiles:
+${ignoreFiles}
+`;
+  return withHashHeader(content);
+}
+
+export const stylelintGenerator: ConfigGenerator = {
+  id: "stylelint",
+  configFile: ".stylelintrc.yaml",
+  languages: ["css"],
+  generate(config: ResolvedConfig): string {
+    return renderStylelintYaml(config);
+  },
+};
@@ -7,6 +7,21 @@ export interface ConfigGenerator {
   readonly configFile: string;
   /** If set, this generator only runs when at least one of these languages is detected */
   readonly languages?: readonly string[];
+  /**
+   * The tool's own config files, in its lookup order. When one exists without
+   * our hash header, the project's config is honoured and nothing is written.
+   */
+  readonly honours?: readonly string[];
+  /**
+   * Written only with --force (or init's --upgrade); without it the tool runs
+   * on its own defaults
+   */
+  readonly onRequest?: true;
   /** Generate config file content from resolved config */
   generate(config: ResolvedConfig): string;
+  /**
+   * Merge generated content into an existing file, keeping the user's keys.
+   * Replaces the generic deep merge of the "merge" strategy.
+   */
+  merge?(existing: string, generated: string): string;
 }
@@ -0,0 +1,27 @@
+import type { ResolvedConfig } from "@/config/schema";
+import type { ConfigGenerator } from "@/generators/types";
+import { withHashHeader } from "@/utils/hash";
+
+function renderYamllintYaml(_config: ResolvedConfig): string {
+  // truthy keys are off so GitHub Actions' `on:` is not flagged
+  const content = `extends: default
+
+rules:
+  line-length:
+    max: 120
+    level: warning
+  document-start: disable
+  truthy:
+    check-keys: false
+`;
+  return withHashHeader(content);
+}
+
+export const yamllintGenerator: ConfigGenerator = {
+  id: "yamllint",
+  configFile: ".yamllint.yaml",
+  languages: ["yaml"],
+  generate(config: ResolvedConfig): string {
+    return renderYamllintYaml(config);
+  },
+};
@@ -1,19 +1,52 @@
+import type { Console } from "@/infra/console";
+import { liveProcessGroups } from "@/infra/process-groups";
+import { createLimiter, type Limiter } from "@/utils/pool";
+
 export interface RunResult {
   stdout: string;
   stderr: string;
   exitCode: number;
+  /** Set when the process was killed for exceeding `timeout` */
+  timedOut?: boolean;
+  /** Set when the process was killed because `signal` aborted */
+  cancelled?: boolean;
+}
+
+export interface RunCommandOptions {
+  cwd?: string;
+  /** Milliseconds before the process and its children are killed */
+  timeout?: number;
+  /** Kills the process and its children when aborted */
+  signal?: AbortSignal;
+  /** Written to the process's stdin, which is closed after it */
+  stdin?: string;
+  /**
+   * Told true when the command has to wait for a process slot, and false once
+   * it gets one (see LimitedCommandRunner)
+   */
+  onWait?: (waiting: boolean) => void;
 }
 
 export interface CommandRunner {
-  run(args: string[], opts?: { cwd?: string; timeout?: number }): Promise<RunResult>;
+  run(args: string[], opts?: RunCommandOptions): Promise<RunResult>;
 }
 
+type Spawned = Bun.Subprocess<"ignore" | Blob, "pipe", "pipe">;
+
 function spawnOrNull(
   cmd: string,
   rest: string[],
-  cwd: string | undefined
-): Bun.Subprocess<"ignore", "pipe", "pipe"> | null {
-  const opts = { stdout: "pipe" as const, stderr: "pipe" as const };
+  cwd: string | undefined,
+  detached: boolean,
+  stdin: string | undefined
+): Spawned | null {
+  // A detached process leads its own group, so a kill reaches its children
+  const opts = {
+    stdin: stdin !== undefined ? new Blob([stdin]) : ("ignore" as const),
+    stdout: "pipe" as const,
+    stderr: "pipe" as const,
+    detached,
+  };
   try {
     return cwd !== undefined
       ? Bun.spawn([cmd, ...rest], { ...opts, cwd })
@@ -23,17 +56,28 @@ function spawnOrNull(
   }
 }
 
+/** Kill the process and everything it spawned (just the process without groups) */
+function killProcessGroup(proc: Spawned): void {
+  try {
+    process.kill(-proc.pid, "SIGKILL");
+  } catch {
+    proc.kill("SIGKILL");
+  }
+}
+
 export class RealCommandRunner implements CommandRunner {
-  async run(
-    args: string[],
-    opts?: { cwd?: string; timeout?: number }
-  ): Promise<RunResult> {
+  async run(args: string[], opts?: RunCommandOptions): Promise<RunResult> {
     const [cmd, ...rest] = args;
     if (!cmd) {
       return { stdout: "", stderr: "No command provided", exitCode: 1 };
     }
+    const signal = opts?.signal;
+    if (signal?.aborted === true) {
+      return { stdout: "", stderr: "", exitCode: 1, cancelled: true };
+    }
 
-    const proc = spawnOrNull(cmd, rest, opts?.cwd);
+    const killable = opts?.timeout !== undefined || signal !== undefined;
+    const proc = spawnOrNull(cmd, rest, opts?.cwd, killable, opts?.stdin);
     if (proc === null) {
       return {
         stdout: "",
@@ -42,12 +86,22 @@ export class RealCommandRunner implements CommandRunner {
       };
     }
 
+    // Out of the terminal's process group, so shutdown has to kill it explicitly
+    if (killable) liveProcessGroups.add(proc.pid);
     let killTimer: ReturnType<typeof setTimeout> | undefined;
+    let timedOut = false;
     if (opts?.timeout !== undefined) {
       killTimer = setTimeout(() => {
-        proc.kill();
+        timedOut = true;
+        killProcessGroup(proc);
       }, opts.timeout);
     }
+    let cancelled = false;
+    const onAbort = () => {
+      cancelled = true;
+      killProcessGroup(proc);
+    };
+    signal?.addEventListener("abort", onAbort, { once: true });
 
     const [stdout, stderr, exitCode] = await Promise.all([
       new Response(proc.stdout).text(),
@@ -58,7 +112,81 @@ export class RealCommandRunner implements CommandRunner {
     if (killTimer !== undefined) {
       clearTimeout(killTimer);
     }
+    signal?.removeEventListener("abort", onAbort);
+    liveProcessGroups.delete(proc.pid);
+
+    return {
+      stdout,
+      stderr,
+      exitCode,
+      ...(timedOut && { timedOut }),
+      ...(cancelled && { cancelled }),
+    };
+  }
+}
 
-    return { stdout, stderr, exitCode };
+/** Shell-like rendering of argv; arguments with spaces or quotes are quoted */
+export function formatArgv(args: readonly string[]): string {
+  return args
+    .map((arg) => (/^[\w@%+=:,./-]+$/.test(arg) ? arg : JSON.stringify(arg)))
+    .join(" ");
+}
+
+/** Logs each command's argv, cwd, exit code and duration at debug level */
+export class LoggingCommandRunner implements CommandRunner {
+  private readonly inner: CommandRunner;
+  private readonly console: Console;
+
+  constructor(inner: CommandRunner, console: Console) {
+    this.inner = inner;
+    this.console = console;
+  }
+
+  async run(args: string[], opts?: RunCommandOptions): Promise<RunResult> {
+    const cwd = opts?.cwd !== undefined ? ` (in ${opts.cwd})` : "";
+    this.console.debug(`$ ${formatArgv(args)}${cwd}`);
+    const start = performance.now();
+    const result = await this.inner.run(args, opts);
+    const ms = Math.round(performance.now() - start);
+    const outcome =
+      result.timedOut === true
+        ? "timed out"
+        : result.cancelled === true
+          ? "cancelled"
+          : `exit ${result.exitCode}`;
+    this.console.debug(`  ${outcome} in ${ms}ms: ${args[0] ?? ""}`);
+    return result;
+  }
+}
+
+/**
+ * Runs at most `maxProcs` commands at once, however many callers share it —
+ * the rest wait their turn. Bounds the total processes across parallel
+ * runners and their shards, independent of how many runners run. A waiting
+ * command whose `signal` aborts is cancelled without ever starting.
+ */
+export class LimitedCommandRunner implements CommandRunner {
+  private readonly inner: CommandRunner;
+  private readonly limit: Limiter;
+
+  constructor(inner: CommandRunner, maxProcs: number) {
+    this.inner = inner;
+    this.limit = createLimiter(maxProcs);
+  }
+
+  async run(args: string[], opts?: RunCommandOptions): Promise<RunResult> {
+    const signal = opts?.signal;
+    const onWait = opts?.onWait;
+    try {
+      return await this.limit(() => this.inner.run(args, opts), {
+        ...(signal !== undefined && { signal }),
+        ...(onWait !== undefined && { onWait }),
+      });
+    } catch (err) {
+      if (signal?.aborted === true) {
+        return { stdout: "", stderr: "", exitCode: 1, cancelled: true };
+      }
+      throw err;
+    }
   }
 }
@@ -1,9 +1,36 @@
 export interface Console {
   info(msg: string): void;
+  /** Status detail that is not part of the report; dropped with --quiet */
+  note(msg: string): void;
   success(msg: string): void;
   warning(msg: string): void;
   error(msg: string): void;
   step(msg: string): void;
+  /** Diagnostic detail shown with --verbose or --debug */
+  verbose(msg: string): void;
+  /** Internals (every command run) shown with --debug only */
+  debug(msg: string): void;
+}
+
+/** How much diagnostic output to show; "normal" shows none */
+export type LogLevel = "normal" | "verbose" | "debug";
+
+/** When to emit ANSI colors; "auto" colors a terminal unless NO_COLOR is set */
+export type ColorMode = "auto" | "always" | "never";
+
+/**
+ * Resolve a color mode for one output stream. "auto" follows
+ * https://no-color.org: a non-empty NO_COLOR disables color, and so does a
+ * stream that is not a terminal (CI logs, pipes, files).
+ */
+export function shouldUseColor(
+  mode: ColorMode,
+  isTTY: boolean,
+  env: Readonly<Record<string, string | undefined>> = process.env
+): boolean {
+  if (mode !== "auto") return mode === "always";
+  const noColor = env.NO_COLOR;
+  return isTTY && (noColor === undefined || noColor === "");
 }
 
 const RESET = "\x1b[0m";
@@ -11,25 +38,90 @@ const GREEN = "\x1b[32m";
 const YELLOW = "\x1b[33m";
 const RED = "\x1b[31m";
 const CYAN = "\x1b[36m";
+const GRAY = "\x1b[90m";
+
+export interface RealConsoleOptions {
+  /**
+   * Write note/success/warning/error/step to stderr so stdout carries only
+   * info() output — used when stdout is a machine-readable report.
+   */
+  statusToStderr?: boolean;
+  /** Diagnostic output to show, always on stderr (default: "normal") */
+  logLevel?: LogLevel;
+  /** Resolved per stream, so stdout piped to a file stays plain (default: "auto") */
+  color?: ColorMode;
+  /** -q/--quiet: drop note, success, step and warning output; info and error print */
+  quiet?: boolean;
+}
+
+/** Discards all output — the default for embedders that only want the report */
+export class SilentConsole implements Console {
+  info(_msg: string): void {}
+  note(_msg: string): void {}
+  success(_msg: string): void {}
+  warning(_msg: string): void {}
+  error(_msg: string): void {}
+  step(_msg: string): void {}
+  verbose(_msg: string): void {}
+  debug(_msg: string): void {}
+}
 
 export class RealConsole implements Console {
+  private readonly status: typeof process.stdout;
+  private readonly logLevel: LogLevel;
+  private readonly quiet: boolean;
+  private readonly statusColor: boolean;
+  private readonly stderrColor: boolean;
+
+  constructor(opts: RealConsoleOptions = {}) {
+    this.status = opts.statusToStderr === true ? process.stderr : process.stdout;
+    this.logLevel = opts.logLevel ?? "normal";
+    this.quiet = opts.quiet === true;
+    const color = opts.color ?? "auto";
+    this.statusColor = shouldUseColor(color, this.status.isTTY === true);
+    this.stderrColor = shouldUseColor(color, process.stderr.isTTY === true);
+  }
+
+  private paint(code: string, msg: string, enabled = this.statusColor): string {
+    return enabled ? `${code}${msg}${RESET}` : msg;
+  }
+
   info(msg: string): void {
     process.stdout.write(`${msg}\n`);
   }
 
+  note(msg: string): void {
+    if (this.quiet) return;
+    this.status.write(`${msg}\n`);
+  }
+
   success(msg: string): void {
-    process.stdout.write(`${GREEN}${msg}${RESET}\n`);
+    if (this.quiet) return;
+    this.status.write(`${this.paint(GREEN, msg)}\n`);
   }
 
   warning(msg: string): void {
-    process.stdout.write(`${YELLOW}${msg}${RESET}\n`);
+    if (this.quiet) return;
+    this.status.write(`${this.paint(YELLOW, msg)}\n`);
   }
 
   error(msg: string): void {
-    process.stdout.write(`${RED}${msg}${RESET}\n`);
+    this.status.write(`${this.paint(RED, msg)}\n`);
   }
 
   step(msg: string): void {
-    process.stdout.write(`${CYAN}${msg}${RESET}\n`);
+    if (this.quiet) return;
+    this.status.write(`${this.paint(CYAN, msg)}\n`);
+  }
+
+  // Diagnostics go to stderr so a machine-readable report on stdout stays valid
+  verbose(msg: string): void {
+    if (this.logLevel === "normal") return;
+    process.stderr.write(`${this.paint(GRAY, `[verbose] ${msg}`, this.stderrColor)}\n`);
+  }
+
+  debug(msg: string): void {
+    if (this.logLevel !== "debug") return;
+    process.stderr.write(`${this.paint(GRAY, `[debug] ${msg}`, this.stderrColor)}\n`);
   }
 }
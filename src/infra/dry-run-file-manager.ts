@@ -0,0 +1,90 @@
+import { join, relative } from "node:path";
+import { minimatch } from "minimatch";
+import type { FileManager, FileStat, GlobOptions } from "@/infra/file-manager";
+
+function missingFile(path: string): Error {
+  return Object.assign(new Error(`ENOENT: no such file or directory, open '${path}'`), {
+    code: "ENOENT",
+  });
+}
+
+/**
+ * Reads through to `inner` but keeps every write and delete in memory, so a
+ * dry run sees its own changes while nothing reaches disk. `changes` maps each
+ * touched path to its new content, or null once deleted, in first-touch order.
+ */
+export class DryRunFileManager implements FileManager {
+  readonly changes = new Map<string, string | null>();
+  private readonly inner: FileManager;
+
+  constructor(inner: FileManager) {
+    this.inner = inner;
+  }
+
+  async readText(path: string): Promise<string> {
+    if (!this.changes.has(path)) return this.inner.readText(path);
+    const content = this.changes.get(path);
+    if (content === null || content === undefined) throw missingFile(path);
+    return content;
+  }
+
+  async writeText(path: string, content: string): Promise<void> {
+    this.changes.set(path, content);
+  }
+
+  async appendText(path: string, content: string): Promise<void> {
+    const existing = (await this.exists(path)) ? await this.readText(path) : "";
+    this.changes.set(path, existing + content);
+  }
+
+  async exists(path: string): Promise<boolean> {
+    if (this.changes.has(path)) return this.changes.get(path) !== null;
+    return this.inner.exists(path);
+  }
+
+  async mkdir(_path: string, _opts?: { parents?: boolean }): Promise<void> {}
+
+  async glob(
+    pattern: string,
+    cwd: string,
+    ignore?: readonly string[],
+    opts?: GlobOptions
+  ): Promise<string[]> {
+    const found = await this.inner.glob(pattern, cwd, ignore, opts);
+    const dot = opts?.dot === true;
+    const kept = found.filter((file) => this.changes.get(join(cwd, file)) !== null);
+    const created = [...this.changes]
+      .filter(([, content]) => content !== null)
+      .map(([path]) => relative(cwd, path))
+      .filter(
+        (file) =>
+          !file.startsWith("..") &&
+          !kept.includes(file) &&
+          minimatch(file, pattern, { dot }) &&
+          !(ignore ?? []).some((ig) => minimatch(file, ig, { dot }))
+      );
+    return [...kept, ...created];
+  }
+
+  isSymlink(path: string): Promise<boolean> {
+    return this.inner.isSymlink(path);
+  }
+
+  async isExecutable(path: string): Promise<boolean> {
+    // A rewritten file keeps its mode; a deleted one has none
+    if (this.changes.get(path) === null) return false;
+    return this.inner.isExecutable(path);
+  }
+
+  async stat(path: string): Promise<FileStat | null> {
+    if (!this.changes.has(path)) return this.inner.stat(path);
+    const content = this.changes.get(path);
+    if (content === null || content === undefined) return null;
+    // A file written in memory counts as modified just now
+    return { mtimeMs: Date.now(), size: Buffer.byteLength(content) };
+  }
+
+  async delete(path: string): Promise<void> {
+    this.changes.set(path, null);
+  }
+}
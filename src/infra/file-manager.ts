@@ -3,14 +3,34 @@ import { Glob } from "bun";
 import { minimatch } from "minimatch";
 import { isEnoent } from "@/utils/errors";
 
+/** Enough to tell whether a file changed without reading it */
+export interface FileStat {
+  readonly mtimeMs: number;
+  readonly size: number;
+}
+
+export interface GlobOptions {
+  /** Also match dotfiles and everything under dot directories, e.g. .github/ */
+  dot?: boolean;
+}
+
 export interface FileManager {
   readText(path: string): Promise<string>;
   writeText(path: string, content: string): Promise<void>;
   appendText(path: string, content: string): Promise<void>;
   exists(path: string): Promise<boolean>;
   mkdir(path: string, opts?: { parents?: boolean }): Promise<void>;
-  glob(pattern: string, cwd: string, ignore?: readonly string[]): Promise<string[]>;
+  glob(
+    pattern: string,
+    cwd: string,
+    ignore?: readonly string[],
+    opts?: GlobOptions
+  ): Promise<string[]>;
   isSymlink(path: string): Promise<boolean>;
+  /** True for a regular file with an execute bit set; false when missing */
+  isExecutable(path: string): Promise<boolean>;
+  /** Modification time and size of a regular file; null when missing */
+  stat(path: string): Promise<FileStat | null>;
   delete(path: string): Promise<void>;
 }
 
@@ -43,15 +63,17 @@ export class RealFileManager implements FileManager {
   async glob(
     pattern: string,
     cwd: string,
-    ignore?: readonly string[]
+    ignore?: readonly string[],
+    opts?: GlobOptions
   ): Promise<string[]> {
+    const dot = opts?.dot === true;
     const g = new Glob(pattern);
     const results: string[] = [];
-    for await (const file of g.scan({ cwd, absolute: false })) {
+    for await (const file of g.scan({ cwd, absolute: false, dot })) {
       results.push(file);
     }
     if (ignore === undefined || ignore.length === 0) return results;
-    return results.filter((f) => !ignore.some((ig) => minimatch(f, ig)));
+    return results.filter((f) => !ignore.some((ig) => minimatch(f, ig, { dot })));
   }
 
   async isSymlink(path: string): Promise<boolean> {
@@ -63,6 +85,24 @@ export class RealFileManager implements FileManager {
     }
   }
 
+  async isExecutable(path: string): Promise<boolean> {
+    try {
+      const stat = await fs.stat(path);
+      return stat.isFile() && (stat.mode & 0o111) !== 0;
+    } catch {
+      return false;
+    }
+  }
+
+  async stat(path: string): Promise<FileStat | null> {
+    try {
+      const stat = await fs.stat(path);
+      return stat.isFile() ? { mtimeMs: stat.mtimeMs, size: stat.size } : null;
+    } catch {
+      return null;
+    }
+  }
+
   async delete(path: string): Promise<void> {
     try {
       await fs.unlink(path);
@@ -0,0 +1,21 @@
+import type { FSWatcher } from "node:fs";
+import { watch } from "node:fs";
+
+export interface WatchHandle {
+  close(): void;
+}
+
+export interface FileWatcher {
+  /** Call `onChange` with each changed path, relative to `dir`, until closed */
+  watch(dir: string, onChange: (relPath: string) => void): WatchHandle;
+}
+
+/** Recursive fs.watch — inotify on Linux, FSEvents on macOS */
+export class RealFileWatcher implements FileWatcher {
+  watch(dir: string, onChange: (relPath: string) => void): WatchHandle {
+    const watcher: FSWatcher = watch(dir, { recursive: true }, (_event, filename) => {
+      if (filename !== null) onChange(filename);
+    });
+    return { close: () => watcher.close() };
+  }
+}
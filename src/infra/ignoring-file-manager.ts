@@ -0,0 +1,67 @@
+import { join, relative } from "node:path";
+import type { FileManager, FileStat, GlobOptions } from "@/infra/file-manager";
+import type { PathMatcher } from "@/utils/ignore-file";
+
+/**
+ * Hides ignored paths from `glob`, so runners that discover their own files
+ * skip them. `isIgnored` receives paths relative to `projectDir`.
+ */
+export class IgnoringFileManager implements FileManager {
+  private readonly inner: FileManager;
+  private readonly projectDir: string;
+  private readonly isIgnored: PathMatcher;
+
+  constructor(inner: FileManager, projectDir: string, isIgnored: PathMatcher) {
+    this.inner = inner;
+    this.projectDir = projectDir;
+    this.isIgnored = isIgnored;
+  }
+
+  readText(path: string): Promise<string> {
+    return this.inner.readText(path);
+  }
+
+  writeText(path: string, content: string): Promise<void> {
+    return this.inner.writeText(path, content);
+  }
+
+  appendText(path: string, content: string): Promise<void> {
+    return this.inner.appendText(path, content);
+  }
+
+  exists(path: string): Promise<boolean> {
+    return this.inner.exists(path);
+  }
+
+  mkdir(path: string, opts?: { parents?: boolean }): Promise<void> {
+    return this.inner.mkdir(path, opts);
+  }
+
+  async glob(
+    pattern: string,
+    cwd: string,
+    ignore?: readonly string[],
+    opts?: GlobOptions
+  ): Promise<string[]> {
+    const found = await this.inner.glob(pattern, cwd, ignore, opts);
+    return found.filter(
+      (file) => !this.isIgnored(relative(this.projectDir, join(cwd, file)))
+    );
+  }
+
+  isSymlink(path: string): Promise<boolean> {
+    return this.inner.isSymlink(path);
+  }
+
+  isExecutable(path: string): Promise<boolean> {
+    return this.inner.isExecutable(path);
+  }
+
+  stat(path: string): Promise<FileStat | null> {
+    return this.inner.stat(path);
+  }
+
+  delete(path: string): Promise<void> {
+    return this.inner.delete(path);
+  }
+}
@@ -0,0 +1,80 @@
+type Kill = (pid: number, signal: NodeJS.Signals) => void;
+
+/** Exit codes for a run stopped by a signal, as a shell reports them */
+const SIGNAL_EXIT_CODES = { SIGINT: 130, SIGTERM: 143 } as const;
+type ShutdownSignal = keyof typeof SIGNAL_EXIT_CODES;
+
+/**
+ * Process groups of detached commands still running. A detached process
+ * leads its own group, so a Ctrl-C at the terminal does not reach it: the
+ * groups are killed explicitly when ai-guardrails exits.
+ */
+export class LiveProcessGroups {
+  private readonly pids = new Set<number>();
+  private readonly kill: Kill;
+
+  constructor(kill: Kill = (pid, signal) => process.kill(pid, signal)) {
+    this.kill = kill;
+  }
+
+  add(pid: number): void {
+    this.pids.add(pid);
+  }
+
+  delete(pid: number): void {
+    this.pids.delete(pid);
+  }
+
+  get size(): number {
+    return this.pids.size;
+  }
+
+  /** SIGKILL every group (just the process where groups are unsupported) */
+  killAll(): void {
+    for (const pid of this.pids) {
+      try {
+        this.kill(-pid, "SIGKILL");
+      } catch {
+        try {
+          this.kill(pid, "SIGKILL");
+        } catch {
+          // Already gone
+        }
+      }
+    }
+    this.pids.clear();
+  }
+}
+
+/** The groups RealCommandRunner spawns */
+export const liveProcessGroups = new LiveProcessGroups();
+
+/**
+ * Kill `groups` when this process exits or gets SIGINT/SIGTERM. After the
+ * kill a signal calls `onSignal`, by default exiting with 130/143. Returns a
+ * function that removes the handlers.
+ */
+export function killProcessGroupsOnShutdown(
+  groups: LiveProcessGroups = liveProcessGroups,
+  onSignal: (signal: ShutdownSignal) => void = (signal) =>
+    process.exit(SIGNAL_EXIT_CODES[signal])
+): () => void {
+  const onExit = () => groups.killAll();
+  const onInt = () => {
+    groups.killAll();
+    onSignal("SIGINT");
+  };
+  const onTerm = () => {
+    groups.killAll();
+    onSignal("SIGTERM");
+  };
+  process.on("exit", onExit);
+  // Once: a second Ctrl-C falls back to the default and terminates at once
+  process.once("SIGINT", onInt);
+  process.once("SIGTERM", onTerm);
+  return () => {
+    process.off("exit", onExit);
+    process.off("SIGINT", onInt);
+    process.off("SIGTERM", onTerm);
+  };
+}
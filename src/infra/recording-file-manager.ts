@@ -0,0 +1,72 @@
+import { join } from "node:path";
+import type { FileManager, FileStat, GlobOptions } from "@/infra/file-manager";
+
+/** Lists files to inspect rather than finds any, so is not recorded */
+const CATCH_ALL_GLOB = "**/*";
+
+/**
+ * Delegates to `inner`, recording every path it finds — exists() and
+ * isExecutable() hits and glob matches, as absolute paths. Shows what a
+ * language was detected from.
+ */
+export class RecordingFileManager implements FileManager {
+  readonly found: string[] = [];
+  private readonly inner: FileManager;
+
+  constructor(inner: FileManager) {
+    this.inner = inner;
+  }
+
+  readText(path: string): Promise<string> {
+    return this.inner.readText(path);
+  }
+
+  writeText(path: string, content: string): Promise<void> {
+    return this.inner.writeText(path, content);
+  }
+
+  appendText(path: string, content: string): Promise<void> {
+    return this.inner.appendText(path, content);
+  }
+
+  async exists(path: string): Promise<boolean> {
+    const exists = await this.inner.exists(path);
+    if (exists) this.found.push(path);
+    return exists;
+  }
+
+  mkdir(path: string, opts?: { parents?: boolean }): Promise<void> {
+    return this.inner.mkdir(path, opts);
+  }
+
+  async glob(
+    pattern: string,
+    cwd: string,
+    ignore?: readonly string[],
+    opts?: GlobOptions
+  ): Promise<string[]> {
+    const matches = await this.inner.glob(pattern, cwd, ignore, opts);
+    if (pattern !== CATCH_ALL_GLOB) {
+      this.found.push(...matches.map((file) => join(cwd, file)));
+    }
+    return matches;
+  }
+
+  isSymlink(path: string): Promise<boolean> {
+    return this.inner.isSymlink(path);
+  }
+
+  async isExecutable(path: string): Promise<boolean> {
+    const executable = await this.inner.isExecutable(path);
+    if (executable) this.found.push(path);
+    return executable;
+  }
+
+  stat(path: string): Promise<FileStat | null> {
+    return this.inner.stat(path);
+  }
+
+  delete(path: string): Promise<void> {
+    return this.inner.delete(path);
+  }
+}
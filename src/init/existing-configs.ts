@@ -0,0 +1,140 @@
+import { join } from "node:path";
+import type { ResolvedConfig, RunnerConfig } from "@/config/schema";
+import type { FileManager } from "@/infra/file-manager";
+import { hasHashHeader } from "@/utils/hash";
+
+/** Where a tool finds its config, and the init module that would write ours */
+export interface ToolConfigFiles {
+  /** Runner the config belongs to */
+  runner: string;
+  /** Init module generating our config for it, deselected when the user has one */
+  module: string;
+  /** Files the tool reads, in its own lookup order */
+  files: readonly string[];
+  /** Files shared with other tools; one counts only with the tool's section */
+  sections?: Readonly<Record<string, RegExp>>;
+}
+
+/** The tool configs `init --init-from existing` adopts */
+export const TOOL_CONFIG_FILES: readonly ToolConfigFiles[] = [
+  {
+    runner: "ruff",
+    module: "ruff-config",
+    files: ["ruff.toml", ".ruff.toml", "pyproject.toml"],
+    sections: { "pyproject.toml": /^\[tool\.ruff[\].]/m },
+  },
+  { runner: "biome", module: "biome-config", files: ["biome.json", "biome.jsonc"] },
+  {
+    runner: "golangci-lint",
+    module: "golangci-config",
+    files: [".golangci.yml", ".golangci.yaml", ".golangci.toml", ".golangci.json"],
+  },
+  { runner: "staticcheck", module: "staticcheck-config", files: ["staticcheck.conf"] },
+  {
+    runner: "hadolint",
+    module: "hadolint-config",
+    files: [".hadolint.yaml", ".hadolint.yml"],
+  },
+  {
+    runner: "yamllint",
+    module: "yamllint-config",
+    files: [".yamllint", ".yamllint.yaml", ".yamllint.yml"],
+  },
+  { runner: "rubocop", module: "rubocop-config", files: [".rubocop.yml"] },
+  {
+    runner: "stylelint",
+    module: "stylelint-config",
+    files: [
+      ".stylelintrc",
+      ".stylelintrc.json",
+      ".stylelintrc.yaml",
+      ".stylelintrc.yml",
+      ".stylelintrc.js",
+      ".stylelintrc.cjs",
+      "stylelint.config.js",
+      "stylelint.config.cjs",
+      "stylelint.config.mjs",
+    ],
+  },
+  {
+    runner: "rustfmt",
+    module: "rustfmt-config",
+    files: ["rustfmt.toml", ".rustfmt.toml"],
+  },
+  { runner: "clippy", module: "clippy-config", files: ["clippy.toml", ".clippy.toml"] },
+  {
+    runner: "markdownlint",
+    module: "markdownlint-config",
+    files: [
+      ".markdownlint.jsonc",
+      ".markdownlint.json",
+      ".markdownlint.yaml",
+      ".markdownlint.yml",
+    ],
+  },
+  {
+    runner: "codespell",
+    module: "codespell-config",
+    files: [".codespellrc", "setup.cfg", "pyproject.toml"],
+    sections: {
+      "setup.cfg": /^\[codespell\]/m,
+      "pyproject.toml": /^\[tool\.codespell\]/m,
+    },
+  },
+];
+
+export interface UserConfig {
+  runner: string;
+  /** Relative to the project root */
+  file: string;
+}
+
+/** The first of the tool's files the user wrote, skipping ones we generated */
+async function userFileFor(
+  tool: ToolConfigFiles,
+  projectDir: string,
+  fileManager: FileManager
+): Promise<string | undefined> {
+  for (const file of tool.files) {
+    const path = join(projectDir, file);
+    if (!(await fileManager.exists(path))) continue;
+    const content = await fileManager.readText(path);
+    if (hasHashHeader(content)) continue;
+    const section = tool.sections?.[file];
+    if (section !== undefined && !section.test(content)) continue;
+    return file;
+  }
+  return undefined;
+}
+
+/** The project's own tool configs at its root, one per tool */
+export async function findUserConfigs(
+  projectDir: string,
+  fileManager: FileManager
+): Promise<UserConfig[]> {
+  const found: UserConfig[] = [];
+  for (const tool of TOOL_CONFIG_FILES) {
+    const file = await userFileFor(tool, projectDir, fileManager);
+    if (file !== undefined) found.push({ runner: tool.runner, file });
+  }
+  return found;
+}
+
+/** `config` with each found file as its runner's user_config */
+export function withUserConfigs(
+  config: ResolvedConfig,
+  found: readonly UserConfig[]
+): ResolvedConfig {
+  const runners: Record<string, RunnerConfig> = { ...config.runners };
+  for (const { runner, file } of found) {
+    runners[runner] = { ...runners[runner], user_config: file };
+  }
+  return { ...config, runners };
+}
+
+/** Init modules whose config the project keeps its own of */
+export function userConfiguredModules(config: ResolvedConfig): string[] {
+  return TOOL_CONFIG_FILES.filter(
+    (tool) => config.runners?.[tool.runner]?.user_config !== undefined
+  ).map((tool) => tool.module);
+}
@@ -0,0 +1,48 @@
+import { join } from "node:path";
+import type { InitContext, InitModule, InitModuleResult } from "@/init/types";
+import { setupAzurePipelinesStep } from "@/steps/setup-azure-pipelines";
+import { AZURE_PIPELINES_FILE, resolveCiProvider } from "@/utils/ci-provider";
+
+export const azurePipelinesModule: InitModule = {
+  id: "azure-pipelines",
+  name: "Azure Pipelines",
+  description: "Write azure-pipelines.yml running ai-guardrails check",
+  category: "ci",
+  defaultEnabled: true,
+  disableFlag: "--no-ci",
+
+  async detect(ctx: InitContext): Promise<boolean> {
+    const provider = await resolveCiProvider(
+      ctx.projectDir,
+      ctx.fileManager,
+      ctx.flags.ci
+    );
+    return provider === "azure";
+  },
+
+  async execute(ctx: InitContext): Promise<InitModuleResult> {
+    const dest = join(ctx.projectDir, AZURE_PIPELINES_FILE);
+    const existed = await ctx.fileManager.exists(dest);
+    const languageIds = new Set(ctx.languages.map((l) => l.id));
+    const result = await setupAzurePipelinesStep(
+      ctx.projectDir,
+      ctx.fileManager,
+      languageIds
+    );
+
+    if (result.status === "error") {
+      return { status: "error", message: result.message };
+    }
+    if (result.status === "skip") {
+      return { status: "skipped", message: result.message };
+    }
+
+    return {
+      status: "ok",
+      message: result.message,
+      ...(existed
+        ? { filesModified: [AZURE_PIPELINES_FILE] }
+        : { filesCreated: [AZURE_PIPELINES_FILE] }),
+    };
+  },
+};
@@ -26,6 +26,13 @@ export const baselineModule: InitModule = {
   },
 
   async execute(ctx: InitContext): Promise<InitModuleResult> {
+    // Running every linter is slow, and the snapshot depends on files not yet written
+    if (ctx.flags.dryRun === true) {
+      return {
+        status: "skipped",
+        message: "Dry run — would capture a baseline snapshot",
+      };
+    }
     const result = await snapshotStep(
       ctx.projectDir,
       ctx.languages,
@@ -0,0 +1,48 @@
+import { join } from "node:path";
+import type { InitContext, InitModule, InitModuleResult } from "@/init/types";
+import { setupCircleciStep } from "@/steps/setup-circleci";
+import { CIRCLECI_CONFIG_FILE, resolveCiProvider } from "@/utils/ci-provider";
+
+export const circleciModule: InitModule = {
+  id: "circleci",
+  name: "CircleCI",
+  description: "Write .circleci/config.yml with an ai-guardrails job",
+  category: "ci",
+  defaultEnabled: true,
+  disableFlag: "--no-ci",
+
+  async detect(ctx: InitContext): Promise<boolean> {
+    const provider = await resolveCiProvider(
+      ctx.projectDir,
+      ctx.fileManager,
+      ctx.flags.ci
+    );
+    return provider === "circleci";
+  },
+
+  async execute(ctx: InitContext): Promise<InitModuleResult> {
+    const dest = join(ctx.projectDir, CIRCLECI_CONFIG_FILE);
+    const existed = await ctx.fileManager.exists(dest);
+    const languageIds = new Set(ctx.languages.map((l) => l.id));
+    const result = await setupCircleciStep(
+      ctx.projectDir,
+      ctx.fileManager,
+      languageIds
+    );
+
+    if (result.status === "error") {
+      return { status: "error", message: result.message };
+    }
+    if (result.status === "skip") {
+      return { status: "skipped", message: result.message };
+    }
+
+    return {
+      status: "ok",
+      message: result.message,
+      ...(existed
+        ? { filesModified: [CIRCLECI_CONFIG_FILE] }
+        : { filesCreated: [CIRCLECI_CONFIG_FILE] }),
+    };
+  },
+};
@@ -0,0 +1,51 @@
+import { clippyGenerator } from "@/generators/clippy";
+import { writeConfigFile } from "@/init/modules/file-conflict";
+import type { InitContext, InitModule, InitModuleResult } from "@/init/types";
+
+export const clippyConfigModule: InitModule = {
+  id: "clippy-config",
+  name: "Clippy Config",
+  description: "Generate clippy.toml for Rust linting",
+  category: "language-config",
+  defaultEnabled: true,
+  disableFlag: "--no-clippy",
+  dependsOn: ["config-tuning"],
+
+  async detect(ctx: InitContext): Promise<boolean> {
+    return ctx.languages.some((l) => l.id === "rust");
+  },
+
+  /** Only `--force` and `--upgrade` write the default; otherwise clippy uses its own */
+  async execute(ctx: InitContext): Promise<InitModuleResult> {
+    const { configFile } = clippyGenerator;
+    const force = ctx.flags.force === true;
+    if (!force && ctx.flags.upgrade !== true) {
+      return {
+        status: "skipped",
+        message: `${configFile} is generated only with --force or --upgrade`,
+      };
+    }
+    const content = clippyGenerator.generate(ctx.config);
+
+    const result = await writeConfigFile(
+      ctx.projectDir,
+      configFile,
+      content,
+      force,
+      ctx.fileManager
+    );
+
+    if (result.status === "skipped") {
+      return { status: "skipped", message: result.reason };
+    }
+    if (result.status === "error") {
+      return { status: "error", message: result.message };
+    }
+
+    return {
+      status: "ok",
+      message: `${configFile} written`,
+      filesCreated: [configFile],
+    };
+  },
+};
@@ -1,7 +1,12 @@
 import { join } from "node:path";
-import { parse as parseToml, stringify as stringifyToml } from "smol-toml";
+import {
+  configFormatFromFlags,
+  configFormatOf,
+  parseConfigText,
+  projectConfigTarget,
+  stringifyConfig,
+} from "@/config/config-file";
 import type { InitContext, InitModule, InitModuleResult } from "@/init/types";
-import { PROJECT_CONFIG_PATH } from "@/models/paths";
 import { isPlainObject } from "@/utils/deep-merge";
 
 function readNumber(flags: Record<string, unknown>, key: string): number | undefined {
@@ -33,12 +38,18 @@ export const configTuningModule: InitModule = {
   },
 
   async execute(ctx: InitContext): Promise<InitModuleResult> {
-    const dest = join(ctx.projectDir, PROJECT_CONFIG_PATH);
+    const file = await projectConfigTarget(
+      ctx.projectDir,
+      ctx.fileManager,
+      configFormatFromFlags(ctx.flags)
+    );
+    const format = configFormatOf(file);
+    const dest = join(ctx.projectDir, file);
 
     let existing: Record<string, unknown> = {};
     try {
       const text = await ctx.fileManager.readText(dest);
-      const parsed: unknown = parseToml(text);
+      const parsed: unknown = parseConfigText(text, format);
       existing = isPlainObject(parsed) ? parsed : {};
     } catch {
       // File may not exist yet — start fresh
@@ -89,8 +100,23 @@ export const configTuningModule: InitModule = {
       updated.ignore_paths = [...existingPaths, ...newPaths];
     }
 
+    // Tool configs adopted by --init-from existing stay the user's
+    const adopted = Object.entries(ctx.config.runners ?? {}).flatMap(([id, runner]) =>
+      runner.user_config !== undefined ? [{ id, userConfig: runner.user_config }] : []
+    );
+    if (adopted.length > 0) {
+      const runners = isPlainObject(existing.runners) ? { ...existing.runners } : {};
+      for (const { id, userConfig } of adopted) {
+        const table = runners[id];
+        const settings = isPlainObject(table) ? table : {};
+        runners[id] = { ...settings, user_config: userConfig };
+      }
+      updated.runners = runners;
+    }
+
     try {
-      await ctx.fileManager.writeText(dest, stringifyToml(updated));
+      const content = stringifyConfig(updated, format);
+      await ctx.fileManager.writeText(dest, content);
     } catch (e: unknown) {
       const message = e instanceof Error ? e.message : String(e);
       return { status: "error", message: `Failed to write config: ${message}` };
@@ -99,7 +125,7 @@ export const configTuningModule: InitModule = {
     return {
       status: "ok",
       message: "Config tuning applied",
-      filesModified: [PROJECT_CONFIG_PATH],
+      filesModified: [file],
     };
   },
 };
@@ -1,20 +1,6 @@
 import { dirname, join } from "node:path";
 import type { FileManager } from "@/infra/file-manager";
-import { HASH_PREFIX, JSONC_HASH_PREFIX, MD_HASH_PREFIX } from "@/utils/hash";
-
-/**
- * Returns true if the given file content starts with a hash header
- * written by ai-guardrails.
- */
-function hasOurHashHeader(content: string): boolean {
-  const nlIdx = content.indexOf("\n");
-  const firstLine = nlIdx === -1 ? content : content.slice(0, nlIdx);
-  return (
-    firstLine.startsWith(HASH_PREFIX) ||
-    firstLine.startsWith(JSONC_HASH_PREFIX) ||
-    firstLine.startsWith(MD_HASH_PREFIX)
-  );
-}
+import { hasHashHeader } from "@/utils/hash";
 
 export type WriteFileResult =
   | { status: "written" }
@@ -48,7 +34,7 @@ export async function writeConfigFile(
       return { status: "error", message: `Failed to read ${configFile}: ${message}` };
     }
 
-    if (!hasOurHashHeader(existing)) {
+    if (!hasHashHeader(existing)) {
       if (!force) {
         return {
           status: "skipped",
@@ -1,6 +1,7 @@
 import { join } from "node:path";
 import type { InitContext, InitModule, InitModuleResult } from "@/init/types";
 import { setupCiStep } from "@/steps/setup-ci";
+import { resolveCiProvider } from "@/utils/ci-provider";
 
 export const githubActionsModule: InitModule = {
   id: "github-actions",
@@ -11,7 +12,13 @@ export const githubActionsModule: InitModule = {
   disableFlag: "--no-ci",
 
   async detect(ctx: InitContext): Promise<boolean> {
-    return ctx.fileManager.exists(join(ctx.projectDir, ".git"));
+    if (!(await ctx.fileManager.exists(join(ctx.projectDir, ".git")))) return false;
+    const provider = await resolveCiProvider(
+      ctx.projectDir,
+      ctx.fileManager,
+      ctx.flags.ci
+    );
+    return provider === "github";
   },
 
   async execute(ctx: InitContext): Promise<InitModuleResult> {
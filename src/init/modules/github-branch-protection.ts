@@ -37,6 +37,12 @@ export const githubBranchProtectionModule: InitModule = {
     const jobNames = await parseWorkflowJobNames(ctx.projectDir, ctx.fileManager);
 
     const apiArgs = buildProtectionArgs(owner, repo, jobNames);
+    if (ctx.flags.dryRun === true) {
+      return {
+        status: "skipped",
+        message: "Dry run — would set branch protection on main",
+      };
+    }
     const result = await ctx.commandRunner.run(apiArgs, { cwd: ctx.projectDir });
 
     if (result.exitCode !== 0) {
@@ -40,6 +40,12 @@ export const githubProtectedPatternsModule: InitModule = {
     const jobNames = await parseWorkflowJobNames(ctx.projectDir, ctx.fileManager);
 
     const apiArgs = buildRulesetArgs(owner, repo, DEFAULT_PATTERNS, jobNames);
+    if (ctx.flags.dryRun === true) {
+      return {
+        status: "skipped",
+        message: `Dry run — would create a ruleset for: ${DEFAULT_PATTERNS.join(", ")}`,
+      };
+    }
     const result = await ctx.commandRunner.run(apiArgs, { cwd: ctx.projectDir });
 
     if (result.exitCode !== 0) {
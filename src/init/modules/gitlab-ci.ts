@@ -0,0 +1,44 @@
+import { join } from "node:path";
+import type { InitContext, InitModule, InitModuleResult } from "@/init/types";
+import { setupGitlabCiStep } from "@/steps/setup-gitlab-ci";
+import { GITLAB_CI_FILE, resolveCiProvider } from "@/utils/ci-provider";
+
+export const gitlabCiModule: InitModule = {
+  id: "gitlab-ci",
+  name: "GitLab CI",
+  description: "Write or merge an ai-guardrails job into .gitlab-ci.yml",
+  category: "ci",
+  defaultEnabled: true,
+  disableFlag: "--no-ci",
+
+  async detect(ctx: InitContext): Promise<boolean> {
+    const provider = await resolveCiProvider(
+      ctx.projectDir,
+      ctx.fileManager,
+      ctx.flags.ci
+    );
+    return provider === "gitlab";
+  },
+
+  async execute(ctx: InitContext): Promise<InitModuleResult> {
+    const existed = await ctx.fileManager.exists(join(ctx.projectDir, GITLAB_CI_FILE));
+    const languageIds = new Set(ctx.languages.map((l) => l.id));
+    const result = await setupGitlabCiStep(
+      ctx.projectDir,
+      ctx.fileManager,
+      languageIds
+    );
+
+    if (result.status === "error") {
+      return { status: "error", message: result.message };
+    }
+
+    return {
+      status: "ok",
+      message: result.message,
+      ...(existed
+        ? { filesModified: [GITLAB_CI_FILE] }
+        : { filesCreated: [GITLAB_CI_FILE] }),
+    };
+  },
+};
@@ -0,0 +1,69 @@
+import { golangciGenerator } from "@/generators/golangci";
+import { findHonouredConfig } from "@/generators/registry";
+import { writeConfigFile } from "@/init/modules/file-conflict";
+import type { InitContext, InitModule, InitModuleResult } from "@/init/types";
+
+export const golangciConfigModule: InitModule = {
+  id: "golangci-config",
+  name: "golangci-lint Config",
+  description: "Generate .golangci.yml for golangci-lint",
+  category: "language-config",
+  defaultEnabled: true,
+  disableFlag: "--no-golangci",
+  dependsOn: ["config-tuning"],
+
+  async detect(ctx: InitContext): Promise<boolean> {
+    return ctx.languages.some((l) => l.id === "go");
+  },
+
+  /**
+   * Only `--force` and `--upgrade` write the default, and never over a config
+   * of the project's own: golangci-lint runs with that as-is.
+   */
+  async execute(ctx: InitContext): Promise<InitModuleResult> {
+    const { configFile } = golangciGenerator;
+    const force = ctx.flags.force === true;
+    if (!force && ctx.flags.upgrade !== true) {
+      return {
+        status: "skipped",
+        message: `${configFile} is generated only with --force or --upgrade`,
+      };
+    }
+    let honoured: string | null;
+    try {
+      honoured = await findHonouredConfig(
+        golangciGenerator,
+        ctx.projectDir,
+        ctx.fileManager
+      );
+    } catch (err) {
+      const message = err instanceof Error ? err.message : String(err);
+      return { status: "error", message: `Failed to read ${configFile}: ${message}` };
+    }
+    if (honoured !== null) {
+      return { status: "skipped", message: `${honoured} exists — golangci-lint uses it` };
+    }
+    const content = golangciGenerator.generate(ctx.config);
+
+    const result = await writeConfigFile(
+      ctx.projectDir,
+      configFile,
+      content,
+      force,
+      ctx.fileManager
+    );
+
+    if (result.status === "skipped") {
+      return { status: "skipped", message: result.reason };
+    }
+    if (result.status === "error") {
+      return { status: "error", message: result.message };
+    }
+
+    return {
+      status: "ok",
+      message: `${configFile} written`,
+      filesCreated: [configFile],
+    };
+  },
+};
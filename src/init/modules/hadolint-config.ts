@@ -0,0 +1,68 @@
+import { hadolintGenerator } from "@/generators/hadolint";
+import { findHonouredConfig } from "@/generators/registry";
+import { writeConfigFile } from "@/init/modules/file-conflict";
+import type { InitContext, InitModule, InitModuleResult } from "@/init/types";
+
+export const hadolintConfigModule: InitModule = {
+  id: "hadolint-config",
+  name: "Hadolint Config",
+  description: "Generate .hadolint.yaml for Dockerfile linting",
+  category: "language-config",
+  defaultEnabled: true,
+  disableFlag: "--no-hadolint",
+  dependsOn: ["config-tuning"],
+
+  async detect(ctx: InitContext): Promise<boolean> {
+    return ctx.languages.some((l) => l.id === "docker");
+  },
+
+  /**
+   * Only `--force` and `--upgrade` write the default, and never over a config
+   * of the project's own: users tune hadolint's rules by hand.
+   */
+  async execute(ctx: InitContext): Promise<InitModuleResult> {
+    const { configFile } = hadolintGenerator;
+    const force = ctx.flags.force === true;
+    if (!force && ctx.flags.upgrade !== true) {
+      return {
+        status: "skipped",
+        message: `${configFile} is generated only with --force or --upgrade`,
+      };
+    }
+    let honoured: string | null;
+    try {
+      honoured = await findHonouredConfig(
+        hadolintGenerator,
+        ctx.projectDir,
+        ctx.fileManager
+      );
+    } catch (err) {
+      const message = err instanceof Error ? err.message : String(err);
+      return { status: "error", message: `Failed to read ${configFile}: ${message}` };
+    }
+    if (honoured !== null) {
+      return { status: "skipped", message: `Using existing ${honoured}` };
+    }
+
+    const result = await writeConfigFile(
+      ctx.projectDir,
+      configFile,
+      hadolintGenerator.generate(ctx.config),
+      force,
+      ctx.fileManager
+    );
+
+    if (result.status === "skipped") {
+      return { status: "skipped", message: result.reason };
+    }
+    if (result.status === "error") {
+      return { status: "error", message: result.message };
+    }
+
+    return {
+      status: "ok",
+      message: `${configFile} written`,
+      filesCreated: [configFile],
+    };
+  },
+};
@@ -34,6 +34,14 @@ export const lefthookModule: InitModule = {
       return { status: "error", message: writeResult.message };
     }
 
+    if (ctx.flags.dryRun === true) {
+      return {
+        status: "ok",
+        message: "lefthook.yml written; would run lefthook install",
+        filesCreated: [lefthookGenerator.configFile],
+      };
+    }
+
     try {
       const installResult = await ctx.commandRunner.run(["lefthook", "install"], {
         cwd: ctx.projectDir,
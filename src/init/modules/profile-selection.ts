@@ -1,18 +1,33 @@
 import { dirname, join } from "node:path";
-import { parse as parseToml, stringify as stringifyToml } from "smol-toml";
-import { PROFILES, type Profile } from "@/config/schema";
+import {
+  configFormatFromFlags,
+  configFormatOf,
+  parseConfigText,
+  projectConfigTarget,
+  stringifyConfig,
+} from "@/config/config-file";
+import { normalizeProfile, type Profile } from "@/config/schema";
 import type { InitContext, InitModule, InitModuleResult } from "@/init/types";
-import { PROJECT_CONFIG_PATH } from "@/models/paths";
 import { isPlainObject } from "@/utils/deep-merge";
 
-function isProfile(value: unknown): value is Profile {
-  return typeof value === "string" && PROFILES.some((p) => p === value);
+const PROFILE_NAMES = ["strict", "standard", "lenient", "minimal"] as const;
+
+/**
+ * The profile --profile names (`minimal` still meaning `lenient`), undefined
+ * when the flag is absent, or null when it names no profile.
+ */
+export function profileFromFlags(
+  flags: Record<string, unknown>
+): Profile | undefined | null {
+  const raw = flags.profile;
+  if (raw === undefined) return undefined;
+  const name = PROFILE_NAMES.find((p) => p === raw);
+  return name !== undefined ? normalizeProfile(name) : null;
 }
 
-function resolveProfile(flags: Record<string, unknown>): Profile {
-  const flagProfile = flags.profile;
-  if (isProfile(flagProfile)) return flagProfile;
-  return "standard";
+/** --profile, else the profile the tool configs were generated from */
+function resolveProfile(ctx: InitContext): Profile {
+  return profileFromFlags(ctx.flags) ?? ctx.config.profile;
 }
 
 export const profileSelectionModule: InitModule = {
@@ -27,8 +42,14 @@ export const profileSelectionModule: InitModule = {
   },
 
   async execute(ctx: InitContext): Promise<InitModuleResult> {
-    const profile = resolveProfile(ctx.flags);
-    const dest = join(ctx.projectDir, PROJECT_CONFIG_PATH);
+    const profile = resolveProfile(ctx);
+    const file = await projectConfigTarget(
+      ctx.projectDir,
+      ctx.fileManager,
+      configFormatFromFlags(ctx.flags)
+    );
+    const format = configFormatOf(file);
+    const dest = join(ctx.projectDir, file);
     const force = ctx.flags.force === true;
     const upgrade = ctx.flags.upgrade === true;
 
@@ -37,7 +58,7 @@ export const profileSelectionModule: InitModule = {
     if (exists && !force && !upgrade) {
       return {
         status: "skipped",
-        message: `${PROJECT_CONFIG_PATH} already exists — use --force to overwrite or --upgrade to update profile`,
+        message: `${file} already exists — use --force to overwrite or --upgrade to update profile`,
       };
     }
 
@@ -46,7 +67,7 @@ export const profileSelectionModule: InitModule = {
     if (exists) {
       try {
         const text = await ctx.fileManager.readText(dest);
-        const parsed: unknown = parseToml(text);
+        const parsed: unknown = parseConfigText(text, format);
         existing = isPlainObject(parsed) ? parsed : {};
       } catch {
         // Cannot read existing file — start fresh
@@ -54,7 +75,7 @@ export const profileSelectionModule: InitModule = {
     }
 
     const configData: Record<string, unknown> = { ...existing, profile };
-    const content = stringifyToml(configData);
+    const content = stringifyConfig(configData, format);
 
     try {
       await ctx.fileManager.mkdir(dirname(dest), { parents: true });
@@ -68,13 +89,13 @@ export const profileSelectionModule: InitModule = {
       return {
         status: "ok",
         message: `Config written with profile=${profile}`,
-        filesModified: [PROJECT_CONFIG_PATH],
+        filesModified: [file],
       };
     }
     return {
       status: "ok",
       message: `Config written with profile=${profile}`,
-      filesCreated: [PROJECT_CONFIG_PATH],
+      filesCreated: [file],
     };
   },
 };
@@ -0,0 +1,43 @@
+import { rubocopGenerator } from "@/generators/rubocop";
+import { writeConfigFile } from "@/init/modules/file-conflict";
+import type { InitContext, InitModule, InitModuleResult } from "@/init/types";
+
+export const rubocopConfigModule: InitModule = {
+  id: "rubocop-config",
+  name: "RuboCop Config",
+  description: "Generate a starter .rubocop.yml for Ruby linting",
+  category: "language-config",
+  defaultEnabled: true,
+  disableFlag: "--no-rubocop",
+  dependsOn: ["config-tuning"],
+
+  async detect(ctx: InitContext): Promise<boolean> {
+    return ctx.languages.some((l) => l.id === "ruby");
+  },
+
+  async execute(ctx: InitContext): Promise<InitModuleResult> {
+    const content = rubocopGenerator.generate(ctx.config);
+    const force = ctx.flags.force === true;
+
+    const result = await writeConfigFile(
+      ctx.projectDir,
+      rubocopGenerator.configFile,
+      content,
+      force,
+      ctx.fileManager
+    );
+
+    if (result.status === "skipped") {
+      return { status: "skipped", message: result.reason };
+    }
+    if (result.status === "error") {
+      return { status: "error", message: result.message };
+    }
+
+    return {
+      status: "ok",
+      message: `${rubocopGenerator.configFile} written`,
+      filesCreated: [rubocopGenerator.configFile],
+    };
+  },
+};
@@ -1,6 +1,15 @@
-import { ruffGenerator } from "@/generators/ruff";
+import { join } from "node:path";
+import { mergeRuffToml, ruffGenerator } from "@/generators/ruff";
 import { writeConfigFile } from "@/init/modules/file-conflict";
 import type { InitContext, InitModule, InitModuleResult } from "@/init/types";
+import { hasHashHeader, isOutdatedTemplate } from "@/utils/hash";
+
+/** Read the existing ruff.toml, or null when there is none */
+async function readExisting(ctx: InitContext): Promise<string | null> {
+  const dest = join(ctx.projectDir, ruffGenerator.configFile);
+  if (!(await ctx.fileManager.exists(dest))) return null;
+  return ctx.fileManager.readText(dest);
+}
 
 export const ruffConfigModule: InitModule = {
   id: "ruff-config",
@@ -16,16 +25,38 @@ export const ruffConfigModule: InitModule = {
   },
 
   async execute(ctx: InitContext): Promise<InitModuleResult> {
-    const content = ruffGenerator.generate(ctx.config);
+    const generated = ruffGenerator.generate(ctx.config);
     // --config-strategy replace overwrites user-managed files (same as --force for lang configs).
     const configStrategy = ctx.flags.configStrategy;
     const force = ctx.flags.force === true || configStrategy === "replace";
+    // --merge (implied by --upgrade) folds our rules into an existing file
+    const merge = !force && (ctx.flags.merge === true || ctx.flags.upgrade === true);
+
+    let content = generated;
+    let merged = false;
+    if (merge) {
+      try {
+        const existing = await readExisting(ctx);
+        // An untouched file from an older template is simply regenerated
+        if (existing !== null && !isOutdatedTemplate(existing)) {
+          content = mergeRuffToml(existing, generated);
+          merged = true;
+          if (!hasHashHeader(existing)) {
+            const file = ruffGenerator.configFile;
+            ctx.console.warning(`${file} is user-owned — merged our rules into it`);
+          }
+        }
+      } catch (err) {
+        const message = err instanceof Error ? err.message : String(err);
+        return { status: "error", message };
+      }
+    }
 
     const result = await writeConfigFile(
       ctx.projectDir,
       ruffGenerator.configFile,
       content,
-      force,
+      force || merged,
       ctx.fileManager
     );
 
@@ -38,7 +69,7 @@ export const ruffConfigModule: InitModule = {
 
     return {
       status: "ok",
-      message: `${ruffGenerator.configFile} written`,
+      message: `${ruffGenerator.configFile} ${merged ? "merged" : "written"}`,
       filesCreated: [ruffGenerator.configFile],
     };
   },
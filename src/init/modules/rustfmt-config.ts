@@ -0,0 +1,51 @@
+import { rustfmtGenerator } from "@/generators/rustfmt";
+import { writeConfigFile } from "@/init/modules/file-conflict";
+import type { InitContext, InitModule, InitModuleResult } from "@/init/types";
+
+export const rustfmtConfigModule: InitModule = {
+  id: "rustfmt-config",
+  name: "rustfmt Config",
+  description: "Generate rustfmt.toml for Rust formatting",
+  category: "language-config",
+  defaultEnabled: true,
+  disableFlag: "--no-rustfmt",
+  dependsOn: ["config-tuning"],
+
+  async detect(ctx: InitContext): Promise<boolean> {
+    return ctx.languages.some((l) => l.id === "rust");
+  },
+
+  /** Only `--force` and `--upgrade` write the default; else rustfmt uses its own */
+  async execute(ctx: InitContext): Promise<InitModuleResult> {
+    const { configFile } = rustfmtGenerator;
+    const force = ctx.flags.force === true;
+    if (!force && ctx.flags.upgrade !== true) {
+      return {
+        status: "skipped",
+        message: `${configFile} is generated only with --force or --upgrade`,
+      };
+    }
+    const content = rustfmtGenerator.generate(ctx.config);
+
+    const result = await writeConfigFile(
+      ctx.projectDir,
+      configFile,
+      content,
+      force,
+      ctx.fileManager
+    );
+
+    if (result.status === "skipped") {
+      return { status: "skipped", message: result.reason };
+    }
+    if (result.status === "error") {
+      return { status: "error", message: result.message };
+    }
+
+    return {
+      status: "ok",
+      message: `${configFile} written`,
+      filesCreated: [configFile],
+    };
+  },
+};
@@ -0,0 +1,68 @@
+import { join } from "node:path";
+import { stylelintGenerator } from "@/generators/stylelint";
+import { writeConfigFile } from "@/init/modules/file-conflict";
+import type { InitContext, InitModule, InitModuleResult } from "@/init/types";
+import { findStylelintConfig } from "@/runners/stylelint";
+import { hasHashHeader } from "@/utils/hash";
+
+/** The existing config, unless it is the one we generated earlier */
+async function userConfig(ctx: InitContext): Promise<string | null> {
+  const found = await findStylelintConfig(ctx.projectDir, ctx.fileManager);
+  if (found !== stylelintGenerator.configFile) return found;
+  const existing = await ctx.fileManager.readText(join(ctx.projectDir, found));
+  return hasHashHeader(existing) ? null : found;
+}
+
+export const stylelintConfigModule: InitModule = {
+  id: "stylelint-config",
+  name: "Stylelint Config",
+  description: "Generate a starter .stylelintrc.yaml for CSS/SCSS/Less linting",
+  category: "language-config",
+  defaultEnabled: true,
+  disableFlag: "--no-stylelint",
+  dependsOn: ["config-tuning"],
+
+  async detect(ctx: InitContext): Promise<boolean> {
+    return ctx.languages.some((l) => l.id === "css");
+  },
+
+  async execute(ctx: InitContext): Promise<InitModuleResult> {
+    const { configFile } = stylelintGenerator;
+    const force = ctx.flags.force === true;
+
+    // Any stylelint config of the user's counts, whatever its name
+    const existing = await userConfig(ctx);
+    if (existing !== null && !force) {
+      return {
+        status: "skipped",
+        message: `Using existing ${existing} — use --force to replace it`,
+      };
+    }
+
+    const result = await writeConfigFile(
+      ctx.projectDir,
+      configFile,
+      stylelintGenerator.generate(ctx.config),
+      force,
+      ctx.fileManager
+    );
+
+    if (result.status === "skipped") {
+      return { status: "skipped", message: result.reason };
+    }
+    if (result.status === "error") {
+      return { status: "error", message: result.message };
+    }
+
+    // stylelint stops at the first config it finds; ours may come after theirs
+    const shadowing = existing !== null && existing !== configFile ? existing : null;
+    return {
+      status: "ok",
+      message:
+        shadowing !== null
+          ? `${configFile} written — remove ${shadowing} so stylelint uses it`
+          : `${configFile} written`,
+      filesCreated: [configFile],
+    };
+  },
+};
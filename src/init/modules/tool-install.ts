@@ -12,6 +12,8 @@ function formatHints(hints: ReadonlyArray<{ id: string; hint: InstallHint }>): s
         hint.cargo,
         hint.go,
         hint.rustup,
+        hint.gem,
+        hint.composer,
       ].filter((c): c is string => c !== undefined);
       const cmdStr = cmds.length > 0 ? cmds[0] : "(see documentation)";
       return `  ${id}: ${hint.description} — ${cmdStr}`;
@@ -1,7 +1,12 @@
 import { join } from "node:path";
-import { parse as parseToml, stringify as stringifyToml } from "smol-toml";
+import {
+  configFormatFromFlags,
+  configFormatOf,
+  parseConfigText,
+  projectConfigTarget,
+  stringifyConfig,
+} from "@/config/config-file";
 import type { InitContext, InitModule, InitModuleResult } from "@/init/types";
-import { PROJECT_CONFIG_PATH } from "@/models/paths";
 import { isPlainObject } from "@/utils/deep-merge";
 import { getVersion, semverLt } from "@/utils/version";
 
@@ -26,14 +31,20 @@ export const versionPinModule: InitModule = {
   },
 
   async execute(ctx: InitContext): Promise<InitModuleResult> {
-    const dest = join(ctx.projectDir, PROJECT_CONFIG_PATH);
+    const file = await projectConfigTarget(
+      ctx.projectDir,
+      ctx.fileManager,
+      configFormatFromFlags(ctx.flags)
+    );
+    const format = configFormatOf(file);
+    const dest = join(ctx.projectDir, file);
     const desired = resolveMinVersion(ctx.flags);
 
     // Read existing config to check for existing pin and preserve all keys.
     let existing: Record<string, unknown> = {};
     try {
       const text = await ctx.fileManager.readText(dest);
-      const parsed: unknown = parseToml(text);
+      const parsed: unknown = parseConfigText(text, format);
       existing = isPlainObject(parsed) ? parsed : {};
     } catch {
       // File may not exist yet — will be created by profile-selection module
@@ -66,7 +77,8 @@ export const versionPinModule: InitModule = {
     const updated: Record<string, unknown> = { ...existing, min_version: desired };
 
     try {
-      await ctx.fileManager.writeText(dest, stringifyToml(updated));
+      const content = stringifyConfig(updated, format);
+      await ctx.fileManager.writeText(dest, content);
     } catch (e: unknown) {
       const message = e instanceof Error ? e.message : String(e);
       return { status: "error", message: `Failed to write config: ${message}` };
@@ -75,7 +87,7 @@ export const versionPinModule: InitModule = {
     return {
       status: "ok",
       message: `min_version pinned to ${desired}`,
-      filesModified: [PROJECT_CONFIG_PATH],
+      filesModified: [file],
     };
   },
 };
@@ -0,0 +1,51 @@
+import { yamllintGenerator } from "@/generators/yamllint";
+import { writeConfigFile } from "@/init/modules/file-conflict";
+import type { InitContext, InitModule, InitModuleResult } from "@/init/types";
+
+export const yamllintConfigModule: InitModule = {
+  id: "yamllint-config",
+  name: "Yamllint Config",
+  description: "Generate .yamllint.yaml for YAML linting",
+  category: "language-config",
+  defaultEnabled: true,
+  disableFlag: "--no-yamllint",
+  dependsOn: ["config-tuning"],
+
+  async detect(ctx: InitContext): Promise<boolean> {
+    return ctx.languages.some((l) => l.id === "yaml");
+  },
+
+  /** Only `--force` and `--upgrade` write the default; else yamllint uses its own */
+  async execute(ctx: InitContext): Promise<InitModuleResult> {
+    const { configFile } = yamllintGenerator;
+    const force = ctx.flags.force === true;
+    if (!force && ctx.flags.upgrade !== true) {
+      return {
+        status: "skipped",
+        message: `${configFile} is generated only with --force or --upgrade`,
+      };
+    }
+    const content = yamllintGenerator.generate(ctx.config);
+
+    const result = await writeConfigFile(
+      ctx.projectDir,
+      configFile,
+      content,
+      force,
+      ctx.fileManager
+    );
+
+    if (result.status === "skipped") {
+      return { status: "skipped", message: result.reason };
+    }
+    if (result.status === "error") {
+      return { status: "error", message: result.message };
+    }
+
+    return {
+      status: "ok",
+      message: `${configFile} written`,
+      filesCreated: [configFile],
+    };
+  },
+};
@@ -0,0 +1,83 @@
+import { relative } from "node:path";
+import type { DryRunFileManager } from "@/infra/dry-run-file-manager";
+import type { FileManager } from "@/infra/file-manager";
+import type { InitModuleResult } from "@/init/types";
+import { hasHashHeader } from "@/utils/hash";
+import { unifiedDiff } from "@/utils/line-diff";
+
+/**
+ * What init would do to one file: "update" rewrites a file it generated,
+ * "modify" changes one the user owns (a merge, or --force), "remove" deletes
+ * a stale generated file.
+ */
+export type PlannedChange = "create" | "update" | "modify" | "remove" | "unchanged";
+
+export interface PlannedFile {
+  /** Relative to the project root */
+  path: string;
+  change: PlannedChange;
+  /** Unified diff against the file on disk, for "update" and "modify" */
+  diff: string[];
+}
+
+/** A module that left its files alone, with the reason it gave */
+export interface SkippedModule {
+  name: string;
+  result: InitModuleResult;
+}
+
+const NOTES: Record<PlannedChange, string> = {
+  create: "",
+  update: " (generated)",
+  modify: " (user-owned)",
+  remove: " (stale)",
+  unchanged: "",
+};
+
+/** Pad an action to a fixed-width column */
+function col(action: string): string {
+  return action.padEnd(10);
+}
+
+/** Compare the changes a dry run recorded with the files on `disk` */
+export async function planFileChanges(
+  dryRun: DryRunFileManager,
+  disk: FileManager,
+  projectDir: string
+): Promise<PlannedFile[]> {
+  const planned: PlannedFile[] = [];
+  for (const [abs, content] of dryRun.changes) {
+    const path = relative(projectDir, abs);
+    const before = (await disk.exists(abs)) ? await disk.readText(abs) : null;
+    if (content === null) {
+      if (before !== null) planned.push({ path, change: "remove", diff: [] });
+    } else if (before === null) {
+      planned.push({ path, change: "create", diff: [] });
+    } else if (before === content) {
+      planned.push({ path, change: "unchanged", diff: [] });
+    } else {
+      const change = hasHashHeader(before) ? "update" : "modify";
+      planned.push({ path, change, diff: unifiedDiff(before, content, path) });
+    }
+  }
+  return planned;
+}
+
+/**
+ * The dry-run report: one line per file init would touch, then one per
+ * module that would skip its files, then the diffs. One string per line.
+ */
+export function formatInitPlan(
+  files: readonly PlannedFile[],
+  skipped: readonly SkippedModule[]
+): string[] {
+  if (files.length === 0 && skipped.length === 0) {
+    return ["Dry run — nothing was written. init would change no files."];
+  }
+  return [
+    "Dry run — nothing was written. init would:",
+    ...files.map(({ path, change }) => `  ${col(change)} ${path}${NOTES[change]}`),
+    ...skipped.map((s) => `  ${col("skip")} [${s.name}] ${s.result.message}`),
+    ...files.flatMap(({ diff }) => (diff.length > 0 ? ["", ...diff] : [])),
+  ];
+}
@@ -1,7 +1,10 @@
 import { agentRulesModule } from "@/init/modules/agent-rules";
+import { azurePipelinesModule } from "@/init/modules/azure-pipelines";
 import { baselineModule } from "@/init/modules/baseline";
 import { biomeConfigModule } from "@/init/modules/biome-config";
+import { circleciModule } from "@/init/modules/circleci";
 import { claudeSettingsModule } from "@/init/modules/claude-settings";
+import { clippyConfigModule } from "@/init/modules/clippy-config";
 import { codespellConfigModule } from "@/init/modules/codespell-config";
 import { configTuningModule } from "@/init/modules/config-tuning";
 import { editorconfigModule } from "@/init/modules/editorconfig";
@@ -10,16 +13,23 @@ import { githubBranchProtectionModule } from "@/init/modules/github-branch-prote
 import { githubCcReviewerModule } from "@/init/modules/github-cc-reviewer";
 import { githubPrTemplateModule } from "@/init/modules/github-pr-template";
 import { githubProtectedPatternsModule } from "@/init/modules/github-protected-patterns";
+import { gitlabCiModule } from "@/init/modules/gitlab-ci";
+import { golangciConfigModule } from "@/init/modules/golangci-config";
+import { hadolintConfigModule } from "@/init/modules/hadolint-config";
 import { helixOnSaveModule } from "@/init/modules/helix-on-save";
 import { lefthookModule } from "@/init/modules/lefthook";
 import { markdownlintConfigModule } from "@/init/modules/markdownlint-config";
 import { nvimOnSaveModule } from "@/init/modules/nvim-on-save";
 import { profileSelectionModule } from "@/init/modules/profile-selection";
+import { rubocopConfigModule } from "@/init/modules/rubocop-config";
 import { ruffConfigModule } from "@/init/modules/ruff-config";
+import { rustfmtConfigModule } from "@/init/modules/rustfmt-config";
 import { staticcheckConfigModule } from "@/init/modules/staticcheck-config";
+import { stylelintConfigModule } from "@/init/modules/stylelint-config";
 import { toolInstallModule } from "@/init/modules/tool-install";
 import { versionPinModule } from "@/init/modules/version-pin";
 import { vscodeOnSaveModule } from "@/init/modules/vscode-on-save";
+import { yamllintConfigModule } from "@/init/modules/yamllint-config";
 import { zedOnSaveModule } from "@/init/modules/zed-on-save";
 import type { InitModule } from "@/init/types";
 
@@ -50,4 +60,14 @@ export const ALL_INIT_MODULES: readonly InitModule[] = [
   helixOnSaveModule,
   nvimOnSaveModule,
   zedOnSaveModule,
+  golangciConfigModule,
+  rustfmtConfigModule,
+  clippyConfigModule,
+  gitlabCiModule,
+  circleciModule,
+  azurePipelinesModule,
+  hadolintConfigModule,
+  yamllintConfigModule,
+  rubocopConfigModule,
+  stylelintConfigModule,
 ];
@@ -57,10 +57,12 @@ function topoSort(modules: readonly InitModule[]): InitModule[] {
 /**
  * Execute a set of init modules in dependency order.
  * Modules not selected (ctx.selections.get(id) !== true) are skipped.
+ * `onResult` receives the result of each module that ran.
  */
 export async function executeModules(
   modules: readonly InitModule[],
-  ctx: InitContext
+  ctx: InitContext,
+  onResult?: (mod: InitModule, result: InitModuleResult) => void
 ): Promise<InitModuleResult[]> {
   const sorted = topoSort(modules);
   const results: InitModuleResult[] = [];
@@ -107,6 +109,7 @@ export async function executeModules(
       ctx.console.error(`[${mod.name}] ${result.message}`);
     }
 
+    onResult?.(mod, result);
     results.push(result);
   }
 
@@ -1,10 +1,12 @@
 import type { DetectOptions, LanguagePlugin } from "@/languages/types";
-import { clangTidyRunner } from "@/runners/clang-tidy";
+import { clangFormatRunner } from "@/runners/clang-format";
+import { C_CPP_GLOB, clangTidyRunner } from "@/runners/clang-tidy";
 import type { LinterRunner } from "@/runners/types";
 
 export const cppPlugin: LanguagePlugin = {
   id: "cpp",
   name: "C/C++",
+  manifests: ["CMakeLists.txt", "meson.build", "compile_commands.json"],
 
   async detect({
     projectDir,
@@ -12,14 +14,11 @@ export const cppPlugin: LanguagePlugin = {
     ignorePaths,
   }: DetectOptions): Promise<boolean> {
     if (await fileManager.exists(`${projectDir}/CMakeLists.txt`)) return true;
-    const [cppFiles, cFiles] = await Promise.all([
-      fileManager.glob("**/*.cpp", projectDir, ignorePaths),
-      fileManager.glob("**/*.c", projectDir, ignorePaths),
-    ]);
-    return cppFiles.length > 0 || cFiles.length > 0;
+    const files = await fileManager.glob(C_CPP_GLOB, projectDir, ignorePaths);
+    return files.length > 0;
   },
 
   runners(): LinterRunner[] {
-    return [clangTidyRunner];
+    return [clangTidyRunner, clangFormatRunner];
   },
 };
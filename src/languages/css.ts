@@ -0,0 +1,25 @@
+import type { DetectOptions, LanguagePlugin } from "@/languages/types";
+import { STYLE_GLOB, stylelintRunner } from "@/runners/stylelint";
+import type { LinterRunner } from "@/runners/types";
+import { packageUses } from "@/utils/package-json";
+
+export const cssPlugin: LanguagePlugin = {
+  id: "css",
+  name: "CSS/SCSS/Less",
+
+  async detect({
+    projectDir,
+    fileManager,
+    ignorePaths,
+  }: DetectOptions): Promise<boolean> {
+    if (await packageUses(projectDir, fileManager, "stylelint", "stylelint")) {
+      return true;
+    }
+    const files = await fileManager.glob(STYLE_GLOB, projectDir, ignorePaths);
+    return files.length > 0;
+  },
+
+  runners(): LinterRunner[] {
+    return [stylelintRunner];
+  },
+};
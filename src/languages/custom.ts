@@ -0,0 +1,21 @@
+import type { ResolvedConfig } from "@/config/schema";
+import type { LanguagePlugin } from "@/languages/types";
+import { createCustomRunner } from "@/runners/custom";
+
+/**
+ * Plugin holding the project's [[custom_runners]], or null when it defines
+ * none. Always active: each runner itself checks that its files exist.
+ */
+export function customPlugin(config: ResolvedConfig): LanguagePlugin | null {
+  const specs = config.customRunners ?? [];
+  if (specs.length === 0) return null;
+  const runners = specs.map(createCustomRunner);
+  return {
+    id: "custom",
+    name: "Custom",
+    async detect(): Promise<boolean> {
+      return true;
+    },
+    runners: () => runners,
+  };
+}
@@ -0,0 +1,21 @@
+import type { DetectOptions, LanguagePlugin } from "@/languages/types";
+import { DOCKERFILE_GLOB, hadolintRunner } from "@/runners/hadolint";
+import type { LinterRunner } from "@/runners/types";
+
+export const dockerPlugin: LanguagePlugin = {
+  id: "docker",
+  name: "Docker",
+
+  async detect({
+    projectDir,
+    fileManager,
+    ignorePaths,
+  }: DetectOptions): Promise<boolean> {
+    const files = await fileManager.glob(DOCKERFILE_GLOB, projectDir, ignorePaths);
+    return files.length > 0;
+  },
+
+  runners(): LinterRunner[] {
+    return [hadolintRunner];
+  },
+};
@@ -1,24 +1,27 @@
 import type { DetectOptions, LanguagePlugin } from "@/languages/types";
 import { dotnetBuildRunner } from "@/runners/dotnet-build";
+import { dotnetFormatRunner } from "@/runners/dotnet-format";
 import type { LinterRunner } from "@/runners/types";
 
 export const dotnetPlugin: LanguagePlugin = {
   id: "dotnet",
   name: ".NET",
+  manifests: ["**/*.csproj", "**/*.sln"],
 
   async detect({
     projectDir,
     fileManager,
     ignorePaths,
   }: DetectOptions): Promise<boolean> {
-    const [csprojFiles, slnFiles] = await Promise.all([
+    const found = await Promise.all([
       fileManager.glob("**/*.csproj", projectDir, ignorePaths),
       fileManager.glob("**/*.sln", projectDir, ignorePaths),
+      fileManager.glob("**/*.cs", projectDir, ignorePaths),
     ]);
-    return csprojFiles.length > 0 || slnFiles.length > 0;
+    return found.some((files) => files.length > 0);
   },
 
   runners(): LinterRunner[] {
-    return [dotnetBuildRunner];
+    return [dotnetBuildRunner, dotnetFormatRunner];
   },
 };
@@ -0,0 +1,21 @@
+import type { DetectOptions, LanguagePlugin } from "@/languages/types";
+import { actionlintRunner, findWorkflows } from "@/runners/actionlint";
+import type { LinterRunner } from "@/runners/types";
+
+export const githubActionsPlugin: LanguagePlugin = {
+  id: "github-actions",
+  name: "GitHub Actions",
+
+  async detect({
+    projectDir,
+    fileManager,
+    ignorePaths,
+  }: DetectOptions): Promise<boolean> {
+    const files = await findWorkflows(fileManager, projectDir, ignorePaths ?? []);
+    return files.length > 0;
+  },
+
+  runners(): LinterRunner[] {
+    return [actionlintRunner];
+  },
+};
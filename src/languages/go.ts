@@ -1,16 +1,42 @@
 import type { DetectOptions, LanguagePlugin } from "@/languages/types";
+import { errcheckRunner } from "@/runners/errcheck";
+import { goBuildRunner } from "@/runners/go-build";
+import { goCoverageRunner } from "@/runners/go-coverage";
+import { goModTidyRunner } from "@/runners/go-mod-tidy";
+import { gofumptRunner } from "@/runners/gofumpt";
+import { goimportsRunner } from "@/runners/goimports";
 import { golangciLintRunner } from "@/runners/golangci-lint";
+import { gosecRunner } from "@/runners/gosec";
+import { govulncheckRunner } from "@/runners/govulncheck";
+import { ineffassignRunner } from "@/runners/ineffassign";
+import { staticcheckRunner } from "@/runners/staticcheck";
 import type { LinterRunner } from "@/runners/types";
+import { unconvertRunner } from "@/runners/unconvert";
 
 export const goPlugin: LanguagePlugin = {
   id: "go",
   name: "Go",
 
   async detect({ projectDir, fileManager }: DetectOptions): Promise<boolean> {
-    return fileManager.exists(`${projectDir}/go.mod`);
+    if (await fileManager.exists(`${projectDir}/go.mod`)) return true;
+    // A workspace root often has no go.mod of its own
+    return fileManager.exists(`${projectDir}/go.work`);
   },
 
   runners(): LinterRunner[] {
-    return [golangciLintRunner];
+    return [
+      goBuildRunner,
+      golangciLintRunner,
+      staticcheckRunner,
+      govulncheckRunner,
+      gosecRunner,
+      errcheckRunner,
+      ineffassignRunner,
+      unconvertRunner,
+      goModTidyRunner,
+      goimportsRunner,
+      gofumptRunner,
+      goCoverageRunner,
+    ];
   },
 };
@@ -0,0 +1,22 @@
+import type { DetectOptions, LanguagePlugin } from "@/languages/types";
+import { helmLintRunner, helmTemplateRunner } from "@/runners/helm";
+import type { LinterRunner } from "@/runners/types";
+import { findCharts } from "@/utils/helm-charts";
+
+export const helmPlugin: LanguagePlugin = {
+  id: "helm",
+  name: "Helm",
+
+  async detect({
+    projectDir,
+    fileManager,
+    ignorePaths,
+  }: DetectOptions): Promise<boolean> {
+    const charts = await findCharts(fileManager, projectDir, ignorePaths ?? []);
+    return charts.length > 0;
+  },
+
+  runners(): LinterRunner[] {
+    return [helmLintRunner, helmTemplateRunner];
+  },
+};
@@ -0,0 +1,23 @@
+import type { DetectOptions, LanguagePlugin } from "@/languages/types";
+import { KOTLIN_GLOB, ktlintRunner } from "@/runners/ktlint";
+import type { LinterRunner } from "@/runners/types";
+
+export const kotlinPlugin: LanguagePlugin = {
+  id: "kotlin",
+  name: "Kotlin",
+  manifests: ["build.gradle.kts", "settings.gradle.kts"],
+
+  async detect({
+    projectDir,
+    fileManager,
+    ignorePaths,
+  }: DetectOptions): Promise<boolean> {
+    if (await fileManager.exists(`${projectDir}/build.gradle.kts`)) return true;
+    const sources = await fileManager.glob(KOTLIN_GLOB, projectDir, ignorePaths);
+    return sources.length > 0;
+  },
+
+  runners(): LinterRunner[] {
+    return [ktlintRunner];
+  },
+};
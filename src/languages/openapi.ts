@@ -0,0 +1,21 @@
+import type { DetectOptions, LanguagePlugin } from "@/languages/types";
+import { findApiSpecs, spectralRunner } from "@/runners/spectral";
+import type { LinterRunner } from "@/runners/types";
+
+export const openapiPlugin: LanguagePlugin = {
+  id: "openapi",
+  name: "OpenAPI",
+
+  async detect({
+    projectDir,
+    fileManager,
+    ignorePaths,
+  }: DetectOptions): Promise<boolean> {
+    const specs = await findApiSpecs(fileManager, projectDir, ignorePaths ?? []);
+    return specs.length > 0;
+  },
+
+  runners(): LinterRunner[] {
+    return [spectralRunner];
+  },
+};
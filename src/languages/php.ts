@@ -0,0 +1,24 @@
+import type { DetectOptions, LanguagePlugin } from "@/languages/types";
+import { PHP_GLOB, phpcsRunner } from "@/runners/phpcs";
+import { phpstanRunner } from "@/runners/phpstan";
+import type { LinterRunner } from "@/runners/types";
+
+export const phpPlugin: LanguagePlugin = {
+  id: "php",
+  name: "PHP",
+  manifests: ["composer.json"],
+
+  async detect({
+    projectDir,
+    fileManager,
+    ignorePaths,
+  }: DetectOptions): Promise<boolean> {
+    if (await fileManager.exists(`${projectDir}/composer.json`)) return true;
+    const sources = await fileManager.glob(PHP_GLOB, projectDir, ignorePaths);
+    return sources.length > 0;
+  },
+
+  runners(): LinterRunner[] {
+    return [phpcsRunner, phpstanRunner];
+  },
+};
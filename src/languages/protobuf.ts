@@ -0,0 +1,27 @@
+import type { DetectOptions, LanguagePlugin } from "@/languages/types";
+import {
+  bufBreakingRunner,
+  bufRunner,
+  findProtoFiles,
+  hasBufConfig,
+} from "@/runners/buf";
+import type { LinterRunner } from "@/runners/types";
+
+export const protobufPlugin: LanguagePlugin = {
+  id: "protobuf",
+  name: "Protobuf",
+
+  async detect({
+    projectDir,
+    fileManager,
+    ignorePaths,
+  }: DetectOptions): Promise<boolean> {
+    if (await hasBufConfig(fileManager, projectDir)) return true;
+    const files = await findProtoFiles(fileManager, projectDir, ignorePaths ?? []);
+    return files.length > 0;
+  },
+
+  runners(): LinterRunner[] {
+    return [bufRunner, bufBreakingRunner];
+  },
+};
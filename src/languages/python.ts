@@ -1,4 +1,5 @@
 import type { DetectOptions, LanguagePlugin } from "@/languages/types";
+import { isortRunner } from "@/runners/isort";
 import { pyrightRunner } from "@/runners/pyright";
 import { ruffRunner } from "@/runners/ruff";
 import type { LinterRunner } from "@/runners/types";
@@ -6,6 +7,13 @@ import type { LinterRunner } from "@/runners/types";
 export const pythonPlugin: LanguagePlugin = {
   id: "python",
   name: "Python",
+  manifests: [
+    "pyproject.toml",
+    "setup.py",
+    "setup.cfg",
+    "requirements*.txt",
+    "Pipfile",
+  ],
 
   async detect({
     projectDir,
@@ -18,6 +26,6 @@ export const pythonPlugin: LanguagePlugin = {
   },
 
   runners(): LinterRunner[] {
-    return [ruffRunner, pyrightRunner];
+    return [ruffRunner, isortRunner, pyrightRunner];
   },
 };
@@ -1,15 +1,33 @@
+import { relative } from "node:path";
+import { minimatch } from "minimatch";
+import type { ResolvedConfig } from "@/config/schema";
 import type { FileManager } from "@/infra/file-manager";
+import { RecordingFileManager } from "@/infra/recording-file-manager";
 import { DEFAULT_IGNORE } from "@/languages/constants";
 import { cppPlugin } from "@/languages/cpp";
+import { cssPlugin } from "@/languages/css";
+import { customPlugin } from "@/languages/custom";
+import { dockerPlugin } from "@/languages/docker";
 import { dotnetPlugin } from "@/languages/dotnet";
+import { githubActionsPlugin } from "@/languages/github-actions";
 import { goPlugin } from "@/languages/go";
+import { helmPlugin } from "@/languages/helm";
+import { kotlinPlugin } from "@/languages/kotlin";
 import { luaPlugin } from "@/languages/lua";
+import { openapiPlugin } from "@/languages/openapi";
+import { phpPlugin } from "@/languages/php";
+import { protobufPlugin } from "@/languages/protobuf";
 import { pythonPlugin } from "@/languages/python";
+import { rubyPlugin } from "@/languages/ruby";
 import { rustPlugin } from "@/languages/rust";
 import { shellPlugin } from "@/languages/shell";
+import { sqlPlugin } from "@/languages/sql";
+import { swiftPlugin } from "@/languages/swift";
+import { terraformPlugin } from "@/languages/terraform";
 import type { LanguagePlugin } from "@/languages/types";
 import { typescriptPlugin } from "@/languages/typescript";
 import { universalPlugin } from "@/languages/universal";
+import { yamlPlugin } from "@/languages/yaml";
 
 /** All built-in language plugins, in detection priority order. Universal is always last. */
 export const ALL_PLUGINS: readonly LanguagePlugin[] = [
@@ -21,9 +39,201 @@ export const ALL_PLUGINS: readonly LanguagePlugin[] = [
   cppPlugin,
   dotnetPlugin,
   luaPlugin,
+  dockerPlugin,
+  yamlPlugin,
+  githubActionsPlugin,
+  terraformPlugin,
+  rubyPlugin,
+  swiftPlugin,
+  kotlinPlugin,
+  phpPlugin,
+  cssPlugin,
+  sqlPlugin,
+  protobufPlugin,
+  helmPlugin,
+  openapiPlugin,
   universalPlugin,
 ];
 
+/** Ids of every runner of every built-in plugin, plus the config's custom runners */
+export function knownRunnerIds(config?: ResolvedConfig): ReadonlySet<string> {
+  const custom = (config?.customRunners ?? []).map((spec) => spec.id);
+  return new Set([
+    ...ALL_PLUGINS.flatMap((plugin) => plugin.runners().map((r) => r.id)),
+    ...custom,
+  ]);
+}
+
+/** Explain why custom runners cannot be used, or null when they can */
+export function validateCustomRunners(
+  specs: readonly { id: string }[]
+): string | null {
+  const builtIn = knownRunnerIds();
+  const clashes = specs
+    .map((spec) => spec.id)
+    .filter((id) => builtIn.has(id));
+  if (clashes.length === 0) return null;
+  return `Custom runner id(s) already used by built-in runners: ${clashes.join(", ")}`;
+}
+
+/** Append the plugin of the config's [[custom_runners]], if it defines any */
+export function withCustomRunners(
+  languages: readonly LanguagePlugin[],
+  config: ResolvedConfig
+): LanguagePlugin[] {
+  const custom = customPlugin(config);
+  return custom === null ? [...languages] : [...languages, custom];
+}
+
+/** Explain why --enable/--disable cannot be applied, or null when they can */
+export function validateRunnerOverrides(
+  enable: readonly string[],
+  disable: readonly string[],
+  config?: ResolvedConfig
+): string | null {
+  const known = knownRunnerIds(config);
+  const unknown = [...enable, ...disable].filter((id) => !known.has(id));
+  if (unknown.length > 0) {
+    return `Unknown runner(s) in --enable/--disable: ${unknown.join(", ")}`;
+  }
+  const both = enable.filter((id) => disable.includes(id));
+  if (both.length > 0) {
+    return `Runner(s) both enabled and disabled: ${both.join(", ")}`;
+  }
+  return null;
+}
+
+/**
+ * Add the runners in `runnerIds` that no detected plugin provides, each through
+ * its undetected plugin restricted to just those runners. This lets
+ * `check --enable` turn on a runner whose language was not detected.
+ */
+export function withEnabledRunners(
+  detected: readonly LanguagePlugin[],
+  runnerIds: readonly string[]
+): LanguagePlugin[] {
+  const missing = new Set(runnerIds);
+  for (const plugin of detected) {
+    for (const runner of plugin.runners()) missing.delete(runner.id);
+  }
+
+  const extra: LanguagePlugin[] = [];
+  for (const plugin of ALL_PLUGINS) {
+    const runners = plugin.runners().filter((r) => missing.has(r.id));
+    if (runners.length === 0) continue;
+    for (const runner of runners) missing.delete(runner.id);
+    extra.push({ ...plugin, runners: () => runners });
+  }
+  return [...detected, ...extra];
+}
+
+/** Explain why --only cannot be applied, or null when it can */
+export function validateOnlyRunners(
+  only: readonly string[],
+  config?: ResolvedConfig,
+  flag = "--only"
+): string | null {
+  const known = knownRunnerIds(config);
+  const unknown = only.filter((id) => !known.has(id));
+  if (unknown.length === 0) return null;
+  return `Unknown runner(s) in ${flag}: ${unknown.join(", ")}`;
+}
+
+/**
+ * Exactly the runners in `runnerIds`, each through its plugin — built-in or
+ * the config's custom one — whether or not its language was detected. This is
+ * `check --only`.
+ */
+export function onlyRunners(
+  runnerIds: readonly string[],
+  config: ResolvedConfig
+): LanguagePlugin[] {
+  const wanted = new Set(runnerIds);
+  return withCustomRunners(ALL_PLUGINS, config).flatMap((plugin) => {
+    const runners = plugin.runners().filter((r) => wanted.has(r.id));
+    return runners.length > 0 ? [{ ...plugin, runners: () => runners }] : [];
+  });
+}
+
+export interface DetectedLanguage {
+  plugin: LanguagePlugin;
+  /** Project-relative files the plugin found while detecting, e.g. go.mod */
+  evidence: string[];
+}
+
+/**
+ * Detect which languages are present in the project, with the files each
+ * was detected from. Returns active plugins in priority order.
+ */
+export async function detectLanguagesWithEvidence(
+  projectDir: string,
+  fileManager: FileManager,
+  ignorePaths?: readonly string[]
+): Promise<DetectedLanguage[]> {
+  const mergedIgnore: readonly string[] = [...DEFAULT_IGNORE, ...(ignorePaths ?? [])];
+  const results = await Promise.all(
+    ALL_PLUGINS.map(async (plugin) => {
+      const recorder = new RecordingFileManager(fileManager);
+      const active = await plugin.detect({
+        projectDir,
+        fileManager: recorder,
+        ignorePaths: mergedIgnore,
+      });
+      const evidence = [
+        ...new Set(recorder.found.map((path) => relative(projectDir, path))),
+      ];
+      return { plugin, active, evidence };
+    })
+  );
+  return results
+    .filter((r) => r.active)
+    .map(({ plugin, evidence }) => ({ plugin, evidence }));
+}
+
+export interface StrictDetections {
+  /** Languages with a manifest, or any evidence for a plugin without manifests */
+  confirmed: DetectedLanguage[];
+  /** Languages detected only from loose source files, e.g. a stray .py */
+  ambiguous: DetectedLanguage[];
+}
+
+/**
+ * Split detections for `--strict-detection`. A file under an ignored directory
+ * is never evidence, so a language found only there is dropped; a plugin that
+ * declares manifests is confirmed only by one of them existing.
+ */
+export async function strictDetections(
+  detected: readonly DetectedLanguage[],
+  projectDir: string,
+  fileManager: FileManager,
+  ignorePaths?: readonly string[]
+): Promise<StrictDetections> {
+  const mergedIgnore: readonly string[] = [...DEFAULT_IGNORE, ...(ignorePaths ?? [])];
+  const matches = (file: string, globs: readonly string[]) =>
+    globs.some((glob) => minimatch(file, glob, { dot: true }));
+  const split: StrictDetections = { confirmed: [], ambiguous: [] };
+  for (const { plugin, evidence: found } of detected) {
+    const evidence = found.filter((file) => !matches(file, mergedIgnore));
+    if (found.length > 0 && evidence.length === 0) continue;
+    const { manifests } = plugin;
+    if (manifests === undefined || evidence.some((f) => matches(f, manifests))) {
+      split.confirmed.push({ plugin, evidence });
+      continue;
+    }
+    // detect() stops at its first hit, so look for the other manifests too
+    const globbed = await Promise.all(
+      manifests.map((glob) => fileManager.glob(glob, projectDir, mergedIgnore))
+    );
+    const manifestFiles = [...new Set(globbed.flat())];
+    if (manifestFiles.length > 0) {
+      split.confirmed.push({ plugin, evidence: [...manifestFiles, ...evidence] });
+    } else {
+      split.ambiguous.push({ plugin, evidence });
+    }
+  }
+  return split;
+}
+
 /**
  * Detect which languages are present in the project.
  * Returns active plugins in priority order.
@@ -34,16 +244,10 @@ export async function detectLanguages(
   fileManager: FileManager,
   ignorePaths?: readonly string[]
 ): Promise<LanguagePlugin[]> {
-  const mergedIgnore: readonly string[] = [...DEFAULT_IGNORE, ...(ignorePaths ?? [])];
-  const results = await Promise.all(
-    ALL_PLUGINS.map(async (plugin) => ({
-      plugin,
-      active: await plugin.detect({
-        projectDir,
-        fileManager,
-        ignorePaths: mergedIgnore,
-      }),
-    }))
+  const detected = await detectLanguagesWithEvidence(
+    projectDir,
+    fileManager,
+    ignorePaths
   );
-  return results.filter((r) => r.active).map((r) => r.plugin);
+  return detected.map((d) => d.plugin);
 }
@@ -0,0 +1,25 @@
+import type { DetectOptions, LanguagePlugin } from "@/languages/types";
+import { RUBY_GLOB, rubocopRunner } from "@/runners/rubocop";
+import type { LinterRunner } from "@/runners/types";
+
+export const rubyPlugin: LanguagePlugin = {
+  id: "ruby",
+  name: "Ruby",
+  manifests: ["Gemfile", "*.gemspec"],
+
+  async detect({
+    projectDir,
+    fileManager,
+    ignorePaths,
+  }: DetectOptions): Promise<boolean> {
+    for (const marker of ["Gemfile", ".ruby-version"]) {
+      if (await fileManager.exists(`${projectDir}/${marker}`)) return true;
+    }
+    const files = await fileManager.glob(RUBY_GLOB, projectDir, ignorePaths);
+    return files.length > 0;
+  },
+
+  runners(): LinterRunner[] {
+    return [rubocopRunner];
+  },
+};
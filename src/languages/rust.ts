@@ -1,5 +1,6 @@
 import type { DetectOptions, LanguagePlugin } from "@/languages/types";
 import { clippyRunner } from "@/runners/clippy";
+import { rustfmtRunner } from "@/runners/rustfmt";
 import type { LinterRunner } from "@/runners/types";
 
 export const rustPlugin: LanguagePlugin = {
@@ -7,10 +8,11 @@ export const rustPlugin: LanguagePlugin = {
   name: "Rust",
 
   async detect({ projectDir, fileManager }: DetectOptions): Promise<boolean> {
-    return fileManager.exists(`${projectDir}/Cargo.toml`);
+    if (await fileManager.exists(`${projectDir}/Cargo.toml`)) return true;
+    return fileManager.exists(`${projectDir}/Cargo.lock`);
   },
 
   runners(): LinterRunner[] {
-    return [clippyRunner];
+    return [clippyRunner, rustfmtRunner];
   },
 };
@@ -2,6 +2,7 @@ import type { DetectOptions, LanguagePlugin } from "@/languages/types";
 import { shellcheckRunner } from "@/runners/shellcheck";
 import { shfmtRunner } from "@/runners/shfmt";
 import type { LinterRunner } from "@/runners/types";
+import { findShebangScripts, SHEBANG_SHELLS } from "@/utils/shebang";
 
 export const shellPlugin: LanguagePlugin = {
   id: "shell",
@@ -17,7 +18,12 @@ export const shellPlugin: LanguagePlugin = {
       projectDir,
       ignorePaths
     );
-    return files.length > 0;
+    if (files.length > 0) return true;
+    // Detection runs before config loads, so only the default shells count here
+    const scripts = await findShebangScripts(fileManager, projectDir, SHEBANG_SHELLS, {
+      ...(ignorePaths !== undefined && { ignore: ignorePaths }),
+    });
+    return scripts.length > 0;
   },
 
   runners(): LinterRunner[] {
@@ -0,0 +1,21 @@
+import type { DetectOptions, LanguagePlugin } from "@/languages/types";
+import { findSqlFiles, sqlfluffRunner } from "@/runners/sqlfluff";
+import type { LinterRunner } from "@/runners/types";
+
+export const sqlPlugin: LanguagePlugin = {
+  id: "sql",
+  name: "SQL",
+
+  async detect({
+    projectDir,
+    fileManager,
+    ignorePaths,
+  }: DetectOptions): Promise<boolean> {
+    const files = await findSqlFiles(fileManager, projectDir, ignorePaths ?? []);
+    return files.length > 0;
+  },
+
+  runners(): LinterRunner[] {
+    return [sqlfluffRunner];
+  },
+};
@@ -0,0 +1,27 @@
+import type { DetectOptions, LanguagePlugin } from "@/languages/types";
+import { SWIFT_GLOB, swiftlintRunner } from "@/runners/swiftlint";
+import type { LinterRunner } from "@/runners/types";
+
+export const swiftPlugin: LanguagePlugin = {
+  id: "swift",
+  name: "Swift",
+  manifests: ["Package.swift", "**/*.xcodeproj/project.pbxproj"],
+
+  async detect({
+    projectDir,
+    fileManager,
+    ignorePaths,
+  }: DetectOptions): Promise<boolean> {
+    if (await fileManager.exists(`${projectDir}/Package.swift`)) return true;
+    // An .xcodeproj is a directory; its project.pbxproj is the file to find
+    const [projects, sources] = await Promise.all([
+      fileManager.glob("**/*.xcodeproj/project.pbxproj", projectDir, ignorePaths),
+      fileManager.glob(SWIFT_GLOB, projectDir, ignorePaths),
+    ]);
+    return projects.length > 0 || sources.length > 0;
+  },
+
+  runners(): LinterRunner[] {
+    return [swiftlintRunner];
+  },
+};
@@ -0,0 +1,23 @@
+import type { DetectOptions, LanguagePlugin } from "@/languages/types";
+import { terraformFmtRunner } from "@/runners/terraform-fmt";
+import { tflintRunner } from "@/runners/tflint";
+import type { LinterRunner } from "@/runners/types";
+import { TERRAFORM_GLOB } from "@/utils/terraform-dirs";
+
+export const terraformPlugin: LanguagePlugin = {
+  id: "terraform",
+  name: "Terraform",
+
+  async detect({
+    projectDir,
+    fileManager,
+    ignorePaths,
+  }: DetectOptions): Promise<boolean> {
+    const files = await fileManager.glob(TERRAFORM_GLOB, projectDir, ignorePaths);
+    return files.length > 0;
+  },
+
+  runners(): LinterRunner[] {
+    return [terraformFmtRunner, tflintRunner];
+  },
+};
@@ -12,6 +12,13 @@ export interface LanguagePlugin {
   readonly id: string;
   /** Human-readable name */
   readonly name: string;
+  /**
+   * Project-relative globs of the build or package files that prove the
+   * language on their own, e.g. pyproject.toml. Detected only from other files,
+   * it counts as ambiguous under `--strict-detection`; absent, any evidence is
+   * enough.
+   */
+  readonly manifests?: readonly string[];
   /** Return true if this language is present in the project */
   detect(opts: DetectOptions): Promise<boolean>;
   /** Runners to use when this language is active */
@@ -1,11 +1,14 @@
 import type { DetectOptions, LanguagePlugin } from "@/languages/types";
 import { biomeRunner } from "@/runners/biome";
+import { eslintRunner } from "@/runners/eslint";
+import { prettierRunner } from "@/runners/prettier";
 import { tscRunner } from "@/runners/tsc";
 import type { LinterRunner } from "@/runners/types";
 
 export const typescriptPlugin: LanguagePlugin = {
   id: "typescript",
   name: "TypeScript/JS",
+  manifests: ["package.json", "tsconfig.json", "deno.json", "deno.jsonc"],
 
   async detect({
     projectDir,
@@ -21,6 +24,6 @@ export const typescriptPlugin: LanguagePlugin = {
   },
 
   runners(): LinterRunner[] {
-    return [biomeRunner, tscRunner];
+    return [biomeRunner, eslintRunner, prettierRunner, tscRunner];
   },
 };
@@ -1,7 +1,12 @@
 import type { DetectOptions, LanguagePlugin } from "@/languages/types";
 import { codespellRunner } from "@/runners/codespell";
+import { fileHygieneRunner } from "@/runners/file-hygiene";
+import { licenseHeaderRunner } from "@/runners/license-header";
+import { lockfileSyncRunner } from "@/runners/lockfile-sync";
+import { markdownLinksRunner } from "@/runners/markdown-links";
 import { markdownlintRunner } from "@/runners/markdownlint";
 import type { LinterRunner } from "@/runners/types";
+import { valeRunner } from "@/runners/vale";
 
 export const universalPlugin: LanguagePlugin = {
   id: "universal",
@@ -13,6 +18,14 @@ export const universalPlugin: LanguagePlugin = {
   },
 
   runners(): LinterRunner[] {
-    return [codespellRunner, markdownlintRunner];
+    return [
+      codespellRunner,
+      markdownlintRunner,
+      markdownLinksRunner,
+      valeRunner,
+      licenseHeaderRunner,
+      lockfileSyncRunner,
+      fileHygieneRunner,
+    ];
   },
 };
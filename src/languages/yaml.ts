@@ -0,0 +1,21 @@
+import type { DetectOptions, LanguagePlugin } from "@/languages/types";
+import type { LinterRunner } from "@/runners/types";
+import { findYamlFiles, yamllintRunner } from "@/runners/yamllint";
+
+export const yamlPlugin: LanguagePlugin = {
+  id: "yaml",
+  name: "YAML",
+
+  async detect({
+    projectDir,
+    fileManager,
+    ignorePaths,
+  }: DetectOptions): Promise<boolean> {
+    const files = await findYamlFiles(fileManager, projectDir, ignorePaths ?? []);
+    return files.length > 0;
+  },
+
+  runners(): LinterRunner[] {
+    return [yamllintRunner];
+  },
+};
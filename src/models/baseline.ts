@@ -46,13 +46,15 @@ export function classifyFingerprint(
 /**
  * Load a baseline from a file on disk. Returns null if the file doesn't exist
  * or contains invalid data. Uses Zod for safe parsing at the file boundary.
+ * `baselinePath` is relative to projectDir (default: BASELINE_PATH).
  */
 export async function loadBaselineFromFile(
   projectDir: string,
-  fileManager: { readText(path: string): Promise<string> }
+  fileManager: { readText(path: string): Promise<string> },
+  baselinePath: string = BASELINE_PATH
 ): Promise<ReadonlyMap<string, BaselineEntry> | null> {
   try {
-    const text = await fileManager.readText(join(projectDir, BASELINE_PATH));
+    const text = await fileManager.readText(join(projectDir, baselinePath));
     const parsed: unknown = JSON.parse(text);
     const entries = z.array(BaselineEntrySchema).parse(parsed);
     return loadBaseline(entries);
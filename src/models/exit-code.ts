@@ -0,0 +1,38 @@
+import type { RunnerReport } from "@/models/runner-report";
+
+/** Every runner ran and no new finding reaches the fail-on level */
+export const EXIT_CLEAN = 0;
+/** New findings at or above the fail-on level */
+export const EXIT_FINDINGS = 1;
+/** A runner errored or timed out, or the run itself broke: results are incomplete */
+export const EXIT_RUNNER_ERROR = 2;
+/** Bad flags or config: nothing was checked */
+export const EXIT_USAGE = 3;
+/** A tool `--require`/`--require-all` names is not installed */
+export const EXIT_MISSING_TOOL = 4;
+
+/**
+ * How `check` exits — a stable contract scripts and CI can branch on, also
+ * `CheckReport.exitCode` in the API.
+ */
+export type ExitCode =
+  | typeof EXIT_CLEAN
+  | typeof EXIT_FINDINGS
+  | typeof EXIT_RUNNER_ERROR
+  | typeof EXIT_USAGE
+  | typeof EXIT_MISSING_TOOL;
+
+/**
+ * The exit code of a check that ran. A broken run outranks its findings, which
+ * it may have cut short: a missing required tool first, then a runner error.
+ * Runners `--fail-fast` cancelled count for nothing; what stopped them does.
+ */
+export function checkExitCode(
+  failingIssueCount: number,
+  runners: readonly RunnerReport[]
+): ExitCode {
+  const failed = runners.filter((r) => r.status === "error");
+  if (failed.some((r) => r.missing === true)) return EXIT_MISSING_TOOL;
+  if (failed.length > 0) return EXIT_RUNNER_ERROR;
+  return failingIssueCount > 0 ? EXIT_FINDINGS : EXIT_CLEAN;
+}
@@ -1,5 +1,14 @@
 import { createHash } from "node:crypto";
 
+/** Finding severities, most severe first */
+export const SEVERITIES = ["error", "warning", "info"] as const;
+export type Severity = (typeof SEVERITIES)[number];
+
+/** True when `severity` is at least as severe as `threshold` */
+export function meetsSeverity(severity: Severity, threshold: Severity): boolean {
+  return SEVERITIES.indexOf(severity) <= SEVERITIES.indexOf(threshold);
+}
+
 export interface LintIssue {
   readonly rule: string; // "E501", "no-unused-vars", "S1481"
   readonly linter: string; // "ruff", "pyright", "biome"
@@ -7,8 +16,10 @@ export interface LintIssue {
   readonly line: number; // 1-indexed
   readonly col: number; // 1-indexed
   readonly message: string;
-  readonly severity: "error" | "warning";
+  readonly severity: Severity;
   readonly fingerprint: string; // content-stable SHA-256
+  readonly module?: string; // Go module dir, project-relative ("." = root)
+  readonly reportedBy?: readonly string[]; // runners behind a deduped finding
 }
 
 export interface FingerprintOpts {
@@ -0,0 +1,106 @@
+import { dirname } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
+import type { LintIssue, Severity } from "@/models/lint-issue";
+import type { RunnerReport, RunnerStatus } from "@/models/runner-report";
+
+/**
+ * Bumped only when a field is removed, renamed or changes meaning; new fields
+ * may be added without a bump, so readers should ignore ones they don't know.
+ */
+export const METRICS_SCHEMA_VERSION = 1;
+
+export type SeverityCounts = Readonly<Record<Severity, number>>;
+
+export interface RunnerMetrics {
+  /** LinterRunner.id */
+  readonly id: string;
+  readonly status: RunnerStatus;
+  readonly durationMs: number;
+  /** Served from the result cache instead of running */
+  readonly cached: boolean;
+  /** Every finding, baselined ones included */
+  readonly findings: SeverityCounts;
+  /** Findings the baseline does not cover */
+  readonly newFindings: SeverityCounts;
+}
+
+/** One line of the `check --metrics` file */
+export interface MetricsRecord {
+  readonly schemaVersion: typeof METRICS_SCHEMA_VERSION;
+  readonly timestamp: string; // ISO 8601
+  /** HEAD commit, or null outside a git repository */
+  readonly commit: string | null;
+  readonly durationMs: number;
+  /** Files the check covered: the changed files on a partial run */
+  readonly filesScanned: number;
+  /** False when the check failed, on findings or a runner error */
+  readonly passed: boolean;
+  readonly findings: SeverityCounts;
+  readonly newFindings: SeverityCounts;
+  /** One entry per enabled runner, in report order */
+  readonly runners: readonly RunnerMetrics[];
+}
+
+export interface MetricsInput {
+  readonly timestamp: string;
+  readonly commit: string | null;
+  readonly durationMs: number;
+  readonly filesScanned: number;
+  readonly passed: boolean;
+  readonly issues: readonly LintIssue[];
+  readonly baselined: ReadonlySet<string>;
+  readonly runners: readonly RunnerReport[];
+}
+
+function countBySeverity(issues: readonly LintIssue[]): SeverityCounts {
+  const counts: Record<Severity, number> = { error: 0, warning: 0, info: 0 };
+  for (const issue of issues) counts[issue.severity]++;
+  return counts;
+}
+
+export function buildMetricsRecord(input: MetricsInput): MetricsRecord {
+  const fresh = input.issues.filter((i) => !input.baselined.has(i.fingerprint));
+  const byRunner = (issues: readonly LintIssue[], id: string) =>
+    countBySeverity(issues.filter((issue) => issue.linter === id));
+  return {
+    schemaVersion: METRICS_SCHEMA_VERSION,
+    timestamp: input.timestamp,
+    commit: input.commit,
+    durationMs: input.durationMs,
+    filesScanned: input.filesScanned,
+    passed: input.passed,
+    findings: countBySeverity(input.issues),
+    newFindings: countBySeverity(fresh),
+    runners: input.runners.map((runner) => ({
+      id: runner.runnerId,
+      status: runner.status,
+      durationMs: runner.durationMs,
+      cached: runner.cached === true,
+      findings: byRunner(input.issues, runner.runnerId),
+      newFindings: byRunner(fresh, runner.runnerId),
+    })),
+  };
+}
+
+/** The HEAD commit hash, or null when git fails (e.g. outside a repository) */
+export async function readHeadCommit(
+  projectDir: string,
+  commandRunner: CommandRunner
+): Promise<string | null> {
+  const result = await commandRunner.run(["git", "rev-parse", "HEAD"], {
+    cwd: projectDir,
+  });
+  const commit = result.stdout.trim();
+  return result.exitCode === 0 && commit !== "" ? commit : null;
+}
+
+/** Append `record` as one JSON line, creating the file and its directory */
+export async function appendMetricsRecord(
+  path: string,
+  record: MetricsRecord,
+  fileManager: FileManager
+): Promise<void> {
+  await fileManager.mkdir(dirname(path), { parents: true });
+  await fileManager.appendText(path, `${JSON.stringify(record)}\n`);
+}
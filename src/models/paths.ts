@@ -2,3 +2,10 @@
 export const BASELINE_PATH = ".ai-guardrails/baseline.json";
 export const AUDIT_PATH = ".ai-guardrails/audit.jsonl";
 export const PROJECT_CONFIG_PATH = ".ai-guardrails/config.toml";
+/** The same config written as YAML; a project has one or the other */
+export const PROJECT_CONFIG_YAML_PATH = ".ai-guardrails/config.yaml";
+export const CACHE_DIR = ".ai-guardrails/cache";
+/** Where `check --stdin` writes the piped buffer, mirroring its project path */
+export const STDIN_DIR = ".ai-guardrails/cache/stdin";
+/** Where `check --staged` writes the staged content of partially staged files */
+export const STAGED_DIR = ".ai-guardrails/cache/staged";
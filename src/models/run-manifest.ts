@@ -0,0 +1,147 @@
+import { join } from "node:path";
+import { z } from "zod";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
+import { DEFAULT_IGNORE } from "@/languages/constants";
+import { CACHE_DIR } from "@/models/paths";
+import type { LinterRunner } from "@/runners/types";
+import { computeHash } from "@/utils/hash";
+import type { PathMatcher } from "@/utils/ignore-file";
+import { listProjectFiles } from "@/utils/project-files";
+
+/**
+ * What `check --since-last-run` compares against: every project file as of
+ * the last such run. `--clear-cache` deletes it, so the next run is full.
+ */
+export const RUN_MANIFEST_PATH = `${CACHE_DIR}/manifest.json`;
+
+const ManifestEntrySchema = z.object({
+  mtimeMs: z.number(),
+  size: z.number(),
+  hash: z.string(),
+});
+
+const RunManifestSchema = z.object({
+  key: z.string(),
+  files: z.record(ManifestEntrySchema),
+});
+
+export type ManifestEntry = z.infer<typeof ManifestEntrySchema>;
+
+export interface RunManifest {
+  /** Tool versions, runner set, configs and baseline the files were checked with */
+  readonly key: string;
+  /** Project-relative path → what the file looked like */
+  readonly files: Readonly<Record<string, ManifestEntry>>;
+}
+
+async function readOrEmpty(
+  fileManager: FileManager,
+  path: string
+): Promise<string> {
+  try {
+    return await fileManager.readText(path);
+  } catch {
+    return "";
+  }
+}
+
+/** The stored manifest, or null when there is none or it does not parse */
+export async function loadRunManifest(
+  projectDir: string,
+  fileManager: FileManager
+): Promise<RunManifest | null> {
+  try {
+    const text = await fileManager.readText(join(projectDir, RUN_MANIFEST_PATH));
+    return RunManifestSchema.parse(JSON.parse(text));
+  } catch {
+    return null;
+  }
+}
+
+export async function saveRunManifest(
+  projectDir: string,
+  manifest: RunManifest,
+  fileManager: FileManager
+): Promise<void> {
+  await fileManager.mkdir(join(projectDir, CACHE_DIR), { parents: true });
+  const path = join(projectDir, RUN_MANIFEST_PATH);
+  await fileManager.writeText(path, JSON.stringify(manifest));
+}
+
+/**
+ * Hash what a file list from an earlier run is only valid under: the runners
+ * and their tool versions, every ai-guardrails config (nested ones too), each
+ * runner's config file and the baseline. Any change means a full run.
+ */
+export async function computeManifestKey(
+  runners: readonly LinterRunner[],
+  projectDir: string,
+  baselinePath: string,
+  commandRunner: CommandRunner,
+  fileManager: FileManager
+): Promise<string> {
+  const parts: string[] = [];
+  for (const runner of [...runners].sort((a, b) => a.id.localeCompare(b.id))) {
+    const version =
+      runner.versionArgs !== undefined
+        ? await commandRunner.run([...runner.versionArgs], { cwd: projectDir })
+        : undefined;
+    const installed = version !== undefined && version.exitCode === 0;
+    parts.push(`${runner.id}:${installed ? version.stdout.trim() : ""}`);
+  }
+  const configs = await fileManager.glob(
+    "**/.ai-guardrails/config.{toml,yaml}",
+    projectDir,
+    DEFAULT_IGNORE
+  );
+  const runnerConfigs = runners.flatMap((r) =>
+    r.configFile !== null ? [r.configFile] : []
+  );
+  const files = [...new Set([...configs, ...runnerConfigs, baselinePath])].sort();
+  for (const file of files) {
+    const content = await readOrEmpty(fileManager, join(projectDir, file));
+    parts.push(`${file}:${computeHash(content)}`);
+  }
+  return computeHash(parts.join("\n"));
+}
+
+/**
+ * Record every project file (see listProjectFiles). A file whose mtime and
+ * size match `previous` keeps its stored hash; the rest are read and hashed.
+ */
+export async function scanProjectFiles(
+  projectDir: string,
+  previous: RunManifest | null,
+  ignorePaths: readonly string[],
+  ignore: PathMatcher | null,
+  fileManager: FileManager
+): Promise<Record<string, ManifestEntry>> {
+  const found = await listProjectFiles(projectDir, ignorePaths, ignore, fileManager);
+  const files: Record<string, ManifestEntry> = {};
+  for (const file of found) {
+    const path = join(projectDir, file);
+    const stat = await fileManager.stat(path);
+    if (stat === null) continue;
+    const before = previous?.files[file];
+    const unchanged =
+      before !== undefined &&
+      before.mtimeMs === stat.mtimeMs &&
+      before.size === stat.size;
+    const hash = unchanged
+      ? before.hash
+      : computeHash(await readOrEmpty(fileManager, path));
+    files[file] = { mtimeMs: stat.mtimeMs, size: stat.size, hash };
+  }
+  return files;
+}
+
+/** Files that are new or whose content changed since `previous` was recorded */
+export function changedFiles(
+  previous: RunManifest,
+  current: Readonly<Record<string, ManifestEntry>>
+): string[] {
+  return Object.entries(current)
+    .filter(([file, entry]) => previous.files[file]?.hash !== entry.hash)
+    .map(([file]) => file);
+}
@@ -0,0 +1,168 @@
+import { join } from "node:path";
+import { z } from "zod";
+import type { ResolvedConfig } from "@/config/schema";
+import { userConfigFile } from "@/config/schema";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
+import { DEFAULT_IGNORE } from "@/languages/constants";
+import type { LintIssue } from "@/models/lint-issue";
+import { SEVERITIES } from "@/models/lint-issue";
+import { CACHE_DIR } from "@/models/paths";
+import type { LinterRunner } from "@/runners/types";
+import { computeHash } from "@/utils/hash";
+import { mapPool, PARALLEL_READS } from "@/utils/pool";
+import { getVersion } from "@/utils/version";
+
+const CachedIssueSchema = z.object({
+  rule: z.string(),
+  linter: z.string(),
+  file: z.string(),
+  line: z.number(),
+  col: z.number(),
+  message: z.string(),
+  severity: z.enum(SEVERITIES),
+  fingerprint: z.string(),
+  module: z.string().optional(),
+});
+
+/**
+ * One cache file per runner at .ai-guardrails/cache/<runner-id>.json.
+ * A new key overwrites the previous entry, so the cache never grows unbounded.
+ */
+const CacheEntrySchema = z.object({
+  key: z.string(),
+  issues: z.array(CachedIssueSchema),
+});
+
+function cachePath(projectDir: string, runnerId: string): string {
+  return join(projectDir, CACHE_DIR, `${runnerId}.json`);
+}
+
+async function readOrEmpty(fileManager: FileManager, path: string): Promise<string> {
+  try {
+    return await fileManager.readText(path);
+  } catch {
+    return "";
+  }
+}
+
+/**
+ * `config` as resolved from every layer it was loaded from — machine, global,
+ * project or --config, nested — in a stable form: sets become sorted arrays
+ * and functions drop out.
+ */
+function serializeConfig(config: ResolvedConfig): string {
+  return JSON.stringify(config, (_key, value: unknown) =>
+    value instanceof Set ? [...value].map(String).sort() : value
+  );
+}
+
+/** The version results are keyed to: the tool's, or ours for an in-process check */
+async function runnerVersion(
+  runner: LinterRunner,
+  projectDir: string,
+  commandRunner: CommandRunner
+): Promise<string | null> {
+  if (runner.cache?.inProcess === true) return getVersion();
+  if (runner.versionArgs === undefined) return null;
+  const version = await commandRunner.run([...runner.versionArgs], {
+    cwd: projectDir,
+  });
+  return version.exitCode === 0 ? version.stdout.trim() : null;
+}
+
+/**
+ * Compute the cache key for a runner: a hash over the tool version, the
+ * resolved config, and the path + content hash of every input file.
+ * Returns null when the runner is not cacheable or its version is unknown.
+ */
+export async function computeCacheKey(
+  runner: LinterRunner,
+  projectDir: string,
+  config: ResolvedConfig,
+  commandRunner: CommandRunner,
+  fileManager: FileManager
+): Promise<string | null> {
+  if (runner.cache === undefined) return null;
+  const version = await runnerVersion(runner, projectDir, commandRunner);
+  if (version === null) return null;
+
+  const userConfig = userConfigFile(config, runner.id);
+  const patterns = [
+    ...runner.cache.inputs,
+    ...(runner.configFile !== null ? [runner.configFile] : []),
+    ...(userConfig !== undefined ? [userConfig] : []),
+  ];
+  const ignore = [...DEFAULT_IGNORE, ...config.ignorePaths];
+  const matched = await Promise.all(
+    patterns.map((pattern) => fileManager.glob(pattern, projectDir, ignore))
+  );
+  const found = await runner.cache.findInputs?.(fileManager, projectDir, config);
+  const files = [...new Set([...matched.flat(), ...(found ?? [])])].sort();
+
+  const fileHashes = await mapPool(files, PARALLEL_READS, async (file) => {
+    const content = await readOrEmpty(fileManager, join(projectDir, file));
+    return `${file}:${computeHash(content)}`;
+  });
+
+  return computeHash(
+    [
+      runner.id,
+      version,
+      computeHash(serializeConfig(config)),
+      ...fileHashes,
+    ].join("\n")
+  );
+}
+
+/** Return cached issues for the runner when the stored key matches, else null */
+export async function loadCachedIssues(
+  projectDir: string,
+  runnerId: string,
+  key: string,
+  fileManager: FileManager
+): Promise<LintIssue[] | null> {
+  try {
+    const text = await fileManager.readText(cachePath(projectDir, runnerId));
+    const entry = CacheEntrySchema.parse(JSON.parse(text));
+    if (entry.key !== key) return null;
+    return entry.issues.map(({ module, ...issue }) => ({
+      ...issue,
+      ...(module !== undefined && { module }),
+    }));
+  } catch {
+    return null;
+  }
+}
+
+export async function saveCachedIssues(
+  projectDir: string,
+  runnerId: string,
+  key: string,
+  issues: readonly LintIssue[],
+  fileManager: FileManager
+): Promise<void> {
+  await fileManager.mkdir(join(projectDir, CACHE_DIR), { parents: true });
+  await fileManager.writeText(
+    cachePath(projectDir, runnerId),
+    JSON.stringify({ key, issues })
+  );
+}
+
+/** Delete every cache entry. Returns the number of entries removed. */
+export async function clearRunnerCache(
+  projectDir: string,
+  fileManager: FileManager
+): Promise<number> {
+  const cacheDir = join(projectDir, CACHE_DIR);
+  let entries: string[];
+  try {
+    entries = await fileManager.glob("*.json", cacheDir);
+  } catch {
+    return 0; // no cache directory yet
+  }
+  for (const entry of entries) {
+    await fileManager.delete(join(cacheDir, entry));
+  }
+  return entries.length;
+}
@@ -0,0 +1,64 @@
+import type { LintIssue } from "@/models/lint-issue";
+
+/**
+ * What happened to a single runner during a check. "skipped" means the tool
+ * is not installed; "disabled" means config or `--disable` turned it off;
+ * "cancelled" means `--fail-fast` stopped it, or it never started.
+ */
+export type RunnerStatus = "ok" | "skipped" | "disabled" | "error" | "cancelled";
+
+export interface RunnerReport {
+  /** LinterRunner.id — also the `linter` field on every issue it reports */
+  readonly runnerId: string;
+  /** Human-readable runner name */
+  readonly name: string;
+  readonly status: RunnerStatus;
+  /** Wall-clock time including the availability probe */
+  readonly durationMs: number;
+  /** True when issues were served from the result cache instead of running */
+  readonly cached?: boolean;
+  /** Failure detail when status is "error", the reason when "cancelled" */
+  readonly message?: string;
+  /** True when status is "error" because a required tool is not installed */
+  readonly missing?: boolean;
+  /** Runs it took, when a transient failure was retried; the last one counts */
+  readonly attempts?: number;
+}
+
+/**
+ * One runner's result, handed out as soon as it can be shown so output can
+ * stream while the other runners are still going.
+ */
+export interface RunnerProgress {
+  readonly report: RunnerReport;
+  /** The runner's findings after ignore, allow and supersede filtering */
+  readonly issues: readonly LintIssue[];
+  /** Fingerprints of `issues` that the baseline suppresses */
+  readonly baselined: ReadonlySet<string>;
+  /** Runners shown so far, this one included */
+  readonly done: number;
+  /** Enabled runners in this check */
+  readonly total: number;
+}
+
+/**
+ * Append a synthetic "ok" report for each linter that produced issues but has
+ * no report of its own, so writers can treat every issue as owned by a runner.
+ */
+export function withLinterReports(
+  runners: readonly RunnerReport[],
+  linters: Iterable<string>
+): RunnerReport[] {
+  const reported = new Set(runners.map((r) => r.runnerId));
+  const synthetic = [...linters]
+    .filter((linter) => !reported.has(linter))
+    .map(
+      (linter): RunnerReport => ({
+        runnerId: linter,
+        name: linter,
+        status: "ok",
+        durationMs: 0,
+      })
+    );
+  return [...runners, ...synthetic];
+}
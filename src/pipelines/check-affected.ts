@@ -0,0 +1,58 @@
+import type { PipelineContext } from "@/pipelines/types";
+import { listChangedFiles } from "@/utils/changed-files";
+import { detectMonorepoTool, listAffectedPackages } from "@/utils/monorepo";
+
+/** What --changed-since leaves in scope */
+export type AffectedScope =
+  | {
+      status: "ok";
+      /** Project-relative files git lists as changed */
+      files?: string[];
+      /** Project-relative dirs of the affected packages, in a monorepo */
+      affected?: string[];
+    }
+  /** Nothing changed: the run passes with `message` */
+  | { status: "empty"; message: string }
+  | { status: "error"; message: string };
+
+/**
+ * Resolve --changed-since `ref`. In a Turborepo or Nx workspace the tool says
+ * which packages are affected; elsewhere, or without the tool installed, git
+ * lists the changed files.
+ */
+export async function resolveAffectedScope(
+  ref: string,
+  ctx: PipelineContext
+): Promise<AffectedScope> {
+  const { projectDir, fileManager, commandRunner, console: cons } = ctx;
+  const tool = await detectMonorepoTool(projectDir, fileManager);
+  let packages: string[] | null = null;
+  let changed: string[] = [];
+  try {
+    if (tool !== null) {
+      packages = await listAffectedPackages(tool, ref, projectDir, commandRunner);
+      if (packages === null) {
+        cons.note(`${tool.name} is not installed — checking the files git lists`);
+      }
+    }
+    if (packages === null) {
+      changed = await listChangedFiles(ref, projectDir, commandRunner, fileManager);
+    }
+  } catch (err) {
+    const message = err instanceof Error ? err.message : String(err);
+    return { status: "error", message };
+  }
+  if ((packages ?? changed).length === 0) {
+    const what = packages !== null ? "packages affected" : "files changed";
+    return { status: "empty", message: `No ${what} since ${ref}` };
+  }
+  if (packages === null) {
+    cons.step(`Checking ${changed.length} file(s) changed since ${ref}`);
+    return { status: "ok", files: changed };
+  }
+  const names = packages.join(", ");
+  cons.step(`Checking ${packages.length} affected package(s): ${names}`);
+  // The workspace root is a package too; with it, every file is affected
+  if (packages.includes(".")) return { status: "ok" };
+  return { status: "ok", affected: packages };
+}
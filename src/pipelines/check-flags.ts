@@ -0,0 +1,113 @@
+import { isAbsolute, relative, resolve } from "node:path";
+import type { FileManager } from "@/infra/file-manager";
+import type { Severity } from "@/models/lint-issue";
+import { SEVERITIES } from "@/models/lint-issue";
+import { DEFAULT_CHANGED_SINCE_REF } from "@/utils/changed-files";
+import { parseRunnerList } from "@/utils/parse";
+import { defaultJobs, defaultMaxProcs } from "@/utils/pool";
+
+/** Resolve --jobs: absent → CPU count, a positive integer → itself, else null */
+export function parseJobs(raw: unknown): number | null {
+  if (raw === undefined) return defaultJobs();
+  const jobs = Number(raw);
+  return Number.isInteger(jobs) && jobs > 0 ? jobs : null;
+}
+
+/** Resolve --max-procs: absent → GOMAXPROCS or CPU count, positive integer → itself */
+export function parseMaxProcs(raw: unknown): number | null {
+  if (raw === undefined) return defaultMaxProcs();
+  const procs = Number(raw);
+  return Number.isInteger(procs) && procs > 0 ? procs : null;
+}
+
+/** Resolve --batch-size: absent → undefined, positive integer → itself, else null */
+export function parseBatchSize(raw: unknown): number | undefined | null {
+  if (raw === undefined) return undefined;
+  const size = Number(raw);
+  return Number.isInteger(size) && size > 0 ? size : null;
+}
+
+/** Resolve --repeat: absent → undefined, positive integer → itself, else null */
+export function parseRepeat(raw: unknown): number | undefined | null {
+  if (raw === undefined) return undefined;
+  const count = Number(raw);
+  return Number.isInteger(count) && count > 0 ? count : null;
+}
+
+/** Resolve --max-findings/--max-per-runner: absent or 0 → undefined (no cap) */
+export function parseFindingLimit(raw: unknown): number | undefined | null {
+  if (raw === undefined) return undefined;
+  const limit = Number(raw);
+  if (!Number.isInteger(limit) || limit < 0) return null;
+  return limit === 0 ? undefined : limit;
+}
+
+/** Resolve --timeout: absent → undefined, positive seconds → itself, else null */
+export function parseTimeout(raw: unknown): number | undefined | null {
+  if (raw === undefined) return undefined;
+  const seconds = Number(raw);
+  return Number.isFinite(seconds) && seconds > 0 ? seconds : null;
+}
+
+/** Resolve --fail-on: absent → undefined (profiles decide), a severity → itself */
+export function parseFailOn(raw: unknown): Severity | undefined | null {
+  if (raw === undefined) return undefined;
+  return SEVERITIES.find((severity) => severity === raw) ?? null;
+}
+
+/** Resolve --changed-since: absent → undefined, bare flag → origin/main */
+export function parseChangedSince(raw: unknown): string | undefined {
+  if (typeof raw === "string" && raw !== "") return raw;
+  return raw === true ? DEFAULT_CHANGED_SINCE_REF : undefined;
+}
+
+/** Resolve --module to a project-relative dir ("." = root); null if outside */
+export function parseModule(
+  raw: unknown,
+  projectDir: string
+): string | undefined | null {
+  if (typeof raw !== "string") return undefined;
+  const rel = relative(projectDir, resolve(projectDir, raw));
+  if (rel.startsWith("..") || isAbsolute(rel)) return null;
+  return rel || ".";
+}
+
+/** --path entries, comma-separated, followed by positional paths */
+function parsePathList(path: unknown, positional: unknown): string[] {
+  const extra = Array.isArray(positional) ? positional.map(String) : [];
+  return [...parseRunnerList(path), ...extra];
+}
+
+/** Resolve --stdin-filename to a project-relative file; null if outside */
+export function parseStdinFilename(
+  raw: unknown,
+  projectDir: string
+): string | undefined | null {
+  if (typeof raw !== "string") return undefined;
+  const rel = relative(projectDir, resolve(projectDir, raw));
+  if (rel === "" || rel.startsWith("..") || isAbsolute(rel)) return null;
+  return rel;
+}
+
+/**
+ * --path entries (see parsePathList) as project-relative paths, each of which
+ * must exist inside the project
+ */
+export async function parsePaths(
+  flags: Readonly<Record<string, unknown>>,
+  projectDir: string,
+  fileManager: FileManager
+): Promise<{ status: "ok"; paths: string[] } | { status: "error"; message: string }> {
+  const paths: string[] = [];
+  for (const raw of parsePathList(flags.path, flags.paths)) {
+    const rel = relative(projectDir, resolve(projectDir, raw)) || ".";
+    if (rel.startsWith("..") || isAbsolute(rel)) {
+      return { status: "error", message: `--path ${raw} is outside the project` };
+    }
+    if (!(await fileManager.exists(resolve(projectDir, rel)))) {
+      return { status: "error", message: `--path ${raw} does not exist` };
+    }
+    paths.push(rel);
+  }
+  return { status: "ok", paths };
+}
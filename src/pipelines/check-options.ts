@@ -0,0 +1,200 @@
+import type { ResolvedConfig } from "@/config/schema";
+import { validateOnlyRunners } from "@/languages/registry";
+import type { Severity } from "@/models/lint-issue";
+import { SEVERITIES } from "@/models/lint-issue";
+import { BASELINE_PATH } from "@/models/paths";
+import {
+  parseBatchSize,
+  parseChangedSince,
+  parseFailOn,
+  parseFindingLimit,
+  parseJobs,
+  parseMaxProcs,
+  parseModule,
+  parseRepeat,
+  parseStdinFilename,
+  parseTimeout,
+} from "@/pipelines/check-flags";
+import { parseReportFormat, type ReportFormat } from "@/steps/report-step";
+import { parseRunnerList } from "@/utils/parse";
+import type { FindingLimits } from "@/writers/text";
+
+/** The `check` flags past runner selection, parsed and checked for conflicts */
+export interface CheckOptions {
+  /** --require/--require-all: a missing tool fails the check instead of skipping */
+  requireTools: ReadonlySet<string> | "all" | undefined;
+  jobs: number;
+  maxProcs: number;
+  failOn: Severity | undefined;
+  timeout: number | undefined;
+  batchSize: number | undefined;
+  repeat: number | undefined;
+  /** --max-findings/--max-per-runner; undefined prints every finding */
+  limits: FindingLimits | undefined;
+  useCache: boolean;
+  format: ReportFormat;
+  output: string | undefined;
+  /** --tee: print the report as usual and also write it to --output */
+  tee: boolean;
+  /** --changed-since */
+  ref: string | undefined;
+  staged: boolean;
+  sinceLastRun: boolean;
+  baselinePath: string;
+  updateBaseline: boolean;
+  /** --module, project-relative */
+  module: string | undefined;
+  /** --stdin-filename, project-relative; set exactly when --stdin is */
+  stdinFile: string | undefined;
+  fix: boolean;
+  diff: boolean;
+}
+
+export type CheckOptionsResult =
+  | { status: "ok"; options: CheckOptions }
+  | { status: "error"; message: string };
+
+function invalid(message: string): CheckOptionsResult {
+  return { status: "error", message };
+}
+
+/** Explain why the flags in `options` cannot be combined, or null when they can */
+function flagConflict(
+  options: CheckOptions,
+  pathScoped: boolean,
+  failFast: boolean
+): string | null {
+  const { staged, ref, sinceLastRun, module, updateBaseline } = options;
+  const { fix, diff, repeat, format, output, tee } = options;
+  const stdin = options.stdinFile !== undefined;
+  if (tee && output === undefined) return "--tee needs --output <path>";
+  if (staged && ref !== undefined) {
+    return "--staged and --changed-since cannot be combined";
+  }
+  if (sinceLastRun && (staged || ref !== undefined || module !== undefined)) {
+    // The manifest describes what a full run checked
+    return "--since-last-run cannot be combined with --staged/--changed-since/--module";
+  }
+  if (
+    updateBaseline &&
+    (staged || ref !== undefined || module !== undefined || pathScoped)
+  ) {
+    // A partial run would drop every entry outside the checked files
+    return "--update-baseline needs a full run; drop --staged/--changed-since/--module/--path";
+  }
+  if (updateBaseline && sinceLastRun) {
+    return "--since-last-run cannot be combined with --update-baseline";
+  }
+  if (updateBaseline && failFast) {
+    // Runners cancelled by --fail-fast would drop their entries too
+    return "--fail-fast cannot be combined with --update-baseline";
+  }
+  if (stdin && (staged || ref !== undefined || sinceLastRun || module !== undefined)) {
+    // The buffer is the one file checked
+    return "--stdin cannot be combined with --staged/--changed-since/--since-last-run/--module";
+  }
+  if (stdin && (updateBaseline || fix)) {
+    // Neither can write back to the editor's buffer
+    return "--stdin cannot be combined with --update-baseline or --fix";
+  }
+  if (diff && (fix || updateBaseline || stdin)) {
+    // --diff is the dry run of --fix
+    return "--diff cannot be combined with --fix, --update-baseline or --stdin";
+  }
+  if (diff && format !== "text" && (output === undefined || tee)) {
+    // The diffs would interleave with the report on stdout
+    return `--diff prints to stdout; write the ${format} report to --output, without --tee`;
+  }
+  if (repeat !== undefined && (fix || diff || updateBaseline)) {
+    // Each pass must check the same tree
+    return "--repeat cannot be combined with --fix, --diff or --update-baseline";
+  }
+  if (pathScoped && (sinceLastRun || module !== undefined || stdin)) {
+    return "--path cannot be combined with --since-last-run/--module/--stdin";
+  }
+  return null;
+}
+
+/**
+ * Parse `flags` into CheckOptions. `loaded` is the config before runner
+ * overrides; `pathScoped` is true when --path narrows the run.
+ */
+export function parseCheckOptions(
+  flags: Readonly<Record<string, unknown>>,
+  projectDir: string,
+  loaded: ResolvedConfig,
+  pathScoped: boolean
+): CheckOptionsResult {
+  const required = parseRunnerList(flags.require);
+  const requireError = validateOnlyRunners(required, loaded, "--require");
+  if (requireError !== null) return invalid(requireError);
+  const jobs = parseJobs(flags.jobs ?? loaded.global?.config.jobs);
+  if (jobs === null) return invalid("--jobs must be a positive integer");
+  const maxProcs = parseMaxProcs(flags.maxProcs);
+  if (maxProcs === null) return invalid("--max-procs must be a positive integer");
+  const failOn = parseFailOn(flags.failOn);
+  if (failOn === null) {
+    return invalid(`--fail-on must be one of: ${SEVERITIES.join(", ")}`);
+  }
+  const timeout = parseTimeout(flags.timeout);
+  if (timeout === null) {
+    return invalid("--timeout must be a positive number of seconds");
+  }
+  const batchSize = parseBatchSize(flags.batchSize);
+  if (batchSize === null) return invalid("--batch-size must be a positive integer");
+  const repeat = parseRepeat(flags.repeat);
+  if (repeat === null) return invalid("--repeat must be a positive integer");
+  const maxFindings = parseFindingLimit(flags.maxFindings);
+  if (maxFindings === null) {
+    return invalid("--max-findings must be a non-negative integer");
+  }
+  const maxPerRunner = parseFindingLimit(flags.maxPerRunner);
+  if (maxPerRunner === null) {
+    return invalid("--max-per-runner must be a non-negative integer");
+  }
+  const module = parseModule(flags.module, projectDir);
+  if (module === null) return invalid("--module must be inside the project");
+  const stdinFile = parseStdinFilename(flags.stdinFilename, projectDir);
+  if (stdinFile === null) return invalid("--stdin-filename must be inside the project");
+  if ((flags.stdin === true) !== (stdinFile !== undefined)) {
+    return invalid("--stdin and --stdin-filename <path> must be given together");
+  }
+
+  const options: CheckOptions = {
+    requireTools:
+      flags.requireAll === true
+        ? "all"
+        : required.length > 0
+          ? new Set(required)
+          : undefined,
+    jobs,
+    maxProcs,
+    failOn,
+    timeout,
+    batchSize,
+    repeat,
+    limits:
+      maxFindings !== undefined || maxPerRunner !== undefined
+        ? {
+            ...(maxFindings !== undefined && { total: maxFindings }),
+            ...(maxPerRunner !== undefined && { perRunner: maxPerRunner }),
+          }
+        : undefined,
+    // commander maps --no-cache to cache: false; --repeat times real work
+    useCache: flags.cache !== false && repeat === undefined,
+    format: parseReportFormat(flags.format),
+    output: typeof flags.output === "string" ? flags.output : undefined,
+    tee: flags.tee === true,
+    ref: parseChangedSince(flags.changedSince),
+    staged: flags.staged === true,
+    sinceLastRun: flags.sinceLastRun === true,
+    baselinePath: typeof flags.baseline === "string" ? flags.baseline : BASELINE_PATH,
+    updateBaseline: flags.updateBaseline === true,
+    module,
+    stdinFile,
+    fix: flags.fix === true,
+    diff: flags.diff === true,
+  };
+  const conflict = flagConflict(options, pathScoped, flags.failFast === true);
+  return conflict !== null ? invalid(conflict) : { status: "ok", options };
+}
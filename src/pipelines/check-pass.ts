@@ -0,0 +1,140 @@
+import { relative } from "node:path";
+import { failOnAt, type ResolvedConfig } from "@/config/schema";
+import type { Console } from "@/infra/console";
+import type { LanguagePlugin } from "@/languages/types";
+import type { LintIssue, Severity } from "@/models/lint-issue";
+import type { CheckOptions } from "@/pipelines/check-options";
+import type { FileScope } from "@/pipelines/check-scope";
+import type { PipelineContext } from "@/pipelines/types";
+import { type CheckStepResult, checkStep } from "@/steps/check-step";
+import { reportQuietRunnerProgress, reportRunnerProgress } from "@/steps/report-step";
+import {
+  FindingCap,
+  formatRunnerProgress,
+  type GroupBy,
+  groupByFromFlags,
+} from "@/writers/text";
+
+/** How the run prints its findings, shared by every pass and the final report */
+export interface CheckOutput {
+  /**
+   * Text output streams each runner's block as it finishes; the other formats
+   * stay a single document written once everything is done
+   */
+  stream: boolean;
+  /** -q/--quiet: the console drops progress; only failing findings are printed */
+  quiet: boolean;
+  /** --quiet-skips: missing tools' skip lines only with --verbose; the count stays */
+  quietSkips: boolean;
+  /** --explain: a rationale and fix hint under each finding in text output */
+  explain: boolean;
+  /**
+   * --group-by file|severity: runners stream their status lines alone and the
+   * findings follow, regrouped, once every runner is done
+   */
+  grouped: Exclude<GroupBy, "runner"> | undefined;
+  /** The severity that fails the check at `issue`'s file */
+  failOnFor: (issue: LintIssue) => Severity;
+}
+
+export function checkOutputFor(
+  ctx: PipelineContext,
+  options: CheckOptions,
+  config: ResolvedConfig
+): CheckOutput {
+  const groupBy = groupByFromFlags(ctx.flags);
+  const { failOn } = options;
+  return {
+    stream: options.format === "text" && !options.updateBaseline,
+    quiet: ctx.flags.quiet === true,
+    quietSkips: ctx.flags.quietSkips === true,
+    explain: ctx.flags.explain === true,
+    grouped: groupBy !== "runner" ? groupBy : undefined,
+    failOnFor: (issue) =>
+      failOn ?? failOnAt(config, relative(ctx.projectDir, issue.file)),
+  };
+}
+
+export interface CheckPassRequest {
+  ctx: PipelineContext;
+  languages: readonly LanguagePlugin[];
+  config: ResolvedConfig;
+  options: CheckOptions;
+  scope: FileScope;
+  /** --path subtrees; undefined when --path restricts nothing */
+  paths: readonly string[] | undefined;
+  output: CheckOutput;
+}
+
+/** One pass of the runners; `out` is where it reports as it goes */
+export type CheckPass = (out?: Console) => Promise<CheckStepResult>;
+
+/** The pass `check` runs, once or (--fix, --repeat) several times */
+export function makeCheckPass(request: CheckPassRequest): CheckPass {
+  const { ctx, languages, config, options, scope, output } = request;
+  const { projectDir, commandRunner, fileManager, flags } = ctx;
+  const { files, standIns, affected, checkIgnore } = scope;
+  const { module, failOn, timeout, batchSize, limits, requireTools } = options;
+  const { stream, quiet, quietSkips, explain, failOnFor } = output;
+  const streamFindings = output.grouped === undefined;
+  const paths = request.paths ?? affected;
+  const fileScopedOnly = options.staged || options.stdinFile !== undefined;
+
+  return async (out = ctx.console) => {
+    // --max-findings/--max-per-runner bound what is printed; the result and the
+    // --output file still cover every finding
+    const cap =
+      stream && streamFindings && limits !== undefined
+        ? new FindingCap(limits)
+        : undefined;
+    const result = await checkStep(
+      projectDir,
+      languages,
+      config,
+      commandRunner,
+      fileManager,
+      out,
+      {
+        jobs: options.jobs,
+        maxProcs: options.maxProcs,
+        useCache: options.useCache,
+        ...(files !== undefined && { files }),
+        ...(fileScopedOnly && { fileScopedOnly: true }),
+        ...(standIns !== undefined && { standIns }),
+        ...(module !== undefined && { module }),
+        baselinePath: options.baselinePath,
+        ...(failOn !== undefined && { failOn }),
+        ...(timeout !== undefined && { timeout }),
+        ...(batchSize !== undefined && { batchSize }),
+        ...(paths !== undefined && { paths }),
+        ...(requireTools !== undefined && { requireTools }),
+        ...(checkIgnore !== null && { ignore: checkIgnore }),
+        ...(flags.includeGenerated === true && { includeGenerated: true }),
+        ...(flags.checkExternal === true && { checkExternal: true }),
+        ...(flags.failFast === true && { failFast: true }),
+        // commander maps --no-dedup to dedup: false
+        ...(flags.dedup === false && { dedup: false }),
+        ...(ctx.tracer !== undefined && { tracer: ctx.tracer }),
+        ...(quietSkips && { quietSkips }),
+        ...(stream && {
+          onRunnerDone: (progress) =>
+            quietSkips && progress.report.status === "skipped"
+              ? out.verbose(formatRunnerProgress(progress))
+              : quiet
+                ? reportQuietRunnerProgress(
+                    progress,
+                    out,
+                    failOnFor,
+                    explain,
+                    cap,
+                    streamFindings
+                  )
+                : reportRunnerProgress(progress, out, explain, cap, streamFindings),
+        }),
+      }
+    );
+    const note = cap?.note() ?? "";
+    if (note !== "") out.error(note);
+    return result;
+  };
+}
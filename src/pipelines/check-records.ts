@@ -0,0 +1,100 @@
+import { relative } from "node:path";
+import { checkExitCode, EXIT_RUNNER_ERROR } from "@/models/exit-code";
+import type { LintIssue } from "@/models/lint-issue";
+import {
+  appendMetricsRecord,
+  buildMetricsRecord,
+  type MetricsInput,
+  readHeadCommit,
+} from "@/models/metrics-record";
+import { type RunManifest, saveRunManifest } from "@/models/run-manifest";
+import type { RunnerReport } from "@/models/runner-report";
+import type { PipelineContext, PipelineResult } from "@/pipelines/types";
+import { writeBaseline } from "@/steps/snapshot-step";
+
+/**
+ * Store the pre-run snapshot for the next --since-last-run, minus files with
+ * new findings so they are checked again. A failed or cancelled runner did
+ * not check its files, so the previous manifest is kept instead.
+ */
+export async function recordRunManifest(
+  projectDir: string,
+  manifest: RunManifest,
+  issues: readonly LintIssue[],
+  baselined: ReadonlySet<string>,
+  runners: readonly RunnerReport[],
+  ctx: PipelineContext
+): Promise<void> {
+  const incomplete = runners.some(
+    (r) => r.status === "error" || r.status === "cancelled"
+  );
+  if (incomplete) return;
+  const flagged = new Set(
+    issues
+      .filter((issue) => !baselined.has(issue.fingerprint))
+      .map((issue) => relative(projectDir, issue.file))
+  );
+  const files = Object.fromEntries(
+    Object.entries(manifest.files).filter(([file]) => !flagged.has(file))
+  );
+  try {
+    const kept = { key: manifest.key, files };
+    await saveRunManifest(projectDir, kept, ctx.fileManager);
+  } catch (err) {
+    const message = err instanceof Error ? err.message : String(err);
+    ctx.console.warning(`Run manifest not saved: ${message}`);
+  }
+}
+
+/**
+ * Append this run's line to the --metrics file. Failing to write it is only a
+ * warning, so a full disk or bad path does not change the check's outcome.
+ */
+export async function recordMetrics(
+  path: string,
+  ctx: PipelineContext,
+  run: Omit<MetricsInput, "timestamp" | "commit">
+): Promise<void> {
+  const record = buildMetricsRecord({
+    timestamp: new Date().toISOString(),
+    commit: await readHeadCommit(ctx.projectDir, ctx.commandRunner),
+    ...run,
+  });
+  try {
+    await appendMetricsRecord(path, record, ctx.fileManager);
+  } catch (err) {
+    const message = err instanceof Error ? err.message : String(err);
+    ctx.console.warning(`Metrics not recorded: ${message}`);
+  }
+}
+
+/**
+ * --update-baseline: write every finding to `baselinePath`. A failed runner
+ * did not report its findings, so the baseline is left alone.
+ */
+export async function recordBaseline(
+  issues: LintIssue[],
+  runners: readonly RunnerReport[],
+  baselinePath: string,
+  ctx: PipelineContext
+): Promise<PipelineResult> {
+  const failed = runners.filter((r) => r.status === "error");
+  if (failed.length > 0) {
+    const names = failed.map((r) => r.name).join(", ");
+    const message = `Baseline not updated — runner(s) failed: ${names}`;
+    return { status: "error", message, exitCode: checkExitCode(0, failed) };
+  }
+  try {
+    const { projectDir, fileManager, console: cons } = ctx;
+    const count = await writeBaseline(projectDir, issues, fileManager, baselinePath);
+    cons.success(`Baseline updated: ${count} issue(s) written to ${baselinePath}`);
+  } catch (err) {
+    const message = err instanceof Error ? err.message : String(err);
+    return {
+      status: "error",
+      message: `Baseline update failed: ${message}`,
+      exitCode: EXIT_RUNNER_ERROR,
+    };
+  }
+  return { status: "ok", issueCount: 0 };
+}
@@ -0,0 +1,32 @@
+import { type Console, SilentConsole } from "@/infra/console";
+import type { CheckPass } from "@/pipelines/check-pass";
+import type { CheckStepResult } from "@/steps/check-step";
+import { type Tracer, traced } from "@/utils/trace";
+import type { RepeatSample } from "@/writers/text";
+
+/**
+ * --repeat `repeat`: run `pass` that many times, timing each. The first
+ * passes are silent; the last reports as usual and its result is the run's.
+ * Without --repeat this is the one pass, still timed.
+ */
+export async function runTimedPasses(
+  pass: CheckPass,
+  repeat: number | undefined,
+  cons: Console,
+  tracer?: Tracer
+): Promise<{ checked: CheckStepResult; samples: RepeatSample[] }> {
+  const samples: RepeatSample[] = [];
+  const timed = async (out?: Console) => {
+    const passStarted = performance.now();
+    const result = await pass(out);
+    const durationMs = Math.round(performance.now() - passStarted);
+    samples.push({ runners: result.runners, durationMs });
+    return result;
+  };
+  for (let n = 1; n < (repeat ?? 1); n++) {
+    cons.step(`Timing pass ${n} of ${repeat}...`);
+    await traced(tracer, `check ${n}`, () => timed(new SilentConsole()));
+  }
+  const checked = await traced(tracer, "check", () => timed());
+  return { checked, samples };
+}
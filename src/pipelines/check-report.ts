@@ -0,0 +1,90 @@
+import type { CheckOptions } from "@/pipelines/check-options";
+import type { CheckOutput } from "@/pipelines/check-pass";
+import type { PipelineContext } from "@/pipelines/types";
+import type { CheckStepResult } from "@/steps/check-step";
+import {
+  failingIssues,
+  reportGroupedIssues,
+  reportStep,
+  reportStreamedSummary,
+} from "@/steps/report-step";
+import {
+  FindingCap,
+  formatRepeatTimings,
+  formatRunnerTimings,
+  type RepeatSample,
+} from "@/writers/text";
+
+export interface CheckReportRequest {
+  ctx: PipelineContext;
+  checked: CheckStepResult;
+  options: CheckOptions;
+  output: CheckOutput;
+  /** Each pass's timings; reported with --repeat */
+  samples: readonly RepeatSample[];
+  /** Wall-clock time of the whole check */
+  durationMs: number;
+}
+
+/**
+ * Report a finished check: the findings the passes did not stream, the timing
+ * tables, and the report in --format (to stdout or --output) or the streamed
+ * summary line.
+ */
+export async function reportCheck(request: CheckReportRequest): Promise<void> {
+  const { ctx, checked, options, output, samples, durationMs } = request;
+  const { projectDir, fileManager, console: cons } = ctx;
+  const { result, issues, baselined, runners } = checked;
+  const { stream, quiet, explain, grouped } = output;
+  const { limits, format } = options;
+
+  if (stream && grouped !== undefined) {
+    const shown = quiet ? failingIssues(issues, baselined, output.failOnFor) : issues;
+    const cap = limits !== undefined ? new FindingCap(limits) : undefined;
+    reportGroupedIssues(shown, cons, grouped, baselined, explain, cap);
+  }
+
+  // Timing table ahead of the summary line; --timings keeps it under --quiet
+  if (stream && (!quiet || ctx.flags.timings === true)) {
+    for (const line of formatRunnerTimings(runners, issues)) cons.info(line);
+  }
+  if (options.repeat !== undefined) {
+    for (const line of formatRepeatTimings(samples)) cons.note(line);
+  }
+  if (!stream) {
+    await reportStep({
+      issues,
+      format,
+      console: cons,
+      fileManager,
+      ...(options.output !== undefined && { outputPath: options.output }),
+      runners,
+      baselined,
+      tee: options.tee,
+      projectDir,
+      explain,
+      outcome: { passed: result.status !== "error", durationMs },
+    });
+    return;
+  }
+  if (!quiet) {
+    reportStreamedSummary(issues, cons, baselined);
+  } else if (checked.failingIssueCount > 0) {
+    // The one-line summary; a passing quiet run prints nothing at all
+    cons.error(result.message);
+  }
+  // The console already streamed the findings; the file gets the full list
+  if (options.output !== undefined) {
+    await reportStep({
+      issues,
+      format,
+      console: cons,
+      fileManager,
+      outputPath: options.output,
+      runners,
+      baselined,
+      projectDir,
+      explain,
+    });
+  }
+}
@@ -0,0 +1,221 @@
+import type { ResolvedConfig } from "@/config/schema";
+import type { LanguagePlugin } from "@/languages/types";
+import { EXIT_RUNNER_ERROR, type ExitCode } from "@/models/exit-code";
+import {
+  changedFiles,
+  computeManifestKey,
+  loadRunManifest,
+  type RunManifest,
+  scanProjectFiles,
+} from "@/models/run-manifest";
+import { resolveAffectedScope } from "@/pipelines/check-affected";
+import { writeStagedStandIns, writeStdinStandIn } from "@/pipelines/check-stand-ins";
+import type { PipelineContext } from "@/pipelines/types";
+import { listStagedFiles } from "@/utils/changed-files";
+import { findGoModules, modulesUnder } from "@/utils/go-modules";
+import { loadIgnoreMatcher, type PathMatcher } from "@/utils/ignore-file";
+import { listProjectFiles } from "@/utils/project-files";
+
+/** True when a project-relative path lies in one of the project-relative dirs */
+export function isUnder(relPath: string, dirs: readonly string[]): boolean {
+  return dirs.some((dir) => relPath === dir || relPath.startsWith(`${dir}/`));
+}
+
+/** What resolveFileScope needs from the run: the parsed scope flags */
+interface ScopeRequest {
+  ctx: PipelineContext;
+  config: ResolvedConfig;
+  languages: readonly LanguagePlugin[];
+  /** --path entries, project-relative; `outside` matches what they leave out */
+  paths: readonly string[];
+  outside: PathMatcher | undefined;
+  staged: boolean;
+  ref: string | undefined;
+  module: string | undefined;
+  sinceLastRun: boolean;
+  baselinePath: string;
+  /** --stdin-filename, project-relative: check the piped buffer as this file */
+  stdinFile: string | undefined;
+}
+
+/** The files a run checks and what it hides */
+export interface FileScope {
+  /** Project-relative files to check; undefined for the whole project */
+  files?: string[];
+  /** --changed-since in a monorepo: project-relative dirs of the affected packages */
+  affected?: string[];
+  /** The .guardrailsignore matcher; null with --no-ignore or without the file */
+  ignore: PathMatcher | null;
+  /** `ignore`, plus whatever lies outside --path or the affected packages */
+  checkIgnore: PathMatcher | null;
+  /** The --since-last-run snapshot to record once the run is done */
+  manifest?: RunManifest;
+  /**
+   * --staged or --stdin: copies holding the content to check in place of
+   * files on disk, project-relative copy path → the file it stands for
+   */
+  standIns?: Map<string, string>;
+}
+
+type ScopeResult =
+  | { status: "ok"; scope: FileScope }
+  /**
+   * Nothing in scope: the run reports no findings and passes with `message`.
+   * `standIns` are the copies already written, for the run to delete.
+   */
+  | {
+      status: "empty";
+      message: string;
+      manifest?: RunManifest;
+      standIns?: Map<string, string>;
+    }
+  /** EXIT_RUNNER_ERROR when git or the stdin copy failed; none for a bad flag */
+  | { status: "error"; message: string; exitCode?: ExitCode };
+
+/**
+ * Resolve --module, --stdin, --staged, --changed-since (with the affected
+ * packages of a Turborepo or Nx workspace), --path and --since-last-run to the
+ * files to check.
+ */
+export async function resolveFileScope(request: ScopeRequest): Promise<ScopeResult> {
+  const { ctx, config, paths, outside, staged, ref, module } = request;
+  const { projectDir, fileManager, commandRunner, console: cons } = ctx;
+  let files: string[] | undefined;
+  let affected: string[] | undefined;
+  let standIns: Map<string, string> | undefined;
+
+  if (module !== undefined) {
+    const modules = await findGoModules(fileManager, projectDir, config.ignorePaths);
+    if (modulesUnder(modules, projectDir, module).length === 0) {
+      return { status: "error", message: `No Go module at or under ${module}` };
+    }
+    cons.step(`Checking module ${module}`);
+  }
+  if (request.stdinFile !== undefined) {
+    const stdinFile = request.stdinFile;
+    try {
+      const copy = await writeStdinStandIn(stdinFile, ctx);
+      files = [copy];
+      standIns = new Map([[copy, stdinFile]]);
+    } catch (err) {
+      const message = err instanceof Error ? err.message : String(err);
+      return {
+        status: "error",
+        message: `--stdin: ${message}`,
+        exitCode: EXIT_RUNNER_ERROR,
+      };
+    }
+    cons.step(`Checking ${stdinFile} from stdin`);
+  } else if (staged) {
+    try {
+      files = await listStagedFiles(projectDir, commandRunner);
+      standIns = await writeStagedStandIns(
+        files,
+        projectDir,
+        commandRunner,
+        fileManager
+      );
+    } catch (err) {
+      const message = err instanceof Error ? err.message : String(err);
+      return { status: "error", message, exitCode: EXIT_RUNNER_ERROR };
+    }
+    if (files.length === 0) {
+      return { status: "empty", message: "No staged files to check" };
+    }
+    cons.step(`Checking ${files.length} staged file(s)`);
+    if (standIns.size > 0) {
+      const partial = standIns.size;
+      cons.verbose(`Checking ${partial} partially staged file(s) as staged`);
+      const copies = new Map([...standIns].map(([copy, real]) => [real, copy]));
+      files = files.map((file) => copies.get(file) ?? file);
+    }
+  } else if (ref !== undefined) {
+    const changed = await resolveAffectedScope(ref, ctx);
+    if (changed.status === "error") {
+      return { ...changed, exitCode: EXIT_RUNNER_ERROR };
+    }
+    if (changed.status === "empty") return changed;
+    files = changed.files;
+    affected = changed.affected;
+  }
+
+  // commander maps --no-ignore to ignore: false
+  const ignore =
+    ctx.flags.ignore === false
+      ? null
+      : await loadIgnoreMatcher(projectDir, fileManager);
+  // Outside --path, or outside the affected packages: hidden and not reported
+  const affectedDirs = affected;
+  const outOfScope: PathMatcher | undefined =
+    affectedDirs !== undefined
+      ? (relPath) => outside?.(relPath) === true || !isUnder(relPath, affectedDirs)
+      : outside;
+  const checkIgnore: PathMatcher | null =
+    outOfScope !== undefined
+      ? (relPath) => outOfScope(relPath) || ignore?.(relPath) === true
+      : ignore;
+
+  if (outOfScope !== undefined) {
+    const candidates =
+      files ??
+      (await listProjectFiles(projectDir, config.ignorePaths, ignore, fileManager));
+    files = candidates.filter((file) => !outOfScope(file));
+    const where = paths.length > 0 ? paths.join(", ") : "the affected packages";
+    if (files.length === 0) {
+      const message = `No files to check under ${where}`;
+      return { status: "empty", message, ...(standIns !== undefined && { standIns }) };
+    }
+    cons.step(`Checking ${files.length} file(s) under ${where}`);
+  }
+
+  let manifest: RunManifest | undefined;
+  if (request.sinceLastRun) {
+    const previous = await loadRunManifest(projectDir, fileManager);
+    const key = await computeManifestKey(
+      request.languages.flatMap((plugin) => plugin.runners()),
+      projectDir,
+      request.baselinePath,
+      commandRunner,
+      fileManager
+    );
+    manifest = {
+      key,
+      files: await scanProjectFiles(
+        projectDir,
+        previous,
+        config.ignorePaths,
+        ignore,
+        fileManager
+      ),
+    };
+    if (previous === null) {
+      cons.note("No manifest from an earlier run — checking all files");
+    } else if (previous.key !== key) {
+      cons.note("Tools or configs changed since the last run — checking all files");
+    } else {
+      files = changedFiles(previous, manifest.files);
+      if (files.length === 0) {
+        const message = "No files changed since the last run";
+        return {
+          status: "empty",
+          message,
+          manifest,
+          ...(standIns !== undefined && { standIns }),
+        };
+      }
+      cons.step(`Checking ${files.length} file(s) changed since the last run`);
+    }
+  }
+
+  return {
+    status: "ok",
+    scope: {
+      ...(files !== undefined && { files }),
+      ...(affected !== undefined && { affected }),
+      ignore,
+      checkIgnore,
+      ...(manifest !== undefined && { manifest }),
+      ...(standIns !== undefined && standIns.size > 0 && { standIns }),
+    },
+  };
+}
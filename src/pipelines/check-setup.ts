@@ -0,0 +1,116 @@
+import { type ResolvedConfig, withRunnerOverrides } from "@/config/schema";
+import type { Console } from "@/infra/console";
+import type { FileManager } from "@/infra/file-manager";
+import { IgnoringFileManager } from "@/infra/ignoring-file-manager";
+import {
+  onlyRunners,
+  validateOnlyRunners,
+  validateRunnerOverrides,
+  withCustomRunners,
+  withEnabledRunners,
+} from "@/languages/registry";
+import type { LanguagePlugin } from "@/languages/types";
+import { detectLanguagesStep } from "@/steps/detect-languages";
+import { loadConfigStep } from "@/steps/load-config";
+import type { PathMatcher } from "@/utils/ignore-file";
+import { type Tracer, traced } from "@/utils/trace";
+
+export interface CheckSetupRequest {
+  projectDir: string;
+  fileManager: FileManager;
+  console: Console;
+  /** `--config`: replaces the project's .ai-guardrails/config.toml */
+  configPath?: string;
+  /** `--only`: exactly these runners, whatever config or detection says */
+  only?: readonly string[];
+  enable?: readonly string[];
+  disable?: readonly string[];
+  /** Paths detection does not look at: those outside `--path` */
+  outside?: PathMatcher;
+  strictDetection?: boolean;
+  tracer?: Tracer;
+}
+
+export interface CheckSetup {
+  /** Detected plugins plus custom runners, narrowed by `only` or widened by `enable` */
+  languages: LanguagePlugin[];
+  /** `loaded` with the runner overrides applied */
+  config: ResolvedConfig;
+  /** Every config layer as loaded, before the overrides */
+  loaded: ResolvedConfig;
+}
+
+export type CheckSetupResult =
+  | { status: "ok"; setup: CheckSetup }
+  | { status: "error"; message: string };
+
+/**
+ * What `ai-guardrails check` and the embedding API share before they run the
+ * runners: detect languages, load every config layer (machine, global,
+ * project or `configPath`, nested) and apply the runner selection.
+ * Precedence: `only` > `enable`/`disable` > `[runners.<id>]` > detection.
+ */
+export async function prepareCheck(
+  request: CheckSetupRequest
+): Promise<CheckSetupResult> {
+  const { projectDir, fileManager, console: cons, outside, tracer } = request;
+  const { only = [], enable = [], disable = [] } = request;
+
+  cons.step("Detecting languages...");
+  const { result: detectResult, languages: detected } = await traced(
+    tracer,
+    "detect-languages",
+    () =>
+      detectLanguagesStep(
+        projectDir,
+        outside !== undefined
+          ? new IgnoringFileManager(fileManager, projectDir, outside)
+          : fileManager,
+        undefined,
+        cons,
+        request.strictDetection === true
+      )
+  );
+  if (detectResult.status === "error") {
+    return { status: "error", message: detectResult.message };
+  }
+  cons.success(detectResult.message);
+
+  cons.step("Loading config...");
+  const { result: configResult, config: loaded } = await traced(
+    tracer,
+    "load-config",
+    () => loadConfigStep(projectDir, fileManager, request.configPath)
+  );
+  if (configResult.status === "error" || loaded === null) {
+    return { status: "error", message: configResult.message };
+  }
+  cons.success(configResult.message);
+
+  if (only.length > 0 && enable.length + disable.length > 0) {
+    const message = "--only cannot be combined with --enable/--disable";
+    return { status: "error", message };
+  }
+  const overrideError =
+    only.length > 0
+      ? validateOnlyRunners(only, loaded)
+      : validateRunnerOverrides(enable, disable, loaded);
+  if (overrideError !== null) {
+    return { status: "error", message: overrideError };
+  }
+
+  // --only runs the named runners even where config or detection says not to
+  const setup: CheckSetup =
+    only.length > 0
+      ? {
+          languages: onlyRunners(only, loaded),
+          config: withRunnerOverrides(loaded, only, []),
+          loaded,
+        }
+      : {
+          languages: withEnabledRunners(withCustomRunners(detected, loaded), enable),
+          config: withRunnerOverrides(loaded, enable, disable),
+          loaded,
+        };
+  return { status: "ok", setup };
+}
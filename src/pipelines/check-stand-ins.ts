@@ -0,0 +1,50 @@
+import { dirname, join, resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
+import { STAGED_DIR, STDIN_DIR } from "@/models/paths";
+import type { PipelineContext } from "@/pipelines/types";
+import { listPartiallyStagedFiles, readStagedContent } from "@/utils/changed-files";
+
+/**
+ * Write the staged content of each partially staged file to a copy under
+ * STAGED_DIR, so `--staged` checks what is committed rather than the disk.
+ * Returns the copies as project-relative copy path → the file it stands for.
+ */
+export async function writeStagedStandIns(
+  staged: readonly string[],
+  projectDir: string,
+  commandRunner: CommandRunner,
+  fileManager: FileManager
+): Promise<Map<string, string>> {
+  const standIns = new Map<string, string>();
+  const partial = await listPartiallyStagedFiles(staged, projectDir, commandRunner);
+  for (const file of partial) {
+    const copy = join(STAGED_DIR, file);
+    const copyPath = resolve(projectDir, copy);
+    const content = await readStagedContent(file, projectDir, commandRunner);
+    await fileManager.mkdir(dirname(copyPath), { parents: true });
+    await fileManager.writeText(copyPath, content);
+    standIns.set(copy, file);
+  }
+  return standIns;
+}
+
+/**
+ * --stdin: write the piped buffer to a copy at the same project path under
+ * STDIN_DIR, reported as the file it stands for. Returns the copy's
+ * project-relative path.
+ */
+export async function writeStdinStandIn(
+  stdinFile: string,
+  ctx: PipelineContext
+): Promise<string> {
+  if (ctx.readStdin === undefined) {
+    throw new Error("standard input is not available");
+  }
+  const copy = join(STDIN_DIR, stdinFile);
+  const copyPath = resolve(ctx.projectDir, copy);
+  const content = await ctx.readStdin();
+  await ctx.fileManager.mkdir(dirname(copyPath), { parents: true });
+  await ctx.fileManager.writeText(copyPath, content);
+  return copy;
+}
@@ -1,61 +1,374 @@
+import { resolve } from "node:path";
+import { configPathFromFlags } from "@/config/config-file";
+import type { PostRunConfig, ResolvedConfig } from "@/config/schema";
+import { LimitedCommandRunner } from "@/infra/command-runner";
+import { IgnoringFileManager } from "@/infra/ignoring-file-manager";
+import { EXIT_FINDINGS, EXIT_RUNNER_ERROR } from "@/models/exit-code";
+import type { LintIssue } from "@/models/lint-issue";
+import { clearRunnerCache } from "@/models/runner-cache";
+import type { RunnerReport } from "@/models/runner-report";
+import { parsePaths } from "@/pipelines/check-flags";
+import { type CheckOptions, parseCheckOptions } from "@/pipelines/check-options";
+import { checkOutputFor, makeCheckPass } from "@/pipelines/check-pass";
+import {
+  recordBaseline,
+  recordMetrics,
+  recordRunManifest,
+} from "@/pipelines/check-records";
+import { runTimedPasses } from "@/pipelines/check-repeat";
+import { reportCheck } from "@/pipelines/check-report";
+import { type FileScope, isUnder, resolveFileScope } from "@/pipelines/check-scope";
+import { type CheckSetup, prepareCheck } from "@/pipelines/check-setup";
 import type { Pipeline, PipelineContext, PipelineResult } from "@/pipelines/types";
-import { checkStep } from "@/steps/check-step";
-import { detectLanguagesStep } from "@/steps/detect-languages";
-import { loadConfigStep } from "@/steps/load-config";
-import type { ReportFormat } from "@/steps/report-step";
+import { diffStep } from "@/steps/diff-step";
+import { type FixScope, fixStep } from "@/steps/fix-step";
+import { goToolchainStep } from "@/steps/go-toolchain";
+import { postRunPayload, postRunStep } from "@/steps/post-run-step";
 import { reportStep } from "@/steps/report-step";
+import { auditSuppressionsStep } from "@/steps/suppressions-step";
+import { loadIgnoreMatcher, type PathMatcher } from "@/utils/ignore-file";
+import { parseRunnerList } from "@/utils/parse";
+import { listProjectFiles } from "@/utils/project-files";
+import { traced } from "@/utils/trace";
 
-export const checkPipeline: Pipeline = {
-  async run(ctx: PipelineContext): Promise<PipelineResult> {
-    const { projectDir, fileManager, commandRunner, console: cons } = ctx;
+/** --report-suppressions: list every suppression comment instead of checking */
+async function reportSuppressions(
+  ctx: PipelineContext,
+  loaded: ResolvedConfig
+): Promise<PipelineResult> {
+  const { projectDir, fileManager, console: cons } = ctx;
+  // commander maps --no-ignore to ignore: false
+  const ignore =
+    ctx.flags.ignore === false
+      ? null
+      : await loadIgnoreMatcher(projectDir, fileManager);
+  const { result, suppressions } = await auditSuppressionsStep(
+    projectDir,
+    loaded,
+    fileManager,
+    ignore
+  );
+  for (const s of suppressions) {
+    const reason = s.reason !== "" ? s.reason : "(no reason — suppresses nothing)";
+    cons.info(`${s.file}:${s.line}  ${s.rule}  ${reason}`);
+  }
+  if (result.status === "error") {
+    const issueCount = suppressions.filter((s) => s.reason === "").length;
+    return {
+      status: "error",
+      message: result.message,
+      issueCount,
+      exitCode: EXIT_FINDINGS,
+    };
+  }
+  cons.success(result.message);
+  return { status: "ok", issueCount: 0 };
+}
+
+/** A finished run: how it ends, and what it found for the post_run hook */
+interface CheckRun {
+  result: PipelineResult;
+  issues: LintIssue[];
+  runners: readonly RunnerReport[];
+  baselined: ReadonlySet<string>;
+}
+
+/** A run that ended without running the runners */
+function nothingFound(result: PipelineResult): CheckRun {
+  return { result, issues: [], runners: [], baselined: new Set() };
+}
 
-    cons.step("Detecting languages...");
-    const { result: detectResult, languages } = await detectLanguagesStep(
+interface CheckRequest {
+  ctx: PipelineContext;
+  setup: CheckSetup;
+  /** --path entries, project-relative */
+  paths: readonly string[];
+  /** The --path subtrees; undefined when they restrict nothing */
+  scope: readonly string[] | undefined;
+  outside: PathMatcher | undefined;
+  /** When the run began, from Date.now() */
+  started: number;
+}
+
+/** Everything `check` does once its config is loaded */
+async function runCheck(request: CheckRequest): Promise<CheckRun> {
+  const { ctx, setup, paths, scope, outside, started } = request;
+  const { projectDir, fileManager, commandRunner, console: cons } = ctx;
+  const { languages, config, loaded } = setup;
+
+  if (ctx.flags.reportSuppressions === true) {
+    return nothingFound(await reportSuppressions(ctx, loaded));
+  }
+
+  const pathScoped = scope !== undefined;
+  const parsed = parseCheckOptions(ctx.flags, projectDir, loaded, pathScoped);
+  if (parsed.status === "error") {
+    return nothingFound({ status: "error", message: parsed.message });
+  }
+  const { options } = parsed;
+  const { format, output, tee } = options;
+
+  if (languages.some((plugin) => plugin.id === "go")) {
+    const toolchain = await goToolchainStep(
       projectDir,
-      fileManager
+      fileManager,
+      commandRunner,
+      cons,
+      config.ignorePaths
     );
-    if (detectResult.status === "error") {
-      return { status: "error", message: detectResult.message };
+    if (toolchain.status === "warn") cons.warning(toolchain.message);
+  }
+
+  cons.step("Running checks...");
+  const resolved = await resolveFileScope({
+    ctx,
+    config,
+    languages,
+    paths,
+    outside,
+    staged: options.staged,
+    ref: options.ref,
+    module: options.module,
+    sinceLastRun: options.sinceLastRun,
+    baselinePath: options.baselinePath,
+    stdinFile: options.stdinFile,
+  });
+  if (resolved.status === "error") {
+    const { message, exitCode } = resolved;
+    // Bad flags carry no exit code; a failing git does
+    return nothingFound({
+      status: "error",
+      message,
+      ...(exitCode !== undefined && { exitCode }),
+    });
+  }
+  const standIns =
+    resolved.status === "ok" ? resolved.scope.standIns : resolved.standIns;
+  try {
+    if (resolved.status === "empty") {
+      // Nothing to check: an empty report, in whatever format was asked for
+      await reportStep({
+        issues: [],
+        format,
+        console: cons,
+        fileManager,
+        ...(output !== undefined && { outputPath: output }),
+        tee,
+        projectDir,
+        outcome: { passed: true, durationMs: Date.now() - started },
+      });
+      if (resolved.manifest !== undefined) {
+        const { manifest } = resolved;
+        await recordRunManifest(projectDir, manifest, [], new Set(), [], ctx);
+      }
+      cons.success(resolved.message);
+      return nothingFound({ status: "ok", issueCount: 0 });
+    }
+    return await checkInScope(request, options, resolved.scope);
+  } finally {
+    // Kept until the run is done so the recheck after --fix reads them too
+    for (const copy of standIns?.keys() ?? []) {
+      await fileManager.delete(resolve(projectDir, copy));
     }
-    cons.success(detectResult.message);
+  }
+}
 
-    cons.step("Loading config...");
-    const { result: configResult, config } = await loadConfigStep(
-      projectDir,
-      fileManager
+/** The run past resolveFileScope: the passes, --fix/--diff, records and report */
+async function checkInScope(
+  request: CheckRequest,
+  options: CheckOptions,
+  fileScope: FileScope
+): Promise<CheckRun> {
+  const { ctx, setup, scope, started } = request;
+  const { projectDir, fileManager, commandRunner, console: cons } = ctx;
+  const { languages, config } = setup;
+  const { maxProcs, batchSize, repeat, baselinePath, updateBaseline, diff } = options;
+  const { files, standIns, ignore, checkIgnore, manifest } = fileScope;
+  const fixPaths = scope ?? fileScope.affected;
+
+  const out = checkOutputFor(ctx, options, config);
+  const runChecks = makeCheckPass({
+    ctx,
+    languages,
+    config,
+    options,
+    scope: fileScope,
+    paths: scope,
+    output: out,
+  });
+  // --repeat N: N-1 silent passes, then the one reported as usual, each timed
+  const timed = await runTimedPasses(runChecks, repeat, cons, ctx.tracer);
+  let { checked } = timed;
+  // --fix and --diff keep to what was checked: the real files behind stand-ins,
+  // and nothing ignored or outside --path or the affected packages
+  const fixScope: FixScope = {
+    ...(files !== undefined && {
+      files: files.map((file) => standIns?.get(file) ?? file),
+    }),
+    ...(options.module !== undefined && { module: options.module }),
+    ...(fixPaths !== undefined && { paths: fixPaths }),
+    ...(batchSize !== undefined && { batchSize }),
+  };
+  const fixFiles =
+    checkIgnore !== null
+      ? new IgnoringFileManager(fileManager, projectDir, checkIgnore)
+      : fileManager;
+
+  if (options.fix) {
+    cons.step("Applying fixes...");
+    const { result: fixResult, fixedFiles } = await traced(ctx.tracer, "fix", () =>
+      fixStep(
+        projectDir,
+        languages,
+        config,
+        new LimitedCommandRunner(commandRunner, maxProcs),
+        fixFiles,
+        checked.issues,
+        checked.runners,
+        cons,
+        fixScope
+      )
     );
-    if (configResult.status === "error" || config === null) {
-      return { status: "error", message: configResult.message };
+    cons.success(fixResult.message);
+    if (fixedFiles > 0) {
+      cons.step("Re-running checks...");
+      checked = await traced(ctx.tracer, "recheck", runChecks);
     }
-    cons.success(configResult.message);
+  }
 
-    cons.step("Running checks...");
-    const {
-      result: checkResult,
-      issues,
-      newIssueCount,
-    } = await checkStep(
-      projectDir,
-      languages,
-      config,
-      commandRunner,
-      fileManager,
-      cons
+  if (diff) {
+    cons.step("Previewing fixes...");
+    const { result: diffResult, lines } = await traced(ctx.tracer, "diff", () =>
+      diffStep(
+        projectDir,
+        languages,
+        config,
+        new LimitedCommandRunner(commandRunner, maxProcs),
+        fixFiles,
+        checked.issues,
+        checked.runners,
+        cons,
+        fixScope
+      )
     );
+    for (const line of lines) cons.info(line);
+    cons.success(diffResult.message);
+  }
+
+  const { result: checkResult, issues, baselined, runners } = checked;
+
+  if (updateBaseline) {
+    const result = await recordBaseline(issues, runners, baselinePath, ctx);
+    // Every finding is in the baseline now
+    const accepted = new Set(issues.map((issue) => issue.fingerprint));
+    return { result, issues, runners, baselined: accepted };
+  }
+
+  if (manifest !== undefined) {
+    await recordRunManifest(projectDir, manifest, issues, baselined, runners, ctx);
+  }
+
+  await reportCheck({
+    ctx,
+    checked,
+    options,
+    output: out,
+    samples: timed.samples,
+    durationMs: Date.now() - started,
+  });
 
-    const rawFormat = ctx.flags.format;
-    const format: ReportFormat = rawFormat === "sarif" ? "sarif" : "text";
-    await reportStep(issues, format, cons, fileManager);
+  if (typeof ctx.flags.metrics === "string") {
+    const scanned =
+      files ??
+      (await listProjectFiles(projectDir, config.ignorePaths, ignore, fileManager));
+    await recordMetrics(ctx.flags.metrics, ctx, {
+      durationMs: Date.now() - started,
+      filesScanned: scanned.length,
+      passed: checkResult.status !== "error",
+      issues,
+      baselined,
+      runners,
+    });
+  }
+
+  const found = { issues, runners, baselined };
+  if (checkResult.status === "error") {
+    const { message } = checkResult;
+    const { failingIssueCount: issueCount, exitCode } = checked;
+    return { result: { status: "error", message, issueCount, exitCode }, ...found };
+  }
+
+  cons.success(checkResult.message);
+  return { result: { status: "ok", issueCount: checked.newIssueCount }, ...found };
+}
 
-    if (checkResult.status === "error") {
-      return {
-        status: "error",
-        message: checkResult.message,
-        issueCount: newIssueCount,
-      };
+/**
+ * Run the [post_run] hook however the run ended, with only its new findings
+ * counted. A failing hook fails a passing run only when it is required;
+ * otherwise it is a warning.
+ */
+async function withPostRun(
+  ctx: PipelineContext,
+  postRun: PostRunConfig,
+  run: CheckRun
+): Promise<PipelineResult> {
+  const { result } = run;
+  // Without an exit code the run never started: bad flags or config
+  if (result.status === "error" && result.exitCode === undefined) return result;
+  const passed = result.status !== "error";
+  const payload = postRunPayload(run.issues, run.runners, run.baselined, passed);
+  const hook = await postRunStep(postRun, payload, ctx.projectDir, ctx.commandRunner);
+  if (hook.status !== "error") return result;
+  // A failing run says why already
+  if (postRun.required && passed) {
+    return { status: "error", message: hook.message, exitCode: EXIT_RUNNER_ERROR };
+  }
+  ctx.console.warning(hook.message);
+  return result;
+}
+
+export const checkPipeline: Pipeline = {
+  async run(ctx: PipelineContext): Promise<PipelineResult> {
+    const { projectDir, fileManager, commandRunner, console: cons } = ctx;
+    const started = Date.now();
+
+    if (ctx.flags.clearCache === true) {
+      const removed = await clearRunnerCache(projectDir, fileManager);
+      cons.success(`Cleared ${removed} cached runner result(s)`);
+      return { status: "ok", issueCount: 0 };
+    }
+
+    // --path: detection, file-oriented runners and findings keep to these subtrees
+    const parsedPaths = await parsePaths(ctx.flags, projectDir, fileManager);
+    if (parsedPaths.status === "error") {
+      return { status: "error", message: parsedPaths.message };
+    }
+    const { paths } = parsedPaths;
+    // Naming the root itself restricts nothing
+    const scope = paths.length > 0 && !paths.includes(".") ? paths : undefined;
+    const outside: PathMatcher | undefined =
+      scope !== undefined ? (relPath) => !isUnder(relPath, scope) : undefined;
+
+    const configPath = configPathFromFlags(ctx.flags);
+    const prepared = await prepareCheck({
+      projectDir,
+      fileManager,
+      console: cons,
+      ...(configPath !== undefined && { configPath }),
+      only: parseRunnerList(ctx.flags.only),
+      enable: parseRunnerList(ctx.flags.enable),
+      disable: parseRunnerList(ctx.flags.disable),
+      ...(outside !== undefined && { outside }),
+      ...(ctx.flags.strictDetection === true && { strictDetection: true }),
+      ...(ctx.tracer !== undefined && { tracer: ctx.tracer }),
+    });
+    if (prepared.status === "error") {
+      return { status: "error", message: prepared.message };
     }
 
-    cons.success(checkResult.message);
-    return { status: "ok", issueCount: newIssueCount };
+    const { setup } = prepared;
+    const run = await runCheck({ ctx, setup, paths, scope, outside, started });
+    const { postRun } = setup.config;
+    return postRun !== undefined ? withPostRun(ctx, postRun, run) : run.result;
   },
 };
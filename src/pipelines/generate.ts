@@ -1,3 +1,5 @@
+import { configPathFromFlags } from "@/config/config-file";
+import { EXIT_FINDINGS, EXIT_RUNNER_ERROR } from "@/models/exit-code";
 import type { Pipeline, PipelineContext, PipelineResult } from "@/pipelines/types";
 import { detectLanguagesStep } from "@/steps/detect-languages";
 import { generateConfigsStep } from "@/steps/generate-configs";
@@ -11,7 +13,10 @@ export const generatePipeline: Pipeline = {
     cons.step("Detecting languages...");
     const { result: detectResult, languages } = await detectLanguagesStep(
       projectDir,
-      fileManager
+      fileManager,
+      undefined,
+      cons,
+      ctx.flags.strictDetection === true
     );
     if (detectResult.status === "error") {
       return { status: "error", message: detectResult.message };
@@ -21,7 +26,8 @@ export const generatePipeline: Pipeline = {
     cons.step("Loading config...");
     const { result: configResult, config } = await loadConfigStep(
       projectDir,
-      fileManager
+      fileManager,
+      configPathFromFlags(ctx.flags)
     );
     if (configResult.status === "error" || config === null) {
       return { status: "error", message: configResult.message };
@@ -34,7 +40,12 @@ export const generatePipeline: Pipeline = {
       cons.step("Validating configs...");
       const validateResult = await validateConfigsStep(projectDir, fileManager);
       if (validateResult.status === "error") {
-        return { status: "error", message: validateResult.message };
+        // Stale configs are what --check looks for, its findings
+        return {
+          status: "error",
+          message: validateResult.message,
+          exitCode: EXIT_FINDINGS,
+        };
       }
       cons.success(validateResult.message);
     } else {
@@ -43,10 +54,13 @@ export const generatePipeline: Pipeline = {
         projectDir,
         languages,
         config,
-        fileManager
+        fileManager,
+        "merge",
+        ctx.flags.force === true
       );
       if (genResult.status === "error") {
-        return { status: "error", message: genResult.message };
+        const { message } = genResult;
+        return { status: "error", message, exitCode: EXIT_RUNNER_ERROR };
       }
       cons.success(genResult.message);
     }
@@ -1,35 +1,42 @@
 import { homedir } from "node:os";
 import { join } from "node:path";
+import {
+  configFormatFromFlags,
+  configFormatOf,
+  configPathFromFlags,
+  findProjectConfig,
+} from "@/config/config-file";
 import { loadMachineConfig, loadProjectConfig, resolveConfig } from "@/config/loader";
+import { PROFILES, type ProjectConfig } from "@/config/schema";
+import { DryRunFileManager } from "@/infra/dry-run-file-manager";
+import {
+  findUserConfigs,
+  userConfiguredModules,
+  withUserConfigs,
+} from "@/init/existing-configs";
+import { profileFromFlags } from "@/init/modules/profile-selection";
+import type { SkippedModule } from "@/init/plan";
+import { formatInitPlan, planFileChanges } from "@/init/plan";
 import { ALL_INIT_MODULES } from "@/init/registry";
 import { executeModules } from "@/init/runner";
 import { applyFlagDisables } from "@/init/selections";
 import type { InitContext } from "@/init/types";
 import { runWizard } from "@/init/wizard";
-import { PROJECT_CONFIG_PATH } from "@/models/paths";
+import { EXIT_RUNNER_ERROR } from "@/models/exit-code";
 import type { Pipeline, PipelineContext, PipelineResult } from "@/pipelines/types";
 import { detectLanguagesStep } from "@/steps/detect-languages";
 import { detectGitHubRepo } from "@/utils/github-repo";
 
-async function configExists(
-  projectDir: string,
-  ctx: PipelineContext
-): Promise<boolean> {
-  try {
-    await ctx.fileManager.readText(join(projectDir, PROJECT_CONFIG_PATH));
-    return true;
-  } catch {
-    return false;
-  }
-}
-
 async function buildInitContext(
   ctx: PipelineContext,
   selections: Map<string, boolean>
 ): Promise<{ initCtx: InitContext | null; error?: string }> {
   const { result: detectResult, languages } = await detectLanguagesStep(
     ctx.projectDir,
-    ctx.fileManager
+    ctx.fileManager,
+    undefined,
+    ctx.console,
+    ctx.flags.strictDetection === true
   );
   if (detectResult.status === "error") {
     return { initCtx: null, error: detectResult.message };
@@ -37,8 +44,32 @@ async function buildInitContext(
 
   const machinePath = join(homedir(), ".ai-guardrails", "config.toml");
   const machine = await loadMachineConfig(machinePath, ctx.fileManager);
-  const project = await loadProjectConfig(ctx.projectDir, ctx.fileManager);
-  const config = resolveConfig(machine, project);
+  let project: ProjectConfig;
+  try {
+    const configPath = configPathFromFlags(ctx.flags);
+    project = await loadProjectConfig(ctx.projectDir, ctx.fileManager, configPath);
+  } catch (err) {
+    const message = err instanceof Error ? err.message : String(err);
+    return { initCtx: null, error: `Config load failed: ${message}` };
+  }
+  // Generators read the profile from config, and --profile is only written
+  // to config.toml by profile-selection — so apply it here for this run
+  const flagProfile = profileFromFlags(ctx.flags);
+  if (flagProfile === null) {
+    return { initCtx: null, error: `--profile must be one of: ${PROFILES.join(", ")}` };
+  }
+  const resolved = resolveConfig(machine, project);
+  const profiled =
+    flagProfile !== undefined ? { ...resolved, profile: flagProfile } : resolved;
+  // --init-from existing: the project's own tool configs are used, not ours
+  const adopted =
+    ctx.flags.initFrom === "existing"
+      ? await findUserConfigs(ctx.projectDir, ctx.fileManager)
+      : [];
+  for (const { runner, file } of adopted) {
+    ctx.console.info(`Using the project's ${file} for ${runner}`);
+  }
+  const config = withUserConfigs(profiled, adopted);
 
   const github = await detectGitHubRepo(ctx.commandRunner, ctx.projectDir);
 
@@ -65,13 +96,39 @@ export const initPipeline: Pipeline = {
     const upgrade = ctx.flags.upgrade === true;
     const yes = ctx.flags.yes === true;
     const isInteractive = (ctx.isTTY || ctx.flags.interactive === true) && !yes;
+    // --dry-run: modules write to memory, and the plan is printed at the end
+    const dryRun =
+      ctx.flags.dryRun === true ? new DryRunFileManager(ctx.fileManager) : undefined;
+    const runCtx = dryRun !== undefined ? { ...ctx, fileManager: dryRun } : ctx;
 
-    const exists = await configExists(ctx.projectDir, ctx);
-    if (exists && !force && !upgrade) {
+    // --config: the config lives outside the project, which gets none of its own
+    const sharedConfig = configPathFromFlags(ctx.flags) !== undefined;
+    let existing: string | undefined;
+    try {
+      if (!sharedConfig) {
+        existing = await findProjectConfig(ctx.projectDir, ctx.fileManager);
+      }
+    } catch (err) {
+      const message = err instanceof Error ? err.message : String(err);
+      return { status: "error", message: `Config load failed: ${message}` };
+    }
+    if (existing !== undefined && !force && !upgrade) {
       return {
         status: "error",
-        message:
-          ".ai-guardrails/config.toml already exists. Use --force to overwrite or --upgrade to refresh.",
+        message: `${existing} already exists. Use --force to overwrite or --upgrade to refresh.`,
+      };
+    }
+    // The existing config keeps its format; writing the other one beside it
+    // would leave two configs, which every later run rejects
+    const format = configFormatFromFlags(ctx.flags);
+    if (
+      existing !== undefined &&
+      format !== undefined &&
+      configFormatOf(existing) !== format
+    ) {
+      return {
+        status: "error",
+        message: `${existing} already exists. Remove it to switch to --config-format ${format}.`,
       };
     }
 
@@ -82,7 +139,7 @@ export const initPipeline: Pipeline = {
     }
 
     // Build InitContext once for language detection and config loading.
-    const preliminary = await buildInitContext(ctx, new Map());
+    const preliminary = await buildInitContext(runCtx, new Map());
     if (preliminary.initCtx === null) {
       return {
         status: "error",
@@ -98,16 +155,37 @@ export const initPipeline: Pipeline = {
       selections = applyFlagDisables(ALL_INIT_MODULES, ctx.flags);
     }
 
+    // A tool with its own config file never gets ours, on --upgrade too
+    for (const id of userConfiguredModules(preliminary.initCtx.config)) {
+      selections.set(id, false);
+    }
+    if (sharedConfig) {
+      for (const mod of ALL_INIT_MODULES) {
+        if (mod.category === "profile") selections.set(mod.id, false);
+      }
+    }
+
     // Reuse the already-built context — just swap in the final selections.
     const initCtx = { ...preliminary.initCtx, selections };
 
-    const results = await executeModules(ALL_INIT_MODULES, initCtx);
+    const skipped: SkippedModule[] = [];
+    const results = await executeModules(ALL_INIT_MODULES, initCtx, (mod, result) => {
+      if (result.status === "skipped") skipped.push({ name: mod.name, result });
+    });
+    if (dryRun !== undefined) {
+      const files = await planFileChanges(dryRun, ctx.fileManager, ctx.projectDir);
+      for (const line of formatInitPlan(files, skipped)) ctx.console.info(line);
+    }
     const errorMessages = results
       .filter((r) => r.status === "error")
       .map((r) => r.message);
 
     return errorMessages.length > 0
-      ? { status: "error", message: `Init failed: ${errorMessages.join("; ")}` }
+      ? {
+          status: "error",
+          message: `Init failed: ${errorMessages.join("; ")}`,
+          exitCode: EXIT_RUNNER_ERROR,
+        }
       : { status: "ok" };
   },
 };
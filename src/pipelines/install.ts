@@ -1,20 +1,26 @@
 import { homedir } from "node:os";
 import { join } from "node:path";
 import { loadMachineConfig, loadProjectConfig, resolveConfig } from "@/config/loader";
+import type { ProjectConfig } from "@/config/schema";
 import { ALL_INIT_MODULES } from "@/init/registry";
 import { executeModules } from "@/init/runner";
 import { applyFlagDisables } from "@/init/selections";
 import type { InitContext } from "@/init/types";
+import { EXIT_RUNNER_ERROR } from "@/models/exit-code";
 import type { Pipeline, PipelineContext, PipelineResult } from "@/pipelines/types";
 import { detectLanguagesStep } from "@/steps/detect-languages";
 import { installHooksStep } from "@/steps/install-hooks";
+import { installToolsStep } from "@/steps/install-tools";
+import { detectJsPackageManager } from "@/utils/package-json";
 
 async function buildInstallContext(
   ctx: PipelineContext
 ): Promise<{ initCtx: InitContext | null; error?: string }> {
   const { result: detectResult, languages } = await detectLanguagesStep(
     ctx.projectDir,
-    ctx.fileManager
+    ctx.fileManager,
+    undefined,
+    ctx.console
   );
   if (detectResult.status === "error") {
     return { initCtx: null, error: detectResult.message };
@@ -22,10 +28,18 @@ async function buildInstallContext(
 
   const machinePath = join(homedir(), ".ai-guardrails", "config.toml");
   const machine = await loadMachineConfig(machinePath, ctx.fileManager);
-  const project = await loadProjectConfig(ctx.projectDir, ctx.fileManager);
+  let project: ProjectConfig;
+  try {
+    project = await loadProjectConfig(ctx.projectDir, ctx.fileManager);
+  } catch (err) {
+    const message = err instanceof Error ? err.message : String(err);
+    return { initCtx: null, error: `Config load failed: ${message}` };
+  }
   const config = resolveConfig(machine, project);
 
   const selections = applyFlagDisables(ALL_INIT_MODULES, ctx.flags);
+  // installToolsStep below installs what tool-install would only list
+  selections.set("tool-install", false);
 
   const initCtx: InitContext = {
     projectDir: ctx.projectDir,
@@ -43,6 +57,21 @@ async function buildInstallContext(
   return { initCtx };
 }
 
+/** Config files, init modules and Claude hooks. Returns an error message or null. */
+async function runMachineSetup(initCtx: InitContext): Promise<string | null> {
+  const results = await executeModules(ALL_INIT_MODULES, initCtx);
+  const errorMessages = results
+    .filter((r) => r.status === "error")
+    .map((r) => r.message);
+
+  if (errorMessages.length > 0) {
+    return `Install failed: ${errorMessages.join("; ")}`;
+  }
+
+  const hooksResult = await installHooksStep(initCtx.fileManager, initCtx.console);
+  return hooksResult.status === "error" ? hooksResult.message : null;
+}
+
 export const installPipeline: Pipeline = {
   async run(ctx: PipelineContext): Promise<PipelineResult> {
     const { initCtx, error } = await buildInstallContext(ctx);
@@ -50,22 +79,30 @@ export const installPipeline: Pipeline = {
       return { status: "error", message: error ?? "Language detection failed" };
     }
 
-    const results = await executeModules(ALL_INIT_MODULES, initCtx);
-    const errorMessages = results
-      .filter((r) => r.status === "error")
-      .map((r) => r.message);
-
-    if (errorMessages.length > 0) {
-      return {
-        status: "error",
-        message: `Install failed: ${errorMessages.join("; ")}`,
-      };
+    const dryRun = ctx.flags.dryRun === true;
+    if (!dryRun) {
+      const setupError = await runMachineSetup(initCtx);
+      if (setupError !== null) {
+        return { status: "error", message: setupError, exitCode: EXIT_RUNNER_ERROR };
+      }
     }
 
-    const hooksResult = await installHooksStep(ctx.fileManager, ctx.console);
-    if (hooksResult.status === "error") {
-      return { status: "error", message: hooksResult.message };
+    ctx.console.step(dryRun ? "Planning tool installs (dry run)" : "Installing tools");
+    const { result } = await installToolsStep(
+      ctx.projectDir,
+      initCtx.languages,
+      initCtx.config,
+      ctx.commandRunner,
+      ctx.console,
+      {
+        dryRun,
+        jsPackageManager: await detectJsPackageManager(ctx.projectDir, ctx.fileManager),
+      }
+    );
+    if (result.status === "error") {
+      return { status: "error", message: result.message, exitCode: EXIT_RUNNER_ERROR };
     }
+    ctx.console.success(result.message);
 
     return { status: "ok" };
   },
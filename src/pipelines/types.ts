@@ -3,6 +3,8 @@ import type { CommandRunner } from "@/infra/command-runner";
 import type { Console } from "@/infra/console";
 import type { FileManager } from "@/infra/file-manager";
 import type { ReadlineHandle } from "@/init/prompt";
+import type { ExitCode } from "@/models/exit-code";
+import type { Tracer } from "@/utils/trace";
 
 export interface PipelineContext {
   projectDir: string;
@@ -13,12 +15,18 @@ export interface PipelineContext {
   flags: Record<string, unknown>;
   isTTY: boolean;
   createReadline: () => ReadlineHandle;
+  /** Everything piped to stdin (`check --stdin`); absent where nothing can be piped */
+  readStdin?: () => Promise<string>;
+  /** Records where the run spends its time (`check --trace`) */
+  tracer?: Tracer;
 }
 
 export interface PipelineResult {
   status: "ok" | "error";
   message?: string;
   issueCount?: number;
+  /** How an error exits, where not a usage error (`check`); see ExitCode */
+  exitCode?: ExitCode;
 }
 
 export interface Pipeline {
@@ -0,0 +1,134 @@
+import { resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
+import type { LintIssue } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
+import { safeParseJson } from "@/utils/parse";
+import { mapShards } from "@/utils/shards";
+
+/** Shape of a single entry in `actionlint -format '{{json .}}'` output */
+interface ActionlintError {
+  message: string;
+  filepath: string;
+  line: number;
+  column: number;
+  kind: string;
+}
+
+function isActionlintError(value: unknown): value is ActionlintError {
+  return (
+    typeof value === "object" &&
+    value !== null &&
+    "message" in value &&
+    typeof value.message === "string" &&
+    "filepath" in value &&
+    typeof value.filepath === "string" &&
+    "line" in value &&
+    typeof value.line === "number" &&
+    "column" in value &&
+    typeof value.column === "number" &&
+    "kind" in value &&
+    typeof value.kind === "string"
+  );
+}
+
+/**
+ * Parse `actionlint -format '{{json .}}'` stdout into raw issues without
+ * fingerprints. Rules are `actionlint/<kind>`; every finding is an error.
+ * Returns [] on malformed/empty input.
+ */
+export function parseActionlintOutput(
+  stdout: string,
+  projectDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const parsed = safeParseJson(stdout);
+  if (!Array.isArray(parsed)) return [];
+
+  return parsed.filter(isActionlintError).map(
+    (entry): Omit<LintIssue, "fingerprint"> => ({
+      rule: `actionlint/${entry.kind}`,
+      linter: "actionlint",
+      file: resolve(projectDir, entry.filepath),
+      line: entry.line,
+      col: entry.column,
+      message: entry.message,
+      severity: "error",
+    })
+  );
+}
+
+export const WORKFLOW_GLOB = ".github/workflows/*.{yml,yaml}";
+
+/**
+ * Glob for GitHub Actions workflow files.
+ * When a changed-file list is given, select from it instead.
+ */
+export async function findWorkflows(
+  fileManager: FileManager,
+  projectDir: string,
+  ignorePaths: readonly string[],
+  files?: readonly string[]
+): Promise<string[]> {
+  if (files !== undefined) return matchFiles(files, WORKFLOW_GLOB);
+  const found = await fileManager.glob(WORKFLOW_GLOB, projectDir, ignorePaths);
+  return found.sort();
+}
+
+export const actionlintRunner: LinterRunner = {
+  id: "actionlint",
+  name: "actionlint",
+  configFile: ".github/actionlint.yaml",
+  fileScoped: true,
+  installHint: {
+    description: "GitHub Actions workflow linter",
+    brew: "brew install actionlint",
+    go: "go install github.com/rhysd/actionlint/cmd/actionlint@latest",
+  },
+  versionArgs: ["actionlint", "-version"],
+  cache: {
+    inputs: [WORKFLOW_GLOB, ".github/actionlint.{yaml,yml}"],
+  },
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    try {
+      const result = await commandRunner.run(["actionlint", "-version"]);
+      return result.exitCode === 0;
+    } catch {
+      return false;
+    }
+  },
+
+  async run({
+    projectDir,
+    config,
+    commandRunner,
+    fileManager,
+    files: changed,
+    batchSize,
+  }: RunOptions): Promise<LintIssue[]> {
+    const files = await findWorkflows(
+      fileManager,
+      projectDir,
+      config.ignorePaths,
+      changed
+    );
+    if (files.length === 0) return [];
+
+    // actionlint picks up .github/actionlint.yaml from the repository on its own
+    const raw = await mapShards(files, batchSize, async (shard) => {
+      const result = await commandRunner.run(
+        ["actionlint", "-format", "{{json .}}", ...shard],
+        { cwd: projectDir }
+      );
+      // Exit 1 means errors were found; 2 and 3 are bad options or a crash
+      if (result.exitCode !== 0 && result.exitCode !== 1) {
+        const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+        throw new Error(`actionlint failed: ${detail}`);
+      }
+      return parseActionlintOutput(result.stdout, projectDir);
+    });
+    return applyFingerprints(raw, projectDir, fileManager);
+  },
+};
@@ -0,0 +1,17 @@
+import { isRunnerEnabled } from "@/config/schema";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+
+/**
+ * Whether `runner` takes part in a check: enabled in config, and used by the
+ * project (`LinterRunner.appliesTo`) unless config enabled it explicitly.
+ */
+export async function isRunnerActive(
+  runner: LinterRunner,
+  opts: RunOptions
+): Promise<boolean> {
+  const { config } = opts;
+  if (!isRunnerEnabled(config, runner.id, runner.defaultEnabled)) return false;
+  if (runner.appliesTo === undefined) return true;
+  if (config.runners?.[runner.id]?.enabled === true) return true;
+  return runner.appliesTo(opts);
+}
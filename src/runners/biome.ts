@@ -1,10 +1,15 @@
 import { resolve } from "node:path";
 import type { CommandRunner } from "@/infra/command-runner";
-import type { LintIssue } from "@/models/lint-issue";
+import type { LintIssue, Severity } from "@/models/lint-issue";
+import { isJsLinter } from "@/runners/eslint";
+import { isJsFormatter } from "@/runners/prettier";
 import type { LinterRunner, RunOptions } from "@/runners/types";
 import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
 import { safeParseJson } from "@/utils/parse";
+import { pipeFix } from "@/utils/pipe-fix";
 import { resolveToolPath } from "@/utils/resolve-tool-path";
+import { forEachShard, mapShards } from "@/utils/shards";
 
 async function detectBiomeVersion(
   commandRunner: CommandRunner,
@@ -35,10 +40,16 @@ export function resetBiomeVersionCache(): void {
 }
 
 const BIOME_LINTER_ID = "biome";
+/** Files biome lints or formats — used to pick targets from a changed-file list */
+const BIOME_GLOB = "**/*.{js,jsx,mjs,cjs,ts,tsx,mts,cts,json,jsonc,css}";
 const BIOME_RULE_PREFIX = "biome/";
 
 // rdjson severity values from biome
-const SEVERITY_ERROR = "ERROR";
+const SEVERITY_BY_LEVEL: Record<string, Severity> = {
+  ERROR: "error",
+  WARNING: "warning",
+  INFO: "info",
+};
 
 interface RdjsonRange {
   // biome rdjson uses 1-based line/column (not the LSP 0-based line/character)
@@ -117,8 +128,7 @@ export function parseBiomeRdjsonOutput(
     const col = diag.location.range.start.column;
     const rule = BIOME_RULE_PREFIX + diag.code.value;
     const message = extractMessage(diag.message);
-    const severity: "error" | "warning" =
-      diag.severity === SEVERITY_ERROR ? "error" : "warning";
+    const severity = SEVERITY_BY_LEVEL[diag.severity] ?? "warning";
     return [
       {
         rule,
@@ -133,14 +143,22 @@ export function parseBiomeRdjsonOutput(
   });
 }
 
+/** Leaves formatting to prettier when it is the project's JS/TS formatter */
+async function formatterArgs(opts: RunOptions): Promise<string[]> {
+  return (await isJsFormatter("biome", opts)) ? [] : ["--formatter-enabled=false"];
+}
+
 export const biomeRunner: LinterRunner = {
   id: BIOME_LINTER_ID,
   name: "Biome",
   configFile: "biome.jsonc",
+  fileScoped: true,
   installHint: {
     description: "TypeScript/JS linter and formatter",
     npm: "npm install -D @biomejs/biome",
   },
+  versionArgs: ["biome", "--version"],
+  watchInputs: [BIOME_GLOB],
 
   async isAvailable(
     commandRunner: CommandRunner,
@@ -149,18 +167,47 @@ export const biomeRunner: LinterRunner = {
     return (await resolveToolPath("biome", projectDir ?? ".", commandRunner)) !== null;
   },
 
-  async run({
-    projectDir,
-    commandRunner,
-    fileManager,
-  }: RunOptions): Promise<LintIssue[]> {
+  async appliesTo(opts: RunOptions): Promise<boolean> {
+    return isJsLinter("biome", opts);
+  },
+
+  async run(opts: RunOptions): Promise<LintIssue[]> {
+    const { projectDir, commandRunner, fileManager, files, batchSize } = opts;
+    const targets = files !== undefined ? matchFiles(files, BIOME_GLOB) : [projectDir];
+    if (targets.length === 0) return [];
     const cmd = (await resolveToolPath("biome", projectDir, commandRunner)) ?? "biome";
-    const result = await commandRunner.run(
-      [cmd, "ci", "--reporter=rdjson", projectDir],
-      { cwd: projectDir }
-    );
-    // biome exits non-zero when issues are found — parse stdout regardless
-    const raw = parseBiomeRdjsonOutput(result.stdout, projectDir);
+    const formatter = await formatterArgs(opts);
+    const raw = await mapShards(targets, batchSize, async (shard) => {
+      const result = await commandRunner.run(
+        [cmd, "ci", "--reporter=rdjson", ...formatter, ...shard],
+        { cwd: projectDir }
+      );
+      // biome exits non-zero when issues are found — parse stdout regardless
+      return parseBiomeRdjsonOutput(result.stdout, projectDir);
+    });
     return applyFingerprints(raw, projectDir, fileManager);
   },
+
+  async fix(opts: RunOptions): Promise<void> {
+    const { projectDir, commandRunner, files, batchSize } = opts;
+    const targets = files !== undefined ? matchFiles(files, BIOME_GLOB) : [projectDir];
+    if (targets.length === 0) return;
+    const cmd = (await resolveToolPath("biome", projectDir, commandRunner)) ?? "biome";
+    const formatter = await formatterArgs(opts);
+    // --write applies safe fixes and formatting only; unsafe fixes stay manual
+    await forEachShard(targets, batchSize, (shard) =>
+      commandRunner.run([cmd, "check", "--write", ...formatter, ...shard], {
+        cwd: projectDir,
+      })
+    );
+  },
+
+  async previewFix(opts: RunOptions, file: string, content: string): Promise<string> {
+    const { projectDir, commandRunner } = opts;
+    const cmd = (await resolveToolPath("biome", projectDir, commandRunner)) ?? "biome";
+    const formatter = await formatterArgs(opts);
+    const args = [cmd, "check", "--write", ...formatter, `--stdin-file-path=${file}`];
+    // Exit 1 means unfixable findings remain; stdout still holds the fix
+    return pipeFix(commandRunner, args, content, projectDir, [0, 1]);
+  },
 };
@@ -0,0 +1,186 @@
+import { join, resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
+import type { LintIssue } from "@/models/lint-issue";
+import type { InstallHint, LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
+import { parseNdjson } from "@/utils/ndjson";
+
+export const PROTO_GLOB = "**/*.proto";
+
+/** Root files that make a directory a buf module or workspace */
+export const BUF_CONFIG_FILES = ["buf.yaml", "buf.work.yaml"] as const;
+
+/** buf's exit code when it found lint failures or breaking changes */
+const BUF_FINDINGS_EXIT = 100;
+
+/** Shape of one annotation in `buf lint|breaking --error-format=json` output */
+interface BufAnnotation {
+  path: string;
+  start_line?: number;
+  start_column?: number;
+  type: string;
+  message: string;
+}
+
+function isBufAnnotation(value: unknown): value is BufAnnotation {
+  return (
+    typeof value === "object" &&
+    value !== null &&
+    "path" in value &&
+    typeof value.path === "string" &&
+    "type" in value &&
+    typeof value.type === "string" &&
+    "message" in value &&
+    typeof value.message === "string"
+  );
+}
+
+/**
+ * Parse `buf lint --error-format=json` (or `buf breaking`) NDJSON into raw
+ * issues without fingerprints. Rules are `<linter>/<type>`, e.g.
+ * `buf/FIELD_LOWER_SNAKE_CASE`; every finding is an error. A file-level
+ * finding without a position is reported at 1:1.
+ */
+export function parseBufOutput(
+  ndjson: string,
+  projectDir: string,
+  linter = "buf"
+): Omit<LintIssue, "fingerprint">[] {
+  return parseNdjson(ndjson)
+    .filter(isBufAnnotation)
+    .map(
+      (entry): Omit<LintIssue, "fingerprint"> => ({
+        rule: `${linter}/${entry.type}`,
+        linter,
+        file: resolve(projectDir, entry.path),
+        line: entry.start_line ?? 1,
+        col: entry.start_column ?? 1,
+        message: entry.message,
+        severity: "error",
+      })
+    );
+}
+
+/**
+ * Glob for .proto files. When a changed-file list is given, select from it
+ * instead.
+ */
+export async function findProtoFiles(
+  fileManager: FileManager,
+  projectDir: string,
+  ignorePaths: readonly string[],
+  files?: readonly string[]
+): Promise<string[]> {
+  if (files !== undefined) return matchFiles(files, PROTO_GLOB);
+  const found = await fileManager.glob(PROTO_GLOB, projectDir, ignorePaths);
+  return found.sort();
+}
+
+/** Whether the project root has a buf.yaml or buf.work.yaml */
+export async function hasBufConfig(
+  fileManager: FileManager,
+  projectDir: string
+): Promise<boolean> {
+  for (const name of BUF_CONFIG_FILES) {
+    if (await fileManager.exists(join(projectDir, name))) return true;
+  }
+  return false;
+}
+
+async function bufAvailable(commandRunner: CommandRunner): Promise<boolean> {
+  try {
+    const result = await commandRunner.run(["buf", "--version"]);
+    return result.exitCode === 0;
+  } catch {
+    return false;
+  }
+}
+
+/** Run a buf subcommand and parse its annotations; other failures throw */
+async function runBuf(
+  args: readonly string[],
+  linter: string,
+  opts: RunOptions
+): Promise<LintIssue[]> {
+  const { projectDir, commandRunner, fileManager } = opts;
+  const result = await commandRunner.run(["buf", ...args, "--error-format=json"], {
+    cwd: projectDir,
+  });
+  if (result.exitCode !== 0 && result.exitCode !== BUF_FINDINGS_EXIT) {
+    const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+    throw new Error(`${linter} failed: ${detail}`);
+  }
+  const raw = parseBufOutput(result.stdout, projectDir, linter);
+  return applyFingerprints(raw, projectDir, fileManager);
+}
+
+const BUF_INSTALL_HINT: InstallHint = {
+  description: "Protobuf linter and breaking-change detector",
+  brew: "brew install bufbuild/buf/buf",
+  npm: "npm install -g @bufbuild/buf",
+  go: "go install github.com/bufbuild/buf/cmd/buf@latest",
+};
+
+export const bufRunner: LinterRunner = {
+  id: "buf",
+  name: "buf lint",
+  configFile: "buf.yaml",
+  fileScoped: true,
+  installHint: BUF_INSTALL_HINT,
+  versionArgs: ["buf", "--version"],
+  cache: {
+    inputs: [PROTO_GLOB, "**/buf.yaml", "buf.work.yaml", "**/buf.lock"],
+  },
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    return bufAvailable(commandRunner);
+  },
+
+  async run(opts: RunOptions): Promise<LintIssue[]> {
+    const { projectDir, config, fileManager } = opts;
+    const files = await findProtoFiles(
+      fileManager,
+      projectDir,
+      config.ignorePaths,
+      opts.files
+    );
+    if (files.length === 0) return [];
+    // buf reads buf.yaml/buf.work.yaml itself and lints the whole module;
+    // --path narrows it to the changed files without losing their imports
+    const paths = opts.files !== undefined ? files.flatMap((f) => ["--path", f]) : [];
+    return runBuf(["lint", ...paths], "buf", opts);
+  },
+};
+
+/**
+ * Opt-in by configuration: compares the protos against
+ * `[config] buf_breaking_against`, any buf input such as `.git#branch=main`.
+ */
+export const bufBreakingRunner: LinterRunner = {
+  id: "buf-breaking",
+  name: "buf breaking",
+  configFile: null,
+  installHint: BUF_INSTALL_HINT,
+  versionArgs: ["buf", "--version"],
+
+  async appliesTo({ config }: RunOptions): Promise<boolean> {
+    return config.values.buf_breaking_against !== undefined;
+  },
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    return bufAvailable(commandRunner);
+  },
+
+  async run(opts: RunOptions): Promise<LintIssue[]> {
+    const against = opts.config.values.buf_breaking_against;
+    if (against === undefined) {
+      throw new Error("buf-breaking needs [config] buf_breaking_against");
+    }
+    const { projectDir, config, fileManager } = opts;
+    const files = await findProtoFiles(fileManager, projectDir, config.ignorePaths);
+    if (files.length === 0) return [];
+    return runBuf(["breaking", "--against", against], "buf-breaking", opts);
+  },
+};
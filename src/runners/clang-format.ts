@@ -0,0 +1,115 @@
+import { resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import { DEFAULT_IGNORE } from "@/languages/constants";
+import type { LintIssue } from "@/models/lint-issue";
+import { C_CPP_GLOB } from "@/runners/clang-tidy";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
+import { pipeFix } from "@/utils/pipe-fix";
+import { forEachShard, mapShards } from "@/utils/shards";
+
+const CLANG_FORMAT_PATTERN =
+  /^(.+):(\d+):(\d+):\s+(?:warning|error):\s+code should be clang-formatted\s+\[-Wclang-format-violations\]$/;
+
+/**
+ * Parse `clang-format --dry-run` stderr — one diagnostic per misformatted
+ * spot, each followed by the source line and a caret — into raw issues
+ * without fingerprints.
+ */
+export function parseClangFormatOutput(
+  text: string,
+  projectDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  return text.split("\n").flatMap((line) => {
+    const match = CLANG_FORMAT_PATTERN.exec(line);
+    if (!match) return [];
+    const file = match[1] ?? "";
+    return [
+      {
+        rule: "clang-format/format",
+        linter: "clang-format",
+        file: resolve(projectDir, file),
+        line: Number.parseInt(match[2] ?? "1", 10),
+        col: Number.parseInt(match[3] ?? "1", 10),
+        message: `Code is not clang-formatted — run: clang-format -i ${file}`,
+        severity: "error",
+      } satisfies Omit<LintIssue, "fingerprint">,
+    ];
+  });
+}
+
+/** C/C++ files to format: the changed ones, or every one outside ignored dirs */
+async function findTargets({
+  projectDir,
+  config,
+  fileManager,
+  files,
+}: RunOptions): Promise<string[]> {
+  if (files !== undefined) return matchFiles(files, C_CPP_GLOB);
+  return fileManager.glob(C_CPP_GLOB, projectDir, [
+    ...DEFAULT_IGNORE,
+    ...config.ignorePaths,
+  ]);
+}
+
+export const clangFormatRunner: LinterRunner = {
+  id: "clang-format",
+  name: "clang-format",
+  configFile: ".clang-format",
+  fileScoped: true,
+  installHint: {
+    description: "C/C++ formatter",
+    brew: "brew install clang-format",
+    apt: "sudo apt install clang-format",
+  },
+  versionArgs: ["clang-format", "--version"],
+  cache: {
+    inputs: [C_CPP_GLOB, "**/.clang-format"],
+  },
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    const result = await commandRunner.run(["clang-format", "--version"]);
+    return result.exitCode === 0;
+  },
+
+  async run(opts: RunOptions): Promise<LintIssue[]> {
+    const { projectDir, commandRunner, fileManager, batchSize } = opts;
+    const targets = await findTargets(opts);
+    if (targets.length === 0) return [];
+    // The default --style=file picks up the nearest .clang-format per file
+    const raw = await mapShards(targets, batchSize, async (shard) => {
+      const result = await commandRunner.run(
+        ["clang-format", "--dry-run", "--Werror", ...shard],
+        { cwd: projectDir }
+      );
+      const issues = parseClangFormatOutput(result.stderr, projectDir);
+      // A non-zero exit without violations is a bad config or unreadable file
+      if (result.exitCode !== 0 && issues.length === 0) {
+        const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+        throw new Error(`clang-format failed: ${detail}`);
+      }
+      return issues;
+    });
+    return applyFingerprints(raw, projectDir, fileManager);
+  },
+
+  async fix(opts: RunOptions): Promise<void> {
+    const targets = await findTargets(opts);
+    await forEachShard(targets, opts.batchSize, (shard) =>
+      opts.commandRunner.run(["clang-format", "-i", ...shard], {
+        cwd: opts.projectDir,
+      })
+    );
+  },
+
+  async previewFix(
+    { projectDir, commandRunner }: RunOptions,
+    file: string,
+    content: string
+  ): Promise<string> {
+    // The .clang-format nearest the assumed path applies, as with -i
+    const args = ["clang-format", `--assume-filename=${file}`];
+    return pipeFix(commandRunner, args, content, projectDir);
+  },
+};
@@ -1,5 +1,6 @@
-import { resolve } from "node:path";
+import { join, resolve } from "node:path";
 import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
 import type { LintIssue } from "@/models/lint-issue";
 import type { LinterRunner, RunOptions } from "@/runners/types";
 import { applyFingerprints } from "@/utils/apply-fingerprints";
@@ -48,26 +49,27 @@ export function parseClangTidyOutput(
   return issues;
 }
 
-const C_CPP_GLOBS = ["**/*.cpp", "**/*.c", "**/*.cxx", "**/*.cc", "**/*.h", "**/*.hpp"];
+/** C and C++ sources and headers */
+export const C_CPP_GLOB = "**/*.{c,cc,cpp,cxx,h,hpp}";
 
-async function findCppFiles(
-  fileManager: RunOptions["fileManager"],
-  projectDir: string
-): Promise<string[]> {
-  const results = await Promise.all(
-    C_CPP_GLOBS.map((g) => fileManager.glob(g, projectDir))
-  );
-  const seen = new Set<string>();
-  const files: string[] = [];
-  for (const batch of results) {
-    for (const f of batch) {
-      if (!seen.has(f)) {
-        seen.add(f);
-        files.push(f);
-      }
+/** Where CMake and Bear usually leave the compilation database */
+const COMPILE_DB_DIRS = [".", "build"];
+
+/**
+ * Directory holding the project's `compile_commands.json`, relative to
+ * projectDir, or null when there is none. Without it clang-tidy guesses the
+ * compiler flags, and its findings are mostly missing-include noise.
+ */
+export async function findCompileDb(
+  projectDir: string,
+  fileManager: FileManager
+): Promise<string | null> {
+  for (const dir of COMPILE_DB_DIRS) {
+    if (await fileManager.exists(join(projectDir, dir, "compile_commands.json"))) {
+      return dir;
     }
   }
-  return files;
+  return null;
 }
 
 export const clangTidyRunner: LinterRunner = {
@@ -79,20 +81,30 @@ export const clangTidyRunner: LinterRunner = {
     brew: "brew install llvm",
     apt: "sudo apt install clang-tidy",
   },
+  versionArgs: ["clang-tidy", "--version"],
+  watchInputs: [C_CPP_GLOB, "compile_commands.json", "build/compile_commands.json"],
 
   async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
     const result = await commandRunner.run(["clang-tidy", "--version"]);
     return result.exitCode === 0;
   },
 
+  async appliesTo({ projectDir, fileManager }: RunOptions): Promise<boolean> {
+    return (await findCompileDb(projectDir, fileManager)) !== null;
+  },
+
   async run(opts: RunOptions): Promise<LintIssue[]> {
     const { projectDir, commandRunner, fileManager } = opts;
-    const files = await findCppFiles(fileManager, projectDir);
+    const files = await fileManager.glob(C_CPP_GLOB, projectDir);
     if (files.length === 0) return [];
 
-    const result = await commandRunner.run(["clang-tidy", "--quiet", ...files], {
-      cwd: projectDir,
-    });
+    // Enabled without a compile DB, clang-tidy searches parent dirs for one
+    const dbDir = await findCompileDb(projectDir, fileManager);
+    const dbArgs = dbDir !== null ? ["-p", dbDir] : [];
+    const result = await commandRunner.run(
+      ["clang-tidy", "--quiet", ...dbArgs, ...files],
+      { cwd: projectDir }
+    );
     const raw = parseClangTidyOutput(result.stderr, projectDir);
     return applyFingerprints(raw, projectDir, fileManager);
   },
@@ -1,6 +1,6 @@
 import { resolve } from "node:path";
 import type { CommandRunner } from "@/infra/command-runner";
-import type { LintIssue } from "@/models/lint-issue";
+import type { LintIssue, Severity } from "@/models/lint-issue";
 import type { LinterRunner, RunOptions } from "@/runners/types";
 import { applyFingerprints } from "@/utils/apply-fingerprints";
 import { parseNdjson } from "@/utils/ndjson";
@@ -33,6 +33,14 @@ function isClippyEntry(value: unknown): value is ClippyEntry {
   );
 }
 
+// note and help are advisory; unknown levels stay warnings
+const SEVERITY_BY_LEVEL: Record<string, Severity> = {
+  error: "error",
+  warning: "warning",
+  note: "info",
+  help: "info",
+};
+
 /**
  * Parse clippy NDJSON output into raw issues without fingerprints.
  * Filters build artifacts — only keeps compiler-message entries with non-null code.
@@ -66,7 +74,7 @@ export function parseClippyNdjson(
       line: primarySpan.line_start,
       col: primarySpan.column_start,
       message: msg.message,
-      severity: msg.level === "error" ? "error" : "warning",
+      severity: SEVERITY_BY_LEVEL[msg.level] ?? "warning",
     });
   }
 
@@ -76,11 +84,15 @@ export function parseClippyNdjson(
 export const clippyRunner: LinterRunner = {
   id: "clippy",
   name: "Clippy",
-  configFile: null,
+  configFile: "clippy.toml",
   installHint: {
     description: "Rust linter",
     rustup: "rustup component add clippy",
   },
+  versionArgs: ["cargo", "clippy", "--version"],
+  cache: {
+    inputs: ["**/*.rs", "**/Cargo.toml", "Cargo.lock"],
+  },
 
   async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
     const result = await commandRunner.run(["cargo", "clippy", "--version"]);
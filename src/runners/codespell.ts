@@ -1,8 +1,12 @@
-import { resolve } from "node:path";
+import { join, resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
 import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
 import type { LintIssue } from "@/models/lint-issue";
 import type { LinterRunner, RunOptions } from "@/runners/types";
 import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { hasHashHeader } from "@/utils/hash";
+import { mapShards } from "@/utils/shards";
 
 const CODESPELL_LINTER_ID = "codespell";
 const CODESPELL_RULE = "codespell/spell";
@@ -43,14 +47,56 @@ export function parseCodespellOutput(
   return issues;
 }
 
+const CODESPELLRC = ".codespellrc";
+const IGNORE_WORDS_KEY = /^\s*ignore-words(-list)?\s*=/m;
+const DICTIONARY_KEY = /^\s*dictionary\s*=/m;
+
+/**
+ * codespell's `dictionary` value for [config] codespell_dictionaries: the files
+ * after "-", the built-in dictionary, which a custom one would otherwise replace
+ */
+export function codespellDictionaryValue(dictionaries: readonly string[]): string {
+  return ["-", ...dictionaries].join(",");
+}
+
+/**
+ * `-L` and `--dictionary` from [config] codespell_ignore_words and
+ * codespell_dictionaries. `codespellrc` is the project's .codespellrc, which
+ * codespell reads too: when it is hand-written (no hash header) and sets either
+ * option itself, that flag is left out so the file wins.
+ */
+export function codespellArgs(config: ResolvedConfig, codespellrc = ""): string[] {
+  const handWritten = codespellrc !== "" && !hasHashHeader(codespellrc);
+  const words = config.values.codespell_ignore_words ?? [];
+  const dictionaries = config.values.codespell_dictionaries ?? [];
+  return [
+    ...(words.length > 0 && !(handWritten && IGNORE_WORDS_KEY.test(codespellrc))
+      ? ["-L", words.join(",")]
+      : []),
+    ...(dictionaries.length > 0 && !(handWritten && DICTIONARY_KEY.test(codespellrc))
+      ? [`--dictionary=${codespellDictionaryValue(dictionaries)}`]
+      : []),
+  ];
+}
+
+async function readCodespellrc(
+  projectDir: string,
+  fileManager: FileManager
+): Promise<string> {
+  const path = join(projectDir, CODESPELLRC);
+  return (await fileManager.exists(path)) ? fileManager.readText(path) : "";
+}
+
 export const codespellRunner: LinterRunner = {
   id: CODESPELL_LINTER_ID,
   name: "Codespell",
-  configFile: ".codespellrc",
+  configFile: CODESPELLRC,
+  fileScoped: true,
   installHint: {
     description: "Spell checker",
     pip: "pip install codespell",
   },
+  versionArgs: ["codespell", "--version"],
 
   async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
     const result = await commandRunner.run(["codespell", "--version"]);
@@ -59,14 +105,27 @@ export const codespellRunner: LinterRunner = {
 
   async run({
     projectDir,
+    config,
     commandRunner,
     fileManager,
+    files,
+    batchSize,
   }: RunOptions): Promise<LintIssue[]> {
-    const result = await commandRunner.run(["codespell", "--quiet-level=2"], {
-      cwd: projectDir,
-    });
-    // codespell exits non-zero when issues are found — parse stdout regardless
-    const raw = parseCodespellOutput(result.stdout, projectDir);
+    // With no file arguments codespell walks the current directory
+    if (files !== undefined && files.length === 0) return [];
+    const args = codespellArgs(config, await readCodespellrc(projectDir, fileManager));
+    const spellcheck = async (targets: readonly string[]) => {
+      const result = await commandRunner.run(
+        ["codespell", "--quiet-level=2", ...args, ...targets],
+        { cwd: projectDir }
+      );
+      // codespell exits non-zero when issues are found — parse stdout regardless
+      return parseCodespellOutput(result.stdout, projectDir);
+    };
+    const raw =
+      files !== undefined
+        ? await mapShards(files, batchSize, spellcheck)
+        : await spellcheck([]);
     return applyFingerprints(raw, projectDir, fileManager);
   },
 };
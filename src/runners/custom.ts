@@ -0,0 +1,128 @@
+import { resolve } from "node:path";
+import type { CustomRunnerConfig } from "@/config/schema";
+import type { CommandRunner } from "@/infra/command-runner";
+import { DEFAULT_IGNORE } from "@/languages/constants";
+import type { LintIssue, Severity } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
+
+const FILES_PLACEHOLDER = "{files}";
+
+/** Native severity words, lower-cased, mapped onto ours; anything else is an error */
+const SEVERITY_BY_WORD: Record<string, Severity> = {
+  error: "error",
+  fatal: "error",
+  warning: "warning",
+  warn: "warning",
+  info: "info",
+  note: "info",
+  hint: "info",
+};
+
+/**
+ * Parse a custom runner's output one line at a time with its `pattern`.
+ * Lines that do not match are skipped. Optional `col`, `severity` and `rule`
+ * groups are used when captured; the rule is `<id>/<rule>` (default
+ * `<id>/issue`) so findings can be ignored and allowed like any other.
+ */
+export function parseCustomOutput(
+  output: string,
+  spec: CustomRunnerConfig,
+  projectDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const regex = new RegExp(spec.pattern);
+  const issues: Omit<LintIssue, "fingerprint">[] = [];
+  for (const line of output.split("\n")) {
+    const groups = regex.exec(line)?.groups;
+    const file = groups?.file;
+    const message = groups?.message;
+    if (groups === undefined || file === undefined || message === undefined) continue;
+    issues.push({
+      rule: `${spec.id}/${groups.rule ?? "issue"}`,
+      linter: spec.id,
+      file: resolve(projectDir, file),
+      line: Number.parseInt(groups.line ?? "", 10) || 1,
+      col: Number.parseInt(groups.col ?? "", 10) || 1,
+      message: message.trim(),
+      severity: SEVERITY_BY_WORD[groups.severity?.toLowerCase() ?? ""] ?? "error",
+    });
+  }
+  return issues;
+}
+
+/** Substitute `{projectDir}`, and expand a `{files}` argument into the files */
+export function expandCommand(
+  command: readonly string[],
+  files: readonly string[],
+  projectDir: string
+): string[] {
+  return command.flatMap((arg) =>
+    arg === FILES_PLACEHOLDER
+      ? [...files]
+      : [arg.replaceAll("{projectDir}", projectDir)]
+  );
+}
+
+/** Project-relative files matching the spec's globs, within `changed` if given */
+async function findSpecFiles(
+  spec: CustomRunnerConfig,
+  opts: RunOptions
+): Promise<string[]> {
+  const { projectDir, config, fileManager, files: changed } = opts;
+  if (changed !== undefined) {
+    return [...new Set(spec.files.flatMap((glob) => matchFiles(changed, glob)))];
+  }
+  const ignore = [...DEFAULT_IGNORE, ...config.ignorePaths];
+  const found = await Promise.all(
+    spec.files.map((glob) => fileManager.glob(glob, projectDir, ignore))
+  );
+  return [...new Set(found.flat())].sort();
+}
+
+/**
+ * Build a runner from a [[custom_runners]] table. It runs only when files
+ * match `files`, from the project root; a `{files}` argument makes it
+ * file-scoped, and `version` enables the result cache.
+ */
+export function createCustomRunner(spec: CustomRunnerConfig): LinterRunner {
+  const binary = spec.command[0] ?? spec.id;
+  const versionArgs = spec.version ?? [binary, "--version"];
+  const okExitCodes = new Set(spec.ok_exit_codes);
+  return {
+    id: spec.id,
+    name: spec.name ?? spec.id,
+    configFile: null,
+    fileScoped: spec.command.includes(FILES_PLACEHOLDER),
+    installHint: { description: spec.install ?? `Custom runner (${binary})` },
+    ...(spec.version !== undefined && {
+      versionArgs: spec.version,
+      cache: { inputs: spec.files },
+    }),
+    watchInputs: spec.files,
+
+    async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+      const result = await commandRunner.run([...versionArgs]);
+      return result.exitCode === 0;
+    },
+
+    async run(opts: RunOptions): Promise<LintIssue[]> {
+      const { projectDir, commandRunner, fileManager } = opts;
+      const files = await findSpecFiles(spec, opts);
+      if (files.length === 0) return [];
+
+      const result = await commandRunner.run(
+        expandCommand(spec.command, files, projectDir),
+        { cwd: projectDir }
+      );
+      // Tools differ in which stream they report on
+      const output = `${result.stdout}\n${result.stderr}`;
+      const raw = parseCustomOutput(output, spec, projectDir);
+      if (raw.length === 0 && !okExitCodes.has(result.exitCode)) {
+        const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+        throw new Error(`${spec.id} failed: ${detail}`);
+      }
+      return applyFingerprints(raw, projectDir, fileManager);
+    },
+  };
+}
@@ -40,7 +40,7 @@ function parseMsBuildLine(line: string): MsBuildMatch | null {
 /**
  * Parse MSBuild text output from `dotnet build` into raw issues without fingerprints.
  *
- * Respects the profile: "minimal" emits errors only; "standard" and "strict" include warnings.
+ * Respects the profile: "lenient" emits errors only; "standard" and "strict" include warnings.
  * LintIssue.file is always absolute (resolved from projectDir).
  */
 export function parseDotnetBuildOutput(
@@ -52,7 +52,7 @@ export function parseDotnetBuildOutput(
   for (const line of output.split(/\r?\n/)) {
     const parsed = parseMsBuildLine(line);
     if (!parsed) continue;
-    if (config.profile === "minimal" && parsed.severity !== "error") continue;
+    if (config.profile === "lenient" && parsed.severity !== "error") continue;
     const absFile = parsed.file.startsWith("/")
       ? parsed.file
       : resolve(projectDir, parsed.file);
@@ -76,6 +76,8 @@ export const dotnetBuildRunner: LinterRunner = {
   installHint: {
     description: ".NET SDK",
   },
+  versionArgs: ["dotnet", "--version"],
+  watchInputs: ["**/*.cs", "**/*.csproj", "**/*.sln", "**/.editorconfig"],
 
   async isAvailable(
     commandRunner: CommandRunner,
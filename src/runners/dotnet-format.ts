@@ -0,0 +1,205 @@
+import { dirname, join, resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
+import type { LintIssue } from "@/models/lint-issue";
+import { CACHE_DIR } from "@/models/paths";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
+import { safeParseJson } from "@/utils/parse";
+import { forEachShard, mapShards } from "@/utils/shards";
+
+interface FileChange {
+  LineNumber: number;
+  CharNumber: number;
+  DiagnosticId: string;
+  FormatDescription: string;
+}
+
+interface FormattedFile {
+  FilePath: string;
+  FileChanges: FileChange[];
+}
+
+function isFormatReport(value: unknown): value is FormattedFile[] {
+  return (
+    Array.isArray(value) &&
+    value.every(
+      (entry) =>
+        typeof entry === "object" &&
+        entry !== null &&
+        "FilePath" in entry &&
+        typeof entry.FilePath === "string" &&
+        "FileChanges" in entry &&
+        Array.isArray(entry.FileChanges)
+    )
+  );
+}
+
+/**
+ * Parse a `dotnet format --report` JSON report into raw issues without
+ * fingerprints, one per change the formatter would make: whitespace, code
+ * style and fixable analyzer diagnostics alike. Returns [] on malformed input.
+ */
+export function parseDotnetFormatReport(
+  report: string,
+  projectDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const parsed = safeParseJson(report);
+  if (!isFormatReport(parsed)) return [];
+  return parsed.flatMap((file) =>
+    file.FileChanges.map(
+      (change): Omit<LintIssue, "fingerprint"> => ({
+        rule: `dotnet-format/${change.DiagnosticId}`,
+        linter: "dotnet-format",
+        file: resolve(projectDir, file.FilePath),
+        line: change.LineNumber,
+        col: change.CharNumber,
+        message: change.FormatDescription,
+        severity: "error",
+      })
+    )
+  );
+}
+
+/** A project or solution to load, and the files to limit it to (all if undefined) */
+export interface DotnetWorkspace {
+  readonly path: string;
+  readonly include?: readonly string[];
+}
+
+/** The project whose directory is the deepest one containing `file` */
+function owningProject(file: string, projects: readonly string[]): string | undefined {
+  const owners = projects.filter((project) => {
+    const dir = dirname(project);
+    return dir === "." || file.startsWith(`${dir}/`);
+  });
+  return owners.sort((a, b) => dirname(b).length - dirname(a).length)[0];
+}
+
+/**
+ * What `dotnet format` loads. Each project is its own workspace rather than
+ * one solution holding them all: loading a large solution is what takes
+ * longest, and separate projects run in parallel. A solution is used only
+ * when no project file is found. With `files`, only the projects owning a
+ * changed `.cs` file are loaded, limited to those files with `--include`.
+ */
+export async function findDotnetWorkspaces(
+  projectDir: string,
+  fileManager: FileManager,
+  ignorePaths: readonly string[],
+  files?: readonly string[]
+): Promise<DotnetWorkspace[]> {
+  const projects = (
+    await fileManager.glob("**/*.csproj", projectDir, ignorePaths)
+  ).sort();
+  if (files === undefined) {
+    if (projects.length > 0) return projects.map((path) => ({ path }));
+    const solutions = await fileManager.glob("**/*.sln", projectDir, ignorePaths);
+    return solutions.sort().map((path) => ({ path }));
+  }
+  const byProject = new Map<string, string[]>();
+  for (const file of matchFiles(files, "**/*.cs")) {
+    const project = owningProject(file, projects);
+    if (project === undefined) continue;
+    byProject.set(project, [...(byProject.get(project) ?? []), file]);
+  }
+  return [...byProject].map(([path, include]) => ({ path, include }));
+}
+
+async function workspacesFor(opts: RunOptions): Promise<DotnetWorkspace[]> {
+  const { projectDir, config, fileManager, files } = opts;
+  return findDotnetWorkspaces(projectDir, fileManager, config.ignorePaths, files);
+}
+
+/** `--include` shards for a workspace, or one unrestricted run */
+function includeShards(
+  workspace: DotnetWorkspace,
+  batchSize: number | undefined,
+  run: (args: string[]) => Promise<Omit<LintIssue, "fingerprint">[]>
+): Promise<Omit<LintIssue, "fingerprint">[]> {
+  if (workspace.include === undefined) return run([]);
+  return mapShards(workspace.include, batchSize, (shard) =>
+    run(["--include", ...shard])
+  );
+}
+
+export const dotnetFormatRunner: LinterRunner = {
+  id: "dotnet-format",
+  name: "dotnet format",
+  configFile: null,
+  installHint: {
+    description: ".NET SDK 6+ (dotnet format is built in)",
+  },
+  fileScoped: true,
+  versionArgs: ["dotnet", "--version"],
+  cache: {
+    inputs: ["**/*.cs", "**/*.csproj", "**/*.sln", "**/.editorconfig"],
+  },
+
+  async isAvailable(
+    commandRunner: CommandRunner,
+    projectDir?: string
+  ): Promise<boolean> {
+    try {
+      const opts = projectDir !== undefined ? { cwd: projectDir } : undefined;
+      const result = await commandRunner.run(["dotnet", "format", "--version"], opts);
+      return result.exitCode === 0;
+    } catch {
+      return false;
+    }
+  },
+
+  async run(opts: RunOptions): Promise<LintIssue[]> {
+    const { projectDir, commandRunner, fileManager, batchSize } = opts;
+    const workspaces = await workspacesFor(opts);
+    const dir = join(projectDir, CACHE_DIR);
+    await fileManager.mkdir(dir, { parents: true });
+    let reports = 0;
+    const perWorkspace = await Promise.all(
+      workspaces.map((workspace) =>
+        includeShards(workspace, batchSize, async (include) => {
+          const report = join(dir, `dotnet-format-${reports++}.json`);
+          const result = await commandRunner.run(
+            [
+              "dotnet",
+              "format",
+              workspace.path,
+              "--verify-no-changes",
+              "--no-restore",
+              "--report",
+              report,
+              ...include,
+            ],
+            { cwd: projectDir }
+          );
+          // Exit 2 means files need formatting; any other failure is the tool's
+          if (result.exitCode !== 0 && result.exitCode !== 2) {
+            const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+            throw new Error(`dotnet format failed: ${detail}`);
+          }
+          if (!(await fileManager.exists(report))) return [];
+          const text = await fileManager.readText(report);
+          await fileManager.delete(report);
+          return parseDotnetFormatReport(text, projectDir);
+        })
+      )
+    );
+    return applyFingerprints(perWorkspace.flat(), projectDir, fileManager);
+  },
+
+  async fix(opts: RunOptions): Promise<void> {
+    const { projectDir, commandRunner, batchSize } = opts;
+    for (const workspace of await workspacesFor(opts)) {
+      const format = ["dotnet", "format", workspace.path, "--no-restore"];
+      if (workspace.include === undefined) {
+        await commandRunner.run(format, { cwd: projectDir });
+        continue;
+      }
+      await forEachShard(workspace.include, batchSize, (shard) =>
+        commandRunner.run([...format, "--include", ...shard], { cwd: projectDir })
+      );
+    }
+  },
+};
+
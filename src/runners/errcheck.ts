@@ -0,0 +1,104 @@
+import { join, resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
+import type { LintIssue } from "@/models/lint-issue";
+import { CACHE_DIR } from "@/models/paths";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { goModulesFor, withModule } from "@/utils/go-modules";
+
+// errcheck exits 1 when it finds unchecked errors, 2 when packages fail to load
+const COMPLETED_EXIT_CODES = new Set([0, 1]);
+
+const EXCLUDE_FILE = "errcheck-exclude.txt";
+
+/** e.g. `main.go:12:9:\tdefer f.Close()` */
+const LINE_RE = /^(.+?):(\d+):(\d+):\s+(.*)$/;
+
+/**
+ * Parse errcheck stdout — one `file:line:col:<tab>source` line per unchecked
+ * error — into raw issues without fingerprints. Returns [] for empty output.
+ */
+export function parseErrcheckOutput(
+  stdout: string,
+  moduleDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const issues: Omit<LintIssue, "fingerprint">[] = [];
+  for (const line of stdout.split("\n")) {
+    const match = LINE_RE.exec(line.trim());
+    if (match === null) continue;
+    const [, file = "", lineNo = "1", col = "1", source = ""] = match;
+    issues.push({
+      rule: "errcheck/unchecked",
+      linter: "errcheck",
+      file: resolve(moduleDir, file),
+      line: Number.parseInt(lineNo, 10),
+      col: Number.parseInt(col, 10),
+      message: `Error return value is not checked: ${source}`,
+      severity: "error",
+    });
+  }
+  return issues;
+}
+
+/**
+ * Write [config] errcheck_exclude as an errcheck `-exclude` file under the
+ * cache dir and return the flag, or [] when nothing is excluded.
+ */
+async function excludeArgs(
+  config: ResolvedConfig,
+  projectDir: string,
+  fileManager: FileManager
+): Promise<string[]> {
+  const excludes = config.values.errcheck_exclude ?? [];
+  if (excludes.length === 0) return [];
+  const dir = join(projectDir, CACHE_DIR);
+  const path = join(dir, EXCLUDE_FILE);
+  await fileManager.mkdir(dir, { parents: true });
+  await fileManager.writeText(path, `${excludes.join("\n")}\n`);
+  return ["-exclude", path];
+}
+
+export const errcheckRunner: LinterRunner = {
+  id: "errcheck",
+  name: "errcheck",
+  configFile: null,
+  installHint: {
+    description: "Go unchecked error detector",
+    go: "go install github.com/kisielk/errcheck@latest",
+  },
+  moduleScoped: true,
+  // No cache: errcheck has no version flag to key cached results on
+  watchInputs: ["**/*.go", "**/go.mod", "**/go.sum"],
+  // golangci-lint runs errcheck in every profile's .golangci.yml
+  supersedes: ["golangci-lint/errcheck"],
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    // errcheck exits 2 after printing -h usage; 127 means it is not installed
+    const result = await commandRunner.run(["errcheck", "-h"]);
+    return result.exitCode !== 127;
+  },
+
+  async run(opts: RunOptions): Promise<LintIssue[]> {
+    const { projectDir, config, commandRunner, fileManager } = opts;
+    const modules = await goModulesFor(opts);
+    const exclude = await excludeArgs(config, projectDir, fileManager);
+    const perModule = await Promise.all(
+      modules.map(async (moduleDir) => {
+        // -blank also reports errors assigned to _, e.g. `data, _ := os.ReadFile(p)`
+        const result = await commandRunner.run(
+          ["errcheck", "-blank", ...exclude, "./..."],
+          { cwd: moduleDir }
+        );
+        if (!COMPLETED_EXIT_CODES.has(result.exitCode)) {
+          const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+          throw new Error(`errcheck failed: ${detail}`);
+        }
+        const raw = parseErrcheckOutput(result.stdout, moduleDir);
+        return withModule(raw, projectDir, moduleDir);
+      })
+    );
+    return applyFingerprints(perModule.flat(), projectDir, fileManager);
+  },
+};
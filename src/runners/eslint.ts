@@ -0,0 +1,172 @@
+import { join, resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
+import type { LintIssue } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
+import { packageUses } from "@/utils/package-json";
+import { safeParseJson } from "@/utils/parse";
+import { resolveToolPath } from "@/utils/resolve-tool-path";
+import { forEachShard, mapShards } from "@/utils/shards";
+
+const ESLINT_LINTER_ID = "eslint";
+/** Files eslint lints — used to pick targets from a changed-file list */
+const ESLINT_GLOB = "**/*.{js,jsx,mjs,cjs,ts,tsx,mts,cts}";
+
+/** Flat config files, then the legacy eslintrc ones */
+const ESLINT_CONFIG_FILES = [
+  "eslint.config.js",
+  "eslint.config.mjs",
+  "eslint.config.cjs",
+  "eslint.config.ts",
+  "eslint.config.mts",
+  "eslint.config.cts",
+  ".eslintrc",
+  ".eslintrc.js",
+  ".eslintrc.cjs",
+  ".eslintrc.yaml",
+  ".eslintrc.yml",
+  ".eslintrc.json",
+];
+
+/** True when the project has an ESLint config or an eslint dependency */
+export async function usesEslint(
+  projectDir: string,
+  fileManager: FileManager
+): Promise<boolean> {
+  for (const name of ESLINT_CONFIG_FILES) {
+    if (await fileManager.exists(join(projectDir, name))) return true;
+  }
+  return packageUses(projectDir, fileManager, "eslint", "eslintConfig");
+}
+
+/**
+ * Whether the JS/TS linter `id` runs: as picked by [config] js_linter, or
+ * by default eslint where the project uses ESLint and biome everywhere else.
+ */
+export async function isJsLinter(
+  id: "biome" | "eslint",
+  { projectDir, config, fileManager }: RunOptions
+): Promise<boolean> {
+  const choice = config.values.js_linter;
+  if (choice !== undefined) return choice === id || choice === "both";
+  const eslint = await usesEslint(projectDir, fileManager);
+  return id === "eslint" ? eslint : !eslint;
+}
+
+interface EslintMessage {
+  ruleId?: string | null;
+  severity: number;
+  message: string;
+  line?: number;
+  column?: number;
+  fatal?: boolean;
+}
+
+interface EslintFileResult {
+  filePath: string;
+  messages: EslintMessage[];
+}
+
+function isEslintOutput(value: unknown): value is EslintFileResult[] {
+  return (
+    Array.isArray(value) &&
+    value.every(
+      (entry) =>
+        typeof entry === "object" &&
+        entry !== null &&
+        "filePath" in entry &&
+        "messages" in entry &&
+        Array.isArray(entry.messages)
+    )
+  );
+}
+
+/**
+ * Parse `eslint --format json` output into raw issues without fingerprints.
+ * Returns [] on malformed or empty input. Parse errors, which have no rule
+ * id, are reported as `eslint/syntax`.
+ */
+export function parseEslintOutput(
+  stdout: string,
+  projectDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const parsed = safeParseJson(stdout);
+  if (!isEslintOutput(parsed)) return [];
+  return parsed.flatMap((result) =>
+    result.messages.map((msg): Omit<LintIssue, "fingerprint"> => ({
+      rule: `eslint/${msg.ruleId ?? "syntax"}`,
+      linter: ESLINT_LINTER_ID,
+      file: resolve(projectDir, result.filePath),
+      line: msg.line ?? 1,
+      col: msg.column ?? 1,
+      message: msg.message,
+      severity: msg.severity === 2 || msg.fatal === true ? "error" : "warning",
+    }))
+  );
+}
+
+export const eslintRunner: LinterRunner = {
+  id: ESLINT_LINTER_ID,
+  name: "ESLint",
+  configFile: null,
+  fileScoped: true,
+  installHint: {
+    description: "JS/TS linter, for projects configured with ESLint",
+    npm: "npm install -D eslint",
+  },
+  versionArgs: ["eslint", "--version"],
+  watchInputs: [ESLINT_GLOB],
+
+  async isAvailable(
+    commandRunner: CommandRunner,
+    projectDir?: string
+  ): Promise<boolean> {
+    return (await resolveToolPath("eslint", projectDir ?? ".", commandRunner)) !== null;
+  },
+
+  async appliesTo(opts: RunOptions): Promise<boolean> {
+    return isJsLinter("eslint", opts);
+  },
+
+  async run({
+    projectDir,
+    commandRunner,
+    fileManager,
+    files,
+    batchSize,
+  }: RunOptions): Promise<LintIssue[]> {
+    const targets = files !== undefined ? matchFiles(files, ESLINT_GLOB) : ["."];
+    if (targets.length === 0) return [];
+    const cmd =
+      (await resolveToolPath("eslint", projectDir, commandRunner)) ?? "eslint";
+    const raw = await mapShards(targets, batchSize, async (shard) => {
+      const result = await commandRunner.run([cmd, "--format", "json", ...shard], {
+        cwd: projectDir,
+      });
+      // Exit 1 means problems were found; 2 means eslint itself failed
+      if (result.exitCode === 2) {
+        const detail = result.stderr.trim() || result.stdout.trim();
+        throw new Error(`eslint failed: ${detail}`);
+      }
+      return parseEslintOutput(result.stdout, projectDir);
+    });
+    return applyFingerprints(raw, projectDir, fileManager);
+  },
+
+  async fix({
+    projectDir,
+    commandRunner,
+    files,
+    batchSize,
+  }: RunOptions): Promise<void> {
+    const targets = files !== undefined ? matchFiles(files, ESLINT_GLOB) : ["."];
+    if (targets.length === 0) return;
+    const cmd =
+      (await resolveToolPath("eslint", projectDir, commandRunner)) ?? "eslint";
+    await forEachShard(targets, batchSize, (shard) =>
+      commandRunner.run([cmd, "--fix", ...shard], { cwd: projectDir })
+    );
+  },
+};
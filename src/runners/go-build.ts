@@ -0,0 +1,94 @@
+import { devNull } from "node:os";
+import { resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { LintIssue } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { goModulesFor, withModule } from "@/utils/go-modules";
+
+/** e.g. `./main.go:14:2: undefined: Foo`; continuation lines are tab-indented */
+const LINE_RE = /^(\S.*?\.go):(\d+):(\d+):\s+(.*)$/;
+
+/** What go build prints for a module with nothing in it to build */
+const NOTHING_TO_BUILD_RE =
+  /matched no packages|no Go files in|build constraints exclude all Go files/;
+
+/**
+ * Parse `go build` output — a `# package` header, then one
+ * `file:line:col: message` line per compiler error, printed to stderr — into
+ * raw issues without fingerprints. Returns [] for empty output.
+ */
+export function parseGoBuildOutput(
+  output: string,
+  moduleDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const issues: Omit<LintIssue, "fingerprint">[] = [];
+  for (const line of output.split("\n")) {
+    const match = LINE_RE.exec(line.trimEnd());
+    if (match === null) continue;
+    const [, file = "", lineNo = "1", col = "1", message = ""] = match;
+    issues.push({
+      rule: "go-build/compile",
+      linter: "go-build",
+      file: resolve(moduleDir, file),
+      line: Number.parseInt(lineNo, 10),
+      col: Number.parseInt(col, 10),
+      message,
+      severity: "error",
+    });
+  }
+  return issues;
+}
+
+/** Compiler errors of the module in moduleDir; [] when it has nothing to build */
+async function buildModule(
+  commandRunner: CommandRunner,
+  moduleDir: string
+): Promise<Omit<LintIssue, "fingerprint">[]> {
+  // -o /dev/null discards the binary a lone main package would leave behind
+  const result = await commandRunner.run(["go", "build", "-o", devNull, "./..."], {
+    cwd: moduleDir,
+  });
+  if (result.exitCode === 0) return [];
+  const issues = parseGoBuildOutput(result.stderr, moduleDir);
+  if (issues.length > 0) return issues;
+  // A workspace module may hold only tools or generated stubs
+  if (NOTHING_TO_BUILD_RE.test(result.stderr)) return [];
+  const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+  throw new Error(`go build failed: ${detail}`);
+}
+
+export const goBuildRunner: LinterRunner = {
+  id: "go-build",
+  name: "go build",
+  configFile: null,
+  installHint: {
+    description: "Go toolchain (go build)",
+    brew: "brew install go",
+    apt: "sudo apt install golang-go",
+  },
+  moduleScoped: true,
+  // Analysis of code that does not compile is meaningless
+  runsFirst: true,
+  versionArgs: ["go", "version"],
+  cache: {
+    inputs: ["**/*.go", "**/go.mod", "**/go.sum", "go.work", "go.work.sum"],
+  },
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    const result = await commandRunner.run(["go", "version"]);
+    return result.exitCode === 0;
+  },
+
+  async run(opts: RunOptions): Promise<LintIssue[]> {
+    const { projectDir, commandRunner, fileManager } = opts;
+    const modules = await goModulesFor(opts);
+    const perModule = await Promise.all(
+      modules.map(async (moduleDir) => {
+        const raw = await buildModule(commandRunner, moduleDir);
+        return withModule(raw, projectDir, moduleDir);
+      })
+    );
+    return applyFingerprints(perModule.flat(), projectDir, fileManager);
+  },
+};
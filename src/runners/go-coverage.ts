@@ -0,0 +1,173 @@
+import { join, posix } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { LintIssue } from "@/models/lint-issue";
+import { CACHE_DIR } from "@/models/paths";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { goModulesFor, withModule } from "@/utils/go-modules";
+
+export interface PackageCoverage {
+  statements: number;
+  covered: number;
+}
+
+/** e.g. `example.com/m/api/handler.go:12.34,15.2 3 1` */
+const BLOCK_RE = /^((.+\.go):\d+\.\d+,\d+\.\d+) (\d+) (\d+)$/;
+
+/** e.g. `ok  \texample.com/m/api\t0.012s\tcoverage: 81.0% of statements` */
+const TESTED_RE = /^ok\s+(\S+)/gm;
+
+/**
+ * Statement counts per package import path from a `-coverprofile` file.
+ * A block listed more than once (one entry per test binary) counts once,
+ * covered if any entry covered it.
+ */
+export function parseCoverProfile(content: string): Map<string, PackageCoverage> {
+  const blocks = new Map<string, { pkg: string; statements: number; hit: boolean }>();
+  for (const line of content.split("\n")) {
+    const match = BLOCK_RE.exec(line.trim());
+    if (match === null) continue;
+    const [, key = "", file = "", statements = "0", count = "0"] = match;
+    const hit = Number.parseInt(count, 10) > 0 || blocks.get(key)?.hit === true;
+    const pkg = posix.dirname(file);
+    blocks.set(key, { pkg, statements: Number.parseInt(statements, 10), hit });
+  }
+  const packages = new Map<string, PackageCoverage>();
+  for (const { pkg, statements, hit } of blocks.values()) {
+    const total = packages.get(pkg) ?? { statements: 0, covered: 0 };
+    total.statements += statements;
+    if (hit) total.covered += statements;
+    packages.set(pkg, total);
+  }
+  return packages;
+}
+
+/** Import paths `go test` ran tests for; `[no test files]` packages are absent */
+export function parseTestedPackages(stdout: string): Set<string> {
+  return new Set([...stdout.matchAll(TESTED_RE)].map((match) => match[1] ?? ""));
+}
+
+function percent({ statements, covered }: PackageCoverage): number {
+  return statements === 0 ? 100 : (covered / statements) * 100;
+}
+
+function formatPercent(value: number): string {
+  return `${Number.parseFloat(value.toFixed(1))}%`;
+}
+
+/**
+ * Issues for a module's coverage below [config] coverage_min (all tested
+ * packages together) or below a package's coverage_packages entry. Packages
+ * without test files are not counted. Reported at the module's go.mod.
+ */
+export function coverageIssues(
+  packages: ReadonlyMap<string, PackageCoverage>,
+  tested: ReadonlySet<string>,
+  config: ResolvedConfig,
+  moduleDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const issue = (rule: string, message: string): Omit<LintIssue, "fingerprint"> => ({
+    rule: `go-coverage/${rule}`,
+    linter: "go-coverage",
+    file: join(moduleDir, "go.mod"),
+    line: 1,
+    col: 1,
+    message,
+    severity: "error",
+  });
+  const measured = [...packages].filter(([pkg]) => tested.has(pkg));
+  const issues: Omit<LintIssue, "fingerprint">[] = [];
+
+  const min = config.values.coverage_min;
+  const total = measured.reduce(
+    (sum, [, cov]) => ({
+      statements: sum.statements + cov.statements,
+      covered: sum.covered + cov.covered,
+    }),
+    { statements: 0, covered: 0 }
+  );
+  if (min !== undefined && total.statements > 0 && percent(total) < min) {
+    const actual = formatPercent(percent(total));
+    issues.push(
+      issue("total", `Coverage ${actual} is below the ${min}% minimum (coverage_min)`)
+    );
+  }
+
+  const thresholds = config.values.coverage_packages ?? {};
+  for (const [pkg, cov] of measured.sort(([a], [b]) => a.localeCompare(b))) {
+    const pkgMin = thresholds[pkg];
+    if (pkgMin === undefined || percent(cov) >= pkgMin) continue;
+    const actual = formatPercent(percent(cov));
+    issues.push(
+      issue("package", `${pkg} coverage ${actual} is below its ${pkgMin}% minimum`)
+    );
+  }
+  return issues;
+}
+
+/** FAIL lines from `go test` output, else its stderr */
+function failureDetail(stdout: string, stderr: string, exitCode: number): string {
+  const failed = stdout
+    .split("\n")
+    .filter((line) => /^(--- )?FAIL/.test(line.trim()))
+    .join("; ");
+  return failed || stderr.trim() || `exit code ${exitCode}`;
+}
+
+function hasThresholds(config: ResolvedConfig): boolean {
+  const packages = config.values.coverage_packages ?? {};
+  return config.values.coverage_min !== undefined || Object.keys(packages).length > 0;
+}
+
+export const goCoverageRunner: LinterRunner = {
+  id: "go-coverage",
+  name: "go test coverage",
+  configFile: null,
+  installHint: {
+    description: "Go toolchain (go test -coverprofile)",
+    brew: "brew install go",
+    apt: "sudo apt install golang-go",
+  },
+  moduleScoped: true,
+  // It runs the whole test suite, so projects opt in, typically for CI only
+  defaultEnabled: false,
+  slow: true,
+  versionArgs: ["go", "version"],
+  // No cache: test results depend on more than the Go sources (testdata, env)
+  watchInputs: ["**/*.go", "**/go.mod", "**/go.sum"],
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    const result = await commandRunner.run(["go", "version"]);
+    return result.exitCode === 0;
+  },
+
+  async run(opts: RunOptions): Promise<LintIssue[]> {
+    const { projectDir, config, commandRunner, fileManager } = opts;
+    // Nothing to enforce without a threshold — skip the test run entirely
+    if (!hasThresholds(config)) return [];
+    const modules = await goModulesFor(opts);
+    const dir = join(projectDir, CACHE_DIR);
+    await fileManager.mkdir(dir, { parents: true });
+    const perModule = await Promise.all(
+      modules.map(async (moduleDir, index) => {
+        const profile = join(dir, `coverage-${index}.out`);
+        const result = await commandRunner.run(
+          ["go", "test", "-covermode=set", `-coverprofile=${profile}`, "./..."],
+          { cwd: moduleDir }
+        );
+        if (result.exitCode !== 0) {
+          const detail = failureDetail(result.stdout, result.stderr, result.exitCode);
+          throw new Error(`go test failed: ${detail}`);
+        }
+        const packages = (await fileManager.exists(profile))
+          ? parseCoverProfile(await fileManager.readText(profile))
+          : new Map<string, PackageCoverage>();
+        const tested = parseTestedPackages(result.stdout);
+        const raw = coverageIssues(packages, tested, config, moduleDir);
+        return withModule(raw, projectDir, moduleDir);
+      })
+    );
+    return applyFingerprints(perModule.flat(), projectDir, fileManager);
+  },
+};
@@ -0,0 +1,132 @@
+import { join } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
+import type { LintIssue } from "@/models/lint-issue";
+import { CACHE_DIR } from "@/models/paths";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { goModulesFor, withModule } from "@/utils/go-modules";
+
+/** 1-based number of the first line where `a` and `b` differ */
+export function firstDifferentLine(a: string, b: string): number {
+  const left = a.split("\n");
+  const right = b.split("\n");
+  const index = left.findIndex((line, i) => line !== right[i]);
+  return (index === -1 ? left.length - 1 : index) + 1;
+}
+
+/**
+ * Issues for a module whose go.mod or go.sum differs from its tidied copy.
+ * A null go.sum means the file does not exist.
+ */
+export function tidyIssues(
+  moduleDir: string,
+  committed: { mod: string; sum: string | null },
+  tidied: { mod: string; sum: string | null }
+): Omit<LintIssue, "fingerprint">[] {
+  const files = [
+    { name: "go.mod", before: committed.mod, after: tidied.mod },
+    { name: "go.sum", before: committed.sum ?? "", after: tidied.sum ?? "" },
+  ];
+  return files
+    .filter(({ before, after }) => before !== after)
+    .map(({ name, before, after }) => ({
+      rule: "go-mod-tidy/untidy",
+      linter: "go-mod-tidy",
+      file: join(moduleDir, name),
+      line: firstDifferentLine(before, after),
+      col: 1,
+      message: `${name} is not tidy — run: go mod tidy`,
+      severity: "error",
+    }));
+}
+
+async function readIfExists(fm: FileManager, path: string): Promise<string | null> {
+  return (await fm.exists(path)) ? fm.readText(path) : null;
+}
+
+async function deleteIfExists(fm: FileManager, path: string): Promise<void> {
+  if (await fm.exists(path)) await fm.delete(path);
+}
+
+/**
+ * Tidy a copy of the module's go.mod and go.sum under the cache dir with
+ * `go mod tidy -modfile`, leaving the real files untouched, and compare.
+ */
+async function checkModule(
+  opts: RunOptions,
+  moduleDir: string,
+  index: number
+): Promise<Omit<LintIssue, "fingerprint">[]> {
+  const { projectDir, commandRunner, fileManager } = opts;
+  const mod = await fileManager.readText(join(moduleDir, "go.mod"));
+  const sum = await readIfExists(fileManager, join(moduleDir, "go.sum"));
+
+  // -modfile=x.mod reads and writes x.sum alongside it
+  const base = join(projectDir, CACHE_DIR, `go-mod-tidy-${index}`);
+  const copyMod = `${base}.mod`;
+  const copySum = `${base}.sum`;
+  await fileManager.writeText(copyMod, mod);
+  if (sum !== null) await fileManager.writeText(copySum, sum);
+  else await deleteIfExists(fileManager, copySum);
+
+  try {
+    const result = await commandRunner.run(
+      ["go", "mod", "tidy", `-modfile=${copyMod}`],
+      { cwd: moduleDir }
+    );
+    if (result.exitCode !== 0) {
+      const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+      throw new Error(`go mod tidy failed: ${detail}`);
+    }
+    const tidied = {
+      mod: await fileManager.readText(copyMod),
+      sum: await readIfExists(fileManager, copySum),
+    };
+    return tidyIssues(moduleDir, { mod, sum }, tidied);
+  } finally {
+    await deleteIfExists(fileManager, copyMod);
+    await deleteIfExists(fileManager, copySum);
+  }
+}
+
+export const goModTidyRunner: LinterRunner = {
+  id: "go-mod-tidy",
+  name: "go mod tidy",
+  configFile: null,
+  installHint: {
+    description: "Go toolchain (go mod tidy)",
+    brew: "brew install go",
+    apt: "sudo apt install golang-go",
+  },
+  moduleScoped: true,
+  versionArgs: ["go", "version"],
+  cache: {
+    inputs: ["**/*.go", "**/go.mod", "**/go.sum"],
+  },
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    const result = await commandRunner.run(["go", "version"]);
+    return result.exitCode === 0;
+  },
+
+  async run(opts: RunOptions): Promise<LintIssue[]> {
+    const { projectDir, fileManager } = opts;
+    const modules = await goModulesFor(opts);
+    await fileManager.mkdir(join(projectDir, CACHE_DIR), { parents: true });
+    const perModule = await Promise.all(
+      modules.map(async (moduleDir, index) => {
+        const raw = await checkModule(opts, moduleDir, index);
+        return withModule(raw, projectDir, moduleDir);
+      })
+    );
+    return applyFingerprints(perModule.flat(), projectDir, fileManager);
+  },
+
+  async fix(opts: RunOptions): Promise<void> {
+    // go mod tidy ignores go.work, so each module is tidied on its own
+    for (const moduleDir of await goModulesFor(opts)) {
+      await opts.commandRunner.run(["go", "mod", "tidy"], { cwd: moduleDir });
+    }
+  },
+};
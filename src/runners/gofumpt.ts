@@ -0,0 +1,115 @@
+import { dirname, relative, resolve } from "node:path";
+import { minimatch } from "minimatch";
+import type { CommandRunner } from "@/infra/command-runner";
+import { DEFAULT_IGNORE } from "@/languages/constants";
+import type { LintIssue } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
+import { pipeFix } from "@/utils/pipe-fix";
+import { forEachShard, mapShards } from "@/utils/shards";
+
+const GO_GLOB = "**/*.go";
+
+/**
+ * Parse `gofumpt -l` stdout — one file per line that needs reformatting —
+ * into raw issues without fingerprints. Returns [] for empty output.
+ */
+export function parseGofumptOutput(
+  stdout: string,
+  projectDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  return stdout
+    .split("\n")
+    .map((line) => line.trim())
+    .filter((line) => line.length > 0)
+    .map((filename) => ({
+      rule: "gofumpt/format",
+      linter: "gofumpt",
+      file: resolve(projectDir, filename),
+      line: 1,
+      col: 1,
+      message: `File is not gofumpt-formatted — run: gofumpt -w ${filename}`,
+      severity: "error",
+    }) satisfies Omit<LintIssue, "fingerprint">);
+}
+
+/**
+ * Project-relative files a `-l` Go formatter (gofumpt, goimports) reports as
+ * unformatted. A full run lists the whole tree (`<listArgs> .`); vendor/ and
+ * ignore_paths are dropped afterwards. A changed-file list is checked in shards.
+ */
+export async function listUnformattedGoFiles(
+  listArgs: readonly [string, ...string[]],
+  { projectDir, config, commandRunner, batchSize }: RunOptions,
+  changed?: readonly string[]
+): Promise<string[]> {
+  const targets = changed !== undefined ? matchFiles(changed, GO_GLOB) : ["."];
+  if (targets.length === 0) return [];
+  const ignore = [...DEFAULT_IGNORE, ...config.ignorePaths];
+  return mapShards(targets, batchSize, async (shard) => {
+    const result = await commandRunner.run([...listArgs, ...shard], {
+      cwd: projectDir,
+    });
+    if (result.exitCode !== 0) {
+      const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+      throw new Error(`${listArgs[0]} failed: ${detail}`);
+    }
+    return result.stdout
+      .split("\n")
+      .map((line) => line.trim())
+      .filter((line) => line.length > 0)
+      .map((line) => relative(projectDir, resolve(projectDir, line)))
+      .filter((file) => !ignore.some((pattern) => minimatch(file, pattern)));
+  });
+}
+
+export const gofumptRunner: LinterRunner = {
+  id: "gofumpt",
+  name: "gofumpt",
+  configFile: null,
+  fileScoped: true,
+  // Stricter than gofmt, so projects opt in with [runners.gofumpt] enabled = true
+  defaultEnabled: false,
+  installHint: {
+    description: "Stricter gofmt",
+    brew: "brew install gofumpt",
+    go: "go install mvdan.cc/gofumpt@latest",
+  },
+  versionArgs: ["gofumpt", "--version"],
+  cache: {
+    inputs: [GO_GLOB, "**/go.mod"],
+  },
+  // gofumpt's rules include gofmt's, so these would repeat its findings
+  supersedes: ["golangci-lint/gofmt", "golangci-lint/gofumpt"],
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    const result = await commandRunner.run(["gofumpt", "--version"]);
+    return result.exitCode === 0;
+  },
+
+  async run(opts: RunOptions): Promise<LintIssue[]> {
+    const { projectDir, fileManager, files: changed } = opts;
+    const files = await listUnformattedGoFiles(["gofumpt", "-l"], opts, changed);
+    const raw = parseGofumptOutput(files.join("\n"), projectDir);
+    return applyFingerprints(raw, projectDir, fileManager);
+  },
+
+  async fix(opts: RunOptions): Promise<void> {
+    // Like the other formatters, --fix covers the whole project unless given files
+    const files = await listUnformattedGoFiles(["gofumpt", "-l"], opts, opts.files);
+    await forEachShard(files, opts.batchSize, (shard) =>
+      opts.commandRunner.run(["gofumpt", "-w", ...shard], { cwd: opts.projectDir })
+    );
+  },
+
+  async previewFix(
+    { projectDir, commandRunner }: RunOptions,
+    file: string,
+    content: string
+  ): Promise<string> {
+    // From the file's directory, so gofumpt finds its go.mod's language version
+    const cwd = dirname(resolve(projectDir, file));
+    return pipeFix(commandRunner, ["gofumpt"], content, cwd);
+  },
+};
@@ -0,0 +1,96 @@
+import { dirname, resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { LintIssue } from "@/models/lint-issue";
+import { listUnformattedGoFiles } from "@/runners/gofumpt";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { pipeFix } from "@/utils/pipe-fix";
+import { forEachShard } from "@/utils/shards";
+
+/**
+ * Parse `goimports -l` stdout — one file per line whose imports are missing,
+ * unused or not grouped and sorted — into raw issues without fingerprints.
+ * Returns [] for empty output.
+ */
+export function parseGoimportsOutput(
+  stdout: string,
+  projectDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  return stdout
+    .split("\n")
+    .map((line) => line.trim())
+    .filter((line) => line.length > 0)
+    .map((filename) => ({
+      rule: "goimports/imports",
+      linter: "goimports",
+      file: resolve(projectDir, filename),
+      line: 1,
+      col: 1,
+      message: `Imports are not organized — run: goimports -w ${filename}`,
+      severity: "error",
+    }) satisfies Omit<LintIssue, "fingerprint">);
+}
+
+/** `-local <prefix>` from [config] go_local_prefix, if set */
+export function goimportsLocalArgs(config: ResolvedConfig): string[] {
+  const prefix = config.values.go_local_prefix;
+  return prefix !== undefined ? ["-local", prefix] : [];
+}
+
+export const goimportsRunner: LinterRunner = {
+  id: "goimports",
+  name: "goimports",
+  configFile: null,
+  fileScoped: true,
+  installHint: {
+    description: "Go import organizer",
+    go: "go install golang.org/x/tools/cmd/goimports@latest",
+  },
+  // No cache: goimports has no version flag to key cached results on
+  watchInputs: ["**/*.go", "**/go.mod"],
+  supersedes: ["golangci-lint/goimports"],
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    // Go's flag package exits 0 after printing -h usage
+    const result = await commandRunner.run(["goimports", "-h"]);
+    return result.exitCode === 0;
+  },
+
+  async run(opts: RunOptions): Promise<LintIssue[]> {
+    const { projectDir, config, fileManager, files: changed } = opts;
+    const local = goimportsLocalArgs(config);
+    const files = await listUnformattedGoFiles(
+      ["goimports", "-l", ...local],
+      opts,
+      changed
+    );
+    const raw = parseGoimportsOutput(files.join("\n"), projectDir);
+    return applyFingerprints(raw, projectDir, fileManager);
+  },
+
+  async fix(opts: RunOptions): Promise<void> {
+    const local = goimportsLocalArgs(opts.config);
+    const files = await listUnformattedGoFiles(
+      ["goimports", "-l", ...local],
+      opts,
+      opts.files
+    );
+    await forEachShard(files, opts.batchSize, (shard) =>
+      opts.commandRunner.run(["goimports", "-w", ...local, ...shard], {
+        cwd: opts.projectDir,
+      })
+    );
+  },
+
+  async previewFix(
+    { projectDir, config, commandRunner }: RunOptions,
+    file: string,
+    content: string
+  ): Promise<string> {
+    // -srcdir resolves imports as if the buffer sat at the file's place
+    const dir = dirname(resolve(projectDir, file));
+    const args = ["goimports", ...goimportsLocalArgs(config), "-srcdir", dir];
+    return pipeFix(commandRunner, args, content, projectDir);
+  },
+};
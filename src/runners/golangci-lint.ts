@@ -3,6 +3,7 @@ import type { CommandRunner } from "@/infra/command-runner";
 import type { LintIssue } from "@/models/lint-issue";
 import type { LinterRunner, RunOptions } from "@/runners/types";
 import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { goModulesFor, withModule } from "@/utils/go-modules";
 import { safeParseJson } from "@/utils/parse";
 
 interface GolangciIssue {
@@ -29,7 +30,7 @@ function isGolangciOutput(value: unknown): value is GolangciOutput {
  */
 export function parseGolangciOutput(
   json: string,
-  projectDir: string
+  moduleDir: string
 ): Omit<LintIssue, "fingerprint">[] {
   const parsed = safeParseJson(json);
   if (parsed === null) return [];
@@ -43,7 +44,7 @@ export function parseGolangciOutput(
     .filter((issue) => issue.Pos?.Filename)
     .map((issue) => {
       const rule = `golangci-lint/${issue.FromLinter}`;
-      const file = resolve(projectDir, issue.Pos.Filename);
+      const file = resolve(moduleDir, issue.Pos.Filename);
 
       return {
         rule,
@@ -85,6 +86,12 @@ async function getVersionFlag(
   return cachedVersionFlagPromise;
 }
 
+/**
+ * golangci-lint exit codes that mean the run completed: 0 = clean,
+ * 1 = issues found, 5 = no Go files to analyse.
+ */
+const COMPLETED_EXIT_CODES: ReadonlySet<number> = new Set([0, 1, 5]);
+
 /** Reset the version flag cache. Exported for test isolation. */
 export function resetVersionFlagCache(): void {
   cachedVersionFlagPromise = undefined;
@@ -99,6 +106,11 @@ export const golangciLintRunner: LinterRunner = {
     brew: "brew install golangci-lint",
     go: "go install github.com/golangci/golangci-lint/cmd/golangci-lint@latest",
   },
+  moduleScoped: true,
+  versionArgs: ["golangci-lint", "--version"],
+  cache: {
+    inputs: ["**/*.go", "**/go.mod", "**/go.sum"],
+  },
 
   async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
     const result = await commandRunner.run(["golangci-lint", "--version"]);
@@ -108,11 +120,34 @@ export const golangciLintRunner: LinterRunner = {
   async run(opts: RunOptions): Promise<LintIssue[]> {
     const { projectDir, commandRunner, fileManager } = opts;
     const jsonFlag = await getVersionFlag(commandRunner, projectDir);
-    const result = await commandRunner.run(
-      ["golangci-lint", "run", jsonFlag, "./..."],
-      { cwd: projectDir }
+    // `./...` stops at nested go.mod files, so each module is its own run;
+    // golangci-lint finds .golangci.yml by searching upward from the cwd.
+    const modules = await goModulesFor(opts);
+    const perModule = await Promise.all(
+      modules.map(async (moduleDir) => {
+        const result = await commandRunner.run(
+          ["golangci-lint", "run", jsonFlag, "./..."],
+          { cwd: moduleDir }
+        );
+        const raw = parseGolangciOutput(result.stdout, moduleDir);
+        // A config error or typecheck failure exits 3+ with no parseable
+        // issues — surface it instead of reporting a clean run.
+        if (raw.length === 0 && !COMPLETED_EXIT_CODES.has(result.exitCode)) {
+          const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+          throw new Error(`golangci-lint failed: ${detail}`);
+        }
+        return withModule(raw, projectDir, moduleDir);
+      })
     );
-    const raw = parseGolangciOutput(result.stdout, projectDir);
-    return applyFingerprints(raw, projectDir, fileManager);
+    return applyFingerprints(perModule.flat(), projectDir, fileManager);
+  },
+
+  async fix(opts: RunOptions): Promise<void> {
+    // Fixes come from linters that support them (gofmt, goimports, misspell, ...)
+    for (const moduleDir of await goModulesFor(opts)) {
+      await opts.commandRunner.run(["golangci-lint", "run", "--fix", "./..."], {
+        cwd: moduleDir,
+      });
+    }
   },
 };
@@ -0,0 +1,134 @@
+import { resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { LintIssue, Severity } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { goModulesFor, withModule } from "@/utils/go-modules";
+import { safeParseJson } from "@/utils/parse";
+
+/** Shape of a single entry in the `Issues` array of `gosec -fmt=json` */
+interface GosecIssue {
+  severity: string;
+  confidence: string;
+  rule_id: string;
+  details: string;
+  file: string;
+  /** A line number, or a range like "12-14" */
+  line: string;
+  column: string;
+}
+
+function isGosecIssue(value: unknown): value is GosecIssue {
+  return (
+    typeof value === "object" &&
+    value !== null &&
+    "rule_id" in value &&
+    typeof value.rule_id === "string" &&
+    "file" in value &&
+    typeof value.file === "string" &&
+    "line" in value &&
+    typeof value.line === "string" &&
+    "column" in value &&
+    typeof value.column === "string" &&
+    "details" in value &&
+    typeof value.details === "string" &&
+    "severity" in value &&
+    typeof value.severity === "string" &&
+    "confidence" in value &&
+    typeof value.confidence === "string"
+  );
+}
+
+const SEVERITY_BY_LEVEL: Record<string, Severity> = {
+  HIGH: "error",
+  MEDIUM: "warning",
+  LOW: "info",
+};
+
+// gosec exits 1 when it reports issues
+const COMPLETED_EXIT_CODES = new Set([0, 1]);
+
+/**
+ * Parse `gosec -fmt=json` stdout into raw issues without fingerprints.
+ * Severity HIGH → error, MEDIUM → warning, LOW → info; the confidence goes in
+ * the message. Returns [] on malformed/empty input.
+ */
+export function parseGosecOutput(
+  stdout: string,
+  moduleDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const parsed = safeParseJson(stdout);
+  if (typeof parsed !== "object" || parsed === null) return [];
+  if (!("Issues" in parsed) || !Array.isArray(parsed.Issues)) return [];
+
+  return parsed.Issues.filter(isGosecIssue).map((issue) => {
+    const confidence = issue.confidence.toLowerCase();
+    return {
+      rule: `gosec/${issue.rule_id}`,
+      linter: "gosec",
+      file: resolve(moduleDir, issue.file),
+      line: Number.parseInt(issue.line, 10) || 1,
+      col: Number.parseInt(issue.column, 10) || 1,
+      message: `${issue.details} (confidence: ${confidence})`,
+      severity: SEVERITY_BY_LEVEL[issue.severity] ?? "warning",
+    } satisfies Omit<LintIssue, "fingerprint">;
+  });
+}
+
+/** `-exclude=G104,G401` for the gosec rules ignored repo-wide, if any */
+export function gosecExcludeArgs(config: ResolvedConfig): string[] {
+  const ids = [...config.ignoredRules]
+    .filter((rule) => rule.startsWith("gosec/"))
+    .map((rule) => rule.slice("gosec/".length));
+  return ids.length > 0 ? [`-exclude=${ids.join(",")}`] : [];
+}
+
+export const gosecRunner: LinterRunner = {
+  id: "gosec",
+  name: "gosec",
+  configFile: null,
+  installHint: {
+    description: "Go security checker",
+    brew: "brew install gosec",
+    go: "go install github.com/securego/gosec/v2/cmd/gosec@latest",
+  },
+  moduleScoped: true,
+  versionArgs: ["gosec", "-version"],
+  cache: {
+    inputs: ["**/*.go", "**/go.mod", "**/go.sum"],
+  },
+  // golangci-lint runs gosec too (standard and strict .golangci.yml)
+  supersedes: ["golangci-lint/gosec"],
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    const result = await commandRunner.run(["gosec", "-version"]);
+    return result.exitCode === 0;
+  },
+
+  async run(opts: RunOptions): Promise<LintIssue[]> {
+    const { projectDir, config, commandRunner, fileManager } = opts;
+    const modules = await goModulesFor(opts);
+    const exclude = [
+      ...gosecExcludeArgs(config),
+      // gosec can skip `// Code generated ... DO NOT EDIT.` files itself
+      ...(opts.includeGenerated === true ? [] : ["-exclude-generated"]),
+    ];
+    const perModule = await Promise.all(
+      modules.map(async (moduleDir) => {
+        // gosec honours #nosec comments on its own
+        const result = await commandRunner.run(
+          ["gosec", "-fmt=json", ...exclude, "./..."],
+          { cwd: moduleDir }
+        );
+        const raw = parseGosecOutput(result.stdout, moduleDir);
+        if (raw.length === 0 && !COMPLETED_EXIT_CODES.has(result.exitCode)) {
+          const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+          throw new Error(`gosec failed: ${detail}`);
+        }
+        return withModule(raw, projectDir, moduleDir);
+      })
+    );
+    return applyFingerprints(perModule.flat(), projectDir, fileManager);
+  },
+};
@@ -0,0 +1,154 @@
+import { resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { LintIssue } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { goModulesFor, withModule } from "@/utils/go-modules";
+import { parseJsonStream } from "@/utils/ndjson";
+
+/** One call-stack frame of a govulncheck finding */
+interface VulnFrame {
+  module: string;
+  package?: string;
+  function?: string;
+  receiver?: string;
+  position?: { filename: string; line: number; column: number };
+}
+
+interface VulnFinding {
+  osv: string;
+  fixed_version?: string;
+  trace: VulnFrame[];
+}
+
+function isFrame(value: unknown): value is VulnFrame {
+  return (
+    typeof value === "object" &&
+    value !== null &&
+    "module" in value &&
+    typeof value.module === "string"
+  );
+}
+
+function isFinding(value: unknown): value is VulnFinding {
+  return (
+    typeof value === "object" &&
+    value !== null &&
+    "osv" in value &&
+    typeof value.osv === "string" &&
+    "trace" in value &&
+    Array.isArray(value.trace) &&
+    value.trace.every(isFrame)
+  );
+}
+
+/** OSV id → summary, from the `{"osv": {...}}` messages in the stream */
+function osvSummaries(messages: readonly unknown[]): Map<string, string> {
+  const summaries = new Map<string, string>();
+  for (const message of messages) {
+    if (typeof message !== "object" || message === null) continue;
+    if (!("osv" in message)) continue;
+    const osv = message.osv;
+    if (typeof osv !== "object" || osv === null) continue;
+    if (!("id" in osv) || typeof osv.id !== "string") continue;
+    if ("summary" in osv && typeof osv.summary === "string") {
+      summaries.set(osv.id, osv.summary);
+    }
+  }
+  return summaries;
+}
+
+/** e.g. golang.org/x/net/http2.serverConn.serve */
+function symbolName(frame: VulnFrame, fn: string): string {
+  const receiver = frame.receiver?.replace(/^\*/, "");
+  const method = receiver !== undefined ? `${receiver}.${fn}` : fn;
+  return `${frame.package ?? frame.module}.${method}`;
+}
+
+/**
+ * Parse `govulncheck -json` output into raw issues without fingerprints.
+ * Only symbol-level findings are kept — the vulnerable function is actually
+ * called — not modules or packages that are merely required or imported.
+ * Each issue sits at the call site in the module's own code (the last frame
+ * with a position), falling back to go.mod; duplicates per site are dropped.
+ */
+export function parseGovulncheckOutput(
+  stream: string,
+  moduleDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const messages = parseJsonStream(stream);
+  const summaries = osvSummaries(messages);
+  const issues: Omit<LintIssue, "fingerprint">[] = [];
+  const seen = new Set<string>();
+
+  for (const message of messages) {
+    if (typeof message !== "object" || message === null) continue;
+    if (!("finding" in message) || !isFinding(message.finding)) continue;
+    const { osv, fixed_version: fixed, trace } = message.finding;
+    const vulnerable = trace[0];
+    const fn = vulnerable?.function;
+    if (vulnerable === undefined || fn === undefined) continue;
+
+    const site = trace.findLast((frame) => frame.position !== undefined)?.position;
+    const file = resolve(moduleDir, site?.filename ?? "go.mod");
+    const line = site?.line ?? 1;
+    const key = `${osv}:${file}:${line}`;
+    if (seen.has(key)) continue;
+    seen.add(key);
+
+    const fix = fixed !== undefined ? `fixed in ${fixed}` : "no fixed version yet";
+    const details = [summaries.get(osv), `calls ${symbolName(vulnerable, fn)}`, fix];
+    issues.push({
+      rule: `govulncheck/${osv}`,
+      linter: "govulncheck",
+      file,
+      line,
+      col: site?.column ?? 1,
+      message: details.filter((part) => part !== undefined).join("; "),
+      severity: "error",
+    });
+  }
+  return issues;
+}
+
+export const govulncheckRunner: LinterRunner = {
+  id: "govulncheck",
+  name: "govulncheck",
+  configFile: null,
+  installHint: {
+    description: "Go vulnerability scanner (needs network access to vuln.go.dev)",
+    go: "go install golang.org/x/vuln/cmd/govulncheck@latest",
+  },
+  moduleScoped: true,
+  versionArgs: ["govulncheck", "-version"],
+  // vuln.go.dev is fetched on every run; a dropped connection is worth another go
+  retry: { attempts: 3, backoff: 2 },
+  // No cache: the vulnerability database changes without any input changing
+  watchInputs: ["**/*.go", "**/go.mod", "**/go.sum"],
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    const result = await commandRunner.run(["govulncheck", "-version"]);
+    return result.exitCode === 0;
+  },
+
+  async run(opts: RunOptions): Promise<LintIssue[]> {
+    const { projectDir, commandRunner, fileManager } = opts;
+    const modules = await goModulesFor(opts);
+    const perModule = await Promise.all(
+      modules.map(async (moduleDir) => {
+        const result = await commandRunner.run(["govulncheck", "-json", "./..."], {
+          cwd: moduleDir,
+        });
+        // With -json the exit code is 0 whatever is found; non-zero means the
+        // scan itself failed (no network, packages that do not build)
+        if (result.exitCode !== 0) {
+          const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+          throw new Error(`govulncheck failed: ${detail}`);
+        }
+        const raw = parseGovulncheckOutput(result.stdout, moduleDir);
+        return withModule(raw, projectDir, moduleDir);
+      })
+    );
+    return applyFingerprints(perModule.flat(), projectDir, fileManager);
+  },
+};
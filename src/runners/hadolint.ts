@@ -0,0 +1,125 @@
+import { resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
+import type { LintIssue, Severity } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
+import { safeParseJson } from "@/utils/parse";
+import { mapShards } from "@/utils/shards";
+
+/** Shape of a single entry in `hadolint --format json` output */
+interface HadolintEntry {
+  file: string;
+  line: number;
+  column: number;
+  level: string;
+  code: string;
+  message: string;
+}
+
+function isHadolintEntry(value: unknown): value is HadolintEntry {
+  return (
+    typeof value === "object" &&
+    value !== null &&
+    "file" in value &&
+    typeof value.file === "string" &&
+    "line" in value &&
+    typeof value.line === "number" &&
+    "column" in value &&
+    typeof value.column === "number" &&
+    "code" in value &&
+    typeof value.code === "string" &&
+    "message" in value &&
+    typeof value.message === "string"
+  );
+}
+
+const SEVERITY_BY_LEVEL: Record<string, Severity> = {
+  error: "error",
+  warning: "warning",
+  info: "info",
+  style: "info",
+};
+
+/**
+ * Parse `hadolint --format json` stdout into raw issues without fingerprints.
+ * Levels error → error, warning → warning, info and style → info.
+ * Returns [] on malformed/empty input.
+ */
+export function parseHadolintOutput(
+  stdout: string,
+  projectDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const parsed = safeParseJson(stdout);
+  if (!Array.isArray(parsed)) return [];
+
+  return parsed.filter(isHadolintEntry).map((entry) => {
+    const severity = SEVERITY_BY_LEVEL[entry.level] ?? "warning";
+    return {
+      rule: `hadolint/${entry.code}`,
+      linter: "hadolint",
+      file: resolve(projectDir, entry.file),
+      line: entry.line,
+      col: entry.column,
+      message: entry.message,
+      severity,
+    } satisfies Omit<LintIssue, "fingerprint">;
+  });
+}
+
+export const DOCKERFILE_GLOB = "**/{Dockerfile,Containerfile,*.dockerfile}";
+
+/**
+ * Glob for Dockerfiles and Containerfiles.
+ * When a changed-file list is given, select from it instead.
+ */
+export async function findDockerfiles(
+  fileManager: FileManager,
+  projectDir: string,
+  files?: readonly string[]
+): Promise<string[]> {
+  if (files !== undefined) return matchFiles(files, DOCKERFILE_GLOB);
+  return fileManager.glob(DOCKERFILE_GLOB, projectDir);
+}
+
+export const hadolintRunner: LinterRunner = {
+  id: "hadolint",
+  name: "Hadolint",
+  configFile: ".hadolint.yaml",
+  fileScoped: true,
+  installHint: {
+    description: "Dockerfile linter (binaries: github.com/hadolint/hadolint/releases)",
+    brew: "brew install hadolint",
+  },
+  versionArgs: ["hadolint", "--version"],
+  cache: {
+    inputs: [DOCKERFILE_GLOB],
+  },
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    const result = await commandRunner.run(["hadolint", "--version"]);
+    return result.exitCode === 0;
+  },
+
+  async run({
+    projectDir,
+    commandRunner,
+    fileManager,
+    files: changed,
+    batchSize,
+  }: RunOptions): Promise<LintIssue[]> {
+    const files = await findDockerfiles(fileManager, projectDir, changed);
+    if (files.length === 0) return [];
+
+    // hadolint picks up .hadolint.yaml from the working directory on its own
+    const raw = await mapShards(files, batchSize, async (shard) => {
+      const result = await commandRunner.run(
+        ["hadolint", "--format", "json", ...shard],
+        { cwd: projectDir }
+      );
+      return parseHadolintOutput(result.stdout, projectDir);
+    });
+    return applyFingerprints(raw, projectDir, fileManager);
+  },
+};
@@ -0,0 +1,245 @@
+import { join, relative, resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { LintIssue, Severity } from "@/models/lint-issue";
+import { CACHE_DIR } from "@/models/paths";
+import type { InstallHint, LinterRunner, RunOptions } from "@/runners/types";
+import { parseYamllintOutput, yamllintRunner } from "@/runners/yamllint";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { CHART_GLOB, findCharts } from "@/utils/helm-charts";
+
+// Matches lines like: [WARNING] templates/service.yaml: object name does not conform
+const HELM_LINT_LINE_PATTERN = /^\[(ERROR|WARNING|INFO)\] ([^:]*): (.+)$/;
+
+const SEVERITY_BY_LEVEL: Record<string, Severity> = {
+  ERROR: "error",
+  WARNING: "warning",
+  INFO: "info",
+};
+
+/** Where a message like `x.yaml:12:20: executing` or `yaml: line 8` points */
+function positionIn(message: string): { line: number; col: number } {
+  const template = /:(\d+):(\d+):/.exec(message);
+  if (template?.[1] && template[2]) {
+    return {
+      line: Number.parseInt(template[1], 10),
+      col: Number.parseInt(template[2], 10),
+    };
+  }
+  const yaml = /\bline (\d+)\b/.exec(message);
+  return { line: yaml?.[1] ? Number.parseInt(yaml[1], 10) : 1, col: 1 };
+}
+
+/** The part of a chart a path names, used as the rule: chart, values or template */
+function chartPart(path: string): string {
+  if (path.startsWith("templates/") || path.includes("/templates/")) return "template";
+  if (/(^|\/)values[^/]*$/.test(path)) return "values";
+  return "chart";
+}
+
+/**
+ * Parse `helm lint` stdout into raw issues without fingerprints. Paths are
+ * relative to the chart; a finding on the chart as a whole (no path) is
+ * reported on its Chart.yaml. helm has no rule ids, so the rule names the
+ * part of the chart: `helm-lint/chart`, `helm-lint/values` or
+ * `helm-lint/template`.
+ */
+export function parseHelmLintOutput(
+  stdout: string,
+  chartDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const issues: Omit<LintIssue, "fingerprint">[] = [];
+  for (const line of stdout.split("\n")) {
+    const match = HELM_LINT_LINE_PATTERN.exec(line.trim());
+    if (!match) continue;
+    const [, level, path = "", message] = match;
+    if (!level || !message) continue;
+    const file = path === "" ? "Chart.yaml" : path;
+    issues.push({
+      rule: `helm-lint/${chartPart(file)}`,
+      linter: "helm-lint",
+      file: resolve(chartDir, file),
+      ...positionIn(message),
+      message,
+      severity: SEVERITY_BY_LEVEL[level] ?? "warning",
+    });
+  }
+  return issues;
+}
+
+/**
+ * The chart file a rendered path such as `mychart/templates/x.yaml` came
+ * from. helm prefixes every path with the chart's name, not its directory.
+ */
+function sourceOf(rendered: string, chartDir: string): string {
+  const [, ...rest] = rendered.split("/");
+  return join(chartDir, ...rest);
+}
+
+// A `<chart>/templates/x.yaml` path in a render error, optionally :line:col
+const RENDER_PATH_PATTERN =
+  /([\w.-]+\/[^\s:()"]+\.(?:ya?ml|tpl|txt|json))(?::(\d+))?(?::(\d+))?/;
+
+/**
+ * Parse the error `helm template` prints when a chart fails to render into
+ * one raw issue (`helm-template/render`) on the template it names, or on
+ * Chart.yaml when it names none. Returns [] for empty stderr.
+ */
+export function parseHelmTemplateError(
+  stderr: string,
+  chartDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const message = stderr.trim().replace(/^Error: /, "");
+  if (message === "") return [];
+  const match = RENDER_PATH_PATTERN.exec(message);
+  const [, path, line, col] = match ?? [];
+  const position = positionIn(message);
+  return [
+    {
+      rule: "helm-template/render",
+      linter: "helm-template",
+      file: path ? sourceOf(path, chartDir) : join(chartDir, "Chart.yaml"),
+      line: line ? Number.parseInt(line, 10) : position.line,
+      col: col ? Number.parseInt(col, 10) : position.col,
+      message: message.split("\n")[0] ?? message,
+      severity: "error",
+    },
+  ];
+}
+
+/** The files `helm template --output-dir` reports writing, in order */
+export function parseWrittenFiles(stdout: string): string[] {
+  return stdout
+    .split("\n")
+    .map((line) => /^wrote (.+)$/.exec(line.trim())?.[1])
+    .filter((path) => path !== undefined);
+}
+
+async function helmAvailable(commandRunner: CommandRunner): Promise<boolean> {
+  try {
+    const result = await commandRunner.run(["helm", "version", "--short"]);
+    return result.exitCode === 0;
+  } catch {
+    return false;
+  }
+}
+
+const HELM_INSTALL_HINT: InstallHint = {
+  description: "Kubernetes package manager (helm lint, helm template)",
+  brew: "brew install helm",
+  go: "go install helm.sh/helm/v3/cmd/helm@latest",
+};
+
+const HELM_CACHE_INPUTS = [
+  CHART_GLOB,
+  "**/Chart.lock",
+  "**/values*.{yaml,yml,json}",
+  "**/templates/**",
+  "**/charts/**",
+  "**/.helmignore",
+];
+
+export const helmLintRunner: LinterRunner = {
+  id: "helm-lint",
+  name: "helm lint",
+  configFile: null,
+  fileScoped: true,
+  installHint: HELM_INSTALL_HINT,
+  versionArgs: ["helm", "version", "--short"],
+  cache: {
+    inputs: HELM_CACHE_INPUTS,
+  },
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    return helmAvailable(commandRunner);
+  },
+
+  async run(opts: RunOptions): Promise<LintIssue[]> {
+    const { projectDir, config, commandRunner, fileManager } = opts;
+    const charts = await findCharts(
+      fileManager,
+      projectDir,
+      config.ignorePaths,
+      opts.files
+    );
+    const perChart = await Promise.all(
+      charts.map(async (chart) => {
+        const chartDir = join(projectDir, chart);
+        const result = await commandRunner.run(["helm", "lint", "."], {
+          cwd: chartDir,
+        });
+        const raw = parseHelmLintOutput(result.stdout, chartDir);
+        // helm lint exits 1 on [ERROR] findings; failing without any is helm's own
+        if (result.exitCode !== 0 && !raw.some((i) => i.severity === "error")) {
+          const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+          throw new Error(`helm-lint failed: ${detail}`);
+        }
+        return raw;
+      })
+    );
+    return applyFingerprints(perChart.flat(), projectDir, fileManager);
+  },
+};
+
+/**
+ * Renders each chart and lints the manifests. A render failure is one
+ * finding; `helm template` stops at the first. The rendered YAML goes through
+ * yamllint (when installed), reported on the source template with the line
+ * in the rendered manifest. Opt-in, as a chart renders only once its
+ * dependencies are fetched and its default values are complete.
+ */
+export const helmTemplateRunner: LinterRunner = {
+  id: "helm-template",
+  name: "helm template",
+  configFile: null,
+  fileScoped: true,
+  defaultEnabled: false,
+  installHint: HELM_INSTALL_HINT,
+  versionArgs: ["helm", "version", "--short"],
+  cache: {
+    inputs: [...HELM_CACHE_INPUTS, ".yamllint.yaml"],
+  },
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    return helmAvailable(commandRunner);
+  },
+
+  async run(opts: RunOptions): Promise<LintIssue[]> {
+    const { projectDir, config, commandRunner, fileManager } = opts;
+    const charts = await findCharts(
+      fileManager,
+      projectDir,
+      config.ignorePaths,
+      opts.files
+    );
+    if (charts.length === 0) return [];
+    const yamllint = await yamllintRunner.isAvailable(commandRunner);
+    const perChart = await Promise.all(
+      charts.map(async (chart) => {
+        const chartDir = join(projectDir, chart);
+        const outDir = join(projectDir, CACHE_DIR, "helm-template", chart);
+        const rendered = await commandRunner.run(
+          ["helm", "template", ".", "--output-dir", outDir],
+          { cwd: chartDir }
+        );
+        if (rendered.exitCode !== 0) {
+          return parseHelmTemplateError(rendered.stderr, chartDir);
+        }
+        const written = parseWrittenFiles(rendered.stdout);
+        if (!yamllint || written.length === 0) return [];
+        // From the project root, so the project's .yamllint.yaml applies
+        const linted = await commandRunner.run(
+          ["yamllint", "-f", "parsable", ...written],
+          { cwd: projectDir }
+        );
+        return parseYamllintOutput(linted.stdout, projectDir).map((issue) => ({
+          ...issue,
+          rule: issue.rule.replace(/^yamllint\//, "helm-template/"),
+          linter: "helm-template",
+          file: sourceOf(relative(outDir, issue.file), chartDir),
+          message: `${issue.message} (line ${issue.line} of the rendered manifest)`,
+        }));
+      })
+    );
+    return applyFingerprints(perChart.flat(), projectDir, fileManager);
+  },
+};
@@ -0,0 +1,80 @@
+import { resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { LintIssue } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { goModulesFor, withModule } from "@/utils/go-modules";
+
+// Like every go/analysis checker, ineffassign exits 3 when it reports findings
+// and 1 when packages fail to load
+const COMPLETED_EXIT_CODES = new Set([0, 3]);
+
+/** e.g. `/repo/main.go:14:2: ineffectual assignment to err` */
+const LINE_RE = /^(.+?):(\d+):(\d+):\s+(.*)$/;
+
+/**
+ * Parse ineffassign output — one `file:line:col: message` line per finding,
+ * printed to stderr — into raw issues without fingerprints. Returns [] for
+ * empty output.
+ */
+export function parseIneffassignOutput(
+  output: string,
+  moduleDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const issues: Omit<LintIssue, "fingerprint">[] = [];
+  for (const line of output.split("\n")) {
+    const match = LINE_RE.exec(line.trim());
+    if (match === null) continue;
+    const [, file = "", lineNo = "1", col = "1", message = ""] = match;
+    issues.push({
+      rule: "ineffassign/ineffectual-assignment",
+      linter: "ineffassign",
+      file: resolve(moduleDir, file),
+      line: Number.parseInt(lineNo, 10),
+      col: Number.parseInt(col, 10),
+      message,
+      severity: "error",
+    });
+  }
+  return issues;
+}
+
+export const ineffassignRunner: LinterRunner = {
+  id: "ineffassign",
+  name: "ineffassign",
+  configFile: null,
+  installHint: {
+    description: "Go ineffectual assignment detector",
+    go: "go install github.com/gordonklaus/ineffassign@latest",
+  },
+  moduleScoped: true,
+  // No cache: ineffassign has no version flag to key cached results on
+  watchInputs: ["**/*.go", "**/go.mod", "**/go.sum"],
+  // The standard and strict .golangci.yml enable ineffassign too
+  supersedes: ["golangci-lint/ineffassign"],
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    // -h prints usage and exits non-zero; 127 means it is not installed
+    const result = await commandRunner.run(["ineffassign", "-h"]);
+    return result.exitCode !== 127;
+  },
+
+  async run(opts: RunOptions): Promise<LintIssue[]> {
+    const { projectDir, commandRunner, fileManager } = opts;
+    const modules = await goModulesFor(opts);
+    const perModule = await Promise.all(
+      modules.map(async (moduleDir) => {
+        const result = await commandRunner.run(["ineffassign", "./..."], {
+          cwd: moduleDir,
+        });
+        if (!COMPLETED_EXIT_CODES.has(result.exitCode)) {
+          const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+          throw new Error(`ineffassign failed: ${detail}`);
+        }
+        const raw = parseIneffassignOutput(result.stderr, moduleDir);
+        return withModule(raw, projectDir, moduleDir);
+      })
+    );
+    return applyFingerprints(perModule.flat(), projectDir, fileManager);
+  },
+};
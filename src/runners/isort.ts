@@ -0,0 +1,156 @@
+import { join, relative, resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
+import type { LintIssue } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
+import { pipeFix } from "@/utils/pipe-fix";
+import { forEachShard, mapShards } from "@/utils/shards";
+
+const PYTHON_GLOB = "**/*.{py,pyi}";
+
+/** Where isort reads its settings from, with the section it looks for */
+const ISORT_CONFIG_SECTIONS: ReadonlyArray<readonly [string, RegExp]> = [
+  [".isort.cfg", /^\[(?:settings|isort)\]/m],
+  ["pyproject.toml", /^\[tool\.isort\]/m],
+  ["setup.cfg", /^\[(?:tool:)?isort\]/m],
+  ["tox.ini", /^\[(?:tool:)?isort\]/m],
+];
+
+/** isort exits 1 when it finds unsorted files, 2 and up on a usage error */
+const COMPLETED_EXIT_CODES = new Set([0, 1]);
+
+/** e.g. `ERROR: /p/app.py Imports are incorrectly sorted and/or formatted.` */
+const ERROR_RE = /^ERROR: (.+?) Imports are incorrectly sorted/;
+/** The header of a file's `--diff`, e.g. `--- /p/app.py:before	2024-...` */
+const DIFF_FILE_RE = /^--- (.+?):before\b/;
+const HUNK_RE = /^@@ -(\d+)/;
+
+/** True when the project configures isort, e.g. with a [tool.isort] table */
+export async function usesIsort(
+  projectDir: string,
+  fileManager: FileManager
+): Promise<boolean> {
+  for (const [name, section] of ISORT_CONFIG_SECTIONS) {
+    const path = join(projectDir, name);
+    if (!(await fileManager.exists(path))) continue;
+    if (section.test(await fileManager.readText(path))) return true;
+  }
+  return false;
+}
+
+/**
+ * Parse `isort --check-only --diff` output into raw issues without
+ * fingerprints: one per file named on stderr, at the first line its diff
+ * (on stdout) changes. Returns [] when every file is sorted.
+ */
+export function parseIsortOutput(
+  stdout: string,
+  stderr: string,
+  projectDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const firstChanged = new Map<string, number>();
+  let current: string | undefined;
+  for (const line of stdout.split("\n")) {
+    const header = DIFF_FILE_RE.exec(line);
+    if (header !== null) {
+      current = resolve(projectDir, header[1] ?? "");
+      continue;
+    }
+    const hunk = HUNK_RE.exec(line);
+    if (hunk !== null && current !== undefined && !firstChanged.has(current)) {
+      firstChanged.set(current, Number.parseInt(hunk[1] ?? "1", 10));
+    }
+  }
+  return stderr.split("\n").flatMap((line) => {
+    const match = ERROR_RE.exec(line.trim());
+    if (match === null) return [];
+    const file = resolve(projectDir, match[1] ?? "");
+    return [
+      {
+        rule: "isort/unsorted",
+        linter: "isort",
+        file,
+        line: Math.max(firstChanged.get(file) ?? 1, 1),
+        col: 1,
+        message: `Imports are not sorted — run: isort ${relative(projectDir, file)}`,
+        severity: "error",
+      } satisfies Omit<LintIssue, "fingerprint">,
+    ];
+  });
+}
+
+/** The changed Python files, or `.` for isort to walk, honouring its skips */
+function isortTargets(files: readonly string[] | undefined): string[] {
+  return files !== undefined ? matchFiles(files, PYTHON_GLOB) : ["."];
+}
+
+export const isortRunner: LinterRunner = {
+  id: "isort",
+  name: "isort",
+  configFile: null,
+  fileScoped: true,
+  installHint: {
+    description: "Python import sorter, for projects configured with isort",
+    pip: "pip install isort",
+  },
+  versionArgs: ["isort", "--version-number"],
+  cache: {
+    inputs: [PYTHON_GLOB, ...ISORT_CONFIG_SECTIONS.map(([name]) => name)],
+  },
+  // Ruff's isort rule would order the same imports by its own settings
+  supersedes: ["ruff/I001"],
+
+  /** Only where the project keeps isort settings: they are the rules */
+  async appliesTo({ projectDir, fileManager }: RunOptions): Promise<boolean> {
+    return usesIsort(projectDir, fileManager);
+  },
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    const result = await commandRunner.run(["isort", "--version-number"]);
+    return result.exitCode === 0;
+  },
+
+  async run({
+    projectDir,
+    commandRunner,
+    fileManager,
+    files,
+    batchSize,
+  }: RunOptions): Promise<LintIssue[]> {
+    const targets = isortTargets(files);
+    if (targets.length === 0) return [];
+    const check = async (shard: readonly string[]) => {
+      // --filter-files applies the config's skip list to files named on argv too
+      const result = await commandRunner.run(
+        ["isort", "--check-only", "--diff", "--filter-files", ...shard],
+        { cwd: projectDir }
+      );
+      if (!COMPLETED_EXIT_CODES.has(result.exitCode)) {
+        const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+        throw new Error(`isort failed: ${detail}`);
+      }
+      return parseIsortOutput(result.stdout, result.stderr, projectDir);
+    };
+    const raw = await mapShards(targets, batchSize, check);
+    return applyFingerprints(raw, projectDir, fileManager);
+  },
+
+  async fix(opts: RunOptions): Promise<void> {
+    const { projectDir, commandRunner, files, batchSize } = opts;
+    await forEachShard(isortTargets(files), batchSize, (shard) =>
+      commandRunner.run(["isort", "--filter-files", ...shard], { cwd: projectDir })
+    );
+  },
+
+  async previewFix(
+    { projectDir, commandRunner }: RunOptions,
+    file: string,
+    content: string
+  ): Promise<string> {
+    // --filename picks the settings that apply at the file's place
+    const args = ["isort", "--filename", file, "-"];
+    return pipeFix(commandRunner, args, content, projectDir);
+  },
+};
@@ -0,0 +1,136 @@
+import { resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { LintIssue } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
+import { safeParseJson } from "@/utils/parse";
+import { resolveToolPath } from "@/utils/resolve-tool-path";
+import { forEachShard, mapShards } from "@/utils/shards";
+
+export const KOTLIN_GLOB = "**/*.{kt,kts}";
+
+/**
+ * Where projects keep a pinned ktlint binary — usually committed next to
+ * `gradlew`, since Gradle's ktlint plugins don't expose a CLI of their own.
+ */
+const KTLINT_LOCAL_DIRS = [".", "bin"];
+
+interface KtlintFile {
+  file: string;
+  errors: Array<{ line: number; column: number; message: string; rule: string }>;
+}
+
+function isKtlintOutput(value: unknown): value is KtlintFile[] {
+  return (
+    Array.isArray(value) &&
+    value.every(
+      (entry) =>
+        typeof entry === "object" &&
+        entry !== null &&
+        "file" in entry &&
+        "errors" in entry &&
+        Array.isArray(entry.errors)
+    )
+  );
+}
+
+async function resolveKtlint(
+  projectDir: string,
+  commandRunner: CommandRunner
+): Promise<string | null> {
+  return resolveToolPath("ktlint", projectDir, commandRunner, KTLINT_LOCAL_DIRS);
+}
+
+/**
+ * Parse `ktlint --reporter=json` stdout into raw issues without fingerprints.
+ * Returns [] on malformed or empty input. ktlint has no severities, so every
+ * violation is an error.
+ */
+export function parseKtlintOutput(
+  stdout: string,
+  projectDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const parsed = safeParseJson(stdout);
+  if (!isKtlintOutput(parsed)) return [];
+  return parsed.flatMap((entry) =>
+    entry.errors.map(
+      (error): Omit<LintIssue, "fingerprint"> => ({
+        rule: `ktlint/${error.rule}`,
+        linter: "ktlint",
+        file: resolve(projectDir, entry.file),
+        line: error.line,
+        col: error.column,
+        message: error.message,
+        severity: "error",
+      })
+    )
+  );
+}
+
+export const ktlintRunner: LinterRunner = {
+  id: "ktlint",
+  name: "ktlint",
+  configFile: ".editorconfig",
+  fileScoped: true,
+  installHint: {
+    description: "Kotlin linter and formatter",
+    brew: "brew install ktlint",
+  },
+  versionArgs: ["ktlint", "--version"],
+  cache: {
+    inputs: [KOTLIN_GLOB, "**/.editorconfig"],
+  },
+
+  async isAvailable(
+    commandRunner: CommandRunner,
+    projectDir?: string
+  ): Promise<boolean> {
+    const cmd = await resolveKtlint(projectDir ?? ".", commandRunner);
+    return cmd !== null;
+  },
+
+  async run({
+    projectDir,
+    commandRunner,
+    fileManager,
+    files,
+    batchSize,
+  }: RunOptions): Promise<LintIssue[]> {
+    // With no patterns ktlint lints every *.kt and *.kts under the working directory
+    const targets = files !== undefined ? matchFiles(files, KOTLIN_GLOB) : undefined;
+    if (targets !== undefined && targets.length === 0) return [];
+    const cmd = (await resolveKtlint(projectDir, commandRunner)) ?? "ktlint";
+    const lint = async (paths: readonly string[]) => {
+      const result = await commandRunner.run([cmd, "--reporter=json", ...paths], {
+        cwd: projectDir,
+      });
+      // Exit 1 also means violations were found; only unparsable output is a failure
+      if (result.exitCode !== 0 && !isKtlintOutput(safeParseJson(result.stdout))) {
+        const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+        throw new Error(`ktlint failed: ${detail}`);
+      }
+      return parseKtlintOutput(result.stdout, projectDir);
+    };
+    const raw =
+      targets !== undefined
+        ? await mapShards(targets, batchSize, lint)
+        : await lint([]);
+    return applyFingerprints(raw, projectDir, fileManager);
+  },
+
+  async fix({
+    projectDir,
+    commandRunner,
+    files,
+    batchSize,
+  }: RunOptions): Promise<void> {
+    const targets = files !== undefined ? matchFiles(files, KOTLIN_GLOB) : undefined;
+    if (targets !== undefined && targets.length === 0) return;
+    const cmd = (await resolveKtlint(projectDir, commandRunner)) ?? "ktlint";
+    const format = (paths: readonly string[]) =>
+      commandRunner.run([cmd, "--format", ...paths], { cwd: projectDir });
+    if (targets === undefined) await format([]);
+    else await forEachShard(targets, batchSize, format);
+  },
+};
@@ -0,0 +1,278 @@
+import { extname, join } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import { DEFAULT_IGNORE } from "@/languages/constants";
+import type { LintIssue } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
+
+/** Line-comment prefix per file extension */
+export const LICENSE_COMMENT_PREFIXES: Readonly<Record<string, string>> = {
+  go: "//",
+  ts: "//",
+  tsx: "//",
+  js: "//",
+  jsx: "//",
+  mjs: "//",
+  cjs: "//",
+  rs: "//",
+  c: "//",
+  h: "//",
+  cc: "//",
+  cpp: "//",
+  hpp: "//",
+  cs: "//",
+  java: "//",
+  kt: "//",
+  swift: "//",
+  proto: "//",
+  py: "#",
+  sh: "#",
+  bash: "#",
+  rb: "#",
+  tf: "#",
+  yaml: "#",
+  yml: "#",
+  toml: "#",
+  lua: "--",
+  sql: "--",
+};
+
+/** Extensions checked when [config] license_header_extensions is unset */
+export const DEFAULT_LICENSE_EXTENSIONS: readonly string[] = [
+  "go",
+  "ts",
+  "tsx",
+  "js",
+  "jsx",
+  "py",
+  "rs",
+  "c",
+  "h",
+  "cpp",
+  "cs",
+  "java",
+  "sh",
+  "lua",
+];
+
+/** Generated-code markers: Go's `Code generated ... DO NOT EDIT.`, `@generated` */
+const GENERATED_MARKER = /\bDO NOT EDIT\b|@generated\b/;
+const GENERATED_SCAN_LINES = 20;
+
+/** Leading lines that must stay first: shebang, encoding, Go build constraints */
+const PRELUDE_LINE = /^(?:#!|#.*\bcoding[:=]|\/\/go:build |\/\/ \+build )/;
+
+/** What tells an outdated license header from a doc comment */
+const LICENSE_WORDS = /licen[cs]e|copyright|spdx/i;
+
+/** `{year}` in a template: a single year or a range, e.g. 2019-2024 */
+const YEAR_PATTERN = "\\d{4}(?:\\s*-\\s*\\d{4})?";
+
+export interface HeaderCheck {
+  status: "ok" | "missing" | "mismatch";
+  /** 1-based line where the header belongs */
+  line: number;
+}
+
+function escapeRegExp(text: string): string {
+  return text.replace(/[.*+?^${}()|[\]\\]/g, "\\$&");
+}
+
+function templateLines(template: string): string[] {
+  return template
+    .replace(/\n+$/, "")
+    .split("\n")
+    .map((line) => line.trimEnd());
+}
+
+/** The template as line comments, with `{year}` filled in */
+export function renderHeader(template: string, prefix: string, year: number): string[] {
+  return templateLines(template)
+    .map((line) => line.replace(/\{year\}/g, String(year)))
+    .map((line) => (line === "" ? prefix : `${prefix} ${line}`));
+}
+
+function headerPatterns(template: string, prefix: string): RegExp[] {
+  return templateLines(template).map((line) => {
+    if (line === "") return new RegExp(`^${escapeRegExp(prefix)}$`);
+    const body = line.split("{year}").map(escapeRegExp).join(YEAR_PATTERN);
+    return new RegExp(`^${escapeRegExp(prefix)} ${body}$`);
+  });
+}
+
+/** True when the file's first lines mark it as generated */
+export function isGeneratedFile(content: string): boolean {
+  return content
+    .split("\n", GENERATED_SCAN_LINES)
+    .some((line) => GENERATED_MARKER.test(line));
+}
+
+/** Index of the first line after the prelude and the blank lines following it */
+function preludeEnd(lines: readonly string[]): number {
+  let end = 0;
+  while (end < lines.length && PRELUDE_LINE.test(lines[end] ?? "")) end++;
+  if (end === 0) return 0;
+  while (end < lines.length && (lines[end] ?? "").trim() === "") end++;
+  return end;
+}
+
+/**
+ * End (exclusive) of a license comment at `start`: a comment block that
+ * mentions a license or copyright and is followed by a blank line, so a doc
+ * comment is never taken for one. null when there is none.
+ */
+function licenseCommentEnd(
+  lines: readonly string[],
+  start: number,
+  prefix: string
+): number | null {
+  let end = start;
+  while (end < lines.length && (lines[end] ?? "").startsWith(prefix)) end++;
+  if (end === start || (lines[end] ?? "").trim() !== "") return null;
+  return LICENSE_WORDS.test(lines.slice(start, end).join("\n")) ? end : null;
+}
+
+/**
+ * Where a file's header stands against the template: present after the
+ * prelude, missing, or a different license comment in its place.
+ */
+export function checkHeader(
+  content: string,
+  template: string,
+  prefix: string
+): HeaderCheck {
+  const lines = content.split("\n");
+  const start = preludeEnd(lines);
+  const line = start + 1;
+  const matches = headerPatterns(template, prefix).every((pattern, i) =>
+    pattern.test((lines[start + i] ?? "").trimEnd())
+  );
+  if (matches) return { status: "ok", line };
+  const existing = licenseCommentEnd(lines, start, prefix);
+  return { status: existing !== null ? "mismatch" : "missing", line };
+}
+
+/**
+ * Content with the template's header in place: inserted after the prelude,
+ * or replacing an outdated license comment there. Unchanged when the header
+ * is already present.
+ */
+export function applyHeader(
+  content: string,
+  template: string,
+  prefix: string,
+  year: number
+): string {
+  if (checkHeader(content, template, prefix).status === "ok") return content;
+  const lines = content.split("\n");
+  const start = preludeEnd(lines);
+  const end = licenseCommentEnd(lines, start, prefix);
+  const prelude = lines.slice(0, start);
+  // A prelude directly followed by code (e.g. a bare shebang) gets a blank line
+  const gap = prelude.length > 0 && prelude.at(-1)?.trim() !== "" ? [""] : [];
+  const rest = end !== null ? lines.slice(end) : ["", ...lines.slice(start)];
+  return [...prelude, ...gap, ...renderHeader(template, prefix, year), ...rest].join(
+    "\n"
+  );
+}
+
+function licenseExtensions(config: ResolvedConfig): readonly string[] {
+  return config.values.license_header_extensions ?? DEFAULT_LICENSE_EXTENSIONS;
+}
+
+function extensionsGlob(extensions: readonly string[]): string {
+  return extensions.length === 1
+    ? `**/*.${extensions[0] ?? ""}`
+    : `**/*.{${extensions.join(",")}}`;
+}
+
+function commentPrefix(file: string): string {
+  const ext = extname(file).slice(1);
+  const prefix = LICENSE_COMMENT_PREFIXES[ext];
+  if (prefix === undefined) {
+    throw new Error(`license-header: no comment syntax known for .${ext} files`);
+  }
+  return prefix;
+}
+
+interface CandidateFile {
+  file: string;
+  path: string;
+  content: string;
+  prefix: string;
+}
+
+/** Non-empty, non-generated files with a licensed extension */
+async function candidateFiles({
+  projectDir,
+  config,
+  fileManager,
+  files: changed,
+}: RunOptions): Promise<CandidateFile[]> {
+  const pattern = extensionsGlob(licenseExtensions(config));
+  const files =
+    changed !== undefined
+      ? matchFiles(changed, pattern)
+      : await fileManager.glob(pattern, projectDir, [
+          ...DEFAULT_IGNORE,
+          ...config.ignorePaths,
+        ]);
+  const candidates: CandidateFile[] = [];
+  for (const file of [...files].sort()) {
+    const path = join(projectDir, file);
+    if (!(await fileManager.exists(path))) continue;
+    const content = await fileManager.readText(path);
+    if (content.trim() === "" || isGeneratedFile(content)) continue;
+    candidates.push({ file, path, content, prefix: commentPrefix(file) });
+  }
+  return candidates;
+}
+
+export const licenseHeaderRunner: LinterRunner = {
+  id: "license-header",
+  name: "License header",
+  configFile: null,
+  fileScoped: true,
+  installHint: {
+    description: "Built-in license header check ([config] license_header)",
+  },
+
+  async isAvailable(): Promise<boolean> {
+    return true;
+  },
+
+  async run(opts: RunOptions): Promise<LintIssue[]> {
+    const template = opts.config.values.license_header;
+    // No template, nothing to enforce
+    if (template === undefined) return [];
+    const raw: Omit<LintIssue, "fingerprint">[] = [];
+    for (const { path, content, prefix } of await candidateFiles(opts)) {
+      const { status, line } = checkHeader(content, template, prefix);
+      if (status === "ok") continue;
+      raw.push({
+        rule: `license-header/${status}`,
+        linter: "license-header",
+        file: path,
+        line,
+        col: 1,
+        message:
+          status === "missing"
+            ? "Missing license header"
+            : "License header does not match [config] license_header",
+        severity: "error",
+      });
+    }
+    return applyFingerprints(raw, opts.projectDir, opts.fileManager);
+  },
+
+  async fix(opts: RunOptions): Promise<void> {
+    const template = opts.config.values.license_header;
+    if (template === undefined) return;
+    const year = new Date().getFullYear();
+    for (const { path, content, prefix } of await candidateFiles(opts)) {
+      const fixed = applyHeader(content, template, prefix, year);
+      if (fixed !== content) await opts.fileManager.writeText(path, fixed);
+    }
+  },
+};
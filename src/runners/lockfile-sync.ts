@@ -0,0 +1,198 @@
+import { basename, join } from "node:path";
+import type { CommandRunner, RunResult } from "@/infra/command-runner";
+import type { LintIssue } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { isTransientError } from "@/utils/retry";
+
+/** One ecosystem's manifest and lockfile, and the tool that compares them */
+export interface LockfileCheck {
+  /** Rule suffix: `lockfile-sync/<id>` */
+  id: string;
+  /** Manifest at the project root, e.g. package.json */
+  manifest: string;
+  /** Lockfile names the tool accepts; the first present is checked */
+  lockfiles: readonly string[];
+  /** Proves the tool is installed; the check is skipped otherwise */
+  versionArgs: readonly string[];
+  /** Reports a stale lockfile without writing it; run from the project root */
+  args: readonly string[];
+  /** What updates the lockfile, shown in the finding */
+  fix: string;
+  /** Whether the run says the lockfile would change; defaults to a failed run */
+  outOfSync?(result: RunResult, lockfile: string): boolean;
+}
+
+/** The `name==version` pins of a requirements file, names PEP 503-normalized */
+export function requirementPins(text: string): string[] {
+  const pins: string[] = [];
+  for (const line of text.split("\n")) {
+    const match = /^([A-Za-z0-9][\w.-]*)(?:\[[^\]]*\])?==([^\s;\\]+)/.exec(line.trim());
+    if (!match?.[1] || !match[2]) continue;
+    pins.push(`${match[1].toLowerCase().replace(/[-_.]+/g, "-")}==${match[2]}`);
+  }
+  return pins.sort();
+}
+
+export const LOCKFILE_CHECKS: readonly LockfileCheck[] = [
+  {
+    id: "go",
+    manifest: "go.mod",
+    lockfiles: ["go.sum"],
+    versionArgs: ["go", "version"],
+    args: ["go", "mod", "verify"],
+    fix: "go mod tidy",
+  },
+  {
+    id: "npm",
+    manifest: "package.json",
+    lockfiles: ["package-lock.json"],
+    versionArgs: ["npm", "--version"],
+    args: ["npm", "ci", "--dry-run", "--ignore-scripts"],
+    fix: "npm install",
+  },
+  {
+    id: "bun",
+    manifest: "package.json",
+    lockfiles: ["bun.lock", "bun.lockb"],
+    versionArgs: ["bun", "--version"],
+    args: ["bun", "install", "--frozen-lockfile", "--dry-run"],
+    fix: "bun install",
+  },
+  {
+    id: "pip-compile",
+    manifest: "requirements.in",
+    lockfiles: ["requirements.txt"],
+    versionArgs: ["pip-compile", "--version"],
+    args: ["pip-compile", "--dry-run", "--no-header", "requirements.in"],
+    fix: "pip-compile requirements.in",
+    // --dry-run exits 0 and prints the file it would write instead
+    outOfSync(result, lockfile) {
+      if (result.exitCode !== 0) return true;
+      const compiled = requirementPins(`${result.stdout}\n${result.stderr}`);
+      return compiled.join("\n") !== requirementPins(lockfile).join("\n");
+    },
+  },
+  {
+    id: "poetry",
+    manifest: "pyproject.toml",
+    lockfiles: ["poetry.lock"],
+    versionArgs: ["poetry", "--version"],
+    args: ["poetry", "check", "--lock"],
+    fix: "poetry lock",
+  },
+];
+
+interface PresentCheck {
+  check: LockfileCheck;
+  /** Absolute path of the lockfile found */
+  lockfile: string;
+}
+
+/** The checks whose manifest and a lockfile are at the project root */
+async function presentChecks({
+  projectDir,
+  fileManager,
+}: RunOptions): Promise<PresentCheck[]> {
+  const present: PresentCheck[] = [];
+  for (const check of LOCKFILE_CHECKS) {
+    if (!(await fileManager.exists(join(projectDir, check.manifest)))) continue;
+    for (const name of check.lockfiles) {
+      const lockfile = join(projectDir, name);
+      if (!(await fileManager.exists(lockfile))) continue;
+      present.push({ check, lockfile });
+      break;
+    }
+  }
+  return present;
+}
+
+async function toolAvailable(
+  commandRunner: CommandRunner,
+  versionArgs: readonly string[]
+): Promise<boolean> {
+  try {
+    const result = await commandRunner.run([...versionArgs]);
+    return result.exitCode === 0;
+  } catch {
+    return false;
+  }
+}
+
+/** The line of a failed run that says what is wrong, for the finding's message */
+function failureDetail(result: RunResult): string {
+  const lines = (result.stderr.trim() || result.stdout.trim())
+    .split("\n")
+    .map((line) => line.replace(/^(?:npm )?error:?/i, "").trim())
+    .filter((line) => line !== "" && !/^code \w+$/.test(line));
+  const telling = lines.find((line) => /sync|mismatch|verif|changed/i.test(line));
+  return telling ?? lines[0] ?? "";
+}
+
+/** The finding for a lockfile the check's run says would change, else none */
+export function lockfileIssues(
+  check: LockfileCheck,
+  lockfile: string,
+  result: RunResult,
+  lockfileText: string
+): Omit<LintIssue, "fingerprint">[] {
+  const outOfSync = check.outOfSync?.(result, lockfileText) ?? result.exitCode !== 0;
+  if (!outOfSync) return [];
+  const detail = result.exitCode !== 0 ? failureDetail(result) : "";
+  const stale = `${basename(lockfile)} is out of sync with ${check.manifest}`;
+  return [
+    {
+      rule: `lockfile-sync/${check.id}`,
+      linter: "lockfile-sync",
+      file: lockfile,
+      line: 1,
+      col: 1,
+      message:
+        `${stale} — run: ${check.fix}` +
+        (detail !== "" ? ` (${detail})` : ""),
+      severity: "error",
+    },
+  ];
+}
+
+/**
+ * Checks that each ecosystem's lockfile at the project root agrees with its
+ * manifest, with the ecosystem's own tool in a mode that writes nothing. A
+ * pair whose tool is not installed is skipped; a run that fails on the
+ * network throws, so it is retried rather than reported.
+ */
+export const lockfileSyncRunner: LinterRunner = {
+  id: "lockfile-sync",
+  name: "Lockfile sync",
+  configFile: null,
+  installHint: {
+    description:
+      "Built-in; uses each ecosystem's tool (go, npm, bun, pip-compile, poetry)",
+  },
+  retry: { attempts: 2, backoff: 2 },
+
+  async appliesTo(opts: RunOptions): Promise<boolean> {
+    return (await presentChecks(opts)).length > 0;
+  },
+
+  async isAvailable(): Promise<boolean> {
+    return true;
+  },
+
+  async run(opts: RunOptions): Promise<LintIssue[]> {
+    const { projectDir, commandRunner, fileManager } = opts;
+    const raw: Omit<LintIssue, "fingerprint">[] = [];
+    for (const { check, lockfile } of await presentChecks(opts)) {
+      if (!(await toolAvailable(commandRunner, check.versionArgs))) continue;
+      const result = await commandRunner.run([...check.args], { cwd: projectDir });
+      if (result.exitCode !== 0 && isTransientError(result.stderr)) {
+        throw new Error(`lockfile-sync failed: ${result.stderr.trim()}`);
+      }
+      // Only a check comparing output reads the lockfile; bun.lockb is binary
+      const text =
+        check.outOfSync !== undefined ? await fileManager.readText(lockfile) : "";
+      raw.push(...lockfileIssues(check, lockfile, result, text));
+    }
+    return applyFingerprints(raw, projectDir, fileManager);
+  },
+};
@@ -0,0 +1,433 @@
+import { dirname, join, relative, resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
+import { DEFAULT_IGNORE } from "@/languages/constants";
+import type { LintIssue, Severity } from "@/models/lint-issue";
+import { CACHE_DIR } from "@/models/paths";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
+import { safeParseJson } from "@/utils/parse";
+import { mapPool } from "@/utils/pool";
+
+const MARKDOWN_LINKS_ID = "markdown-links";
+const MARKDOWN_GLOB = "**/*.md";
+
+/** A link target in a markdown file, at its 1-based line and column */
+export interface MarkdownLink {
+  target: string;
+  line: number;
+  col: number;
+}
+
+export interface MarkdownDocument {
+  links: MarkdownLink[];
+  /** Fragments a link may point at: heading slugs and HTML id/name anchors */
+  anchors: Set<string>;
+}
+
+const FENCE = /^\s{0,3}(`{3,}|~{3,})/;
+const ATX_HEADING = /^\s{0,3}#{1,6}\s+(.*?)(?:\s+#+)?\s*$/;
+const SETEXT_UNDERLINE = /^\s{0,3}(=+|-+)\s*$/;
+const REFERENCE_DEFINITION = /^\s{0,3}\[[^\]]+\]:\s*(<[^>]*>|\S+)/;
+// [label](target "title"), with one level of nested brackets for badges:
+// [![alt](image)](target)
+const INLINE_LINK =
+  /!?\[((?:[^[\]]|\[[^\]]*\])*)\]\(\s*(<[^>]*>|[^\s)]+)(?:\s+[^)]*)?\)/g;
+const AUTOLINK = /<(https?:\/\/[^>\s]+)>/g;
+const HTML_LINK = /<(?:a|img)\b[^>]*?\b(?:href|src)="([^"]+)"/gi;
+const HTML_ANCHOR = /<[a-z][^>]*?\b(?:id|name)="([^"]+)"/gi;
+const CODE_SPAN = /(`+).*?\1/g;
+
+/**
+ * GitHub's heading anchor: lowercased, punctuation dropped, each space a
+ * hyphen, e.g. "Config & CLI flags" → "config--cli-flags".
+ */
+export function headingSlug(text: string): string {
+  return text
+    .replace(/\[([^\]]*)\]\([^)]*\)/g, "$1")
+    .replace(/<[^>]+>/g, "")
+    .trim()
+    .toLowerCase()
+    .replace(/[^\p{L}\p{N}\s_-]/gu, "")
+    .replace(/\s/g, "-");
+}
+
+function unwrap(target: string): string {
+  return target.startsWith("<") && target.endsWith(">") ? target.slice(1, -1) : target;
+}
+
+/** Blank out code spans, keeping columns, so links inside them are skipped */
+function withoutCodeSpans(line: string): string {
+  return line.replace(CODE_SPAN, (span) => " ".repeat(span.length));
+}
+
+function inlineLinks(text: string, line: number, offset: number): MarkdownLink[] {
+  const links: MarkdownLink[] = [];
+  for (const match of text.matchAll(INLINE_LINK)) {
+    const [whole, label, target] = match;
+    if (label === undefined || target === undefined) continue;
+    const col = offset + match.index + 1;
+    links.push({ target: unwrap(target), line, col });
+    // A badge's image sits inside the label
+    const labelStart = offset + match.index + whole.indexOf("[") + 1;
+    links.push(...inlineLinks(label, line, labelStart));
+  }
+  return links;
+}
+
+function frontMatterEnd(lines: readonly string[]): number {
+  if (lines[0]?.trimEnd() !== "---") return 0;
+  const close = lines.findIndex((line, i) => i > 0 && line.trimEnd() === "---");
+  return close === -1 ? 0 : close + 1;
+}
+
+/**
+ * The links and anchors of a markdown document. Fenced code blocks, code
+ * spans and YAML front matter are skipped; a link must sit on one line.
+ */
+export function parseMarkdown(content: string): MarkdownDocument {
+  const lines = content.split("\n");
+  const links: MarkdownLink[] = [];
+  const anchors = new Set<string>();
+  const slugCounts = new Map<string, number>();
+  const addHeading = (text: string): void => {
+    const slug = headingSlug(text);
+    const n = slugCounts.get(slug) ?? 0;
+    slugCounts.set(slug, n + 1);
+    anchors.add(n === 0 ? slug : `${slug}-${n}`);
+  };
+
+  let fence: string | null = null;
+  for (let i = frontMatterEnd(lines); i < lines.length; i++) {
+    const raw = lines[i] ?? "";
+    const fenceMatch = FENCE.exec(raw);
+    if (fence !== null) {
+      const closes = fenceMatch?.[1];
+      if (closes?.[0] === fence[0] && closes.length >= fence.length) fence = null;
+      continue;
+    }
+    if (fenceMatch?.[1] !== undefined) {
+      fence = fenceMatch[1];
+      continue;
+    }
+
+    const line = withoutCodeSpans(raw);
+    const lineNo = i + 1;
+    // Headings keep their code spans: `## The \`check\` command` → #the-check-command
+    const heading = ATX_HEADING.exec(raw)?.[1];
+    const previous = lines[i - 1] ?? "";
+    if (heading !== undefined) {
+      addHeading(heading);
+    } else if (
+      i > 0 &&
+      SETEXT_UNDERLINE.test(line) &&
+      previous.trim() !== "" &&
+      !ATX_HEADING.test(previous)
+    ) {
+      addHeading(previous);
+    }
+
+    const reference = REFERENCE_DEFINITION.exec(line)?.[1];
+    if (reference !== undefined) {
+      const col = line.indexOf("[") + 1;
+      links.push({ target: unwrap(reference), line: lineNo, col });
+      continue;
+    }
+    links.push(...inlineLinks(line, lineNo, 0));
+    for (const pattern of [AUTOLINK, HTML_LINK]) {
+      for (const match of line.matchAll(pattern)) {
+        if (match[1] !== undefined) {
+          links.push({ target: match[1], line: lineNo, col: match.index + 1 });
+        }
+      }
+    }
+    for (const match of line.matchAll(HTML_ANCHOR)) {
+      if (match[1] !== undefined) anchors.add(match[1]);
+    }
+  }
+  return { links, anchors };
+}
+
+function decode(text: string): string {
+  try {
+    return decodeURIComponent(text);
+  } catch {
+    return text;
+  }
+}
+
+function isExternal(target: string): boolean {
+  return /^https?:\/\//i.test(target);
+}
+
+/** mailto:, tel:, ftp: and other schemes, and protocol-relative URLs */
+function isUnchecked(target: string): boolean {
+  return target.startsWith("//") || /^[a-z][a-z\d+.-]*:/i.test(target);
+}
+
+type RawIssue = Omit<LintIssue, "fingerprint">;
+
+function brokenLink(
+  file: string,
+  link: MarkdownLink,
+  rule: string,
+  message: string,
+  severity: Severity = "error"
+): RawIssue {
+  return {
+    rule: `${MARKDOWN_LINKS_ID}/${rule}`,
+    linter: MARKDOWN_LINKS_ID,
+    file,
+    line: link.line,
+    col: link.col,
+    message,
+    severity,
+  };
+}
+
+/** Parsed markdown files of one run, so each linked document is read once */
+class DocumentCache {
+  private readonly docs = new Map<string, Promise<MarkdownDocument | null>>();
+  private readonly fileManager: FileManager;
+
+  constructor(fileManager: FileManager) {
+    this.fileManager = fileManager;
+  }
+
+  get(path: string): Promise<MarkdownDocument | null> {
+    let doc = this.docs.get(path);
+    if (doc === undefined) {
+      doc = this.fileManager.readText(path).then(parseMarkdown, () => null);
+      this.docs.set(path, doc);
+    }
+    return doc;
+  }
+}
+
+/**
+ * Broken local links of one markdown file: relative (or project-root `/`)
+ * paths that do not exist, and `#fragments` that match no heading of this
+ * or the linked markdown file.
+ */
+async function checkLocalLinks(
+  projectDir: string,
+  file: string,
+  doc: MarkdownDocument,
+  fileManager: FileManager,
+  docs: DocumentCache
+): Promise<RawIssue[]> {
+  const abs = resolve(projectDir, file);
+  const issues: RawIssue[] = [];
+  for (const link of doc.links) {
+    if (isExternal(link.target) || isUnchecked(link.target)) continue;
+    const [pathPart = "", fragment] = link.target.split("#", 2);
+    const path = decode(pathPart.split("?", 1)[0] ?? "");
+
+    if (path === "") {
+      const missing = fragment !== undefined && !doc.anchors.has(decode(fragment));
+      if (missing && fragment !== "") {
+        const message = `Broken link: no heading for #${fragment}`;
+        issues.push(brokenLink(abs, link, "missing-anchor", message));
+      }
+      continue;
+    }
+
+    const target = path.startsWith("/")
+      ? join(projectDir, path)
+      : resolve(dirname(abs), path);
+    const shown = relative(projectDir, target) || ".";
+    if (!(await fileManager.exists(target))) {
+      const message = `Broken link: ${shown} does not exist`;
+      issues.push(brokenLink(abs, link, "missing-file", message));
+      continue;
+    }
+    if (fragment === undefined || fragment === "" || !/\.md$/i.test(target)) continue;
+    const linked = await docs.get(target);
+    if (linked !== null && !linked.anchors.has(decode(fragment))) {
+      const message = `Broken link: no heading for #${fragment} in ${shown}`;
+      issues.push(brokenLink(abs, link, "missing-anchor", message));
+    }
+  }
+  return issues;
+}
+
+/** Where external link statuses are kept between runs */
+export const EXTERNAL_LINK_CACHE = `${CACHE_DIR}/external-links.json`;
+
+/** How long a checked URL's status is reused */
+export const EXTERNAL_LINK_TTL_MS = 24 * 60 * 60 * 1000;
+
+/** Hosts checked at once; each host's URLs are checked one after another */
+const EXTERNAL_HOSTS_IN_PARALLEL = 4;
+
+interface CachedStatus {
+  status: number;
+  checkedAt: number;
+}
+
+function isCachedStatus(value: unknown): value is CachedStatus {
+  return (
+    typeof value === "object" &&
+    value !== null &&
+    "status" in value &&
+    typeof value.status === "number" &&
+    "checkedAt" in value &&
+    typeof value.checkedAt === "number"
+  );
+}
+
+async function readStatusCache(
+  path: string,
+  fileManager: FileManager
+): Promise<Map<string, CachedStatus>> {
+  const cache = new Map<string, CachedStatus>();
+  if (!(await fileManager.exists(path))) return cache;
+  const parsed = safeParseJson(await fileManager.readText(path));
+  if (typeof parsed !== "object" || parsed === null) return cache;
+  for (const [url, entry] of Object.entries(parsed)) {
+    if (isCachedStatus(entry)) cache.set(url, entry);
+  }
+  return cache;
+}
+
+/** HTTP status of `url` via curl, or null when the request itself failed */
+async function fetchStatus(
+  url: string,
+  commandRunner: CommandRunner,
+  head: boolean
+): Promise<number | null> {
+  const result = await commandRunner.run([
+    "curl",
+    "--silent",
+    "--location",
+    ...(head ? ["--head"] : []),
+    "--output",
+    "/dev/null",
+    "--write-out",
+    "%{http_code}",
+    "--max-time",
+    "10",
+    url,
+  ]);
+  const status = Number.parseInt(result.stdout.trim(), 10);
+  return result.exitCode === 0 && status > 0 ? status : null;
+}
+
+async function urlStatus(
+  url: string,
+  commandRunner: CommandRunner
+): Promise<number | null> {
+  const head = await fetchStatus(url, commandRunner, true);
+  // Some servers refuse HEAD; confirm a failure with GET
+  if (head === null || head < 400) return head;
+  return fetchStatus(url, commandRunner, false);
+}
+
+function hostOf(url: string): string {
+  try {
+    return new URL(url).host;
+  } catch {
+    return url;
+  }
+}
+
+/**
+ * Broken external (http/https) links of the given files: URLs answering with
+ * an HTTP error status. Unreachable hosts and 429s are not reported, so an
+ * offline run stays clean. Statuses are cached for EXTERNAL_LINK_TTL_MS.
+ */
+async function checkExternalLinks(
+  projectDir: string,
+  linksByFile: ReadonlyMap<string, readonly MarkdownLink[]>,
+  commandRunner: CommandRunner,
+  fileManager: FileManager,
+  now = Date.now()
+): Promise<RawIssue[]> {
+  const cachePath = join(projectDir, EXTERNAL_LINK_CACHE);
+  const cache = await readStatusCache(cachePath, fileManager);
+  const targets = [...linksByFile.values()].flat().map((link) => link.target);
+  const urls = new Set(targets.filter(isExternal));
+  const stale = [...urls].filter((url) => {
+    const cached = cache.get(url);
+    return cached === undefined || now - cached.checkedAt > EXTERNAL_LINK_TTL_MS;
+  });
+
+  const byHost = new Map<string, string[]>();
+  for (const url of stale) {
+    const host = hostOf(url);
+    byHost.set(host, [...(byHost.get(host) ?? []), url]);
+  }
+  await mapPool([...byHost.values()], EXTERNAL_HOSTS_IN_PARALLEL, async (hostUrls) => {
+    for (const url of hostUrls) {
+      const status = await urlStatus(url, commandRunner);
+      // Rate limited: unknown, so asked again next run
+      if (status === null || status === 429) continue;
+      cache.set(url, { status, checkedAt: now });
+    }
+  });
+
+  if (stale.length > 0) {
+    await fileManager.mkdir(join(projectDir, CACHE_DIR), { parents: true });
+    const entries = Object.fromEntries(cache);
+    await fileManager.writeText(cachePath, `${JSON.stringify(entries, null, 2)}\n`);
+  }
+
+  const issues: RawIssue[] = [];
+  for (const [file, links] of linksByFile) {
+    for (const link of links) {
+      const status = cache.get(link.target)?.status;
+      if (status === undefined || status < 400) continue;
+      const abs = resolve(projectDir, file);
+      const message = `Broken link: ${link.target} returned HTTP ${status}`;
+      issues.push(brokenLink(abs, link, "broken-external", message, "warning"));
+    }
+  }
+  return issues;
+}
+
+export const markdownLinksRunner: LinterRunner = {
+  id: MARKDOWN_LINKS_ID,
+  name: "Markdown links",
+  configFile: null,
+  fileScoped: true,
+  installHint: {
+    description: "Built-in markdown link checker (curl for --check-external)",
+  },
+
+  async isAvailable(): Promise<boolean> {
+    return true;
+  },
+
+  async run({
+    projectDir,
+    commandRunner,
+    fileManager,
+    files: changed,
+    checkExternal,
+  }: RunOptions): Promise<LintIssue[]> {
+    const files =
+      changed !== undefined
+        ? matchFiles(changed, MARKDOWN_GLOB)
+        : await fileManager.glob(MARKDOWN_GLOB, projectDir, DEFAULT_IGNORE);
+    const docs = new DocumentCache(fileManager);
+    const raw: RawIssue[] = [];
+    const linksByFile = new Map<string, MarkdownLink[]>();
+    for (const file of files) {
+      const doc = await docs.get(join(projectDir, file));
+      if (doc === null) continue;
+      raw.push(...(await checkLocalLinks(projectDir, file, doc, fileManager, docs)));
+      linksByFile.set(file, doc.links);
+    }
+    if (checkExternal === true) {
+      const external = await checkExternalLinks(
+        projectDir,
+        linksByFile,
+        commandRunner,
+        fileManager
+      );
+      raw.push(...external);
+    }
+    return applyFingerprints(raw, projectDir, fileManager);
+  },
+};
@@ -1,8 +1,12 @@
 import { resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import { userConfigFile } from "@/config/schema";
 import type { CommandRunner } from "@/infra/command-runner";
 import type { LintIssue } from "@/models/lint-issue";
 import type { LinterRunner, RunOptions } from "@/runners/types";
 import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
+import { forEachShard, mapShards } from "@/utils/shards";
 
 const MARKDOWNLINT_LINTER_ID = "markdownlint";
 const MARKDOWNLINT_RULE_PREFIX = "markdownlint/";
@@ -41,14 +45,35 @@ export function parseMarkdownlintOutput(
   return issues;
 }
 
+const MARKDOWNLINT_GLOBS = [
+  "**/*.md",
+  "!node_modules/**",
+  "!dist/**",
+  "!.venv/**",
+  "!venv/**",
+  "!build/**",
+] as const;
+const MARKDOWNLINT_CONFIG = ".markdownlint.jsonc";
+
+/** `--config` with ours, or the project's own file when it keeps one */
+function configArgs(config: ResolvedConfig): string[] {
+  const file = userConfigFile(config, MARKDOWNLINT_LINTER_ID) ?? MARKDOWNLINT_CONFIG;
+  return ["--config", file];
+}
+
 export const markdownlintRunner: LinterRunner = {
   id: MARKDOWNLINT_LINTER_ID,
   name: "markdownlint",
-  configFile: ".markdownlint.jsonc",
+  configFile: MARKDOWNLINT_CONFIG,
+  fileScoped: true,
   installHint: {
     description: "Markdown linter",
     npm: "npm install -g markdownlint-cli2",
   },
+  versionArgs: ["markdownlint-cli2", "--version"],
+  cache: {
+    inputs: ["**/*.md"],
+  },
 
   async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
     const result = await commandRunner.run(["markdownlint-cli2", "--version"]);
@@ -57,25 +82,40 @@ export const markdownlintRunner: LinterRunner = {
 
   async run({
     projectDir,
+    config,
     commandRunner,
     fileManager,
+    files,
+    batchSize,
   }: RunOptions): Promise<LintIssue[]> {
-    const result = await commandRunner.run(
-      [
-        "markdownlint-cli2",
-        "**/*.md",
-        "!node_modules/**",
-        "!dist/**",
-        "!.venv/**",
-        "!venv/**",
-        "!build/**",
-        "--config",
-        ".markdownlint.jsonc",
-      ],
-      { cwd: projectDir }
-    );
-    // markdownlint-cli2 exits non-zero on issues — parse stdout regardless
-    const raw = parseMarkdownlintOutput(result.stdout, projectDir);
+    const globs =
+      files !== undefined ? matchFiles(files, "**/*.md") : MARKDOWNLINT_GLOBS;
+    if (globs.length === 0) return [];
+    const raw = await mapShards(globs, batchSize, async (shard) => {
+      const result = await commandRunner.run(
+        ["markdownlint-cli2", ...shard, ...configArgs(config)],
+        { cwd: projectDir }
+      );
+      // markdownlint-cli2 exits non-zero on issues — parse stdout regardless
+      return parseMarkdownlintOutput(result.stdout, projectDir);
+    });
     return applyFingerprints(raw, projectDir, fileManager);
   },
+
+  async fix({
+    projectDir,
+    config,
+    commandRunner,
+    files,
+    batchSize,
+  }: RunOptions): Promise<void> {
+    const globs =
+      files !== undefined ? matchFiles(files, "**/*.md") : MARKDOWNLINT_GLOBS;
+    await forEachShard(globs, batchSize, (shard) =>
+      commandRunner.run(
+        ["markdownlint-cli2", "--fix", ...shard, ...configArgs(config)],
+        { cwd: projectDir }
+      )
+    );
+  },
 };
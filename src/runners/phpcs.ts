@@ -0,0 +1,162 @@
+import { join, resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
+import type { LintIssue } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
+import { safeParseJson } from "@/utils/parse";
+import { resolveToolPath } from "@/utils/resolve-tool-path";
+import { forEachShard, mapShards } from "@/utils/shards";
+
+export const PHP_GLOB = "**/*.php";
+
+/** Composer installs a project's PHP tools here */
+export const COMPOSER_BIN_DIRS = [join("vendor", "bin")];
+
+/** The rulesets phpcs and phpcbf pick up from the working directory */
+const PHPCS_CONFIG_FILES = [
+  ".phpcs.xml",
+  "phpcs.xml",
+  ".phpcs.xml.dist",
+  "phpcs.xml.dist",
+];
+
+/** Flags for a project without a ruleset: PSR-12 on PHP files, outside vendor/ */
+const DEFAULT_STANDARD_ARGS = [
+  "--standard=PSR12",
+  "--extensions=php",
+  "--ignore=*/vendor/*,*/node_modules/*",
+];
+
+interface PhpcsMessage {
+  message: string;
+  source: string;
+  type: string;
+  line: number;
+  column: number;
+}
+
+interface PhpcsOutput {
+  files: Record<string, { messages: PhpcsMessage[] }>;
+}
+
+function isPhpcsOutput(value: unknown): value is PhpcsOutput {
+  return (
+    typeof value === "object" &&
+    value !== null &&
+    "files" in value &&
+    typeof value.files === "object" &&
+    value.files !== null
+  );
+}
+
+async function hasRuleset(
+  projectDir: string,
+  fileManager: FileManager
+): Promise<boolean> {
+  for (const name of PHPCS_CONFIG_FILES) {
+    if (await fileManager.exists(join(projectDir, name))) return true;
+  }
+  return false;
+}
+
+async function resolvePhpcs(
+  tool: "phpcs" | "phpcbf",
+  projectDir: string,
+  commandRunner: CommandRunner
+): Promise<string | null> {
+  return resolveToolPath(tool, projectDir, commandRunner, COMPOSER_BIN_DIRS);
+}
+
+/**
+ * Parse `phpcs --report=json` stdout into raw issues without fingerprints.
+ * Returns [] on malformed or empty input. The rule is the sniff code, e.g.
+ * `phpcs/PSR12.Files.FileHeader.SpacingAfterBlock`.
+ */
+export function parsePhpcsOutput(
+  stdout: string,
+  projectDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const parsed = safeParseJson(stdout);
+  if (!isPhpcsOutput(parsed)) return [];
+  return Object.entries(parsed.files).flatMap(([file, { messages }]) =>
+    messages.map(
+      (m): Omit<LintIssue, "fingerprint"> => ({
+        rule: `phpcs/${m.source}`,
+        linter: "phpcs",
+        file: resolve(projectDir, file),
+        line: m.line,
+        col: m.column,
+        message: m.message,
+        severity: m.type === "ERROR" ? "error" : "warning",
+      })
+    )
+  );
+}
+
+/** Files to check (`.` lets phpcs walk the tree) and the ruleset flags */
+async function phpcsArgs({
+  projectDir,
+  fileManager,
+  files,
+}: RunOptions): Promise<{ targets: string[]; standard: string[] }> {
+  const targets = files !== undefined ? matchFiles(files, PHP_GLOB) : ["."];
+  const standard = (await hasRuleset(projectDir, fileManager))
+    ? []
+    : DEFAULT_STANDARD_ARGS;
+  return { targets, standard };
+}
+
+export const phpcsRunner: LinterRunner = {
+  id: "phpcs",
+  name: "PHP_CodeSniffer",
+  configFile: "phpcs.xml",
+  fileScoped: true,
+  installHint: {
+    description: "PHP coding standard linter and fixer",
+    composer: "composer require --dev squizlabs/php_codesniffer",
+    brew: "brew install php-code-sniffer",
+  },
+  versionArgs: ["phpcs", "--version"],
+  cache: {
+    inputs: [PHP_GLOB, ...PHPCS_CONFIG_FILES],
+  },
+
+  async isAvailable(
+    commandRunner: CommandRunner,
+    projectDir?: string
+  ): Promise<boolean> {
+    const cmd = await resolvePhpcs("phpcs", projectDir ?? ".", commandRunner);
+    return cmd !== null;
+  },
+
+  async run(opts: RunOptions): Promise<LintIssue[]> {
+    const { projectDir, commandRunner, fileManager, batchSize } = opts;
+    const { targets, standard } = await phpcsArgs(opts);
+    if (targets.length === 0) return [];
+    const cmd = (await resolvePhpcs("phpcs", projectDir, commandRunner)) ?? "phpcs";
+    const raw = await mapShards(targets, batchSize, async (shard) => {
+      const result = await commandRunner.run(
+        [cmd, "--report=json", "-q", ...standard, ...shard],
+        { cwd: projectDir }
+      );
+      // Exit 1 and 2 also mean violations; only unparsable output is a failure
+      if (result.exitCode !== 0 && !isPhpcsOutput(safeParseJson(result.stdout))) {
+        const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+        throw new Error(`phpcs failed: ${detail}`);
+      }
+      return parsePhpcsOutput(result.stdout, projectDir);
+    });
+    return applyFingerprints(raw, projectDir, fileManager);
+  },
+
+  async fix(opts: RunOptions): Promise<void> {
+    const { projectDir, commandRunner, batchSize } = opts;
+    const { targets, standard } = await phpcsArgs(opts);
+    const cmd = (await resolvePhpcs("phpcbf", projectDir, commandRunner)) ?? "phpcbf";
+    await forEachShard(targets, batchSize, (shard) =>
+      commandRunner.run([cmd, "-q", ...standard, ...shard], { cwd: projectDir })
+    );
+  },
+};
@@ -0,0 +1,125 @@
+import { join, resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { LintIssue } from "@/models/lint-issue";
+import { COMPOSER_BIN_DIRS, PHP_GLOB } from "@/runners/phpcs";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { safeParseJson } from "@/utils/parse";
+import { resolveToolPath } from "@/utils/resolve-tool-path";
+
+/** PHPStan reads its level and paths from one of these; without one it has no paths */
+const PHPSTAN_CONFIG_FILES = ["phpstan.neon", "phpstan.neon.dist", "phpstan.dist.neon"];
+
+interface PhpstanMessage {
+  message: string;
+  line?: number;
+  identifier?: string;
+}
+
+interface PhpstanOutput {
+  files: Record<string, { messages: PhpstanMessage[] }>;
+  errors: string[];
+}
+
+function isPhpstanOutput(value: unknown): value is PhpstanOutput {
+  return (
+    typeof value === "object" &&
+    value !== null &&
+    "files" in value &&
+    typeof value.files === "object" &&
+    value.files !== null &&
+    "errors" in value &&
+    Array.isArray(value.errors)
+  );
+}
+
+async function resolvePhpstan(
+  projectDir: string,
+  commandRunner: CommandRunner
+): Promise<string | null> {
+  return resolveToolPath("phpstan", projectDir, commandRunner, COMPOSER_BIN_DIRS);
+}
+
+/**
+ * Parse `phpstan analyse --error-format=json` stdout into raw issues without
+ * fingerprints. Returns [] on malformed or empty input. PHPStan reports no
+ * columns or severities, so every finding is an error at column 1; messages
+ * from releases without identifiers fall back to `phpstan/error`.
+ */
+export function parsePhpstanOutput(
+  stdout: string,
+  projectDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const parsed = safeParseJson(stdout);
+  if (!isPhpstanOutput(parsed)) return [];
+  return Object.entries(parsed.files).flatMap(([file, { messages }]) =>
+    messages.map(
+      (m): Omit<LintIssue, "fingerprint"> => ({
+        rule: `phpstan/${m.identifier ?? "error"}`,
+        linter: "phpstan",
+        file: resolve(projectDir, file),
+        line: m.line ?? 1,
+        col: 1,
+        message: m.message,
+        severity: "error",
+      })
+    )
+  );
+}
+
+export const phpstanRunner: LinterRunner = {
+  id: "phpstan",
+  name: "PHPStan",
+  configFile: "phpstan.neon",
+  installHint: {
+    description: "PHP static analyzer",
+    composer: "composer require --dev phpstan/phpstan",
+    brew: "brew install phpstan",
+  },
+  versionArgs: ["phpstan", "--version"],
+  cache: {
+    inputs: [PHP_GLOB, ...PHPSTAN_CONFIG_FILES, "composer.lock"],
+  },
+
+  async appliesTo({ projectDir, fileManager }: RunOptions): Promise<boolean> {
+    for (const name of PHPSTAN_CONFIG_FILES) {
+      if (await fileManager.exists(join(projectDir, name))) return true;
+    }
+    return false;
+  },
+
+  async isAvailable(
+    commandRunner: CommandRunner,
+    projectDir?: string
+  ): Promise<boolean> {
+    const cmd = await resolvePhpstan(projectDir ?? ".", commandRunner);
+    return cmd !== null;
+  },
+
+  async run({
+    projectDir,
+    commandRunner,
+    fileManager,
+  }: RunOptions): Promise<LintIssue[]> {
+    // Whole-project: PHPStan analyses the paths its config lists, since types
+    // flow across files
+    const cmd = (await resolvePhpstan(projectDir, commandRunner)) ?? "phpstan";
+    const result = await commandRunner.run(
+      [cmd, "analyse", "--error-format=json", "--no-progress", "--no-interaction"],
+      { cwd: projectDir }
+    );
+    const parsed = safeParseJson(result.stdout);
+    if (!isPhpstanOutput(parsed)) {
+      if (result.exitCode === 0) return [];
+      const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+      throw new Error(`phpstan failed: ${detail}`);
+    }
+    const raw = parsePhpstanOutput(result.stdout, projectDir);
+    // Top-level errors are config or autoload problems, not findings
+    const [firstError] = parsed.errors;
+    if (raw.length === 0 && firstError !== undefined) {
+      throw new Error(`phpstan failed: ${firstError}`);
+    }
+    return applyFingerprints(raw, projectDir, fileManager);
+  },
+};
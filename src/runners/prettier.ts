@@ -0,0 +1,166 @@
+import { join, resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
+import type { LintIssue } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
+import { packageUses } from "@/utils/package-json";
+import { pipeFix } from "@/utils/pipe-fix";
+import { resolveToolPath } from "@/utils/resolve-tool-path";
+import { forEachShard, mapShards } from "@/utils/shards";
+
+const PRETTIER_LINTER_ID = "prettier";
+/** Files prettier formats — used to pick targets from a changed-file list */
+const PRETTIER_GLOB =
+  "**/*.{js,jsx,mjs,cjs,ts,tsx,mts,cts,json,jsonc,md,css,scss,html,yaml,yml}";
+
+const PRETTIER_CONFIG_FILES = [
+  ".prettierrc",
+  ".prettierrc.json",
+  ".prettierrc.json5",
+  ".prettierrc.yaml",
+  ".prettierrc.yml",
+  ".prettierrc.toml",
+  ".prettierrc.js",
+  ".prettierrc.mjs",
+  ".prettierrc.cjs",
+  ".prettierrc.ts",
+  "prettier.config.js",
+  "prettier.config.mjs",
+  "prettier.config.cjs",
+  "prettier.config.ts",
+];
+
+/** `--check` ends with a `[warn]` summary line that names no file */
+const SUMMARY_LINE = /^Code style issues found\b/;
+
+/** True when the project has a Prettier config or a prettier dependency */
+export async function usesPrettier(
+  projectDir: string,
+  fileManager: FileManager
+): Promise<boolean> {
+  for (const name of PRETTIER_CONFIG_FILES) {
+    if (await fileManager.exists(join(projectDir, name))) return true;
+  }
+  return packageUses(projectDir, fileManager, "prettier", "prettier");
+}
+
+/**
+ * Whether `id` formats JS/TS: as picked by [config] js_formatter, or by
+ * default prettier where the project uses Prettier and biome everywhere else.
+ */
+export async function isJsFormatter(
+  id: "biome" | "prettier",
+  { projectDir, config, fileManager }: RunOptions
+): Promise<boolean> {
+  const choice = config.values.js_formatter;
+  if (choice !== undefined) return choice === id;
+  const prettier = await usesPrettier(projectDir, fileManager);
+  return id === "prettier" ? prettier : !prettier;
+}
+
+/**
+ * Parse `prettier --check` output — a `[warn] <file>` line per unformatted
+ * file, then a summary — into raw issues without fingerprints.
+ */
+export function parsePrettierCheckOutput(
+  output: string,
+  projectDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  return output
+    .split("\n")
+    .flatMap((line) => {
+      const match = /^\[warn\] (.+)$/.exec(line.trim());
+      const file = match?.[1];
+      return file !== undefined && !SUMMARY_LINE.test(file) ? [file] : [];
+    })
+    .map((file) => ({
+      rule: "prettier/format",
+      linter: PRETTIER_LINTER_ID,
+      file: resolve(projectDir, file),
+      line: 1,
+      col: 1,
+      message: `File is not Prettier-formatted — run: prettier --write ${file}`,
+      severity: "error",
+    }) satisfies Omit<LintIssue, "fingerprint">);
+}
+
+export const prettierRunner: LinterRunner = {
+  id: PRETTIER_LINTER_ID,
+  name: "Prettier",
+  configFile: null,
+  fileScoped: true,
+  installHint: {
+    description: "Formatter for JS/TS, JSON, Markdown, CSS and YAML",
+    npm: "npm install -D prettier",
+  },
+  versionArgs: ["prettier", "--version"],
+  watchInputs: [PRETTIER_GLOB],
+
+  async isAvailable(
+    commandRunner: CommandRunner,
+    projectDir?: string
+  ): Promise<boolean> {
+    const cmd = await resolveToolPath("prettier", projectDir ?? ".", commandRunner);
+    return cmd !== null;
+  },
+
+  async appliesTo(opts: RunOptions): Promise<boolean> {
+    return isJsFormatter("prettier", opts);
+  },
+
+  async run({
+    projectDir,
+    commandRunner,
+    fileManager,
+    files,
+    batchSize,
+  }: RunOptions): Promise<LintIssue[]> {
+    const targets = files !== undefined ? matchFiles(files, PRETTIER_GLOB) : ["."];
+    if (targets.length === 0) return [];
+    const cmd =
+      (await resolveToolPath("prettier", projectDir, commandRunner)) ?? "prettier";
+    // prettier reads .prettierrc and .prettierignore itself, relative to cwd
+    const raw = await mapShards(targets, batchSize, async (shard) => {
+      const result = await commandRunner.run(
+        [cmd, "--check", "--ignore-unknown", ...shard],
+        { cwd: projectDir }
+      );
+      // Exit 1 means unformatted files; 2 means prettier itself failed
+      if (result.exitCode === 2) {
+        const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+        throw new Error(`prettier failed: ${detail}`);
+      }
+      return parsePrettierCheckOutput(`${result.stdout}\n${result.stderr}`, projectDir);
+    });
+    return applyFingerprints(raw, projectDir, fileManager);
+  },
+
+  async fix({
+    projectDir,
+    commandRunner,
+    files,
+    batchSize,
+  }: RunOptions): Promise<void> {
+    const targets = files !== undefined ? matchFiles(files, PRETTIER_GLOB) : ["."];
+    if (targets.length === 0) return;
+    const cmd =
+      (await resolveToolPath("prettier", projectDir, commandRunner)) ?? "prettier";
+    await forEachShard(targets, batchSize, (shard) =>
+      commandRunner.run([cmd, "--write", "--ignore-unknown", ...shard], {
+        cwd: projectDir,
+      })
+    );
+  },
+
+  async previewFix(
+    { projectDir, commandRunner }: RunOptions,
+    file: string,
+    content: string
+  ): Promise<string> {
+    const cmd =
+      (await resolveToolPath("prettier", projectDir, commandRunner)) ?? "prettier";
+    return pipeFix(commandRunner, [cmd, "--stdin-filepath", file], content, projectDir);
+  },
+};
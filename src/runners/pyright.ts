@@ -1,5 +1,5 @@
 import type { CommandRunner } from "@/infra/command-runner";
-import type { LintIssue } from "@/models/lint-issue";
+import type { LintIssue, Severity } from "@/models/lint-issue";
 import type { LinterRunner, RunOptions } from "@/runners/types";
 import { applyFingerprints } from "@/utils/apply-fingerprints";
 import { safeParseJson } from "@/utils/parse";
@@ -55,9 +55,15 @@ function isPyrightOutput(value: unknown): value is PyrightOutput {
   return "generalDiagnostics" in value && Array.isArray(value.generalDiagnostics);
 }
 
+const SEVERITY_BY_LEVEL: Record<string, Severity> = {
+  error: "error",
+  warning: "warning",
+  information: "info",
+};
+
 /**
  * Parse pyright --outputjson output into raw issues without fingerprints.
- * Skips information-level diagnostics.
+ * Information-level diagnostics are reported as `info`.
  * Returns [] for empty stdout or invalid JSON.
  *
  * LintIssue.file is the absolute path emitted by pyright.
@@ -76,7 +82,6 @@ export function parsePyrightOutput(
   const issues: Omit<LintIssue, "fingerprint">[] = [];
   for (const diag of parsed.generalDiagnostics) {
     if (!isPyrightDiagnostic(diag)) continue;
-    if (diag.severity === "information") continue;
 
     const rule = `pyright/${diag.rule ?? "unknown"}`;
     // pyright uses 0-indexed lines and columns; LintIssue is 1-indexed
@@ -90,7 +95,7 @@ export function parsePyrightOutput(
       line,
       col,
       message: diag.message,
-      severity: diag.severity === "error" ? "error" : "warning",
+      severity: SEVERITY_BY_LEVEL[diag.severity] ?? "warning",
     });
   }
   return issues;
@@ -105,6 +110,8 @@ export const pyrightRunner: LinterRunner = {
     npm: "npm install -D pyright",
     pip: "pip install pyright",
   },
+  versionArgs: ["pyright", "--version"],
+  watchInputs: ["**/*.py", "**/*.pyi", "pyproject.toml"],
 
   async isAvailable(runner: CommandRunner, projectDir?: string): Promise<boolean> {
     return (await resolveToolPath("pyright", projectDir ?? ".", runner)) !== null;
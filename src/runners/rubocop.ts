@@ -0,0 +1,142 @@
+import { resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { LintIssue, Severity } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
+import { safeParseJson } from "@/utils/parse";
+import { forEachShard, mapShards } from "@/utils/shards";
+
+/** Ruby sources, plus the extensionless files RuboCop inspects by default */
+export const RUBY_GLOB = "**/{*.rb,*.rake,*.gemspec,Gemfile,Rakefile}";
+
+// RuboCop severities, most to least severe; convention and refactor are style
+const SEVERITY_BY_LEVEL: Record<string, Severity> = {
+  fatal: "error",
+  error: "error",
+  warning: "warning",
+  convention: "warning",
+  refactor: "warning",
+  info: "info",
+};
+
+interface RubocopOffense {
+  severity: string;
+  message: string;
+  cop_name: string;
+  location: {
+    start_line?: number;
+    start_column?: number;
+    line?: number;
+    column?: number;
+  };
+}
+
+interface RubocopOutput {
+  files: Array<{ path: string; offenses: RubocopOffense[] }>;
+}
+
+function isRubocopOutput(value: unknown): value is RubocopOutput {
+  return (
+    typeof value === "object" &&
+    value !== null &&
+    "files" in value &&
+    Array.isArray(value.files)
+  );
+}
+
+/**
+ * Parse `rubocop --format json` stdout into raw issues without fingerprints.
+ * Returns [] on malformed or empty input. The `Cop/Name: ` prefix RuboCop
+ * puts on messages is dropped, since the rule already carries it.
+ */
+export function parseRubocopOutput(
+  stdout: string,
+  projectDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const parsed = safeParseJson(stdout);
+  if (!isRubocopOutput(parsed)) return [];
+  return parsed.files.flatMap((file) =>
+    file.offenses.map((offense): Omit<LintIssue, "fingerprint"> => {
+      const prefix = `${offense.cop_name}: `;
+      const { location } = offense;
+      return {
+        rule: `rubocop/${offense.cop_name}`,
+        linter: "rubocop",
+        file: resolve(projectDir, file.path),
+        line: location.start_line ?? location.line ?? 1,
+        col: location.start_column ?? location.column ?? 1,
+        message: offense.message.startsWith(prefix)
+          ? offense.message.slice(prefix.length)
+          : offense.message,
+        severity: SEVERITY_BY_LEVEL[offense.severity] ?? "warning",
+      };
+    })
+  );
+}
+
+export const rubocopRunner: LinterRunner = {
+  id: "rubocop",
+  name: "RuboCop",
+  configFile: ".rubocop.yml",
+  fileScoped: true,
+  installHint: {
+    description: "Ruby linter and formatter",
+    gem: "gem install rubocop",
+    brew: "brew install rubocop",
+    apt: "sudo apt install rubocop",
+  },
+  versionArgs: ["rubocop", "--version"],
+  cache: {
+    inputs: [RUBY_GLOB],
+  },
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    const result = await commandRunner.run(["rubocop", "--version"]);
+    return result.exitCode === 0;
+  },
+
+  async run({
+    projectDir,
+    commandRunner,
+    fileManager,
+    files,
+    batchSize,
+  }: RunOptions): Promise<LintIssue[]> {
+    // Given `.`, rubocop finds the files itself, honoring .rubocop.yml
+    const targets = files !== undefined ? matchFiles(files, RUBY_GLOB) : ["."];
+    if (targets.length === 0) return [];
+    const raw = await mapShards(targets, batchSize, async (shard) => {
+      // --force-exclusion keeps .rubocop.yml's Exclude for files named explicitly
+      const result = await commandRunner.run(
+        ["rubocop", "--format", "json", "--force-exclusion", ...shard],
+        { cwd: projectDir }
+      );
+      // Exit 1 means offenses were found; 2 means rubocop itself failed
+      if (result.exitCode === 2) {
+        const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+        throw new Error(`rubocop failed: ${detail}`);
+      }
+      return parseRubocopOutput(result.stdout, projectDir);
+    });
+    return applyFingerprints(raw, projectDir, fileManager);
+  },
+
+  async fix({
+    projectDir,
+    commandRunner,
+    files,
+    batchSize,
+  }: RunOptions): Promise<void> {
+    if (files === undefined) {
+      // -a applies safe corrections only; unsafe ones (-A) stay manual
+      await commandRunner.run(["rubocop", "-a"], { cwd: projectDir });
+      return;
+    }
+    await forEachShard(matchFiles(files, RUBY_GLOB), batchSize, (shard) =>
+      commandRunner.run(["rubocop", "-a", "--force-exclusion", ...shard], {
+        cwd: projectDir,
+      })
+    );
+  },
+};
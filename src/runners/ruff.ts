@@ -4,7 +4,10 @@ import type { CommandRunner } from "@/infra/command-runner";
 import type { LintIssue } from "@/models/lint-issue";
 import type { LinterRunner, RunOptions } from "@/runners/types";
 import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
 import { safeParseJson } from "@/utils/parse";
+import { pipeFix } from "@/utils/pipe-fix";
+import { forEachShard, mapShards } from "@/utils/shards";
 
 // Codes starting with E or F are errors; everything else is a warning.
 const ERROR_PREFIXES = ["E", "F"] as const;
@@ -88,10 +91,16 @@ export const ruffRunner: LinterRunner = {
   id: "ruff",
   name: "Ruff",
   configFile: "ruff.toml",
+  fileScoped: true,
   installHint: {
     description: "Python linter and formatter",
     pip: "pip install ruff",
   },
+  versionArgs: ["ruff", "--version"],
+  minVersion: "0.1.0", // --output-format=json replaced --format
+  cache: {
+    inputs: ["**/*.py", "**/*.pyi", "pyproject.toml"],
+  },
 
   async isAvailable(runner: CommandRunner): Promise<boolean> {
     const result = await runner.run(["ruff", "--version"]);
@@ -99,12 +108,40 @@ export const ruffRunner: LinterRunner = {
   },
 
   async run(opts: RunOptions): Promise<LintIssue[]> {
-    const { projectDir, config, commandRunner, fileManager } = opts;
-    const result = await commandRunner.run(
-      ["ruff", "check", "--output-format=json", projectDir],
-      { cwd: projectDir }
-    );
-    const raw = parseRuffOutput(result.stdout, config, projectDir);
+    const { projectDir, config, commandRunner, fileManager, files, batchSize } = opts;
+    const targets =
+      files !== undefined ? matchFiles(files, "**/*.{py,pyi}") : [projectDir];
+    if (targets.length === 0) return [];
+    const raw = await mapShards(targets, batchSize, async (shard) => {
+      const result = await commandRunner.run(
+        ["ruff", "check", "--output-format=json", ...shard],
+        { cwd: projectDir }
+      );
+      return parseRuffOutput(result.stdout, config, projectDir);
+    });
     return applyFingerprints(raw, projectDir, fileManager);
   },
+
+  async fix({
+    projectDir,
+    commandRunner,
+    files,
+    batchSize,
+  }: RunOptions): Promise<void> {
+    const targets =
+      files !== undefined ? matchFiles(files, "**/*.{py,pyi}") : [projectDir];
+    await forEachShard(targets, batchSize, (shard) =>
+      commandRunner.run(["ruff", "check", "--fix", ...shard], { cwd: projectDir })
+    );
+  },
+
+  async previewFix(
+    { projectDir, commandRunner }: RunOptions,
+    file: string,
+    content: string
+  ): Promise<string> {
+    // With stdin, --fix prints the fixed source; exit 1 means findings remain
+    const args = ["ruff", "check", "--fix", "--stdin-filename", file, "-"];
+    return pipeFix(commandRunner, args, content, projectDir, [0, 1]);
+  },
 };
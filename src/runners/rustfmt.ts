@@ -0,0 +1,66 @@
+import { resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { LintIssue } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+
+/**
+ * Parse `cargo fmt --check -- --files-with-diff` stdout into raw issues
+ * without fingerprints. One file per line needs reformatting.
+ * Returns [] for empty output.
+ */
+export function parseRustfmtOutput(
+  stdout: string,
+  projectDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const filenames = stdout
+    .split("\n")
+    .map((line) => line.trim())
+    .filter((line) => line.length > 0);
+
+  return filenames.map(
+    (filename) =>
+      ({
+        rule: "rustfmt/format",
+        linter: "rustfmt",
+        file: resolve(projectDir, filename),
+        line: 1,
+        col: 1,
+        message: "File needs formatting — run: cargo fmt",
+        severity: "error",
+      }) satisfies Omit<LintIssue, "fingerprint">
+  );
+}
+
+export const rustfmtRunner: LinterRunner = {
+  id: "rustfmt",
+  name: "rustfmt",
+  configFile: "rustfmt.toml",
+  installHint: {
+    description: "Rust formatter",
+    rustup: "rustup component add rustfmt",
+  },
+  versionArgs: ["cargo", "fmt", "--version"],
+  cache: {
+    inputs: ["**/*.rs", ".rustfmt.toml"],
+  },
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    const result = await commandRunner.run(["cargo", "fmt", "--version"]);
+    return result.exitCode === 0;
+  },
+
+  async run(opts: RunOptions): Promise<LintIssue[]> {
+    const { projectDir, commandRunner, fileManager } = opts;
+    const result = await commandRunner.run(
+      ["cargo", "fmt", "--check", "--", "--files-with-diff"],
+      { cwd: projectDir }
+    );
+    const raw = parseRustfmtOutput(result.stdout, projectDir);
+    return applyFingerprints(raw, projectDir, fileManager);
+  },
+
+  async fix({ projectDir, commandRunner }: RunOptions): Promise<void> {
+    await commandRunner.run(["cargo", "fmt"], { cwd: projectDir });
+  },
+};
@@ -3,7 +3,9 @@ import type { CommandRunner } from "@/infra/command-runner";
 import type { LintIssue } from "@/models/lint-issue";
 import type { LinterRunner, RunOptions } from "@/runners/types";
 import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
 import { safeParseJson } from "@/utils/parse";
+import { mapShards } from "@/utils/shards";
 
 const SELENE_LINTER_ID = "selene";
 const SELENE_RULE_PREFIX = "selene/";
@@ -66,11 +68,16 @@ export const seleneRunner: LinterRunner = {
   id: SELENE_LINTER_ID,
   name: "Selene",
   configFile: "selene.toml",
+  fileScoped: true,
   installHint: {
     description: "Lua linter",
     cargo: "cargo install selene",
     brew: "brew install selene",
   },
+  versionArgs: ["selene", "--version"],
+  cache: {
+    inputs: ["**/*.lua", "*.yml"],
+  },
 
   async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
     const result = await commandRunner.run(["selene", "--version"]);
@@ -81,16 +88,24 @@ export const seleneRunner: LinterRunner = {
     projectDir,
     commandRunner,
     fileManager,
+    files,
+    batchSize,
   }: RunOptions): Promise<LintIssue[]> {
-    const luaFiles = await fileManager.glob("**/*.lua", projectDir);
+    const luaFiles =
+      files !== undefined
+        ? matchFiles(files, "**/*.lua")
+        : await fileManager.glob("**/*.lua", projectDir);
     if (luaFiles.length === 0) return [];
 
-    const result = await commandRunner.run(
-      ["selene", "--display-style=Json2", projectDir],
-      { cwd: projectDir }
-    );
-    // selene exits non-zero when issues are found — parse stdout regardless
-    const raw = parseSeleneOutput(result.stdout, projectDir);
+    const targets = files !== undefined ? luaFiles : [projectDir];
+    const raw = await mapShards(targets, batchSize, async (shard) => {
+      const result = await commandRunner.run(
+        ["selene", "--display-style=Json2", ...shard],
+        { cwd: projectDir }
+      );
+      // selene exits non-zero when issues are found — parse stdout regardless
+      return parseSeleneOutput(result.stdout, projectDir);
+    });
     return applyFingerprints(raw, projectDir, fileManager);
   },
 };
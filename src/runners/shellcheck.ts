@@ -1,10 +1,14 @@
 import { resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
 import type { CommandRunner } from "@/infra/command-runner";
 import type { FileManager } from "@/infra/file-manager";
-import type { LintIssue } from "@/models/lint-issue";
+import type { LintIssue, Severity } from "@/models/lint-issue";
 import type { LinterRunner, RunOptions } from "@/runners/types";
 import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
 import { safeParseJson } from "@/utils/parse";
+import { mapShards } from "@/utils/shards";
+import { findShebangScripts, SHEBANG_SHELLS } from "@/utils/shebang";
 
 /** Shape of a single comment in shellcheck --format=json1 output */
 interface ShellcheckComment {
@@ -30,6 +34,14 @@ function isShellcheckOutput(value: unknown): value is ShellcheckOutput {
   );
 }
 
+// style and info are advisory; unknown levels stay warnings
+const SEVERITY_BY_LEVEL: Record<string, Severity> = {
+  error: "error",
+  warning: "warning",
+  info: "info",
+  style: "info",
+};
+
 /**
  * Parse shellcheck --format=json1 stdout into raw issues without fingerprints.
  * Returns [] on malformed/empty input.
@@ -46,7 +58,7 @@ export function parseShellcheckOutput(
   return parsed.comments.map((comment) => {
     const rule = `shellcheck/SC${comment.code}`;
     const file = resolve(projectDir, comment.file);
-    const severity = comment.level === "error" ? "error" : "warning";
+    const severity = SEVERITY_BY_LEVEL[comment.level] ?? "warning";
 
     return {
       rule,
@@ -60,23 +72,63 @@ export function parseShellcheckOutput(
   });
 }
 
-/** Glob for all shell script files across supported extensions. */
+const SHELL_GLOB = "**/*.{sh,bash,zsh,ksh}";
+
+/** Shebang interpreters treated as shells: the defaults plus [config] shebang_shells */
+export function shebangShells(config?: ResolvedConfig): readonly string[] {
+  return [...SHEBANG_SHELLS, ...(config?.values.shebang_shells ?? [])];
+}
+
+/**
+ * Glob for all shell script files across supported extensions, plus the
+ * extensionless executables with a shell shebang (see `shebangShells`).
+ * When a changed-file list is given, select from it instead.
+ */
 export async function findShellFiles(
   fileManager: FileManager,
-  projectDir: string
+  projectDir: string,
+  files?: readonly string[],
+  config?: ResolvedConfig
 ): Promise<string[]> {
-  return fileManager.glob("**/*.{sh,bash,zsh,ksh}", projectDir);
+  const named =
+    files !== undefined
+      ? matchFiles(files, SHELL_GLOB)
+      : await fileManager.glob(SHELL_GLOB, projectDir);
+  const shells = shebangShells(config);
+  const scripts = await findShebangScripts(fileManager, projectDir, shells, {
+    ...(files !== undefined && { files }),
+    ...(config !== undefined && { ignore: config.ignorePaths }),
+  });
+  return [...named, ...scripts];
+}
+
+/** Cache inputs of the shell runners beyond SHELL_GLOB */
+export function findShebangInputs(
+  fileManager: FileManager,
+  projectDir: string,
+  config: ResolvedConfig
+): Promise<string[]> {
+  return findShebangScripts(fileManager, projectDir, shebangShells(config), {
+    ignore: config.ignorePaths,
+  });
 }
 
 export const shellcheckRunner: LinterRunner = {
   id: "shellcheck",
   name: "ShellCheck",
   configFile: null,
+  fileScoped: true,
   installHint: {
     description: "Shell script linter",
     brew: "brew install shellcheck",
     apt: "sudo apt install shellcheck",
   },
+  versionArgs: ["shellcheck", "--version"],
+  minVersion: "0.7.0", // --format=json1 first shipped
+  cache: {
+    inputs: [SHELL_GLOB, ".shellcheckrc"],
+    findInputs: findShebangInputs,
+  },
 
   async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
     const result = await commandRunner.run(["shellcheck", "--version"]);
@@ -85,17 +137,22 @@ export const shellcheckRunner: LinterRunner = {
 
   async run({
     projectDir,
+    config,
     commandRunner,
     fileManager,
+    files: changed,
+    batchSize,
   }: RunOptions): Promise<LintIssue[]> {
-    const files = await findShellFiles(fileManager, projectDir);
+    const files = await findShellFiles(fileManager, projectDir, changed, config);
     if (files.length === 0) return [];
 
-    const result = await commandRunner.run(["shellcheck", "--format=json1", ...files], {
-      cwd: projectDir,
+    const raw = await mapShards(files, batchSize, async (shard) => {
+      const result = await commandRunner.run(
+        ["shellcheck", "--format=json1", ...shard],
+        { cwd: projectDir }
+      );
+      return parseShellcheckOutput(result.stdout, projectDir);
     });
-
-    const raw = parseShellcheckOutput(result.stdout, projectDir);
     return applyFingerprints(raw, projectDir, fileManager);
   },
 };
@@ -1,9 +1,11 @@
 import { resolve } from "node:path";
 import type { CommandRunner } from "@/infra/command-runner";
 import type { LintIssue } from "@/models/lint-issue";
-import { findShellFiles } from "@/runners/shellcheck";
+import { findShebangInputs, findShellFiles } from "@/runners/shellcheck";
 import type { LinterRunner, RunOptions } from "@/runners/types";
 import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { pipeFix } from "@/utils/pipe-fix";
+import { forEachShard, mapShards } from "@/utils/shards";
 
 /**
  * Parse shfmt -l stdout into raw issues without fingerprints.
@@ -40,11 +42,17 @@ export const shfmtRunner: LinterRunner = {
   id: "shfmt",
   name: "shfmt",
   configFile: null,
+  fileScoped: true,
   installHint: {
     description: "Shell script formatter",
     brew: "brew install shfmt",
     go: "go install mvdan.cc/sh/v3/cmd/shfmt@latest",
   },
+  versionArgs: ["shfmt", "--version"],
+  cache: {
+    inputs: ["**/*.{sh,bash,zsh,ksh}", ".editorconfig"],
+    findInputs: findShebangInputs,
+  },
 
   async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
     const result = await commandRunner.run(["shfmt", "--version"]);
@@ -53,17 +61,44 @@ export const shfmtRunner: LinterRunner = {
 
   async run({
     projectDir,
+    config,
     commandRunner,
     fileManager,
+    files: changed,
+    batchSize,
   }: RunOptions): Promise<LintIssue[]> {
-    const files = await findShellFiles(fileManager, projectDir);
+    const files = await findShellFiles(fileManager, projectDir, changed, config);
     if (files.length === 0) return [];
 
-    const result = await commandRunner.run(["shfmt", "-l", ...files], {
-      cwd: projectDir,
+    const raw = await mapShards(files, batchSize, async (shard) => {
+      const result = await commandRunner.run(["shfmt", "-l", ...shard], {
+        cwd: projectDir,
+      });
+      return parseShfmtOutput(result.stdout, projectDir);
     });
-
-    const raw = parseShfmtOutput(result.stdout, projectDir);
     return applyFingerprints(raw, projectDir, fileManager);
   },
+
+  async fix({
+    projectDir,
+    config,
+    commandRunner,
+    fileManager,
+    files: named,
+    batchSize,
+  }: RunOptions): Promise<void> {
+    const files = await findShellFiles(fileManager, projectDir, named, config);
+    await forEachShard(files, batchSize, (shard) =>
+      commandRunner.run(["shfmt", "-w", ...shard], { cwd: projectDir })
+    );
+  },
+
+  async previewFix(
+    { projectDir, commandRunner }: RunOptions,
+    file: string,
+    content: string
+  ): Promise<string> {
+    // --filename picks the dialect and .editorconfig section as for the file
+    return pipeFix(commandRunner, ["shfmt", "--filename", file], content, projectDir);
+  },
 };
@@ -0,0 +1,208 @@
+import { basename, join, resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
+import { DEFAULT_IGNORE } from "@/languages/constants";
+import type { LintIssue, Severity } from "@/models/lint-issue";
+import { CACHE_DIR } from "@/models/paths";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
+import { safeParseJson } from "@/utils/parse";
+import { resolveToolPath } from "@/utils/resolve-tool-path";
+import { mapShards } from "@/utils/shards";
+
+/** Files that may hold an API description; their content decides */
+export const API_SPEC_GLOB = "**/*.{yaml,yml,json}";
+
+/** The rulesets spectral finds on its own in the working directory */
+export const SPECTRAL_RULESETS = [
+  ".spectral.yaml",
+  ".spectral.yml",
+  ".spectral.json",
+  ".spectral.js",
+  ".spectral.mjs",
+  ".spectral.cjs",
+];
+
+/** Written under the cache dir when the project has no ruleset of its own */
+const DEFAULT_RULESET_FILE = "spectral-ruleset.yaml";
+const DEFAULT_RULESET = 'extends: ["spectral:oas"]\n';
+
+/** Named like an API description, e.g. openapi.yaml or swagger.json */
+const SPEC_NAME_RE = /^(?:openapi|swagger)\.(?:ya?ml|json)$/;
+/** A top-level `openapi: 3.1.0` or `swagger: "2.0"` key in YAML */
+const YAML_VERSION_KEY = /^(?:openapi|swagger)\s*:\s*["']?\d/m;
+/** The same key in JSON */
+const JSON_VERSION_KEY = /"(?:openapi|swagger)"\s*:\s*"\d/;
+
+/** spectral's exit code when a result is at or above its fail severity */
+const SPECTRAL_FINDINGS_EXIT = 1;
+
+/** spectral's numeric severities: 0 error, 1 warn, 2 information, 3 hint */
+const SEVERITY_BY_LEVEL: readonly Severity[] = ["error", "warning", "info", "info"];
+
+/** True when `content` of the file at `path` is an OpenAPI or Swagger document */
+export function isApiSpec(path: string, content: string): boolean {
+  if (SPEC_NAME_RE.test(basename(path))) return true;
+  return path.endsWith(".json")
+    ? JSON_VERSION_KEY.test(content)
+    : YAML_VERSION_KEY.test(content);
+}
+
+/**
+ * Project-relative OpenAPI and Swagger documents outside DEFAULT_IGNORE and
+ * `ignorePaths`, sorted. When a changed-file list is given, select from it
+ * instead of globbing.
+ */
+export async function findApiSpecs(
+  fileManager: FileManager,
+  projectDir: string,
+  ignorePaths: readonly string[],
+  files?: readonly string[]
+): Promise<string[]> {
+  const candidates =
+    files !== undefined
+      ? matchFiles(files, API_SPEC_GLOB)
+      : await fileManager.glob(API_SPEC_GLOB, projectDir, [
+          ...DEFAULT_IGNORE,
+          ...ignorePaths,
+        ]);
+  const specs: string[] = [];
+  for (const file of candidates) {
+    const path = join(projectDir, file);
+    if (!(await fileManager.exists(path))) continue;
+    if (isApiSpec(file, await fileManager.readText(path))) specs.push(file);
+  }
+  return specs.toSorted();
+}
+
+/** Shape of one result in `spectral lint --format json` output */
+interface SpectralResult {
+  code: string | number;
+  message: string;
+  severity: number;
+  source: string;
+  range?: { start?: { line?: number; character?: number } };
+}
+
+function isSpectralResult(value: unknown): value is SpectralResult {
+  return (
+    typeof value === "object" &&
+    value !== null &&
+    "code" in value &&
+    (typeof value.code === "string" || typeof value.code === "number") &&
+    "message" in value &&
+    typeof value.message === "string" &&
+    "severity" in value &&
+    typeof value.severity === "number" &&
+    "source" in value &&
+    typeof value.source === "string"
+  );
+}
+
+/**
+ * Parse `spectral lint --format json` output into raw issues without
+ * fingerprints. Positions are 0-based in spectral and 1-based here. Severities
+ * error and warn map across; information and hint are info. Returns [] on
+ * malformed or empty input.
+ */
+export function parseSpectralOutput(
+  stdout: string,
+  projectDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const parsed = safeParseJson(stdout);
+  if (!Array.isArray(parsed)) return [];
+  return parsed.filter(isSpectralResult).map(
+    (result): Omit<LintIssue, "fingerprint"> => ({
+      rule: `spectral/${result.code}`,
+      linter: "spectral",
+      file: resolve(projectDir, result.source),
+      line: (result.range?.start?.line ?? 0) + 1,
+      col: (result.range?.start?.character ?? 0) + 1,
+      message: result.message,
+      severity: SEVERITY_BY_LEVEL[result.severity] ?? "warning",
+    })
+  );
+}
+
+/** The project's own ruleset, or null when spectral would find none */
+export async function findSpectralRuleset(
+  projectDir: string,
+  fileManager: FileManager
+): Promise<string | null> {
+  for (const name of SPECTRAL_RULESETS) {
+    if (await fileManager.exists(join(projectDir, name))) return name;
+  }
+  return null;
+}
+
+/**
+ * `--ruleset` for spectral: nothing when the project has a ruleset, since
+ * spectral reads it itself, else a written one extending `spectral:oas`.
+ */
+async function rulesetArgs(
+  projectDir: string,
+  fileManager: FileManager
+): Promise<string[]> {
+  if ((await findSpectralRuleset(projectDir, fileManager)) !== null) return [];
+  const dir = join(projectDir, CACHE_DIR);
+  const path = join(dir, DEFAULT_RULESET_FILE);
+  await fileManager.mkdir(dir, { parents: true });
+  await fileManager.writeText(path, DEFAULT_RULESET);
+  return ["--ruleset", path];
+}
+
+export const spectralRunner: LinterRunner = {
+  id: "spectral",
+  name: "Spectral",
+  configFile: null,
+  fileScoped: true,
+  installHint: {
+    description: "OpenAPI and Swagger document linter",
+    npm: "npm install -D @stoplight/spectral-cli",
+  },
+  versionArgs: ["spectral", "--version"],
+  cache: {
+    inputs: [API_SPEC_GLOB, ...SPECTRAL_RULESETS],
+  },
+
+  async isAvailable(
+    commandRunner: CommandRunner,
+    projectDir?: string
+  ): Promise<boolean> {
+    const cmd = await resolveToolPath("spectral", projectDir ?? ".", commandRunner);
+    return cmd !== null;
+  },
+
+  async run({
+    projectDir,
+    config,
+    commandRunner,
+    fileManager,
+    files,
+    batchSize,
+  }: RunOptions): Promise<LintIssue[]> {
+    const specs = await findApiSpecs(
+      fileManager,
+      projectDir,
+      config.ignorePaths,
+      files
+    );
+    if (specs.length === 0) return [];
+    const cmd =
+      (await resolveToolPath("spectral", projectDir, commandRunner)) ?? "spectral";
+    const ruleset = await rulesetArgs(projectDir, fileManager);
+    const raw = await mapShards(specs, batchSize, async (shard) => {
+      const result = await commandRunner.run(
+        [cmd, "lint", "--format", "json", "--quiet", ...ruleset, ...shard],
+        { cwd: projectDir }
+      );
+      if (result.exitCode !== 0 && result.exitCode !== SPECTRAL_FINDINGS_EXIT) {
+        const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+        throw new Error(`spectral failed: ${detail}`);
+      }
+      return parseSpectralOutput(result.stdout, projectDir);
+    });
+    return applyFingerprints(raw, projectDir, fileManager);
+  },
+};
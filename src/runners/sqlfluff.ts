@@ -0,0 +1,202 @@
+import { join, resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
+import type { LintIssue } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
+import { safeParseJson } from "@/utils/parse";
+import { forEachShard, mapShards } from "@/utils/shards";
+
+export const SQL_GLOB = "**/*.sql";
+
+/** sqlfluff's own default is no dialect at all, which makes every run fail */
+export const DEFAULT_SQL_DIALECT = "ansi";
+
+/** One violation in `sqlfluff lint --format json`; 1.x/2.x use line_no/line_pos */
+interface SqlfluffViolation {
+  code: string;
+  description: string;
+  warning?: boolean;
+  start_line_no?: number;
+  start_line_pos?: number;
+  line_no?: number;
+  line_pos?: number;
+}
+
+interface SqlfluffFile {
+  filepath: string;
+  violations: SqlfluffViolation[];
+}
+
+function isSqlfluffOutput(value: unknown): value is SqlfluffFile[] {
+  return (
+    Array.isArray(value) &&
+    value.every(
+      (entry) =>
+        typeof entry === "object" &&
+        entry !== null &&
+        "filepath" in entry &&
+        typeof entry.filepath === "string" &&
+        "violations" in entry &&
+        Array.isArray(entry.violations)
+    )
+  );
+}
+
+/**
+ * Parse `sqlfluff lint --format json` stdout into raw issues without
+ * fingerprints. Violations sqlfluff flags as warnings stay warnings; the rest
+ * (parse errors included) are errors. Returns [] on malformed/empty input.
+ */
+export function parseSqlfluffOutput(
+  stdout: string,
+  projectDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const parsed = safeParseJson(stdout);
+  if (!isSqlfluffOutput(parsed)) return [];
+  return parsed.flatMap((file) =>
+    file.violations.map(
+      (violation): Omit<LintIssue, "fingerprint"> => ({
+        rule: `sqlfluff/${violation.code}`,
+        linter: "sqlfluff",
+        file: resolve(projectDir, file.filepath),
+        line: violation.start_line_no ?? violation.line_no ?? 1,
+        col: violation.start_line_pos ?? violation.line_pos ?? 1,
+        message: violation.description,
+        severity: violation.warning === true ? "warning" : "error",
+      })
+    )
+  );
+}
+
+/** The `dialect` set in the [sqlfluff] section of a `.sqlfluff` file */
+export function parseSqlfluffDialect(text: string): string | undefined {
+  let section = "";
+  for (const raw of text.split("\n")) {
+    const line = raw.trim();
+    const header = /^\[([^\]]+)\]$/.exec(line);
+    if (header !== null) {
+      section = header[1]?.trim() ?? "";
+      continue;
+    }
+    if (section !== "sqlfluff") continue;
+    const match = /^dialect\s*[=:]\s*([\w-]+)/.exec(line);
+    if (match !== null) return match[1];
+  }
+  return undefined;
+}
+
+/**
+ * The `--dialect` to pass, or undefined to leave it to the project's
+ * `.sqlfluff`. `[config] sqlfluff_dialect` wins; a root `.sqlfluff` that sets
+ * a dialect comes next, so nested `.sqlfluff` files can still override it;
+ * otherwise ANSI.
+ */
+export async function sqlfluffDialect(
+  fileManager: FileManager,
+  projectDir: string,
+  config: ResolvedConfig
+): Promise<string | undefined> {
+  if (config.values.sqlfluff_dialect !== undefined) {
+    return config.values.sqlfluff_dialect;
+  }
+  const path = join(projectDir, ".sqlfluff");
+  if (
+    (await fileManager.exists(path)) &&
+    parseSqlfluffDialect(await fileManager.readText(path)) !== undefined
+  ) {
+    return undefined;
+  }
+  return DEFAULT_SQL_DIALECT;
+}
+
+/**
+ * Glob for SQL files. When a changed-file list is given, select from it
+ * instead.
+ */
+export async function findSqlFiles(
+  fileManager: FileManager,
+  projectDir: string,
+  ignorePaths: readonly string[],
+  files?: readonly string[]
+): Promise<string[]> {
+  if (files !== undefined) return matchFiles(files, SQL_GLOB);
+  const found = await fileManager.glob(SQL_GLOB, projectDir, ignorePaths);
+  return found.sort();
+}
+
+async function dialectArgs(opts: RunOptions): Promise<string[]> {
+  const { projectDir, config, fileManager } = opts;
+  const dialect = await sqlfluffDialect(fileManager, projectDir, config);
+  return dialect !== undefined ? ["--dialect", dialect] : [];
+}
+
+export const sqlfluffRunner: LinterRunner = {
+  id: "sqlfluff",
+  name: "SQLFluff",
+  configFile: ".sqlfluff",
+  fileScoped: true,
+  installHint: {
+    description: "SQL linter and formatter",
+    pip: "pip install sqlfluff",
+    brew: "brew install sqlfluff",
+  },
+  versionArgs: ["sqlfluff", "--version"],
+  minVersion: "3.0.0", // fix applies without prompting for confirmation
+  cache: {
+    inputs: [SQL_GLOB, "**/.sqlfluff", ".sqlfluffignore"],
+  },
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    try {
+      const result = await commandRunner.run(["sqlfluff", "--version"]);
+      return result.exitCode === 0;
+    } catch {
+      return false;
+    }
+  },
+
+  async run(opts: RunOptions): Promise<LintIssue[]> {
+    const { projectDir, config, commandRunner, fileManager, batchSize } = opts;
+    const files = await findSqlFiles(
+      fileManager,
+      projectDir,
+      config.ignorePaths,
+      opts.files
+    );
+    if (files.length === 0) return [];
+
+    const dialect = await dialectArgs(opts);
+    const raw = await mapShards(files, batchSize, async (shard) => {
+      const result = await commandRunner.run(
+        ["sqlfluff", "lint", "--format", "json", ...dialect, ...shard],
+        { cwd: projectDir }
+      );
+      // Exit 1 means violations were found; 2 means sqlfluff itself failed
+      if (result.exitCode !== 0 && result.exitCode !== 1) {
+        const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+        throw new Error(`sqlfluff failed: ${detail}`);
+      }
+      return parseSqlfluffOutput(result.stdout, projectDir);
+    });
+    return applyFingerprints(raw, projectDir, fileManager);
+  },
+
+  async fix(opts: RunOptions): Promise<void> {
+    const { projectDir, config, commandRunner, fileManager, batchSize } = opts;
+    const files = await findSqlFiles(
+      fileManager,
+      projectDir,
+      config.ignorePaths,
+      opts.files
+    );
+    const dialect = await dialectArgs(opts);
+    await forEachShard(files, batchSize, (shard) =>
+      commandRunner.run(["sqlfluff", "fix", ...dialect, ...shard], {
+        cwd: projectDir,
+      })
+    );
+  },
+};
@@ -0,0 +1,108 @@
+import { resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { LintIssue } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import {
+  goLanguageVersion,
+  goModulesFor,
+  readGoVersion,
+  withModule,
+} from "@/utils/go-modules";
+import { parseNdjson } from "@/utils/ndjson";
+
+/** Shape of a single diagnostic in `staticcheck -f json` output */
+interface StaticcheckDiagnostic {
+  code: string;
+  severity: string;
+  location: {
+    file: string;
+    line: number;
+    column: number;
+  };
+  message: string;
+}
+
+function isStaticcheckDiagnostic(value: unknown): value is StaticcheckDiagnostic {
+  return (
+    typeof value === "object" &&
+    value !== null &&
+    "code" in value &&
+    typeof value.code === "string" &&
+    "location" in value &&
+    typeof value.location === "object" &&
+    value.location !== null &&
+    "message" in value
+  );
+}
+
+/**
+ * Parse staticcheck NDJSON output into raw issues without fingerprints.
+ * Skips diagnostics with severity "ignored" and those without a file.
+ */
+export function parseStaticcheckOutput(
+  ndjson: string,
+  moduleDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const issues: Omit<LintIssue, "fingerprint">[] = [];
+
+  for (const entry of parseNdjson(ndjson)) {
+    if (!isStaticcheckDiagnostic(entry)) continue;
+    if (entry.severity === "ignored") continue;
+    if (!entry.location.file) continue;
+
+    issues.push({
+      rule: `staticcheck/${entry.code}`,
+      linter: "staticcheck",
+      file: resolve(moduleDir, entry.location.file),
+      line: entry.location.line,
+      col: entry.location.column,
+      message: entry.message,
+      severity: entry.severity === "error" ? "error" : "warning",
+    });
+  }
+
+  return issues;
+}
+
+export const staticcheckRunner: LinterRunner = {
+  id: "staticcheck",
+  name: "staticcheck",
+  configFile: "staticcheck.conf",
+  installHint: {
+    description: "Go static analysis (SA/ST/QF checks)",
+    brew: "brew install staticcheck",
+    go: "go install honnef.co/go/tools/cmd/staticcheck@latest",
+  },
+  moduleScoped: true,
+  versionArgs: ["staticcheck", "-version"],
+  cache: {
+    inputs: ["**/*.go", "**/go.mod", "**/go.sum"],
+  },
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    const result = await commandRunner.run(["staticcheck", "-version"]);
+    return result.exitCode === 0;
+  },
+
+  async run(opts: RunOptions): Promise<LintIssue[]> {
+    const { projectDir, commandRunner, fileManager } = opts;
+    // staticcheck reads staticcheck.conf from each package directory upward,
+    // so running from the module root honors any repo-level config as-is.
+    const modules = await goModulesFor(opts);
+    const perModule = await Promise.all(
+      modules.map(async (moduleDir) => {
+        // Checks that need a newer Go than the module targets are skipped
+        const goVersion = await readGoVersion(fileManager, moduleDir);
+        const target = goVersion !== null ? ["-go", goLanguageVersion(goVersion)] : [];
+        const result = await commandRunner.run(
+          ["staticcheck", "-f", "json", ...target, "./..."],
+          { cwd: moduleDir }
+        );
+        const raw = parseStaticcheckOutput(result.stdout, moduleDir);
+        return withModule(raw, projectDir, moduleDir);
+      })
+    );
+    return applyFingerprints(perModule.flat(), projectDir, fileManager);
+  },
+};
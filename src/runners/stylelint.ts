@@ -0,0 +1,177 @@
+import { join, resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
+import type { LintIssue } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
+import { packageHasConfig } from "@/utils/package-json";
+import { safeParseJson } from "@/utils/parse";
+import { resolveToolPath } from "@/utils/resolve-tool-path";
+import { forEachShard, mapShards } from "@/utils/shards";
+
+export const STYLE_GLOB = "**/*.{css,scss,less}";
+
+/** The config files stylelint searches for after package.json, in its lookup order */
+export const STYLELINT_CONFIG_FILES = [
+  ".stylelintrc",
+  ".stylelintrc.json",
+  ".stylelintrc.yaml",
+  ".stylelintrc.yml",
+  ".stylelintrc.js",
+  ".stylelintrc.cjs",
+  ".stylelintrc.mjs",
+  "stylelint.config.js",
+  "stylelint.config.cjs",
+  "stylelint.config.mjs",
+];
+
+/**
+ * The config stylelint picks up — `package.json` with a `stylelint` key, or
+ * the first config file — or null without one.
+ */
+export async function findStylelintConfig(
+  projectDir: string,
+  fileManager: FileManager
+): Promise<string | null> {
+  if (await packageHasConfig(projectDir, fileManager, "stylelint")) {
+    return "package.json";
+  }
+  for (const name of STYLELINT_CONFIG_FILES) {
+    if (await fileManager.exists(join(projectDir, name))) return name;
+  }
+  return null;
+}
+
+interface StylelintWarning {
+  line?: number;
+  column?: number;
+  rule: string;
+  severity: string;
+  text: string;
+}
+
+interface StylelintResult {
+  source: string;
+  warnings: StylelintWarning[];
+}
+
+function isStylelintOutput(value: unknown): value is StylelintResult[] {
+  return (
+    Array.isArray(value) &&
+    value.every(
+      (entry) =>
+        typeof entry === "object" &&
+        entry !== null &&
+        "source" in entry &&
+        "warnings" in entry &&
+        Array.isArray(entry.warnings)
+    )
+  );
+}
+
+/**
+ * Parse `stylelint --formatter json` output into raw issues without
+ * fingerprints. Returns [] on malformed or empty input. The ` (rule-name)`
+ * suffix stylelint puts on messages is dropped, since the rule carries it;
+ * unparsable files are reported as `stylelint/CssSyntaxError`.
+ */
+export function parseStylelintOutput(
+  output: string,
+  projectDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const parsed = safeParseJson(output);
+  if (!isStylelintOutput(parsed)) return [];
+  return parsed.flatMap((result) =>
+    result.warnings.map((w): Omit<LintIssue, "fingerprint"> => {
+      const suffix = ` (${w.rule})`;
+      return {
+        rule: `stylelint/${w.rule}`,
+        linter: "stylelint",
+        file: resolve(projectDir, result.source),
+        line: w.line ?? 1,
+        col: w.column ?? 1,
+        message: w.text.endsWith(suffix) ? w.text.slice(0, -suffix.length) : w.text,
+        severity: w.severity === "error" ? "error" : "warning",
+      };
+    })
+  );
+}
+
+/** Changed style files, or the glob for all of them (stylelint expands it) */
+function stylelintTargets(files: readonly string[] | undefined): string[] {
+  return files !== undefined ? matchFiles(files, STYLE_GLOB) : [STYLE_GLOB];
+}
+
+export const stylelintRunner: LinterRunner = {
+  id: "stylelint",
+  name: "Stylelint",
+  configFile: ".stylelintrc.yaml",
+  fileScoped: true,
+  installHint: {
+    description: "CSS, SCSS and Less linter",
+    npm: "npm install -D stylelint postcss-scss postcss-less",
+  },
+  versionArgs: ["stylelint", "--version"],
+  cache: {
+    inputs: [STYLE_GLOB, ...STYLELINT_CONFIG_FILES, "package.json"],
+  },
+
+  async isAvailable(
+    commandRunner: CommandRunner,
+    projectDir?: string
+  ): Promise<boolean> {
+    const cmd = await resolveToolPath("stylelint", projectDir ?? ".", commandRunner);
+    return cmd !== null;
+  },
+
+  // Without a config stylelint has no rules and refuses to run
+  async appliesTo({ projectDir, fileManager }: RunOptions): Promise<boolean> {
+    return (await findStylelintConfig(projectDir, fileManager)) !== null;
+  },
+
+  async run({
+    projectDir,
+    commandRunner,
+    fileManager,
+    files,
+    batchSize,
+  }: RunOptions): Promise<LintIssue[]> {
+    const targets = stylelintTargets(files);
+    if (targets.length === 0) return [];
+    const cmd =
+      (await resolveToolPath("stylelint", projectDir, commandRunner)) ?? "stylelint";
+    const raw = await mapShards(targets, batchSize, async (shard) => {
+      const result = await commandRunner.run(
+        [cmd, "--formatter", "json", "--allow-empty-input", ...shard],
+        { cwd: projectDir }
+      );
+      // Exit 2 means problems were found; any other failure is stylelint's own
+      if (result.exitCode !== 0 && result.exitCode !== 2) {
+        const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+        throw new Error(`stylelint failed: ${detail}`);
+      }
+      // Stylelint 16 reports to stderr, older releases to stdout
+      const issues = parseStylelintOutput(result.stdout, projectDir);
+      return issues.length > 0
+        ? issues
+        : parseStylelintOutput(result.stderr, projectDir);
+    });
+    return applyFingerprints(raw, projectDir, fileManager);
+  },
+
+  async fix({
+    projectDir,
+    commandRunner,
+    files,
+    batchSize,
+  }: RunOptions): Promise<void> {
+    const cmd =
+      (await resolveToolPath("stylelint", projectDir, commandRunner)) ?? "stylelint";
+    await forEachShard(stylelintTargets(files), batchSize, (shard) =>
+      commandRunner.run([cmd, "--fix", "--allow-empty-input", ...shard], {
+        cwd: projectDir,
+      })
+    );
+  },
+};
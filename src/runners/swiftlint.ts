@@ -0,0 +1,127 @@
+import { resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { LintIssue } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
+import { safeParseJson } from "@/utils/parse";
+import { forEachShard, mapShards } from "@/utils/shards";
+
+export const SWIFT_GLOB = "**/*.swift";
+
+interface SwiftlintViolation {
+  file: string | null;
+  line: number | null;
+  character: number | null;
+  severity: string;
+  reason: string;
+  rule_id: string;
+}
+
+function isSwiftlintOutput(value: unknown): value is SwiftlintViolation[] {
+  return (
+    Array.isArray(value) &&
+    value.every(
+      (entry) =>
+        typeof entry === "object" &&
+        entry !== null &&
+        "rule_id" in entry &&
+        "reason" in entry
+    )
+  );
+}
+
+/**
+ * Parse `swiftlint lint --reporter json` stdout into raw issues without
+ * fingerprints. Returns [] on malformed or empty input. Severity `Error`
+ * maps to error, anything else to warning.
+ */
+export function parseSwiftlintOutput(
+  stdout: string,
+  projectDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const parsed = safeParseJson(stdout);
+  if (!isSwiftlintOutput(parsed)) return [];
+  return parsed.flatMap((violation) => {
+    if (violation.file === null) return [];
+    return [
+      {
+        rule: `swiftlint/${violation.rule_id}`,
+        linter: "swiftlint",
+        file: resolve(projectDir, violation.file),
+        line: violation.line ?? 1,
+        col: violation.character ?? 1,
+        message: violation.reason,
+        severity: violation.severity.toLowerCase() === "error" ? "error" : "warning",
+      } satisfies Omit<LintIssue, "fingerprint">,
+    ];
+  });
+}
+
+export const swiftlintRunner: LinterRunner = {
+  id: "swiftlint",
+  name: "SwiftLint",
+  configFile: ".swiftlint.yml",
+  fileScoped: true,
+  installHint: {
+    description: "Swift linter (Linux: github.com/realm/SwiftLint/releases)",
+    brew: "brew install swiftlint",
+  },
+  versionArgs: ["swiftlint", "version"],
+  cache: {
+    inputs: [SWIFT_GLOB],
+  },
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    const result = await commandRunner.run(["swiftlint", "version"]);
+    return result.exitCode === 0;
+  },
+
+  async run({
+    projectDir,
+    commandRunner,
+    fileManager,
+    files,
+    batchSize,
+  }: RunOptions): Promise<LintIssue[]> {
+    // With no paths swiftlint lints the working directory, honoring .swiftlint.yml
+    const targets = files !== undefined ? matchFiles(files, SWIFT_GLOB) : undefined;
+    if (targets !== undefined && targets.length === 0) return [];
+    const lint = async (paths: readonly string[]) => {
+      // --force-exclude applies .swiftlint.yml's excluded paths to named files too
+      const args = paths.length > 0 ? ["--force-exclude", ...paths] : [];
+      const result = await commandRunner.run(
+        ["swiftlint", "lint", "--reporter", "json", "--quiet", ...args],
+        { cwd: projectDir }
+      );
+      // swiftlint exits 2 on serious violations; only unparsable output is a failure
+      if (result.exitCode !== 0 && !isSwiftlintOutput(safeParseJson(result.stdout))) {
+        const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+        throw new Error(`swiftlint failed: ${detail}`);
+      }
+      return parseSwiftlintOutput(result.stdout, projectDir);
+    };
+    const raw =
+      targets !== undefined
+        ? await mapShards(targets, batchSize, lint)
+        : await lint([]);
+    return applyFingerprints(raw, projectDir, fileManager);
+  },
+
+  async fix({
+    projectDir,
+    commandRunner,
+    files,
+    batchSize,
+  }: RunOptions): Promise<void> {
+    if (files === undefined) {
+      await commandRunner.run(["swiftlint", "--fix"], { cwd: projectDir });
+      return;
+    }
+    await forEachShard(matchFiles(files, SWIFT_GLOB), batchSize, (shard) =>
+      commandRunner.run(["swiftlint", "--fix", "--force-exclude", ...shard], {
+        cwd: projectDir,
+      })
+    );
+  },
+};
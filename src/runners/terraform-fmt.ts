@@ -0,0 +1,104 @@
+import { resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { LintIssue } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { pipeFix } from "@/utils/pipe-fix";
+import { forEachShard, mapShards } from "@/utils/shards";
+import { findTerraformFiles, TERRAFORM_GLOB } from "@/utils/terraform-dirs";
+
+/**
+ * Parse `terraform fmt -check` stdout into raw issues without fingerprints.
+ * One file per line needs reformatting, relative to `dir`.
+ * Returns [] for empty output.
+ */
+export function parseTerraformFmtOutput(
+  stdout: string,
+  dir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const filenames = stdout
+    .split("\n")
+    .map((line) => line.trim())
+    .filter((line) => line.length > 0);
+
+  return filenames.map(
+    (filename) =>
+      ({
+        rule: "terraform-fmt/format",
+        linter: "terraform-fmt",
+        file: resolve(dir, filename),
+        line: 1,
+        col: 1,
+        message: "File needs formatting — run: terraform fmt",
+        severity: "error",
+      }) satisfies Omit<LintIssue, "fingerprint">
+  );
+}
+
+/**
+ * Every Terraform file outside ignored paths. They are passed by name rather
+ * than formatted with `-recursive`, which would reach ignored directories too.
+ */
+async function fmtTargets({
+  projectDir,
+  config,
+  fileManager,
+}: RunOptions): Promise<string[]> {
+  return findTerraformFiles(fileManager, projectDir, config.ignorePaths);
+}
+
+export const terraformFmtRunner: LinterRunner = {
+  id: "terraform-fmt",
+  name: "terraform fmt",
+  configFile: null,
+  installHint: {
+    description: "Terraform formatter (ships with the terraform CLI)",
+    brew: "brew install hashicorp/tap/terraform",
+  },
+  versionArgs: ["terraform", "version"],
+  cache: {
+    inputs: [TERRAFORM_GLOB],
+  },
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    const result = await commandRunner.run(["terraform", "version"]);
+    return result.exitCode === 0;
+  },
+
+  async run(opts: RunOptions): Promise<LintIssue[]> {
+    const { projectDir, commandRunner, fileManager, batchSize } = opts;
+    const targets = await fmtTargets(opts);
+    if (targets.length === 0) return [];
+    const raw = await mapShards(targets, batchSize, async (shard) => {
+      const result = await commandRunner.run(["terraform", "fmt", "-check", ...shard], {
+        cwd: projectDir,
+      });
+      // Exits 3 when files need formatting, listing them on stdout
+      const issues = parseTerraformFmtOutput(result.stdout, projectDir);
+      // A non-zero exit without a listed file is a syntax error or bad target
+      if (result.exitCode !== 0 && issues.length === 0) {
+        const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+        throw new Error(`terraform fmt failed: ${detail}`);
+      }
+      return issues;
+    });
+    return applyFingerprints(raw, projectDir, fileManager);
+  },
+
+  async fix(opts: RunOptions): Promise<void> {
+    const targets = await fmtTargets(opts);
+    await forEachShard(targets, opts.batchSize, (shard) =>
+      opts.commandRunner.run(["terraform", "fmt", ...shard], {
+        cwd: opts.projectDir,
+      })
+    );
+  },
+
+  async previewFix(
+    { projectDir, commandRunner }: RunOptions,
+    _file: string,
+    content: string
+  ): Promise<string> {
+    return pipeFix(commandRunner, ["terraform", "fmt", "-"], content, projectDir);
+  },
+};
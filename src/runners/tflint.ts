@@ -0,0 +1,115 @@
+import { resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { LintIssue, Severity } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { safeParseJson } from "@/utils/parse";
+import { findTerraformDirs, TERRAFORM_GLOB } from "@/utils/terraform-dirs";
+
+/** Shape of a single issue in `tflint --format json` output */
+interface TflintIssue {
+  rule: { name: string; severity: string };
+  message: string;
+  range: {
+    filename: string;
+    start: { line: number; column: number };
+  };
+}
+
+function isTflintIssue(value: unknown): value is TflintIssue {
+  return (
+    typeof value === "object" &&
+    value !== null &&
+    "rule" in value &&
+    typeof value.rule === "object" &&
+    value.rule !== null &&
+    "name" in value.rule &&
+    typeof value.rule.name === "string" &&
+    "message" in value &&
+    typeof value.message === "string" &&
+    "range" in value &&
+    typeof value.range === "object" &&
+    value.range !== null &&
+    "filename" in value.range &&
+    typeof value.range.filename === "string" &&
+    "start" in value.range &&
+    typeof value.range.start === "object" &&
+    value.range.start !== null &&
+    "line" in value.range.start &&
+    typeof value.range.start.line === "number" &&
+    "column" in value.range.start &&
+    typeof value.range.start.column === "number"
+  );
+}
+
+function issuesOf(value: unknown): unknown[] {
+  if (typeof value !== "object" || value === null || !("issues" in value)) return [];
+  return Array.isArray(value.issues) ? value.issues : [];
+}
+
+const SEVERITY_BY_LEVEL: Record<string, Severity> = {
+  error: "error",
+  warning: "warning",
+  notice: "info",
+};
+
+/**
+ * Parse `tflint --format json` stdout into raw issues without fingerprints.
+ * Filenames are relative to the directory tflint ran in.
+ * Returns [] on malformed/empty input.
+ */
+export function parseTflintOutput(
+  stdout: string,
+  dir: string
+): Omit<LintIssue, "fingerprint">[] {
+  return issuesOf(safeParseJson(stdout))
+    .filter(isTflintIssue)
+    .map((issue) => {
+      const severity = SEVERITY_BY_LEVEL[issue.rule.severity] ?? "warning";
+      return {
+        rule: `tflint/${issue.rule.name}`,
+        linter: "tflint",
+        file: resolve(dir, issue.range.filename),
+        line: issue.range.start.line,
+        col: issue.range.start.column,
+        message: issue.message,
+        severity,
+      } satisfies Omit<LintIssue, "fingerprint">;
+    });
+}
+
+export const tflintRunner: LinterRunner = {
+  id: "tflint",
+  name: "TFLint",
+  configFile: null,
+  installHint: {
+    description: "Terraform linter",
+    brew: "brew install tflint",
+    go: "go install github.com/terraform-linters/tflint@latest",
+  },
+  versionArgs: ["tflint", "--version"],
+  cache: {
+    inputs: [TERRAFORM_GLOB, "**/.tflint.hcl"],
+  },
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    const result = await commandRunner.run(["tflint", "--version"]);
+    return result.exitCode === 0;
+  },
+
+  async run(opts: RunOptions): Promise<LintIssue[]> {
+    const { projectDir, config, commandRunner, fileManager } = opts;
+    // tflint only inspects the module in its cwd, so visit each directory;
+    // a .tflint.hcl there is picked up as-is
+    const dirs = await findTerraformDirs(fileManager, projectDir, config.ignorePaths);
+    const perDir = await Promise.all(
+      dirs.map(async (dir) => {
+        const result = await commandRunner.run(["tflint", "--format", "json"], {
+          cwd: dir,
+        });
+        return parseTflintOutput(result.stdout, dir);
+      })
+    );
+    return applyFingerprints(perDir.flat(), projectDir, fileManager);
+  },
+};
@@ -71,6 +71,8 @@ export const tscRunner: LinterRunner = {
     description: "TypeScript type checker",
     npm: "npm install -D typescript",
   },
+  versionArgs: ["tsc", "--version"],
+  watchInputs: ["**/*.{ts,tsx,mts,cts}", "**/tsconfig*.json"],
 
   async isAvailable(
     commandRunner: CommandRunner,
@@ -1,4 +1,4 @@
-import type { ResolvedConfig } from "@/config/schema";
+import type { ResolvedConfig, RetryPolicy } from "@/config/schema";
 import type { CommandRunner } from "@/infra/command-runner";
 import type { FileManager } from "@/infra/file-manager";
 import type { LintIssue } from "@/models/lint-issue";
@@ -8,6 +8,43 @@ export interface RunOptions {
   config: ResolvedConfig;
   commandRunner: CommandRunner;
   fileManager: FileManager;
+  /**
+   * Project-relative files to check (`--changed-since`, `--staged`), or for
+   * `fix` to fix. File-oriented runners restrict themselves to these;
+   * whole-project runners ignore it.
+   */
+  files?: readonly string[];
+  /**
+   * Project-relative module directory (`check --module`). Multi-module runners
+   * (the Go ones) lint only the modules at or under it; others ignore it.
+   */
+  module?: string;
+  /**
+   * Project-relative subtrees to check (`check --path`). Multi-module runners
+   * lint only the modules under them, or the module containing one.
+   */
+  paths?: readonly string[];
+  /**
+   * Lint generated files too (`check --include-generated`). Runners whose tool
+   * can skip generated code on its own, like gosec, only do so when unset.
+   */
+  includeGenerated?: boolean;
+  /**
+   * Also check external links (`check --check-external`). Off by default so
+   * offline runs stay deterministic; only the markdown link checker uses it.
+   */
+  checkExternal?: boolean;
+  /**
+   * Most files passed to one tool invocation (`check --batch-size`, default
+   * DEFAULT_BATCH_SIZE). Runners that list files in argv run once per shard.
+   */
+  batchSize?: number;
+  /**
+   * Aborted when the check stops early (`check --fail-fast`). Commands run
+   * through `commandRunner` are killed on their own; a long in-process runner
+   * can watch it too.
+   */
+  signal?: AbortSignal;
 }
 
 export interface InstallHint {
@@ -27,6 +64,29 @@ export interface InstallHint {
   readonly go?: string;
   /** rustup component command, e.g. "rustup component add clippy" */
   readonly rustup?: string;
+  /** RubyGems install command, e.g. "gem install rubocop" */
+  readonly gem?: string;
+  /** Composer install command, e.g. "composer require --dev phpstan/phpstan" */
+  readonly composer?: string;
+}
+
+export interface RunnerCacheSpec {
+  /** Globs (relative to project root) of every file that can affect the result */
+  readonly inputs: readonly string[];
+  /**
+   * Further project-relative input files no glob can describe, e.g. shell
+   * scripts recognized by their shebang.
+   */
+  findInputs?(
+    fileManager: FileManager,
+    projectDir: string,
+    config: ResolvedConfig
+  ): Promise<string[]>;
+  /**
+   * The check runs inside ai-guardrails, not as a tool: results are keyed to
+   * the ai-guardrails version and no `versionArgs` are needed.
+   */
+  readonly inProcess?: boolean;
 }
 
 export interface LinterRunner {
@@ -38,8 +98,80 @@ export interface LinterRunner {
   readonly configFile: string | null;
   /** Install instructions for this tool */
   readonly installHint: InstallHint;
+  /**
+   * Honours `RunOptions.files` by checking (and fixing) only those files.
+   * `check --staged` runs file-scoped runners alone; whole-project runners
+   * are left out.
+   */
+  readonly fileScoped?: boolean;
+  /**
+   * Runs once per module and honours `RunOptions.module`. `check --module`
+   * runs module-scoped runners alone.
+   */
+  readonly moduleScoped?: boolean;
+  /**
+   * false for opt-in runners: they run only when [runners.<id>] sets
+   * enabled = true or `check --enable` names them. Defaults to true.
+   */
+  readonly defaultEnabled?: boolean;
+  /**
+   * Takes far longer than a lint pass, e.g. it runs the test suite. `list`
+   * and `doctor` mark it so projects know to enable it deliberately.
+   */
+  readonly slow?: boolean;
+  /**
+   * Command printing the tool version. `doctor` reports it, and an upgrade
+   * invalidates cached results. Node tools are resolved like `isAvailable`.
+   */
+  readonly versionArgs?: readonly string[];
+  /** Oldest supported tool version; `doctor` reports older installs as outdated */
+  readonly minVersion?: string;
+  /**
+   * Declares the runner's inputs so its results can be cached. Requires
+   * `versionArgs`, unless the check is `inProcess`. Omit to always run.
+   */
+  readonly cache?: RunnerCacheSpec;
+  /**
+   * Globs (relative to project root) whose changes make `watch` re-run this
+   * runner. Defaults to `cache.inputs`; with neither, any change re-runs it.
+   */
+  readonly watchInputs?: readonly string[];
+  /**
+   * Rules another runner reports for the same checks, e.g. a meta-linter
+   * wrapping this tool. Dropped whenever this runner runs, so a finding is
+   * not reported twice.
+   */
+  readonly supersedes?: readonly string[];
+  /**
+   * A prerequisite of the other runners, e.g. a build: it finishes before any
+   * other starts, so with `check --fail-fast` its failure cancels them all.
+   */
+  readonly runsFirst?: boolean;
+  /**
+   * Re-runs after a transient (network) failure, for runners that fetch
+   * remote data. [runners.<id>] retry wins. Defaults to NO_RETRY.
+   */
+  readonly retry?: RetryPolicy;
+  /**
+   * Whether this project uses the tool at all, e.g. ESLint only where it is
+   * configured. A runner that does not apply is reported as disabled unless
+   * [runners.<id>] or `--enable` turns it on explicitly. Omit to always apply.
+   */
+  appliesTo?(opts: RunOptions): Promise<boolean>;
   /** Check if the tool binary is reachable */
   isAvailable(commandRunner: CommandRunner, projectDir?: string): Promise<boolean>;
   /** Run the linter, return normalized issues */
   run(opts: RunOptions): Promise<LintIssue[]>;
+  /**
+   * Apply the tool's safe autofixes in place, to `opts.files` when given and
+   * the runner is fileScoped. Omitted by runners whose tool has no autofix —
+   * `check --fix` skips them.
+   */
+  fix?(opts: RunOptions): Promise<void>;
+  /**
+   * What `fix` would make of `file` (project-relative) given its `content`,
+   * without writing anything — `check --diff` shows it. Omitted by runners
+   * whose tool can only fix files in place.
+   */
+  previewFix?(opts: RunOptions, file: string, content: string): Promise<string>;
 }
@@ -0,0 +1,86 @@
+import { resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { LintIssue } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { goModulesFor, withModule } from "@/utils/go-modules";
+
+/** e.g. `/repo/internal/size.go:9:14: unnecessary conversion` */
+const LINE_RE = /^(.+?):(\d+):(\d+):\s+(.*)$/;
+
+/**
+ * Parse unconvert stdout — one `file:line:col: message` line per redundant
+ * conversion — into raw issues without fingerprints. Returns [] for empty
+ * output.
+ */
+export function parseUnconvertOutput(
+  stdout: string,
+  moduleDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const issues: Omit<LintIssue, "fingerprint">[] = [];
+  for (const line of stdout.split("\n")) {
+    const match = LINE_RE.exec(line.trim());
+    if (match === null) continue;
+    const [, file = "", lineNo = "1", col = "1", message = ""] = match;
+    issues.push({
+      rule: "unconvert/unnecessary-conversion",
+      linter: "unconvert",
+      file: resolve(moduleDir, file),
+      line: Number.parseInt(lineNo, 10),
+      col: Number.parseInt(col, 10),
+      message,
+      severity: "warning",
+    });
+  }
+  return issues;
+}
+
+export const unconvertRunner: LinterRunner = {
+  id: "unconvert",
+  name: "unconvert",
+  configFile: null,
+  installHint: {
+    description: "Go unnecessary type conversion detector",
+    go: "go install github.com/mdempsky/unconvert@latest",
+  },
+  moduleScoped: true,
+  // No cache: unconvert has no version flag to key cached results on
+  watchInputs: ["**/*.go", "**/go.mod", "**/go.sum"],
+  // The strict .golangci.yml enables unconvert too
+  supersedes: ["golangci-lint/unconvert"],
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    // unconvert exits 2 after printing -h usage; 127 means it is not installed
+    const result = await commandRunner.run(["unconvert", "-h"]);
+    return result.exitCode !== 127;
+  },
+
+  async run(opts: RunOptions): Promise<LintIssue[]> {
+    const { projectDir, commandRunner, fileManager } = opts;
+    const modules = await goModulesFor(opts);
+    const perModule = await Promise.all(
+      modules.map(async (moduleDir) => {
+        const result = await commandRunner.run(["unconvert", "./..."], {
+          cwd: moduleDir,
+        });
+        const raw = parseUnconvertOutput(result.stdout, moduleDir);
+        // Exit 1 also means conversions were found; without any, it failed to load
+        if (result.exitCode !== 0 && raw.length === 0) {
+          const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+          throw new Error(`unconvert failed: ${detail}`);
+        }
+        return withModule(raw, projectDir, moduleDir);
+      })
+    );
+    return applyFingerprints(perModule.flat(), projectDir, fileManager);
+  },
+
+  async fix(opts: RunOptions): Promise<void> {
+    // -apply rewrites the files in place, dropping each redundant conversion
+    for (const moduleDir of await goModulesFor(opts)) {
+      await opts.commandRunner.run(["unconvert", "-apply", "./..."], {
+        cwd: moduleDir,
+      });
+    }
+  },
+};
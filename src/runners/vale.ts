@@ -0,0 +1,146 @@
+import { join, resolve } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
+import { DEFAULT_IGNORE } from "@/languages/constants";
+import type { LintIssue, Severity } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
+import { isPlainObject } from "@/utils/deep-merge";
+import { mapShards } from "@/utils/shards";
+
+const VALE_GLOB = "**/*.{md,rst}";
+
+/** The config files vale looks for in the project root */
+export const VALE_CONFIG_FILES = [".vale.ini", "_vale.ini"] as const;
+
+/** vale exits 1 when it reports error-level alerts, 2 on a runtime error */
+const COMPLETED_EXIT_CODES = new Set([0, 1]);
+
+/** Shape of one alert in `vale --output=JSON` output */
+interface ValeAlert {
+  Check: string;
+  Severity: string;
+  Line: number;
+  Span?: readonly number[];
+  Message: string;
+}
+
+function isValeAlert(value: unknown): value is ValeAlert {
+  return (
+    isPlainObject(value) &&
+    typeof value.Check === "string" &&
+    typeof value.Severity === "string" &&
+    typeof value.Line === "number" &&
+    typeof value.Message === "string"
+  );
+}
+
+/** vale's error/warning/suggestion levels */
+function valeSeverity(level: string): Severity {
+  if (level === "error") return "error";
+  return level === "warning" ? "warning" : "info";
+}
+
+/**
+ * Parse `vale --output=JSON` output — alerts keyed by file — into raw issues
+ * without fingerprints. Rules are `vale/<check>`, e.g. `vale/Vale.Spelling`;
+ * a suggestion is an info. Returns [] for empty output.
+ */
+export function parseValeOutput(
+  stdout: string,
+  projectDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  if (stdout.trim() === "") return [];
+  const parsed: unknown = JSON.parse(stdout);
+  if (!isPlainObject(parsed)) return [];
+  return Object.entries(parsed).flatMap(([file, alerts]) =>
+    (Array.isArray(alerts) ? alerts : []).filter(isValeAlert).map(
+      (alert): Omit<LintIssue, "fingerprint"> => ({
+        rule: `vale/${alert.Check}`,
+        linter: "vale",
+        file: resolve(projectDir, file),
+        line: alert.Line,
+        col: alert.Span?.[0] ?? 1,
+        message: alert.Message,
+        severity: valeSeverity(alert.Severity),
+      })
+    )
+  );
+}
+
+/** Whether the project root has a vale config */
+async function hasValeConfig(
+  fileManager: FileManager,
+  projectDir: string
+): Promise<boolean> {
+  for (const name of VALE_CONFIG_FILES) {
+    if (await fileManager.exists(join(projectDir, name))) return true;
+  }
+  return false;
+}
+
+export const valeRunner: LinterRunner = {
+  id: "vale",
+  name: "Vale",
+  configFile: null,
+  fileScoped: true,
+  installHint: {
+    description: "Prose linter (then `vale sync` for the styles .vale.ini names)",
+    brew: "brew install vale",
+  },
+  versionArgs: ["vale", "--version"],
+  cache: {
+    inputs: [VALE_GLOB, ...VALE_CONFIG_FILES],
+  },
+
+  /** Only where the project keeps a .vale.ini: its styles are the rules */
+  async appliesTo({ projectDir, fileManager }: RunOptions): Promise<boolean> {
+    return hasValeConfig(fileManager, projectDir);
+  },
+
+  async isAvailable(
+    commandRunner: CommandRunner,
+    projectDir?: string
+  ): Promise<boolean> {
+    const version = await commandRunner.run(["vale", "--version"]);
+    if (version.exitCode !== 0 || projectDir === undefined) {
+      return version.exitCode === 0;
+    }
+    // Loading the config fails while its StylesPath is missing, i.e. before
+    // `vale sync` has downloaded the styles
+    const config = await commandRunner.run(["vale", "ls-config"], { cwd: projectDir });
+    return config.exitCode === 0;
+  },
+
+  async run({
+    projectDir,
+    config,
+    commandRunner,
+    fileManager,
+    files,
+    batchSize,
+  }: RunOptions): Promise<LintIssue[]> {
+    const targets =
+      files !== undefined
+        ? matchFiles(files, VALE_GLOB)
+        : await fileManager.glob(VALE_GLOB, projectDir, [
+            ...DEFAULT_IGNORE,
+            ...config.ignorePaths,
+          ]);
+    if (targets.length === 0) return [];
+    const lint = async (shard: readonly string[]) => {
+      const result = await commandRunner.run(["vale", "--output=JSON", ...shard], {
+        cwd: projectDir,
+      });
+      if (!COMPLETED_EXIT_CODES.has(result.exitCode)) {
+        const output = result.stderr.trim() || result.stdout.trim();
+        const detail = output || `exit code ${result.exitCode}`;
+        throw new Error(`vale failed: ${detail}`);
+      }
+      return parseValeOutput(result.stdout, projectDir);
+    };
+    const raw = await mapShards(targets.toSorted(), batchSize, lint);
+    return applyFingerprints(raw, projectDir, fileManager);
+  },
+};
@@ -0,0 +1,126 @@
+import { resolve } from "node:path";
+import { minimatch } from "minimatch";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
+import { DEFAULT_IGNORE } from "@/languages/constants";
+import type { LintIssue } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { applyFingerprints } from "@/utils/apply-fingerprints";
+import { matchFiles } from "@/utils/changed-files";
+import { findCharts, isChartTemplate } from "@/utils/helm-charts";
+import { mapShards } from "@/utils/shards";
+
+// Matches lines like: ci.yml:3:81: [warning] too many spaces after colon (colons)
+const YAMLLINT_LINE_PATTERN =
+  /^(.+?):(\d+):(\d+): \[(error|warning)\] (.+?)(?: \(([\w-]+)\))?$/;
+
+/**
+ * Parse `yamllint -f parsable` stdout into raw issues without fingerprints.
+ * Lines without a trailing `(rule)` are reported as `yamllint/syntax`.
+ * Returns [] on empty or non-matching input.
+ */
+export function parseYamllintOutput(
+  stdout: string,
+  projectDir: string
+): Omit<LintIssue, "fingerprint">[] {
+  const issues: Omit<LintIssue, "fingerprint">[] = [];
+  for (const line of stdout.split("\n")) {
+    const match = YAMLLINT_LINE_PATTERN.exec(line.trim());
+    if (!match) continue;
+    const [, filePath, lineStr, colStr, level, message, rule] = match;
+    if (!filePath || !lineStr || !colStr || !message) continue;
+
+    issues.push({
+      rule: `yamllint/${rule ?? "syntax"}`,
+      linter: "yamllint",
+      file: resolve(projectDir, filePath),
+      line: Number.parseInt(lineStr, 10),
+      col: Number.parseInt(colStr, 10),
+      message,
+      severity: level === "error" ? "error" : "warning",
+    });
+  }
+  return issues;
+}
+
+export const YAML_GLOB = "**/*.{yml,yaml}";
+// Hidden directories are skipped by `**`; CI config lives in one of them
+const HIDDEN_YAML_GLOBS = [".github/**/*.{yml,yaml}", ".gitlab/**/*.{yml,yaml}"];
+
+/**
+ * Find the YAML files to lint, skipping DEFAULT_IGNORE, `ignore_paths`
+ * (e.g. generated manifests) and Helm chart templates, which helm-template
+ * lints once rendered. When a changed-file list is given, select from it
+ * instead.
+ */
+export async function findYamlFiles(
+  fileManager: FileManager,
+  projectDir: string,
+  ignorePaths: readonly string[],
+  files?: readonly string[]
+): Promise<string[]> {
+  const ignore = [...DEFAULT_IGNORE, ...ignorePaths];
+  const charts = await findCharts(fileManager, projectDir, ignorePaths);
+  if (files !== undefined) {
+    return matchFiles(files, YAML_GLOB).filter(
+      (file) =>
+        !ignore.some((pattern) => minimatch(file, pattern, { dot: true })) &&
+        !isChartTemplate(file, charts)
+    );
+  }
+  const found = await Promise.all(
+    [YAML_GLOB, ...HIDDEN_YAML_GLOBS].map((pattern) =>
+      fileManager.glob(pattern, projectDir, ignore)
+    )
+  );
+  return [...new Set(found.flat())].filter((file) => !isChartTemplate(file, charts));
+}
+
+export const yamllintRunner: LinterRunner = {
+  id: "yamllint",
+  name: "yamllint",
+  configFile: ".yamllint.yaml",
+  fileScoped: true,
+  installHint: {
+    description: "YAML linter",
+    pip: "pip install yamllint",
+    brew: "brew install yamllint",
+    apt: "sudo apt install yamllint",
+  },
+  versionArgs: ["yamllint", "--version"],
+  cache: {
+    inputs: [YAML_GLOB, ...HIDDEN_YAML_GLOBS],
+  },
+
+  async isAvailable(commandRunner: CommandRunner): Promise<boolean> {
+    const result = await commandRunner.run(["yamllint", "--version"]);
+    return result.exitCode === 0;
+  },
+
+  async run({
+    projectDir,
+    config,
+    commandRunner,
+    fileManager,
+    files: changed,
+    batchSize,
+  }: RunOptions): Promise<LintIssue[]> {
+    const files = await findYamlFiles(
+      fileManager,
+      projectDir,
+      config.ignorePaths,
+      changed
+    );
+    if (files.length === 0) return [];
+
+    // yamllint picks up .yamllint.yaml from the working directory on its own
+    const raw = await mapShards(files, batchSize, async (shard) => {
+      const result = await commandRunner.run(["yamllint", "-f", "parsable", ...shard], {
+        cwd: projectDir,
+      });
+      // yamllint exits non-zero when issues are found — parse stdout regardless
+      return parseYamllintOutput(result.stdout, projectDir);
+    });
+    return applyFingerprints(raw, projectDir, fileManager);
+  },
+};
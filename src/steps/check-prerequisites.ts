@@ -16,7 +16,7 @@ export interface PrereqReport {
 }
 
 /** Collect all unique runners across plugins, keyed by id. */
-function uniqueRunners(plugins: readonly LanguagePlugin[]): LinterRunner[] {
+export function uniqueRunners(plugins: readonly LanguagePlugin[]): LinterRunner[] {
   const seen = new Set<string>();
   const runners: LinterRunner[] = [];
   for (const plugin of plugins) {
@@ -1,22 +1,183 @@
-import { relative } from "node:path";
+import { relative, resolve } from "node:path";
 import { minimatch } from "minimatch";
 import type { ResolvedConfig } from "@/config/schema";
+import {
+  configForPath,
+  failOnAt,
+  isRunnerEnabledAt,
+  PROFILE_DEFAULTS,
+  runnerTimeout,
+} from "@/config/schema";
 import type { CommandRunner } from "@/infra/command-runner";
+import { LimitedCommandRunner } from "@/infra/command-runner";
 import type { Console } from "@/infra/console";
 import type { FileManager } from "@/infra/file-manager";
+import { IgnoringFileManager } from "@/infra/ignoring-file-manager";
 import type { LanguagePlugin } from "@/languages/types";
 import { classifyFingerprint, loadBaselineFromFile } from "@/models/baseline";
-import type { LintIssue } from "@/models/lint-issue";
+import { checkExitCode, EXIT_RUNNER_ERROR, type ExitCode } from "@/models/exit-code";
+import type { LintIssue, Severity } from "@/models/lint-issue";
+import { meetsSeverity } from "@/models/lint-issue";
+import type { RunnerProgress, RunnerReport } from "@/models/runner-report";
 import type { StepResult } from "@/models/step-result";
 import { error, ok } from "@/models/step-result";
-import type { RunOptions } from "@/runners/types";
+import { isRunnerActive } from "@/runners/active";
+import type { LinterRunner, RunOptions } from "@/runners/types";
 import { filterAllowComments } from "@/steps/filter-allow-comments";
+import {
+  cancelledOutcome,
+  describeOutcome,
+  type RunnerOutcome,
+  runRunner,
+} from "@/steps/run-runner";
+import { createProgressEmitter, supersededRules } from "@/steps/runner-progress";
+import { dedupeIssues } from "@/utils/dedupe-issues";
+import { fingerprintIssue } from "@/utils/fingerprint";
+import { findGeneratedFiles } from "@/utils/generated-files";
+import type { PathMatcher } from "@/utils/ignore-file";
+import { defaultJobs, defaultMaxProcs, mapPool } from "@/utils/pool";
+import { type Tracer, traced } from "@/utils/trace";
 
 export interface CheckStepResult {
   result: StepResult;
   issues: LintIssue[];
   newIssueCount: number;
+  /** New issues at or above the `failOn` severity — these fail the check */
+  failingIssueCount: number;
+  /** Fingerprints of the issues suppressed by the baseline */
+  baselined: ReadonlySet<string>;
   skipped: number;
+  /** One entry per enabled runner, sorted by runner name */
+  runners: RunnerReport[];
+  /** How `check` exits for this result */
+  exitCode: ExitCode;
+}
+
+export interface CheckStepOptions {
+  /** Max runners in flight at once (default: CPU count) */
+  jobs?: number;
+  /**
+   * Max tool processes at once across every runner and shard, whatever
+   * `jobs` is (default: defaultMaxProcs() — GOMAXPROCS, else CPU count)
+   */
+  maxProcs?: number;
+  /** Reuse cached results for unchanged cacheable runners (default: false) */
+  useCache?: boolean;
+  /** Restrict file-oriented runners to these project-relative paths */
+  files?: readonly string[];
+  /** Run only runners that honour `files`, dropping whole-project ones */
+  fileScopedOnly?: boolean;
+  /** Project-relative module dir; only module-scoped runners run, on it alone */
+  module?: string;
+  /** Project-relative subtrees (`check --path`), passed on to module-scoped runners */
+  paths?: readonly string[];
+  /** Baseline file relative to projectDir (default: BASELINE_PATH) */
+  baselinePath?: string;
+  /**
+   * Lowest severity of a new issue that fails the check (default: the
+   * profile's, from the config nearest each file — see failOnAt)
+   */
+  failOn?: Severity;
+  /**
+   * Seconds each runner may take before it is killed and reported as failed
+   * (default: DEFAULT_RUNNER_TIMEOUT_S). A [runners.<id>] timeout wins.
+   */
+  timeout?: number;
+  /**
+   * Paths from .gitignore/.guardrailsignore. Hidden from runners that find
+   * their own files, dropped from `files`, and filtered out of findings.
+   */
+  ignore?: PathMatcher;
+  /**
+   * Also check files marked `// Code generated ... DO NOT EDIT.` (default:
+   * false — they are treated like ignored paths).
+   */
+  includeGenerated?: boolean;
+  /** Also check external links (see RunOptions.checkExternal) */
+  checkExternal?: boolean;
+  /**
+   * Most files per tool invocation; runners that pass files in argv run once
+   * per shard (default: DEFAULT_BATCH_SIZE)
+   */
+  batchSize?: number;
+  /**
+   * Stop at the first runner that fails the check — a new finding at or above
+   * `failOn`, or a runner error — killing in-flight runners and never starting
+   * queued ones; both are reported as "cancelled" (default: false).
+   */
+  failFast?: boolean;
+  /**
+   * Called with each runner's filtered result as soon as it is final. A
+   * result whose rules another runner may supersede waits for that runner.
+   */
+  onRunnerDone?: (progress: RunnerProgress) => void;
+  /**
+   * Runners whose missing tool fails the check instead of being skipped
+   * (`check --require`), or "all" of them (`--require-all`)
+   */
+  requireTools?: ReadonlySet<string> | "all";
+  /**
+   * Temp copies standing in for project files (`check --stdin`), as
+   * project-relative copy path → the path it stands for. Ignore rules see the
+   * real path, and the copy's findings are reported under it.
+   */
+  standIns?: ReadonlyMap<string, string>;
+  /**
+   * Collapse findings several runners report for the same problem into one
+   * listing them all (default: true; `check --no-dedup` turns it off)
+   */
+  dedup?: boolean;
+  /**
+   * Log each missing tool's "not found — skipping" line at verbose level
+   * instead of as a warning (`check --quiet-skips`); the closing count of
+   * skipped runners is still printed (default: false)
+   */
+  quietSkips?: boolean;
+  /** Records the step's phases and each runner as spans (`check --trace`) */
+  tracer?: Tracer;
+}
+
+export const DEFAULT_RUNNER_TIMEOUT_S = 120;
+
+/**
+ * Move findings in stand-in copies onto the files they stand for, with
+ * fingerprints recomputed for the real path so the baseline still matches.
+ */
+async function restoreStandIns(
+  issues: readonly LintIssue[],
+  standIns: ReadonlyMap<string, string>,
+  projectDir: string,
+  fileManager: FileManager
+): Promise<LintIssue[]> {
+  return Promise.all(
+    issues.map(async (issue) => {
+      const real = standIns.get(relative(projectDir, issue.file));
+      if (real === undefined) return issue;
+      const lines = (await fileManager.readText(issue.file)).split("\n");
+      const moved = { ...issue, file: resolve(projectDir, real) };
+      const fingerprint = fingerprintIssue({ ...moved, file: real }, lines);
+      return { ...moved, fingerprint };
+    })
+  );
+}
+
+/**
+ * Extend `ignore` with the Go files marked as generated, so they are hidden
+ * from runners and their findings dropped like those in ignored paths.
+ */
+async function withGeneratedFiles(
+  projectDir: string,
+  fileManager: FileManager,
+  config: ResolvedConfig,
+  ignore: PathMatcher | undefined,
+  cons?: Console
+): Promise<PathMatcher | undefined> {
+  const generated = new Set(
+    await findGeneratedFiles(fileManager, projectDir, config.ignorePaths)
+  );
+  if (generated.size === 0) return ignore;
+  cons?.verbose(`Skipping ${generated.size} generated file(s)`);
+  return (relPath) => generated.has(relPath) || ignore?.(relPath) === true;
 }
 
 export async function checkStep(
@@ -25,73 +186,256 @@ export async function checkStep(
   config: ResolvedConfig,
   commandRunner: CommandRunner,
   fileManager: FileManager,
-  cons?: Console
+  cons?: Console,
+  options: CheckStepOptions = {}
 ): Promise<CheckStepResult> {
+  const {
+    jobs = defaultJobs(),
+    maxProcs = defaultMaxProcs(),
+    timeout = DEFAULT_RUNNER_TIMEOUT_S,
+    includeGenerated = false,
+    failFast = false,
+    dedup = true,
+  } = options;
+  const { module, standIns, requireTools, tracer } = options;
   try {
-    const opts: RunOptions = { projectDir, config, commandRunner, fileManager };
-
-    let skipped = 0;
-    const runnerResults = await Promise.all(
-      languages.flatMap((plugin) =>
-        plugin.runners().map(async (runner) => {
-          const available = await runner.isAvailable(commandRunner, projectDir);
-          if (!available) {
-            cons?.warning(
-              `  ${runner.name} not found — skipping (${runner.installHint.description})`
-            );
-            skipped++;
-            const empty: LintIssue[] = [];
-            return empty;
-          }
-          return runner.run(opts);
-        })
-      )
+    const matcher = includeGenerated
+      ? options.ignore
+      : await traced(tracer, "find-generated-files", () =>
+          withGeneratedFiles(projectDir, fileManager, config, options.ignore, cons)
+        );
+    const ignore: PathMatcher | undefined =
+      matcher !== undefined && standIns !== undefined
+        ? (relPath) => matcher(standIns.get(relPath) ?? relPath)
+        : matcher;
+    const files =
+      ignore !== undefined
+        ? options.files?.filter((file) => !ignore(file))
+        : options.files;
+    // Cached results cover the whole project, so a subset bypasses the cache
+    const useCache =
+      options.useCache === true && files === undefined && module === undefined;
+    const controller = new AbortController();
+    const opts: RunOptions = {
+      projectDir,
+      config,
+      commandRunner: new LimitedCommandRunner(commandRunner, maxProcs),
+      fileManager:
+        ignore !== undefined
+          ? new IgnoringFileManager(fileManager, projectDir, ignore)
+          : fileManager,
+      ...(files !== undefined && { files }),
+      ...(module !== undefined && { module }),
+      ...(options.paths !== undefined && { paths: options.paths }),
+      ...(includeGenerated && { includeGenerated }),
+      ...(options.checkExternal === true && { checkExternal: true }),
+      ...(options.batchSize !== undefined && { batchSize: options.batchSize }),
+      ...(failFast && { signal: controller.signal }),
+    };
+
+    const candidates = languages.flatMap((plugin) =>
+      plugin
+        .runners()
+        .filter(
+          (runner) => options.fileScopedOnly !== true || runner.fileScoped === true
+        )
+        .filter((runner) => module === undefined || runner.moduleScoped === true)
+    );
+    // Config, and each runner's appliesTo, decide which candidates run
+    const active = await traced(tracer, "select-runners", () =>
+      Promise.all(candidates.map((runner) => isRunnerActive(runner, opts)))
+    );
+    // Superseding runners go first, so with --jobs 1 no result waits on a later one
+    const supersedesOthers = (runner: LinterRunner) =>
+      Number(runner.supersedes !== undefined);
+    const enabled = candidates
+      .filter((_, i) => active[i] === true)
+      .toSorted((a, b) => supersedesOthers(b) - supersedesOthers(a));
+    // Disabled runners are still reported so they are not mistaken for missing tools
+    const disabled = candidates.filter((_, i) => active[i] !== true);
+    // Only the ones turned off in config or with --disable are worth a line
+    for (const runner of disabled) {
+      if (config.runners?.[runner.id]?.enabled !== false) continue;
+      cons?.note(`  ${runner.name} (disabled)`);
+    }
+
+    const baseline =
+      (await loadBaselineFromFile(projectDir, fileManager, options.baselinePath)) ??
+      new Map();
+
+    // Allow comments in a stand-in's findings are read from the copy
+    const copies = new Map(
+      [...(standIns ?? [])].map(([copy, real]) => [
+        resolve(projectDir, real),
+        resolve(projectDir, copy),
+      ])
+    );
+    const sources = {
+      readText: (path: string) => fileManager.readText(copies.get(path) ?? path),
+    };
+
+    // The config nearest a finding's file decides whether it is kept
+    const keep = (runner: LinterRunner) => (issue: LintIssue) => {
+      const relPath = relative(projectDir, issue.file);
+      const scoped = configForPath(config, relPath);
+      if (scoped.isAllowed(issue.rule, relPath)) return false;
+      if (!isRunnerEnabledAt(config, runner.id, relPath)) return false;
+      // Whole-project runners scan ignored paths themselves; drop what they find
+      if (ignore?.(relPath) === true) return false;
+      return !scoped.ignorePaths.some((pattern) =>
+        minimatch(relPath, pattern, { dot: true })
+      );
+    };
+    const failOnFor = (issue: LintIssue) =>
+      options.failOn ?? failOnAt(config, relative(projectDir, issue.file));
+
+    const { onRunnerDone } = options;
+    const emit =
+      onRunnerDone !== undefined
+        ? createProgressEmitter(enabled, baseline, onRunnerDone, dedup)
+        : undefined;
+    // A streamed result prints its own status line instead of the progress ones
+    const progressCons = emit !== undefined ? undefined : cons;
+
+    // --fail-fast: a runner that fails the check stops the rest
+    const failsCheck = ({ report }: RunnerOutcome, issues: readonly LintIssue[]) =>
+      report.status === "error" ||
+      issues.some(
+        (issue) =>
+          classifyFingerprint(issue.fingerprint, baseline) === "new" &&
+          meetsSeverity(issue.severity, failOnFor(issue))
+      );
+
+    const runOne = async (runner: LinterRunner): Promise<RunnerOutcome> => {
+      if (controller.signal.aborted) {
+        const cancelled = cancelledOutcome(runner);
+        emit?.({ runner, ...cancelled });
+        return cancelled;
+      }
+      const limit = runnerTimeout(config, runner.id, timeout);
+      // Runners overlap, so each gets its own lane in the trace
+      const traceOpts = { cat: "runner", lane: runner.id };
+      const outcome = await traced(
+        tracer,
+        runner.id,
+        () =>
+          runRunner({
+            runner,
+            runOpts: opts,
+            useCache,
+            timeoutS: limit,
+            required: requireTools === "all" || requireTools?.has(runner.id) === true,
+            ...(progressCons !== undefined && { cons: progressCons }),
+            ...(cons !== undefined && { verboseCons: cons }),
+            quietSkips: options.quietSkips === true,
+          }),
+        traceOpts
+      );
+      cons?.verbose(describeOutcome(outcome));
+      const found =
+        standIns !== undefined
+          ? await restoreStandIns(outcome.issues, standIns, projectDir, fileManager)
+          : outcome.issues;
+      // Filter by inline suppression comments
+      const kept = found.filter(keep(runner));
+      const issues = await traced(
+        tracer,
+        "allow-comments",
+        () => filterAllowComments(kept, sources, projectDir),
+        traceOpts
+      );
+      if (failFast && !controller.signal.aborted && failsCheck(outcome, issues)) {
+        cons?.verbose(`${runner.name} failed the check — cancelling the rest`);
+        controller.abort();
+      }
+      emit?.({ runner, report: outcome.report, issues });
+      return { ...outcome, issues };
+    };
+    // Prerequisites such as a build finish before the rest start
+    const first = enabled.filter((runner) => runner.runsFirst === true);
+    const rest = enabled.filter((runner) => runner.runsFirst !== true);
+    const outcomes = [
+      ...(await mapPool(first, jobs, runOne)),
+      ...(await mapPool(rest, jobs, runOne)),
+    ];
+    // Completion order varies with jobs; sort so reports are deterministic
+    const runnerResults = outcomes.toSorted((a, b) =>
+      a.report.name.localeCompare(b.report.name)
     );
 
+    const disabledReports = disabled.map(
+      (runner): RunnerReport => ({
+        runnerId: runner.id,
+        name: runner.name,
+        status: "disabled",
+        durationMs: 0,
+      })
+    );
+    const runners = [
+      ...runnerResults.map((r) => r.report),
+      ...disabledReports,
+    ].toSorted((a, b) => a.name.localeCompare(b.name));
+    const skipped = runners.filter((r) => r.status === "skipped").length;
     if (skipped > 0) {
       cons?.warning(
         `${skipped} runner(s) skipped — run \`ai-guardrails init\` to install missing tools`
       );
     }
 
-    const allIssues = runnerResults.flat();
-    const filtered = allIssues.filter((issue) => {
-      if (config.isAllowed(issue.rule, issue.file)) return false;
-      if (config.ignorePaths.length > 0) {
-        const relPath = relative(projectDir, issue.file);
-        if (
-          config.ignorePaths.some((pattern) =>
-            minimatch(relPath, pattern, { dot: true })
-          )
-        ) {
-          return false;
-        }
-      }
-      return true;
-    });
-
-    // Filter by inline allow comments
-    const afterAllow = await filterAllowComments(filtered, fileManager);
-
-    const baseline = (await loadBaselineFromFile(projectDir, fileManager)) ?? new Map();
+    const superseded = supersededRules(enabled, runners);
+    const kept = runnerResults
+      .flatMap((r) => r.issues)
+      .filter((issue) => !superseded.has(issue.rule));
+    const afterAllow = dedup ? dedupeIssues(kept) : kept;
 
     const newIssues = afterAllow.filter(
       (issue) => classifyFingerprint(issue.fingerprint, baseline) === "new"
     );
+    const baselined = new Set(
+      afterAllow
+        .map((issue) => issue.fingerprint)
+        .filter((fp) => classifyFingerprint(fp, baseline) === "existing")
+    );
     const baselinedCount = afterAllow.length - newIssues.length;
+    const failing = newIssues.filter((issue) =>
+      meetsSeverity(issue.severity, failOnFor(issue))
+    );
+    // With nested profiles and no --fail-on, each file has its own threshold
+    const failOn =
+      options.failOn ??
+      (config.scopes === undefined
+        ? PROFILE_DEFAULTS[config.profile].failOn
+        : "their directory's fail-on level");
 
-    const msg =
+    const baselinedNote = baselinedCount > 0 ? ` (${baselinedCount} baselined)` : "";
+    const failingNote =
+      failing.length < newIssues.length
+        ? `, ${failing.length} at or above ${failOn}`
+        : "";
+    const issueMsg =
       newIssues.length === 0
         ? baselinedCount > 0
-          ? `No new issues (${baselinedCount} baselined)`
+          ? `No new issues${baselinedNote}`
           : "No issues found"
-        : `Found ${newIssues.length} new issue(s)${baselinedCount > 0 ? ` (${baselinedCount} baselined)` : ""}`;
+        : `Found ${newIssues.length} new issue(s)${baselinedNote}${failingNote}`;
+    const failed = runners.filter((r) => r.status === "error");
+    const failedNames = failed.map((r) => r.name).join(", ");
+    const failedNote =
+      failed.length > 0 ? `; ${failed.length} runner(s) failed: ${failedNames}` : "";
+    const cancelled = runners.filter((r) => r.status === "cancelled").length;
+    const cancelledNote =
+      cancelled > 0 ? `; stopped early, ${cancelled} runner(s) cancelled` : "";
+    const msg = `${issueMsg}${failedNote}${cancelledNote}`;
 
     return {
-      result: newIssues.length > 0 ? error(msg) : ok(msg),
+      result: failing.length > 0 || failed.length > 0 ? error(msg) : ok(msg),
       issues: afterAllow,
       newIssueCount: newIssues.length,
+      failingIssueCount: failing.length,
+      baselined,
       skipped,
+      runners,
+      exitCode: checkExitCode(failing.length, runners),
     };
   } catch (err) {
     const message = err instanceof Error ? err.message : String(err);
@@ -99,7 +443,11 @@ export async function checkStep(
       result: error(`Check failed: ${message}`),
       issues: [],
       newIssueCount: 0,
+      failingIssueCount: 0,
+      baselined: new Set(),
       skipped: 0,
+      runners: [],
+      exitCode: EXIT_RUNNER_ERROR,
     };
   }
 }
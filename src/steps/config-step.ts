@@ -0,0 +1,558 @@
+import { resolve } from "node:path";
+import { makeRe } from "minimatch";
+import { stringify as stringifyToml } from "smol-toml";
+import {
+  type ConfigFormat,
+  configFormatOf,
+  configKeys,
+  findProjectConfig,
+  parseConfigText,
+} from "@/config/config-file";
+import type {
+  ConfigScope,
+  FileClassification,
+  NestedConfig,
+  ProjectConfig,
+  ResolvedConfig,
+} from "@/config/schema";
+import {
+  classificationFor,
+  configForPath,
+  HooksConfigSchema,
+  isRunnerEnabled,
+  isRunnerEnabledAt,
+  profileEnables,
+  ProjectConfigSchema,
+  RetryConfigSchema,
+  RunnerConfigSchema,
+  runnerTimeout,
+  scopesFor,
+  withRunnerOverrides,
+} from "@/config/schema";
+import type { FileManager } from "@/infra/file-manager";
+import { knownRunnerIds } from "@/languages/registry";
+import { PROJECT_CONFIG_PATH } from "@/models/paths";
+import type { StepResult } from "@/models/step-result";
+import { error, ok } from "@/models/step-result";
+import type { LinterRunner } from "@/runners/types";
+import { DEFAULT_RUNNER_TIMEOUT_S } from "@/steps/check-step";
+import { isPlainObject } from "@/utils/deep-merge";
+
+type KeyPath = readonly (string | number)[];
+
+/** A problem before it is located in the file */
+type Finding = [path: KeyPath, message: string];
+
+export interface ConfigProblem {
+  /** e.g. "config.line_length" or "custom_runners[0].pattern"; "" for syntax */
+  key: string;
+  message: string;
+  /** 1-based line in the config file, when it could be located */
+  line?: number;
+  /** The config file, when it is not .ai-guardrails/config.toml */
+  file?: string;
+}
+
+export interface ValidateConfigStepResult {
+  result: StepResult;
+  problems: ConfigProblem[];
+}
+
+/** e.g. ["custom_runners", 0, "pattern"] → "custom_runners[0].pattern" */
+function formatKeyPath(path: KeyPath): string {
+  return path
+    .map((part, i) => {
+      if (typeof part === "number") return `[${part}]`;
+      return i > 0 ? `.${part}` : part;
+    })
+    .join("");
+}
+
+interface TableHeader {
+  /** Key path of the table, with the index of an array-of-tables entry */
+  path: string;
+  /** 0-based line index */
+  index: number;
+}
+
+const TABLE_HEADER = /^\s*(\[\[?)\s*([^\]]+?)\s*\]\]?\s*(?:#.*)?$/;
+
+function unquote(part: string): string {
+  return part.trim().replace(/^(["'])(.*)\1$/, "$2");
+}
+
+function tableHeaders(lines: readonly string[]): TableHeader[] {
+  const counts = new Map<string, number>();
+  const headers: TableHeader[] = [];
+  lines.forEach((line, index) => {
+    const match = TABLE_HEADER.exec(line);
+    const [, bracket, name] = match ?? [];
+    if (bracket === undefined || name === undefined) return;
+    const parts = name.split(".").map(unquote);
+    if (bracket !== "[[") {
+      headers.push({ path: formatKeyPath(parts), index });
+      return;
+    }
+    const table = formatKeyPath(parts);
+    const n = counts.get(table) ?? 0;
+    counts.set(table, n + 1);
+    headers.push({ path: `${table}[${n}]`, index });
+  });
+  return headers;
+}
+
+function escapeRegExp(text: string): string {
+  return text.replace(/[.*+?^${}()|[\]\\]/g, "\\$&");
+}
+
+/**
+ * Best-effort 1-based line of `path` in TOML text: the `key =` line inside
+ * the deepest table header on the path, else that header's line.
+ */
+export function locateTomlKey(text: string, path: KeyPath): number | undefined {
+  const lines = text.split("\n");
+  const headers = tableHeaders(lines);
+  for (let depth = path.length; depth >= 0; depth--) {
+    const prefix = formatKeyPath(path.slice(0, depth));
+    const header = depth > 0 ? headers.find((h) => h.path === prefix) : undefined;
+    if (depth > 0 && header === undefined) continue;
+
+    const start = header !== undefined ? header.index + 1 : 0;
+    const next = headers.find((h) => h.index >= start);
+    const end = next?.index ?? lines.length;
+    const key = path[depth];
+    if (typeof key === "string") {
+      const assignment = new RegExp(`^\\s*(["']?)${escapeRegExp(key)}\\1\\s*=`);
+      for (let i = start; i < end; i++) {
+        if (assignment.test(lines[i] ?? "")) return i + 1;
+      }
+    }
+    if (header !== undefined) return header.index + 1;
+  }
+  return undefined;
+}
+
+interface YamlLine {
+  /** 0-based line index */
+  index: number;
+  indent: number;
+  /** A `- ` sequence entry */
+  item: boolean;
+  /** The mapping key the line opens, and its column */
+  key?: string;
+  keyIndent: number;
+}
+
+const YAML_LINE = /^(\s*)(-\s+)?(?:(["']?)([^"'#\s][^"'#]*?)\3\s*:(?:\s|$))?/;
+
+function yamlLines(text: string): YamlLine[] {
+  return text.split("\n").flatMap((line, index): YamlLine[] => {
+    if (/^\s*(?:#.*)?$/.test(line) || /^(?:---|\.\.\.)\s*$/.test(line)) return [];
+    const [, lead = "", dash, , key] = YAML_LINE.exec(line) ?? [];
+    const keyIndent = lead.length + (dash?.length ?? 0);
+    return [
+      {
+        index,
+        indent: lead.length,
+        item: dash !== undefined,
+        keyIndent,
+        ...(key !== undefined && { key }),
+      },
+    ];
+  });
+}
+
+/**
+ * Best-effort 1-based line of `path` in block-style YAML: each key looked up
+ * among the lines nested under the one before, each index counted by `- `
+ * entries; the deepest part found when the rest is not. Flow style (`{}`,
+ * `[]`) is not looked into.
+ */
+export function locateYamlKey(text: string, path: KeyPath): number | undefined {
+  let scope = yamlLines(text);
+  let line: number | undefined;
+  for (const part of path) {
+    const hit = typeof part === "number" ? yamlItem(scope, part) : yamlKey(scope, part);
+    if (hit === undefined) return line;
+    line = hit.index + 1;
+    // A sequence may sit at its key's own indent
+    const within = ({ indent, item }: YamlLine): boolean =>
+      typeof part === "number"
+        ? indent > hit.indent
+        : indent > hit.keyIndent || (item && indent === hit.keyIndent);
+    const rest = scope.slice(scope.indexOf(hit) + 1);
+    const end = rest.findIndex((entry) => !within(entry));
+    const nested = end === -1 ? rest : rest.slice(0, end);
+    // A `- key: value` entry's own line holds its first key
+    const opens = typeof part === "number" && hit.key !== undefined;
+    scope = opens ? [{ ...hit, item: false }, ...nested] : nested;
+  }
+  return line;
+}
+
+/** The `n`th sequence entry among the outermost ones of `scope` */
+function yamlItem(scope: readonly YamlLine[], n: number): YamlLine | undefined {
+  const indent = scope.find((entry) => entry.item)?.indent;
+  return scope.filter((entry) => entry.item && entry.indent === indent)[n];
+}
+
+/** The line opening `key` among the outermost keys of `scope` */
+function yamlKey(scope: readonly YamlLine[], key: string): YamlLine | undefined {
+  const indent = scope.find((entry) => entry.key !== undefined)?.keyIndent;
+  return scope.find((entry) => entry.key === key && entry.keyIndent === indent);
+}
+
+/** Keys of `value` that `shape` does not declare (zod drops them silently) */
+function unknownKeys(value: unknown, shape: object, path: KeyPath): KeyPath[] {
+  if (!isPlainObject(value)) return [];
+  return Object.keys(value)
+    .filter((key) => !Object.hasOwn(shape, key))
+    .map((key) => [...path, key]);
+}
+
+/** Why a project-relative glob cannot match as intended, or null if it can */
+export function checkGlob(glob: string): string | null {
+  if (makeRe(glob) === false) return "invalid glob pattern";
+  if (glob.startsWith("/") || glob.startsWith("./")) {
+    return "globs are relative to the project root — drop the leading / or ./";
+  }
+  for (const [open, close] of [
+    ["[", "]"],
+    ["{", "}"],
+  ] as const) {
+    if (glob.split(open).length !== glob.split(close).length) {
+      return `unbalanced ${open}${close} — it would be matched literally`;
+    }
+  }
+  return null;
+}
+
+/** Problems beyond the schema: unknown runner ids and globs that never match */
+function semanticProblems(project: ProjectConfig): Finding[] {
+  const builtIn = knownRunnerIds();
+  const custom = project.custom_runners;
+  const known = new Set([...builtIn, ...custom.map((spec) => spec.id)]);
+  const problems: Finding[] = [];
+
+  for (const id of Object.keys(project.runners)) {
+    if (known.has(id)) continue;
+    const hint = "see `ai-guardrails list`";
+    problems.push([["runners", id], `unknown runner "${id}" — ${hint}`]);
+  }
+  custom.forEach((spec, i) => {
+    if (!builtIn.has(spec.id)) return;
+    const message = `"${spec.id}" is a built-in runner id`;
+    problems.push([["custom_runners", i, "id"], message]);
+  });
+
+  const globs: Finding[] = [
+    ...project.ignore_paths.map((glob, i): Finding => [["ignore_paths", i], glob]),
+    ...project.allow.map((entry, i): Finding => [["allow", i, "glob"], entry.glob]),
+    ...custom.flatMap((spec, i) =>
+      spec.files.map((glob, j): Finding => [["custom_runners", i, "files", j], glob])
+    ),
+  ];
+  for (const [path, glob] of globs) {
+    const problem = checkGlob(glob);
+    if (problem !== null) problems.push([path, `${problem}: "${glob}"`]);
+  }
+  return problems;
+}
+
+function syntaxLine(err: unknown): number | undefined {
+  // smol-toml's TomlError carries the position of the parse failure
+  if (typeof err !== "object" || err === null || !("line" in err)) return undefined;
+  return typeof err.line === "number" ? err.line : undefined;
+}
+
+/**
+ * Every problem in the text of a project config: syntax, keys the schema does
+ * not know (silently ignored when loading), schema violations such as
+ * out-of-range values, unknown runner ids, and globs that cannot match.
+ * Problems are located by line, in YAML on a best-effort basis.
+ */
+export function findConfigProblems(
+  text: string,
+  format: ConfigFormat = "toml"
+): ConfigProblem[] {
+  let raw: Record<string, unknown>;
+  try {
+    raw = parseConfigText(text, format);
+  } catch (err) {
+    const detail = err instanceof Error ? err.message.split("\n")[0] : undefined;
+    const line = syntaxLine(err);
+    const message = `invalid ${format.toUpperCase()}: ${detail ?? String(err)}`;
+    return [{ key: "", message, ...(line !== undefined && { line }) }];
+  }
+
+  const located = ([path, message]: Finding): ConfigProblem => {
+    const line =
+      format === "toml" ? locateTomlKey(text, path) : locateYamlKey(text, path);
+    return { key: formatKeyPath(path), message, ...(line !== undefined && { line }) };
+  };
+
+  const runnerTables = isPlainObject(raw.runners) ? Object.entries(raw.runners) : [];
+  const unknown = [
+    ...unknownKeys(raw, ProjectConfigSchema.shape, []),
+    ...unknownKeys(raw.hooks, HooksConfigSchema.shape, ["hooks"]),
+    ...runnerTables.flatMap(([id, table]) => [
+      ...unknownKeys(table, RunnerConfigSchema.shape, ["runners", id]),
+      ...(isPlainObject(table)
+        ? unknownKeys(table.retry, RetryConfigSchema.shape, ["runners", id, "retry"])
+        : []),
+    ]),
+  ].map((path): Finding => [path, "unknown key — it is ignored"]);
+
+  const parsed = ProjectConfigSchema.safeParse(raw);
+  const invalid = parsed.success
+    ? semanticProblems(parsed.data)
+    : parsed.error.issues.map((issue): Finding => [issue.path, issue.message]);
+
+  return [...unknown, ...invalid].map(located);
+}
+
+/** e.g. ".ai-guardrails/config.toml:12: config.line_length: Number must be …" */
+export function formatConfigProblem(problem: ConfigProblem): string {
+  const file = problem.file ?? PROJECT_CONFIG_PATH;
+  const where = problem.line !== undefined ? `${file}:${problem.line}` : file;
+  const key = problem.key !== "" ? ` ${problem.key}:` : "";
+  return `${where}:${key} ${problem.message}`;
+}
+
+/**
+ * Check the project's config, TOML or YAML; a missing file is fine. With
+ * `configPath` (`--config`), that file is checked instead and must exist.
+ */
+export async function validateConfigStep(
+  projectDir: string,
+  fileManager: FileManager,
+  configPath?: string
+): Promise<ValidateConfigStepResult> {
+  try {
+    if (configPath !== undefined && !(await fileManager.exists(configPath))) {
+      throw new Error(`--config ${configPath} does not exist`);
+    }
+    const file = configPath ?? (await findProjectConfig(projectDir, fileManager));
+    if (file === undefined) {
+      return {
+        result: ok(`No ${PROJECT_CONFIG_PATH} — defaults apply`),
+        problems: [],
+      };
+    }
+    const format = configFormatOf(file);
+    const text = await fileManager.readText(resolve(projectDir, file));
+    const problems = findConfigProblems(text, format).map((problem) =>
+      file !== PROJECT_CONFIG_PATH ? { ...problem, file } : problem
+    );
+    if (problems.length > 0) {
+      return {
+        result: error(`${file} has ${problems.length} problem(s)`),
+        problems,
+      };
+    }
+    return { result: ok(`${file} is valid`), problems: [] };
+  } catch (err) {
+    const message = err instanceof Error ? err.message : String(err);
+    return { result: error(`Config validation failed: ${message}`), problems: [] };
+  }
+}
+
+export interface RunnerOverrides {
+  enable: readonly string[];
+  disable: readonly string[];
+  /** Where `disable` came from; defaults to --disable */
+  disableSource?: string;
+}
+
+function nestedConfigPath(scope: ConfigScope): string {
+  return `${scope.dir}/${PROJECT_CONFIG_PATH}`;
+}
+
+const MACHINE_CONFIG = "~/.ai-guardrails/config.toml";
+
+/** The project config's file: the one read, else `.ai-guardrails/config.toml` */
+function projectFile(config: ResolvedConfig): string {
+  return config.written?.project?.path ?? config.configPath ?? PROJECT_CONFIG_PATH;
+}
+
+/** Lists each layer adds to rather than replaces */
+const MERGED_KEYS: ReadonlySet<string> = new Set(["ignore", "allow", "ignore_paths"]);
+
+/** Layers that set each effective key path, lowest first */
+type ConfigSources = Map<string, string[]>;
+
+function setSources(
+  sources: ConfigSources,
+  layer: string,
+  keys: Iterable<string>
+): void {
+  for (const key of keys) {
+    const below = MERGED_KEYS.has(key) ? (sources.get(key) ?? []) : [];
+    sources.set(key, [...below.filter((l) => l !== layer), layer]);
+  }
+}
+
+/** The keys a nested config or classification table sets */
+function nestedKeys(project: NestedConfig): string[] {
+  const { ignore, allow, ignore_paths } = project;
+  return [
+    ...(project.profile !== undefined ? ["profile"] : []),
+    ...Object.entries({ ignore, allow, ignore_paths })
+      .filter(([, list]) => list.length > 0)
+      .map(([key]) => key),
+    ...Object.entries(project.runners)
+      .filter(([, runner]) => runner.enabled !== undefined)
+      .map(([id]) => `runners.${id}.enabled`),
+  ];
+}
+
+/**
+ * The project's [runners.<id>] keys, when the loader did not record what its
+ * file wrote: those runner keys the global config did not set
+ */
+function projectRunnerKeys(config: ResolvedConfig): string[] {
+  return Object.entries(config.runners ?? {})
+    .flatMap(([id, runner]) => Object.keys(runner).map((key) => `runners.${id}.${key}`))
+    .filter((key) => config.global?.applied.includes(key) !== true);
+}
+
+/**
+ * The layers that set each effective key path at a path under `chain` and
+ * `classification`: machine, global and project config, then the nested
+ * configs outermost first, then the classification
+ */
+function configSources(
+  config: ResolvedConfig,
+  chain: readonly ConfigScope[],
+  classification: FileClassification | undefined
+): ConfigSources {
+  const sources: ConfigSources = new Map();
+  setSources(sources, MACHINE_CONFIG, config.written?.machine?.keys ?? []);
+  if (config.global !== undefined) {
+    setSources(sources, config.global.path, configKeys(config.global.config));
+  }
+  const project = config.written?.project?.keys ?? projectRunnerKeys(config);
+  setSources(sources, projectFile(config), project);
+  for (const scope of chain.toReversed()) {
+    setSources(sources, nestedConfigPath(scope), nestedKeys(scope.project));
+  }
+  if (classification !== undefined) {
+    const layer = `[classifications.${classification.name}]`;
+    setSources(sources, layer, nestedKeys(classification.project));
+  }
+  return sources;
+}
+
+/** Where `key` (or the table holding it) was set, or undefined for a default */
+function sourceOf(sources: ConfigSources, key: string): string | undefined {
+  const parts = key.split(".");
+  for (let depth = parts.length; depth > 0; depth--) {
+    const layers = sources.get(parts.slice(0, depth).join("."));
+    if (layers !== undefined) return layers.join(" + ");
+  }
+  return undefined;
+}
+
+/** Comment each `key = value` line of TOML text with the layer that set it */
+function annotateSources(toml: string, sources: ConfigSources): string[] {
+  let table = "";
+  return toml.split("\n").map((line) => {
+    const [, , name] = TABLE_HEADER.exec(line) ?? [];
+    if (name !== undefined) {
+      table = name.split(".").map(unquote).join(".");
+      return line;
+    }
+    const [, key] = /^\s*("[^"]*"|'[^']*'|[\w-]+)\s*=/.exec(line) ?? [];
+    if (key === undefined) return line;
+    const path = table !== "" ? `${table}.${unquote(key)}` : unquote(key);
+    return `${line}  # ${sourceOf(sources, path) ?? "default"}`;
+  });
+}
+
+function enabledSource(
+  config: ResolvedConfig,
+  runnerId: string,
+  overrides: RunnerOverrides,
+  sources: ConfigSources
+): string {
+  if (overrides.disable.includes(runnerId)) {
+    return overrides.disableSource ?? "--disable";
+  }
+  if (overrides.enable.includes(runnerId)) return "--enable";
+  const source = sourceOf(sources, `runners.${runnerId}.enabled`);
+  if (source !== undefined) return source;
+  if (profileEnables(config, runnerId)) return `profile ${config.profile}`;
+  return "default";
+}
+
+/**
+ * The effective config as TOML: defaults, then ~/.ai-guardrails/config.toml,
+ * then the global config, then the project file, then GUARDRAILS_DISABLE, then
+ * `--enable/--disable`.
+ * Every value is commented with the layer that set it ("default" when none
+ * did; each layer adding to a list such as `ignore_paths`), and each runner
+ * of `runners` gets a table with its resolved `enabled` and `timeout`,
+ * commented the same way. One string per line.
+ * With a project-relative `path`, the nested configs above it apply too, then
+ * its file classification.
+ */
+export function formatEffectiveConfig(
+  config: ResolvedConfig,
+  runners: readonly LinterRunner[],
+  overrides: RunnerOverrides,
+  path = ""
+): string[] {
+  const root = withRunnerOverrides(config, overrides.enable, overrides.disable);
+  const effective = configForPath(root, path);
+  const chain = scopesFor(root, path);
+  const classification = path !== "" ? classificationFor(root, path) : undefined;
+  const settings = {
+    profile: effective.profile,
+    ...(effective.minVersion !== undefined && { min_version: effective.minVersion }),
+    ignore_paths: [...effective.ignorePaths],
+    config: effective.values,
+    ...(effective.ignore.length > 0 && { ignore: [...effective.ignore] }),
+    ...(effective.allow.length > 0 && { allow: [...effective.allow] }),
+    ...(effective.hooks !== undefined && { hooks: effective.hooks }),
+    ...(effective.customRunners !== undefined &&
+      effective.customRunners.length > 0 && {
+        custom_runners: [...effective.customRunners],
+      }),
+    ...(effective.postRun !== undefined && { post_run: effective.postRun }),
+  };
+
+  const sources = configSources(config, chain, classification);
+  const runnerLines = runners.flatMap((runner) => {
+    // Runners run project-wide; a nested config can only drop their findings
+    const enabled =
+      isRunnerEnabled(root, runner.id, runner.defaultEnabled) &&
+      isRunnerEnabledAt(root, runner.id, path);
+    const timeout = runnerTimeout(root, runner.id, DEFAULT_RUNNER_TIMEOUT_S);
+    const timeoutSource = sourceOf(sources, `runners.${runner.id}.timeout`);
+    const source = enabledSource(config, runner.id, overrides, sources);
+    return [
+      "",
+      `[runners.${runner.id}]`,
+      `enabled = ${enabled}  # ${source}`,
+      `timeout = ${timeout}  # ${timeoutSource ?? "default"}`,
+    ];
+  });
+
+  const layers = [
+    ...(config.global !== undefined ? [config.global.path] : []),
+    projectFile(config),
+    ...chain.toReversed().map(nestedConfigPath),
+    ...(classification !== undefined
+      ? [`[classifications.${classification.name}]`]
+      : []),
+    "GUARDRAILS_DISABLE",
+    "--enable/--disable",
+  ];
+  const at = path !== "" ? ` for ${path}` : "";
+  return [
+    `# Effective config${at}: defaults < ${MACHINE_CONFIG}`,
+    `#   < ${layers.join(" < ")}`,
+    ...annotateSources(stringifyToml(settings), sources),
+    ...runnerLines,
+  ];
+}
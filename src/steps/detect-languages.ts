@@ -1,26 +1,70 @@
+import type { Console } from "@/infra/console";
 import type { FileManager } from "@/infra/file-manager";
-import { detectLanguages } from "@/languages/registry";
+import { detectLanguagesWithEvidence, strictDetections } from "@/languages/registry";
 import type { LanguagePlugin } from "@/languages/types";
 import type { StepResult } from "@/models/step-result";
 import { error, ok } from "@/models/step-result";
 
+/** Evidence files listed per language before the rest are counted */
+const MAX_EVIDENCE = 3;
+
+/** e.g. "from pyproject.toml, src/app.py and 41 more"; "always on" without files */
+export function describeEvidence(evidence: readonly string[]): string {
+  const shown = evidence.slice(0, MAX_EVIDENCE).join(", ");
+  const rest = evidence.length - MAX_EVIDENCE;
+  const more = rest > 0 ? ` and ${rest} more` : "";
+  return evidence.length > 0 ? `from ${shown}${more}` : "always on";
+}
+
+/**
+ * Detect the project's languages. With `strict` (`--strict-detection`) a
+ * language seen only in loose source files is warned about and left out, and
+ * files under ignored directories never count.
+ */
 export async function detectLanguagesStep(
   projectDir: string,
   fileManager: FileManager,
-  ignorePaths?: readonly string[]
-): Promise<{ result: StepResult; languages: LanguagePlugin[] }> {
+  ignorePaths?: readonly string[],
+  cons?: Console,
+  strict = false
+): Promise<{
+  result: StepResult;
+  languages: LanguagePlugin[];
+  /** Plugin id → project-relative files that triggered its detection */
+  evidence: ReadonlyMap<string, readonly string[]>;
+}> {
   try {
-    const languages = await detectLanguages(projectDir, fileManager, ignorePaths);
+    const found = await detectLanguagesWithEvidence(
+      projectDir,
+      fileManager,
+      ignorePaths
+    );
+    const { confirmed: detected, ambiguous } = strict
+      ? await strictDetections(found, projectDir, fileManager, ignorePaths)
+      : { confirmed: found, ambiguous: [] };
+    for (const { plugin, evidence } of ambiguous) {
+      const manifest = plugin.manifests?.[0] ?? "a manifest";
+      cons?.warning(
+        `${plugin.name} detected only ${describeEvidence(evidence)} — ` +
+          `not acted on under --strict-detection; add ${manifest} to confirm it`
+      );
+    }
+    for (const { plugin, evidence } of detected) {
+      cons?.verbose(`${plugin.name}: ${describeEvidence(evidence)}`);
+    }
+    const languages = detected.map((d) => d.plugin);
     const names = languages.map((p) => p.name).join(", ");
     return {
       result: ok(`Detected languages: ${names || "none"}`),
       languages,
+      evidence: new Map(detected.map((d) => [d.plugin.id, d.evidence])),
     };
   } catch (err) {
     const message = err instanceof Error ? err.message : String(err);
     return {
       result: error(`Language detection failed: ${message}`),
       languages: [],
+      evidence: new Map(),
     };
   }
 }
@@ -0,0 +1,95 @@
+import { relative } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { Console } from "@/infra/console";
+import type { FileManager } from "@/infra/file-manager";
+import type { LanguagePlugin } from "@/languages/types";
+import type { LintIssue } from "@/models/lint-issue";
+import type { RunnerReport } from "@/models/runner-report";
+import type { StepResult } from "@/models/step-result";
+import { ok } from "@/models/step-result";
+import type { RunOptions } from "@/runners/types";
+import { type FixScope, selectFixers } from "@/steps/fix-step";
+import { unifiedDiff } from "@/utils/line-diff";
+
+export interface DiffStepResult {
+  result: StepResult;
+  /** A unified diff per changed file, blank-line separated; one string per line */
+  lines: string[];
+  /** Distinct files `--fix` would change */
+  changedFiles: number;
+}
+
+/**
+ * Preview what `check --fix` would change, writing nothing.
+ *
+ * Picks the same runners and files as fixStep (selectFixers), but pipes each
+ * file through the runner's `previewFix` instead. Runners see the previous
+ * ones' output, as they would on disk, and each file's diff covers all of
+ * them. A fixer without a preview is named with a warning.
+ */
+export async function diffStep(
+  projectDir: string,
+  languages: readonly LanguagePlugin[],
+  config: ResolvedConfig,
+  commandRunner: CommandRunner,
+  fileManager: FileManager,
+  issues: readonly LintIssue[],
+  reports: readonly RunnerReport[],
+  cons?: Console,
+  scope: FixScope = {}
+): Promise<DiffStepResult> {
+  const { files: _checked, ...passed } = scope;
+  const opts: RunOptions = {
+    projectDir,
+    config,
+    commandRunner,
+    fileManager,
+    ...passed,
+  };
+  const fixers = selectFixers(projectDir, languages, config, issues, reports, scope);
+  const original = new Map<string, string>();
+  const fixed = new Map<string, string>();
+  let changingRunners = 0;
+
+  for (const { runner, files } of fixers) {
+    if (runner.previewFix === undefined) {
+      cons?.warning(`  ${runner.name} cannot preview its fixes — --fix applies them`);
+      continue;
+    }
+
+    const previews = new Map<string, string>();
+    try {
+      for (const file of files) {
+        let before = fixed.get(file) ?? original.get(file);
+        if (before === undefined) {
+          before = await fileManager.readText(file);
+          original.set(file, before);
+        }
+        const after = await runner.previewFix(opts, relative(projectDir, file), before);
+        if (after !== before) previews.set(file, after);
+      }
+    } catch (err) {
+      const message = err instanceof Error ? err.message : String(err);
+      cons?.warning(`  ${runner.name} preview failed — ${message}`);
+      continue;
+    }
+    if (previews.size === 0) continue;
+    changingRunners++;
+    for (const [file, content] of previews) fixed.set(file, content);
+  }
+
+  const diffs = [...fixed]
+    .toSorted(([a], [b]) => a.localeCompare(b))
+    .map(([file, content]) =>
+      unifiedDiff(original.get(file) ?? "", content, relative(projectDir, file))
+    )
+    .filter((diff) => diff.length > 0);
+  return {
+    result: ok(
+      `--fix would change ${diffs.length} file(s) across ${changingRunners} runner(s)`
+    ),
+    lines: diffs.flatMap((diff, i) => (i > 0 ? ["", ...diff] : diff)),
+    changedFiles: diffs.length,
+  };
+}
@@ -0,0 +1,131 @@
+import type { ResolvedConfig } from "@/config/schema";
+import { isRunnerEnabled } from "@/config/schema";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { LanguagePlugin } from "@/languages/types";
+import type { StepResult } from "@/models/step-result";
+import { error, ok } from "@/models/step-result";
+import type { LinterRunner } from "@/runners/types";
+import { uniqueRunners } from "@/steps/check-prerequisites";
+import { preferredInstallCmd } from "@/steps/install-prerequisites";
+import { resolveToolPath } from "@/utils/resolve-tool-path";
+import { semverLt } from "@/utils/version";
+
+export type ToolStatus = "ok" | "missing" | "outdated";
+
+export interface ToolReport {
+  runnerId: string;
+  status: ToolStatus;
+  /** Set for runners much slower than a lint pass (`LinterRunner.slow`) */
+  slow?: boolean;
+  /** Parsed X.Y.Z version, null when missing or unparseable */
+  version: string | null;
+  minVersion?: string;
+  /** Install command, or the tool description when there is none */
+  hint: string;
+}
+
+export interface DoctorStepResult {
+  result: StepResult;
+  tools: ToolReport[];
+}
+
+/** Extract the first X.Y[.Z] version number from `--version` output */
+export function parseToolVersion(output: string): string | null {
+  const match = /(\d+\.\d+(?:\.\d+)?)/.exec(output);
+  return match?.[1] ?? null;
+}
+
+async function detectVersion(
+  runner: LinterRunner,
+  projectDir: string,
+  commandRunner: CommandRunner
+): Promise<string | null> {
+  const [tool, ...rest] = runner.versionArgs ?? [];
+  if (tool === undefined) return null;
+  // Same lookup as isAvailable for node tools: node_modules/.bin first, then PATH
+  const bin = (await resolveToolPath(tool, projectDir, commandRunner)) ?? tool;
+  const result = await commandRunner.run([bin, ...rest], { cwd: projectDir });
+  if (result.exitCode !== 0) return null;
+  return parseToolVersion(`${result.stdout}\n${result.stderr}`);
+}
+
+async function inspectRunner(
+  runner: LinterRunner,
+  projectDir: string,
+  commandRunner: CommandRunner
+): Promise<ToolReport> {
+  const { installHint } = runner;
+  const hint = preferredInstallCmd(installHint) ?? installHint.description;
+  const base = {
+    runnerId: runner.id,
+    hint,
+    ...(runner.slow === true && { slow: true }),
+    ...(runner.minVersion !== undefined && { minVersion: runner.minVersion }),
+  };
+
+  if (!(await runner.isAvailable(commandRunner, projectDir))) {
+    return { ...base, status: "missing", version: null };
+  }
+
+  const version = await detectVersion(runner, projectDir, commandRunner);
+  const outdated =
+    version !== null &&
+    runner.minVersion !== undefined &&
+    semverLt(version, runner.minVersion);
+  return { ...base, status: outdated ? "outdated" : "ok", version };
+}
+
+function versionCell(tool: ToolReport): string {
+  if (tool.status === "outdated") {
+    return `${tool.version ?? "?"} (<${tool.minVersion ?? "?"})`;
+  }
+  return tool.version ?? "-";
+}
+
+/** Render the report as an aligned table (one string per line) */
+export function formatDoctorTable(tools: readonly ToolReport[]): string[] {
+  const rows = [
+    ["TOOL", "STATUS", "VERSION", "INSTALL"],
+    ...tools.map((t) => [
+      t.slow === true ? `${t.runnerId} (slow)` : t.runnerId,
+      t.status,
+      versionCell(t),
+      t.status === "ok" ? "" : t.hint,
+    ]),
+  ];
+  const widths = [0, 1, 2].map((col) =>
+    Math.max(...rows.map((row) => (row[col] ?? "").length))
+  );
+  return rows.map((row) =>
+    row
+      .map((cell, col) => (col < 3 ? cell.padEnd(widths[col] ?? 0) : cell))
+      .join("  ")
+      .trimEnd()
+  );
+}
+
+/**
+ * Inspect every enabled runner for the detected languages: is the tool
+ * reachable, which version is installed, and does it meet `minVersion`.
+ * The result is an error when any tool is missing or outdated.
+ */
+export async function doctorStep(
+  projectDir: string,
+  languages: readonly LanguagePlugin[],
+  config: ResolvedConfig,
+  commandRunner: CommandRunner
+): Promise<DoctorStepResult> {
+  const runners = uniqueRunners(languages).filter((r) =>
+    isRunnerEnabled(config, r.id, r.defaultEnabled)
+  );
+  const tools = await Promise.all(
+    runners.map((runner) => inspectRunner(runner, projectDir, commandRunner))
+  );
+
+  const missing = tools.filter((t) => t.status === "missing").length;
+  const outdated = tools.filter((t) => t.status === "outdated").length;
+  const ready = tools.length - missing - outdated;
+  const msg = `${ready}/${tools.length} tool(s) ready, ${missing} missing, ${outdated} outdated`;
+
+  return { result: missing + outdated > 0 ? error(msg) : ok(msg), tools };
+}
@@ -1,38 +1,28 @@
+import { relative } from "node:path";
 import type { LintIssue } from "@/models/lint-issue";
-import { ALLOW_COMMENT_RE } from "@/utils/allow-comment-re";
+import { parseSuppressions, type Suppression } from "@/utils/allow-comment-re";
+import { fingerprintIssue } from "@/utils/fingerprint";
 
-interface AllowDirective {
-  /** 1-indexed line number of the directive itself */
-  line: number;
-  rule: string;
-}
-
-function parseDirectives(source: string): AllowDirective[] {
-  const directives: AllowDirective[] = [];
-  const lines = source.split("\n");
-  for (let i = 0; i < lines.length; i++) {
-    const line = lines[i];
-    if (line === undefined) continue;
-    const match = ALLOW_COMMENT_RE.exec(line);
-    if (match !== null) {
-      const rule = match[1];
-      if (rule !== undefined) {
-        directives.push({ line: i + 1, rule });
-      }
-    }
-  }
-  return directives;
-}
+/** Reported for a guardrails:ignore that gives no reason */
+export const MISSING_REASON_RULE = "guardrails/ignore-reason";
 
-function isSuppressed(issue: LintIssue, directives: AllowDirective[]): boolean {
-  return directives.some(
-    (d) => d.rule === issue.rule && (d.line === issue.line || d.line + 1 === issue.line)
+function suppresses(directive: Suppression, issue: LintIssue): boolean {
+  return (
+    directive.rule === issue.rule &&
+    (directive.line === issue.line || directive.line + 1 === issue.line)
   );
 }
 
+/**
+ * Drop the issues an inline `ai-guardrails-allow` or `guardrails:ignore`
+ * comment on the same line or the line above suppresses. A guardrails:ignore
+ * without a reason suppresses nothing: its issue stays, and the comment is
+ * reported as a `guardrails/ignore-reason` error of its own.
+ */
 export async function filterAllowComments(
   issues: readonly LintIssue[],
-  fileManager: { readText(path: string): Promise<string> }
+  fileManager: { readText(path: string): Promise<string> },
+  projectDir: string
 ): Promise<LintIssue[]> {
   if (issues.length === 0) return [];
 
@@ -50,6 +40,7 @@ export async function filterAllowComments(
   }
 
   const suppressed = new Set<number>();
+  const unexplained: LintIssue[] = [];
 
   await Promise.all(
     Array.from(byFile.entries()).map(async ([filePath, indices]) => {
@@ -61,17 +52,37 @@ export async function filterAllowComments(
         return;
       }
 
-      const directives = parseDirectives(source);
+      const directives = parseSuppressions(source);
       if (directives.length === 0) return;
 
+      const reported = new Set<Suppression>();
       for (const idx of indices) {
         const issue = issues[idx];
-        if (issue !== undefined && isSuppressed(issue, directives)) {
+        if (issue === undefined) continue;
+        const matching = directives.filter((d) => suppresses(d, issue));
+        if (matching.some((d) => d.reason !== "")) {
           suppressed.add(idx);
+          continue;
         }
+        for (const d of matching) reported.add(d);
+      }
+
+      const sourceLines = source.split("\n");
+      for (const d of reported) {
+        const raw = {
+          rule: MISSING_REASON_RULE,
+          linter: "guardrails",
+          file: filePath,
+          line: d.line,
+          col: d.col,
+          message: `guardrails:ignore ${d.rule} has no reason, so it suppresses nothing`,
+          severity: "error",
+        } satisfies Omit<LintIssue, "fingerprint">;
+        const rel = { ...raw, file: relative(projectDir, filePath) };
+        unexplained.push({ ...raw, fingerprint: fingerprintIssue(rel, sourceLines) });
       }
     })
   );
 
-  return issues.filter((_, i) => !suppressed.has(i));
+  return [...issues.filter((_, i) => !suppressed.has(i)), ...unexplained];
 }
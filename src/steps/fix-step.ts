@@ -0,0 +1,163 @@
+import { relative } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import { isRunnerEnabled, isRunnerEnabledAt } from "@/config/schema";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { Console } from "@/infra/console";
+import type { FileManager } from "@/infra/file-manager";
+import type { LanguagePlugin } from "@/languages/types";
+import type { LintIssue } from "@/models/lint-issue";
+import type { RunnerReport } from "@/models/runner-report";
+import type { StepResult } from "@/models/step-result";
+import { ok } from "@/models/step-result";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { computeHash } from "@/utils/hash";
+
+export interface FixStepResult {
+  result: StepResult;
+  /** Distinct files whose content changed during the fix pass */
+  fixedFiles: number;
+  /** Runners that changed at least one file */
+  fixedRunners: number;
+}
+
+/**
+ * What the check covered, so --fix and --diff stay inside it: `files` are the
+ * project-relative files it checked (undefined for the whole project), and
+ * `module`, `paths` and `batchSize` are passed on to the runners as they were.
+ */
+export type FixScope = Pick<RunOptions, "files" | "module" | "paths" | "batchSize">;
+
+/** A fix-capable runner and the files its issues are in, sorted */
+export interface Fixer {
+  runner: LinterRunner;
+  files: string[];
+}
+
+/**
+ * The runners `--fix` and `--diff` act on: enabled, ran without failing, able
+ * to fix, and with issues to fix — in plugin order, as they would run. A
+ * runner's files are those in `scope` whose nearest config leaves it enabled.
+ */
+export function selectFixers(
+  projectDir: string,
+  languages: readonly LanguagePlugin[],
+  config: ResolvedConfig,
+  issues: readonly LintIssue[],
+  reports: readonly RunnerReport[],
+  scope: FixScope = {}
+): Fixer[] {
+  const ran = new Set(reports.filter((r) => r.status === "ok").map((r) => r.runnerId));
+  const checked = scope.files !== undefined ? new Set(scope.files) : undefined;
+  const fixes = (runner: LinterRunner) => (file: string) => {
+    const relPath = relative(projectDir, file);
+    if (checked !== undefined && !checked.has(relPath)) return false;
+    return isRunnerEnabledAt(config, runner.id, relPath);
+  };
+  return languages
+    .flatMap((plugin) => plugin.runners())
+    .filter(
+      (runner) =>
+        runner.fix !== undefined &&
+        isRunnerEnabled(config, runner.id, runner.defaultEnabled) &&
+        ran.has(runner.id)
+    )
+    .map((runner) => ({
+      runner,
+      files: [
+        ...new Set(issues.filter((i) => i.linter === runner.id).map((i) => i.file)),
+      ]
+        .filter(fixes(runner))
+        .toSorted(),
+    }))
+    .filter((fixer) => fixer.files.length > 0);
+}
+
+/**
+ * Content hashes of `files`; "" for a file that no longer exists. A file that
+ * cannot be read is warned about and left out, so it never counts as fixed.
+ */
+async function hashFiles(
+  files: readonly string[],
+  fileManager: FileManager,
+  cons: Console | undefined
+): Promise<Map<string, string>> {
+  const hashes = new Map<string, string>();
+  for (const file of files) {
+    try {
+      const exists = await fileManager.exists(file);
+      hashes.set(file, exists ? computeHash(await fileManager.readText(file)) : "");
+    } catch (err) {
+      const message = err instanceof Error ? err.message : String(err);
+      cons?.warning(`  Could not read ${file} — ${message}`);
+    }
+  }
+  return hashes;
+}
+
+/**
+ * Re-invoke each fix-capable runner that reported issues in fix mode.
+ *
+ * Fixers run sequentially since several tools may touch the same file.
+ * A file-scoped runner fixes only the files that carried its issues, which are
+ * also what is compared before and after and what the summary counts. When
+ * `scope` limits the check to some files, a runner that can only fix the
+ * whole project is skipped with a warning. Runners selectFixers leaves out
+ * are skipped silently.
+ */
+export async function fixStep(
+  projectDir: string,
+  languages: readonly LanguagePlugin[],
+  config: ResolvedConfig,
+  commandRunner: CommandRunner,
+  fileManager: FileManager,
+  issues: readonly LintIssue[],
+  reports: readonly RunnerReport[],
+  cons?: Console,
+  scope: FixScope = {}
+): Promise<FixStepResult> {
+  const { files: _checked, ...passed } = scope;
+  const opts: RunOptions = {
+    projectDir,
+    config,
+    commandRunner,
+    fileManager,
+    ...passed,
+  };
+  const fixers = selectFixers(projectDir, languages, config, issues, reports, scope);
+  const changed = new Set<string>();
+  let fixedRunners = 0;
+
+  for (const { runner, files } of fixers) {
+    if (runner.fix === undefined) continue;
+    const fileScoped = runner.fileScoped === true;
+    if (scope.files !== undefined && !fileScoped) {
+      cons?.warning(
+        `  ${runner.name} can only fix the whole project — skipped for a partial check`
+      );
+      continue;
+    }
+    const before = await hashFiles(files, fileManager, cons);
+    try {
+      const fixOpts = fileScoped
+        ? { ...opts, files: files.map((file) => relative(projectDir, file)) }
+        : opts;
+      await runner.fix(fixOpts);
+    } catch (err) {
+      const message = err instanceof Error ? err.message : String(err);
+      cons?.warning(`  ${runner.name} fix failed — ${message}`);
+      continue;
+    }
+    const after = await hashFiles(files, fileManager, cons);
+
+    const runnerChanged = files.filter((f) => before.get(f) !== after.get(f));
+    if (runnerChanged.length === 0) continue;
+    fixedRunners++;
+    for (const file of runnerChanged) changed.add(file);
+  }
+
+  return {
+    result: ok(`Fixed ${changed.size} file(s) across ${fixedRunners} runner(s)`),
+    fixedFiles: changed.size,
+    fixedRunners,
+  };
+}
@@ -1,22 +1,32 @@
 import { dirname, join } from "node:path";
 import type { ConfigStrategy, ResolvedConfig } from "@/config/schema";
+import { userConfigFile } from "@/config/schema";
 import { generateLefthookConfig, lefthookGenerator } from "@/generators/lefthook";
-import { ALL_GENERATORS, applicableGenerators } from "@/generators/registry";
+import {
+  ALL_GENERATORS,
+  applicableGenerators,
+  findHonouredConfig,
+} from "@/generators/registry";
+import type { ConfigGenerator } from "@/generators/types";
 import type { FileManager } from "@/infra/file-manager";
 import type { LanguagePlugin } from "@/languages/types";
 import type { StepResult } from "@/models/step-result";
 import { error, ok } from "@/models/step-result";
 import { applyStrategy } from "@/utils/config-merge";
-import { HASH_PREFIX, JSONC_HASH_PREFIX, MD_HASH_PREFIX } from "@/utils/hash";
+import { hasHashHeader } from "@/utils/hash";
+
+type GeneratorOutcome =
+  | { file: string; skipped?: true; userOwned?: true }
+  | { error: string };
 
 async function runGenerator(
   projectDir: string,
   fileManager: FileManager,
-  id: string,
-  configFile: string,
+  generator: ConfigGenerator,
   generate: () => string,
   strategy: ConfigStrategy
-): Promise<{ file: string; skipped?: true } | { error: string }> {
+): Promise<GeneratorOutcome> {
+  const { id, configFile } = generator;
   try {
     // Check skip condition BEFORE calling generate() so that a throwing
     // generator (e.g. lefthook without active plugins) does not mask a skip.
@@ -25,6 +35,8 @@ async function runGenerator(
     if (exists && strategy === "skip") {
       return { file: configFile, skipped: true };
     }
+    const honoured = await findHonouredConfig(generator, projectDir, fileManager);
+    if (honoured !== null) return { file: honoured, skipped: true };
 
     const generated = generate();
     const content = await applyStrategy(
@@ -32,31 +44,47 @@ async function runGenerator(
       configFile,
       generated,
       strategy,
-      fileManager
+      fileManager,
+      generator.merge
     );
 
     if (content === null) {
       return { file: configFile, skipped: true };
     }
 
+    // A merge into a file without our header modifies a user-owned file
+    const userOwned =
+      exists &&
+      strategy === "merge" &&
+      !hasHashHeader(await fileManager.readText(dest));
+
     await fileManager.mkdir(dirname(dest), { parents: true });
     await fileManager.writeText(dest, content);
-    return { file: configFile };
+    return userOwned ? { file: configFile, userOwned: true } : { file: configFile };
   } catch (err) {
     const message = err instanceof Error ? err.message : String(err);
     return { error: `${id}: ${message}` };
   }
 }
 
+/**
+ * Write the config of every generator the detected `languages` apply to.
+ * `force` (--force) also writes the generators that run only on request.
+ */
 export async function generateConfigsStep(
   projectDir: string,
   languages: readonly LanguagePlugin[],
   config: ResolvedConfig,
   fileManager: FileManager,
-  strategy: ConfigStrategy = "merge"
+  strategy: ConfigStrategy = "merge",
+  force = false
 ): Promise<StepResult> {
   const activeIds = new Set(languages.map((l) => l.id));
-  const applicable = applicableGenerators(activeIds);
+  // A tool using the project's own config file ([runners.<id>] user_config) gets none
+  const applicable = applicableGenerators(activeIds).filter(
+    (g) =>
+      userConfigFile(config, g.id) === undefined && (force || g.onRequest !== true)
+  );
 
   const results = await Promise.all(
     applicable.map((g) => {
@@ -64,18 +92,12 @@ export async function generateConfigsStep(
         g.id === lefthookGenerator.id
           ? () => generateLefthookConfig(config, languages)
           : () => g.generate(config);
-      return runGenerator(
-        projectDir,
-        fileManager,
-        g.id,
-        g.configFile,
-        generate,
-        strategy
-      );
+      return runGenerator(projectDir, fileManager, g, generate, strategy);
     })
   );
 
   const written: string[] = [];
+  const userOwned: string[] = [];
   const skipped: string[] = [];
   const errors: string[] = [];
   for (const r of results) {
@@ -85,6 +107,7 @@ export async function generateConfigsStep(
       skipped.push(r.file);
     } else {
       written.push(r.file);
+      if (r.userOwned === true) userOwned.push(r.file);
     }
   }
 
@@ -101,13 +124,7 @@ export async function generateConfigsStep(
       try {
         const dest = join(projectDir, g.configFile);
         if (await fileManager.exists(dest)) {
-          const content = await fileManager.readText(dest);
-          const firstLine = content.split("\n")[0] ?? "";
-          if (
-            firstLine.startsWith(HASH_PREFIX) ||
-            firstLine.startsWith(JSONC_HASH_PREFIX) ||
-            firstLine.startsWith(MD_HASH_PREFIX)
-          ) {
+          if (hasHashHeader(await fileManager.readText(dest))) {
             await fileManager.delete(dest);
             removed.push(g.configFile);
           }
@@ -123,6 +140,9 @@ export async function generateConfigsStep(
   if (written.length > 0) {
     parts.push(`Generated ${written.length} config file(s): ${written.join(", ")}`);
   }
+  if (userOwned.length > 0) {
+    parts.push(`Merged into user-owned file(s): ${userOwned.join(", ")}`);
+  }
   if (skipped.length > 0) {
     parts.push(`Skipped ${skipped.length} existing file(s): ${skipped.join(", ")}`);
   }
@@ -0,0 +1,129 @@
+import { dirname, isAbsolute, join } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
+import type { StepResult } from "@/models/step-result";
+import { error, ok } from "@/models/step-result";
+import { SHEBANG_SHELLS, shebangInterpreter } from "@/utils/shebang";
+
+export const HOOK_BLOCK_BEGIN = "# >>> ai-guardrails pre-commit (managed block)";
+export const HOOK_BLOCK_END = "# <<< ai-guardrails pre-commit";
+
+const HOOK_BLOCK = `${HOOK_BLOCK_BEGIN}
+ai-guardrails check --staged || exit $?
+${HOOK_BLOCK_END}
+`;
+
+/**
+ * Add our block to a pre-commit script. A fresh script gets a shebang; an
+ * existing one gets the block right after its shebang so it runs first and
+ * aborts the commit on findings without masking the script's own exit code.
+ * Returns null when the block is already present. Throws for a script in
+ * another language than shell, e.g. `#!/usr/bin/env python`, where our lines
+ * would not run.
+ */
+export function addHookBlock(existing: string | null): string | null {
+  if (existing === null) return `#!/bin/sh\n${HOOK_BLOCK}`;
+  if (existing.includes(HOOK_BLOCK_BEGIN)) return null;
+
+  const interpreter = shebangInterpreter(existing);
+  if (interpreter !== null && !SHEBANG_SHELLS.includes(interpreter)) {
+    throw new Error(
+      `the pre-commit hook is a ${interpreter} script — have it run \`ai-guardrails check --staged\` and fail the commit on a non-zero exit`
+    );
+  }
+
+  if (!existing.startsWith("#!")) return `${HOOK_BLOCK}${existing}`;
+  const nl = existing.indexOf("\n");
+  const shebang = nl === -1 ? `${existing}\n` : existing.slice(0, nl + 1);
+  const rest = nl === -1 ? "" : existing.slice(nl + 1);
+  return `${shebang}${HOOK_BLOCK}${rest}`;
+}
+
+/**
+ * Strip our block from a pre-commit script. Returns null when nothing but a
+ * shebang would remain, meaning the whole file was ours and can be deleted.
+ */
+export function removeHookBlock(existing: string): string | null {
+  const begin = existing.indexOf(HOOK_BLOCK_BEGIN);
+  const end = existing.indexOf(HOOK_BLOCK_END, begin);
+  if (begin === -1 || end === -1) return existing;
+
+  const after = existing.slice(end + HOOK_BLOCK_END.length).replace(/^\n/, "");
+  const stripped = `${existing.slice(0, begin)}${after}`;
+  const meaningful = stripped
+    .split("\n")
+    .filter((line) => line.trim() !== "" && !line.startsWith("#!"));
+  return meaningful.length === 0 ? null : stripped;
+}
+
+/** Resolve the pre-commit path via git so worktrees and core.hooksPath work */
+async function preCommitPath(
+  projectDir: string,
+  commandRunner: CommandRunner
+): Promise<string> {
+  const result = await commandRunner.run(
+    ["git", "rev-parse", "--git-path", "hooks/pre-commit"],
+    { cwd: projectDir }
+  );
+  if (result.exitCode !== 0) {
+    const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+    throw new Error(`not a git repository: ${detail}`);
+  }
+  const path = result.stdout.trim();
+  return isAbsolute(path) ? path : join(projectDir, path);
+}
+
+async function readIfExists(
+  path: string,
+  fileManager: FileManager
+): Promise<string | null> {
+  return (await fileManager.exists(path)) ? fileManager.readText(path) : null;
+}
+
+export async function installGitHookStep(
+  projectDir: string,
+  commandRunner: CommandRunner,
+  fileManager: FileManager
+): Promise<StepResult> {
+  try {
+    const dest = await preCommitPath(projectDir, commandRunner);
+    const updated = addHookBlock(await readIfExists(dest, fileManager));
+    if (updated === null) return ok("pre-commit hook already installed");
+
+    await fileManager.mkdir(dirname(dest), { parents: true });
+    await fileManager.writeText(dest, updated);
+    const chmod = await commandRunner.run(["chmod", "+x", dest], { cwd: projectDir });
+    if (chmod.exitCode !== 0) {
+      return error(`Could not make ${dest} executable: ${chmod.stderr.trim()}`);
+    }
+    return ok("pre-commit hook installed — commits run `check --staged`");
+  } catch (err) {
+    const message = err instanceof Error ? err.message : String(err);
+    return error(`Hook install failed: ${message}`);
+  }
+}
+
+export async function uninstallGitHookStep(
+  projectDir: string,
+  commandRunner: CommandRunner,
+  fileManager: FileManager
+): Promise<StepResult> {
+  try {
+    const dest = await preCommitPath(projectDir, commandRunner);
+    const existing = await readIfExists(dest, fileManager);
+    if (existing === null || !existing.includes(HOOK_BLOCK_BEGIN)) {
+      return ok("No ai-guardrails pre-commit hook to remove");
+    }
+
+    const stripped = removeHookBlock(existing);
+    if (stripped === null) {
+      await fileManager.delete(dest);
+    } else {
+      await fileManager.writeText(dest, stripped);
+    }
+    return ok("pre-commit hook removed");
+  } catch (err) {
+    const message = err instanceof Error ? err.message : String(err);
+    return error(`Hook uninstall failed: ${message}`);
+  }
+}
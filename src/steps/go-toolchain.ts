@@ -0,0 +1,50 @@
+import { relative } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { Console } from "@/infra/console";
+import type { FileManager } from "@/infra/file-manager";
+import type { StepResult } from "@/models/step-result";
+import { ok, skip, warn } from "@/models/step-result";
+import { compareGoVersions, findGoModules, readGoVersion } from "@/utils/go-modules";
+
+/** "go1.22.3" (`go env GOVERSION`) → "1.22.3"; null for devel builds */
+export function parseGoToolchainVersion(stdout: string): string | null {
+  const match = /^go(\d+\.\d+(?:\.\d+)?)/.exec(stdout.trim());
+  return match?.[1] ?? null;
+}
+
+/**
+ * Log the `go` directive of each module at verbose level, and warn when the
+ * installed toolchain is older than one of them — go and the Go runners then
+ * refuse to load that module. Never fails the check: `skip` without a go
+ * binary, `warn` for a too-old toolchain, else `ok`.
+ */
+export async function goToolchainStep(
+  projectDir: string,
+  fileManager: FileManager,
+  commandRunner: CommandRunner,
+  cons: Console,
+  ignorePaths: readonly string[] = []
+): Promise<StepResult> {
+  const modules = await findGoModules(fileManager, projectDir, ignorePaths);
+  const targets: { module: string; version: string }[] = [];
+  for (const moduleDir of modules) {
+    const version = await readGoVersion(fileManager, moduleDir);
+    const module = relative(projectDir, moduleDir) || ".";
+    cons.verbose(`Go module ${module}: go ${version ?? "(no go directive)"}`);
+    if (version !== null) targets.push({ module, version });
+  }
+
+  // From the project root, so GOTOOLCHAIN=auto switching is reflected
+  const result = await commandRunner.run(["go", "env", "GOVERSION"], {
+    cwd: projectDir,
+  });
+  const toolchain =
+    result.exitCode === 0 ? parseGoToolchainVersion(result.stdout) : null;
+  if (toolchain === null) return skip("Go toolchain version unknown");
+  cons.verbose(`Go toolchain: go${toolchain}`);
+
+  const newer = targets.filter((t) => compareGoVersions(toolchain, t.version) < 0);
+  if (newer.length === 0) return ok(`Go toolchain go${toolchain}`);
+  const needed = newer.map((t) => `${t.module} (go ${t.version})`).join(", ");
+  return warn(`Go toolchain go${toolchain} is older than required by ${needed}`);
+}
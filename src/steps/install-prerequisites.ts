@@ -7,7 +7,7 @@ import type { InstallHint } from "@/runners/types";
 import type { PrereqReport } from "@/steps/check-prerequisites";
 
 /** Pick the first available install command from a hint, in preference order. */
-function preferredInstallCmd(hint: InstallHint): string | undefined {
+export function preferredInstallCmd(hint: InstallHint): string | undefined {
   return (
     hint.npm ??
     hint.pip ??
@@ -15,7 +15,9 @@ function preferredInstallCmd(hint: InstallHint): string | undefined {
     hint.apt ??
     hint.cargo ??
     hint.go ??
-    hint.rustup
+    hint.rustup ??
+    hint.gem ??
+    hint.composer
   );
 }
 
@@ -0,0 +1,180 @@
+import type { ResolvedConfig } from "@/config/schema";
+import { isRunnerEnabled } from "@/config/schema";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { Console } from "@/infra/console";
+import type { LanguagePlugin } from "@/languages/types";
+import type { StepResult } from "@/models/step-result";
+import { error, ok } from "@/models/step-result";
+import type { InstallHint, LinterRunner } from "@/runners/types";
+import { uniqueRunners } from "@/steps/check-prerequisites";
+import {
+  devDependencyCommand,
+  type JsPackageManager,
+} from "@/utils/package-json";
+
+/**
+ * Package managers we install through, in preference order. Each installs
+ * into a location of its own (GOPATH/bin, pipx venvs, ~/.cargo, the rustup
+ * toolchain), never the system Python or the project's package.json. pip
+ * hints are installed with pipx; without it, and for npm hints and system
+ * package managers (brew, apt), the command is printed for the user to run.
+ */
+const AUTO_MANAGERS = ["go", "pipx", "cargo", "rustup"] as const;
+type AutoManager = (typeof AUTO_MANAGERS)[number];
+
+const MANAGER_PROBES: Record<AutoManager, readonly string[]> = {
+  go: ["go", "version"],
+  pipx: ["pipx", "--version"],
+  cargo: ["cargo", "--version"],
+  rustup: ["rustup", "--version"],
+};
+
+export type ToolInstallOutcome = "installed" | "failed" | "manual" | "planned";
+
+export interface ToolInstallReport {
+  runnerId: string;
+  outcome: ToolInstallOutcome;
+  /** Command run (or planned); for manual tools, the hint shown instead */
+  command: string;
+}
+
+export interface InstallToolsOptions {
+  /** Print the commands without running them */
+  dryRun?: boolean;
+  /** Selects the manual hint (brew on darwin, apt on linux) */
+  platform?: NodeJS.Platform;
+  /** Rewrites npm hints for the project's package manager; default npm */
+  jsPackageManager?: JsPackageManager;
+}
+
+export interface InstallToolsResult {
+  result: StepResult;
+  tools: ToolInstallReport[];
+}
+
+/**
+ * Manual install instructions for tools we will not install ourselves: a
+ * dev dependency for the project's package manager first, then pip, then the
+ * system package (brew on darwin, apt elsewhere).
+ */
+export function manualInstallHint(
+  hint: InstallHint,
+  platform: NodeJS.Platform,
+  jsPackageManager: JsPackageManager = "npm"
+): string {
+  if (hint.npm !== undefined) return devDependencyCommand(hint.npm, jsPackageManager);
+  const native = platform === "darwin" ? hint.brew : hint.apt;
+  return hint.pip ?? native ?? hint.brew ?? hint.apt ?? hint.description;
+}
+
+async function availableManagers(
+  projectDir: string,
+  commandRunner: CommandRunner
+): Promise<Set<AutoManager>> {
+  const probes = await Promise.all(
+    AUTO_MANAGERS.map(async (manager) => {
+      const result = await commandRunner.run([...MANAGER_PROBES[manager]], {
+        cwd: projectDir,
+      });
+      return result.exitCode === 0 ? manager : null;
+    })
+  );
+  return new Set(probes.filter((m): m is AutoManager => m !== null));
+}
+
+function hintFor(hint: InstallHint, manager: AutoManager): string | undefined {
+  if (manager !== "pipx") return hint[manager];
+  return hint.pip?.replace(/^pip install /, "pipx install ");
+}
+
+function autoInstallCmd(
+  hint: InstallHint,
+  managers: ReadonlySet<AutoManager>
+): string | undefined {
+  for (const manager of AUTO_MANAGERS) {
+    const cmd = hintFor(hint, manager);
+    if (cmd !== undefined && managers.has(manager)) return cmd;
+  }
+  return undefined;
+}
+
+async function installOne(
+  runner: LinterRunner,
+  cmd: string,
+  projectDir: string,
+  commandRunner: CommandRunner,
+  cons: Console
+): Promise<ToolInstallReport> {
+  // Install hints are hardcoded simple commands (no quoting or escaping needed)
+  const parts = cmd.split(/\s+/).filter(Boolean);
+  const result = await commandRunner.run(parts, { cwd: projectDir });
+  if (result.exitCode === 0) {
+    cons.success(`${runner.id} installed`);
+    return { runnerId: runner.id, outcome: "installed", command: cmd };
+  }
+  cons.warning(`Failed to install ${runner.id} (exit code ${result.exitCode})`);
+  return { runnerId: runner.id, outcome: "failed", command: cmd };
+}
+
+/**
+ * Install the missing tools of every enabled runner for the detected
+ * languages. Each command is printed before it runs; `dryRun` prints only.
+ * Tools with no isolated install (npm dev dependencies, pip without pipx,
+ * system packages) get a manual hint instead.
+ * The result is an error only when an install command fails.
+ */
+export async function installToolsStep(
+  projectDir: string,
+  languages: readonly LanguagePlugin[],
+  config: ResolvedConfig,
+  commandRunner: CommandRunner,
+  cons: Console,
+  options: InstallToolsOptions = {}
+): Promise<InstallToolsResult> {
+  const {
+    dryRun = false,
+    platform = process.platform,
+    jsPackageManager = "npm",
+  } = options;
+
+  const runners = uniqueRunners(languages).filter((r) =>
+    isRunnerEnabled(config, r.id, r.defaultEnabled)
+  );
+  const availability = await Promise.all(
+    runners.map((r) => r.isAvailable(commandRunner, projectDir))
+  );
+  const missing = runners.filter((_, i) => availability[i] !== true);
+  if (missing.length === 0) {
+    return { result: ok("All tools are already installed"), tools: [] };
+  }
+
+  const managers = await availableManagers(projectDir, commandRunner);
+  const tools: ToolInstallReport[] = [];
+  // Sequential: package managers do not like concurrent installs
+  for (const runner of missing) {
+    const cmd = autoInstallCmd(runner.installHint, managers);
+    if (cmd === undefined) {
+      const manual = manualInstallHint(runner.installHint, platform, jsPackageManager);
+      cons.warning(`${runner.id}: install manually — ${manual}`);
+      tools.push({ runnerId: runner.id, outcome: "manual", command: manual });
+      continue;
+    }
+    cons.info(`  $ ${cmd}`);
+    if (dryRun) {
+      tools.push({ runnerId: runner.id, outcome: "planned", command: cmd });
+      continue;
+    }
+    tools.push(await installOne(runner, cmd, projectDir, commandRunner, cons));
+  }
+
+  const count = (outcome: ToolInstallOutcome): number =>
+    tools.filter((t) => t.outcome === outcome).length;
+  const manual = count("manual");
+  const failed = count("failed");
+  const manualNote = `${manual} need manual install`;
+  const msg = dryRun
+    ? `${count("planned")} tool(s) would be installed, ${manualNote}`
+    : `${count("installed")} tool(s) installed, ${failed} failed, ${manualNote}`;
+
+  return { result: failed > 0 ? error(msg) : ok(msg), tools };
+}
@@ -0,0 +1,142 @@
+import type { ResolvedConfig } from "@/config/schema";
+import { isRunnerEnabled } from "@/config/schema";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
+import type { LanguagePlugin } from "@/languages/types";
+import type { StepResult } from "@/models/step-result";
+import { error, ok } from "@/models/step-result";
+import { isRunnerActive } from "@/runners/active";
+import type { LinterRunner } from "@/runners/types";
+import { describeEvidence } from "@/steps/detect-languages";
+import { preferredInstallCmd } from "@/steps/install-prerequisites";
+
+/** "unavailable" means the runner is enabled but its tool is not installed */
+export type RunnerPlanStatus = "enabled" | "disabled" | "unavailable";
+
+export interface RunnerPlan {
+  id: string;
+  name: string;
+  status: RunnerPlanStatus;
+  /** Set for runners much slower than a lint pass (`LinterRunner.slow`) */
+  slow?: boolean;
+  /** Install command, or the tool description, for unavailable runners */
+  hint?: string;
+}
+
+export interface LanguagePlan {
+  id: string;
+  name: string;
+  /** Project-relative files that triggered detection; empty = always on */
+  evidence: readonly string[];
+  runners: RunnerPlan[];
+}
+
+export interface ListStepResult {
+  result: StepResult;
+  languages: LanguagePlan[];
+}
+
+async function planRunner(
+  runner: LinterRunner,
+  projectDir: string,
+  config: ResolvedConfig,
+  commandRunner: CommandRunner,
+  fileManager: FileManager | undefined
+): Promise<RunnerPlan> {
+  const base = {
+    id: runner.id,
+    name: runner.name,
+    ...(runner.slow === true && { slow: true }),
+  };
+  // Without a file manager, appliesTo cannot look at the project
+  const active =
+    fileManager !== undefined
+      ? await isRunnerActive(runner, { projectDir, config, commandRunner, fileManager })
+      : isRunnerEnabled(config, runner.id, runner.defaultEnabled);
+  if (!active) return { ...base, status: "disabled" };
+  if (await runner.isAvailable(commandRunner, projectDir)) {
+    return { ...base, status: "enabled" };
+  }
+  const { installHint } = runner;
+  const hint = preferredInstallCmd(installHint) ?? installHint.description;
+  return { ...base, status: "unavailable", hint };
+}
+
+/**
+ * The plan for this repo: each detected language with the files that
+ * triggered it, and whether each of its runners would run, is turned off in
+ * config (or not used by the project), or is missing its tool. Disabled
+ * runners are not probed.
+ */
+export async function listStep(
+  projectDir: string,
+  languages: readonly LanguagePlugin[],
+  evidence: ReadonlyMap<string, readonly string[]>,
+  config: ResolvedConfig,
+  commandRunner: CommandRunner,
+  fileManager?: FileManager
+): Promise<ListStepResult> {
+  try {
+    const plans = await Promise.all(
+      languages.map(
+        async (plugin): Promise<LanguagePlan> => ({
+          id: plugin.id,
+          name: plugin.name,
+          evidence: evidence.get(plugin.id) ?? [],
+          runners: await Promise.all(
+            plugin
+              .runners()
+              .map((runner) =>
+                planRunner(runner, projectDir, config, commandRunner, fileManager)
+              )
+          ),
+        })
+      )
+    );
+    const all = plans.flatMap((plan) => plan.runners);
+    const count = (status: RunnerPlanStatus) =>
+      all.filter((r) => r.status === status).length;
+    const msg =
+      `${plans.length} language(s), ${count("enabled")} runner(s) enabled, ` +
+      `${count("disabled")} disabled, ${count("unavailable")} unavailable`;
+    return { result: ok(msg), languages: plans };
+  } catch (err) {
+    const message = err instanceof Error ? err.message : String(err);
+    return { result: error(`List failed: ${message}`), languages: [] };
+  }
+}
+
+const STATUS_MARK: Record<RunnerPlanStatus, string> = {
+  enabled: "✓",
+  disabled: "-",
+  unavailable: "✗",
+};
+
+/** Render the plan as text, one block per language (one string per line) */
+export function formatListText(languages: readonly LanguagePlan[]): string[] {
+  const width = Math.max(
+    0,
+    ...languages.flatMap((plan) => plan.runners.map((r) => r.id.length))
+  );
+  return languages.flatMap((plan) => [
+    `${plan.name} (${describeEvidence(plan.evidence)})`,
+    ...plan.runners.map((runner) => {
+      const hint = runner.hint !== undefined ? ` — ${runner.hint}` : "";
+      const mark = STATUS_MARK[runner.status];
+      const slow = runner.slow === true ? " (slow)" : "";
+      return `  ${mark} ${runner.id.padEnd(width)}  ${runner.status}${slow}${hint}`;
+    }),
+  ]);
+}
+
+/** Bumped only on breaking changes to the `list --format json` shape */
+export const LIST_SCHEMA_VERSION = 1;
+
+export interface ListJson {
+  schemaVersion: number;
+  languages: readonly LanguagePlan[];
+}
+
+export function listToJson(languages: readonly LanguagePlan[]): ListJson {
+  return { schemaVersion: LIST_SCHEMA_VERSION, languages };
+}
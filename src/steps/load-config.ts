@@ -1,22 +1,44 @@
 import { homedir } from "node:os";
 import { join } from "node:path";
-import { loadMachineConfig, loadProjectConfig, resolveConfig } from "@/config/loader";
+import {
+  loadGlobalConfig,
+  loadMachineLayer,
+  loadNestedConfigs,
+  loadProjectLayer,
+  resolveConfig,
+} from "@/config/loader";
 import type { ResolvedConfig } from "@/config/schema";
+import { withNestedConfigs } from "@/config/schema";
 import type { FileManager } from "@/infra/file-manager";
 import type { StepResult } from "@/models/step-result";
 import { error, ok } from "@/models/step-result";
 
+/**
+ * Load and resolve every config layer. `configPath` (`--config`) replaces the
+ * project's `.ai-guardrails/config.toml`; nested configs still apply.
+ */
 export async function loadConfigStep(
   projectDir: string,
-  fileManager: FileManager
+  fileManager: FileManager,
+  configPath?: string
 ): Promise<{ result: StepResult; config: ResolvedConfig | null }> {
   try {
     const machinePath = join(homedir(), ".ai-guardrails", "config.toml");
-    const machine = await loadMachineConfig(machinePath, fileManager);
-    const project = await loadProjectConfig(projectDir, fileManager);
-    const config = resolveConfig(machine, project);
+    const machine = await loadMachineLayer(machinePath, fileManager);
+    const global = await loadGlobalConfig(fileManager);
+    const project = await loadProjectLayer(projectDir, fileManager, configPath);
+    const resolved = resolveConfig(machine.machine, project.project, global);
+    const root = {
+      ...resolved,
+      written: { machine: machine.written, project: project.written },
+      ...(configPath !== undefined && { configPath }),
+    };
+    const nested = await loadNestedConfigs(projectDir, fileManager, root.ignorePaths);
+    const config = withNestedConfigs(root, nested);
+    const nestedNote = nested.length > 0 ? `, ${nested.length} nested config(s)` : "";
+    const globalNote = global !== undefined ? `, global ${global.path}` : "";
     return {
-      result: ok(`Config loaded: profile=${config.profile}`),
+      result: ok(`Config loaded: profile=${config.profile}${nestedNote}${globalNote}`),
       config,
     };
   } catch (err) {
@@ -0,0 +1,96 @@
+import type { PostRunConfig } from "@/config/schema";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { LintIssue } from "@/models/lint-issue";
+import type { RunnerReport } from "@/models/runner-report";
+import type { StepResult } from "@/models/step-result";
+import { error, ok } from "@/models/step-result";
+import { issuesToJson, type JsonReport, summarizeReport } from "@/writers/json";
+
+/** The JSON report handed to the hook, with the run's outcome */
+export interface PostRunPayload extends JsonReport {
+  /** Whether the check passed */
+  passed: boolean;
+}
+
+/**
+ * The hook's payload: every finding, baselined ones marked, with a summary
+ * that counts only the new ones — what `{errors}` and `{warnings}` stand for.
+ */
+export function postRunPayload(
+  issues: LintIssue[],
+  runners: readonly RunnerReport[],
+  baselined: ReadonlySet<string>,
+  passed: boolean
+): PostRunPayload {
+  const fresh = issues.filter((issue) => !baselined.has(issue.fingerprint));
+  return {
+    ...issuesToJson(issues, runners, baselined),
+    summary: summarizeReport(fresh, runners),
+    passed,
+  };
+}
+
+/** The hook's argv: its `command` with the run's summary substituted, or curl */
+export function postRunArgs(
+  postRun: PostRunConfig,
+  payload: PostRunPayload,
+  projectDir: string
+): string[] {
+  if (postRun.url !== undefined) {
+    return [
+      "curl",
+      "--silent",
+      "--show-error",
+      "--fail",
+      "--request",
+      "POST",
+      "--header",
+      "Content-Type: application/json",
+      "--data-binary",
+      "@-",
+      postRun.url,
+    ];
+  }
+  const values: Record<string, string> = {
+    "{status}": payload.passed ? "passed" : "failed",
+    "{errors}": String(payload.summary.errors),
+    "{warnings}": String(payload.summary.warnings),
+    "{projectDir}": projectDir,
+  };
+  return (postRun.command ?? []).map((arg) =>
+    Object.entries(values).reduce(
+      (out, [key, value]) => out.replaceAll(key, value),
+      arg
+    )
+  );
+}
+
+/**
+ * Run the [post_run] hook with the JSON report on its stdin — POSTed as the
+ * body for a `url`. It runs from the project root whether the check passed or
+ * not; a hook that fails, times out or is missing gives an error result.
+ */
+export async function postRunStep(
+  postRun: PostRunConfig,
+  payload: PostRunPayload,
+  projectDir: string,
+  commandRunner: CommandRunner
+): Promise<StepResult> {
+  const args = postRunArgs(postRun, payload, projectDir);
+  const result = await commandRunner.run(args, {
+    cwd: projectDir,
+    timeout: postRun.timeout * 1000,
+    stdin: JSON.stringify(payload, null, 2),
+  });
+  // A webhook URL often embeds its secret; name only the host
+  const target =
+    postRun.url !== undefined ? new URL(postRun.url).host : (args[0] ?? "");
+  if (result.timedOut === true) {
+    return error(`post_run ${target} timed out after ${postRun.timeout}s`);
+  }
+  if (result.exitCode !== 0) {
+    const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+    return error(`post_run ${target} failed: ${detail}`);
+  }
+  return ok(`post_run ${target} done`);
+}
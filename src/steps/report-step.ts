@@ -1,33 +1,213 @@
+import { dirname } from "node:path";
 import type { Console } from "@/infra/console";
 import type { FileManager } from "@/infra/file-manager";
-import type { LintIssue } from "@/models/lint-issue";
+import type { LintIssue, Severity } from "@/models/lint-issue";
+import { meetsSeverity } from "@/models/lint-issue";
+import type { RunnerProgress, RunnerReport } from "@/models/runner-report";
 import type { StepResult } from "@/models/step-result";
 import { ok } from "@/models/step-result";
+import { explainIssue } from "@/writers/explanations";
+import { issuesToGithub } from "@/writers/github";
+import { issuesToGitlab } from "@/writers/gitlab";
+import { issuesToJson } from "@/writers/json";
+import { issuesToJunit } from "@/writers/junit";
 import { issuesToSarif } from "@/writers/sarif";
-import { formatIssues } from "@/writers/text";
+import { type CheckOutcome, issuesToSummary } from "@/writers/summary";
+import {
+  type FindingCap,
+  formatGroupedIssues,
+  formatIssue,
+  formatIssueSummary,
+  formatIssues,
+  formatRunnerProgress,
+  type GroupBy,
+} from "@/writers/text";
 
-export type ReportFormat = "text" | "sarif";
+export type ReportFormat =
+  | "text"
+  | "sarif"
+  | "json"
+  | "junit"
+  | "github"
+  | "gitlab"
+  | "summary";
 
-export async function reportStep(
+/**
+ * The `--format` to report in. `auto` picks `github` annotations inside
+ * GitHub Actions (GITHUB_ACTIONS=true) and `text` everywhere else.
+ */
+export function parseReportFormat(
+  raw: unknown,
+  env: Readonly<Record<string, string | undefined>> = process.env
+): ReportFormat {
+  if (
+    raw === "sarif" ||
+    raw === "json" ||
+    raw === "junit" ||
+    raw === "github" ||
+    raw === "gitlab" ||
+    raw === "summary"
+  ) {
+    return raw;
+  }
+  if (raw === "auto") return env.GITHUB_ACTIONS === "true" ? "github" : "text";
+  return "text";
+}
+
+function serializeReport(
+  format: Exclude<ReportFormat, "text">,
   issues: LintIssue[],
-  format: ReportFormat,
-  console: Console,
-  fileManager: FileManager,
-  sarifOutputPath?: string
-): Promise<StepResult> {
-  if (format === "sarif") {
-    const sarifJson = JSON.stringify(issuesToSarif(issues), null, 2);
-    if (sarifOutputPath) {
-      await fileManager.writeText(sarifOutputPath, sarifJson);
-    } else {
-      console.info(sarifJson);
-    }
+  runners: readonly RunnerReport[],
+  baselined: ReadonlySet<string>,
+  projectDir: string | undefined,
+  outcome: CheckOutcome
+): string {
+  if (format === "summary") {
+    const summary = issuesToSummary(issues, runners, outcome, baselined);
+    return JSON.stringify(summary, null, 2);
   }
+  if (format === "junit") return issuesToJunit(issues, runners, baselined);
+  if (format === "github") return issuesToGithub(issues, runners, baselined);
+  if (format === "gitlab") {
+    return JSON.stringify(issuesToGitlab(issues, projectDir), null, 2);
+  }
+  const report =
+    format === "sarif"
+      ? issuesToSarif(issues, runners, baselined, projectDir)
+      : issuesToJson(issues, runners, baselined);
+  return JSON.stringify(report, null, 2);
+}
 
-  if (format === "text") {
-    const text = formatIssues(issues);
-    if (text) console.error(text);
+export interface ReportStepOptions {
+  issues: LintIssue[];
+  format: ReportFormat;
+  console: Console;
+  fileManager: FileManager;
+  /** Write the report here, creating its parent directories, instead of printing it */
+  outputPath?: string;
+  runners?: readonly RunnerReport[];
+  /** Fingerprints of the findings the baseline already has */
+  baselined?: ReadonlySet<string>;
+  /** Print the report as well as writing it to `outputPath` */
+  tee?: boolean;
+  /** Makes the gitlab format's paths repository-relative */
+  projectDir?: string;
+  /** Adds each rule's rationale and fix hint to the text format */
+  explain?: boolean;
+  /** The pass/fail and duration the summary format reports; without it, a pass */
+  outcome?: CheckOutcome;
+}
+
+/**
+ * Print the report in `format`, or write it to `outputPath`. Text goes to
+ * stderr like the rest of the check's output; the other formats go to stdout.
+ */
+export async function reportStep(options: ReportStepOptions): Promise<StepResult> {
+  const { issues, format, console, fileManager, outputPath, projectDir } = options;
+  const { runners = [], baselined = new Set<string>(), tee = false } = options;
+  const { explain = false, outcome = { passed: true, durationMs: 0 } } = options;
+  const serialized =
+    format === "text"
+      ? formatIssues(issues, baselined, explain)
+      : serializeReport(format, issues, runners, baselined, projectDir, outcome);
+
+  if (outputPath) {
+    await fileManager.mkdir(dirname(outputPath), { parents: true });
+    await fileManager.writeText(outputPath, serialized);
+  }
+  // A clean run in text or github format has nothing to print
+  if ((!outputPath || tee) && serialized !== "") {
+    if (format === "text") console.error(serialized);
+    else console.info(serialized);
   }
 
   return ok(`Reported ${issues.length} issue(s) in ${format} format`);
 }
+
+/**
+ * Print one finished runner of a streamed text report, within `cap`; without
+ * `findings`, just its status line.
+ */
+export function reportRunnerProgress(
+  progress: RunnerProgress,
+  console: Console,
+  explain = false,
+  cap?: FindingCap,
+  findings = true
+): void {
+  const text = formatRunnerProgress(progress, explain, cap, findings);
+  const { status } = progress.report;
+  if (status === "error" || progress.issues.length > 0) console.error(text);
+  else if (status === "skipped" || status === "cancelled") console.warning(text);
+  else console.success(text);
+}
+
+/**
+ * The findings that fail the check: new, and at or above `failOn` or the
+ * threshold it gives each finding.
+ */
+export function failingIssues(
+  issues: readonly LintIssue[],
+  baselined: ReadonlySet<string>,
+  failOn: Severity | ((issue: LintIssue) => Severity)
+): LintIssue[] {
+  return issues.filter(
+    (issue) =>
+      !baselined.has(issue.fingerprint) &&
+      meetsSeverity(issue.severity, typeof failOn === "string" ? failOn : failOn(issue))
+  );
+}
+
+/**
+ * Print one finished runner for -q/--quiet: a failed runner's status line,
+ * else only its failingIssues, as many as `cap` lets through — none without
+ * `findings`, for a report that prints them grouped at the end.
+ */
+export function reportQuietRunnerProgress(
+  progress: RunnerProgress,
+  console: Console,
+  failOn: Severity | ((issue: LintIssue) => Severity),
+  explain = false,
+  cap?: FindingCap,
+  findings = true
+): void {
+  if (progress.report.status === "error") {
+    console.error(formatRunnerProgress(progress));
+    return;
+  }
+  if (!findings) return;
+  const failing = failingIssues(progress.issues, progress.baselined, failOn);
+  const shown = cap !== undefined ? cap.take(failing) : failing;
+  const lines = shown.flatMap((issue) =>
+    explain ? [formatIssue(issue), ...explainIssue(issue)] : [formatIssue(issue)]
+  );
+  if (lines.length > 0) console.error(lines.join("\n"));
+}
+
+/**
+ * Print a streamed report's findings under `--group-by` headings, once every
+ * runner is done, within `cap`.
+ */
+export function reportGroupedIssues(
+  issues: readonly LintIssue[],
+  console: Console,
+  groupBy: Exclude<GroupBy, "runner">,
+  baselined: ReadonlySet<string> = new Set(),
+  explain = false,
+  cap?: FindingCap
+): void {
+  const text = formatGroupedIssues(issues, groupBy, baselined, explain, cap);
+  if (text !== "") console.error(text);
+  const note = cap?.note() ?? "";
+  if (note !== "") console.error(note);
+}
+
+/** Close a streamed text report: the issues are out, so only the summary line */
+export function reportStreamedSummary(
+  issues: readonly LintIssue[],
+  console: Console,
+  baselined: ReadonlySet<string> = new Set()
+): void {
+  const summary = formatIssueSummary(issues, baselined);
+  if (summary) console.error(summary);
+}
@@ -3,10 +3,13 @@ import type { CommandRunner } from "@/infra/command-runner";
 import type { FileManager } from "@/infra/file-manager";
 import type { LanguagePlugin } from "@/languages/types";
 import type { LintIssue } from "@/models/lint-issue";
+import { isRunnerActive } from "@/runners/active";
+import { defaultJobs, mapPool } from "@/utils/pool";
 
 /**
  * Run all linter runners for the given language plugins and collect their issues.
- * Runners that report themselves unavailable are skipped.
+ * Runners that are disabled in config, do not apply to the project, or report
+ * themselves unavailable are skipped.
  */
 export async function runLinterCollection(
   projectDir: string,
@@ -16,17 +19,18 @@ export async function runLinterCollection(
   fileManager: FileManager
 ): Promise<LintIssue[]> {
   const opts = { projectDir, config, commandRunner, fileManager };
-  const results = await Promise.all(
-    languages.flatMap((plugin) =>
-      plugin.runners().map(async (runner) => {
-        const available = await runner.isAvailable(commandRunner, projectDir);
-        if (!available) {
-          const empty: LintIssue[] = [];
-          return empty;
-        }
-        return runner.run(opts);
-      })
-    )
+  const candidates = languages.flatMap((plugin) => plugin.runners());
+  const active = await Promise.all(
+    candidates.map((runner) => isRunnerActive(runner, opts))
   );
+  const enabled = candidates.filter((_, i) => active[i] === true);
+  const results = await mapPool(enabled, defaultJobs(), async (runner) => {
+    const available = await runner.isAvailable(commandRunner, projectDir);
+    if (!available) {
+      const empty: LintIssue[] = [];
+      return empty;
+    }
+    return runner.run(opts);
+  });
   return results.flat();
 }
@@ -0,0 +1,185 @@
+import { runnerRetry } from "@/config/schema";
+import type { Console } from "@/infra/console";
+import type { LintIssue } from "@/models/lint-issue";
+import {
+  computeCacheKey,
+  loadCachedIssues,
+  saveCachedIssues,
+} from "@/models/runner-cache";
+import type { RunnerReport } from "@/models/runner-report";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import {
+  CANCELLED_MESSAGE,
+  createRunnerClock,
+  raceDeadline,
+  withDeadline,
+} from "@/steps/runner-deadline";
+import { isTransientError, retryDelayMs, sleep } from "@/utils/retry";
+
+export interface RunnerOutcome {
+  report: RunnerReport;
+  issues: LintIssue[];
+}
+
+export interface RunRunnerOptions {
+  runner: LinterRunner;
+  /** What the runner is given; `signal` aborting kills it as "cancelled" */
+  runOpts: RunOptions;
+  /** Reuse the cached results of a cacheable runner whose inputs are unchanged */
+  useCache: boolean;
+  /** Seconds before the runner is killed and reported as an error */
+  timeoutS: number;
+  /** A missing tool is an "error" instead of "skipped" (`--require`) */
+  required: boolean;
+  /** Where the runner's status lines go */
+  cons?: Console;
+  /** Logs each retry, and skips under `quietSkips` (default: `cons`) */
+  verboseCons?: Console;
+  /** `--quiet-skips`: a missing tool's skip line goes to `verboseCons` only */
+  quietSkips?: boolean;
+}
+
+/**
+ * Run one runner, capturing availability, timing, and failures in its report.
+ * A throwing runner becomes an "error" report so the others' findings survive,
+ * and so does one that takes longer than `timeoutS` (its processes are killed;
+ * time its commands spend waiting for a --max-procs slot does not count).
+ * One still going when `runOpts.signal` aborts is killed and reported as
+ * "cancelled". With useCache, a cacheable runner whose inputs are unchanged is
+ * not re-run. A run failing with a transient error is retried, within the same
+ * timeout, as its retry policy allows.
+ */
+export async function runRunner(options: RunRunnerOptions): Promise<RunnerOutcome> {
+  const { runner, runOpts, useCache, timeoutS, required, cons } = options;
+  const { verboseCons = cons, quietSkips = false } = options;
+  const base = { runnerId: runner.id, name: runner.name };
+  const start = performance.now();
+  const elapsed = () => Math.round(performance.now() - start);
+  const timeoutMessage = `timed out after ${timeoutS}s`;
+  // Started now, but paused while the runner's commands wait for a process slot
+  const clock = createRunnerClock(timeoutS * 1000);
+  const { signal } = runOpts;
+  const opts: RunOptions = {
+    ...runOpts,
+    commandRunner: withDeadline(runOpts.commandRunner, clock, timeoutMessage, signal),
+  };
+  const retry = runnerRetry(runOpts.config, runner.id, runner.retry);
+  let attempts = 1;
+  const retried = () => (attempts > 1 ? { attempts } : {});
+
+  const runWithRetry = async (): Promise<LintIssue[]> => {
+    try {
+      return await runner.run(opts);
+    } catch (err) {
+      const message = err instanceof Error ? err.message : String(err);
+      const transient = isTransientError(message, retry.match);
+      if (!transient || signal?.aborted === true || attempts >= retry.attempts) {
+        throw err;
+      }
+      const delayMs = retryDelayMs(retry.backoff, attempts);
+      attempts++;
+      verboseCons?.verbose(
+        `  ${runner.name} hit a transient error, retrying in ${delayMs}ms ` +
+          `(attempt ${attempts}/${retry.attempts}): ${message}`
+      );
+      await sleep(delayMs, signal);
+      return runWithRetry();
+    }
+  };
+
+  const attempt = async (): Promise<RunnerOutcome> => {
+    const available = await runner.isAvailable(opts.commandRunner, opts.projectDir);
+    if (!available && required) {
+      const message = `required but not installed (${runner.installHint.description})`;
+      return {
+        report: {
+          ...base,
+          status: "error",
+          durationMs: elapsed(),
+          message,
+          missing: true,
+        },
+        issues: [],
+      };
+    }
+    if (!available) {
+      const { description } = runner.installHint;
+      const note = `  ${runner.name} not found — skipping (${description})`;
+      if (quietSkips) verboseCons?.verbose(note);
+      else cons?.warning(note);
+      return {
+        report: { ...base, status: "skipped", durationMs: elapsed() },
+        issues: [],
+      };
+    }
+
+    const { projectDir, config, commandRunner, fileManager } = opts;
+    const key = useCache
+      ? await computeCacheKey(runner, projectDir, config, commandRunner, fileManager)
+      : null;
+    if (key !== null) {
+      const cached = await loadCachedIssues(projectDir, runner.id, key, fileManager);
+      if (cached !== null) {
+        cons?.success(`  ${runner.name} (cached)`);
+        return {
+          report: { ...base, status: "ok", durationMs: elapsed(), cached: true },
+          issues: cached,
+        };
+      }
+    }
+
+    const issues = await runWithRetry();
+    if (key !== null) {
+      await saveCachedIssues(projectDir, runner.id, key, issues, fileManager);
+    }
+    return {
+      report: { ...base, status: "ok", durationMs: elapsed(), ...retried() },
+      issues,
+    };
+  };
+
+  try {
+    return await raceDeadline(attempt(), clock, timeoutMessage, signal);
+  } catch (err) {
+    // Whatever a killed runner threw, it failed because it was cancelled
+    if (signal?.aborted === true) return cancelledOutcome(runner, elapsed());
+    const message = err instanceof Error ? err.message : String(err);
+    cons?.error(`  ${runner.name} failed — ${message}`);
+    return {
+      report: {
+        ...base,
+        status: "error",
+        durationMs: elapsed(),
+        message,
+        ...retried(),
+      },
+      issues: [],
+    };
+  }
+}
+
+/** A runner stopped by --fail-fast, or never started because of it */
+export function cancelledOutcome(
+  runner: LinterRunner,
+  durationMs = 0
+): RunnerOutcome {
+  return {
+    report: {
+      runnerId: runner.id,
+      name: runner.name,
+      status: "cancelled",
+      durationMs,
+      message: CANCELLED_MESSAGE,
+    },
+    issues: [],
+  };
+}
+
+/** e.g. "Ruff: ok (cached) in 12ms, 3 issue(s)" */
+export function describeOutcome({ report, issues }: RunnerOutcome): string {
+  const cached = report.cached === true ? " (cached)" : "";
+  const found = report.status === "ok" ? `, ${issues.length} issue(s)` : "";
+  const tries = report.attempts !== undefined ? `, ${report.attempts} attempts` : "";
+  const timing = `in ${report.durationMs}ms${found}${tries}`;
+  return `${report.name}: ${report.status}${cached} ${timing}`;
+}
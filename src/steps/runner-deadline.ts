@@ -0,0 +1,117 @@
+import type { CommandRunner } from "@/infra/command-runner";
+
+export const CANCELLED_MESSAGE = "cancelled by --fail-fast";
+
+/**
+ * A runner's time budget. It pauses while every command the runner has in
+ * flight is waiting for a --max-procs slot, so a runner queued behind the
+ * others' commands does not time out before it gets to work.
+ */
+export interface RunnerClock {
+  /** Milliseconds of the budget left */
+  remaining(): number;
+  /** Track one command until `work` settles; its queued time is not counted */
+  command<T>(work: (onWait: (waiting: boolean) => void) => Promise<T>): Promise<T>;
+}
+
+export function createRunnerClock(budgetMs: number): RunnerClock {
+  const start = performance.now();
+  let inFlight = 0;
+  let waiting = 0;
+  let pausedMs = 0;
+  let pausedAt: number | undefined;
+
+  const update = () => {
+    const paused = waiting > 0 && waiting === inFlight;
+    if (paused && pausedAt === undefined) pausedAt = performance.now();
+    if (!paused && pausedAt !== undefined) {
+      pausedMs += performance.now() - pausedAt;
+      pausedAt = undefined;
+    }
+  };
+
+  return {
+    remaining() {
+      const now = performance.now();
+      const paused = pausedMs + (pausedAt !== undefined ? now - pausedAt : 0);
+      return start + budgetMs + paused - now;
+    },
+    async command(work) {
+      inFlight++;
+      let queued = false;
+      const onWait = (isWaiting: boolean) => {
+        if (isWaiting === queued) return;
+        queued = isWaiting;
+        waiting += isWaiting ? 1 : -1;
+        update();
+      };
+      try {
+        return await work(onWait);
+      } finally {
+        onWait(false);
+        inFlight--;
+        update();
+      }
+    },
+  };
+}
+
+/**
+ * Give every command the time left on `clock`; a command killed for running
+ * past it fails the runner with `message`. Commands also get `signal`, so
+ * aborting it kills them.
+ */
+export function withDeadline(
+  inner: CommandRunner,
+  clock: RunnerClock,
+  message: string,
+  signal?: AbortSignal
+): CommandRunner {
+  return {
+    async run(args, runOpts) {
+      if (signal?.aborted === true) throw new Error(CANCELLED_MESSAGE);
+      const remaining = Math.round(clock.remaining());
+      if (remaining <= 0) throw new Error(message);
+      const timeout = Math.min(runOpts?.timeout ?? remaining, remaining);
+      const result = await clock.command((onWait) =>
+        inner.run(args, {
+          ...runOpts,
+          timeout,
+          onWait,
+          ...(signal !== undefined && { signal }),
+        })
+      );
+      if (result.timedOut === true) throw new Error(message);
+      if (result.cancelled === true) throw new Error(CANCELLED_MESSAGE);
+      return result;
+    },
+  };
+}
+
+/** Reject with `message` when `clock` runs out first, or when `signal` aborts */
+export async function raceDeadline<T>(
+  work: Promise<T>,
+  clock: RunnerClock,
+  message: string,
+  signal?: AbortSignal
+): Promise<T> {
+  let timer: ReturnType<typeof setTimeout> | undefined;
+  let onAbort: (() => void) | undefined;
+  const expired = new Promise<never>((_, reject) => {
+    // The clock pauses while the runner waits for a slot, so check it again
+    const expire = () => {
+      const left = clock.remaining();
+      if (left > 0) timer = setTimeout(expire, left);
+      else reject(new Error(message));
+    };
+    timer = setTimeout(expire, clock.remaining());
+    onAbort = () => reject(new Error(CANCELLED_MESSAGE));
+    signal?.addEventListener("abort", onAbort, { once: true });
+  });
+  try {
+    return await Promise.race([work, expired]);
+  } finally {
+    clearTimeout(timer);
+    if (onAbort !== undefined) signal?.removeEventListener("abort", onAbort);
+  }
+}
@@ -0,0 +1,80 @@
+import type { BaselineEntry } from "@/models/baseline";
+import { classifyFingerprint } from "@/models/baseline";
+import type { LintIssue } from "@/models/lint-issue";
+import type { RunnerProgress, RunnerReport } from "@/models/runner-report";
+import type { LinterRunner } from "@/runners/types";
+import { duplicateKey } from "@/utils/dedupe-issues";
+
+/** Rules superseded by a runner that ran, e.g. golangci-lint/gosec next to gosec */
+export function supersededRules(
+  runners: readonly LinterRunner[],
+  reports: readonly RunnerReport[]
+): Set<string> {
+  const ran = new Set(reports.filter((r) => r.status === "ok").map((r) => r.runnerId));
+  return new Set(
+    runners.filter((r) => ran.has(r.id)).flatMap((r) => r.supersedes ?? [])
+  );
+}
+
+export interface FinishedRunner {
+  runner: LinterRunner;
+  report: RunnerReport;
+  issues: LintIssue[];
+}
+
+/**
+ * Hand each finished runner to `onDone`, holding back one with findings a
+ * still-running runner may supersede until that runner has finished too.
+ * With `dedup`, a finding an earlier-shown runner already reported is left
+ * out of the later runner's block.
+ */
+export function createProgressEmitter(
+  runners: readonly LinterRunner[],
+  baseline: ReadonlyMap<string, BaselineEntry>,
+  onDone: (progress: RunnerProgress) => void,
+  dedup: boolean
+): (finished: FinishedRunner) => void {
+  const pending = new Set(runners.map((r) => r.id));
+  const reports: RunnerReport[] = [];
+  // duplicateKey → the runner whose block showed it
+  const shownBy = new Map<string, string>();
+  let held: FinishedRunner[] = [];
+  let done = 0;
+
+  const isDuplicate = (issue: LintIssue) => {
+    if (!dedup) return false;
+    const key = duplicateKey(issue);
+    const owner = shownBy.get(key);
+    if (owner === undefined) shownBy.set(key, issue.linter);
+    return owner !== undefined && owner !== issue.linter;
+  };
+
+  const waiting = ({ runner, issues }: FinishedRunner) =>
+    runners.some(
+      (other) =>
+        other.id !== runner.id &&
+        pending.has(other.id) &&
+        issues.some((issue) => other.supersedes?.includes(issue.rule) === true)
+    );
+
+  return (finished) => {
+    pending.delete(finished.runner.id);
+    reports.push(finished.report);
+    held.push(finished);
+    const ready = held.filter((entry) => !waiting(entry));
+    held = held.filter((entry) => waiting(entry));
+    const superseded = supersededRules(runners, reports);
+    for (const { report, issues } of ready) {
+      const shown = issues.filter(
+        (issue) => !superseded.has(issue.rule) && !isDuplicate(issue)
+      );
+      const baselined = new Set(
+        shown
+          .map((issue) => issue.fingerprint)
+          .filter((fp) => classifyFingerprint(fp, baseline) === "existing")
+      );
+      done++;
+      onDone({ report, issues: shown, baselined, done, total: runners.length });
+    }
+  };
+}
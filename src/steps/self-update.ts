@@ -0,0 +1,189 @@
+import { basename } from "node:path";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
+import type { StepResult } from "@/models/step-result";
+import { error, ok } from "@/models/step-result";
+import { safeParseJson } from "@/utils/parse";
+import { getVersion, semverLt } from "@/utils/version";
+
+const REPO = "Questi0nM4rk/ai-guardrails";
+const LATEST_RELEASE_URL = `https://api.github.com/repos/${REPO}/releases/latest`;
+const DOWNLOAD_URL = `https://github.com/${REPO}/releases/download`;
+/** The asset release.yml publishes next to the binaries */
+const CHECKSUMS_ASSET = "checksums.sha256";
+const RELEASE_TAG = /^v(\d+\.\d+\.\d+)$/;
+
+export interface Release {
+  tag: string;
+  version: string;
+}
+
+/**
+ * The release asset built for this OS/arch, as named by release.yml
+ * (`ai-guardrails-linux-x64`), or null where no binary is published.
+ */
+export function releaseAssetName(
+  platform: string = process.platform,
+  arch: string = process.arch
+): string | null {
+  if (platform !== "linux" && platform !== "darwin") return null;
+  if (arch !== "x64" && arch !== "arm64") return null;
+  return `ai-guardrails-${platform}-${arch}`;
+}
+
+/**
+ * True when `execPath` is a compiled ai-guardrails binary. Under `bun run`
+ * it is bun itself; see packageManagerUpdate for installs a package manager owns.
+ */
+export function isReleaseBinary(execPath: string): boolean {
+  return basename(execPath).startsWith("ai-guardrails");
+}
+
+/** Install paths a package manager owns, with the command that updates them */
+const PACKAGE_MANAGED = [
+  { marker: "/.bun/install/global/", update: "bun update -g ai-guardrails" },
+  { marker: "/node_modules/", update: "npm install -g ai-guardrails@latest" },
+  { marker: "/Cellar/", update: "brew upgrade ai-guardrails" },
+] as const;
+
+/**
+ * The command that updates `execPath` when a package manager installed it
+ * (npm, pnpm or yarn under node_modules, bun's global dir, Homebrew's Cellar),
+ * or null for a binary installed by hand
+ */
+export function packageManagerUpdate(execPath: string): string | null {
+  const managed = PACKAGE_MANAGED.find(({ marker }) => execPath.includes(marker));
+  return managed?.update ?? null;
+}
+
+/** The SHA-256 `checksums` lists for `asset` (sha256sum format), or null */
+export function findChecksum(checksums: string, asset: string): string | null {
+  for (const line of checksums.split("\n")) {
+    const match = /^([0-9a-f]{64})\s+\*?(.+)$/.exec(line.trim());
+    if (match?.[2] === asset) return match[1] ?? null;
+  }
+  return null;
+}
+
+function describeFailure(stderr: string, exitCode: number): string {
+  return stderr.trim() || `exit code ${exitCode}`;
+}
+
+/**
+ * Ask the GitHub releases API for the latest release. `latest` is set only
+ * when it is newer than `current`; the result says which, or why it failed.
+ */
+export async function checkForUpdateStep(
+  commandRunner: CommandRunner,
+  current: string = getVersion()
+): Promise<{ result: StepResult; latest: Release | null }> {
+  const response = await commandRunner.run([
+    "curl",
+    "-fsSL",
+    "--max-time",
+    "30",
+    "-H",
+    "Accept: application/vnd.github+json",
+    LATEST_RELEASE_URL,
+  ]);
+  if (response.exitCode !== 0) {
+    const detail = describeFailure(response.stderr, response.exitCode);
+    const result = error(`Could not reach GitHub releases: ${detail}`);
+    return { result, latest: null };
+  }
+  const body = safeParseJson(response.stdout);
+  const tag =
+    typeof body === "object" && body !== null && "tag_name" in body
+      ? body.tag_name
+      : undefined;
+  const version = typeof tag === "string" ? RELEASE_TAG.exec(tag)?.[1] : undefined;
+  if (typeof tag !== "string" || version === undefined) {
+    return { result: error("Unexpected response from GitHub releases"), latest: null };
+  }
+  if (!semverLt(current, version)) {
+    return { result: ok(`ai-guardrails ${current} is up to date`), latest: null };
+  }
+  return {
+    result: ok(`ai-guardrails ${tag} is available (installed: ${current})`),
+    latest: { tag, version },
+  };
+}
+
+/** First field of `sha256sum`/`shasum -a 256` output, or null if neither ran */
+async function sha256(
+  path: string,
+  commandRunner: CommandRunner
+): Promise<string | null> {
+  for (const cmd of [["sha256sum"], ["shasum", "-a", "256"]]) {
+    const result = await commandRunner.run([...cmd, path]);
+    const digest = result.stdout.trim().split(/\s+/)[0];
+    if (result.exitCode === 0 && digest !== undefined && digest !== "") {
+      return digest;
+    }
+  }
+  return null;
+}
+
+/**
+ * Download `asset` of `release` next to `target`, check it against the
+ * release's checksums.sha256, then move it over `target`. A rename within one
+ * directory is atomic, so `target` is either the old binary or the new one.
+ */
+export async function selfUpdateStep(
+  release: Release,
+  asset: string,
+  target: string,
+  commandRunner: CommandRunner,
+  fileManager: FileManager
+): Promise<StepResult> {
+  const download = `${target}.download`;
+  const base = `${DOWNLOAD_URL}/${release.tag}`;
+  const fail = async (message: string): Promise<StepResult> => {
+    if (await fileManager.exists(download)) await fileManager.delete(download);
+    return error(message);
+  };
+
+  const fetched = await commandRunner.run([
+    "curl",
+    "-fsSL",
+    "--max-time",
+    "300",
+    "-o",
+    download,
+    `${base}/${asset}`,
+  ]);
+  if (fetched.exitCode !== 0) {
+    const detail = describeFailure(fetched.stderr, fetched.exitCode);
+    return fail(`Download of ${asset} failed: ${detail}`);
+  }
+  const checksums = await commandRunner.run([
+    "curl",
+    "-fsSL",
+    "--max-time",
+    "60",
+    `${base}/${CHECKSUMS_ASSET}`,
+  ]);
+  if (checksums.exitCode !== 0) {
+    const detail = describeFailure(checksums.stderr, checksums.exitCode);
+    return fail(`Download of ${CHECKSUMS_ASSET} failed: ${detail}`);
+  }
+  const expected = findChecksum(checksums.stdout, asset);
+  if (expected === null) return fail(`${asset} is not in ${CHECKSUMS_ASSET}`);
+  const actual = await sha256(download, commandRunner);
+  if (actual === null) return fail("Neither sha256sum nor shasum is available");
+  if (actual !== expected) {
+    return fail(`Checksum mismatch for ${asset}: expected ${expected}, got ${actual}`);
+  }
+
+  for (const args of [
+    ["chmod", "755", download],
+    ["mv", "-f", download, target],
+  ]) {
+    const result = await commandRunner.run(args);
+    if (result.exitCode !== 0) {
+      const detail = describeFailure(result.stderr, result.exitCode);
+      return fail(`Could not replace ${target}: ${detail}`);
+    }
+  }
+  return ok(`Updated ai-guardrails to ${release.tag}`);
+}
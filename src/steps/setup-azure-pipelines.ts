@@ -0,0 +1,74 @@
+import { join } from "node:path";
+import type { FileManager } from "@/infra/file-manager";
+import type { StepResult } from "@/models/step-result";
+import { error, ok, skip } from "@/models/step-result";
+import { AZURE_PIPELINES_FILE } from "@/utils/ci-provider";
+import { CI_CHECK_COMMAND, CI_JUNIT_REPORT, ciInstallCommands } from "@/utils/ci-tools";
+import { HASH_PREFIX, withHashHeader } from "@/utils/hash";
+
+/**
+ * Full azure-pipelines.yml body. Hosted Ubuntu agents have no bun and run
+ * steps as a non-root user, so bun is installed first and apt/pip use sudo.
+ */
+export function buildAzurePipelinesConfig(languages: ReadonlySet<string>): string {
+  const install = ciInstallCommands(languages, { sudo: true }).map(
+    (cmd) => `      ${cmd}`
+  );
+  return withHashHeader(`trigger:
+  branches:
+    include:
+      - "*"
+
+pr:
+  branches:
+    include:
+      - "*"
+
+pool:
+  vmImage: ubuntu-latest
+
+steps:
+  - script: |
+      curl -fsSL https://bun.sh/install | bash
+      echo "##vso[task.prependpath]$HOME/.bun/bin"
+    displayName: Install bun
+  - script: |
+${install.join("\n")}
+    displayName: Install tools
+  - script: ${CI_CHECK_COMMAND}
+    displayName: Check
+  - task: PublishTestResults@2
+    condition: always()
+    inputs:
+      testResultsFormat: JUnit
+      testResultsFiles: ${CI_JUNIT_REPORT}
+`);
+}
+
+/**
+ * Write azure-pipelines.yml.
+ *
+ * A missing file, or one carrying our hash header, is (re)generated whole.
+ * A hand-written pipeline is left alone for the user to extend.
+ */
+export async function setupAzurePipelinesStep(
+  projectDir: string,
+  fileManager: FileManager,
+  languages: ReadonlySet<string>
+): Promise<StepResult> {
+  const dest = join(projectDir, AZURE_PIPELINES_FILE);
+  try {
+    if (await fileManager.exists(dest)) {
+      const existing = await fileManager.readText(dest);
+      if (!existing.startsWith(HASH_PREFIX)) {
+        const hint = "add the ai-guardrails check step yourself";
+        return skip(`${AZURE_PIPELINES_FILE} is hand-written — ${hint}`);
+      }
+    }
+    await fileManager.writeText(dest, buildAzurePipelinesConfig(languages));
+    return ok(`CI config written to ${AZURE_PIPELINES_FILE}`);
+  } catch (err) {
+    const message = err instanceof Error ? err.message : String(err);
+    return error(`CI setup failed: ${message}`);
+  }
+}
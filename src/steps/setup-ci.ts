@@ -2,6 +2,7 @@ import { join } from "node:path";
 import type { FileManager } from "@/infra/file-manager";
 import type { StepResult } from "@/models/step-result";
 import { error, ok } from "@/models/step-result";
+import { ciToolPackages } from "@/utils/ci-tools";
 
 function buildCiWorkflow(languages: ReadonlySet<string>): string {
   const hasTs = languages.has("typescript");
@@ -26,8 +27,7 @@ function buildCiWorkflow(languages: ReadonlySet<string>): string {
   }
 
   // pip installs: Python tools + codespell (always needed)
-  const pipPackages: string[] = ["codespell"];
-  if (hasPython) pipPackages.unshift("ruff", "pyright");
+  const pipPackages = ciToolPackages(languages).pip;
   const pipStepName = hasPython ? "Install Python tools" : "Install codespell";
   steps.push(`      - name: ${pipStepName}`);
   steps.push(`        run: pip install ${pipPackages.join(" ")}`);
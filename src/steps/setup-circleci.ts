@@ -0,0 +1,68 @@
+import { dirname, join } from "node:path";
+import type { FileManager } from "@/infra/file-manager";
+import type { StepResult } from "@/models/step-result";
+import { error, ok, skip } from "@/models/step-result";
+import { CIRCLECI_CONFIG_FILE } from "@/utils/ci-provider";
+import { CI_CHECK_COMMAND, CI_JUNIT_REPORT, ciInstallCommands } from "@/utils/ci-tools";
+import { HASH_PREFIX, withHashHeader } from "@/utils/hash";
+
+export const CIRCLECI_JOB_NAME = "ai-guardrails";
+
+/** Full .circleci/config.yml body: one docker job plus a workflow running it */
+export function buildCircleciConfig(languages: ReadonlySet<string>): string {
+  const install = ciInstallCommands(languages).map((cmd) => `            ${cmd}`);
+  return withHashHeader(`version: 2.1
+
+jobs:
+  ${CIRCLECI_JOB_NAME}:
+    docker:
+      - image: oven/bun:1
+    steps:
+      - checkout
+      - run:
+          name: Install tools
+          command: |
+${install.join("\n")}
+      - run:
+          name: Check
+          command: ${CI_CHECK_COMMAND}
+      - store_test_results:
+          path: ${CI_JUNIT_REPORT}
+          when: always
+
+workflows:
+  check:
+    jobs:
+      - ${CIRCLECI_JOB_NAME}
+`);
+}
+
+/**
+ * Write .circleci/config.yml.
+ *
+ * A missing file, or one carrying our hash header, is (re)generated whole.
+ * A hand-written config is left alone — CircleCI has no include mechanism
+ * to merge a job into safely, so the user adds it by hand.
+ */
+export async function setupCircleciStep(
+  projectDir: string,
+  fileManager: FileManager,
+  languages: ReadonlySet<string>
+): Promise<StepResult> {
+  const dest = join(projectDir, CIRCLECI_CONFIG_FILE);
+  try {
+    if (await fileManager.exists(dest)) {
+      const existing = await fileManager.readText(dest);
+      if (!existing.startsWith(HASH_PREFIX)) {
+        const hint = `add the ${CIRCLECI_JOB_NAME} job yourself`;
+        return skip(`${CIRCLECI_CONFIG_FILE} is hand-written — ${hint}`);
+      }
+    }
+    await fileManager.mkdir(dirname(dest), { parents: true });
+    await fileManager.writeText(dest, buildCircleciConfig(languages));
+    return ok(`CI config written to ${CIRCLECI_CONFIG_FILE}`);
+  } catch (err) {
+    const message = err instanceof Error ? err.message : String(err);
+    return error(`CI setup failed: ${message}`);
+  }
+}
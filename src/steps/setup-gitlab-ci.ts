@@ -0,0 +1,132 @@
+import { join } from "node:path";
+import type { FileManager } from "@/infra/file-manager";
+import type { StepResult } from "@/models/step-result";
+import { error, ok } from "@/models/step-result";
+import { GITLAB_CI_FILE } from "@/utils/ci-provider";
+import { CI_CHECK_COMMAND, CI_JUNIT_REPORT, ciInstallCommands } from "@/utils/ci-tools";
+import { HASH_PREFIX, withHashHeader } from "@/utils/hash";
+
+export const GITLAB_JOB_NAME = "ai-guardrails";
+export const GITLAB_BLOCK_BEGIN =
+  "# >>> ai-guardrails job (managed by ai-guardrails init — edits are overwritten)";
+export const GITLAB_BLOCK_END = "# <<< ai-guardrails job";
+
+const DEFAULT_STAGE = "test";
+
+function buildGitlabJob(languages: ReadonlySet<string>, stage: string): string {
+  const beforeScript = ciInstallCommands(languages).map((cmd) => `    - ${cmd}`);
+
+  return `${GITLAB_JOB_NAME}:
+  stage: ${stage}
+  image: oven/bun:1
+  before_script:
+${beforeScript.join("\n")}
+  script:
+    - ${CI_CHECK_COMMAND}
+  artifacts:
+    when: always
+    reports:
+      junit: ${CI_JUNIT_REPORT}
+`;
+}
+
+/** Full .gitlab-ci.yml body for a project without one (or one we own) */
+export function buildGitlabCiConfig(languages: ReadonlySet<string>): string {
+  return withHashHeader(
+    `stages:\n  - ${DEFAULT_STAGE}\n\n${buildGitlabJob(languages, DEFAULT_STAGE)}`
+  );
+}
+
+function unquote(value: string): string {
+  return value.trim().replace(/^["']|["']$/g, "");
+}
+
+/**
+ * Read the top-level `stages:` list from a GitLab CI file.
+ * Handles both the inline `[a, b]` and the block `- a` forms.
+ * Returns null when the file declares no stages.
+ */
+export function parseGitlabStages(content: string): string[] | null {
+  const lines = content.split("\n");
+  const start = lines.findIndex((line) => /^stages:/.test(line));
+  if (start === -1) return null;
+
+  const inline = (lines[start] ?? "").slice("stages:".length).trim();
+  if (inline.startsWith("[")) {
+    return inline
+      .replace(/^\[|\]$/g, "")
+      .split(",")
+      .map(unquote)
+      .filter((s) => s.length > 0);
+  }
+
+  const stages: string[] = [];
+  for (const line of lines.slice(start + 1)) {
+    if (line.trim() === "" || line.trim().startsWith("#")) continue;
+    const item = /^\s+-\s*(.+)$/.exec(line);
+    if (item === null) break;
+    stages.push(unquote(item[1] ?? ""));
+  }
+  return stages;
+}
+
+/**
+ * Insert or refresh the guardrails job inside a hand-written .gitlab-ci.yml.
+ *
+ * The job lives between marker comments so re-running init replaces only
+ * our block. It uses the `test` stage unless the file declares its own
+ * stages without one, in which case it joins the last declared stage.
+ */
+export function mergeGitlabCiConfig(
+  existing: string,
+  languages: ReadonlySet<string>
+): string {
+  const stages = parseGitlabStages(existing);
+  const stage =
+    stages === null || stages.includes(DEFAULT_STAGE)
+      ? DEFAULT_STAGE
+      : (stages.at(-1) ?? DEFAULT_STAGE);
+  const job = buildGitlabJob(languages, stage);
+  const block = `${GITLAB_BLOCK_BEGIN}\n${job}${GITLAB_BLOCK_END}\n`;
+
+  const begin = existing.indexOf(GITLAB_BLOCK_BEGIN);
+  const end = existing.indexOf(GITLAB_BLOCK_END, begin);
+  if (begin !== -1 && end !== -1) {
+    const after = existing.slice(end + GITLAB_BLOCK_END.length).replace(/^\n/, "");
+    return `${existing.slice(0, begin)}${block}${after}`;
+  }
+
+  const base = existing.endsWith("\n") ? existing : `${existing}\n`;
+  return `${base}\n${block}`;
+}
+
+/**
+ * Write the GitLab CI job.
+ *
+ * A missing file, or one carrying our hash header, is (re)generated whole.
+ * A hand-written file is never replaced — the job is merged in as a
+ * marked block instead, even with --force.
+ */
+export async function setupGitlabCiStep(
+  projectDir: string,
+  fileManager: FileManager,
+  languages: ReadonlySet<string>
+): Promise<StepResult> {
+  const dest = join(projectDir, GITLAB_CI_FILE);
+  try {
+    const existing = (await fileManager.exists(dest))
+      ? await fileManager.readText(dest)
+      : null;
+
+    if (existing === null || existing.startsWith(HASH_PREFIX)) {
+      await fileManager.writeText(dest, buildGitlabCiConfig(languages));
+      return ok(`CI config written to ${GITLAB_CI_FILE}`);
+    }
+
+    await fileManager.writeText(dest, mergeGitlabCiConfig(existing, languages));
+    return ok(`${GITLAB_JOB_NAME} job merged into ${GITLAB_CI_FILE}`);
+  } catch (err) {
+    const message = err instanceof Error ? err.message : String(err);
+    return error(`CI setup failed: ${message}`);
+  }
+}
@@ -23,6 +23,25 @@ function issueToEntry(issue: LintIssue, projectDir: string): BaselineEntry {
   };
 }
 
+/**
+ * Write every issue to the baseline file (relative to projectDir), replacing
+ * its contents. Returns the number of entries written.
+ */
+export async function writeBaseline(
+  projectDir: string,
+  issues: readonly LintIssue[],
+  fileManager: FileManager,
+  baselinePath: string = BASELINE_PATH
+): Promise<number> {
+  const entries: BaselineEntry[] = issues.map((issue) =>
+    issueToEntry(issue, projectDir)
+  );
+  const dest = join(projectDir, baselinePath);
+  await fileManager.mkdir(dirname(dest), { parents: true });
+  await fileManager.writeText(dest, JSON.stringify(entries, null, 2));
+  return entries.length;
+}
+
 export async function snapshotStep(
   projectDir: string,
   languages: readonly LanguagePlugin[],
@@ -44,16 +63,16 @@ export async function snapshotStep(
       (issue) => !config.isAllowed(issue.rule, issue.file)
     );
 
-    const afterAllow = await filterAllowComments(filtered, fileManager);
-    const entries: BaselineEntry[] = afterAllow.map((issue) =>
-      issueToEntry(issue, projectDir)
+    const afterAllow = await filterAllowComments(filtered, fileManager, projectDir);
+    const count = await writeBaseline(
+      projectDir,
+      afterAllow,
+      fileManager,
+      baselinePath
     );
-    const dest = join(projectDir, baselinePath ?? BASELINE_PATH);
-    await fileManager.mkdir(dirname(dest), { parents: true });
-    await fileManager.writeText(dest, JSON.stringify(entries, null, 2));
 
     return ok(
-      `Snapshot captured: ${entries.length} issue(s) written to ${baselinePath ?? BASELINE_PATH}`
+      `Snapshot captured: ${count} issue(s) written to ${baselinePath ?? BASELINE_PATH}`
     );
   } catch (err) {
     const message = err instanceof Error ? err.message : String(err);
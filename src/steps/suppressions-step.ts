@@ -0,0 +1,61 @@
+import { join } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import type { FileManager } from "@/infra/file-manager";
+import { DEFAULT_IGNORE } from "@/languages/constants";
+import { CACHE_DIR } from "@/models/paths";
+import type { StepResult } from "@/models/step-result";
+import { error, ok } from "@/models/step-result";
+import { parseSuppressions, type Suppression } from "@/utils/allow-comment-re";
+import type { PathMatcher } from "@/utils/ignore-file";
+
+export interface SuppressionEntry extends Suppression {
+  /** Project-relative path of the file the directive is in */
+  file: string;
+}
+
+/**
+ * Every inline suppression in the project — ai-guardrails-allow and
+ * guardrails:ignore comments — sorted by file and line, for `check
+ * --report-suppressions`. Errors when any guardrails:ignore lacks a reason.
+ */
+export async function auditSuppressionsStep(
+  projectDir: string,
+  config: ResolvedConfig,
+  fileManager: FileManager,
+  ignore: PathMatcher | null
+): Promise<{ result: StepResult; suppressions: SuppressionEntry[] }> {
+  const files = await fileManager.glob("**/*", projectDir, [
+    ...DEFAULT_IGNORE,
+    ...config.ignorePaths,
+    `${CACHE_DIR}/**`,
+  ]);
+  const perFile = await Promise.all(
+    files
+      .filter((file) => ignore?.(file) !== true)
+      .map(async (file): Promise<SuppressionEntry[]> => {
+        try {
+          const source = await fileManager.readText(join(projectDir, file));
+          return parseSuppressions(source).map((s) => ({ ...s, file }));
+        } catch {
+          return [];
+        }
+      })
+  );
+  const suppressions = perFile
+    .flat()
+    .toSorted((a, b) => a.file.localeCompare(b.file) || a.line - b.line);
+
+  const unexplained = suppressions.filter((s) => s.reason === "").length;
+  if (unexplained > 0) {
+    return {
+      result: error(
+        `${unexplained} of ${suppressions.length} suppression(s) give no reason`
+      ),
+      suppressions,
+    };
+  }
+  return {
+    result: ok(`${suppressions.length} active suppression(s)`),
+    suppressions,
+  };
+}
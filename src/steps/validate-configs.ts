@@ -4,28 +4,7 @@ import type { ConfigGenerator } from "@/generators/types";
 import type { FileManager } from "@/infra/file-manager";
 import type { StepResult } from "@/models/step-result";
 import { error, ok } from "@/models/step-result";
-import { computeHash } from "@/utils/hash";
-
-const HASH_HEADER_PATTERN =
-  /^(?:\/\/|#) ai-guardrails:sha256=([0-9a-f]{64})$|^<!-- ai-guardrails:sha256=([0-9a-f]{64}) -->$/;
-
-function hasValidHash(content: string): boolean {
-  const firstNewline = content.indexOf("\n");
-  if (firstNewline === -1) return false;
-  const headerLine = content.slice(0, firstNewline);
-  const rest = content.slice(firstNewline + 1);
-  const match = HASH_HEADER_PATTERN.exec(headerLine);
-  const storedHash = match?.[1] ?? match?.[2];
-  if (!storedHash) return false;
-  return computeHash(rest) === storedHash;
-}
-
-function hasHashHeader(content: string): boolean {
-  const firstNewline = content.indexOf("\n");
-  if (firstNewline === -1) return false;
-  const headerLine = content.slice(0, firstNewline);
-  return HASH_HEADER_PATTERN.test(headerLine);
-}
+import { computeHash, parseHashHeader, TEMPLATE_VERSION } from "@/utils/hash";
 
 async function validateOne(
   generator: ConfigGenerator,
@@ -45,9 +24,14 @@ async function validateOne(
   // Files with no header are user-owned and not tamper-checked.
   // Files with a valid hash were written by us (generated or merged) and are intact.
   // Files with a header but invalid hash have been manually edited after generation.
-  if (hasHashHeader(content) && !hasValidHash(content)) {
+  const header = parseHashHeader(content);
+  if (header !== null && computeHash(header.body) !== header.hash) {
     return `tampered: ${generator.configFile}`;
   }
+  // Intact, but written from an older template: `init --upgrade` refreshes it
+  if (header !== null && header.templateVersion < TEMPLATE_VERSION) {
+    return `outdated: ${generator.configFile} (template v${header.templateVersion})`;
+  }
 
   // Staleness detection (comparing on-disk content against fresh generation) is
   // intentionally not implemented here. Merged files also receive a valid hash header
@@ -0,0 +1,201 @@
+import { relative } from "node:path";
+import { minimatch } from "minimatch";
+import type { ResolvedConfig } from "@/config/schema";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { Console } from "@/infra/console";
+import { SilentConsole } from "@/infra/console";
+import type { FileManager } from "@/infra/file-manager";
+import type { FileWatcher } from "@/infra/file-watcher";
+import type { LanguagePlugin } from "@/languages/types";
+import type { LintIssue } from "@/models/lint-issue";
+import type { RunnerReport } from "@/models/runner-report";
+import type { LinterRunner } from "@/runners/types";
+import type { CheckStepOptions } from "@/steps/check-step";
+import { checkStep } from "@/steps/check-step";
+import { createBatcher } from "@/utils/debounce";
+import { formatIssue } from "@/writers/text";
+
+export interface WatchStepOptions
+  extends Pick<
+    CheckStepOptions,
+    "jobs" | "baselinePath" | "timeout" | "ignore" | "includeGenerated"
+  > {
+  /** Stops watching when aborted */
+  signal: AbortSignal;
+  /** Quiet period after the last change before re-running (default: 300) */
+  debounceMs?: number;
+  /** Clear the terminal before each redraw */
+  clearScreen?: boolean;
+}
+
+export const DEFAULT_DEBOUNCE_MS = 300;
+
+// Our own cache and audit writes, and git's bookkeeping, would re-trigger runs
+const UNWATCHED_DIRS = [".git/", ".ai-guardrails/"];
+
+const CLEAR_SCREEN = "\x1b[2J\x1b[H";
+
+/** Issues listed per runner before the rest are summarised */
+const MAX_LISTED_ISSUES = 5;
+
+interface RunnerState {
+  report: RunnerReport;
+  /** Issues not in the baseline */
+  newIssues: LintIssue[];
+}
+
+/**
+ * Runners whose inputs include one of the changed project-relative paths:
+ * `watchInputs`, else `cache.inputs`, plus the runner's config file. A runner
+ * declaring no inputs is affected by every change.
+ */
+export function runnersForChanges(
+  runners: readonly LinterRunner[],
+  changed: readonly string[]
+): LinterRunner[] {
+  return runners.filter((runner) => {
+    const inputs = runner.watchInputs ?? runner.cache?.inputs;
+    if (inputs === undefined) return true;
+    return changed.some(
+      (path) =>
+        path === runner.configFile ||
+        inputs.some((glob) => minimatch(path, glob, { dot: true }))
+    );
+  });
+}
+
+/** Restrict each plugin to the given runners */
+function onlyRunners(
+  languages: readonly LanguagePlugin[],
+  runners: readonly LinterRunner[]
+): LanguagePlugin[] {
+  const ids = new Set(runners.map((r) => r.id));
+  return languages.map((plugin) => ({
+    ...plugin,
+    runners: () => plugin.runners().filter((r) => ids.has(r.id)),
+  }));
+}
+
+/** e.g. "src/a.py, src/b.py (+3 more)" */
+function describeChanges(changed: readonly string[]): string {
+  const shown = changed.slice(0, 3).join(", ");
+  return changed.length > 3 ? `${shown} (+${changed.length - 3} more)` : shown;
+}
+
+function statusLines(projectDir: string, state: RunnerState): string[] {
+  const { report, newIssues } = state;
+  switch (report.status) {
+    case "skipped":
+      return [`  - ${report.name}  not installed`];
+    case "disabled":
+      return [`  - ${report.name}  disabled`];
+    case "error":
+      return [`  ! ${report.name}  failed — ${report.message ?? "unknown error"}`];
+    case "cancelled":
+      return [`  - ${report.name}  cancelled`];
+    case "ok": {
+      if (newIssues.length === 0) return [`  ✓ ${report.name}`];
+      const listed = newIssues
+        .slice(0, MAX_LISTED_ISSUES)
+        .map((issue) => {
+          const file = relative(projectDir, issue.file);
+          return `      ${formatIssue({ ...issue, file })}`;
+        });
+      const more = newIssues.length - listed.length;
+      return [
+        `  ✗ ${report.name}  ${newIssues.length} new issue(s)`,
+        ...listed,
+        ...(more > 0 ? [`      … and ${more} more`] : []),
+      ];
+    }
+  }
+}
+
+/**
+ * Run every enabled runner, then watch `projectDir` and re-run only the
+ * runners affected by each debounced batch of changes, redrawing one compact
+ * status block per run. Resolves once `options.signal` is aborted.
+ */
+export async function watchStep(
+  projectDir: string,
+  languages: readonly LanguagePlugin[],
+  config: ResolvedConfig,
+  commandRunner: CommandRunner,
+  fileManager: FileManager,
+  cons: Console,
+  watcher: FileWatcher,
+  options: WatchStepOptions
+): Promise<void> {
+  const { signal, ignore, debounceMs = DEFAULT_DEBOUNCE_MS } = options;
+  const state = new Map<string, RunnerState>();
+  const allRunners = languages.flatMap((plugin) => plugin.runners());
+
+  const render = (changed: readonly string[]) => {
+    const lines = [
+      changed.length === 0
+        ? "ai-guardrails watch"
+        : `ai-guardrails watch — changed: ${describeChanges(changed)}`,
+      ...[...state.values()]
+        .toSorted((a, b) => a.report.name.localeCompare(b.report.name))
+        .flatMap((runnerState) => statusLines(projectDir, runnerState)),
+      "",
+      "Watching for changes — Ctrl-C to exit",
+    ];
+    const prefix = options.clearScreen === true ? CLEAR_SCREEN : "";
+    cons.info(`${prefix}${lines.join("\n")}`);
+  };
+
+  const runChecks = async (runners: readonly LinterRunner[], changed: string[]) => {
+    // Progress lines would scroll the status block away; failures show in it
+    const checked = await checkStep(
+      projectDir,
+      onlyRunners(languages, runners),
+      config,
+      commandRunner,
+      fileManager,
+      new SilentConsole(),
+      {
+        useCache: true,
+        ...(options.jobs !== undefined && { jobs: options.jobs }),
+        ...(options.baselinePath !== undefined && {
+          baselinePath: options.baselinePath,
+        }),
+        ...(options.timeout !== undefined && { timeout: options.timeout }),
+        ...(ignore !== undefined && { ignore }),
+        ...(options.includeGenerated === true && { includeGenerated: true }),
+      }
+    );
+    for (const report of checked.runners) {
+      const newIssues = checked.issues.filter(
+        (issue) =>
+          issue.linter === report.runnerId && !checked.baselined.has(issue.fingerprint)
+      );
+      state.set(report.runnerId, { report, newIssues });
+    }
+    if (checked.runners.length === 0 && checked.result.status === "error") {
+      cons.error(checked.result.message);
+    }
+    render(changed);
+  };
+
+  // Runs never overlap; a batch arriving mid-run waits for it
+  let queue = runChecks(allRunners, []);
+  const batcher = createBatcher<string>(debounceMs, (changed) => {
+    const affected = runnersForChanges(allRunners, changed);
+    if (affected.length === 0) return;
+    queue = queue.then(() => runChecks(affected, changed));
+  });
+
+  const handle = watcher.watch(projectDir, (relPath) => {
+    if (UNWATCHED_DIRS.some((dir) => relPath.startsWith(dir))) return;
+    if (ignore?.(relPath) === true) return;
+    batcher.add(relPath);
+  });
+
+  await new Promise<void>((resolve) => {
+    if (signal.aborted) resolve();
+    else signal.addEventListener("abort", () => resolve(), { once: true });
+  });
+  handle.close();
+  batcher.cancel();
+}
@@ -7,3 +7,52 @@
  *   -- ai-guardrails-allow selene/shadowing "reason"
  */
 export const ALLOW_COMMENT_RE = /ai-guardrails-allow\s+([\w-]+\/[\w\-.]+)\s+"([^"]+)"/;
+
+/**
+ * Matches the unified guardrails:ignore directive, whose reason is the rest
+ * of the comment, quoted or not:
+ *   // guardrails:ignore biome/noConsole CLI entry point prints by design
+ *   #  guardrails:ignore ruff/S603 "arguments are a fixed list"
+ */
+export const IGNORE_COMMENT_RE = /guardrails:ignore\s+([\w-]+\/[\w\-.]+)(.*)$/;
+
+/** Block-comment closers that end the line after a directive's reason */
+const COMMENT_CLOSER = /\s*(?:\*\/|-->|#\}|\]\])\s*$/;
+
+export interface Suppression {
+  /** 1-indexed line of the directive itself */
+  line: number;
+  /** 1-indexed column where the directive starts */
+  col: number;
+  rule: string;
+  /** Empty when a guardrails:ignore gives none */
+  reason: string;
+}
+
+function ignoreReason(rest: string): string {
+  const reason = rest.replace(COMMENT_CLOSER, "").trim();
+  const quoted = /^"([^"]*)"$/.exec(reason);
+  return (quoted?.[1] ?? reason).trim();
+}
+
+/** Every ai-guardrails-allow and guardrails:ignore directive in `source` */
+export function parseSuppressions(source: string): Suppression[] {
+  const found: Suppression[] = [];
+  const lines = source.split("\n");
+  for (let i = 0; i < lines.length; i++) {
+    const line = lines[i];
+    if (line === undefined) continue;
+    const allow = ALLOW_COMMENT_RE.exec(line);
+    if (allow?.[1] !== undefined) {
+      const reason = allow[2] ?? "";
+      found.push({ line: i + 1, col: allow.index + 1, rule: allow[1], reason });
+      continue;
+    }
+    const ignore = IGNORE_COMMENT_RE.exec(line);
+    if (ignore?.[1] !== undefined) {
+      const reason = ignoreReason(ignore[2] ?? "");
+      found.push({ line: i + 1, col: ignore.index + 1, rule: ignore[1], reason });
+    }
+  }
+  return found;
+}
@@ -0,0 +1,106 @@
+import { join } from "node:path";
+import { minimatch } from "minimatch";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
+
+/** Ref compared against when `--changed-since` is given without a value */
+export const DEFAULT_CHANGED_SINCE_REF = "origin/main";
+
+/**
+ * List project-relative files changed between `ref` and HEAD (merge-base diff,
+ * `git diff --name-only <ref>...HEAD`). Deleted files are dropped.
+ * Throws when git fails, e.g. for an unknown ref.
+ */
+export async function listChangedFiles(
+  ref: string,
+  projectDir: string,
+  commandRunner: CommandRunner,
+  fileManager: FileManager
+): Promise<string[]> {
+  const result = await commandRunner.run(
+    ["git", "diff", "--name-only", `${ref}...HEAD`],
+    { cwd: projectDir }
+  );
+  if (result.exitCode !== 0) {
+    const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+    throw new Error(`git diff against ${ref} failed: ${detail}`);
+  }
+
+  const listed = result.stdout
+    .split("\n")
+    .map((line) => line.trim())
+    .filter((line) => line.length > 0);
+  const existing: string[] = [];
+  for (const file of listed) {
+    if (await fileManager.exists(join(projectDir, file))) existing.push(file);
+  }
+  return existing;
+}
+
+/**
+ * List project-relative files staged for commit
+ * (`git diff --cached --name-only --diff-filter=ACM`). Deletions and renames
+ * away are excluded by the filter, so every entry exists in the work tree,
+ * though not always with its staged content: see listPartiallyStagedFiles.
+ * Throws when git fails, e.g. outside a repository.
+ */
+export async function listStagedFiles(
+  projectDir: string,
+  commandRunner: CommandRunner
+): Promise<string[]> {
+  const result = await commandRunner.run(
+    ["git", "diff", "--cached", "--name-only", "--diff-filter=ACM"],
+    { cwd: projectDir }
+  );
+  if (result.exitCode !== 0) {
+    const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+    throw new Error(`git diff --cached failed: ${detail}`);
+  }
+  return result.stdout
+    .split("\n")
+    .map((line) => line.trim())
+    .filter((line) => line.length > 0);
+}
+
+/**
+ * The `staged` files that also have changes not staged for commit
+ * (`git diff --name-only`): their work-tree content is not what is committed.
+ * Throws when git fails.
+ */
+export async function listPartiallyStagedFiles(
+  staged: readonly string[],
+  projectDir: string,
+  commandRunner: CommandRunner
+): Promise<string[]> {
+  if (staged.length === 0) return [];
+  const result = await commandRunner.run(["git", "diff", "--name-only"], {
+    cwd: projectDir,
+  });
+  if (result.exitCode !== 0) {
+    const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+    throw new Error(`git diff failed: ${detail}`);
+  }
+  const unstaged = new Set(result.stdout.split("\n").map((line) => line.trim()));
+  return staged.filter((file) => unstaged.has(file));
+}
+
+/** The staged content of a project-relative file (`git show :<file>`) */
+export async function readStagedContent(
+  file: string,
+  projectDir: string,
+  commandRunner: CommandRunner
+): Promise<string> {
+  const result = await commandRunner.run(["git", "show", `:${file}`], {
+    cwd: projectDir,
+  });
+  if (result.exitCode !== 0) {
+    const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+    throw new Error(`git show :${file} failed: ${detail}`);
+  }
+  return result.stdout;
+}
+
+/** Keep only the files matching a glob pattern (minimatch, dotfiles included) */
+export function matchFiles(files: readonly string[], pattern: string): string[] {
+  return files.filter((file) => minimatch(file, pattern, { dot: true }));
+}
@@ -0,0 +1,40 @@
+import { join } from "node:path";
+import type { FileManager } from "@/infra/file-manager";
+
+export const CI_PROVIDERS = ["github", "gitlab", "circleci", "azure", "none"] as const;
+export type CiProvider = (typeof CI_PROVIDERS)[number];
+
+export const GITLAB_CI_FILE = ".gitlab-ci.yml";
+export const CIRCLECI_CONFIG_FILE = ".circleci/config.yml";
+export const AZURE_PIPELINES_FILE = "azure-pipelines.yml";
+
+const DETECTED_CONFIG_FILES: readonly (readonly [string, CiProvider])[] = [
+  [GITLAB_CI_FILE, "gitlab"],
+  [CIRCLECI_CONFIG_FILE, "circleci"],
+  [AZURE_PIPELINES_FILE, "azure"],
+];
+
+function isCiProvider(value: unknown): value is CiProvider {
+  return CI_PROVIDERS.some((p) => p === value);
+}
+
+/**
+ * Decide which CI config init should generate.
+ *
+ * An explicit `--ci <provider>` wins and `--no-ci` means none. Otherwise an
+ * existing .gitlab-ci.yml, .circleci/config.yml or azure-pipelines.yml
+ * selects that provider; everything else defaults to GitHub, whether or
+ * not .github/ already exists.
+ */
+export async function resolveCiProvider(
+  projectDir: string,
+  fileManager: FileManager,
+  flag: unknown
+): Promise<CiProvider> {
+  if (flag === false) return "none";
+  if (isCiProvider(flag)) return flag;
+  for (const [file, provider] of DETECTED_CONFIG_FILES) {
+    if (await fileManager.exists(join(projectDir, file))) return provider;
+  }
+  return "github";
+}
@@ -0,0 +1,48 @@
+/** Tools a CI job must install before `ai-guardrails check` can run them */
+export interface CiToolPackages {
+  /** Debian packages; python3-pip is always needed for codespell */
+  apt: string[];
+  /** PyPI packages: Python tools when Python is detected, codespell always */
+  pip: string[];
+  /** Install the project's JS dependencies when a bun lockfile exists */
+  bunInstall: boolean;
+}
+
+export const CI_JUNIT_REPORT = "ai-guardrails-junit.xml";
+
+/** The check command every provider runs, writing a JUnit report */
+export const CI_CHECK_COMMAND =
+  `bunx ai-guardrails check --format junit --output ${CI_JUNIT_REPORT}`;
+
+const BUN_INSTALL_COMMAND =
+  "if [ -f bun.lock ] || [ -f bun.lockb ]; then bun install --frozen-lockfile; fi";
+
+/** Packages to install in CI for the detected languages */
+export function ciToolPackages(languages: ReadonlySet<string>): CiToolPackages {
+  const hasShell = languages.has("shell");
+  const hasPython = languages.has("python");
+  return {
+    apt: ["python3-pip", ...(hasShell ? ["shellcheck", "shfmt"] : [])],
+    pip: [...(hasPython ? ["ruff", "pyright"] : []), "codespell"],
+    bunInstall: languages.has("typescript"),
+  };
+}
+
+/**
+ * Shell commands installing every tool on a Debian-based image with bun,
+ * such as oven/bun:1. With `sudo`, apt and pip run as root from a
+ * non-root user, as on hosted Ubuntu agents.
+ */
+export function ciInstallCommands(
+  languages: ReadonlySet<string>,
+  options: { sudo?: boolean } = {}
+): string[] {
+  const { apt, pip, bunInstall } = ciToolPackages(languages);
+  const root = options.sudo === true ? "sudo " : "";
+  const aptInstall = `${root}apt-get install -y --no-install-recommends`;
+  return [
+    `${root}apt-get update && ${aptInstall} ${apt.join(" ")}`,
+    `${root}pip3 install --break-system-packages ${pip.join(" ")}`,
+    ...(bunInstall ? [BUN_INSTALL_COMMAND] : []),
+  ];
+}
@@ -1,4 +1,4 @@
-const COMMANDS = "init install generate check snapshot status report hook completion";
+const COMMANDS = "init install generate check watch snapshot status doctor version update list config report hook hooks completion";
 
 export function generateBashCompletion(): string {
   return `# bash completion for ai-guardrails
@@ -15,23 +15,48 @@ _ai_guardrails() {
 
   case "\${COMP_WORDS[1]}" in
     init)
-      COMPREPLY=($(compgen -W "--yes --profile --force --upgrade --interactive --no-hooks --no-ci --no-agent-rules --config-strategy --project-dir" -- "$cur"))
+      COMPREPLY=($(compgen -W "--yes --profile --force --upgrade --dry-run --merge --interactive --no-hooks --no-ci --ci --config-format --no-agent-rules --config-strategy --init-from --strict-detection --project-dir" -- "$cur"))
       ;;
     install)
-      COMPREPLY=($(compgen -W "--upgrade --project-dir" -- "$cur"))
+      COMPREPLY=($(compgen -W "--upgrade --dry-run --project-dir" -- "$cur"))
       ;;
     generate)
-      COMPREPLY=($(compgen -W "--check --project-dir" -- "$cur"))
+      COMPREPLY=($(compgen -W "--check --strict-detection --project-dir" -- "$cur"))
       ;;
     check)
-      COMPREPLY=($(compgen -W "--format --baseline --strict --project-dir" -- "$cur"))
+      COMPREPLY=($(compgen -W "--format --output --tee --baseline --update-baseline --strict --fail-on --enable --disable --only --require --require-all --fix --diff --staged --module --path --timeout --jobs --max-procs --fail-fast --strict-detection --batch-size --no-cache --no-ignore --include-generated --no-dedup --check-external --timings --quiet-skips --explain --max-findings --max-per-runner --group-by --metrics --clear-cache --report-suppressions --changed-since --since-last-run --stdin --stdin-filename --project-dir" -- "$cur"))
+      ;;
+    watch)
+      COMPREPLY=($(compgen -W "--debounce --no-ignore --include-generated --project-dir" -- "$cur"))
       ;;
     snapshot)
       COMPREPLY=($(compgen -W "--baseline --project-dir" -- "$cur"))
       ;;
+    doctor)
+      COMPREPLY=($(compgen -W "--strict --project-dir" -- "$cur"))
+      ;;
+    version)
+      COMPREPLY=($(compgen -W "--check-update" -- "$cur"))
+      ;;
+    update)
+      COMPREPLY=($(compgen -W "--yes" -- "$cur"))
+      ;;
+    list)
+      COMPREPLY=($(compgen -W "--format --project-dir" -- "$cur"))
+      ;;
     status|report)
       COMPREPLY=($(compgen -W "--project-dir" -- "$cur"))
       ;;
+    config)
+      if [ "$COMP_CWORD" -eq 2 ]; then
+        COMPREPLY=($(compgen -W "validate show schema" -- "$cur"))
+      else
+        COMPREPLY=($(compgen -W "--enable --disable --path --project-dir" -- "$cur"))
+      fi
+      ;;
+    hooks)
+      COMPREPLY=($(compgen -W "install uninstall" -- "$cur"))
+      ;;
     hook)
       COMPREPLY=($(compgen -W "dangerous-cmd protect-configs protect-reads suppress-comments" -- "$cur"))
       ;;
@@ -39,7 +64,7 @@ _ai_guardrails() {
       COMPREPLY=($(compgen -W "bash zsh fish" -- "$cur"))
       ;;
     *)
-      COMPREPLY=($(compgen -W "--project-dir --quiet --no-color --version --help" -- "$cur"))
+      COMPREPLY=($(compgen -W "--project-dir --config --quiet --color --no-color --verbose --debug --version --help" -- "$cur"))
       ;;
   esac
 }
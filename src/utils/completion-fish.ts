@@ -7,43 +7,106 @@ complete -c ai-guardrails -n '__fish_use_subcommand' -a 'init' -d 'Per-project s
 complete -c ai-guardrails -n '__fish_use_subcommand' -a 'install' -d 'One-time machine setup'
 complete -c ai-guardrails -n '__fish_use_subcommand' -a 'generate' -d 'Regenerate all managed config files'
 complete -c ai-guardrails -n '__fish_use_subcommand' -a 'check' -d 'Hold-the-line enforcement'
+complete -c ai-guardrails -n '__fish_use_subcommand' -a 'watch' -d 'Re-run affected runners on file changes'
 complete -c ai-guardrails -n '__fish_use_subcommand' -a 'snapshot' -d 'Capture current lint state as baseline'
 complete -c ai-guardrails -n '__fish_use_subcommand' -a 'status' -d 'Project health dashboard'
+complete -c ai-guardrails -n '__fish_use_subcommand' -a 'doctor' -d 'Report tool availability and versions'
+complete -c ai-guardrails -n '__fish_use_subcommand' -a 'version' -d 'Print the build version, commit and date'
+complete -c ai-guardrails -n '__fish_use_subcommand' -a 'update' -d 'Replace this binary with the latest release'
+complete -c ai-guardrails -n '__fish_use_subcommand' -a 'list' -d 'Show detected languages and which runners will run'
+complete -c ai-guardrails -n '__fish_use_subcommand' -a 'config' -d 'Validate, show or describe the project config'
 complete -c ai-guardrails -n '__fish_use_subcommand' -a 'report' -d 'Show recent check run history'
 complete -c ai-guardrails -n '__fish_use_subcommand' -a 'hook' -d 'Internal hook dispatcher'
+complete -c ai-guardrails -n '__fish_use_subcommand' -a 'hooks' -d 'Manage the git pre-commit hook'
 complete -c ai-guardrails -n '__fish_use_subcommand' -a 'completion' -d 'Generate shell completion script'
 
 # Global flags
 complete -c ai-guardrails -l project-dir -d 'Override working directory' -r
-complete -c ai-guardrails -l quiet -d 'Suppress info/success output'
-complete -c ai-guardrails -l no-color -d 'Disable ANSI color output'
+complete -c ai-guardrails -l config -d 'Read the project config from this file' -r
+complete -c ai-guardrails -s q -l quiet -d 'Print only failures: findings, errors and a summary'
+complete -c ai-guardrails -l color -d 'Colorize output' -r -a 'auto always never'
+complete -c ai-guardrails -l no-color -d 'Same as --color never'
+complete -c ai-guardrails -s v -l verbose -d 'Log detection evidence and runner timings'
+complete -c ai-guardrails -l debug -d 'Also log every command run'
 
 # init flags
 complete -c ai-guardrails -n '__fish_seen_subcommand_from init' -l yes -d 'Accept all defaults'
-complete -c ai-guardrails -n '__fish_seen_subcommand_from init' -l profile -d 'Set profile' -r -a 'strict standard minimal'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from init' -l profile -d 'Set profile' -r -a 'strict standard lenient'
 complete -c ai-guardrails -n '__fish_seen_subcommand_from init' -l force -d 'Overwrite existing managed files'
 complete -c ai-guardrails -n '__fish_seen_subcommand_from init' -l upgrade -d 'Refresh generated files'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from init' -l dry-run -d 'Print what init would write without writing'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from init' -l merge -d 'Merge recommended rules into existing ruff.toml'
 complete -c ai-guardrails -n '__fish_seen_subcommand_from init' -l interactive -d 'Prompt for each optional step'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from init' -l strict-detection -d 'Act only on manifest-confirmed languages'
 complete -c ai-guardrails -n '__fish_seen_subcommand_from init' -l no-hooks -d 'Skip lefthook install'
 complete -c ai-guardrails -n '__fish_seen_subcommand_from init' -l no-ci -d 'Skip CI workflow generation'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from init' -l ci -d 'CI provider' -r -a 'github gitlab circleci azure none'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from init' -l config-format -d 'Project config format' -r -a 'toml yaml'
 complete -c ai-guardrails -n '__fish_seen_subcommand_from init' -l no-agent-rules -d 'Skip AGENTS.md and IDE rule files'
 complete -c ai-guardrails -n '__fish_seen_subcommand_from init' -l config-strategy -d 'Config handling strategy' -r -a 'merge replace skip'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from init' -l init-from -d 'Adopt existing tool configs' -r -a 'existing'
 complete -c ai-guardrails -n '__fish_seen_subcommand_from init' -l project-dir -d 'Override working directory' -r
 
 # install flags
 complete -c ai-guardrails -n '__fish_seen_subcommand_from install' -l upgrade -d 'Overwrite existing machine config'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from install' -l dry-run -d 'Print tool install commands without running them'
 complete -c ai-guardrails -n '__fish_seen_subcommand_from install' -l project-dir -d 'Override working directory' -r
 
 # generate flags
 complete -c ai-guardrails -n '__fish_seen_subcommand_from generate' -l check -d 'Verify files are up-to-date'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from generate' -l strict-detection -d 'Act only on manifest-confirmed languages'
 complete -c ai-guardrails -n '__fish_seen_subcommand_from generate' -l project-dir -d 'Override working directory' -r
 
 # check flags
-complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l format -d 'Output format' -r -a 'text sarif'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l format -d 'Output format' -r -a 'text sarif json junit github gitlab summary auto'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l output -d 'Write report to file' -r
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l tee -d 'Also print the report written with --output'
 complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l baseline -d 'Custom baseline path' -r
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l update-baseline -d 'Rewrite the baseline from current findings'
 complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l strict -d 'Ignore baseline'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l fail-on -d 'Lowest severity that fails' -r -a 'error warning info'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l enable -d 'Comma-separated runner ids to force on' -r
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l disable -d 'Comma-separated runner ids to skip' -r
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l only -d 'Run just these comma-separated runner ids' -r
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l require -d 'Fail when the tools of these runners are missing' -r
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l require-all -d 'Fail when the tool of any enabled runner is missing'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l fix -d 'Apply safe autofixes'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l diff -d 'Preview what --fix would change'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l staged -d 'Only check staged files'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l module -d 'Only check the Go module(s) under path' -r
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l path -d 'Only check these comma-separated directories' -r
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l timeout -d 'Kill a runner after this many seconds' -r
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l jobs -d 'Max runners in parallel' -r
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l max-procs -d 'Max tool processes at once' -r
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l fail-fast -d 'Stop at the first failing runner'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l strict-detection -d 'Act only on manifest-confirmed languages'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l batch-size -d 'Max files per tool invocation' -r
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l no-cache -d 'Ignore cached results'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l no-ignore -d 'Check paths in ignore files too'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l include-generated -d 'Check generated files too'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l no-dedup -d 'Keep duplicate findings from overlapping runners'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l check-external -d 'Also check external links in markdown'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l timings -d 'Show per-runner timings, even with --quiet'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l quiet-skips -d 'Log missing tools skip lines only with --verbose'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l max-findings -d 'Print at most n findings (0 = all)' -r
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l max-per-runner -d 'Print at most n findings per runner' -r
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l group-by -d 'Group printed findings' -r -a 'runner file severity'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l explain -d 'Print rule rationale and fix hints'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l metrics -d 'Append a JSON-lines metrics record' -r
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l clear-cache -d 'Delete cached results'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l report-suppressions -d 'List inline suppressions'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l changed-since -d 'Only check files changed since a git ref'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l since-last-run -d 'Only check files changed since the last such run'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l stdin -d 'Check content piped to stdin'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l stdin-filename -d 'Project path of the stdin content' -r
 complete -c ai-guardrails -n '__fish_seen_subcommand_from check' -l project-dir -d 'Override working directory' -r
 
+# watch flags
+complete -c ai-guardrails -n '__fish_seen_subcommand_from watch' -l debounce -d 'Quiet period in ms before re-running' -r
+complete -c ai-guardrails -n '__fish_seen_subcommand_from watch' -l no-ignore -d 'Watch paths in ignore files too'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from watch' -l include-generated -d 'Check generated files too'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from watch' -l project-dir -d 'Override working directory' -r
+
 # snapshot flags
 complete -c ai-guardrails -n '__fish_seen_subcommand_from snapshot' -l baseline -d 'Custom output path' -r
 complete -c ai-guardrails -n '__fish_seen_subcommand_from snapshot' -l project-dir -d 'Override working directory' -r
@@ -51,9 +114,35 @@ complete -c ai-guardrails -n '__fish_seen_subcommand_from snapshot' -l project-d
 # status flags
 complete -c ai-guardrails -n '__fish_seen_subcommand_from status' -l project-dir -d 'Override working directory' -r
 
+# doctor flags
+complete -c ai-guardrails -n '__fish_seen_subcommand_from doctor' -l strict -d 'Exit 1 when tools are missing or outdated'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from doctor' -l project-dir -d 'Override working directory' -r
+
+# version flags
+complete -c ai-guardrails -n '__fish_seen_subcommand_from version' -l check-update -d 'Ask GitHub whether a newer release exists'
+
+# update flags
+complete -c ai-guardrails -n '__fish_seen_subcommand_from update' -s y -l yes -d 'Update without asking for confirmation'
+
+# list flags
+complete -c ai-guardrails -n '__fish_seen_subcommand_from list' -l format -d 'Output format' -r -a 'text json'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from list' -l project-dir -d 'Override working directory' -r
+
 # report flags
 complete -c ai-guardrails -n '__fish_seen_subcommand_from report' -l project-dir -d 'Override working directory' -r
 
+# config subcommands
+complete -c ai-guardrails -n '__fish_seen_subcommand_from config' -a 'validate' -d 'Check .ai-guardrails/config.toml for problems'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from config' -a 'show' -d 'Print the effective config'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from config' -a 'schema' -d 'Print the JSON Schema of config.toml'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from show' -l enable -d 'Runner ids to force on' -r
+complete -c ai-guardrails -n '__fish_seen_subcommand_from show' -l disable -d 'Runner ids to skip' -r
+complete -c ai-guardrails -n '__fish_seen_subcommand_from show' -l path -d 'Show the config for a directory' -r
+
+# hooks subcommands
+complete -c ai-guardrails -n '__fish_seen_subcommand_from hooks' -a 'install' -d 'Install the pre-commit hook'
+complete -c ai-guardrails -n '__fish_seen_subcommand_from hooks' -a 'uninstall' -d 'Remove the pre-commit hook'
+
 # hook subcommands
 complete -c ai-guardrails -n '__fish_seen_subcommand_from hook' -a 'dangerous-cmd' -d 'Check for dangerous shell commands'
 complete -c ai-guardrails -n '__fish_seen_subcommand_from hook' -a 'protect-configs' -d 'Protect managed config files'
@@ -7,18 +7,29 @@ _ai_guardrails() {
     'install:One-time machine setup'
     'generate:Regenerate all managed config files'
     'check:Hold-the-line enforcement'
+    'watch:Re-run affected runners on file changes'
     'snapshot:Capture current lint state as baseline'
     'status:Project health dashboard'
+    'doctor:Report tool availability and versions'
+    'version:Print the build version, commit and date'
+    'update:Replace this binary with the latest release'
+    'list:Show detected languages and which runners will run'
+    'config:Validate, show or describe the project config'
     'report:Show recent check run history'
     'hook:Internal hook dispatcher'
+    'hooks:Manage the git pre-commit hook'
     'completion:Generate shell completion script'
   )
 
   local -a global_opts
   global_opts=(
     '--project-dir[Override working directory]:dir:_files -/'
-    '--quiet[Suppress info/success output]'
-    '--no-color[Disable ANSI color output]'
+    '--config[Read the project config from this file]:file:_files'
+    '(-q --quiet)'{-q,--quiet}'[Print only failures: findings, errors and a summary]'
+    '--color[Colorize output]:when:(auto always never)'
+    '--no-color[Same as --color never]'
+    '(-v --verbose)'{-v,--verbose}'[Log detection evidence and runner timings]'
+    '--debug[Also log every command run]'
     '--version[Print version]'
     '--help[Show help]'
   )
@@ -37,31 +48,84 @@ _ai_guardrails() {
         init)
           _arguments \\
             '--yes[Accept all defaults]' \\
-            '--profile[Set profile]:profile:(strict standard minimal)' \\
+            '--profile[Set profile]:profile:(strict standard lenient)' \\
             '--force[Overwrite existing managed files]' \\
             '--upgrade[Refresh generated files]' \\
+            '--dry-run[Print what init would write without writing]' \\
+            '--merge[Merge recommended rules into existing ruff.toml]' \\
             '--interactive[Prompt for each optional step]' \\
             '--no-hooks[Skip lefthook install]' \\
             '--no-ci[Skip CI workflow generation]' \\
+            '--ci[CI provider]:provider:(github gitlab circleci azure none)' \\
+            '--config-format[Project config format]:format:(toml yaml)' \\
             '--no-agent-rules[Skip AGENTS.md and IDE rule files]' \\
             '--config-strategy[Config handling strategy]:strategy:(merge replace skip)' \\
+            '--init-from[Adopt existing tool configs]:source:(existing)' \\
+            '--strict-detection[Act only on manifest-confirmed languages]' \\
             '--project-dir[Override working directory]:dir:_files -/'
           ;;
         install)
           _arguments \\
             '--upgrade[Overwrite existing machine config]' \\
+            '--dry-run[Print tool install commands without running them]' \\
             '--project-dir[Override working directory]:dir:_files -/'
           ;;
         generate)
           _arguments \\
             '--check[Verify files are up-to-date]' \\
+            '--strict-detection[Act only on manifest-confirmed languages]' \\
             '--project-dir[Override working directory]:dir:_files -/'
           ;;
         check)
           _arguments \\
-            '--format[Output format]:format:(text sarif)' \\
+            '--format[Output format]:format:(text sarif json junit github gitlab summary auto)' \\
+            '--output[Write report to file]:file:_files' \\
+            '--tee[Also print the report written with --output]' \\
             '--baseline[Custom baseline path]:file:_files' \\
+            '--update-baseline[Rewrite the baseline from current findings]' \\
             '--strict[Ignore baseline]' \\
+            '--fail-on[Lowest severity that fails]:level:(error warning info)' \\
+            '--enable[Comma-separated runner ids to force on]:runners:' \\
+            '--disable[Comma-separated runner ids to skip]:runners:' \\
+            '--only[Run just these comma-separated runner ids]:runners:' \\
+            '--require[Fail when the tools of these runners are missing]:runners:' \\
+            '--require-all[Fail when the tool of any enabled runner is missing]' \\
+            '--fix[Apply safe autofixes]' \\
+            '--diff[Preview what --fix would change]' \\
+            '--staged[Only check staged files]' \\
+            '--module[Only check the Go module(s) under path]:directory:_files -/' \\
+            '--path[Only check these comma-separated directories]:directory:_files -/' \\
+            '--timeout[Kill a runner after this many seconds]:seconds:' \\
+            '--jobs[Max runners in parallel]:jobs:' \\
+            '--max-procs[Max tool processes at once]:procs:' \\
+            '--fail-fast[Stop at the first failing runner]' \\
+            '--strict-detection[Act only on manifest-confirmed languages]' \\
+            '--batch-size[Max files per tool invocation]:n:' \\
+            '--no-cache[Ignore cached results]' \\
+            '--no-ignore[Check paths in ignore files too]' \\
+            '--include-generated[Check generated files too]' \\
+            '--no-dedup[Keep duplicate findings from overlapping runners]' \\
+            '--check-external[Also check external links in markdown]' \\
+            '--timings[Show per-runner timings, even with --quiet]' \\
+            '--quiet-skips[Log missing tools skip lines only with --verbose]' \\
+            '--explain[Print rule rationale and fix hints]' \\
+            '--max-findings[Print at most n findings (0 = all)]:n:' \\
+            '--max-per-runner[Print at most n findings per runner]:n:' \\
+            '--group-by[Group printed findings]:key:(runner file severity)' \\
+            '--metrics[Append a JSON-lines metrics record]:file:_files' \\
+            '--clear-cache[Delete cached results]' \\
+            '--report-suppressions[List inline suppressions]' \\
+            '--changed-since[Only check files changed since a git ref]:ref:' \\
+            '--since-last-run[Only check files changed since the last such run]' \\
+            '--stdin[Check content piped to stdin]' \\
+            '--stdin-filename[Project path of the stdin content]:file:_files' \\
+            '--project-dir[Override working directory]:dir:_files -/'
+          ;;
+        watch)
+          _arguments \\
+            '--debounce[Quiet period in ms before re-running]:ms:' \\
+            '--no-ignore[Watch paths in ignore files too]' \\
+            '--include-generated[Check generated files too]' \\
             '--project-dir[Override working directory]:dir:_files -/'
           ;;
         snapshot)
@@ -69,10 +133,45 @@ _ai_guardrails() {
             '--baseline[Custom output path]:file:_files' \\
             '--project-dir[Override working directory]:dir:_files -/'
           ;;
+        doctor)
+          _arguments \\
+            '--strict[Exit 1 when tools are missing or outdated]' \\
+            '--project-dir[Override working directory]:dir:_files -/'
+          ;;
+        version)
+          _arguments \\
+            '--check-update[Ask GitHub whether a newer release exists]'
+          ;;
+        update)
+          _arguments \\
+            '(-y --yes)'{-y,--yes}'[Update without asking for confirmation]'
+          ;;
+        list)
+          _arguments \\
+            '--format[Output format]:format:(text json)' \\
+            '--project-dir[Override working directory]:dir:_files -/'
+          ;;
         status|report)
           _arguments \\
             '--project-dir[Override working directory]:dir:_files -/'
           ;;
+        config)
+          local -a actions
+          actions=(
+            'validate:Check .ai-guardrails/config.toml for problems'
+            'show:Print the effective config'
+            'schema:Print the JSON Schema of config.toml'
+          )
+          _describe 'action' actions
+          ;;
+        hooks)
+          local -a actions
+          actions=(
+            'install:Install the pre-commit hook'
+            'uninstall:Remove the pre-commit hook'
+          )
+          _describe 'action' actions
+          ;;
         hook)
           local -a hooks
           hooks=(
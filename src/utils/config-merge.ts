@@ -3,7 +3,7 @@ import { parse as parseToml, stringify as stringifyToml } from "smol-toml";
 import type { ConfigStrategy } from "@/config/schema";
 import type { FileManager } from "@/infra/file-manager";
 import { deepMerge, isPlainObject } from "@/utils/deep-merge";
-import { withHashHeader, withJsoncHashHeader } from "@/utils/hash";
+import { isOutdatedTemplate, withHashHeader, withJsoncHashHeader } from "@/utils/hash";
 
 /** File extensions that support structured merge (JSON/JSONC and TOML). */
 const MERGEABLE_EXTENSIONS = new Set([".json", ".jsonc", ".toml"]);
@@ -85,6 +85,9 @@ function serializeForMerge(data: Record<string, unknown>, configFile: string): s
 
 /**
  * Apply config merge strategy: merge/replace/skip for an existing file.
+ * `merge`, when given, replaces the generic deep merge for this file. An
+ * untouched file from an older template is replaced rather than merged, as
+ * merging would keep its old values.
  * Returns the content to write, or null if the file should be skipped.
  */
 export async function applyStrategy(
@@ -92,7 +95,8 @@ export async function applyStrategy(
   configFile: string,
   generated: string,
   strategy: ConfigStrategy,
-  fileManager: FileManager
+  fileManager: FileManager,
+  merge?: (existing: string, generated: string) => string
 ): Promise<string | null> {
   const dest = join(projectDir, configFile);
   const exists = await fileManager.exists(dest);
@@ -102,9 +106,11 @@ export async function applyStrategy(
   if (strategy === "replace") return generated;
 
   // strategy === "merge"
+  const existingText = await fileManager.readText(dest);
+  if (isOutdatedTemplate(existingText)) return generated;
+  if (merge !== undefined) return merge(existingText, generated);
   if (!isMergeable(configFile)) return generated;
 
-  const existingText = await fileManager.readText(dest);
   const existingData = parseForMerge(existingText, configFile);
   const generatedData = parseForMerge(generated, configFile);
 
@@ -0,0 +1,39 @@
+import type { Session } from "node:inspector";
+import { isPlainObject } from "@/utils/deep-merge";
+
+export interface CpuProfile {
+  /** Stop sampling; resolves to the profile as `.cpuprofile` JSON */
+  stop(): Promise<string>;
+}
+
+function post(session: Session, method: string): Promise<unknown> {
+  return new Promise((resolve, reject) => {
+    session.post(method, (err, params) => {
+      if (err !== null) reject(err);
+      else resolve(params);
+    });
+  });
+}
+
+/**
+ * Start sampling this process's CPU through the inspector's Profiler domain.
+ * The profile opens in Chrome DevTools (Performance tab) or speedscope.
+ * Throws where the runtime has no inspector session to profile with.
+ */
+export async function startCpuProfile(): Promise<CpuProfile> {
+  const { Session } = await import("node:inspector");
+  const session = new Session();
+  session.connect();
+  await post(session, "Profiler.enable");
+  await post(session, "Profiler.start");
+  return {
+    async stop(): Promise<string> {
+      const result = await post(session, "Profiler.stop");
+      session.disconnect();
+      if (!isPlainObject(result) || !isPlainObject(result.profile)) {
+        throw new Error("the profiler returned no profile");
+      }
+      return JSON.stringify(result.profile);
+    },
+  };
+}
@@ -0,0 +1,32 @@
+export interface Batcher<T> {
+  add(item: T): void;
+  /** Drop pending items without flushing */
+  cancel(): void;
+}
+
+/**
+ * Collect items until none arrive for `delayMs`, then hand the distinct ones
+ * to `flush` in arrival order — e.g. one save touching several files.
+ */
+export function createBatcher<T>(
+  delayMs: number,
+  flush: (items: T[]) => void
+): Batcher<T> {
+  let pending = new Set<T>();
+  let timer: ReturnType<typeof setTimeout> | undefined;
+  return {
+    add(item) {
+      pending.add(item);
+      clearTimeout(timer);
+      timer = setTimeout(() => {
+        const items = [...pending];
+        pending = new Set();
+        flush(items);
+      }, delayMs);
+    },
+    cancel() {
+      clearTimeout(timer);
+      pending = new Set();
+    },
+  };
+}
@@ -0,0 +1,62 @@
+import type { LintIssue } from "@/models/lint-issue";
+import { SEVERITIES } from "@/models/lint-issue";
+import { computeHash } from "@/utils/hash";
+
+/**
+ * The message as compared across tools: lowercased, whitespace collapsed,
+ * without a leading `SA4006: `-style code or a trailing period. A wrapper like
+ * golangci-lint repeats its linter's message with the code in front.
+ */
+export function normalizeMessage(message: string): string {
+  return message
+    .trim()
+    .replace(/^[A-Z]+\d+:\s*/, "")
+    .replace(/\.$/, "")
+    .replace(/\s+/g, " ")
+    .toLowerCase();
+}
+
+/** What two runners' reports of one problem share: path, line and message */
+export function duplicateKey(
+  issue: Pick<LintIssue, "file" | "line" | "message">
+): string {
+  const message = normalizeMessage(issue.message);
+  return computeHash([issue.file, issue.line, message].join("\n"));
+}
+
+/** Every runner that reported `issue`: its own linter first */
+export function reporters(issue: LintIssue): readonly string[] {
+  return issue.reportedBy ?? [issue.linter];
+}
+
+/**
+ * Collapse findings that different runners report for the same problem into
+ * one, with `reportedBy` listing each runner. The most severe report is kept,
+ * the first one on a tie, so its fingerprint stays the same from run to run.
+ * A runner repeating itself is left alone: those are distinct problems.
+ */
+export function dedupeIssues(issues: readonly LintIssue[]): LintIssue[] {
+  const kept: LintIssue[] = [];
+  const byKey = new Map<string, number>();
+  for (const issue of issues) {
+    const key = duplicateKey(issue);
+    const index = byKey.get(key);
+    const existing = index !== undefined ? kept[index] : undefined;
+    if (index === undefined || existing === undefined) {
+      byKey.set(key, kept.length);
+      kept.push(issue);
+      continue;
+    }
+    const seen = reporters(existing);
+    if (seen.includes(issue.linter)) {
+      kept.push(issue);
+      continue;
+    }
+    const moreSevere =
+      SEVERITIES.indexOf(issue.severity) < SEVERITIES.indexOf(existing.severity);
+    const winner = moreSevere ? issue : existing;
+    const others = [...seen, issue.linter].filter((id) => id !== winner.linter);
+    kept[index] = { ...winner, reportedBy: [winner.linter, ...others] };
+  }
+  return kept;
+}
@@ -0,0 +1,43 @@
+import { join } from "node:path";
+import type { FileManager } from "@/infra/file-manager";
+import { DEFAULT_IGNORE } from "@/languages/constants";
+
+/** The Go convention for generated code (protoc, mockgen, stringer, ...) */
+const GENERATED_MARKER = /^\/\/ Code generated .* DO NOT EDIT\.$/;
+
+/** Header lines searched when a file has no package clause to stop at */
+const MAX_HEADER_LINES = 50;
+
+/**
+ * True when Go source carries the `// Code generated ... DO NOT EDIT.` marker
+ * in its header, i.e. before the package clause.
+ */
+export function isGeneratedSource(content: string): boolean {
+  const lines = content.split("\n", MAX_HEADER_LINES);
+  for (const raw of lines) {
+    const line = raw.trimEnd();
+    if (GENERATED_MARKER.test(line)) return true;
+    if (line.startsWith("package ")) return false;
+  }
+  return false;
+}
+
+/**
+ * Project-relative Go files marked as generated, skipping vendored and
+ * `ignore_paths` files. Sorted for stable output.
+ */
+export async function findGeneratedFiles(
+  fileManager: FileManager,
+  projectDir: string,
+  ignorePaths: readonly string[]
+): Promise<string[]> {
+  const ignore = [...DEFAULT_IGNORE, ...ignorePaths];
+  const files = await fileManager.glob("**/*.go", projectDir, ignore);
+  const generated = await Promise.all(
+    files.map(async (file) => {
+      const content = await fileManager.readText(join(projectDir, file));
+      return isGeneratedSource(content) ? file : null;
+    })
+  );
+  return generated.filter((file): file is string => file !== null).sort();
+}
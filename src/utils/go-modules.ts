@@ -0,0 +1,140 @@
+import { dirname, join, relative } from "node:path";
+import { minimatch } from "minimatch";
+import type { FileManager } from "@/infra/file-manager";
+import { DEFAULT_IGNORE } from "@/languages/constants";
+import type { LintIssue } from "@/models/lint-issue";
+import type { RunOptions } from "@/runners/types";
+
+/**
+ * Module directories from the `use` directives of a go.work file, in file
+ * order — both `use ./api` and the `use ( ... )` block form.
+ */
+export function parseGoWork(content: string): string[] {
+  const dirs: string[] = [];
+  let inBlock = false;
+  for (const raw of content.split("\n")) {
+    const line = raw.replace(/\/\/.*$/, "").trim();
+    if (inBlock) {
+      if (line === ")") inBlock = false;
+      else if (line !== "") dirs.push(unquote(line));
+      continue;
+    }
+    const match = /^use\s+(.+)$/.exec(line);
+    const target = match?.[1]?.trim();
+    if (target === undefined) continue;
+    if (target === "(") inBlock = true;
+    else dirs.push(unquote(target));
+  }
+  return dirs;
+}
+
+function unquote(path: string): string {
+  return path.replace(/^["`](.*)["`]$/, "$1");
+}
+
+/** The `go` directive of go.mod content, e.g. "1.22" or "1.21.5"; null if absent */
+export function parseGoDirective(content: string): string | null {
+  const match = /^go\s+(\d+\.\d+(?:\.\d+)?)\s*(?:\/\/.*)?$/m.exec(content);
+  return match?.[1] ?? null;
+}
+
+/** The `go` directive of the module in moduleDir; null without go.mod or directive */
+export async function readGoVersion(
+  fileManager: FileManager,
+  moduleDir: string
+): Promise<string | null> {
+  const goMod = join(moduleDir, "go.mod");
+  if (!(await fileManager.exists(goMod))) return null;
+  return parseGoDirective(await fileManager.readText(goMod));
+}
+
+/** Language version ("1.22") of a go directive or toolchain version ("1.22.3") */
+export function goLanguageVersion(version: string): string {
+  return version.split(".").slice(0, 2).join(".");
+}
+
+/** Compare dotted Go versions numerically: negative when a is older than b */
+export function compareGoVersions(a: string, b: string): number {
+  const left = a.split(".").map(Number);
+  const right = b.split(".").map(Number);
+  for (let i = 0; i < Math.max(left.length, right.length); i++) {
+    const diff = (left[i] ?? 0) - (right[i] ?? 0);
+    if (diff !== 0) return diff;
+  }
+  return 0;
+}
+
+/**
+ * Find every Go module under projectDir. A root go.work defines the modules
+ * (as the go command does); otherwise every go.mod is one. Returns absolute
+ * module directories, root first, sorted for stable output.
+ */
+export async function findGoModules(
+  fileManager: FileManager,
+  projectDir: string,
+  ignorePaths: readonly string[] = []
+): Promise<string[]> {
+  const ignore = [...DEFAULT_IGNORE, ...ignorePaths];
+  const goWork = join(projectDir, "go.work");
+  if (await fileManager.exists(goWork)) {
+    const dirs = parseGoWork(await fileManager.readText(goWork))
+      .map((dir) => join(projectDir, dir))
+      .filter((dir) => {
+        const goMod = relative(projectDir, join(dir, "go.mod"));
+        return !ignore.some((pattern) => minimatch(goMod, pattern, { dot: true }));
+      });
+    return [...new Set(dirs)].sort();
+  }
+  const goMods = await fileManager.glob("**/go.mod", projectDir, ignore);
+  return goMods.map((rel) => join(projectDir, dirname(rel))).sort();
+}
+
+/** Module directories at or under the project-relative `scope` */
+export function modulesUnder(
+  modules: readonly string[],
+  projectDir: string,
+  scope: string
+): string[] {
+  const root = join(projectDir, scope);
+  return modules.filter((dir) => dir === root || dir.startsWith(`${root}/`));
+}
+
+/**
+ * Module directories for the project-relative `paths`: those under each path,
+ * or else the innermost module containing it. Sorted, without duplicates.
+ */
+export function modulesForPaths(
+  modules: readonly string[],
+  projectDir: string,
+  paths: readonly string[]
+): string[] {
+  const picked = paths.flatMap((path) => {
+    const under = modulesUnder(modules, projectDir, path);
+    if (under.length > 0) return under;
+    const root = join(projectDir, path);
+    const owners = modules.filter((dir) => root.startsWith(`${dir}/`));
+    return owners.sort((a, b) => b.length - a.length).slice(0, 1);
+  });
+  return [...new Set(picked)].sort();
+}
+
+/**
+ * The modules a Go runner should lint: all of them, those under `--module`,
+ * or those for `--path`
+ */
+export async function goModulesFor(opts: RunOptions): Promise<string[]> {
+  const { projectDir, config, fileManager, module, paths } = opts;
+  const modules = await findGoModules(fileManager, projectDir, config.ignorePaths);
+  if (module !== undefined) return modulesUnder(modules, projectDir, module);
+  return paths === undefined ? modules : modulesForPaths(modules, projectDir, paths);
+}
+
+/** Record which module each issue came from, relative to the project root */
+export function withModule<T extends Omit<LintIssue, "fingerprint">>(
+  issues: readonly T[],
+  projectDir: string,
+  moduleDir: string
+): T[] {
+  const module = relative(projectDir, moduleDir) || ".";
+  return issues.map((issue) => ({ ...issue, module }));
+}
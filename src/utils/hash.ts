@@ -5,6 +5,63 @@ export const JSONC_HASH_PREFIX = "// ai-guardrails:sha256=";
 export const MD_HASH_PREFIX = "<!-- ai-guardrails:sha256=";
 export const MD_HASH_SUFFIX = " -->";
 
+/**
+ * Version of our generator templates, stored in every hash header. Bump it when
+ * a template changes so `init --upgrade` regenerates files we wrote earlier.
+ * Headers without a version predate it and count as version 0.
+ */
+export const TEMPLATE_VERSION = 1;
+
+const TEMPLATE_TAG = `;template=v${TEMPLATE_VERSION}`;
+
+const HASH_HEADER_PATTERN =
+  /^(?:\/\/|#) ai-guardrails:sha256=([0-9a-f]{64})(?:;template=v(\d+))?$|^<!-- ai-guardrails:sha256=([0-9a-f]{64})(?:;template=v(\d+))? -->$/;
+
+export interface HashHeader {
+  /** SHA-256 of the body below the header line */
+  hash: string;
+  templateVersion: number;
+  body: string;
+}
+
+/** True when the first line is a hash header written by ai-guardrails. */
+export function hasHashHeader(content: string): boolean {
+  const firstLine = content.split("\n", 1)[0] ?? "";
+  return (
+    firstLine.startsWith(HASH_PREFIX) ||
+    firstLine.startsWith(JSONC_HASH_PREFIX) ||
+    firstLine.startsWith(MD_HASH_PREFIX)
+  );
+}
+
+/** Parse the hash header on the first line, or null if there is none. */
+export function parseHashHeader(content: string): HashHeader | null {
+  const newline = content.indexOf("\n");
+  if (newline === -1) return null;
+  const match = HASH_HEADER_PATTERN.exec(content.slice(0, newline));
+  const hash = match?.[1] ?? match?.[3];
+  if (hash === undefined) return null;
+  const version = match?.[2] ?? match?.[4];
+  return {
+    hash,
+    templateVersion: version !== undefined ? Number.parseInt(version, 10) : 0,
+    body: content.slice(newline + 1),
+  };
+}
+
+/**
+ * True when the file is ours, unedited since we wrote it, and generated from
+ * an older template — safe to regenerate without losing user changes.
+ */
+export function isOutdatedTemplate(content: string): boolean {
+  const header = parseHashHeader(content);
+  return (
+    header !== null &&
+    header.templateVersion < TEMPLATE_VERSION &&
+    computeHash(header.body) === header.hash
+  );
+}
+
 /** Compute a hex SHA-256 digest of a string. */
 export function computeHash(content: string): string {
   return createHash("sha256").update(content).digest("hex");
@@ -15,7 +72,7 @@ export function computeHash(content: string): string {
  * The hash covers the body text below the header line.
  */
 export function makeHashHeader(content: string): string {
-  return `${HASH_PREFIX}${computeHash(content)}`;
+  return `${HASH_PREFIX}${computeHash(content)}${TEMPLATE_TAG}`;
 }
 
 /**
@@ -23,7 +80,7 @@ export function makeHashHeader(content: string): string {
  * The hash covers the body text below the header line.
  */
 export function makeJsoncHashHeader(content: string): string {
-  return `${JSONC_HASH_PREFIX}${computeHash(content)}`;
+  return `${JSONC_HASH_PREFIX}${computeHash(content)}${TEMPLATE_TAG}`;
 }
 
 /**
@@ -44,8 +101,9 @@ export function withJsoncHashHeader(content: string): string {
 
 /**
  * Prepend a Markdown-safe hash header (using HTML comment syntax) to the given content.
- * The format is: `<!-- ai-guardrails:sha256=<hash> -->\n<content>`.
+ * The format is: `<!-- ai-guardrails:sha256=<hash>;template=v<n> -->\n<content>`.
  */
 export function withMarkdownHashHeader(content: string): string {
-  return `${MD_HASH_PREFIX}${computeHash(content)}${MD_HASH_SUFFIX}\n${content}`;
+  const hash = `${computeHash(content)}${TEMPLATE_TAG}`;
+  return `${MD_HASH_PREFIX}${hash}${MD_HASH_SUFFIX}\n${content}`;
 }
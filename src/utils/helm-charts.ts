@@ -0,0 +1,42 @@
+import { dirname, relative, sep } from "node:path";
+import type { FileManager } from "@/infra/file-manager";
+import { DEFAULT_IGNORE } from "@/languages/constants";
+
+export const CHART_GLOB = "**/Chart.yaml";
+
+/**
+ * Find the project's Helm charts: the project-relative directories holding a
+ * Chart.yaml, sorted, without subcharts — helm lints and renders those as
+ * part of their parent. With a changed-file list, only the charts holding
+ * one of the files are returned.
+ */
+export async function findCharts(
+  fileManager: FileManager,
+  projectDir: string,
+  ignorePaths: readonly string[],
+  files?: readonly string[]
+): Promise<string[]> {
+  const found = await fileManager.glob(CHART_GLOB, projectDir, [
+    ...DEFAULT_IGNORE,
+    ...ignorePaths,
+  ]);
+  const dirs = [...new Set(found.map((rel) => dirname(rel)))].sort();
+  const within = (path: string, dir: string): boolean =>
+    dir === "." || path.startsWith(`${dir}/`);
+  const charts = dirs.filter(
+    (dir) => !dirs.some((other) => other !== dir && within(dir, other))
+  );
+  if (files === undefined) return charts;
+  return charts.filter((chart) => files.some((file) => within(file, chart)));
+}
+
+/**
+ * Whether project-relative `file` is a template of one of `charts` or of a
+ * subchart inside one. Templates are not YAML until helm renders them.
+ */
+export function isChartTemplate(file: string, charts: readonly string[]): boolean {
+  return charts.some((chart) => {
+    const rel = chart === "." ? file : relative(chart, file);
+    return !rel.startsWith("..") && rel.split(sep).includes("templates");
+  });
+}
@@ -0,0 +1,123 @@
+import { dirname, join } from "node:path";
+import { minimatch } from "minimatch";
+import type { FileManager } from "@/infra/file-manager";
+import { DEFAULT_IGNORE } from "@/languages/constants";
+
+/** Guardrails-only ignore patterns, applied after every .gitignore */
+export const GUARDRAILS_IGNORE_FILE = ".guardrailsignore";
+
+/** True when a project-relative path is ignored */
+export type PathMatcher = (relPath: string) => boolean;
+
+interface IgnoreRule {
+  /** minimatch pattern, relative to `base` */
+  glob: string;
+  negated: boolean;
+  /** A trailing slash: the pattern matches directories only */
+  dirOnly: boolean;
+  /** A slash other than a trailing one: anchored to `base`, else any depth */
+  anchored: boolean;
+  /** Directory of the ignore file, relative to the project root ("" = root) */
+  base: string;
+}
+
+/**
+ * Parse gitignore-style content: comments, blank lines, `!` negation, `\`
+ * escapes. `base` is the directory holding the file, for nested .gitignores.
+ */
+export function parseIgnorePatterns(content: string, base = ""): IgnoreRule[] {
+  const rules: IgnoreRule[] = [];
+  for (const raw of content.split("\n")) {
+    const line = raw.trimEnd();
+    if (line === "" || line.startsWith("#")) continue;
+    const negated = line.startsWith("!");
+    const body = negated ? line.slice(1) : line;
+    const pattern = body.startsWith("\\") ? body.slice(1) : body;
+    if (pattern === "" || pattern === "/") continue;
+
+    const dirOnly = pattern.endsWith("/");
+    const trimmed = dirOnly ? pattern.slice(0, -1) : pattern;
+    const anchored = trimmed.includes("/");
+    const glob = trimmed.startsWith("/") ? trimmed.slice(1) : trimmed;
+    rules.push({ glob, negated, dirOnly, anchored, base });
+  }
+  return rules;
+}
+
+function ruleMatches(rule: IgnoreRule, path: string, isDir: boolean): boolean {
+  if (rule.dirOnly && !isDir) return false;
+  if (rule.base !== "" && !path.startsWith(`${rule.base}/`)) return false;
+  const rel = rule.base !== "" ? path.slice(rule.base.length + 1) : path;
+  // Unanchored patterns match the last path component at any depth
+  return minimatch(rel, rule.glob, { dot: true, matchBase: !rule.anchored });
+}
+
+/** Whether `path` itself is excluded: as in git, the last matching rule wins */
+function isExcluded(
+  rules: readonly IgnoreRule[],
+  path: string,
+  isDir: boolean
+): boolean {
+  let ignored = false;
+  for (const rule of rules) {
+    if (ruleMatches(rule, path, isDir)) ignored = !rule.negated;
+  }
+  return ignored;
+}
+
+/**
+ * Whether `path` or a directory above it is excluded. As in git, a file
+ * inside an excluded directory cannot be re-included with `!` — negate the
+ * directory's contents (`dir/*`) instead.
+ */
+function isPathIgnored(
+  rules: readonly IgnoreRule[],
+  relPath: string,
+  isDir: boolean
+): boolean {
+  const parts = relPath.split("/");
+  return parts.some((_, i) => {
+    const path = parts.slice(0, i + 1).join("/");
+    return isExcluded(rules, path, isDir || i < parts.length - 1);
+  });
+}
+
+/** Build a matcher for file paths with git's semantics */
+export function createIgnoreMatcher(rules: readonly IgnoreRule[]): PathMatcher {
+  return (relPath) => isPathIgnored(rules, relPath, false);
+}
+
+function depth(relPath: string): number {
+  return relPath.split("/").length;
+}
+
+/**
+ * Load every .gitignore — the project root's, then nested ones from the
+ * shallowest down, skipping those inside ignored directories as git does —
+ * and finally the root .guardrailsignore, so it can add patterns or
+ * re-include (`!`) gitignored paths. Returns null when no ignore file exists.
+ */
+export async function loadIgnoreMatcher(
+  projectDir: string,
+  fileManager: FileManager
+): Promise<PathMatcher | null> {
+  const found = await fileManager.glob("**/.gitignore", projectDir, DEFAULT_IGNORE);
+  const gitignores = found.sort((a, b) => depth(a) - depth(b) || a.localeCompare(b));
+
+  const rules: IgnoreRule[] = [];
+  for (const file of gitignores) {
+    const base = dirname(file) === "." ? "" : dirname(file);
+    if (base !== "" && isPathIgnored(rules, base, true)) continue;
+    const content = await fileManager.readText(join(projectDir, file));
+    rules.push(...parseIgnorePatterns(content, base));
+  }
+
+  const guardrailsignore = join(projectDir, GUARDRAILS_IGNORE_FILE);
+  const hasGuardrailsignore = await fileManager.exists(guardrailsignore);
+  if (hasGuardrailsignore) {
+    rules.push(...parseIgnorePatterns(await fileManager.readText(guardrailsignore)));
+  }
+
+  const anyFile = gitignores.length > 0 || hasGuardrailsignore;
+  return anyFile ? createIgnoreMatcher(rules) : null;
+}
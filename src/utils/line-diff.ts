@@ -0,0 +1,99 @@
+interface Edit {
+  op: " " | "-" | "+";
+  line: string;
+  /** 1-based line numbers this edit sits at in the old and the new text */
+  oldNo: number;
+  newNo: number;
+}
+
+function splitLines(text: string): string[] {
+  if (text === "") return [];
+  const lines = text.split("\n");
+  if (lines.at(-1) === "") lines.pop();
+  return lines;
+}
+
+/** Line edits turning `a` into `b`, from their longest common subsequence */
+function lineEdits(a: readonly string[], b: readonly string[]): Edit[] {
+  const width = b.length + 1;
+  // lcs[i * width + j]: common lines of a[i..] and b[j..]
+  const lcs = new Uint32Array((a.length + 1) * width);
+  for (let i = a.length - 1; i >= 0; i--) {
+    for (let j = b.length - 1; j >= 0; j--) {
+      lcs[i * width + j] =
+        a[i] === b[j]
+          ? (lcs[(i + 1) * width + j + 1] ?? 0) + 1
+          : Math.max(lcs[(i + 1) * width + j] ?? 0, lcs[i * width + j + 1] ?? 0);
+    }
+  }
+
+  const edits: Edit[] = [];
+  let i = 0;
+  let j = 0;
+  const push = (op: Edit["op"], line: string) => {
+    edits.push({ op, line, oldNo: i + 1, newNo: j + 1 });
+  };
+  while (i < a.length || j < b.length) {
+    const oldLine = a[i];
+    const newLine = b[j];
+    if (oldLine !== undefined && oldLine === newLine) {
+      push(" ", oldLine);
+      i++;
+      j++;
+    } else if (
+      oldLine !== undefined &&
+      (newLine === undefined ||
+        (lcs[(i + 1) * width + j] ?? 0) >= (lcs[i * width + j + 1] ?? 0))
+    ) {
+      // On a tie, removals go first, as in `diff -u`
+      push("-", oldLine);
+      i++;
+    } else {
+      push("+", newLine ?? "");
+      j++;
+    }
+  }
+  return edits;
+}
+
+/** "@@ -3,7 +3,8 @@" — a zero-length side points at the line before it */
+function hunkHeader(edits: readonly Edit[]): string {
+  const first = edits[0];
+  const oldLen = edits.filter((e) => e.op !== "+").length;
+  const newLen = edits.filter((e) => e.op !== "-").length;
+  const oldStart = (first?.oldNo ?? 1) - (oldLen === 0 ? 1 : 0);
+  const newStart = (first?.newNo ?? 1) - (newLen === 0 ? 1 : 0);
+  return `@@ -${oldStart},${oldLen} +${newStart},${newLen} @@`;
+}
+
+/**
+ * Unified diff of two texts, with `context` unchanged lines around each
+ * change. [] when they are equal. One string per line, headers included.
+ */
+export function unifiedDiff(
+  before: string,
+  after: string,
+  path: string,
+  context = 3
+): string[] {
+  const edits = lineEdits(splitLines(before), splitLines(after));
+  const changed = edits.flatMap((edit, i) => (edit.op !== " " ? [i] : []));
+  if (changed.length === 0) return [];
+
+  // Merge changes whose context overlaps into one hunk
+  const ranges: Array<[number, number]> = [];
+  for (const i of changed) {
+    const start = Math.max(0, i - context);
+    const end = Math.min(edits.length - 1, i + context);
+    const last = ranges.at(-1);
+    if (last !== undefined && start <= last[1] + 1) last[1] = end;
+    else ranges.push([start, end]);
+  }
+
+  const lines = [`--- ${path}`, `+++ ${path}`];
+  for (const [start, end] of ranges) {
+    const hunk = edits.slice(start, end + 1);
+    lines.push(hunkHeader(hunk), ...hunk.map((edit) => `${edit.op}${edit.line}`));
+  }
+  return lines;
+}
@@ -0,0 +1,136 @@
+import { join, normalize } from "node:path";
+import { z } from "zod";
+import type { CommandRunner } from "@/infra/command-runner";
+import type { FileManager } from "@/infra/file-manager";
+import { safeParseJson } from "@/utils/parse";
+import { resolveToolPath } from "@/utils/resolve-tool-path";
+
+/** A JS monorepo tool that knows which packages a change affects */
+export interface MonorepoTool {
+  readonly id: "turbo" | "nx";
+  readonly name: string;
+  /** The workspace config file that marks a project as using the tool */
+  readonly configFile: string;
+}
+
+export const TURBOREPO: MonorepoTool = {
+  id: "turbo",
+  name: "Turborepo",
+  configFile: "turbo.json",
+};
+
+export const NX: MonorepoTool = { id: "nx", name: "Nx", configFile: "nx.json" };
+
+/** In detection order: a repo with both is driven by Turborepo */
+const MONOREPO_TOOLS: readonly MonorepoTool[] = [TURBOREPO, NX];
+
+/** The monorepo tool whose config file is at the project root; null without one */
+export async function detectMonorepoTool(
+  projectDir: string,
+  fileManager: FileManager
+): Promise<MonorepoTool | null> {
+  for (const tool of MONOREPO_TOOLS) {
+    if (await fileManager.exists(join(projectDir, tool.configFile))) return tool;
+  }
+  return null;
+}
+
+/** `turbo ls --output=json`: only the package paths are used */
+const TurboLsSchema = z.object({
+  packages: z.object({
+    items: z.array(z.object({ name: z.string(), path: z.string() })),
+  }),
+});
+
+/** `nx show project <name> --json`: only the project root is used */
+const NxProjectSchema = z.object({ root: z.string() });
+
+/** Project-relative package dir, "." for the workspace root */
+function packageDir(path: string): string {
+  const dir = normalize(path).replace(/\/+$/, "");
+  return dir === "" ? "." : dir;
+}
+
+/** Package dirs from `turbo ls --output=json`; null when it is not that shape */
+export function parseTurboPackages(stdout: string): string[] | null {
+  const parsed = TurboLsSchema.safeParse(safeParseJson(stdout));
+  if (!parsed.success) return null;
+  return parsed.data.packages.items.map((item) => packageDir(item.path));
+}
+
+async function runTool(
+  bin: string,
+  args: readonly string[],
+  projectDir: string,
+  commandRunner: CommandRunner
+): Promise<string> {
+  const result = await commandRunner.run([bin, ...args], { cwd: projectDir });
+  if (result.exitCode !== 0) {
+    const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+    throw new Error(`${[bin, ...args].join(" ")} failed: ${detail}`);
+  }
+  return result.stdout;
+}
+
+/** Packages changed since `ref`, and those depending on them (`...[ref]`) */
+async function turboAffected(
+  bin: string,
+  ref: string,
+  projectDir: string,
+  commandRunner: CommandRunner
+): Promise<string[]> {
+  const args = ["ls", `--filter=...[${ref}]`, "--output=json"];
+  const stdout = await runTool(bin, args, projectDir, commandRunner);
+  const dirs = parseTurboPackages(stdout);
+  if (dirs === null) throw new Error("turbo ls printed no package list");
+  return dirs;
+}
+
+/** Projects `nx affected` would run, each looked up for its root */
+async function nxAffected(
+  bin: string,
+  ref: string,
+  projectDir: string,
+  commandRunner: CommandRunner
+): Promise<string[]> {
+  const args = ["show", "projects", "--affected", `--base=${ref}`, "--head=HEAD"];
+  const listed = safeParseJson(
+    await runTool(bin, [...args, "--json"], projectDir, commandRunner)
+  );
+  const names = z.array(z.string()).safeParse(listed);
+  if (!names.success) throw new Error("nx show projects printed no project list");
+  const dirs: string[] = [];
+  for (const name of names.data) {
+    const stdout = await runTool(
+      bin,
+      ["show", "project", name, "--json"],
+      projectDir,
+      commandRunner
+    );
+    const project = NxProjectSchema.safeParse(safeParseJson(stdout));
+    if (!project.success) throw new Error(`nx show project ${name} printed no root`);
+    dirs.push(packageDir(project.data.root));
+  }
+  return dirs;
+}
+
+/**
+ * Project-relative dirs of the packages `tool` finds affected by the changes
+ * since `ref`, sorted and deduplicated; "." when the workspace root is one.
+ * Returns null when the tool is not installed, so the caller can fall back to
+ * a plain git diff. Throws when the tool fails, e.g. for an unknown ref.
+ */
+export async function listAffectedPackages(
+  tool: MonorepoTool,
+  ref: string,
+  projectDir: string,
+  commandRunner: CommandRunner
+): Promise<string[] | null> {
+  const bin = await resolveToolPath(tool.id, projectDir, commandRunner);
+  if (bin === null) return null;
+  const dirs =
+    tool.id === "turbo"
+      ? await turboAffected(bin, ref, projectDir, commandRunner)
+      : await nxAffected(bin, ref, projectDir, commandRunner);
+  return [...new Set(dirs)].toSorted();
+}
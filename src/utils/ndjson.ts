@@ -15,3 +15,41 @@ export function parseNdjson(text: string): unknown[] {
   }
   return results;
 }
+
+/**
+ * Parse a stream of concatenated JSON objects, each possibly spanning several
+ * lines (e.g. `govulncheck -json`). Objects that fail to parse are skipped.
+ */
+export function parseJsonStream(text: string): unknown[] {
+  const results: unknown[] = [];
+  let depth = 0;
+  let start = -1;
+  let inString = false;
+  let escaped = false;
+
+  for (let i = 0; i < text.length; i++) {
+    const ch = text[i];
+    if (inString) {
+      if (escaped) escaped = false;
+      else if (ch === "\\") escaped = true;
+      else if (ch === '"') inString = false;
+      continue;
+    }
+    if (ch === '"') {
+      inString = true;
+    } else if (ch === "{") {
+      if (depth === 0) start = i;
+      depth++;
+    } else if (ch === "}" && depth > 0) {
+      depth--;
+      if (depth === 0) {
+        try {
+          results.push(JSON.parse(text.slice(start, i + 1)));
+        } catch {
+          // Skip malformed objects
+        }
+      }
+    }
+  }
+  return results;
+}
@@ -0,0 +1,92 @@
+import { join } from "node:path";
+import { z } from "zod";
+import type { FileManager } from "@/infra/file-manager";
+import { safeParseJson } from "@/utils/parse";
+
+/** Loose schema for package.json — only validates the fields we care about */
+const PackageJsonSchema = z
+  .object({
+    dependencies: z.record(z.unknown()).optional(),
+    devDependencies: z.record(z.unknown()).optional(),
+  })
+  .passthrough();
+
+async function readPackageJson(
+  projectDir: string,
+  fileManager: FileManager
+): Promise<z.infer<typeof PackageJsonSchema> | null> {
+  const path = join(projectDir, "package.json");
+  if (!(await fileManager.exists(path))) return null;
+  const text = await fileManager.readText(path);
+  const parsed = PackageJsonSchema.safeParse(safeParseJson(text));
+  return parsed.success ? parsed.data : null;
+}
+
+/**
+ * True when the project's package.json depends on `name` (dependencies or
+ * devDependencies) or has a top-level `configKey`, e.g. `eslintConfig`.
+ * False without a package.json or when it does not parse.
+ */
+export async function packageUses(
+  projectDir: string,
+  fileManager: FileManager,
+  name: string,
+  configKey: string
+): Promise<boolean> {
+  const pkg = await readPackageJson(projectDir, fileManager);
+  if (pkg === null) return false;
+  const { dependencies, devDependencies } = pkg;
+  return configKey in pkg || name in { ...dependencies, ...devDependencies };
+}
+
+/** True when the project's package.json has a top-level `configKey` */
+export async function packageHasConfig(
+  projectDir: string,
+  fileManager: FileManager,
+  configKey: string
+): Promise<boolean> {
+  const pkg = await readPackageJson(projectDir, fileManager);
+  return pkg !== null && configKey in pkg;
+}
+
+export type JsPackageManager = "bun" | "pnpm" | "yarn" | "npm";
+
+/** Lockfiles that identify the package manager, checked in this order */
+const LOCKFILES: readonly [string, JsPackageManager][] = [
+  ["bun.lock", "bun"],
+  ["bun.lockb", "bun"],
+  ["pnpm-lock.yaml", "pnpm"],
+  ["yarn.lock", "yarn"],
+  ["package-lock.json", "npm"],
+];
+
+/** The project's package manager, from its lockfile; npm without one */
+export async function detectJsPackageManager(
+  projectDir: string,
+  fileManager: FileManager
+): Promise<JsPackageManager> {
+  for (const [lockfile, manager] of LOCKFILES) {
+    if (await fileManager.exists(join(projectDir, lockfile))) return manager;
+  }
+  return "npm";
+}
+
+const ADD_DEV: Record<JsPackageManager, string> = {
+  bun: "bun add -d",
+  pnpm: "pnpm add -D",
+  yarn: "yarn add -D",
+  npm: "npm install -D",
+};
+
+/**
+ * Rewrite an `npm install -D <packages>` hint for the project's package
+ * manager. Other commands (e.g. `npm install -g`) are returned unchanged.
+ */
+export function devDependencyCommand(
+  npmHint: string,
+  manager: JsPackageManager
+): string {
+  const prefix = "npm install -D ";
+  if (!npmHint.startsWith(prefix)) return npmHint;
+  return `${ADD_DEV[manager]} ${npmHint.slice(prefix.length)}`;
+}
@@ -9,3 +9,12 @@ export function safeParseJson(text: string): unknown {
     return null;
   }
 }
+
+/** Split a comma-separated --only/--enable/--disable value into runner ids */
+export function parseRunnerList(raw: unknown): string[] {
+  if (typeof raw !== "string") return [];
+  return raw
+    .split(",")
+    .map((id) => id.trim())
+    .filter((id) => id !== "");
+}
@@ -0,0 +1,21 @@
+import type { CommandRunner } from "@/infra/command-runner";
+
+/**
+ * Pipe `content` through a tool that reads a file on stdin and prints it fixed
+ * on stdout — the dry run behind `LinterRunner.previewFix`. Throws with the
+ * tool's stderr when it exits outside okExitCodes.
+ */
+export async function pipeFix(
+  commandRunner: CommandRunner,
+  args: readonly string[],
+  content: string,
+  cwd: string,
+  okExitCodes: readonly number[] = [0]
+): Promise<string> {
+  const result = await commandRunner.run([...args], { cwd, stdin: content });
+  if (!okExitCodes.includes(result.exitCode)) {
+    const detail = result.stderr.trim() || `exit code ${result.exitCode}`;
+    throw new Error(`${args[0] ?? "fix"} failed: ${detail}`);
+  }
+  return result.stdout;
+}
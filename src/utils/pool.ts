@@ -0,0 +1,106 @@
+import { availableParallelism } from "node:os";
+
+/** Default worker count: one per available CPU */
+export function defaultJobs(): number {
+  return Math.max(1, availableParallelism());
+}
+
+/**
+ * Default cap on concurrent tool processes: GOMAXPROCS when it is a positive
+ * integer (CI images often set it to the container's CPU quota), else one
+ * per available CPU.
+ */
+export function defaultMaxProcs(
+  env: Readonly<Record<string, string | undefined>> = process.env
+): number {
+  const fromEnv = Number(env.GOMAXPROCS);
+  return Number.isInteger(fromEnv) && fromEnv > 0 ? fromEnv : defaultJobs();
+}
+
+/** Files an in-process check reads at once; reads wait on the disk, not a CPU */
+export const PARALLEL_READS = 16;
+
+export interface LimiterOptions {
+  /** Aborting it rejects the call while it is still queued */
+  signal?: AbortSignal;
+  /** Told true when the call has to queue, and false once it leaves the queue */
+  onWait?: (waiting: boolean) => void;
+}
+
+export type Limiter = <T>(fn: () => Promise<T>, opts?: LimiterOptions) => Promise<T>;
+
+/**
+ * A counting semaphore: the returned function runs `fn` once fewer than
+ * `max` of its calls are in flight, queueing the rest in call order.
+ */
+export function createLimiter(max: number): Limiter {
+  const limit = Math.max(1, max);
+  const queue: Array<() => void> = [];
+  let active = 0;
+
+  const release = () => {
+    const next = queue.shift();
+    // Hand the slot straight to the next waiter, so active stays the same
+    if (next !== undefined) next();
+    else active--;
+  };
+
+  const acquire = ({ signal, onWait }: LimiterOptions) =>
+    new Promise<void>((resolve, reject) => {
+      if (signal?.aborted === true) {
+        reject(new Error("aborted while waiting for a slot"));
+        return;
+      }
+      const grant = () => {
+        signal?.removeEventListener("abort", onAbort);
+        onWait?.(false);
+        resolve();
+      };
+      const onAbort = () => {
+        queue.splice(queue.indexOf(grant), 1);
+        onWait?.(false);
+        reject(new Error("aborted while waiting for a slot"));
+      };
+      signal?.addEventListener("abort", onAbort, { once: true });
+      queue.push(grant);
+      onWait?.(true);
+    });
+
+  return async <T>(fn: () => Promise<T>, opts: LimiterOptions = {}): Promise<T> => {
+    if (active < limit) active++;
+    else await acquire(opts);
+    try {
+      return await fn();
+    } finally {
+      release();
+    }
+  };
+}
+
+/**
+ * Map items through an async fn with at most `limit` calls in flight.
+ * Results keep the input order regardless of completion order.
+ * A limit of 1 runs every call strictly one after another.
+ */
+export async function mapPool<T, R>(
+  items: readonly T[],
+  limit: number,
+  fn: (item: T) => Promise<R>
+): Promise<R[]> {
+  const results = new Array<R>(items.length);
+  let next = 0;
+
+  // Workers claim indices synchronously, so no two workers share an item
+  async function worker(): Promise<void> {
+    while (next < items.length) {
+      const index = next++;
+      const item = items[index];
+      if (item === undefined) continue;
+      results[index] = await fn(item);
+    }
+  }
+
+  const workerCount = Math.min(Math.max(1, limit), items.length);
+  await Promise.all(Array.from({ length: workerCount }, () => worker()));
+  return results;
+}
@@ -0,0 +1,24 @@
+import type { FileManager } from "@/infra/file-manager";
+import { DEFAULT_IGNORE } from "@/languages/constants";
+import type { PathMatcher } from "@/utils/ignore-file";
+
+/** Config and baseline files, which describe the check rather than the project */
+const GUARDRAILS_FILES_GLOB = "**/.ai-guardrails/**";
+
+/**
+ * Every project file a check covers, project-relative and sorted: all files
+ * outside the always-skipped directories, `ignorePaths` and `ignore`.
+ */
+export async function listProjectFiles(
+  projectDir: string,
+  ignorePaths: readonly string[],
+  ignore: PathMatcher | null,
+  fileManager: FileManager
+): Promise<string[]> {
+  const found = await fileManager.glob("**/*", projectDir, [
+    ...DEFAULT_IGNORE,
+    ...ignorePaths,
+    GUARDRAILS_FILES_GLOB,
+  ]);
+  return found.filter((file) => ignore?.(file) !== true).sort();
+}
@@ -1,20 +1,23 @@
 import { join } from "node:path";
 import type { CommandRunner } from "@/infra/command-runner";
 
-// Cache keyed by "toolName:projectDir" — stores the Promise so concurrent
+// Cache keyed by "toolName:projectDir:localDirs" — stores the Promise so concurrent
 // callers awaiting the same key don't each spawn their own --version probes.
 const resolveCache = new Map<string, Promise<string | null>>();
 
 async function performResolve(
   toolName: string,
   projectDir: string,
-  commandRunner: CommandRunner
+  commandRunner: CommandRunner,
+  localDirs: readonly string[]
 ): Promise<string | null> {
-  const localPath = join(projectDir, "node_modules", ".bin", toolName);
-  const localResult = await commandRunner.run([localPath, "--version"], {
-    cwd: projectDir,
-  });
-  if (localResult.exitCode === 0) return localPath;
+  for (const dir of localDirs) {
+    const localPath = join(projectDir, dir, toolName);
+    const localResult = await commandRunner.run([localPath, "--version"], {
+      cwd: projectDir,
+    });
+    if (localResult.exitCode === 0) return localPath;
+  }
   const globalResult = await commandRunner.run([toolName, "--version"]);
   if (globalResult.exitCode === 0) return toolName;
   return null;
@@ -25,21 +28,23 @@ async function performResolve(
  *
  * Checks `<projectDir>/node_modules/.bin/<toolName>` first so compiled
  * binaries (which don't inherit node_modules/.bin in PATH) can still invoke
- * locally-installed tools.  Falls back to the bare tool name (global PATH
+ * locally-installed tools.  Tools installed elsewhere in the project pass
+ * their own `localDirs`, tried in order.  Falls back to the bare tool name (global PATH
  * lookup) when the local binary is absent.  Returns null when neither works.
  *
- * Results are cached per (toolName, projectDir) pair so repeated calls
+ * Results are cached per (toolName, projectDir, localDirs) so repeated calls
  * (e.g. isAvailable() followed by run()) do not re-spawn --version probes.
  */
 export async function resolveToolPath(
   toolName: string,
   projectDir: string,
-  commandRunner: CommandRunner
+  commandRunner: CommandRunner,
+  localDirs: readonly string[] = [join("node_modules", ".bin")]
 ): Promise<string | null> {
-  const key = `${toolName}:${projectDir}`;
+  const key = `${toolName}:${projectDir}:${localDirs.join(",")}`;
   let pending = resolveCache.get(key);
   if (pending === undefined) {
-    pending = performResolve(toolName, projectDir, commandRunner);
+    pending = performResolve(toolName, projectDir, commandRunner, localDirs);
     resolveCache.set(key, pending);
   }
   return pending;
@@ -0,0 +1,45 @@
+/**
+ * Failure messages of a network hiccup rather than of the tool or the code:
+ * Node and libc socket errors, Go's net package, curl and proxies' 5xx pages.
+ */
+export const TRANSIENT_ERROR_PATTERNS: readonly RegExp[] = [
+  /\b(ECONNRESET|ECONNREFUSED|ETIMEDOUT|ENOTFOUND|EAI_AGAIN|ENETUNREACH)\b/,
+  /connection (reset|refused|timed out)/i,
+  /temporary failure in name resolution/i,
+  /no such host/i,
+  /network is unreachable/i,
+  /i\/o timeout/i,
+  /TLS handshake timeout/i,
+  /could not resolve host/i,
+  /\b(502 Bad Gateway|503 Service Unavailable|504 Gateway Time-?out)\b/i,
+];
+
+/** True when `message` looks transient, by the built-in or the `extra` regexes */
+export function isTransientError(
+  message: string,
+  extra: readonly string[] = []
+): boolean {
+  return (
+    TRANSIENT_ERROR_PATTERNS.some((pattern) => pattern.test(message)) ||
+    extra.some((pattern) => new RegExp(pattern).test(message))
+  );
+}
+
+/** Milliseconds to wait before retry number `retry` (1-based): backoff, doubling */
+export function retryDelayMs(backoffS: number, retry: number): number {
+  return backoffS * 1000 * 2 ** (retry - 1);
+}
+
+/** Resolve after `ms`, or as soon as `signal` aborts */
+export function sleep(ms: number, signal?: AbortSignal): Promise<void> {
+  return new Promise((resolve) => {
+    if (signal?.aborted === true) return resolve();
+    const done = () => {
+      clearTimeout(timer);
+      signal?.removeEventListener("abort", done);
+      resolve();
+    };
+    const timer = setTimeout(done, ms);
+    signal?.addEventListener("abort", done, { once: true });
+  });
+}
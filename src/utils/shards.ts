@@ -0,0 +1,40 @@
+/** Files per tool invocation when `--batch-size` is not given */
+export const DEFAULT_BATCH_SIZE = 500;
+
+/** Split `items` into consecutive shards of at most `size` items */
+export function toShards<T>(items: readonly T[], size: number): T[][] {
+  const step = Math.max(1, Math.floor(size));
+  const shards: T[][] = [];
+  for (let start = 0; start < items.length; start += step) {
+    shards.push(items.slice(start, start + step));
+  }
+  return shards;
+}
+
+/**
+ * Run `fn` once per shard of `files`, one shard at a time so argv length and
+ * tool memory stay bounded, and concatenate the results in shard order — the
+ * same order a single invocation over every file would give.
+ */
+export async function mapShards<R>(
+  files: readonly string[],
+  batchSize: number | undefined,
+  fn: (shard: string[]) => Promise<readonly R[]>
+): Promise<R[]> {
+  const results: R[] = [];
+  for (const shard of toShards(files, batchSize ?? DEFAULT_BATCH_SIZE)) {
+    results.push(...(await fn(shard)));
+  }
+  return results;
+}
+
+/** Like mapShards, for invocations that only have side effects (e.g. `--fix`) */
+export async function forEachShard(
+  files: readonly string[],
+  batchSize: number | undefined,
+  fn: (shard: string[]) => Promise<unknown>
+): Promise<void> {
+  for (const shard of toShards(files, batchSize ?? DEFAULT_BATCH_SIZE)) {
+    await fn(shard);
+  }
+}
@@ -0,0 +1,79 @@
+import { basename, join } from "node:path";
+import type { FileManager } from "@/infra/file-manager";
+import { DEFAULT_IGNORE } from "@/languages/constants";
+
+/** Interpreters whose scripts are shell scripts; [config] shebang_shells adds more */
+export const SHEBANG_SHELLS: readonly string[] = ["sh", "bash", "zsh", "dash"];
+
+/**
+ * The interpreter a script's first line names, e.g. "bash" for `#!/bin/bash -e`
+ * or `#!/usr/bin/env bash`; null without a shebang.
+ */
+export function shebangInterpreter(content: string): string | null {
+  const first = content.split("\n", 1)[0] ?? "";
+  if (!first.startsWith("#!")) return null;
+  const [command, ...args] = first.slice(2).trim().split(/\s+/);
+  if (command === undefined || command === "") return null;
+  if (basename(command) !== "env") return basename(command);
+  // Skip env's options and VAR=value assignments: `env -S bash -e`, `env LC_ALL=C sh`
+  const program = args.find((arg) => !arg.startsWith("-") && !arg.includes("="));
+  return program !== undefined ? basename(program) : null;
+}
+
+/**
+ * Interpreters already read, per file manager: a check run hands all its
+ * runners the same one, so shellcheck, shfmt and their cache keys share reads.
+ */
+const interpreters = new WeakMap<FileManager, Map<string, Promise<string | null>>>();
+
+function readInterpreter(
+  fileManager: FileManager,
+  path: string
+): Promise<string | null> {
+  let cache = interpreters.get(fileManager);
+  if (cache === undefined) {
+    cache = new Map();
+    interpreters.set(fileManager, cache);
+  }
+  let interpreter = cache.get(path);
+  if (interpreter === undefined) {
+    interpreter = fileManager.readText(path).then(shebangInterpreter, () => null);
+    cache.set(path, interpreter);
+  }
+  return interpreter;
+}
+
+function hasExtension(file: string): boolean {
+  return basename(file).includes(".");
+}
+
+/**
+ * Project-relative executable files without an extension whose shebang names
+ * one of `shells`, e.g. a `deploy` script starting `#!/usr/bin/env bash`.
+ * Candidates are `files` when given, else every file outside `ignore` and
+ * DEFAULT_IGNORE. Sorted.
+ */
+export async function findShebangScripts(
+  fileManager: FileManager,
+  projectDir: string,
+  shells: readonly string[],
+  options: { files?: readonly string[]; ignore?: readonly string[] } = {}
+): Promise<string[]> {
+  const candidates =
+    options.files ??
+    (await fileManager.glob("**/*", projectDir, [
+      ...DEFAULT_IGNORE,
+      ...(options.ignore ?? []),
+    ]));
+  const found = await Promise.all(
+    candidates
+      .filter((file) => !hasExtension(file))
+      .map(async (file) => {
+        const path = join(projectDir, file);
+        const interpreter = await readInterpreter(fileManager, path);
+        if (interpreter === null || !shells.includes(interpreter)) return null;
+        return (await fileManager.isExecutable(path)) ? file : null;
+      })
+  );
+  return found.filter((file): file is string => file !== null).sort();
+}
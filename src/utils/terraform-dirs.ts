@@ -0,0 +1,34 @@
+import { dirname, join } from "node:path";
+import type { FileManager } from "@/infra/file-manager";
+import { DEFAULT_IGNORE } from "@/languages/constants";
+
+export const TERRAFORM_GLOB = "**/*.{tf,tfvars}";
+// `terraform init` caches downloaded modules here
+const TERRAFORM_CACHE_IGNORE = "**/.terraform/**";
+
+/** Terraform files (.tf/.tfvars) outside ignored paths, relative to projectDir */
+export async function findTerraformFiles(
+  fileManager: FileManager,
+  projectDir: string,
+  ignorePaths: readonly string[] = []
+): Promise<string[]> {
+  return fileManager.glob(TERRAFORM_GLOB, projectDir, [
+    ...DEFAULT_IGNORE,
+    TERRAFORM_CACHE_IGNORE,
+    ...ignorePaths,
+  ]);
+}
+
+/**
+ * Find every directory holding Terraform files (.tf/.tfvars) under projectDir.
+ * Returns absolute directories, sorted so parents come before children.
+ */
+export async function findTerraformDirs(
+  fileManager: FileManager,
+  projectDir: string,
+  ignorePaths: readonly string[] = []
+): Promise<string[]> {
+  const files = await findTerraformFiles(fileManager, projectDir, ignorePaths);
+  const dirs = new Set(files.map((rel) => join(projectDir, dirname(rel))));
+  return [...dirs].sort();
+}
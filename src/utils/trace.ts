@@ -0,0 +1,103 @@
+/** A timed span in the Chrome Trace Event format ("X", complete event) */
+export interface SpanEvent {
+  readonly name: string;
+  readonly cat: string;
+  readonly ph: "X";
+  /** Start, in microseconds since the tracer was created */
+  readonly ts: number;
+  /** Duration in microseconds */
+  readonly dur: number;
+  readonly pid: number;
+  readonly tid: number;
+}
+
+/** Names a lane ("M", metadata event) so viewers label it */
+export interface LaneEvent {
+  readonly name: "thread_name";
+  readonly ph: "M";
+  readonly pid: number;
+  readonly tid: number;
+  readonly args: { readonly name: string };
+}
+
+export interface SpanOptions {
+  /** Category viewers can filter on (default: "check") */
+  cat?: string;
+  /** Lane to draw the span on (default: "main"); concurrent work needs its own */
+  lane?: string;
+}
+
+const MAIN_LANE = "main";
+
+/**
+ * Records where a run spends its time as spans, written out in the Chrome
+ * Trace Event format that chrome://tracing and ui.perfetto.dev open. Spans
+ * sharing a lane must nest; runners, which overlap, get a lane each.
+ */
+export class Tracer {
+  private readonly spans: SpanEvent[] = [];
+  private readonly lanes = new Map<string, number>();
+  private readonly now: () => number;
+  private readonly origin: number;
+
+  constructor(now: () => number = () => performance.now()) {
+    this.now = now;
+    this.origin = now();
+  }
+
+  /** Time `fn` as a span named `name`, whether it resolves or throws */
+  async span<T>(
+    name: string,
+    fn: () => Promise<T>,
+    opts: SpanOptions = {}
+  ): Promise<T> {
+    const start = this.now();
+    try {
+      return await fn();
+    } finally {
+      const end = this.now();
+      this.spans.push({
+        name,
+        cat: opts.cat ?? "check",
+        ph: "X",
+        ts: Math.round((start - this.origin) * 1000),
+        dur: Math.round((end - start) * 1000),
+        pid: 1,
+        tid: this.lane(opts.lane ?? MAIN_LANE),
+      });
+    }
+  }
+
+  private lane(name: string): number {
+    const known = this.lanes.get(name);
+    if (known !== undefined) return known;
+    const tid = this.lanes.size + 1;
+    this.lanes.set(name, tid);
+    return tid;
+  }
+
+  /** The trace file's content: lane names, then spans in start order */
+  toJSON(): { traceEvents: (LaneEvent | SpanEvent)[]; displayTimeUnit: "ms" } {
+    const lanes = [...this.lanes].map(
+      ([name, tid]): LaneEvent => ({
+        name: "thread_name",
+        ph: "M",
+        pid: 1,
+        tid,
+        args: { name },
+      })
+    );
+    const spans = this.spans.toSorted((a, b) => a.ts - b.ts);
+    return { traceEvents: [...lanes, ...spans], displayTimeUnit: "ms" };
+  }
+}
+
+/** `fn` as a span of `tracer`, or just `fn` when there is no tracer */
+export function traced<T>(
+  tracer: Tracer | undefined,
+  name: string,
+  fn: () => Promise<T>,
+  opts?: SpanOptions
+): Promise<T> {
+  return tracer !== undefined ? tracer.span(name, fn, opts) : fn();
+}
@@ -30,3 +30,21 @@ export function semverLt(a: string, b: string): boolean {
 export function getVersion(): string {
   return pkg.version;
 }
+
+export interface BuildInfo {
+  version: string;
+  commit: string;
+  date: string;
+}
+
+/**
+ * Version, commit and build date of this binary. release.yml bakes the
+ * commit and date in with `bun build --define`; elsewhere they are "unknown".
+ */
+export function getBuildInfo(): BuildInfo {
+  return {
+    version: pkg.version,
+    commit: process.env.AI_GUARDRAILS_BUILD_COMMIT ?? "unknown",
+    date: process.env.AI_GUARDRAILS_BUILD_DATE ?? "unknown",
+  };
+}
@@ -0,0 +1,340 @@
+import type { LintIssue } from "@/models/lint-issue";
+
+export interface RuleExplanation {
+  /** Why the rule exists, in a sentence or two */
+  readonly why: string;
+  /** What usually resolves it, when there is a common answer */
+  readonly fix?: string;
+}
+
+const FORMATTED: RuleExplanation = {
+  why: "The file differs from what the project's formatter produces, so diffs fill with layout noise.",
+  fix: "Run `ai-guardrails check --fix`, or the formatter itself.",
+};
+
+/** Rules that only say a file is not formatted; one explanation fits them all */
+const FORMAT_RULES = [
+  "clang-format/format",
+  "gofumpt/format",
+  "goimports/imports",
+  "isort/unsorted",
+  "prettier/format",
+  "rustfmt/format",
+  "terraform-fmt/format",
+  "dotnet-format/WHITESPACE",
+  "dotnet-format/FINALNEWLINE",
+  "dotnet-format/IDE0055",
+];
+
+/**
+ * Bundled rationale and fix hints for `check --explain`, keyed by the full
+ * rule id (`<runner>/<code>`). This covers the rules new contributors hit
+ * most; a rule missing here is printed with its code alone.
+ */
+export const RULE_EXPLANATIONS: Readonly<Record<string, RuleExplanation>> = {
+  ...Object.fromEntries(FORMAT_RULES.map((rule) => [rule, FORMATTED])),
+
+  // Python
+  "ruff/E501": {
+    why: "Long lines are hard to read side by side and in review.",
+    fix: "Break the expression over several lines, or raise line_length in the config.",
+  },
+  "ruff/F401": {
+    why: "An unused import slows start-up and suggests code that was removed or never written.",
+    fix: "Delete the import; in `__init__.py`, list re-exports in `__all__`.",
+  },
+  "ruff/F841": {
+    why: "A variable that is assigned but never read is usually a typo or leftover.",
+    fix: "Remove the assignment, or name it `_` if the value is deliberately discarded.",
+  },
+  "ruff/E722": {
+    why: "A bare `except:` also catches KeyboardInterrupt and SystemExit, hiding real failures.",
+    fix: "Catch the specific exceptions you expect, or at least `except Exception:`.",
+  },
+  "ruff/B006": {
+    why: "A mutable default argument is created once and shared by every call.",
+    fix: "Default to `None` and create the list or dict inside the function.",
+  },
+  "ruff/S101": {
+    why: "`assert` is stripped under `python -O`, so it cannot guard production code.",
+    fix: "Raise an explicit exception; asserts belong in tests.",
+  },
+  "pyright/reportMissingImports": {
+    why: "The module cannot be resolved, so nothing that uses it is type-checked.",
+    fix: "Install the package in the environment pyright uses, or fix the import path.",
+  },
+  "pyright/reportOptionalMemberAccess": {
+    why: "The value may be None here, and accessing an attribute on None raises at runtime.",
+    fix: "Check for None first, or narrow the type so None is ruled out.",
+  },
+  "pyright/reportAttributeAccessIssue": {
+    why: "The attribute does not exist on this type, which raises AttributeError at runtime.",
+    fix: "Check the spelling, or fix the declared type of the object.",
+  },
+  "pyright/reportArgumentType": {
+    why: "The argument's type does not match the parameter, so the call may misbehave.",
+    fix: "Convert the value, or correct the annotation that is wrong.",
+  },
+
+  // TypeScript / JavaScript
+  "tsc/TS2322": {
+    why: "The value's type is not assignable to the declared type.",
+    fix: "Fix the value, or widen the declared type if it is too narrow.",
+  },
+  "tsc/TS2304": {
+    why: "The name is not declared in scope, so this fails at runtime.",
+    fix: "Import or declare it; check for typos.",
+  },
+  "tsc/TS2345": {
+    why: "The argument's type does not match the parameter type.",
+    fix: "Convert or narrow the argument, or fix the function's signature.",
+  },
+  "tsc/TS7006": {
+    why: "An untyped parameter is implicitly `any`, which turns type checking off for it.",
+    fix: "Annotate the parameter's type.",
+  },
+  "biome/lint/correctness/noUnusedVariables": {
+    why: "An unused variable is dead code, and often a sign of a bug or leftover.",
+    fix: "Remove it, or prefix it with `_` when it must exist (e.g. a callback parameter).",
+  },
+  "biome/lint/suspicious/noExplicitAny": {
+    why: "`any` disables type checking for everything it touches.",
+    fix: "Use a real type, `unknown` plus narrowing, or a generic.",
+  },
+  "biome/lint/style/noNonNullAssertion": {
+    why: "`!` asserts a value is defined without checking, so a wrong guess crashes later.",
+    fix: "Check for null/undefined explicitly, or use optional chaining.",
+  },
+  "biome/lint/suspicious/noConsole": {
+    why: "Stray console calls leak noise or data into production output.",
+    fix: "Remove it, or use the project's logger.",
+  },
+  "eslint/no-unused-vars": {
+    why: "An unused variable is dead code, and often a sign of a bug or leftover.",
+    fix: "Remove it, or prefix it with `_` if your config allows that.",
+  },
+
+  // Go
+  "staticcheck/SA4006": {
+    why: "A value is assigned and then overwritten or dropped before anything reads it.",
+    fix: "Remove the assignment, or use the value — often a missed error check.",
+  },
+  "staticcheck/SA1019": {
+    why: "The identifier is deprecated and may be removed in a future release.",
+    fix: "Switch to the replacement named in its deprecation notice.",
+  },
+  "staticcheck/SA5011": {
+    why: "A pointer is dereferenced on a path where it can be nil.",
+    fix: "Return or branch before the dereference when the pointer is nil.",
+  },
+  "staticcheck/S1000": {
+    why: "A `select` with one case is just a channel operation written the long way.",
+    fix: "Use a plain send or receive.",
+  },
+  "staticcheck/ST1003": {
+    why: "The name breaks Go naming conventions (e.g. `Id` instead of `ID`).",
+    fix: "Rename it as suggested in the message.",
+  },
+  "gosec/G101": {
+    why: "A string that looks like a credential is hard-coded in the source.",
+    fix: "Load secrets from the environment or a secret store.",
+  },
+  "gosec/G104": {
+    why: "An error return is ignored, so a failure goes unnoticed.",
+    fix: "Handle the error, or assign it to `_` with a comment saying why it is safe.",
+  },
+  "gosec/G304": {
+    why: "A file path comes from a variable, which can allow path traversal.",
+    fix: "Clean the path and check it stays inside the directory you expect.",
+  },
+  "gosec/G401": {
+    why: "MD5 and SHA-1 are broken for security purposes.",
+    fix: "Use SHA-256 or a purpose-built algorithm (bcrypt/argon2 for passwords).",
+  },
+  "gosec/G404": {
+    why: "math/rand is predictable, so it must not produce secrets or tokens.",
+    fix: "Use crypto/rand.",
+  },
+  "errcheck/unchecked": {
+    why: "An error return is ignored, so a failure goes unnoticed.",
+    fix: "Handle the error, or assign it to `_` with a comment saying why it is safe.",
+  },
+  "ineffassign/ineffectual-assignment": {
+    why: "The assigned value is never read before being overwritten or going out of scope.",
+    fix: "Remove the assignment, or use the value — often a missed error check.",
+  },
+  "unconvert/unnecessary-conversion": {
+    why: "The value already has the target type, so the conversion is noise.",
+    fix: "Drop the conversion, or run `ai-guardrails check --fix`.",
+  },
+  "go-build/compile": {
+    why: "The code does not compile, so no other check of it can be trusted.",
+    fix: "Fix the compiler error; `go build ./...` in the module reproduces it.",
+  },
+  "go-mod-tidy/untidy": {
+    why: "go.mod or go.sum lists modules the code does not use, or misses ones it does.",
+    fix: "Run `go mod tidy` and commit the result.",
+  },
+
+  // Rust
+  "clippy/clippy::unwrap_used": {
+    why: "`unwrap()` panics on None/Err, turning a recoverable error into a crash.",
+    fix: "Propagate with `?`, or handle the None/Err case.",
+  },
+  "clippy/clippy::needless_return": {
+    why: "A trailing `return` is redundant in Rust, where the last expression is the value.",
+    fix: "Drop `return` and the semicolon.",
+  },
+
+  // Shell
+  "shellcheck/SC2086": {
+    why: "An unquoted variable is split on spaces and glob-expanded, so paths with spaces break.",
+    fix: 'Quote it: "$var".',
+  },
+  "shellcheck/SC2046": {
+    why: "Unquoted command substitution is split on spaces and glob-expanded.",
+    fix: 'Quote it: "$(cmd)", or read the output into an array.',
+  },
+  "shellcheck/SC2155": {
+    why: "`local x=$(cmd)` returns local's status, masking the command's failure.",
+    fix: "Declare and assign separately: `local x; x=$(cmd)`.",
+  },
+  "shellcheck/SC2034": {
+    why: "The variable is assigned but never used, often a typo in its name.",
+    fix: "Remove it, or export it if another process reads it.",
+  },
+
+  // Containers
+  "hadolint/DL3007": {
+    why: "`:latest` changes under you, so builds are not reproducible.",
+    fix: "Pin the image to a version tag or digest.",
+  },
+  "hadolint/DL3008": {
+    why: "Unpinned apt packages make builds change whenever the repository does.",
+    fix: "Pin versions: `apt-get install foo=1.2.*`.",
+  },
+  "hadolint/DL3025": {
+    why: "Shell-form CMD runs under /bin/sh, which does not forward signals to your process.",
+    fix: 'Use the JSON form: CMD ["app", "--flag"].',
+  },
+
+  // CI workflows
+  "actionlint/expression": {
+    why: "An expression is invalid, or interpolates untrusted input straight into a script.",
+    fix: "Fix the expression; pass untrusted values like PR titles through `env:` instead.",
+  },
+  "actionlint/runner-label": {
+    why: "No runner has this label, so the job waits in the queue forever.",
+    fix: "Fix the label, or declare self-hosted labels in .github/actionlint.yaml.",
+  },
+
+  // SQL
+  "sqlfluff/PRS": {
+    why: "sqlfluff could not parse the statement, so no other rule checked it.",
+    fix: "Fix the syntax, or set the right dialect in .sqlfluff or `[config] sqlfluff_dialect`.",
+  },
+  "sqlfluff/AM04": {
+    why: "`SELECT *` returns whatever columns the table has today, so results change under you.",
+    fix: "List the columns the query needs.",
+  },
+
+  // Protobuf
+  "buf/FIELD_LOWER_SNAKE_CASE": {
+    why: "Generated code maps field names per language; lower_snake_case is what every generator expects.",
+    fix: "Rename the field — safe before release, since the wire format uses field numbers.",
+  },
+  "buf-breaking/FIELD_NO_DELETE": {
+    why: "Deleting a field lets its number be reused, so old clients misread new messages.",
+    fix: "Keep the field, or delete it and add `reserved` for its number and name.",
+  },
+
+  // Helm
+  "helm-lint/chart": {
+    why: "Chart.yaml is missing metadata helm and chart repositories rely on.",
+    fix: "Add the field helm names, e.g. `icon` or a SemVer `version`.",
+  },
+  "helm-template/render": {
+    why: "The chart does not render with its default values, so `helm install` fails too.",
+    fix: "Fix the template helm names, or give the value it needs a default in values.yaml.",
+  },
+
+  // OpenAPI
+  "spectral/oas3-schema": {
+    why: "The document is not valid OpenAPI 3, so generators and gateways may reject or misread it.",
+    fix: "Fix the property spectral names to match the OpenAPI schema at that path.",
+  },
+  "spectral/oas2-schema": {
+    why: "The document is not valid Swagger 2.0, so generators and gateways may reject or misread it.",
+    fix: "Fix the property spectral names to match the Swagger 2.0 schema at that path.",
+  },
+  "spectral/operation-operationId": {
+    why: "Client generators name methods after operationId; without it they invent unstable names.",
+    fix: "Give the operation a unique `operationId`.",
+  },
+
+  // Docs and prose
+  "codespell/spell": {
+    why: "A common misspelling; typos in identifiers and docs are hard to search for.",
+    fix: "Apply the suggested spelling, or add the word to the codespell ignore list.",
+  },
+  "markdownlint/MD013": {
+    why: "Long lines make Markdown diffs hard to review.",
+    fix: "Wrap the paragraph; tables and URLs can be excluded in the config.",
+  },
+  "markdownlint/MD041": {
+    why: "Documents should start with a top-level heading so they render with a title.",
+    fix: "Make the first line a `# Heading`.",
+  },
+
+  // Dependencies
+  "lockfile-sync/go": {
+    why: "go.sum does not match the modules go.mod pulls in, so builds elsewhere fail.",
+    fix: "Run `go mod tidy`, or `go clean -modcache` if a cached module was modified.",
+  },
+  "lockfile-sync/npm": {
+    why: "package-lock.json no longer matches package.json, so `npm ci` in CI fails.",
+    fix: "Run `npm install` and commit package-lock.json.",
+  },
+  "lockfile-sync/bun": {
+    why: "The bun lockfile no longer matches package.json; a frozen install fails.",
+    fix: "Run `bun install` and commit the lockfile.",
+  },
+  "lockfile-sync/pip-compile": {
+    why: "requirements.txt is not what requirements.in compiles to, so installs drift.",
+    fix: "Run `pip-compile requirements.in` and commit requirements.txt.",
+  },
+  "lockfile-sync/poetry": {
+    why: "poetry.lock was not updated after pyproject.toml changed.",
+    fix: "Run `poetry lock` and commit poetry.lock.",
+  },
+
+  // Repository hygiene
+  "file-hygiene/large-file": {
+    why: "Large files bloat every clone for good, even once deleted from the tree.",
+    fix: "Remove it, or list it in [config] file_hygiene_allow if it belongs here.",
+  },
+  "file-hygiene/binary": {
+    why: "Binaries are usually build output, unreviewable and stale on the next build.",
+    fix: "Delete it and ignore its directory, or allow it in [config] file_hygiene_allow.",
+  },
+  "file-hygiene/private-key": {
+    why: "Anyone who can read the repository, or its history, holds the key.",
+    fix: "Revoke the key, remove the file, and load keys from a secret store instead.",
+  },
+  "file-hygiene/aws-access-key": {
+    why: "AWS credentials in source are found and abused within minutes of a push.",
+    fix: "Deactivate the key in IAM, then read credentials from the environment.",
+  },
+};
+
+/**
+ * The `why:`/`fix:` lines `--explain` prints under a finding, indented to sit
+ * beneath it. Empty for rules without a bundled explanation.
+ */
+export function explainIssue(issue: Pick<LintIssue, "rule">): string[] {
+  const explanation = RULE_EXPLANATIONS[issue.rule];
+  if (explanation === undefined) return [];
+  const lines = [`    why: ${explanation.why}`];
+  if (explanation.fix !== undefined) lines.push(`    fix: ${explanation.fix}`);
+  return lines;
+}
@@ -0,0 +1,63 @@
+import type { LintIssue, Severity } from "@/models/lint-issue";
+import type { RunnerReport } from "@/models/runner-report";
+import { withLinterReports } from "@/models/runner-report";
+import { groupBy } from "@/utils/collections";
+
+/** GitHub Actions workflow command per severity */
+const COMMANDS: Record<Severity, string> = {
+  error: "error",
+  warning: "warning",
+  info: "notice",
+};
+
+/** Escape a workflow command's message, as @actions/core does */
+function escapeData(value: string): string {
+  return value.replace(/%/g, "%25").replace(/\r/g, "%0D").replace(/\n/g, "%0A");
+}
+
+/** Escape a workflow command property value, which also ends at `,` and `:` */
+function escapeProperty(value: string): string {
+  return escapeData(value).replace(/:/g, "%3A").replace(/,/g, "%2C");
+}
+
+function annotation(issue: LintIssue): string {
+  const properties = [
+    `file=${escapeProperty(issue.file)}`,
+    `line=${issue.line}`,
+    `col=${issue.col}`,
+    `title=${escapeProperty(issue.rule)}`,
+  ].join(",");
+  return `::${COMMANDS[issue.severity]} ${properties}::${escapeData(issue.message)}`;
+}
+
+function runnerLines(runner: RunnerReport, issues: readonly LintIssue[]): string[] {
+  if (runner.status === "error") {
+    const title = escapeProperty(`${runner.name} failed`);
+    return [`::error title=${title}::${escapeData(runner.message ?? "runner failed")}`];
+  }
+  return issues.map(annotation);
+}
+
+/**
+ * Render findings as GitHub Actions workflow commands, so they show up as
+ * annotations on the pull request diff. Each runner with findings, or that
+ * failed, gets a collapsible `::group::`. Baselined findings are left out:
+ * they do not fail the check. Absolute paths are fine — the Actions runner
+ * maps paths inside the workspace to repository paths.
+ */
+export function issuesToGithub(
+  issues: LintIssue[],
+  runners: readonly RunnerReport[] = [],
+  baselined: ReadonlySet<string> = new Set()
+): string {
+  const fresh = issues.filter((issue) => !baselined.has(issue.fingerprint));
+  const byLinter = groupBy(fresh, (issue) => issue.linter);
+  const all = withLinterReports(runners, byLinter.keys());
+  return all
+    .flatMap((runner) => {
+      const lines = runnerLines(runner, byLinter.get(runner.runnerId) ?? []);
+      if (lines.length === 0) return [];
+      return [`::group::${runner.name}`, ...lines, "::endgroup::"];
+    })
+    .join("\n");
+}
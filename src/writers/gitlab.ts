@@ -0,0 +1,61 @@
+import { relative, sep } from "node:path";
+import type { LintIssue, Severity } from "@/models/lint-issue";
+import { computeHash } from "@/utils/hash";
+
+type GitlabSeverity = "info" | "minor" | "major" | "critical" | "blocker";
+
+/** GitLab Code Quality severity per finding severity */
+const SEVERITIES: Record<Severity, GitlabSeverity> = {
+  error: "major",
+  warning: "minor",
+  info: "info",
+};
+
+export interface GitlabIssue {
+  description: string;
+  check_name: string;
+  fingerprint: string;
+  severity: GitlabSeverity;
+  location: { path: string; lines: { begin: number } };
+}
+
+/**
+ * Collapse the parts of a message that drift between runs of the same
+ * finding: whitespace and numbers, e.g. the length in "Line too long (93 > 88)".
+ */
+function normalizeMessage(message: string): string {
+  return message.replace(/\d+/g, "N").replace(/\s+/g, " ").trim();
+}
+
+function repoPath(file: string, projectDir: string | undefined): string {
+  const path = projectDir !== undefined ? relative(projectDir, file) : file;
+  return path.split(sep).join("/");
+}
+
+/**
+ * Render findings as a GitLab Code Quality report. The fingerprint hashes the
+ * path, rule and normalized message but not the line, so a finding keeps it
+ * while code above it moves and GitLab can tell new findings from resolved
+ * ones. Repeats of one finding in a file are numbered to keep them distinct.
+ * Baselined findings stay in: the merge request diffs against the target
+ * branch's report, where they appear too. Paths are relative to `projectDir`.
+ */
+export function issuesToGitlab(
+  issues: LintIssue[],
+  projectDir?: string
+): GitlabIssue[] {
+  const seen = new Map<string, number>();
+  return issues.map((issue) => {
+    const path = repoPath(issue.file, projectDir);
+    const key = [path, issue.rule, normalizeMessage(issue.message)].join("\n");
+    const occurrence = seen.get(key) ?? 0;
+    seen.set(key, occurrence + 1);
+    return {
+      description: issue.message,
+      check_name: issue.rule,
+      fingerprint: computeHash(occurrence === 0 ? key : `${key}\n${occurrence}`),
+      severity: SEVERITIES[issue.severity],
+      location: { path, lines: { begin: issue.line } },
+    };
+  });
+}
@@ -0,0 +1,118 @@
+import type { LintIssue } from "@/models/lint-issue";
+import type { RunnerReport, RunnerStatus } from "@/models/runner-report";
+import { withLinterReports } from "@/models/runner-report";
+import { groupBy } from "@/utils/collections";
+
+/**
+ * Bump on any breaking change to JsonReport — renamed or removed fields, or
+ * changed meaning. Adding optional fields is not breaking.
+ */
+export const JSON_REPORT_SCHEMA_VERSION = 1;
+
+interface JsonFinding {
+  file: string;
+  line: number;
+  col: number;
+  rule: string;
+  severity: LintIssue["severity"];
+  message: string;
+  fingerprint: string;
+  /** True for an accepted pre-existing finding, listed in the baseline */
+  baselined: boolean;
+  /** Module the runner ran in, project-relative — multi-module Go repos */
+  module?: string;
+  /** Every runner that reported this finding, when duplicates were collapsed */
+  reportedBy?: readonly string[];
+}
+
+interface JsonRunner {
+  id: string;
+  name: string;
+  status: RunnerStatus;
+  skipped: boolean;
+  cached: boolean;
+  durationMs: number;
+  error?: string;
+  findings: JsonFinding[];
+}
+
+/** Findings by severity and runners by outcome, shared with the summary format */
+export interface JsonSummary {
+  errors: number;
+  warnings: number;
+  infos: number;
+  skipped: number;
+  disabled: number;
+  failed: number;
+  /** Runners stopped or never started by `--fail-fast`; absent when none */
+  cancelled?: number;
+}
+
+export interface JsonReport {
+  schemaVersion: number;
+  runners: JsonRunner[];
+  summary: JsonSummary;
+}
+
+function toFinding(issue: LintIssue, baselined: ReadonlySet<string>): JsonFinding {
+  return {
+    file: issue.file,
+    line: issue.line,
+    col: issue.col,
+    rule: issue.rule,
+    severity: issue.severity,
+    message: issue.message,
+    fingerprint: issue.fingerprint,
+    baselined: baselined.has(issue.fingerprint),
+    ...(issue.module !== undefined && { module: issue.module }),
+    ...(issue.reportedBy !== undefined && { reportedBy: issue.reportedBy }),
+  };
+}
+
+/** Count `issues` by severity and `runners` by how they ended */
+export function summarizeReport(
+  issues: readonly LintIssue[],
+  runners: readonly RunnerReport[]
+): JsonSummary {
+  const cancelled = runners.filter((r) => r.status === "cancelled").length;
+  return {
+    errors: issues.filter((i) => i.severity === "error").length,
+    warnings: issues.filter((i) => i.severity === "warning").length,
+    infos: issues.filter((i) => i.severity === "info").length,
+    skipped: runners.filter((r) => r.status === "skipped").length,
+    disabled: runners.filter((r) => r.status === "disabled").length,
+    failed: runners.filter((r) => r.status === "error").length,
+    ...(cancelled > 0 && { cancelled }),
+  };
+}
+
+/**
+ * Convert runner reports and their issues into the versioned JSON report.
+ * Issues from a linter with no matching report get a synthetic "ok" entry.
+ * Findings whose fingerprint is in `baselined` are marked `baselined: true`.
+ */
+export function issuesToJson(
+  issues: LintIssue[],
+  runners: readonly RunnerReport[] = [],
+  baselined: ReadonlySet<string> = new Set()
+): JsonReport {
+  const byLinter = groupBy(issues, (issue) => issue.linter);
+  const all = withLinterReports(runners, byLinter.keys());
+
+  return {
+    schemaVersion: JSON_REPORT_SCHEMA_VERSION,
+    runners: all.map((runner) => ({
+      id: runner.runnerId,
+      name: runner.name,
+      status: runner.status,
+      skipped: runner.status === "skipped",
+      cached: runner.cached === true,
+      durationMs: runner.durationMs,
+      ...(runner.message !== undefined && { error: runner.message }),
+      findings: (byLinter.get(runner.runnerId) ?? []).map((issue) =>
+        toFinding(issue, baselined)
+      ),
+    })),
+    summary: summarizeReport(issues, runners),
+  };
+}
@@ -0,0 +1,161 @@
+import type { LintIssue } from "@/models/lint-issue";
+import type { RunnerReport, RunnerStatus } from "@/models/runner-report";
+import { withLinterReports } from "@/models/runner-report";
+import { groupBy } from "@/utils/collections";
+
+const XML_ESCAPES: Record<string, string> = {
+  "&": "&amp;",
+  "<": "&lt;",
+  ">": "&gt;",
+  '"': "&quot;",
+  "'": "&apos;",
+};
+
+function escapeXml(value: string): string {
+  return value.replace(/[&<>"']/g, (ch) => XML_ESCAPES[ch] ?? ch);
+}
+
+function seconds(durationMs: number): string {
+  return (durationMs / 1000).toFixed(3);
+}
+
+/** Render ` key="value"` pairs with escaped values */
+function attrs(values: Record<string, string | number>): string {
+  return Object.entries(values)
+    .map(([key, value]) => ` ${key}="${escapeXml(String(value))}"`)
+    .join("");
+}
+
+/** Why a runner that did not run is reported as a skipped testcase */
+const SKIP_REASONS: Partial<Record<RunnerStatus, string>> = {
+  disabled: "disabled",
+  skipped: "not installed",
+  cancelled: "cancelled (--fail-fast)",
+};
+
+function isSkipped(runner: RunnerReport): boolean {
+  return SKIP_REASONS[runner.status] !== undefined;
+}
+
+function buildTestcases(
+  runner: RunnerReport,
+  issues: readonly LintIssue[],
+  baselined: ReadonlySet<string>
+): string[] {
+  const runnerCase = attrs({ name: runner.name, classname: runner.runnerId, time: 0 });
+
+  if (isSkipped(runner)) {
+    const reason = SKIP_REASONS[runner.status] ?? runner.status;
+    return [
+      `    <testcase${runnerCase}>`,
+      `      <skipped${attrs({ message: `${runner.name} ${reason}` })}/>`,
+      "    </testcase>",
+    ];
+  }
+
+  if (runner.status === "error") {
+    const message = runner.message ?? "runner failed";
+    return [
+      `    <testcase${runnerCase}>`,
+      `      <error${attrs({ message })}>${escapeXml(message)}</error>`,
+      "    </testcase>",
+    ];
+  }
+
+  // A clean runner still gets one passing testcase so it shows up in CI summaries
+  if (issues.length === 0) return [`    <testcase${runnerCase}/>`];
+
+  return issues.flatMap((issue) => {
+    const name = `${issue.file}:${issue.line}:${issue.col} ${issue.rule}`;
+    const failure = attrs({ message: issue.message, type: issue.severity });
+    const result = baselined.has(issue.fingerprint)
+      ? `      <skipped${attrs({ message: `baselined: ${issue.message}` })}/>`
+      : `      <failure${failure}>${escapeXml(issue.message)}</failure>`;
+    return [
+      `    <testcase${attrs({ name, classname: runner.runnerId, time: 0 })}>`,
+      result,
+      "    </testcase>",
+    ];
+  });
+}
+
+/** Findings that fail the testcase: those not in the baseline */
+function failureCount(
+  runner: RunnerReport,
+  issues: readonly LintIssue[],
+  baselined: ReadonlySet<string>
+): number {
+  if (runner.status !== "ok") return 0;
+  return issues.filter((issue) => !baselined.has(issue.fingerprint)).length;
+}
+
+/** A skipped runner is one skipped testcase; otherwise its baselined findings */
+function skippedCount(
+  runner: RunnerReport,
+  issues: readonly LintIssue[],
+  baselined: ReadonlySet<string>
+): number {
+  if (isSkipped(runner)) return 1;
+  if (runner.status !== "ok") return 0;
+  return issues.length - failureCount(runner, issues, baselined);
+}
+
+/** One testcase per finding; any other runner outcome is a single testcase */
+function testCount(runner: RunnerReport, issues: readonly LintIssue[]): number {
+  return runner.status === "ok" && issues.length > 0 ? issues.length : 1;
+}
+
+function buildSuite(
+  runner: RunnerReport,
+  issues: readonly LintIssue[],
+  baselined: ReadonlySet<string>
+): string[] {
+  const suite = attrs({
+    name: runner.name,
+    tests: testCount(runner, issues),
+    failures: failureCount(runner, issues, baselined),
+    errors: runner.status === "error" ? 1 : 0,
+    skipped: skippedCount(runner, issues, baselined),
+    time: seconds(runner.durationMs),
+  });
+  return [
+    `  <testsuite${suite}>`,
+    ...buildTestcases(runner, issues, baselined),
+    "  </testsuite>",
+  ];
+}
+
+/**
+ * Convert runner reports and their issues into a JUnit XML document.
+ * Each runner is a <testsuite> and each finding a failing <testcase>, or a
+ * skipped one when its fingerprint is in `baselined`; skipped, disabled,
+ * cancelled and failed runners appear as a single skipped/errored testcase.
+ */
+export function issuesToJunit(
+  issues: LintIssue[],
+  runners: readonly RunnerReport[] = [],
+  baselined: ReadonlySet<string> = new Set()
+): string {
+  const byLinter = groupBy(issues, (issue) => issue.linter);
+  const all = withLinterReports(runners, byLinter.keys());
+  const issuesOf = (r: RunnerReport) => byLinter.get(r.runnerId) ?? [];
+
+  const totals = attrs({
+    name: "ai-guardrails",
+    tests: all.reduce((sum, r) => sum + testCount(r, issuesOf(r)), 0),
+    failures: all.reduce(
+      (sum, r) => sum + failureCount(r, issuesOf(r), baselined),
+      0
+    ),
+    errors: all.filter((r) => r.status === "error").length,
+    skipped: all.reduce((sum, r) => sum + skippedCount(r, issuesOf(r), baselined), 0),
+    time: seconds(all.reduce((sum, r) => sum + r.durationMs, 0)),
+  });
+
+  return [
+    '<?xml version="1.0" encoding="UTF-8"?>',
+    `<testsuites${totals}>`,
+    ...all.flatMap((runner) => buildSuite(runner, issuesOf(runner), baselined)),
+    "</testsuites>",
+  ].join("\n");
+}
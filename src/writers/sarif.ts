@@ -1,21 +1,52 @@
+import { isAbsolute, relative, sep } from "node:path";
+import { pathToFileURL } from "node:url";
 import type { LintIssue } from "@/models/lint-issue";
+import type { RunnerReport, RunnerStatus } from "@/models/runner-report";
+import { withLinterReports } from "@/models/runner-report";
+import { groupBy } from "@/utils/collections";
+import { getVersion } from "@/utils/version";
+
+/** The project root, as code scanning's checkout maps it to the repository */
+const SRCROOT = "%SRCROOT%";
+
+interface SarifArtifactLocation {
+  uri: string;
+  uriBaseId?: typeof SRCROOT;
+}
 
 interface SarifLocation {
   physicalLocation: {
-    artifactLocation: { uri: string };
+    artifactLocation: SarifArtifactLocation;
     region: { startLine: number; startColumn: number };
   };
 }
 
 interface SarifResult {
   ruleId: string;
+  ruleIndex: number;
   level: "error" | "warning" | "note";
   message: { text: string };
   locations: SarifLocation[];
+  /** "unchanged" for a finding in the baseline, so code scanning can tell them apart */
+  baselineState: "new" | "unchanged";
+}
+
+interface SarifRule {
+  id: string;
+  defaultConfiguration: { level: SarifResult["level"] };
+}
+
+interface SarifInvocation {
+  executionSuccessful: boolean;
+  toolExecutionNotifications?: Array<{ level: "error"; message: { text: string } }>;
 }
 
 interface SarifRun {
-  tool: { driver: { name: string; version: string; rules: unknown[] } };
+  /** `version` is ai-guardrails' own: the runner's tool version is not tracked */
+  tool: { driver: { name: string; version: string; rules: SarifRule[] } };
+  automationDetails: { id: string };
+  originalUriBaseIds?: Record<typeof SRCROOT, { uri: string }>;
+  invocations: SarifInvocation[];
   results: SarifResult[];
 }
 
@@ -26,42 +57,123 @@ interface SarifLog {
 }
 
 function severityToLevel(severity: LintIssue["severity"]): SarifResult["level"] {
-  return severity === "error" ? "error" : "warning";
+  return severity === "info" ? "note" : severity;
 }
 
 /**
- * Convert LintIssue[] to SARIF 2.1.0 format.
+ * Where `file` is: relative to %SRCROOT% when it lies in `projectDir`, as code
+ * scanning needs to map it to the repository; a file:// URI otherwise.
  */
-export function issuesToSarif(issues: LintIssue[]): SarifLog {
+function artifactLocation(
+  file: string,
+  projectDir: string | undefined
+): SarifArtifactLocation {
+  if (projectDir !== undefined) {
+    const rel = relative(projectDir, file);
+    if (rel !== "" && !rel.startsWith("..") && !isAbsolute(rel)) {
+      const uri = rel.split(sep).map(encodeURIComponent).join("/");
+      return { uri, uriBaseId: SRCROOT };
+    }
+  }
+  return { uri: pathToFileURL(file).href };
+}
+
+/** `projectDir` as the file:// URI %SRCROOT% stands for, with its trailing slash */
+function srcRootUri(projectDir: string): string {
+  const { href } = pathToFileURL(projectDir);
+  return href.endsWith("/") ? href : `${href}/`;
+}
+
+function buildInvocation(runner: RunnerReport): SarifInvocation {
+  if (runner.status !== "error") return { executionSuccessful: true };
+  return {
+    executionSuccessful: false,
+    toolExecutionNotifications: [
+      { level: "error", message: { text: runner.message ?? "runner failed" } },
+    ],
+  };
+}
+
+function buildRun(
+  runner: RunnerReport,
+  issues: readonly LintIssue[],
+  baselined: ReadonlySet<string>,
+  projectDir: string | undefined
+): SarifRun {
+  const rules: SarifRule[] = [];
+  const ruleIndex = new Map<string, number>();
+  for (const issue of issues) {
+    if (!ruleIndex.has(issue.rule)) {
+      ruleIndex.set(issue.rule, rules.length);
+      rules.push({
+        id: issue.rule,
+        defaultConfiguration: { level: severityToLevel(issue.severity) },
+      });
+    }
+  }
+
   const results: SarifResult[] = issues.map((issue) => ({
     ruleId: issue.rule,
+    ruleIndex: ruleIndex.get(issue.rule) ?? 0,
     level: severityToLevel(issue.severity),
     message: { text: issue.message },
     locations: [
       {
         physicalLocation: {
-          artifactLocation: { uri: issue.file },
+          artifactLocation: artifactLocation(issue.file, projectDir),
           region: { startLine: issue.line, startColumn: issue.col },
         },
       },
     ],
+    baselineState: baselined.has(issue.fingerprint) ? "unchanged" : "new",
   }));
 
-  const run: SarifRun = {
-    tool: {
-      driver: {
-        name: "ai-guardrails",
-        version: "3.0.0",
-        rules: [],
-      },
-    },
+  return {
+    tool: { driver: { name: runner.name, version: getVersion(), rules } },
+    // Distinct category per run so code scanning keeps each runner's alerts apart
+    automationDetails: { id: `ai-guardrails/${runner.runnerId}/` },
+    ...(projectDir !== undefined && {
+      originalUriBaseIds: { [SRCROOT]: { uri: srcRootUri(projectDir) } },
+    }),
+    invocations: [buildInvocation(runner)],
     results,
   };
+}
+
+/** Runners that produced no results, clean or otherwise */
+const NOT_RUN: ReadonlySet<RunnerStatus> = new Set([
+  "skipped",
+  "disabled",
+  "cancelled",
+]);
+
+/**
+ * Convert LintIssue[] to SARIF 2.1.0 format with one run per runner.
+ * Runners that were skipped, disabled or cancelled produce no run. Issues
+ * from a linter with no matching report still get a run, named after the linter.
+ * Each result's `baselineState` is "unchanged" when its fingerprint is in
+ * `baselined`, "new" otherwise. Files in `projectDir` are relative to
+ * %SRCROOT%; without it, and outside it, they are file:// URIs.
+ */
+export function issuesToSarif(
+  issues: LintIssue[],
+  runners: readonly RunnerReport[] = [],
+  baselined: ReadonlySet<string> = new Set(),
+  projectDir?: string
+): SarifLog {
+  const byLinter = groupBy(issues, (issue) => issue.linter);
+  const runs: SarifRun[] = [];
+
+  for (const runner of withLinterReports(runners, byLinter.keys())) {
+    if (NOT_RUN.has(runner.status)) continue;
+    const own = byLinter.get(runner.runnerId) ?? [];
+    runs.push(buildRun(runner, own, baselined, projectDir));
+  }
 
   return {
     version: "2.1.0",
     $schema:
       "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
-    runs: [run],
+    runs,
   };
 }
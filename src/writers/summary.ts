@@ -0,0 +1,90 @@
+import type { LintIssue } from "@/models/lint-issue";
+import type { RunnerReport, RunnerStatus } from "@/models/runner-report";
+import { withLinterReports } from "@/models/runner-report";
+import { groupBy } from "@/utils/collections";
+import { type JsonSummary, summarizeReport } from "@/writers/json";
+
+/**
+ * Bump on any breaking change to SummaryReport — renamed or removed fields, or
+ * changed meaning. Adding optional fields is not breaking.
+ */
+export const SUMMARY_REPORT_SCHEMA_VERSION = 1;
+
+/** How the check ended, which only the pipeline knows */
+export interface CheckOutcome {
+  /** False when the check fails, with the same exit code as any other format */
+  passed: boolean;
+  /** Wall-clock time of the whole check */
+  durationMs: number;
+}
+
+interface SummaryRunner {
+  id: string;
+  name: string;
+  status: RunnerStatus;
+  cached: boolean;
+  durationMs: number;
+  errors: number;
+  warnings: number;
+  infos: number;
+  /** Findings the baseline already holds, left out of the counts above */
+  baselined: number;
+  error?: string;
+}
+
+/** The JSON report's totals, over new findings only, and the baselined count */
+type SummaryTotals = JsonSummary & { baselined: number };
+
+export interface SummaryReport {
+  schemaVersion: number;
+  passed: boolean;
+  durationMs: number;
+  runners: SummaryRunner[];
+  summary: SummaryTotals;
+}
+
+/**
+ * The JSON report's counts without its findings: per-runner status and counts
+ * by severity, the totals, and the check's `outcome`. The counts cover new
+ * findings; those whose fingerprint is in `baselined` are counted apart.
+ * Issues from a linter with no matching report get a synthetic "ok" entry, as
+ * in issuesToJson.
+ */
+export function issuesToSummary(
+  issues: LintIssue[],
+  runners: readonly RunnerReport[],
+  outcome: CheckOutcome,
+  baselined: ReadonlySet<string> = new Set()
+): SummaryReport {
+  const byLinter = groupBy(issues, (issue) => issue.linter);
+  const all = withLinterReports(runners, byLinter.keys());
+  const isNew = (issue: LintIssue) => !baselined.has(issue.fingerprint);
+  const fresh = issues.filter(isNew);
+
+  return {
+    schemaVersion: SUMMARY_REPORT_SCHEMA_VERSION,
+    passed: outcome.passed,
+    durationMs: outcome.durationMs,
+    runners: all.map((runner) => {
+      const own = byLinter.get(runner.runnerId) ?? [];
+      const ownFresh = own.filter(isNew);
+      const counts = summarizeReport(ownFresh, []);
+      return {
+        id: runner.runnerId,
+        name: runner.name,
+        status: runner.status,
+        cached: runner.cached === true,
+        durationMs: runner.durationMs,
+        errors: counts.errors,
+        warnings: counts.warnings,
+        infos: counts.infos,
+        baselined: own.length - ownFresh.length,
+        ...(runner.message !== undefined && { error: runner.message }),
+      };
+    }),
+    summary: {
+      ...summarizeReport(fresh, runners),
+      baselined: issues.length - fresh.length,
+    },
+  };
+}
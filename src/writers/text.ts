@@ -1,28 +1,284 @@
 import type { LintIssue } from "@/models/lint-issue";
+import { SEVERITIES } from "@/models/lint-issue";
+import type { RunnerProgress, RunnerReport } from "@/models/runner-report";
+import { explainIssue } from "@/writers/explanations";
 
 /**
  * Format a list of lint issues as human-readable text.
+ * Issues whose fingerprint is in `baselined` are suffixed with `(baselined)`;
+ * with `explain`, each is followed by its rule's rationale and fix hint.
+ * The summary line counts issues per severity.
  * Returns an empty string if there are no issues.
  */
-export function formatIssues(issues: LintIssue[]): string {
+export function formatIssues(
+  issues: LintIssue[],
+  baselined: ReadonlySet<string> = new Set(),
+  explain = false
+): string {
   if (issues.length === 0) return "";
+  const lines = issues.map((issue) => issueLine(issue, baselined, explain));
+  return [...lines, "", formatIssueSummary(issues, baselined)].join("\n");
+}
+
+/**
+ * The summary line alone, e.g. "5 issue(s) found: 1 error, 3 warning, 1 info".
+ * Returns an empty string if there are no issues.
+ */
+export function formatIssueSummary(
+  issues: readonly LintIssue[],
+  baselined: ReadonlySet<string> = new Set()
+): string {
+  if (issues.length === 0) return "";
+  const counts = SEVERITIES.map(
+    (severity) => `${issues.filter((i) => i.severity === severity).length} ${severity}`
+  ).join(", ");
+  const baselinedCount = issues.filter((i) => baselined.has(i.fingerprint)).length;
+  const baselinedNote = baselinedCount > 0 ? ` (${baselinedCount} baselined)` : "";
+  return `${issues.length} issue(s) found: ${counts}${baselinedNote}`;
+}
 
-  const lines: string[] = [];
-  for (const issue of issues) {
-    const location = `${issue.file}:${issue.line}:${issue.col}`;
-    const severity = issue.severity.toUpperCase();
-    lines.push(`${location}: [${severity}] ${issue.rule}: ${issue.message}`);
+/** `--max-findings` / `--max-per-runner`; an absent limit prints everything */
+export interface FindingLimits {
+  readonly total?: number;
+  readonly perRunner?: number;
+}
+
+/**
+ * Counts the findings a streamed report prints against `--max-findings` and
+ * `--max-per-runner`, across runners, and how many it held back.
+ */
+export class FindingCap {
+  private readonly limits: FindingLimits;
+  private readonly shownByRunner = new Map<string, number>();
+  private shown = 0;
+  private hidden = 0;
+
+  constructor(limits: FindingLimits) {
+    this.limits = limits;
   }
-  lines.push("");
-  lines.push(`${issues.length} issue(s) found`);
-  return lines.join("\n");
+
+  /** The issues still within the limits, counting them as printed */
+  take(issues: readonly LintIssue[]): LintIssue[] {
+    const { total, perRunner } = this.limits;
+    return issues.filter((issue) => {
+      const byRunner = this.shownByRunner.get(issue.linter) ?? 0;
+      if (
+        (total !== undefined && this.shown >= total) ||
+        (perRunner !== undefined && byRunner >= perRunner)
+      ) {
+        this.hidden++;
+        return false;
+      }
+      this.shown++;
+      this.shownByRunner.set(issue.linter, byRunner + 1);
+      return true;
+    });
+  }
+
+  /** The line saying how many findings were held back; empty when none were */
+  note(): string {
+    if (this.hidden === 0) return "";
+    return `... and ${this.hidden} more findings (use --max-findings 0 for all)`;
+  }
+}
+
+/** How `check --group-by` organizes the findings of a text report */
+export const GROUP_BYS = ["runner", "file", "severity"] as const;
+export type GroupBy = (typeof GROUP_BYS)[number];
+
+/** The --group-by value, `runner` when absent or unknown */
+export function groupByFromFlags(flags: Record<string, unknown>): GroupBy {
+  return GROUP_BYS.find((groupBy) => groupBy === flags.groupBy) ?? "runner";
+}
+
+function byLocation(a: LintIssue, b: LintIssue): number {
+  return a.file.localeCompare(b.file) || a.line - b.line || a.col - b.col;
+}
+
+/**
+ * Split findings into `--group-by file` or `severity` groups: files in path
+ * order, severities most severe first, each group's findings by location.
+ */
+export function groupIssues(
+  issues: readonly LintIssue[],
+  groupBy: Exclude<GroupBy, "runner">
+): { heading: string; issues: LintIssue[] }[] {
+  const sorted = issues.toSorted(byLocation);
+  const keys: string[] =
+    groupBy === "severity"
+      ? SEVERITIES.filter((severity) => sorted.some((i) => i.severity === severity))
+      : [...new Set(sorted.map((issue) => issue.file))];
+  return keys.map((key) => {
+    const group = sorted.filter((issue) =>
+      groupBy === "severity" ? issue.severity === key : issue.file === key
+    );
+    const label = groupBy === "severity" ? key.toUpperCase() : key;
+    return { heading: `${label} (${group.length})`, issues: group };
+  });
 }
 
 /**
- * Format a single lint issue as a string.
+ * Format findings collected from every runner under `--group-by` headings,
+ * a blank line between groups (explained, with `explain`; only those `cap`
+ * lets through). Returns an empty string if there are no issues.
+ */
+export function formatGroupedIssues(
+  issues: readonly LintIssue[],
+  groupBy: Exclude<GroupBy, "runner">,
+  baselined: ReadonlySet<string> = new Set(),
+  explain = false,
+  cap?: FindingCap
+): string {
+  const blocks = groupIssues(issues, groupBy).flatMap(({ heading, issues: group }) => {
+    const shown = cap !== undefined ? cap.take(group) : group;
+    if (shown.length === 0) return [];
+    const lines = shown.map((issue) => issueLine(issue, baselined, explain));
+    return [[heading, ...lines].join("\n")];
+  });
+  return blocks.join("\n\n");
+}
+
+/**
+ * Format one finished runner as it streams in: a `[3/7 complete]` status line,
+ * then its issues (explained, with `explain`; only those `cap` lets through).
+ * Without `findings` the status line stands alone, for a report that prints
+ * the findings grouped once every runner is done.
+ */
+export function formatRunnerProgress(
+  progress: RunnerProgress,
+  explain = false,
+  cap?: FindingCap,
+  findings = true
+): string {
+  const { report, issues, baselined, done, total } = progress;
+  const prefix = `[${done}/${total} complete] ${report.name}:`;
+  switch (report.status) {
+    case "skipped":
+      return `${prefix} not installed — skipping`;
+    case "disabled":
+      return `${prefix} disabled`;
+    case "error":
+      return `${prefix} failed — ${report.message ?? "unknown error"}`;
+    case "cancelled":
+      return `${prefix} ${report.message ?? "cancelled"}`;
+    case "ok": {
+      const cached = report.cached === true ? " (cached)" : "";
+      const found = issues.length === 0 ? "no issues" : `${issues.length} issue(s)`;
+      const status = `${prefix} ${found} in ${report.durationMs}ms${cached}`;
+      if (!findings) return status;
+      const shown = cap !== undefined ? cap.take(issues) : issues;
+      const lines = shown.map((issue) => issueLine(issue, baselined, explain));
+      return [status, ...lines].join("\n");
+    }
+  }
+}
+
+function issueLine(
+  issue: LintIssue,
+  baselined: ReadonlySet<string>,
+  explain: boolean
+): string {
+  const line = formatIssue(issue);
+  const shown = baselined.has(issue.fingerprint) ? `${line} (baselined)` : line;
+  return explain ? [shown, ...explainIssue(issue)].join("\n") : shown;
+}
+
+/**
+ * Format a single lint issue as a string, naming the other runners that
+ * reported it when duplicates were collapsed.
  */
 export function formatIssue(issue: LintIssue): string {
   const location = `${issue.file}:${issue.line}:${issue.col}`;
   const severity = issue.severity.toUpperCase();
-  return `${location}: [${severity}] ${issue.rule}: ${issue.message}`;
+  const others = (issue.reportedBy ?? []).filter((id) => id !== issue.linter);
+  const also = others.length > 0 ? ` (also reported by ${others.join(", ")})` : "";
+  return `${location}: [${severity}] ${issue.rule}: ${issue.message}${also}`;
+}
+
+/**
+ * Per-runner timing table, slowest first so the bottleneck is the top row.
+ * Disabled runners are left out; one string per line.
+ */
+export function formatRunnerTimings(
+  runners: readonly RunnerReport[],
+  issues: readonly LintIssue[]
+): string[] {
+  const shown = runners
+    .filter((runner) => runner.status !== "disabled")
+    .toSorted((a, b) => b.durationMs - a.durationMs);
+  if (shown.length === 0) return [];
+  const rows = [
+    ["RUNNER", "STATUS", "FINDINGS", "DURATION"],
+    ...shown.map((runner) => [
+      runner.name,
+      runner.cached === true ? `${runner.status} (cached)` : runner.status,
+      String(issues.filter((issue) => issue.linter === runner.runnerId).length),
+      `${runner.durationMs}ms`,
+    ]),
+  ];
+  const widths = [0, 1, 2, 3].map((col) =>
+    Math.max(...rows.map((row) => (row[col] ?? "").length))
+  );
+  // Numbers are right-aligned so durations line up by magnitude
+  return rows.map((row) =>
+    row
+      .map((cell, col) =>
+        col < 2 ? cell.padEnd(widths[col] ?? 0) : cell.padStart(widths[col] ?? 0)
+      )
+      .join("  ")
+  );
+}
+
+/** One pass of `check --repeat`: its runners and how long the whole pass took */
+export interface RepeatSample {
+  readonly runners: readonly RunnerReport[];
+  readonly durationMs: number;
+}
+
+function median(values: readonly number[]): number {
+  const sorted = values.toSorted((a, b) => a - b);
+  const mid = Math.floor(sorted.length / 2);
+  return sorted.length % 2 === 1
+    ? (sorted[mid] ?? 0)
+    : Math.round(((sorted[mid - 1] ?? 0) + (sorted[mid] ?? 0)) / 2);
+}
+
+/**
+ * `check --repeat` table: min, median and max wall-clock of each runner
+ * across the passes, slowest median first, then of the passes as a whole.
+ * Runners that never ran (disabled, not installed) are left out.
+ */
+export function formatRepeatTimings(samples: readonly RepeatSample[]): string[] {
+  const durations = new Map<string, number[]>();
+  for (const { runners } of samples) {
+    for (const runner of runners) {
+      if (runner.status === "disabled" || runner.status === "skipped") continue;
+      const seen = durations.get(runner.name) ?? [];
+      durations.set(runner.name, [...seen, runner.durationMs]);
+    }
+  }
+  const row = (name: string, values: readonly number[]) => [
+    name,
+    `${Math.min(...values)}ms`,
+    `${median(values)}ms`,
+    `${Math.max(...values)}ms`,
+  ];
+  const rows = [
+    ["RUNNER", "MIN", "MEDIAN", "MAX"],
+    ...[...durations]
+      .toSorted(([, a], [, b]) => median(b) - median(a))
+      .map(([name, values]) => row(name, values)),
+    row("total", samples.map((sample) => sample.durationMs)),
+  ];
+  const widths = [0, 1, 2, 3].map((col) =>
+    Math.max(...rows.map((cells) => (cells[col] ?? "").length))
+  );
+  const table = rows.map((cells) =>
+    cells
+      .map((cell, col) =>
+        col === 0 ? cell.padEnd(widths[col] ?? 0) : cell.padStart(widths[col] ?? 0)
+      )
+      .join("  ")
+  );
+  return [`Timings over ${samples.length} run(s), cache off:`, ...table];
 }
@@ -0,0 +1,112 @@
+import { describe, expect, test } from "bun:test";
+import { createGuardrails, EXIT_MISSING_TOOL } from "@/api";
+import { FakeCommandRunner } from "./fakes/fake-command-runner";
+import { FakeConsole } from "./fakes/fake-console";
+import { FakeFileManager } from "./fakes/fake-file-manager";
+
+const RUFF_ISSUE = JSON.stringify([
+  {
+    code: "E501",
+    filename: "/project/foo.py",
+    location: { row: 1, column: 1 },
+    message: "Line too long",
+  },
+]);
+
+function makeProject(): { fm: FakeFileManager; cr: FakeCommandRunner } {
+  const fm = new FakeFileManager();
+  fm.seed("/project/pyproject.toml", "[tool.ruff]");
+  fm.seed("/project/foo.py", "x = 1\n");
+  const cr = new FakeCommandRunner();
+  cr.register(["ruff", "check", "--output-format=json", "/project"], {
+    stdout: RUFF_ISSUE,
+    stderr: "",
+    exitCode: 1,
+  });
+  return { fm, cr };
+}
+
+describe("Guardrails.check", () => {
+  test("reports findings per runner with the CLI exit code", async () => {
+    const { fm, cr } = makeProject();
+    const guardrails = createGuardrails({ fileManager: fm, commandRunner: cr });
+
+    const { exitCode, newIssueCount, report } = await guardrails.check("/project");
+
+    expect(exitCode).toBe(1);
+    expect(newIssueCount).toBe(1);
+    const ruff = report.runners.find((r) => r.id === "ruff");
+    expect(ruff?.findings.map((f) => f.rule)).toEqual(["ruff/E501"]);
+  });
+
+  test("skips disabled runners", async () => {
+    const { fm, cr } = makeProject();
+    const guardrails = createGuardrails({ fileManager: fm, commandRunner: cr });
+
+    const { exitCode, report } = await guardrails.check("/project", {
+      disable: ["ruff"],
+    });
+
+    expect(exitCode).toBe(0);
+    expect(report.runners.find((r) => r.id === "ruff")?.status).toBe("disabled");
+  });
+
+  test("reads the config at configPath instead of the project's", async () => {
+    const { fm, cr } = makeProject();
+    fm.seed("/ci/guardrails.toml", "[runners.ruff]\nenabled = false\n");
+    const guardrails = createGuardrails({ fileManager: fm, commandRunner: cr });
+
+    const { exitCode, report } = await guardrails.check("/project", {
+      configPath: "/ci/guardrails.toml",
+    });
+
+    expect(exitCode).toBe(0);
+    expect(report.runners.find((r) => r.id === "ruff")?.status).toBe("disabled");
+  });
+
+  test("runs only the runners named in only", async () => {
+    const { fm, cr } = makeProject();
+    const guardrails = createGuardrails({ fileManager: fm, commandRunner: cr });
+
+    const { report } = await guardrails.check("/project", { only: ["ruff"] });
+
+    expect(report.runners.map((r) => r.id)).toEqual(["ruff"]);
+  });
+
+  test("exits EXIT_MISSING_TOOL when a required tool is not installed", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/pyproject.toml", "[tool.ruff]");
+    const cr = new FakeCommandRunner();
+    cr.register(["ruff", "--version"], { stdout: "", stderr: "", exitCode: 127 });
+    const guardrails = createGuardrails({ fileManager: fm, commandRunner: cr });
+
+    const { exitCode } = await guardrails.check("/project", {
+      requireTools: new Set(["ruff"]),
+    });
+
+    expect(exitCode).toBe(EXIT_MISSING_TOOL);
+  });
+
+  test("writes progress to the injected console", async () => {
+    const { fm, cr } = makeProject();
+    const cons = new FakeConsole();
+    const guardrails = createGuardrails({
+      fileManager: fm,
+      commandRunner: cr,
+      console: cons,
+    });
+
+    await guardrails.check("/project", { disable: ["codespell"] });
+
+    expect(cons.notes).toContain("  Codespell (disabled)");
+  });
+
+  test("throws on an unknown runner id", async () => {
+    const { fm, cr } = makeProject();
+    const guardrails = createGuardrails({ fileManager: fm, commandRunner: cr });
+
+    await expect(guardrails.check("/project", { enable: ["nope"] })).rejects.toThrow(
+      "Unknown runner(s)"
+    );
+  });
+});
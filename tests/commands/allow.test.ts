@@ -65,6 +65,33 @@ describe("appendAllowEntry", () => {
     expect(appended).toContain('reason = "URL too long"');
   });
 
+  test("adds an allow item to a YAML config, keeping its other keys", async () => {
+    const fm = new FakeFileManager();
+    const cons = new FakeConsole();
+    fm.seed(
+      "/project/.ai-guardrails/config.yaml",
+      [
+        "profile: strict",
+        "allow:",
+        "  - rule: ruff/E501",
+        "    glob: docs/**",
+        "    reason: tables",
+      ].join("\n")
+    );
+
+    await appendAllowEntry("/project", "ruff/S101", "tests/**", "asserts", fm, cons);
+
+    expect(fm.appended).toEqual([]);
+    const text = await fm.readText("/project/.ai-guardrails/config.yaml");
+    expect(Bun.YAML.parse(text)).toEqual({
+      profile: "strict",
+      allow: [
+        { rule: "ruff/E501", glob: "docs/**", reason: "tables" },
+        { rule: "ruff/S101", glob: "tests/**", reason: "asserts" },
+      ],
+    });
+  });
+
   test("emits success message", async () => {
     const fm = new FakeFileManager();
     const cons = new FakeConsole();
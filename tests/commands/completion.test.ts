@@ -9,10 +9,17 @@ const ALL_COMMANDS = [
   "install",
   "generate",
   "check",
+  "watch",
   "snapshot",
   "status",
+  "doctor",
+  "version",
+  "update",
+  "list",
+  "config",
   "report",
   "hook",
+  "hooks",
   "completion",
 ];
 
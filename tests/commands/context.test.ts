@@ -0,0 +1,26 @@
+import { describe, expect, test } from "bun:test";
+import { colorModeFromFlags, logLevelFromFlags } from "@/commands/context";
+
+describe("colorModeFromFlags", () => {
+  test("defaults to auto", () => {
+    expect(colorModeFromFlags({})).toBe("auto");
+  });
+
+  test("--color <when> picks the mode", () => {
+    expect(colorModeFromFlags({ color: "always" })).toBe("always");
+    expect(colorModeFromFlags({ color: "never" })).toBe("never");
+    expect(colorModeFromFlags({ color: "auto" })).toBe("auto");
+  });
+
+  test("--no-color means never", () => {
+    expect(colorModeFromFlags({ color: false })).toBe("never");
+  });
+});
+
+describe("logLevelFromFlags", () => {
+  test("--debug wins over --verbose", () => {
+    expect(logLevelFromFlags({})).toBe("normal");
+    expect(logLevelFromFlags({ verbose: true })).toBe("verbose");
+    expect(logLevelFromFlags({ verbose: true, debug: true })).toBe("debug");
+  });
+});
@@ -0,0 +1,97 @@
+import { describe, expect, test } from "bun:test";
+import {
+  envOverrides,
+  formatEnvOptions,
+  withEnvOverrides,
+} from "@/commands/env-overrides";
+
+describe("envOverrides", () => {
+  test("maps each GUARDRAILS_* var to its check flag", () => {
+    const env = {
+      GUARDRAILS_FORMAT: "sarif",
+      GUARDRAILS_FAIL_ON: "warning",
+      GUARDRAILS_JOBS: "4",
+      GUARDRAILS_NO_CACHE: "1",
+      GUARDRAILS_DISABLE: "ruff, pyright",
+    };
+
+    expect(envOverrides({ cache: true }, env)).toEqual({
+      format: "sarif",
+      failOn: "warning",
+      jobs: "4",
+      cache: false,
+      disable: "ruff,pyright",
+    });
+  });
+
+  test("a flag given on the command line wins over the env", () => {
+    const env = { GUARDRAILS_FORMAT: "sarif", GUARDRAILS_JOBS: "4" };
+
+    expect(envOverrides({ format: "json", jobs: "1" }, env)).toEqual({});
+  });
+
+  test("ignores empty vars and a GUARDRAILS_NO_CACHE that is not truthy", () => {
+    const env = { GUARDRAILS_FORMAT: " ", GUARDRAILS_NO_CACHE: "false" };
+
+    expect(envOverrides({ cache: true }, env)).toEqual({});
+    expect(envOverrides({}, { GUARDRAILS_NO_CACHE: "Yes" })).toEqual({ cache: false });
+  });
+
+  test("GUARDRAILS_DISABLE leaves runners named by --enable alone", () => {
+    const env = { GUARDRAILS_DISABLE: "ruff,pyright" };
+
+    expect(envOverrides({ enable: "ruff" }, env)).toEqual({ disable: "pyright" });
+    expect(envOverrides({ enable: "ruff,pyright" }, env)).toEqual({});
+  });
+
+  test("GUARDRAILS_DISABLE steps aside for --disable and --only", () => {
+    const env = { GUARDRAILS_DISABLE: "ruff" };
+
+    expect(envOverrides({ disable: "pyright" }, env)).toEqual({});
+    expect(envOverrides({ only: "pyright" }, env)).toEqual({});
+  });
+});
+
+describe("withEnvOverrides", () => {
+  test("keeps the command-line flags and fills in the rest", () => {
+    const flags = withEnvOverrides(
+      { strict: true, failOn: "info" },
+      { GUARDRAILS_FAIL_ON: "warning", GUARDRAILS_FORMAT: "json" }
+    );
+
+    expect(flags).toEqual({ strict: true, failOn: "info", format: "json" });
+  });
+});
+
+describe("formatEnvOptions", () => {
+  test("shows each option with the env var it came from or default", () => {
+    const lines = formatEnvOptions("standard", {
+      GUARDRAILS_FAIL_ON: "warning",
+      GUARDRAILS_JOBS: "2",
+      GUARDRAILS_NO_CACHE: "true",
+    });
+
+    expect(lines).toContain('# format = "text"  # default');
+    expect(lines).toContain('# fail_on = "warning"  # GUARDRAILS_FAIL_ON');
+    expect(lines).toContain("# jobs = 2  # GUARDRAILS_JOBS");
+    expect(lines).toContain("# cache = false  # GUARDRAILS_NO_CACHE");
+  });
+
+  test("takes jobs from the global config beneath GUARDRAILS_JOBS", () => {
+    const global = { path: "/g/config.yaml", config: { jobs: 3, runners: {} } };
+
+    expect(formatEnvOptions("standard", {}, global)).toContain(
+      "# jobs = 3  # /g/config.yaml"
+    );
+    expect(formatEnvOptions("standard", { GUARDRAILS_JOBS: "2" }, global)).toContain(
+      "# jobs = 2  # GUARDRAILS_JOBS"
+    );
+  });
+
+  test("defaults fail_on to the profile's threshold", () => {
+    expect(formatEnvOptions("strict", {})).toContain(
+      '# fail_on = "warning"  # default'
+    );
+    expect(formatEnvOptions("lenient", {})).toContain('# fail_on = "error"  # default');
+  });
+});
@@ -0,0 +1,155 @@
+import { describe, expect, test } from "bun:test";
+import {
+  configFormatFromFlags,
+  configFormatOf,
+  configPathFromFlags,
+  findGlobalConfig,
+  findProjectConfig,
+  globalConfigDir,
+  parseConfigText,
+  projectConfigTarget,
+  stringifyConfig,
+} from "@/config/config-file";
+import { CONFIG_SCHEMA_DIRECTIVE, YAML_SCHEMA_DIRECTIVE } from "@/config/json-schema";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+describe("configFormatOf", () => {
+  test("tells the format by extension", () => {
+    expect(configFormatOf(".ai-guardrails/config.toml")).toBe("toml");
+    expect(configFormatOf(".ai-guardrails/config.yaml")).toBe("yaml");
+    expect(configFormatOf("legacy/.ai-guardrails/config.yml")).toBe("yaml");
+  });
+});
+
+describe("configFormatFromFlags", () => {
+  test("returns the named format, or undefined", () => {
+    expect(configFormatFromFlags({ configFormat: "yaml" })).toBe("yaml");
+    expect(configFormatFromFlags({ configFormat: "json5" })).toBeUndefined();
+    expect(configFormatFromFlags({})).toBeUndefined();
+  });
+});
+
+describe("configPathFromFlags", () => {
+  test("resolves --config from the working directory", () => {
+    expect(configPathFromFlags({ config: "/ci/shared.toml" })).toBe("/ci/shared.toml");
+    expect(configPathFromFlags({ config: "shared.yaml" })).toBe(
+      `${process.cwd()}/shared.yaml`
+    );
+    expect(configPathFromFlags({})).toBeUndefined();
+  });
+});
+
+describe("parseConfigText", () => {
+  test("reads the same config from TOML and YAML", () => {
+    const toml = 'profile = "strict"\n[config]\nline_length = 100\n';
+    const yaml = "profile: strict\nconfig:\n  line_length: 100\n";
+    expect(parseConfigText(yaml, "yaml")).toEqual(parseConfigText(toml, "toml"));
+  });
+
+  test("reads an empty YAML document as an empty config", () => {
+    expect(parseConfigText("", "yaml")).toEqual({});
+    expect(parseConfigText("# nothing yet\n", "yaml")).toEqual({});
+  });
+
+  test("throws when the YAML document is not a mapping", () => {
+    expect(() => parseConfigText("- profile\n", "yaml")).toThrow(
+      "expected a mapping of config keys"
+    );
+  });
+});
+
+describe("stringifyConfig", () => {
+  const data = {
+    profile: "strict",
+    allow: [{ rule: "ruff/E501", glob: "**", reason: "x" }],
+  };
+
+  test("heads each format with its schema directive", () => {
+    expect(stringifyConfig(data, "toml")).toStartWith(CONFIG_SCHEMA_DIRECTIVE);
+    expect(stringifyConfig(data, "yaml")).toStartWith(YAML_SCHEMA_DIRECTIVE);
+  });
+
+  test("round-trips through parseConfigText", () => {
+    for (const format of ["toml", "yaml"] as const) {
+      expect(parseConfigText(stringifyConfig(data, format), format)).toEqual(data);
+    }
+  });
+});
+
+describe("findProjectConfig", () => {
+  test("finds whichever format exists", async () => {
+    const fm = new FakeFileManager();
+    expect(await findProjectConfig("/project", fm)).toBeUndefined();
+    fm.seed("/project/.ai-guardrails/config.yaml", "profile: strict\n");
+    expect(await findProjectConfig("/project", fm)).toBe(".ai-guardrails/config.yaml");
+  });
+
+  test("throws when both formats exist", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.ai-guardrails/config.toml", 'profile = "strict"\n');
+    fm.seed("/project/.ai-guardrails/config.yaml", "profile: strict\n");
+    await expect(findProjectConfig("/project", fm)).rejects.toThrow(
+      "both .ai-guardrails/config.toml and .ai-guardrails/config.yaml exist"
+    );
+  });
+});
+
+describe("globalConfigDir", () => {
+  test("lives under XDG_CONFIG_HOME, else ~/.config", () => {
+    expect(globalConfigDir({ XDG_CONFIG_HOME: "/xdg" }, "/home/me")).toBe(
+      "/xdg/ai-guardrails"
+    );
+    expect(globalConfigDir({}, "/home/me")).toBe("/home/me/.config/ai-guardrails");
+  });
+});
+
+describe("findGlobalConfig", () => {
+  const DIR = "/home/me/.config/ai-guardrails";
+
+  test("finds config.yaml or config.toml in the global config dir", async () => {
+    const fm = new FakeFileManager();
+    expect(await findGlobalConfig(fm, {}, "/home/me")).toBeUndefined();
+    fm.seed(`${DIR}/config.yaml`, "jobs: 4\n");
+    expect(await findGlobalConfig(fm, {}, "/home/me")).toBe(`${DIR}/config.yaml`);
+  });
+
+  test("throws when both formats exist", async () => {
+    const fm = new FakeFileManager();
+    fm.seed(`${DIR}/config.yaml`, "jobs: 4\n");
+    fm.seed(`${DIR}/config.toml`, "jobs = 4\n");
+    await expect(findGlobalConfig(fm, {}, "/home/me")).rejects.toThrow("keep only one");
+  });
+
+  test("is skipped in CI unless GUARDRAILS_GLOBAL_CONFIG points at one", async () => {
+    const fm = new FakeFileManager();
+    fm.seed(`${DIR}/config.yaml`, "jobs: 4\n");
+    fm.seed("/ci/guardrails.yaml", "jobs: 2\n");
+
+    expect(await findGlobalConfig(fm, { CI: "true" }, "/home/me")).toBeUndefined();
+    expect(await findGlobalConfig(fm, { CI: "false" }, "/home/me")).toBe(
+      `${DIR}/config.yaml`
+    );
+    const env = { CI: "true", GUARDRAILS_GLOBAL_CONFIG: "/ci/guardrails.yaml" };
+    expect(await findGlobalConfig(fm, env, "/home/me")).toBe("/ci/guardrails.yaml");
+  });
+
+  test("throws when GUARDRAILS_GLOBAL_CONFIG names a missing file", async () => {
+    const env = { GUARDRAILS_GLOBAL_CONFIG: "/nope.yaml" };
+    await expect(findGlobalConfig(new FakeFileManager(), env, "/h")).rejects.toThrow(
+      "GUARDRAILS_GLOBAL_CONFIG: /nope.yaml does not exist"
+    );
+  });
+});
+
+describe("projectConfigTarget", () => {
+  test("prefers the existing config over the requested format", async () => {
+    const fm = new FakeFileManager();
+    const toml = ".ai-guardrails/config.toml";
+    expect(await projectConfigTarget("/project", fm)).toBe(toml);
+    expect(await projectConfigTarget("/project", fm, "yaml")).toBe(
+      ".ai-guardrails/config.yaml"
+    );
+    fm.seed("/project/.ai-guardrails/config.toml", 'profile = "strict"\n');
+    expect(await projectConfigTarget("/project", fm, "yaml")).toBe(toml);
+  });
+});
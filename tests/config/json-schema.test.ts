@@ -0,0 +1,117 @@
+import { describe, expect, test } from "bun:test";
+import { readFileSync } from "node:fs";
+import { resolve } from "node:path";
+import { z } from "zod";
+import {
+  CONFIG_SCHEMA_DIRECTIVE,
+  projectConfigJsonSchema,
+  toJsonSchema,
+  withSchemaDirective,
+  withYamlSchemaDirective,
+  YAML_SCHEMA_DIRECTIVE,
+} from "@/config/json-schema";
+
+const SCHEMA_FILE = resolve(import.meta.dir, "../../schema/config.schema.json");
+
+describe("toJsonSchema", () => {
+  test("maps objects with required, optional and defaulted keys", () => {
+    const schema = z.object({
+      id: z.string().min(1),
+      name: z.string().optional(),
+      codes: z.array(z.number().int()).default([0]),
+    });
+    expect(toJsonSchema(schema)).toEqual({
+      type: "object",
+      properties: {
+        id: { type: "string", minLength: 1 },
+        name: { type: "string" },
+        codes: { type: "array", items: { type: "integer" }, default: [0] },
+      },
+      required: ["id"],
+      additionalProperties: false,
+    });
+  });
+
+  test("keeps number bounds, enums, patterns and descriptions", () => {
+    expect(toJsonSchema(z.number().positive().max(10))).toEqual({
+      type: "number",
+      exclusiveMinimum: 0,
+      maximum: 10,
+    });
+    expect(toJsonSchema(z.enum(["a", "b"]).describe("pick one"))).toEqual({
+      description: "pick one",
+      type: "string",
+      enum: ["a", "b"],
+    });
+    expect(toJsonSchema(z.string().regex(/^\d+$/))).toEqual({
+      type: "string",
+      pattern: "^\\d+$",
+    });
+  });
+
+  test("is never stricter than zod", () => {
+    expect(toJsonSchema(z.string().regex(/^[a-z]+$/i))).toEqual({ type: "string" });
+    expect(toJsonSchema(z.object({}).passthrough())).toMatchObject({
+      additionalProperties: true,
+    });
+    expect(toJsonSchema(z.unknown())).toEqual({});
+  });
+
+  test("maps records to additionalProperties", () => {
+    expect(toJsonSchema(z.record(z.boolean()))).toEqual({
+      type: "object",
+      additionalProperties: { type: "boolean" },
+    });
+  });
+});
+
+describe("projectConfigJsonSchema", () => {
+  const schema = projectConfigJsonSchema();
+
+  test("describes the top-level config.toml keys", () => {
+    expect(schema.$schema).toBe("http://json-schema.org/draft-07/schema#");
+    expect(schema.additionalProperties).toBe(false);
+    expect(Object.keys(schema.properties ?? {})).toEqual([
+      "profile",
+      "min_version",
+      "config",
+      "ignore",
+      "allow",
+      "hooks",
+      "ignore_paths",
+      "runners",
+      "custom_runners",
+      "classifications",
+      "post_run",
+    ]);
+  });
+
+  test("matches the committed schema/config.schema.json", () => {
+    // Regenerate with: ai-guardrails config schema > schema/config.schema.json
+    const committed: unknown = JSON.parse(readFileSync(SCHEMA_FILE, "utf8"));
+    expect(committed).toEqual(schema);
+  });
+});
+
+describe("withSchemaDirective", () => {
+  test("prefixes the directive once", () => {
+    const once = withSchemaDirective('profile = "strict"\n');
+    expect(once).toBe(`${CONFIG_SCHEMA_DIRECTIVE}\nprofile = "strict"\n`);
+    expect(withSchemaDirective(once)).toBe(once);
+  });
+
+  test("replaces a directive pointing elsewhere", () => {
+    const text = withSchemaDirective('#:schema ./old.json\nprofile = "strict"\n');
+    expect(text).toBe(`${CONFIG_SCHEMA_DIRECTIVE}\nprofile = "strict"\n`);
+  });
+});
+
+describe("withYamlSchemaDirective", () => {
+  test("prefixes the directive once, replacing a stale one", () => {
+    const once = withYamlSchemaDirective("profile: strict\n");
+    expect(once).toBe(`${YAML_SCHEMA_DIRECTIVE}\nprofile: strict\n`);
+    expect(withYamlSchemaDirective(once)).toBe(once);
+    const stale = "# yaml-language-server: $schema=./old.json\nprofile: strict\n";
+    expect(withYamlSchemaDirective(stale)).toBe(once);
+  });
+});
@@ -2,8 +2,18 @@ import { describe, expect, test } from "bun:test";
 import { ZodError } from "zod";
 import {
   buildResolvedConfig,
+  classificationFor,
+  configForPath,
+  failOnAt,
+  GlobalConfigSchema,
+  isRunnerEnabled,
+  isRunnerEnabledAt,
   MachineConfigSchema,
+  NestedConfigSchema,
   ProjectConfigSchema,
+  runnerTimeout,
+  withNestedConfigs,
+  withRunnerOverrides,
 } from "@/config/schema";
 
 describe("MachineConfigSchema", () => {
@@ -30,7 +40,12 @@ describe("MachineConfigSchema", () => {
   test("parses all valid profiles", () => {
     expect(MachineConfigSchema.parse({ profile: "strict" }).profile).toBe("strict");
     expect(MachineConfigSchema.parse({ profile: "standard" }).profile).toBe("standard");
-    expect(MachineConfigSchema.parse({ profile: "minimal" }).profile).toBe("minimal");
+    expect(MachineConfigSchema.parse({ profile: "lenient" }).profile).toBe("lenient");
+  });
+
+  test("reads the old profile name minimal as lenient", () => {
+    expect(MachineConfigSchema.parse({ profile: "minimal" }).profile).toBe("lenient");
+    expect(ProjectConfigSchema.parse({ profile: "minimal" }).profile).toBe("lenient");
   });
 
   test("throws ZodError for invalid profile", () => {
@@ -65,7 +80,7 @@ describe("MachineConfigSchema", () => {
 describe("ProjectConfigSchema", () => {
   test("parses valid project config with all fields", () => {
     const result = ProjectConfigSchema.parse({
-      profile: "minimal",
+      profile: "lenient",
       config: { line_length: 100, indent_width: 2 },
       ignore: [{ rule: "ruff/D", reason: "No docstrings" }],
       allow: [
@@ -76,7 +91,7 @@ describe("ProjectConfigSchema", () => {
         },
       ],
     });
-    expect(result.profile).toBe("minimal");
+    expect(result.profile).toBe("lenient");
     expect(result.config.line_length).toBe(100);
     expect(result.ignore).toHaveLength(1);
     expect(result.allow).toHaveLength(1);
@@ -132,6 +147,16 @@ describe("ProjectConfigSchema", () => {
     expect(result.config.python_version).toBe("3.11");
   });
 
+  test("go_local_prefix accepts comma-separated import paths", () => {
+    const result = ProjectConfigSchema.parse({
+      config: { go_local_prefix: "example.com/acme,example.com/tools" },
+    });
+    expect(result.config.go_local_prefix).toBe("example.com/acme,example.com/tools");
+    expect(() =>
+      ProjectConfigSchema.parse({ config: { go_local_prefix: "example.com/a, b" } })
+    ).toThrow(ZodError);
+  });
+
   test("allow entry requires glob", () => {
     expect(() =>
       ProjectConfigSchema.parse({
@@ -158,6 +183,75 @@ describe("ProjectConfigSchema", () => {
     });
     expect(result.ignore_paths).toEqual(["tests/e2e/fixtures/**", "vendor/**"]);
   });
+
+  test("runners defaults to empty object", () => {
+    const result = ProjectConfigSchema.parse({});
+    expect(result.runners).toEqual({});
+  });
+
+  test("throws ZodError for non-boolean runner enabled flag", () => {
+    expect(() =>
+      ProjectConfigSchema.parse({ runners: { staticcheck: { enabled: "no" } } })
+    ).toThrow(ZodError);
+  });
+
+  test("parses custom runners, defaulting ok_exit_codes to 0 and 1", () => {
+    const result = ProjectConfigSchema.parse({
+      custom_runners: [
+        {
+          id: "acme-lint",
+          files: ["**/*.acme"],
+          command: ["acme-lint", "{files}"],
+          pattern: "^(?<file>[^:]+):(?<line>\\d+): (?<message>.*)$",
+        },
+      ],
+    });
+    expect(result.custom_runners[0]?.ok_exit_codes).toEqual([0, 1]);
+  });
+
+  test("throws ZodError for a custom runner pattern missing a required group", () => {
+    expect(() =>
+      ProjectConfigSchema.parse({
+        custom_runners: [
+          {
+            id: "acme-lint",
+            files: ["**/*.acme"],
+            command: ["acme-lint"],
+            pattern: "^(?<file>[^:]+): (?<message>.*)$",
+          },
+        ],
+      })
+    ).toThrow(ZodError);
+  });
+
+  test("throws ZodError for duplicate custom runner ids", () => {
+    const spec = {
+      id: "acme-lint",
+      files: ["**/*.acme"],
+      command: ["acme-lint"],
+      pattern: "^(?<file>[^:]+):(?<line>\\d+): (?<message>.*)$",
+    };
+    expect(() => ProjectConfigSchema.parse({ custom_runners: [spec, spec] })).toThrow(
+      ZodError
+    );
+  });
+
+  test("parses a post_run hook, optional by default", () => {
+    const config = ProjectConfigSchema.parse({
+      post_run: { url: "https://hooks.example.com/x" },
+    });
+    expect(config.post_run).toEqual({
+      url: "https://hooks.example.com/x",
+      required: false,
+      timeout: 30,
+    });
+  });
+
+  test("throws ZodError for a post_run with both or neither of command and url", () => {
+    const both = { command: ["notify"], url: "https://hooks.example.com/x" };
+    expect(() => ProjectConfigSchema.parse({ post_run: both })).toThrow(ZodError);
+    expect(() => ProjectConfigSchema.parse({ post_run: {} })).toThrow(ZodError);
+  });
 });
 
 describe("buildResolvedConfig", () => {
@@ -179,10 +273,10 @@ describe("buildResolvedConfig", () => {
 
   test("falls back to machine profile when project profile is absent", () => {
     const resolved = buildResolvedConfig(
-      makeMachine({ profile: "minimal" }),
+      makeMachine({ profile: "lenient" }),
       makeProject()
     );
-    expect(resolved.profile).toBe("minimal");
+    expect(resolved.profile).toBe("lenient");
   });
 
   test("merges machine and project ignore lists, deduped by rule", () => {
@@ -242,6 +336,18 @@ describe("buildResolvedConfig", () => {
     expect(resolved.values.python_version).toBeUndefined();
   });
 
+  test("resolved values include go_local_prefix when set", () => {
+    const project = makeProject({ config: { go_local_prefix: "example.com/acme" } });
+    const resolved = buildResolvedConfig(makeMachine(), project);
+    expect(resolved.values.go_local_prefix).toBe("example.com/acme");
+  });
+
+  test("resolved values include errcheck_exclude when set", () => {
+    const project = makeProject({ config: { errcheck_exclude: ["fmt.Fprintf"] } });
+    const resolved = buildResolvedConfig(makeMachine(), project);
+    expect(resolved.values.errcheck_exclude).toEqual(["fmt.Fprintf"]);
+  });
+
   test("ignorePaths is empty array when not set in project config", () => {
     const resolved = buildResolvedConfig(makeMachine(), makeProject());
     expect(resolved.ignorePaths).toEqual([]);
@@ -255,3 +361,298 @@ describe("buildResolvedConfig", () => {
     expect(resolved.ignorePaths).toEqual(["tests/e2e/fixtures/**", "vendor/**"]);
   });
 });
+
+describe("global config", () => {
+  function makeGlobal(raw: Record<string, unknown>) {
+    return { path: "/g/config.yaml", config: GlobalConfigSchema.parse(raw) };
+  }
+
+  test("rejects keys that feed generated configs", () => {
+    expect(() => GlobalConfigSchema.parse({ config: { line_length: 120 } })).toThrow(
+      ZodError
+    );
+    expect(() =>
+      GlobalConfigSchema.parse({ runners: { ruff: { user_config: "x.toml" } } })
+    ).toThrow(ZodError);
+  });
+
+  test("fills in beneath the project config, key by key", () => {
+    const global = makeGlobal({
+      profile: "strict",
+      jobs: 2,
+      runners: { ruff: { enabled: false, timeout: 300 }, biome: { enabled: false } },
+    });
+    const project = ProjectConfigSchema.parse({ runners: { ruff: { enabled: true } } });
+
+    const machine = MachineConfigSchema.parse({});
+    const resolved = buildResolvedConfig(machine, project, global);
+
+    expect(resolved.profile).toBe("strict");
+    expect(resolved.runners?.ruff).toEqual({ enabled: true, timeout: 300 });
+    expect(resolved.runners?.biome).toEqual({ enabled: false });
+    expect(resolved.global?.applied).toEqual([
+      "profile",
+      "jobs",
+      "runners.ruff.timeout",
+      "runners.biome.enabled",
+    ]);
+  });
+
+  test("loses the profile to the project's", () => {
+    const global = makeGlobal({ profile: "strict" });
+    const project = ProjectConfigSchema.parse({ profile: "lenient" });
+
+    const machine = MachineConfigSchema.parse({});
+    const resolved = buildResolvedConfig(machine, project, global);
+
+    expect(resolved.profile).toBe("lenient");
+    expect(resolved.global?.applied).toEqual([]);
+  });
+});
+
+describe("isRunnerEnabled", () => {
+  test("returns true when the runner has no config entry", () => {
+    const resolved = buildResolvedConfig(
+      MachineConfigSchema.parse({}),
+      ProjectConfigSchema.parse({})
+    );
+    expect(isRunnerEnabled(resolved, "staticcheck")).toBe(true);
+  });
+
+  test("returns false when the runner sets enabled = false", () => {
+    const resolved = buildResolvedConfig(
+      MachineConfigSchema.parse({}),
+      ProjectConfigSchema.parse({ runners: { staticcheck: { enabled: false } } })
+    );
+    expect(isRunnerEnabled(resolved, "staticcheck")).toBe(false);
+    expect(isRunnerEnabled(resolved, "golangci-lint")).toBe(true);
+  });
+
+  test("uses the default for opt-in runners without a config entry", () => {
+    const resolved = buildResolvedConfig(
+      MachineConfigSchema.parse({}),
+      ProjectConfigSchema.parse({ runners: { gofumpt: { enabled: true } } })
+    );
+    expect(isRunnerEnabled(resolved, "gofumpt", false)).toBe(true);
+    expect(isRunnerEnabled(resolved, "other", false)).toBe(false);
+  });
+
+  test("turns on the opt-in runners the profile enables", () => {
+    const strict = buildResolvedConfig(
+      MachineConfigSchema.parse({}),
+      ProjectConfigSchema.parse({ profile: "strict" })
+    );
+    expect(isRunnerEnabled(strict, "gofumpt", false)).toBe(true);
+    expect(isRunnerEnabled(strict, "other", false)).toBe(false);
+  });
+
+  test("lets enabled = false win over the profile", () => {
+    const resolved = buildResolvedConfig(
+      MachineConfigSchema.parse({}),
+      ProjectConfigSchema.parse({
+        profile: "strict",
+        runners: { gofumpt: { enabled: false } },
+      })
+    );
+    expect(isRunnerEnabled(resolved, "gofumpt", false)).toBe(false);
+  });
+});
+
+describe("runnerTimeout", () => {
+  test("prefers the runner's own timeout over the fallback", () => {
+    const resolved = buildResolvedConfig(
+      MachineConfigSchema.parse({}),
+      ProjectConfigSchema.parse({ runners: { markdownlint: { timeout: 300 } } })
+    );
+    expect(runnerTimeout(resolved, "markdownlint", 120)).toBe(300);
+    expect(runnerTimeout(resolved, "codespell", 120)).toBe(120);
+  });
+
+  test("rejects a non-positive timeout", () => {
+    expect(() =>
+      ProjectConfigSchema.parse({ runners: { ruff: { timeout: 0 } } })
+    ).toThrow(ZodError);
+  });
+});
+
+describe("withRunnerOverrides", () => {
+  const resolved = buildResolvedConfig(
+    MachineConfigSchema.parse({}),
+    ProjectConfigSchema.parse({ runners: { staticcheck: { enabled: false } } })
+  );
+
+  test("--enable overrides enabled = false in config", () => {
+    const config = withRunnerOverrides(resolved, ["staticcheck"], []);
+    expect(isRunnerEnabled(config, "staticcheck")).toBe(true);
+  });
+
+  test("--disable turns off a runner with no config entry", () => {
+    const config = withRunnerOverrides(resolved, [], ["codespell"]);
+    expect(isRunnerEnabled(config, "codespell")).toBe(false);
+    expect(isRunnerEnabled(config, "staticcheck")).toBe(false);
+  });
+
+  test("does not modify the loaded config", () => {
+    withRunnerOverrides(resolved, ["staticcheck"], ["codespell"]);
+    expect(isRunnerEnabled(resolved, "staticcheck")).toBe(false);
+    expect(isRunnerEnabled(resolved, "codespell")).toBe(true);
+  });
+});
+
+describe("withNestedConfigs", () => {
+  const root = buildResolvedConfig(
+    MachineConfigSchema.parse({}),
+    ProjectConfigSchema.parse({
+      ignore: [{ rule: "ruff/E501", reason: "formatter owns line length" }],
+      ignore_paths: ["gen/**"],
+    })
+  );
+  const config = withNestedConfigs(root, [
+    {
+      dir: "services/api",
+      project: NestedConfigSchema.parse({
+        runners: { ruff: { enabled: true } },
+        allow: [{ rule: "ruff/S101", glob: "tests/**", reason: "pytest asserts" }],
+      }),
+    },
+    {
+      dir: "services",
+      project: NestedConfigSchema.parse({
+        profile: "strict",
+        runners: { ruff: { enabled: false } },
+      }),
+    },
+    {
+      dir: "legacy",
+      project: NestedConfigSchema.parse({
+        profile: "lenient",
+        ignore: [{ rule: "ruff/F401", reason: "unused imports left as is" }],
+        ignore_paths: ["vendored/**"],
+      }),
+    },
+  ]);
+
+  test("picks the nearest config for a path, else the root", () => {
+    expect(configForPath(config, "legacy/old.py").profile).toBe("lenient");
+    expect(configForPath(config, "services/api/app.py").profile).toBe("strict");
+    expect(configForPath(config, "servicesx/app.py")).toBe(config);
+    expect(configForPath(config, "main.py")).toBe(config);
+  });
+
+  test("adds a nested config's ignores and paths to its parent's", () => {
+    const legacy = configForPath(config, "legacy/old.py");
+    expect(legacy.isAllowed("ruff/F401", "legacy/old.py")).toBe(true);
+    expect(legacy.isAllowed("ruff/E501", "legacy/old.py")).toBe(true);
+    expect(legacy.ignorePaths).toEqual(["gen/**", "legacy/vendored/**"]);
+    expect(config.isAllowed("ruff/F401", "main.py")).toBe(false);
+  });
+
+  test("resolves allow globs against the nested config's directory", () => {
+    const api = configForPath(config, "services/api/tests/test_app.py");
+    expect(api.isAllowed("ruff/S101", "services/api/tests/test_app.py")).toBe(true);
+    expect(api.isAllowed("ruff/S101", "tests/test_app.py")).toBe(false);
+  });
+
+  test("inherits through intermediate configs, the nearest winning", () => {
+    expect(isRunnerEnabledAt(config, "ruff", "services/worker.py")).toBe(false);
+    expect(isRunnerEnabledAt(config, "ruff", "services/api/app.py")).toBe(true);
+    expect(isRunnerEnabledAt(config, "ruff", "legacy/old.py")).toBe(true);
+  });
+
+  test("takes the --fail-on default from the nearest profile", () => {
+    expect(failOnAt(config, "services/api/app.py")).toBe("warning");
+    expect(failOnAt(config, "legacy/old.py")).toBe("error");
+    expect(failOnAt(config, "main.py")).toBe("error");
+  });
+
+  test("--enable/--disable win over nested configs too", () => {
+    const overridden = withRunnerOverrides(config, ["ruff"], []);
+    expect(isRunnerEnabledAt(overridden, "ruff", "services/worker.py")).toBe(true);
+  });
+
+  test("rejects keys that only the root config can set", () => {
+    expect(() => NestedConfigSchema.parse({ config: { line_length: 120 } })).toThrow(
+      ZodError
+    );
+    expect(() =>
+      NestedConfigSchema.parse({ runners: { ruff: { timeout: 30 } } })
+    ).toThrow(ZodError);
+  });
+});
+
+describe("classifications", () => {
+  const root = buildResolvedConfig(
+    MachineConfigSchema.parse({}),
+    ProjectConfigSchema.parse({
+      classifications: {
+        test: {
+          profile: "lenient",
+          ignore: [{ rule: "ruff/S101", reason: "pytest asserts" }],
+          runners: { errcheck: { enabled: false } },
+        },
+        fixtures: { files: ["testdata/**"], ignore_paths: ["testdata/big/**"] },
+      },
+    })
+  );
+
+  test("classifies the usual test files by default, else by files", () => {
+    expect(classificationFor(root, "pkg/handler_test.go")?.name).toBe("test");
+    expect(classificationFor(root, "tests/test_app.py")?.name).toBe("test");
+    expect(classificationFor(root, "src/app.test.ts")?.name).toBe("test");
+    expect(classificationFor(root, "testdata/input.json")?.name).toBe("fixtures");
+    expect(classificationFor(root, "pkg/handler.go")).toBeUndefined();
+  });
+
+  test("leaves every file unclassified without a table", () => {
+    const plain = buildResolvedConfig(
+      MachineConfigSchema.parse({}),
+      ProjectConfigSchema.parse({})
+    );
+    expect(plain.classifications).toBeUndefined();
+    expect(classificationFor(plain, "pkg/handler_test.go")).toBeUndefined();
+  });
+
+  test("applies a classification's profile, ignores and paths to its files", () => {
+    const tests = configForPath(root, "tests/test_app.py");
+    expect(tests.profile).toBe("lenient");
+    expect(tests.isAllowed("ruff/S101", "tests/test_app.py")).toBe(true);
+    expect(failOnAt(root, "tests/test_app.py")).toBe("error");
+    expect(configForPath(root, "testdata/a.json").ignorePaths).toEqual([
+      "testdata/big/**",
+    ]);
+    expect(configForPath(root, "app.py")).toBe(root);
+  });
+
+  test("drops a runner's findings in its files", () => {
+    expect(isRunnerEnabledAt(root, "errcheck", "pkg/handler_test.go")).toBe(false);
+    expect(isRunnerEnabledAt(root, "errcheck", "pkg/handler.go")).toBe(true);
+  });
+
+  test("applies on top of the nearest nested config", () => {
+    const config = withNestedConfigs(root, [
+      {
+        dir: "legacy",
+        project: NestedConfigSchema.parse({
+          ignore: [{ rule: "ruff/F401", reason: "unused imports left as is" }],
+          runners: { errcheck: { enabled: true } },
+        }),
+      },
+    ]);
+    const legacyTest = configForPath(config, "legacy/test_old.py");
+    expect(legacyTest.profile).toBe("lenient");
+    expect(legacyTest.isAllowed("ruff/F401", "legacy/test_old.py")).toBe(true);
+    expect(isRunnerEnabledAt(config, "errcheck", "legacy/old_test.go")).toBe(false);
+  });
+
+  test("--enable/--disable win over classifications too", () => {
+    const overridden = withRunnerOverrides(root, ["errcheck"], []);
+    expect(isRunnerEnabledAt(overridden, "errcheck", "pkg/handler_test.go")).toBe(true);
+  });
+
+  test("rejects a classification of the project's own without files", () => {
+    const fixtures = { profile: "lenient" };
+    expect(() =>
+      ProjectConfigSchema.parse({ classifications: { fixtures } })
+    ).toThrow(ZodError);
+  });
+});
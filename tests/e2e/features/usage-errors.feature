@@ -0,0 +1,29 @@
+Feature: Usage errors exit 3
+
+  Scenario Outline: A bad flag or config exits 3 for every command
+    Given a bare "typescript" fixture project
+    When I run ai-guardrails with "<args>"
+    Then the command should exit with code 3
+
+    Examples:
+      | args                                      |
+      | check --jobs 0                            |
+      | watch --debounce soon                     |
+      | --config missing.toml doctor              |
+      | --config missing.toml list                |
+      | --config missing.toml status              |
+      | --config missing.toml snapshot            |
+      | --config missing.toml generate            |
+      | --config missing.toml config validate     |
+      | config show --enable nosuchrunner         |
+      | init --yes --no-baseline --profile nope   |
+      | version --no-such-flag                    |
+      | update --no-such-flag                     |
+      | completion tcsh                           |
+      | allow not-a-rule src/** because           |
+
+  Scenario: Install exits 3 for an invalid project config
+    Given a bare "typescript" fixture project
+    And the project config sets profile "nope"
+    When I run ai-guardrails with "install --dry-run"
+    Then the command should exit with code 3
@@ -14,8 +14,8 @@ Then<E2EWorld>(
   "the check should complete without config error",
   async (world: E2EWorld) => {
     // Exit 0 = no issues (or tools skipped), exit 1 = lint violations found.
-    // Exit 2 = config error (broken). Both 0 and 1 are valid depending on tool availability.
-    expect(world.result.exitCode).not.toBe(2);
+    // Exit 3 = config error (broken). Both 0 and 1 are valid depending on tool availability.
+    expect(world.result.exitCode).not.toBe(3);
   }
 );
 
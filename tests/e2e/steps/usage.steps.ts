@@ -0,0 +1,30 @@
+import { expect } from "bun:test";
+import { Given, Then, When } from "@questi0nm4rk/feats";
+import type { E2EWorld } from "./project.steps";
+
+Given<E2EWorld>(
+  "the project config sets profile {string}",
+  async (world: E2EWorld, profile: unknown) => {
+    await world.project.run("mkdir", ["-p", ".ai-guardrails"]);
+    const write = `echo 'profile = "${String(profile)}"' > .ai-guardrails/config.toml`;
+    await world.project.run("sh", ["-c", write]);
+  }
+);
+
+When<E2EWorld>(
+  "I run ai-guardrails with {string}",
+  async (world: E2EWorld, args: unknown) => {
+    world.result = await world.project.run(world.binaryPath, [
+      "--project-dir",
+      ".",
+      ...String(args).split(/\s+/),
+    ]);
+  }
+);
+
+Then<E2EWorld>(
+  "the command should exit with code {int}",
+  async (world: E2EWorld, code: unknown) => {
+    expect(world.result.exitCode).toBe(Number(code));
+  }
+);
@@ -1,18 +1,28 @@
-import type { CommandRunner, RunResult } from "@/infra/command-runner";
+import type {
+  CommandRunner,
+  RunCommandOptions,
+  RunResult,
+} from "@/infra/command-runner";
 
 export class FakeCommandRunner implements CommandRunner {
   readonly calls: string[][] = [];
+  /** Working directory passed with each call, index-aligned with `calls` */
+  readonly cwds: Array<string | undefined> = [];
+  /** Timeout passed with each call, index-aligned with `calls` */
+  readonly timeouts: Array<number | undefined> = [];
+  /** Stdin passed with each call, index-aligned with `calls` */
+  readonly stdins: Array<string | undefined> = [];
   private readonly responses = new Map<string, RunResult>();
 
   register(args: string[], response: RunResult): void {
     this.responses.set(args.join(" "), response);
   }
 
-  async run(
-    args: string[],
-    _opts?: { cwd?: string; timeout?: number }
-  ): Promise<RunResult> {
+  async run(args: string[], opts?: RunCommandOptions): Promise<RunResult> {
     this.calls.push(args);
+    this.cwds.push(opts?.cwd);
+    this.timeouts.push(opts?.timeout);
+    this.stdins.push(opts?.stdin);
     return (
       this.responses.get(args.join(" ")) ?? {
         stdout: "",
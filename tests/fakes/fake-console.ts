@@ -2,15 +2,22 @@ import type { Console } from "@/infra/console";
 
 export class FakeConsole implements Console {
   readonly infos: string[] = [];
+  readonly notes: string[] = [];
   readonly successes: string[] = [];
   readonly warnings: string[] = [];
   readonly errors: string[] = [];
   readonly steps: string[] = [];
+  readonly verboses: string[] = [];
+  readonly debugs: string[] = [];
 
   info(msg: string): void {
     this.infos.push(msg);
   }
 
+  note(msg: string): void {
+    this.notes.push(msg);
+  }
+
   success(msg: string): void {
     this.successes.push(msg);
   }
@@ -26,4 +33,12 @@ export class FakeConsole implements Console {
   step(msg: string): void {
     this.steps.push(msg);
   }
+
+  verbose(msg: string): void {
+    this.verboses.push(msg);
+  }
+
+  debug(msg: string): void {
+    this.debugs.push(msg);
+  }
 }
@@ -1,16 +1,30 @@
-import type { FileManager } from "@/infra/file-manager";
+import type { FileManager, FileStat, GlobOptions } from "@/infra/file-manager";
 
 export class FakeFileManager implements FileManager {
   private readonly files = new Map<string, string>();
+  private readonly executables = new Set<string>();
+  /** Fake mtimes: every seed or write moves the file's clock forward */
+  private readonly mtimes = new Map<string, number>();
+  private clock = 0;
   readonly written: Array<[string, string]> = [];
   readonly appended: Array<[string, string]> = [];
   readonly deleted: string[] = [];
+  /** Every path readText was asked for, in call order */
+  readonly read: string[] = [];
 
   seed(path: string, content: string): void {
     this.files.set(path, content);
+    this.mtimes.set(path, ++this.clock);
+  }
+
+  /** Seed a file with its execute bit set */
+  seedExecutable(path: string, content: string): void {
+    this.seed(path, content);
+    this.executables.add(path);
   }
 
   async readText(path: string): Promise<string> {
+    this.read.push(path);
     const content = this.files.get(path);
     if (content === undefined) {
       throw new Error(`File not found: ${path}`);
@@ -20,17 +34,23 @@ export class FakeFileManager implements FileManager {
 
   async writeText(path: string, content: string): Promise<void> {
     this.files.set(path, content);
+    this.mtimes.set(path, ++this.clock);
     this.written.push([path, content]);
   }
 
   async appendText(path: string, content: string): Promise<void> {
     const existing = this.files.get(path) ?? "";
     this.files.set(path, existing + content);
+    this.mtimes.set(path, ++this.clock);
     this.appended.push([path, content]);
   }
 
   async exists(path: string): Promise<boolean> {
-    return this.files.has(path);
+    // A directory exists when a seeded file lives under it, as on disk
+    const dir = `${path}/`;
+    return (
+      this.files.has(path) || [...this.files.keys()].some((f) => f.startsWith(dir))
+    );
   }
 
   async mkdir(_path: string, _opts?: { parents?: boolean }): Promise<void> {
@@ -40,7 +60,8 @@ export class FakeFileManager implements FileManager {
   async glob(
     pattern: string,
     cwd: string,
-    ignore?: readonly string[]
+    ignore?: readonly string[],
+    opts?: GlobOptions
   ): Promise<string[]> {
     // Normalize the cwd prefix so we can strip it from absolute-path keys.
     // When cwd is empty or "/", there is no meaningful prefix to strip.
@@ -52,7 +73,8 @@ export class FakeFileManager implements FileManager {
       // how RealFileManager returns relative paths from Bun's Glob.scan.
       const relative =
         prefix !== null && key.startsWith(prefix) ? key.slice(prefix.length) : key;
-      if (matchesGlob(relative, pattern)) {
+      const dotted = opts?.dot === true || spellsOutDots(relative, pattern);
+      if (dotted && matchesGlob(relative, pattern)) {
         results.push(relative);
       }
     }
@@ -65,12 +87,34 @@ export class FakeFileManager implements FileManager {
     return false;
   }
 
+  async isExecutable(path: string): Promise<boolean> {
+    return this.files.has(path) && this.executables.has(path);
+  }
+
+  async stat(path: string): Promise<FileStat | null> {
+    const content = this.files.get(path);
+    if (content === undefined) return null;
+    return { mtimeMs: this.mtimes.get(path) ?? 0, size: Buffer.byteLength(content) };
+  }
+
   async delete(path: string): Promise<void> {
     this.files.delete(path);
+    this.mtimes.delete(path);
+    this.executables.delete(path);
     this.deleted.push(path);
   }
 }
 
+/**
+ * Without `dot`, Bun's glob matches a dot segment (.env, .github/) only where
+ * the pattern spells one out, as a `.*` segment does
+ */
+function spellsOutDots(path: string, pattern: string): boolean {
+  const hidden = path.split("/").filter((segment) => segment.startsWith("."));
+  const spelled = pattern.split("/").filter((segment) => segment.startsWith("."));
+  return hidden.length <= spelled.length;
+}
+
 /** Simple glob matching for fake: supports *, **, and {a,b,c} brace expansion */
 function matchesGlob(path: string, pattern: string): boolean {
   // Expand {a,b,c} into alternation before converting to regex.
@@ -0,0 +1,23 @@
+import type { FileWatcher, WatchHandle } from "@/infra/file-watcher";
+
+export class FakeFileWatcher implements FileWatcher {
+  readonly watched: string[] = [];
+  closed = false;
+  private listeners: Array<(relPath: string) => void> = [];
+
+  watch(dir: string, onChange: (relPath: string) => void): WatchHandle {
+    this.watched.push(dir);
+    this.listeners.push(onChange);
+    return {
+      close: () => {
+        this.closed = true;
+        this.listeners = [];
+      },
+    };
+  }
+
+  /** Simulate a change to a project-relative path */
+  emit(relPath: string): void {
+    for (const listener of this.listeners) listener(relPath);
+  }
+}
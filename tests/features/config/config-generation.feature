@@ -46,9 +46,9 @@ Feature: Config file generation
     Then the output should contain '"recommended": true'
     And the output should not contain '"style"'
 
-  Scenario: biomeGenerator minimal profile has recommended false
+  Scenario: biomeGenerator lenient profile has recommended false
     Given the biome generator
-    When I generate with profile "minimal"
+    When I generate with profile "lenient"
     Then the output should contain '"recommended": false'
     And the output should contain '"noExplicitAny": "error"'
 
@@ -110,9 +110,9 @@ Feature: Config file generation
     When I generate with default config
     Then the output should contain 'select = ["E", "F", "W", "I", "UP", "S", "B", "A", "C4", "ICN", "PIE", "PT", "RSE", "SIM", "TID"]'
 
-  Scenario: ruffGenerator minimal profile selects critical rules only
+  Scenario: ruffGenerator lenient profile selects critical rules only
     Given the ruff generator
-    When I generate with profile "minimal"
+    When I generate with profile "lenient"
     Then the output should contain 'select = ["E", "F", "S"]'
 
   # ─── lefthook generator ────────────────────────────────────────────────────
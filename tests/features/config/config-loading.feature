@@ -32,14 +32,26 @@ Feature: Config loading and merging
   Scenario: Load valid project config
     Given a file at "/proj/.ai-guardrails/config.toml" containing:
       """
-      profile = "minimal"
+      profile = "lenient"
       [config]
       line_length = 100
       """
     When I load the project config from "/proj"
-    Then the profile should be "minimal"
+    Then the profile should be "lenient"
     And the config line_length should be 100
 
+  Scenario: Project config rejects a custom runner reusing a built-in id
+    Given a file at "/proj/.ai-guardrails/config.toml" containing:
+      """
+      [[custom_runners]]
+      id = "ruff"
+      files = ["**/*.py"]
+      command = ["ruff-wrapper", "{files}"]
+      pattern = '^(?<file>[^:]+):(?<line>\d+): (?<message>.*)$'
+      """
+    When I load the project config from "/proj"
+    Then the error should mention "already used by built-in runners: ruff"
+
   Scenario: Project config defaults when file does not exist
     Given no file at "/proj/.ai-guardrails/config.toml"
     When I load the project config from "/proj"
@@ -48,9 +60,9 @@ Feature: Config loading and merging
 
   Scenario: Project profile overrides machine profile
     Given a machine config with profile "strict"
-    And a project config with profile "minimal"
+    And a project config with profile "lenient"
     When I resolve the config
-    Then the resolved profile should be "minimal"
+    Then the resolved profile should be "lenient"
 
   Scenario: Machine profile used when project has none
     Given a machine config with profile "strict"
@@ -35,11 +35,349 @@ Feature: Check pipeline
     When the check pipeline runs
     Then the check exit code should be 1
 
-  Scenario: Exit code 2 for config error
+  Scenario: Exit code 3 for config error
     Given a check pipeline result with status "error" and issue count 0
-    Then the check exit code for that result should be 2
+    Then the check exit code for that result should be 3
 
   Scenario: Format flag passes through context
     Given a project with no lint issues and format flag "sarif"
     When the check pipeline runs
     Then the result status should be "ok"
+
+  Scenario: SARIF report is written to the output path with one run per runner
+    Given a project with 1 lint issue and flags format "sarif" and output "/project/out.sarif"
+    When the check pipeline runs
+    Then the SARIF file "/project/out.sarif" should have a run for "Ruff" with 1 result
+
+  Scenario: Summary report has the counts and the verdict, not the findings
+    Given a project with 2 lint issues and flags format "summary" and output "/project/status.json"
+    When the check pipeline runs
+    Then the check exit code should be 1
+    And the summary file "/project/status.json" should fail the check with 2 errors from "ruff"
+
+  Scenario: Fix flag re-invokes fix-capable runners on the files with issues
+    Given a project with 1 lint issue and the fix flag
+    When the check pipeline runs
+    Then the command runner should have run "ruff check --fix foo0.py"
+    And the console should have recorded success "Fixed 0 file(s) across 0 runner(s)"
+
+  Scenario: Invalid jobs flag is a usage error
+    Given a project with no lint issues and jobs flag "0"
+    When the check pipeline runs
+    Then the check exit code should be 3
+
+  Scenario: Invalid max-procs flag is a usage error
+    Given a project with no lint issues and max-procs flag "0"
+    When the check pipeline runs
+    Then the check exit code should be 3
+
+  Scenario: Invalid timeout flag is a usage error
+    Given a project with no lint issues and timeout flag "-5"
+    When the check pipeline runs
+    Then the check exit code should be 3
+
+  Scenario: Unknown fail-on level is a usage error
+    Given a project with no lint issues and fail-on flag "fatal"
+    When the check pipeline runs
+    Then the check exit code should be 3
+
+  Scenario: Strict profile fails on warnings without a fail-on flag
+    Given a project with profile "strict" and one ruff warning
+    When the check pipeline runs
+    Then the check exit code should be 1
+
+  Scenario: Standard profile lets warnings pass without a fail-on flag
+    Given a project with profile "standard" and one ruff warning
+    When the check pipeline runs
+    Then the check exit code should be 0
+
+  Scenario: Disable flag skips a runner enabled by detection
+    Given a project with 1 lint issue and disable flag "ruff"
+    When the check pipeline runs
+    Then the result status should be "ok"
+    And the command runner should not have run "ruff"
+
+  Scenario: Unknown runner in enable flag is a usage error
+    Given a project with no lint issues and enable flag "ruff,no-such-linter"
+    When the check pipeline runs
+    Then the check exit code should be 3
+
+  Scenario: Unknown runner in require flag is a usage error
+    Given a project with 1 lint issue and the require flag "nope"
+    When the check pipeline runs
+    Then the check exit code should be 3
+    And the result message should contain "Unknown runner(s) in --require: nope"
+
+  Scenario: Require flag fails the check when the tool is missing
+    Given a project without ruff installed and the require flag "ruff"
+    When the check pipeline runs
+    Then the check exit code should be 4
+    And the result message should contain "Ruff"
+
+  Scenario: Quiet-skips flag moves skip lines to verbose and keeps the skipped count
+    Given a project without ruff installed and the quiet-skips flag
+    When the check pipeline runs
+    Then the result status should be "ok"
+    And no console warning should contain "Ruff: not installed — skipping"
+    And a verbose console line should contain "Ruff: not installed — skipping"
+    And the console should have recorded warning "1 runner(s) skipped — run `ai-guardrails init` to install missing tools"
+
+  Scenario: Max-findings flag prints only the first findings and keeps the exit code
+    Given a project with 5 lint issues and the max-findings flag "2"
+    When the check pipeline runs
+    Then the check exit code should be 1
+    And the console should have printed 2 findings
+    And the console should have recorded error "... and 3 more findings (use --max-findings 0 for all)"
+
+  Scenario: Max-findings flag of 0 prints every finding
+    Given a project with 5 lint issues and the max-findings flag "0"
+    When the check pipeline runs
+    Then the console should have printed 5 findings
+
+  Scenario: Negative max-findings flag is a usage error
+    Given a project with 5 lint issues and the max-findings flag "-1"
+    When the check pipeline runs
+    Then the check exit code should be 3
+    And the result message should contain "--max-findings must be a non-negative integer"
+
+  Scenario: Repeat flag runs the check several times and reports the spread
+    Given a project with 2 lint issues and the repeat flag "3"
+    When the check pipeline runs
+    Then the check exit code should be 1
+    And the command runner should have run "ruff check --output-format=json /project" 3 times
+    And the console should have printed 2 findings
+    And the console should have recorded note "Timings over 3 run(s), cache off:"
+
+  Scenario: Zero repeat flag is a usage error
+    Given a project with 2 lint issues and the repeat flag "0"
+    When the check pipeline runs
+    Then the check exit code should be 3
+    And the result message should contain "--repeat must be a positive integer"
+
+  Scenario: Repeat flag cannot be combined with fix
+    Given a project with 2 lint issues and the repeat flag "2" with fix
+    When the check pipeline runs
+    Then the check exit code should be 3
+    And the result message should contain "--repeat cannot be combined with --fix"
+
+  Scenario: Group-by file flag prints the findings once, under a heading per file
+    Given a project with 3 lint issues and the group-by flag "file"
+    When the check pipeline runs
+    Then the check exit code should be 1
+    And the console should have printed 3 findings
+    And the console should have printed the line "/project/foo1.py (1)"
+
+  Scenario: Clear cache flag removes cached results without running checks
+    Given a project with a cached runner result and the clear-cache flag
+    When the check pipeline runs
+    Then the result status should be "ok"
+    And the console should have recorded success "Cleared 1 cached runner result(s)"
+
+  Scenario: Report-suppressions lists suppressions without running linters
+    Given a project with a guardrails:ignore without a reason and the report-suppressions flag
+    When the check pipeline runs
+    Then the check exit code should be 1
+    And the command runner should not have run "ruff"
+
+  Scenario: Changed-since with no changed files exits 0 without running linters
+    Given a project with no changes since "origin/main"
+    When the check pipeline runs
+    Then the result status should be "ok"
+    And the console should have recorded success "No files changed since origin/main"
+
+  Scenario: Changed-since with an unknown ref is an error
+    Given a project where git cannot diff against "nope"
+    When the check pipeline runs
+    Then the check exit code should be 2
+
+  Scenario: Changed-since in a Turborepo checks every file of the affected packages
+    Given a Turborepo project where "apps/api" changed since "origin/main"
+    When the check pipeline runs
+    Then the result status should be "ok"
+    And the command runner should have run "ruff check --output-format=json apps/api/main.py"
+
+  Scenario: Changed-since in a Turborepo with no affected package exits 0
+    Given a Turborepo project where "" changed since "origin/main"
+    When the check pipeline runs
+    Then the result status should be "ok"
+    And the console should have recorded success "No packages affected since origin/main"
+
+  Scenario: Staged mode runs only file-scoped runners on staged files
+    Given a project with staged files "app.py,README.md"
+    When the check pipeline runs
+    Then the result status should be "ok"
+    And the command runner should have run "ruff check --output-format=json app.py"
+    And the command runner should not have run "pyright"
+
+  Scenario: Staged mode checks the staged content of a partially staged file
+    Given a project where "app.py" is staged with other content than on disk
+    When the check pipeline runs
+    Then the check exit code should be 1
+    And the command runner should have run "ruff check --output-format=json .ai-guardrails/cache/staged/app.py"
+    And the JSON report "report.json" should list a finding in "app.py" at line 1
+    And the file ".ai-guardrails/cache/staged/app.py" should have been deleted
+
+  Scenario: Staged mode deletes the staged copies when --path leaves nothing to check
+    Given a project where "app.py" is staged with other content and the path flag "other"
+    When the check pipeline runs
+    Then the result status should be "ok"
+    And the command runner should not have run "ruff"
+    And the file ".ai-guardrails/cache/staged/app.py" should have been deleted
+
+  Scenario: Staged mode with nothing staged exits 0 without running linters
+    Given a project with staged files ""
+    When the check pipeline runs
+    Then the result status should be "ok"
+    And the console should have recorded success "No staged files to check"
+    And the command runner should not have run "ruff"
+
+  Scenario: Staged mode exits 2 when git cannot list the staged files
+    Given a project in staged mode where git fails with "not a git repository"
+    When the check pipeline runs
+    Then the check exit code should be 2
+    And the result message should contain "not a git repository"
+
+  Scenario: Staged and changed-since cannot be combined
+    Given a project with the staged and changed-since flags
+    When the check pipeline runs
+    Then the check exit code should be 3
+
+  Scenario: Metrics flag appends a record for the run
+    Given a project with 2 lint issues and the metrics flag "reports/metrics.jsonl"
+    When the check pipeline runs
+    And the check pipeline runs again
+    Then the metrics file "reports/metrics.jsonl" should have 2 records with 2 errors
+
+  Scenario: Post-run hook gets the JSON report of a failing check
+    Given a project with 2 lint issues and the post_run command "notify.sh {status} {errors}"
+    When the check pipeline runs
+    Then the check exit code should be 1
+    And "notify.sh failed 2" should have received a report with 2 errors on stdin
+
+  Scenario: Post-run hook runs when nothing is in scope
+    Given a project with 0 lint issues and the post_run command "notify.sh {status} {errors}"
+    And the staged flag is set
+    When the check pipeline runs
+    Then the check exit code should be 0
+    And "notify.sh passed 0" should have received a report with 0 errors on stdin
+
+  Scenario: Post-run hook counts findings an update-baseline run accepts as not new
+    Given a project with 2 lint issues and the post_run command "notify.sh {status} {errors}"
+    And the update-baseline flag is set
+    When the check pipeline runs
+    Then the check exit code should be 0
+    And "notify.sh passed 0" should have received a report with 0 errors on stdin
+
+  Scenario: A failing post-run hook only warns
+    Given a project with 0 lint issues and the post_run command "notify.sh"
+    And the command "notify.sh" fails with "no route to host"
+    When the check pipeline runs
+    Then the check exit code should be 0
+    And the console should have recorded warning "post_run notify.sh failed: no route to host"
+
+  Scenario: A failing required post-run hook fails the check
+    Given a project with 0 lint issues and the post_run command "notify.sh"
+    And the post_run hook is required
+    And the command "notify.sh" fails with "no route to host"
+    When the check pipeline runs
+    Then the check exit code should be 2
+    And the result message should contain "post_run notify.sh failed: no route to host"
+
+  Scenario: Since-last-run without a manifest checks every file and records one
+    Given a project with no lint issues and the since-last-run flag
+    When the check pipeline runs
+    Then the result status should be "ok"
+    And the command runner should have run "ruff check --output-format=json /project"
+    And a file ending with ".ai-guardrails/cache/manifest.json" should be written
+
+  Scenario: Quiet run with no findings prints nothing to stdout
+    Given a project with no lint issues and the quiet and since-last-run flags
+    When the check pipeline runs
+    Then the result status should be "ok"
+    And nothing should be printed to stdout
+
+  Scenario: Since-last-run with nothing changed exits 0 without running linters
+    Given a project with no lint issues and the since-last-run flag
+    When the check pipeline runs
+    And the check pipeline runs again
+    Then the result status should be "ok"
+    And the console should have recorded success "No files changed since the last run"
+
+  Scenario: Since-last-run and staged cannot be combined
+    Given a project with the since-last-run and staged flags
+    When the check pipeline runs
+    Then the check exit code should be 3
+
+  Scenario: Stdin mode checks the piped buffer as the named file
+    Given a project with a buffer for "src/app.py" on stdin with 1 lint issue
+    When the check pipeline runs
+    Then the check exit code should be 1
+    And the command runner should have run "ruff check --output-format=json .ai-guardrails/cache/stdin/src/app.py"
+    And the command runner should not have run "pyright"
+    And the JSON report "report.json" should list a finding in "src/app.py" at line 1
+    And the file ".ai-guardrails/cache/stdin/src/app.py" should have been deleted
+
+  Scenario: Stdin without a filename is a usage error
+    Given a project with the stdin flag and no filename
+    When the check pipeline runs
+    Then the check exit code should be 3
+    And the command runner should not have run "ruff"
+
+  Scenario: Stdin and fix cannot be combined
+    Given a project with the stdin and fix flags
+    When the check pipeline runs
+    Then the check exit code should be 3
+
+    Given a project with 2 lint issues and the update-baseline flag for "custom/baseline.json"
+    When the check pipeline runs
+    Then the result status should be "ok"
+    And a file ending with "custom/baseline.json" should be written
+    And the console should have recorded success "Baseline updated: 2 issue(s) written to custom/baseline.json"
+
+  Scenario: Issues in the updated baseline no longer fail the check
+    Given a project with 2 lint issues and the update-baseline flag for "custom/baseline.json"
+    When the check pipeline runs
+    And the check pipeline runs again with baseline "custom/baseline.json"
+    Then the check exit code should be 0
+
+  Scenario: Update-baseline cannot be combined with staged mode
+    Given a project with the update-baseline and staged flags
+    When the check pipeline runs
+    Then the check exit code should be 3
+
+  Scenario: Module flag runs only the Go runners, in that module
+    Given a Go workspace with modules "api" and "worker" and the module flag "api"
+    When the check pipeline runs
+    Then the check exit code should be 0
+    And "staticcheck" should have run only in "/project/api"
+    And the command runner should not have run "ruff"
+
+  Scenario: Path flag checks only the files under the given directories
+    Given a project with Python files "svc/a.py,lib/b.py" and the path flag "svc"
+    When the check pipeline runs
+    Then the result status should be "ok"
+    And the command runner should have run "ruff check --output-format=json svc/a.py"
+
+  Scenario: Path flag lints only the Go modules under it
+    Given a Go workspace with modules "api" and "worker" and the path flag "api"
+    When the check pipeline runs
+    Then the check exit code should be 0
+    And "staticcheck" should have run only in "/project/api"
+
+  Scenario: Path flag naming a missing directory fails
+    Given a project with Python files "svc/a.py" and the path flag "nope"
+    When the check pipeline runs
+    Then the check exit code should be 3
+    And the result message should contain "--path nope does not exist"
+
+  Scenario: Module flag naming no Go module fails
+    Given a Go workspace with modules "api" and "worker" and the module flag "docs"
+    When the check pipeline runs
+    Then the check exit code should be 3
+    And the result message should contain "No Go module at or under docs"
+
+  Scenario: Update-baseline cannot be combined with the module flag
+    Given a Go workspace with modules "api" and "worker" and the module flag "api"
+    And the update-baseline flag is set
+    When the check pipeline runs
+    Then the check exit code should be 3
@@ -41,11 +41,24 @@ Feature: Install pipeline
     Given an install result with status "ok"
     Then the install exit code should be 0
 
-  Scenario: Install exit code 2 on error
+  Scenario: Install exit code 3 on a usage error
     Given an install result with status "error"
-    Then the install exit code should be 2
+    Then the install exit code should be 3
+
+  Scenario: Install exits 3 for an invalid project config
+    Given a default install project with an invalid project config
+    When the install pipeline runs
+    Then the result status should be "error"
+    And the install exit code for the run should be 3
 
   Scenario: Install merges hooks into ~/.claude/settings.json
     Given a default install project
     When the install pipeline runs
     Then hooks should be merged into settings.json
+
+  Scenario: Dry run writes no files and runs no installs
+    Given a default install project with dryRun flag
+    When the install pipeline runs
+    Then the result status should be "ok"
+    And no files should be written
+    And lefthook install should not have been called
@@ -6,18 +6,51 @@ Feature: Language detection
     Then "<language>" should be detected
 
     Examples:
-      | language   | marker                |
-      | python     | pyproject.toml        |
-      | python     | src/app.py            |
-      | typescript | package.json          |
-      | typescript | src/index.ts          |
-      | typescript | index.js              |
-      | rust       | Cargo.toml            |
-      | go         | go.mod                |
-      | shell      | scripts/build.sh      |
-      | cpp        | CMakeLists.txt        |
-      | dotnet     | MyApp.csproj          |
-      | lua        | src/main.lua          |
+      | language       | marker                         |
+      | python         | pyproject.toml                 |
+      | python         | src/app.py                     |
+      | typescript     | package.json                   |
+      | typescript     | src/index.ts                   |
+      | typescript     | index.js                       |
+      | rust           | Cargo.toml                     |
+      | rust           | Cargo.lock                     |
+      | go             | go.mod                         |
+      | go             | go.work                        |
+      | shell          | scripts/build.sh               |
+      | cpp            | CMakeLists.txt                 |
+      | cpp            | src/engine.cc                  |
+      | cpp            | include/engine.hpp             |
+      | dotnet         | MyApp.csproj                   |
+      | dotnet         | MyApp.sln                      |
+      | dotnet         | src/Program.cs                 |
+      | lua            | src/main.lua                   |
+      | docker         | Dockerfile                     |
+      | docker         | deploy/Containerfile           |
+      | docker         | api.dockerfile                 |
+      | yaml           | deploy/values.yaml             |
+      | yaml           | .github/workflows/ci.yml       |
+      | github-actions | .github/workflows/ci.yml       |
+      | github-actions | .github/workflows/release.yaml |
+      | terraform      | infra/main.tf                  |
+      | terraform      | envs/prod.tfvars               |
+      | ruby           | Gemfile                        |
+      | ruby           | .ruby-version                  |
+      | ruby           | app/models/user.rb             |
+      | swift          | Package.swift                  |
+      | swift          | App/AppDelegate.swift          |
+      | kotlin         | build.gradle.kts               |
+      | kotlin         | src/main/kotlin/App.kt         |
+      | php            | composer.json                  |
+      | php            | src/Controller/Home.php        |
+      | css            | styles/app.css                 |
+      | css            | src/theme.scss                 |
+      | css            | legacy/site.less               |
+      | sql            | models/orders.sql              |
+      | protobuf       | api/v1/orders.proto            |
+      | protobuf       | buf.yaml                       |
+      | helm           | charts/api/Chart.yaml          |
+      | openapi        | api/openapi.yaml               |
+      | openapi        | docs/swagger.json              |
 
   Scenario: Universal plugin always included for empty project
     Given an empty project
@@ -41,9 +74,9 @@ Feature: Language detection
     When languages are detected
     Then only "universal" should be detected
 
-  Scenario: ALL_PLUGINS contains 9 plugins
+  Scenario: ALL_PLUGINS contains 22 plugins
     When the plugin registry is inspected
-    Then it should contain 9 plugins
+    Then it should contain 22 plugins
 
   Scenario: Universal plugin is last in registry
     When the plugin registry is inspected
@@ -82,18 +115,31 @@ Feature: Language detection
     Then the runner ids should include "ruff"
     And the runner ids should include "pyright"
 
-  Scenario: Python plugin returns exactly 2 runners
+  Scenario: Python plugin returns ruff, isort and pyright runners
     When the "python" plugin runners are inspected
-    Then there should be 2 runners
+    Then there should be 3 runners
+    And the runner ids should include "isort"
 
-  Scenario: TypeScript plugin returns biome and tsc runners
+  Scenario: TypeScript plugin returns biome, eslint, prettier and tsc runners
     When the "typescript" plugin runners are inspected
     Then the runner ids should include "biome"
+    And the runner ids should include "eslint"
+    And the runner ids should include "prettier"
     And the runner ids should include "tsc"
 
-  Scenario: TypeScript plugin returns exactly 2 runners
+  Scenario: TypeScript plugin returns exactly 4 runners
     When the "typescript" plugin runners are inspected
-    Then there should be 2 runners
+    Then there should be 4 runners
+
+  Scenario: Go plugin returns golangci-lint and staticcheck runners
+    When the "go" plugin runners are inspected
+    Then the runner ids should include "golangci-lint"
+    And the runner ids should include "staticcheck"
+
+  Scenario: Rust plugin returns clippy and rustfmt runners
+    When the "rust" plugin runners are inspected
+    Then the runner ids should include "clippy"
+    And the runner ids should include "rustfmt"
 
   # Ignore path scenarios — files in dependency dirs must NOT trigger detection
 
@@ -111,6 +157,7 @@ Feature: Language detection
       | shell      | vendor/scripts/build.sh                    |
       | cpp        | build/generated/foo.cpp                    |
       | lua        | vendor/libs/module.lua                     |
+      | yaml       | node_modules/pkg/config.yaml               |
 
   Scenario: src/app.py still triggers Python detection
     Given a project with file "src/app.py"
@@ -7,7 +7,7 @@ import {
 } from "@/config/schema";
 import { biomeGenerator } from "@/generators/biome";
 
-function makeConfig(profile?: "strict" | "standard" | "minimal") {
+function makeConfig(profile?: "strict" | "standard" | "lenient") {
   return buildResolvedConfig(
     MachineConfigSchema.parse({ profile: profile ?? "standard" }),
     ProjectConfigSchema.parse({})
@@ -85,8 +85,8 @@ describe("biomeGenerator", () => {
     expect(parsed.linter.rules.correctness).toBeUndefined();
   });
 
-  test("minimal profile has recommended false with only critical rules", () => {
-    const output = biomeGenerator.generate(makeConfig("minimal"));
+  test("lenient profile has recommended false with only critical rules", () => {
+    const output = biomeGenerator.generate(makeConfig("lenient"));
     const parsed = MinimalRulesSchema.parse(parseJsonBody(output));
     expect(parsed.linter.rules.recommended).toBe(false);
     expect(parsed.linter.rules.correctness).toBeDefined();
@@ -97,14 +97,14 @@ describe("biomeGenerator", () => {
   test("profiles produce different linter.rules sections", () => {
     const strict = biomeGenerator.generate(makeConfig("strict"));
     const standard = biomeGenerator.generate(makeConfig("standard"));
-    const minimal = biomeGenerator.generate(makeConfig("minimal"));
+    const lenient = biomeGenerator.generate(makeConfig("lenient"));
     expect(strict).not.toBe(standard);
-    expect(standard).not.toBe(minimal);
-    expect(strict).not.toBe(minimal);
+    expect(standard).not.toBe(lenient);
+    expect(strict).not.toBe(lenient);
   });
 
   test("generate output contains valid JSON body for all profiles", () => {
-    for (const profile of ["strict", "standard", "minimal"] as const) {
+    for (const profile of ["strict", "standard", "lenient"] as const) {
       const output = biomeGenerator.generate(makeConfig(profile));
       expect(() => parseJsonBody(output)).not.toThrow();
     }
@@ -118,7 +118,7 @@ describe("biomeGenerator", () => {
     expect(biomeGenerator.generate(makeConfig("standard"))).toMatchSnapshot();
   });
 
-  test("minimal output matches snapshot", () => {
-    expect(biomeGenerator.generate(makeConfig("minimal"))).toMatchSnapshot();
+  test("lenient output matches snapshot", () => {
+    expect(biomeGenerator.generate(makeConfig("lenient"))).toMatchSnapshot();
   });
 });
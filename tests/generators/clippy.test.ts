@@ -0,0 +1,49 @@
+import { describe, expect, test } from "bun:test";
+import type { ResolvedConfig } from "@/config/schema";
+import { clippyGenerator } from "@/generators/clippy";
+
+function makeConfig(overrides: Partial<ResolvedConfig> = {}): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 4 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+describe("clippyGenerator", () => {
+  test("uses standard thresholds by default", () => {
+    const output = clippyGenerator.generate(makeConfig());
+    expect(output).toContain("cognitive-complexity-threshold = 25");
+    expect(output).toContain("too-many-arguments-threshold = 7");
+  });
+
+  test("strict profile tightens thresholds", () => {
+    const output = clippyGenerator.generate(makeConfig({ profile: "strict" }));
+    expect(output).toContain("cognitive-complexity-threshold = 15");
+    expect(output).toContain("too-many-arguments-threshold = 5");
+  });
+
+  test("includes hash header", () => {
+    const output = clippyGenerator.generate(makeConfig());
+    expect(output).toMatch(/^# ai-guardrails:sha256=/);
+  });
+
+  test("has configFile set to clippy.toml", () => {
+    expect(clippyGenerator.configFile).toBe("clippy.toml");
+  });
+
+  test("has languages set to rust", () => {
+    expect(clippyGenerator.languages).toEqual(["rust"]);
+  });
+
+  test("output matches snapshot", () => {
+    const output = clippyGenerator.generate(makeConfig());
+    expect(output).toMatchSnapshot();
+  });
+});
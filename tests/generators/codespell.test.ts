@@ -31,4 +31,19 @@ describe("codespellGenerator", () => {
     const output = codespellGenerator.generate(makeConfig());
     expect(output).toMatchSnapshot();
   });
+
+  test("writes ignore words and dictionaries from [config]", () => {
+    const config = buildResolvedConfig(
+      MachineConfigSchema.parse({}),
+      ProjectConfigSchema.parse({
+        config: {
+          codespell_ignore_words: ["ba", "nd"],
+          codespell_dictionaries: ["docs/words.txt"],
+        },
+      })
+    );
+    const output = codespellGenerator.generate(config);
+    expect(output).toContain("ignore-words-list = ba,nd\n");
+    expect(output).toContain("dictionary = -,docs/words.txt\n");
+  });
 });
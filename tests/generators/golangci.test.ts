@@ -0,0 +1,71 @@
+import { describe, expect, test } from "bun:test";
+import type { ResolvedConfig } from "@/config/schema";
+import { golangciGenerator } from "@/generators/golangci";
+
+function makeConfig(overrides: Partial<ResolvedConfig> = {}): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 88, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+describe("golangciGenerator", () => {
+  test("enables the standard linter set by default", () => {
+    const output = golangciGenerator.generate(makeConfig());
+    expect(output).toContain("default: none");
+    expect(output).toContain("    - staticcheck");
+    expect(output).toContain("    - gosec");
+    expect(output).not.toContain("    - revive");
+  });
+
+  test("strict profile enables lll with the configured line length", () => {
+    const output = golangciGenerator.generate(makeConfig({ profile: "strict" }));
+    expect(output).toContain("    - lll");
+    expect(output).toContain("line-length: 88");
+  });
+
+  test("declares the v2 schema, not the v1 keys v2 rejects", () => {
+    const output = golangciGenerator.generate(makeConfig({ profile: "strict" }));
+    expect(output).toContain('version: "2"');
+    expect(output).toContain("linters:\n  default: none");
+    expect(output).toContain("  settings:\n    lll:\n      line-length: 88");
+    expect(output).not.toContain("disable-all");
+    expect(output).not.toContain("linters-settings:");
+  });
+
+  test("lenient profile omits linter settings", () => {
+    const output = golangciGenerator.generate(makeConfig({ profile: "lenient" }));
+    expect(output).not.toContain("settings:");
+    expect(output).not.toContain("    - gosec");
+  });
+
+  test("includes hash header", () => {
+    const output = golangciGenerator.generate(makeConfig());
+    expect(output).toMatch(/^# ai-guardrails:sha256=/);
+  });
+
+  test("has configFile set to .golangci.yml", () => {
+    expect(golangciGenerator.configFile).toBe(".golangci.yml");
+  });
+
+  test("has languages set to go", () => {
+    expect(golangciGenerator.languages).toEqual(["go"]);
+  });
+
+  test("output matches snapshot", () => {
+    const output = golangciGenerator.generate(makeConfig());
+    expect(output).toMatchSnapshot();
+  });
+
+  test("strict output matches snapshot", () => {
+    const output = golangciGenerator.generate(makeConfig({ profile: "strict" }));
+    expect(output).toMatchSnapshot();
+  });
+});
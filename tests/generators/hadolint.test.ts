@@ -0,0 +1,43 @@
+import { describe, expect, test } from "bun:test";
+import type { ResolvedConfig } from "@/config/schema";
+import { hadolintGenerator } from "@/generators/hadolint";
+
+function makeConfig(overrides: Partial<ResolvedConfig> = {}): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 88, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+describe("hadolintGenerator", () => {
+  test("generates a minimal .hadolint.yaml", () => {
+    const output = hadolintGenerator.generate(makeConfig());
+    expect(output).toContain("failure-threshold: warning");
+    expect(output).toContain("ignored: []");
+  });
+
+  test("includes hash header", () => {
+    const output = hadolintGenerator.generate(makeConfig());
+    expect(output).toMatch(/^# ai-guardrails:sha256=/);
+  });
+
+  test("has configFile set to .hadolint.yaml", () => {
+    expect(hadolintGenerator.configFile).toBe(".hadolint.yaml");
+  });
+
+  test("has languages set to docker", () => {
+    expect(hadolintGenerator.languages).toEqual(["docker"]);
+  });
+
+  test("output matches snapshot", () => {
+    const output = hadolintGenerator.generate(makeConfig());
+    expect(output).toMatchSnapshot();
+  });
+});
@@ -0,0 +1,43 @@
+import { describe, expect, test } from "bun:test";
+import type { ResolvedConfig } from "@/config/schema";
+import { rubocopGenerator } from "@/generators/rubocop";
+
+function makeConfig(overrides: Partial<ResolvedConfig> = {}): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 88, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+describe("rubocopGenerator", () => {
+  test("generates .rubocop.yml with the line length from config", () => {
+    const output = rubocopGenerator.generate(makeConfig());
+    expect(output).toContain("NewCops: enable");
+    expect(output).toContain("Max: 88");
+  });
+
+  test("includes hash header", () => {
+    const output = rubocopGenerator.generate(makeConfig());
+    expect(output).toMatch(/^# ai-guardrails:sha256=/);
+  });
+
+  test("has configFile set to .rubocop.yml", () => {
+    expect(rubocopGenerator.configFile).toBe(".rubocop.yml");
+  });
+
+  test("has languages set to ruby", () => {
+    expect(rubocopGenerator.languages).toEqual(["ruby"]);
+  });
+
+  test("output matches snapshot", () => {
+    const output = rubocopGenerator.generate(makeConfig());
+    expect(output).toMatchSnapshot();
+  });
+});
@@ -1,12 +1,14 @@
 import { describe, expect, test } from "bun:test";
+import { parse as parseToml } from "smol-toml";
 import {
   buildResolvedConfig,
   MachineConfigSchema,
   ProjectConfigSchema,
 } from "@/config/schema";
-import { ruffGenerator } from "@/generators/ruff";
+import { mergeRuffToml, ruffGenerator } from "@/generators/ruff";
+import { computeHash, TEMPLATE_VERSION } from "@/utils/hash";
 
-function makeConfig(profile?: "strict" | "standard" | "minimal") {
+function makeConfig(profile?: "strict" | "standard" | "lenient") {
   return buildResolvedConfig(
     MachineConfigSchema.parse({ profile: profile ?? "standard" }),
     ProjectConfigSchema.parse({})
@@ -40,8 +42,8 @@ describe("ruffGenerator", () => {
     expect(output).not.toContain('select = ["E", "F", "S"]');
   });
 
-  test("minimal profile selects only critical rules", () => {
-    const output = ruffGenerator.generate(makeConfig("minimal"));
+  test("lenient profile selects only critical rules", () => {
+    const output = ruffGenerator.generate(makeConfig("lenient"));
     expect(output).toContain('select = ["E", "F", "S"]');
     expect(output).not.toContain('select = ["ALL"]');
   });
@@ -49,10 +51,10 @@ describe("ruffGenerator", () => {
   test("profiles produce different select lines", () => {
     const strict = ruffGenerator.generate(makeConfig("strict"));
     const standard = ruffGenerator.generate(makeConfig("standard"));
-    const minimal = ruffGenerator.generate(makeConfig("minimal"));
+    const lenient = ruffGenerator.generate(makeConfig("lenient"));
     expect(strict).not.toBe(standard);
-    expect(standard).not.toBe(minimal);
-    expect(strict).not.toBe(minimal);
+    expect(standard).not.toBe(lenient);
+    expect(strict).not.toBe(lenient);
   });
 
   test("includes banned-api section", () => {
@@ -124,7 +126,51 @@ describe("ruffGenerator", () => {
     expect(ruffGenerator.generate(makeConfig("standard"))).toMatchSnapshot();
   });
 
-  test("minimal output matches snapshot", () => {
-    expect(ruffGenerator.generate(makeConfig("minimal"))).toMatchSnapshot();
+  test("lenient output matches snapshot", () => {
+    expect(ruffGenerator.generate(makeConfig("lenient"))).toMatchSnapshot();
+  });
+});
+
+describe("mergeRuffToml", () => {
+  const generated = ruffGenerator.generate(makeConfig("lenient"));
+
+  function lintOf(merged: string): Record<string, unknown> {
+    const body = merged.slice(merged.indexOf("\n") + 1);
+    return parseToml(body).lint as Record<string, unknown>;
+  }
+
+  test("keeps user keys and appends missing recommended rules to extend-select", () => {
+    const existing =
+      'line-length = 120\n[lint]\nselect = ["E", "D"]\nextend-select = ["N"]\n';
+    const merged = mergeRuffToml(existing, generated);
+
+    expect(merged).toContain("line-length = 120");
+    const lint = lintOf(merged);
+    expect(lint.select).toEqual(["E", "D"]);
+    expect(lint["extend-select"]).toEqual(["N", "F", "S"]);
+  });
+
+  test("adds our select when the user has none", () => {
+    const lint = lintOf(mergeRuffToml('[lint]\nignore = ["E501"]\n', generated));
+    expect(lint.select).toEqual(["E", "F", "S"]);
+    expect(lint.ignore).toEqual(["E501"]);
+  });
+
+  test("leaves extend-select alone when the user selects ALL", () => {
+    const lint = lintOf(mergeRuffToml('[lint]\nselect = ["ALL"]\n', generated));
+    expect(lint["extend-select"]).toBeUndefined();
+  });
+
+  test("writes a hash header computed over the merged content", () => {
+    const merged = mergeRuffToml("line-length = 100\n", generated);
+    const [header, ...body] = merged.split("\n");
+    const hash = computeHash(body.join("\n"));
+    expect(header).toBe(`# ai-guardrails:sha256=${hash};template=v${TEMPLATE_VERSION}`);
+  });
+
+  test("throws on a malformed existing file", () => {
+    expect(() => mergeRuffToml("not = valid = toml", generated)).toThrow(
+      "Cannot merge ruff.toml"
+    );
   });
 });
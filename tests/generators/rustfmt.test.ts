@@ -0,0 +1,43 @@
+import { describe, expect, test } from "bun:test";
+import type { ResolvedConfig } from "@/config/schema";
+import { rustfmtGenerator } from "@/generators/rustfmt";
+
+function makeConfig(overrides: Partial<ResolvedConfig> = {}): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 4 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+describe("rustfmtGenerator", () => {
+  test("maps line_length and indent_width to rustfmt options", () => {
+    const output = rustfmtGenerator.generate(makeConfig());
+    expect(output).toContain("max_width = 100");
+    expect(output).toContain("tab_spaces = 4");
+  });
+
+  test("includes hash header", () => {
+    const output = rustfmtGenerator.generate(makeConfig());
+    expect(output).toMatch(/^# ai-guardrails:sha256=/);
+  });
+
+  test("has configFile set to rustfmt.toml", () => {
+    expect(rustfmtGenerator.configFile).toBe("rustfmt.toml");
+  });
+
+  test("has languages set to rust", () => {
+    expect(rustfmtGenerator.languages).toEqual(["rust"]);
+  });
+
+  test("output matches snapshot", () => {
+    const output = rustfmtGenerator.generate(makeConfig());
+    expect(output).toMatchSnapshot();
+  });
+});
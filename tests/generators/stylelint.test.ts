@@ -0,0 +1,51 @@
+import { describe, expect, test } from "bun:test";
+import type { ResolvedConfig } from "@/config/schema";
+import { stylelintGenerator } from "@/generators/stylelint";
+
+function makeConfig(overrides: Partial<ResolvedConfig> = {}): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 88, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+describe("stylelintGenerator", () => {
+  test("generates .stylelintrc.yaml with core rules and SCSS/Less syntaxes", () => {
+    const output = stylelintGenerator.generate(makeConfig());
+    expect(output).toContain("  color-no-invalid-hex: true");
+    expect(output).toContain("customSyntax: postcss-scss");
+    expect(output).toContain("customSyntax: postcss-less");
+  });
+
+  test("ignores the config's ignore_paths", () => {
+    const output = stylelintGenerator.generate(
+      makeConfig({ ignorePaths: ["public/vendor/**"] })
+    );
+    expect(output).toContain('  - "public/vendor/**"');
+  });
+
+  test("includes hash header", () => {
+    const output = stylelintGenerator.generate(makeConfig());
+    expect(output).toMatch(/^# ai-guardrails:sha256=/);
+  });
+
+  test("has configFile set to .stylelintrc.yaml", () => {
+    expect(stylelintGenerator.configFile).toBe(".stylelintrc.yaml");
+  });
+
+  test("has languages set to css", () => {
+    expect(stylelintGenerator.languages).toEqual(["css"]);
+  });
+
+  test("output matches snapshot", () => {
+    const output = stylelintGenerator.generate(makeConfig());
+    expect(output).toMatchSnapshot();
+  });
+});
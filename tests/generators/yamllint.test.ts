@@ -0,0 +1,43 @@
+import { describe, expect, test } from "bun:test";
+import type { ResolvedConfig } from "@/config/schema";
+import { yamllintGenerator } from "@/generators/yamllint";
+
+function makeConfig(overrides: Partial<ResolvedConfig> = {}): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 88, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+describe("yamllintGenerator", () => {
+  test("generates .yamllint.yaml extending the default rules", () => {
+    const output = yamllintGenerator.generate(makeConfig());
+    expect(output).toContain("extends: default");
+    expect(output).toContain("document-start: disable");
+  });
+
+  test("includes hash header", () => {
+    const output = yamllintGenerator.generate(makeConfig());
+    expect(output).toMatch(/^# ai-guardrails:sha256=/);
+  });
+
+  test("has configFile set to .yamllint.yaml", () => {
+    expect(yamllintGenerator.configFile).toBe(".yamllint.yaml");
+  });
+
+  test("has languages set to yaml", () => {
+    expect(yamllintGenerator.languages).toEqual(["yaml"]);
+  });
+
+  test("output matches snapshot", () => {
+    const output = yamllintGenerator.generate(makeConfig());
+    expect(output).toMatchSnapshot();
+  });
+});
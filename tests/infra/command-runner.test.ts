@@ -1,5 +1,12 @@
 import { describe, expect, test } from "bun:test";
+import type { CommandRunner } from "@/infra/command-runner";
+import {
+  formatArgv,
+  LimitedCommandRunner,
+  LoggingCommandRunner,
+} from "@/infra/command-runner";
 import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeConsole } from "../fakes/fake-console";
 
 describe("FakeCommandRunner", () => {
   test("returns registered response for matching args", async () => {
@@ -41,3 +48,92 @@ describe("FakeCommandRunner", () => {
     expect(b.stdout).toBe("b");
   });
 });
+
+describe("LoggingCommandRunner", () => {
+  test("logs argv and cwd before, and the exit code after, at debug level", async () => {
+    const inner = new FakeCommandRunner();
+    inner.register(["ruff", "check", "."], { stdout: "", stderr: "", exitCode: 1 });
+    const cons = new FakeConsole();
+    const runner = new LoggingCommandRunner(inner, cons);
+
+    const result = await runner.run(["ruff", "check", "."], { cwd: "/project" });
+
+    expect(result.exitCode).toBe(1);
+    expect(cons.debugs[0]).toBe("$ ruff check . (in /project)");
+    expect(cons.debugs[1]).toMatch(/^ {2}exit 1 in \d+ms: ruff$/);
+    expect(cons.infos).toEqual([]);
+  });
+
+  test("passes options through to the inner runner", async () => {
+    const inner = new FakeCommandRunner();
+    const runner = new LoggingCommandRunner(inner, new FakeConsole());
+
+    await runner.run(["tsc"], { cwd: "/project", timeout: 5000 });
+
+    expect(inner.cwds).toEqual(["/project"]);
+    expect(inner.timeouts).toEqual([5000]);
+  });
+});
+
+describe("LimitedCommandRunner", () => {
+  test("runs at most maxProcs commands at once across callers", async () => {
+    let inFlight = 0;
+    let peak = 0;
+    const inner: CommandRunner = {
+      async run() {
+        inFlight++;
+        peak = Math.max(peak, inFlight);
+        await new Promise((resolve) => setTimeout(resolve, 1));
+        inFlight--;
+        return { stdout: "", stderr: "", exitCode: 0 };
+      },
+    };
+    const runner = new LimitedCommandRunner(inner, 2);
+
+    await Promise.all(["a", "b", "c", "d", "e"].map((cmd) => runner.run([cmd])));
+
+    expect(peak).toBe(2);
+  });
+
+  test("passes args and options through to the inner runner", async () => {
+    const inner = new FakeCommandRunner();
+    const runner = new LimitedCommandRunner(inner, 1);
+
+    await runner.run(["go", "vet", "./..."], { cwd: "/project/api", timeout: 5000 });
+
+    expect(inner.calls).toEqual([["go", "vet", "./..."]]);
+    expect(inner.cwds).toEqual(["/project/api"]);
+    expect(inner.timeouts).toEqual([5000]);
+  });
+
+  test("cancels a waiting command whose signal aborts without running it", async () => {
+    const calls: string[][] = [];
+    let release = () => {};
+    const inner: CommandRunner = {
+      async run(args) {
+        calls.push(args);
+        await new Promise<void>((resolve) => (release = resolve));
+        return { stdout: "", stderr: "", exitCode: 0 };
+      },
+    };
+    const runner = new LimitedCommandRunner(inner, 1);
+    const controller = new AbortController();
+    const first = runner.run(["a"]);
+    const queued = runner.run(["b"], { signal: controller.signal });
+
+    controller.abort();
+
+    expect(await queued).toMatchObject({ exitCode: 1, cancelled: true });
+    release();
+    await first;
+    expect(calls).toEqual([["a"]]);
+  });
+});
+
+describe("formatArgv", () => {
+  test("quotes only arguments a shell would split", () => {
+    expect(formatArgv(["grep", "-e", "two words", "--flag=x", "src/a.ts"])).toBe(
+      'grep -e "two words" --flag=x src/a.ts'
+    );
+  });
+});
@@ -0,0 +1,22 @@
+import { describe, expect, test } from "bun:test";
+import { shouldUseColor } from "@/infra/console";
+
+describe("shouldUseColor", () => {
+  test("auto colors a terminal", () => {
+    expect(shouldUseColor("auto", true, {})).toBe(true);
+  });
+
+  test("auto stays plain when output is not a terminal", () => {
+    expect(shouldUseColor("auto", false, {})).toBe(false);
+  });
+
+  test("auto honours a non-empty NO_COLOR", () => {
+    expect(shouldUseColor("auto", true, { NO_COLOR: "1" })).toBe(false);
+    expect(shouldUseColor("auto", true, { NO_COLOR: "" })).toBe(true);
+  });
+
+  test("always and never override the terminal check and NO_COLOR", () => {
+    expect(shouldUseColor("always", false, { NO_COLOR: "1" })).toBe(true);
+    expect(shouldUseColor("never", true, {})).toBe(false);
+  });
+});
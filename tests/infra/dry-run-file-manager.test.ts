@@ -0,0 +1,44 @@
+import { describe, expect, test } from "bun:test";
+import { DryRunFileManager } from "@/infra/dry-run-file-manager";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+describe("DryRunFileManager", () => {
+  test("keeps writes and deletes in memory and reads them back", async () => {
+    const inner = new FakeFileManager();
+    inner.seed("/project/a.txt", "old\n");
+    inner.seed("/project/b.txt", "stale\n");
+    const fm = new DryRunFileManager(inner);
+
+    await fm.writeText("/project/a.txt", "new\n");
+    await fm.appendText("/project/c.txt", "created\n");
+    await fm.delete("/project/b.txt");
+
+    expect(await fm.readText("/project/a.txt")).toBe("new\n");
+    expect(await fm.exists("/project/b.txt")).toBe(false);
+    expect(await fm.readText("/project/c.txt")).toBe("created\n");
+    await expect(fm.readText("/project/b.txt")).rejects.toThrow("ENOENT");
+    expect(await inner.readText("/project/a.txt")).toBe("old\n");
+    expect(await inner.exists("/project/b.txt")).toBe(true);
+    expect(await inner.exists("/project/c.txt")).toBe(false);
+    expect([...fm.changes.keys()]).toEqual([
+      "/project/a.txt",
+      "/project/c.txt",
+      "/project/b.txt",
+    ]);
+  });
+
+  test("glob sees created files and hides deleted ones", async () => {
+    const inner = new FakeFileManager();
+    inner.seed("/project/keep.yml", "");
+    inner.seed("/project/gone.yml", "");
+    const fm = new DryRunFileManager(inner);
+
+    await fm.writeText("/project/deploy/ci.yml", "");
+    await fm.delete("/project/gone.yml");
+
+    expect((await fm.glob("**/*.yml", "/project")).toSorted()).toEqual([
+      "deploy/ci.yml",
+      "keep.yml",
+    ]);
+  });
+});
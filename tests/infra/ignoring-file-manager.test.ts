@@ -0,0 +1,26 @@
+import { describe, expect, test } from "bun:test";
+import { IgnoringFileManager } from "@/infra/ignoring-file-manager";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+describe("IgnoringFileManager", () => {
+  test("glob hides ignored paths relative to the project root", async () => {
+    const inner = new FakeFileManager();
+    inner.seed("/project/src/main.py", "");
+    inner.seed("/project/src/gen/api.py", "");
+    const fm = new IgnoringFileManager(inner, "/project", (rel) =>
+      rel.startsWith("src/gen/")
+    );
+
+    expect(await fm.glob("**/*.py", "/project")).toEqual(["src/main.py"]);
+    // Paths are matched from the project root whatever the glob cwd
+    expect(await fm.glob("**/*.py", "/project/src")).toEqual(["main.py"]);
+  });
+
+  test("reads ignored files directly", async () => {
+    const inner = new FakeFileManager();
+    inner.seed("/project/src/gen/api.py", "x = 1\n");
+    const fm = new IgnoringFileManager(inner, "/project", () => true);
+
+    expect(await fm.readText("/project/src/gen/api.py")).toBe("x = 1\n");
+  });
+});
@@ -0,0 +1,73 @@
+import { describe, expect, test } from "bun:test";
+import { killProcessGroupsOnShutdown, LiveProcessGroups } from "@/infra/process-groups";
+
+/** Stands in for process.kill: records kills of a fake long-running child */
+function fakeKill(groupsSupported = true) {
+  const kills: [number, NodeJS.Signals][] = [];
+  const kill = (pid: number, signal: NodeJS.Signals) => {
+    if (pid < 0 && !groupsSupported) throw new Error("ESRCH");
+    kills.push([pid, signal]);
+  };
+  return { kills, kill };
+}
+
+describe("LiveProcessGroups", () => {
+  test("kills the whole group of every live child", () => {
+    const { kills, kill } = fakeKill();
+    const groups = new LiveProcessGroups(kill);
+    groups.add(4242);
+    groups.add(4343);
+    groups.delete(4343);
+
+    groups.killAll();
+
+    expect(kills).toEqual([[-4242, "SIGKILL"]]);
+    expect(groups.size).toBe(0);
+  });
+
+  test("falls back to the process itself without process groups", () => {
+    const { kills, kill } = fakeKill(false);
+    const groups = new LiveProcessGroups(kill);
+    groups.add(4242);
+
+    groups.killAll();
+
+    expect(kills).toEqual([[4242, "SIGKILL"]]);
+  });
+});
+
+describe("killProcessGroupsOnShutdown", () => {
+  test("kills live children on SIGTERM before handing the signal on", () => {
+    const { kills, kill } = fakeKill();
+    const groups = new LiveProcessGroups(kill);
+    groups.add(4242);
+    const signals: string[] = [];
+    const dispose = killProcessGroupsOnShutdown(groups, (signal) => {
+      signals.push(signal);
+      expect(kills).toEqual([[-4242, "SIGKILL"]]);
+    });
+
+    try {
+      process.emit("SIGTERM");
+    } finally {
+      dispose();
+    }
+
+    expect(signals).toEqual(["SIGTERM"]);
+  });
+
+  test("kills live children when the process exits", () => {
+    const { kills, kill } = fakeKill();
+    const groups = new LiveProcessGroups(kill);
+    groups.add(4242);
+    const dispose = killProcessGroupsOnShutdown(groups, () => {});
+
+    try {
+      process.emit("exit", 0);
+    } finally {
+      dispose();
+    }
+
+    expect(kills).toEqual([[-4242, "SIGKILL"]]);
+  });
+});
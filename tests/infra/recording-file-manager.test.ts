@@ -0,0 +1,18 @@
+import { describe, expect, test } from "bun:test";
+import { RecordingFileManager } from "@/infra/recording-file-manager";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+describe("RecordingFileManager", () => {
+  test("records exists() hits and glob matches as absolute paths", async () => {
+    const inner = new FakeFileManager();
+    inner.seed("/project/go.mod", "module x");
+    inner.seed("/project/cmd/main.go", "");
+    const fm = new RecordingFileManager(inner);
+
+    expect(await fm.exists("/project/go.mod")).toBe(true);
+    expect(await fm.exists("/project/go.work")).toBe(false);
+    expect(await fm.glob("**/*.go", "/project")).toEqual(["cmd/main.go"]);
+
+    expect(fm.found).toEqual(["/project/go.mod", "/project/cmd/main.go"]);
+  });
+});
@@ -0,0 +1,98 @@
+import { describe, expect, test } from "bun:test";
+import {
+  buildResolvedConfig,
+  MachineConfigSchema,
+  ProjectConfigSchema,
+} from "@/config/schema";
+import {
+  findUserConfigs,
+  userConfiguredModules,
+  withUserConfigs,
+} from "@/init/existing-configs";
+import { withHashHeader } from "@/utils/hash";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+function makeConfig() {
+  return buildResolvedConfig(
+    MachineConfigSchema.parse({}),
+    ProjectConfigSchema.parse({})
+  );
+}
+
+describe("findUserConfigs", () => {
+  test("finds each tool's first config file in its lookup order", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.ruff.toml", "line-length = 120\n");
+    fm.seed("/project/ruff.toml", "line-length = 100\n");
+    fm.seed("/project/.golangci.yaml", "linters: {}\n");
+
+    const found = await findUserConfigs("/project", fm);
+
+    expect(found).toEqual([
+      { runner: "ruff", file: "ruff.toml" },
+      { runner: "golangci-lint", file: ".golangci.yaml" },
+    ]);
+  });
+
+  test("skips files we generated", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/ruff.toml", withHashHeader("line-length = 88\n"));
+    fm.seed("/project/.ruff.toml", "line-length = 120\n");
+
+    const found = await findUserConfigs("/project", fm);
+
+    expect(found).toEqual([{ runner: "ruff", file: ".ruff.toml" }]);
+  });
+
+  test("counts a shared file only with the tool's section", async () => {
+    const fm = new FakeFileManager();
+    fm.seed(
+      "/project/pyproject.toml",
+      '[project]\nname = "app"\n\n[tool.ruff.lint]\nselect = ["E"]\n'
+    );
+    fm.seed("/project/setup.cfg", "[metadata]\nname = app\n");
+
+    const found = await findUserConfigs("/project", fm);
+
+    expect(found).toEqual([{ runner: "ruff", file: "pyproject.toml" }]);
+  });
+
+  test("returns [] when the project keeps no tool configs", async () => {
+    expect(await findUserConfigs("/project", new FakeFileManager())).toEqual([]);
+  });
+});
+
+describe("withUserConfigs", () => {
+  test("records each file as its runner's user_config, keeping its settings", () => {
+    const config = {
+      ...makeConfig(),
+      runners: { ruff: { enabled: true } },
+    };
+
+    const found = [{ runner: "ruff", file: "pyproject.toml" }];
+
+    const adopted = withUserConfigs(config, found);
+
+    expect(adopted.runners?.ruff).toEqual({
+      enabled: true,
+      user_config: "pyproject.toml",
+    });
+  });
+});
+
+describe("userConfiguredModules", () => {
+  test("names the init modules of runners with a user_config", () => {
+    const config = withUserConfigs(makeConfig(), [
+      { runner: "golangci-lint", file: ".golangci.yml" },
+      { runner: "markdownlint", file: ".markdownlint.yaml" },
+    ]);
+    expect(userConfiguredModules(config)).toEqual([
+      "golangci-config",
+      "markdownlint-config",
+    ]);
+  });
+
+  test("returns [] without user configs", () => {
+    expect(userConfiguredModules(makeConfig())).toEqual([]);
+  });
+});
@@ -351,3 +351,27 @@ describe("github-protected-patterns — calls rulesets API", () => {
     expect(call?.join(" ")).toContain("required_status_checks][0][context]=check");
   });
 });
+
+// ---------------------------------------------------------------------------
+// --dry-run
+// ---------------------------------------------------------------------------
+
+describe("github modules — dry run", () => {
+  test("branch protection and rulesets make no API call", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.git", "");
+    const cr = new FakeCommandRunner();
+    const ctx = makeCtx({ fileManager: fm, commandRunner: cr, flags: { dryRun: true } });
+
+    const protection = await githubBranchProtectionModule.execute(ctx);
+    const patterns = await githubProtectedPatternsModule.execute(ctx);
+
+    expect(protection).toEqual({
+      status: "skipped",
+      message: "Dry run — would set branch protection on main",
+    });
+    expect(patterns.status).toBe("skipped");
+    expect(patterns.message).toContain("release/*");
+    expect(cr.calls).toEqual([]);
+  });
+});
@@ -0,0 +1,113 @@
+import { describe, expect, test } from "bun:test";
+import {
+  buildResolvedConfig,
+  MachineConfigSchema,
+  ProjectConfigSchema,
+} from "@/config/schema";
+import { golangciConfigModule } from "@/init/modules/golangci-config";
+import type { InitContext } from "@/init/types";
+import type { LanguagePlugin } from "@/languages/types";
+import { withHashHeader } from "@/utils/hash";
+import { FakeCommandRunner } from "../../fakes/fake-command-runner";
+import { FakeConsole } from "../../fakes/fake-console";
+import { FakeFileManager } from "../../fakes/fake-file-manager";
+
+const goPlugin = { id: "go" } as LanguagePlugin;
+const tsPlugin = { id: "typescript" } as LanguagePlugin;
+
+function makeCtx(overrides?: Partial<InitContext>): InitContext {
+  const config = buildResolvedConfig(
+    MachineConfigSchema.parse({}),
+    ProjectConfigSchema.parse({})
+  );
+  return {
+    projectDir: "/project",
+    fileManager: new FakeFileManager(),
+    commandRunner: new FakeCommandRunner(),
+    console: new FakeConsole(),
+    config,
+    languages: [],
+    selections: new Map(),
+    isTTY: false,
+    createReadline: () => ({
+      question: (_q: string, cb: (a: string) => void) => cb(""),
+      close: () => {},
+    }),
+    flags: {},
+    ...overrides,
+  };
+}
+
+describe("golangciConfigModule", () => {
+  test("detect returns true when Go is detected", async () => {
+    const ctx = makeCtx({ languages: [goPlugin] });
+    expect(await golangciConfigModule.detect(ctx)).toBe(true);
+  });
+
+  test("detect returns false when Go is not detected", async () => {
+    const ctx = makeCtx({ languages: [tsPlugin] });
+    expect(await golangciConfigModule.detect(ctx)).toBe(false);
+  });
+
+  test("detect returns false for empty languages", async () => {
+    const ctx = makeCtx({ languages: [] });
+    expect(await golangciConfigModule.detect(ctx)).toBe(false);
+  });
+
+  test("execute writes nothing without --force or --upgrade", async () => {
+    const fm = new FakeFileManager();
+    const ctx = makeCtx({ fileManager: fm, languages: [goPlugin] });
+
+    const result = await golangciConfigModule.execute(ctx);
+
+    expect(result.status).toBe("skipped");
+    expect(fm.written).toHaveLength(0);
+  });
+
+  test("execute writes .golangci.yml with --upgrade", async () => {
+    const fm = new FakeFileManager();
+    const ctx = makeCtx({
+      fileManager: fm,
+      languages: [goPlugin],
+      flags: { upgrade: true },
+    });
+
+    const result = await golangciConfigModule.execute(ctx);
+
+    expect(result.status).toBe("ok");
+    const written = fm.written.find(([p]) => p.endsWith(".golangci.yml"));
+    expect(written).toBeDefined();
+    expect(written?.[1]).toContain("linters:");
+    expect(written?.[1]).toContain("    - errcheck");
+  });
+
+  test("execute honours the project's own config, even with --force", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.golangci.yaml", "existing content");
+    const ctx = makeCtx({
+      fileManager: fm,
+      languages: [goPlugin],
+      flags: { force: true },
+    });
+
+    const result = await golangciConfigModule.execute(ctx);
+
+    expect(result.status).toBe("skipped");
+    expect(result.message).toContain(".golangci.yaml");
+    expect(fm.written).toHaveLength(0);
+  });
+
+  test("execute refreshes a config it generated when force is true", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.golangci.yml", withHashHeader("linters: {}\n"));
+    const ctx = makeCtx({
+      fileManager: fm,
+      languages: [goPlugin],
+      flags: { force: true },
+    });
+
+    const result = await golangciConfigModule.execute(ctx);
+
+    expect(result.status).toBe("ok");
+  });
+});
@@ -0,0 +1,146 @@
+import { describe, expect, test } from "bun:test";
+import {
+  buildResolvedConfig,
+  MachineConfigSchema,
+  ProjectConfigSchema,
+} from "@/config/schema";
+import { hadolintConfigModule } from "@/init/modules/hadolint-config";
+import type { InitContext } from "@/init/types";
+import type { LanguagePlugin } from "@/languages/types";
+import { FakeCommandRunner } from "../../fakes/fake-command-runner";
+import { FakeConsole } from "../../fakes/fake-console";
+import { FakeFileManager } from "../../fakes/fake-file-manager";
+
+const dockerPlugin = { id: "docker" } as LanguagePlugin;
+const tsPlugin = { id: "typescript" } as LanguagePlugin;
+
+function makeCtx(overrides?: Partial<InitContext>): InitContext {
+  const config = buildResolvedConfig(
+    MachineConfigSchema.parse({}),
+    ProjectConfigSchema.parse({})
+  );
+  return {
+    projectDir: "/project",
+    fileManager: new FakeFileManager(),
+    commandRunner: new FakeCommandRunner(),
+    console: new FakeConsole(),
+    config,
+    languages: [],
+    selections: new Map(),
+    isTTY: false,
+    createReadline: () => ({
+      question: (_q: string, cb: (a: string) => void) => cb(""),
+      close: () => {},
+    }),
+    flags: {},
+    ...overrides,
+  };
+}
+
+describe("hadolintConfigModule", () => {
+  test("detect returns true when Docker is detected", async () => {
+    const ctx = makeCtx({ languages: [dockerPlugin] });
+    expect(await hadolintConfigModule.detect(ctx)).toBe(true);
+  });
+
+  test("detect returns false when Docker is not detected", async () => {
+    const ctx = makeCtx({ languages: [tsPlugin] });
+    expect(await hadolintConfigModule.detect(ctx)).toBe(false);
+  });
+
+  test("execute writes nothing without --force or --upgrade", async () => {
+    const fm = new FakeFileManager();
+    const ctx = makeCtx({ fileManager: fm, languages: [dockerPlugin] });
+
+    const result = await hadolintConfigModule.execute(ctx);
+
+    expect(result.status).toBe("skipped");
+    expect(fm.written).toHaveLength(0);
+  });
+
+  test("execute writes .hadolint.yaml with our header on --force", async () => {
+    const fm = new FakeFileManager();
+    const ctx = makeCtx({
+      fileManager: fm,
+      languages: [dockerPlugin],
+      flags: { force: true },
+    });
+
+    const result = await hadolintConfigModule.execute(ctx);
+
+    expect(result.status).toBe("ok");
+    const written = fm.written.find(([p]) => p.endsWith(".hadolint.yaml"));
+    expect(written?.[1]).toMatch(/^# ai-guardrails:sha256=/);
+    expect(written?.[1]).toContain("failure-threshold: warning");
+  });
+
+  test("execute keeps a hand-written .hadolint.yaml even with force", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.hadolint.yaml", "ignored: [DL3008]\n");
+    const ctx = makeCtx({
+      fileManager: fm,
+      languages: [dockerPlugin],
+      flags: { force: true },
+    });
+
+    const result = await hadolintConfigModule.execute(ctx);
+
+    expect(result.status).toBe("skipped");
+    expect(fm.written).toHaveLength(0);
+  });
+
+  test("execute honours a .hadolint.yml, the other name hadolint reads", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.hadolint.yml", "ignored: [DL3008]\n");
+    const ctx = makeCtx({
+      fileManager: fm,
+      languages: [dockerPlugin],
+      flags: { force: true },
+    });
+
+    const result = await hadolintConfigModule.execute(ctx);
+
+    expect(result).toMatchObject({
+      status: "skipped",
+      message: "Using existing .hadolint.yml",
+    });
+    expect(fm.written).toHaveLength(0);
+  });
+
+  test("execute reports an unreadable .hadolint.yaml as an error", async () => {
+    /** Fails every read, as on a permission error */
+    class UnreadableFileManager extends FakeFileManager {
+      override async readText(_path: string): Promise<string> {
+        throw new Error("EACCES: permission denied");
+      }
+    }
+    const fm = new UnreadableFileManager();
+    fm.seed("/project/.hadolint.yaml", "ignored: []\n");
+    const ctx = makeCtx({
+      fileManager: fm,
+      languages: [dockerPlugin],
+      flags: { force: true },
+    });
+
+    const result = await hadolintConfigModule.execute(ctx);
+
+    expect(result.status).toBe("error");
+    expect(result.message).toContain("EACCES");
+    expect(fm.written).toHaveLength(0);
+  });
+
+  test("execute refreshes a .hadolint.yaml it generated on --upgrade", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.hadolint.yaml", "# ai-guardrails:sha256=stale\nignored: []\n");
+    const ctx = makeCtx({
+      fileManager: fm,
+      languages: [dockerPlugin],
+      flags: { upgrade: true },
+    });
+
+    const result = await hadolintConfigModule.execute(ctx);
+
+    expect(result.status).toBe("ok");
+    expect(fm.written).toHaveLength(1);
+  });
+});
@@ -0,0 +1,119 @@
+import { describe, expect, test } from "bun:test";
+import { parse as parseToml } from "smol-toml";
+import { YAML_SCHEMA_DIRECTIVE } from "@/config/json-schema";
+import {
+  buildResolvedConfig,
+  MachineConfigSchema,
+  ProjectConfigSchema,
+} from "@/config/schema";
+import {
+  profileFromFlags,
+  profileSelectionModule,
+} from "@/init/modules/profile-selection";
+import type { InitContext } from "@/init/types";
+import { PROJECT_CONFIG_PATH } from "@/models/paths";
+import { FakeCommandRunner } from "../../fakes/fake-command-runner";
+import { FakeConsole } from "../../fakes/fake-console";
+import { FakeFileManager } from "../../fakes/fake-file-manager";
+
+function makeCtx(overrides?: Partial<InitContext>): InitContext {
+  const config = buildResolvedConfig(
+    MachineConfigSchema.parse({}),
+    ProjectConfigSchema.parse({})
+  );
+  return {
+    projectDir: "/project",
+    fileManager: new FakeFileManager(),
+    commandRunner: new FakeCommandRunner(),
+    console: new FakeConsole(),
+    config,
+    languages: [],
+    selections: new Map(),
+    isTTY: false,
+    createReadline: () => ({
+      question: (_q: string, cb: (a: string) => void) => cb(""),
+      close: () => {},
+    }),
+    flags: {},
+    ...overrides,
+  };
+}
+
+const CONFIG_PATH = `/project/${PROJECT_CONFIG_PATH}`;
+
+async function writtenProfile(fm: FakeFileManager): Promise<unknown> {
+  const parsed = parseToml(await fm.readText(CONFIG_PATH));
+  return parsed.profile;
+}
+
+describe("profileFromFlags", () => {
+  test("returns the named profile", () => {
+    expect(profileFromFlags({ profile: "strict" })).toBe("strict");
+    expect(profileFromFlags({ profile: "lenient" })).toBe("lenient");
+  });
+
+  test("reads the old name minimal as lenient", () => {
+    expect(profileFromFlags({ profile: "minimal" })).toBe("lenient");
+  });
+
+  test("returns undefined without the flag and null for an unknown name", () => {
+    expect(profileFromFlags({})).toBeUndefined();
+    expect(profileFromFlags({ profile: "extreme" })).toBeNull();
+  });
+});
+
+describe("profileSelectionModule.execute", () => {
+  test("writes the --profile flag to config.toml", async () => {
+    const fm = new FakeFileManager();
+
+    const result = await profileSelectionModule.execute(
+      makeCtx({ fileManager: fm, flags: { profile: "strict" } })
+    );
+
+    expect(result.status).toBe("ok");
+    expect(await writtenProfile(fm)).toBe("strict");
+  });
+
+  test("keeps the loaded profile on --upgrade without the flag", async () => {
+    const fm = new FakeFileManager();
+    fm.seed(CONFIG_PATH, 'profile = "lenient"\n');
+    const config = buildResolvedConfig(
+      MachineConfigSchema.parse({}),
+      ProjectConfigSchema.parse({ profile: "lenient" })
+    );
+
+    await profileSelectionModule.execute(
+      makeCtx({ fileManager: fm, config, flags: { upgrade: true } })
+    );
+
+    expect(await writtenProfile(fm)).toBe("lenient");
+  });
+
+  test("writes config.yaml with --config-format yaml", async () => {
+    const fm = new FakeFileManager();
+
+    const result = await profileSelectionModule.execute(
+      makeCtx({ fileManager: fm, flags: { profile: "strict", configFormat: "yaml" } })
+    );
+
+    expect(result.filesCreated).toEqual([".ai-guardrails/config.yaml"]);
+    const text = await fm.readText("/project/.ai-guardrails/config.yaml");
+    expect(text).toStartWith(YAML_SCHEMA_DIRECTIVE);
+    expect(Bun.YAML.parse(text)).toEqual({ profile: "strict" });
+    expect(await fm.exists(CONFIG_PATH)).toBe(false);
+  });
+
+  test("keeps an existing YAML config's format on --upgrade", async () => {
+    const fm = new FakeFileManager();
+    const yaml = "profile: lenient\nmin_version: 1.0.0\n";
+    fm.seed("/project/.ai-guardrails/config.yaml", yaml);
+
+    await profileSelectionModule.execute(
+      makeCtx({ fileManager: fm, flags: { profile: "strict", upgrade: true } })
+    );
+
+    const text = await fm.readText("/project/.ai-guardrails/config.yaml");
+    expect(Bun.YAML.parse(text)).toEqual({ profile: "strict", min_version: "1.0.0" });
+    expect(await fm.exists(CONFIG_PATH)).toBe(false);
+  });
+});
@@ -0,0 +1,92 @@
+import { describe, expect, test } from "bun:test";
+import {
+  buildResolvedConfig,
+  MachineConfigSchema,
+  ProjectConfigSchema,
+} from "@/config/schema";
+import { rubocopConfigModule } from "@/init/modules/rubocop-config";
+import type { InitContext } from "@/init/types";
+import type { LanguagePlugin } from "@/languages/types";
+import { FakeCommandRunner } from "../../fakes/fake-command-runner";
+import { FakeConsole } from "../../fakes/fake-console";
+import { FakeFileManager } from "../../fakes/fake-file-manager";
+
+const rubyPlugin = { id: "ruby" } as LanguagePlugin;
+const tsPlugin = { id: "typescript" } as LanguagePlugin;
+
+function makeCtx(overrides?: Partial<InitContext>): InitContext {
+  const config = buildResolvedConfig(
+    MachineConfigSchema.parse({}),
+    ProjectConfigSchema.parse({})
+  );
+  return {
+    projectDir: "/project",
+    fileManager: new FakeFileManager(),
+    commandRunner: new FakeCommandRunner(),
+    console: new FakeConsole(),
+    config,
+    languages: [],
+    selections: new Map(),
+    isTTY: false,
+    createReadline: () => ({
+      question: (_q: string, cb: (a: string) => void) => cb(""),
+      close: () => {},
+    }),
+    flags: {},
+    ...overrides,
+  };
+}
+
+describe("rubocopConfigModule", () => {
+  test("detect returns true when Ruby is detected", async () => {
+    const ctx = makeCtx({ languages: [rubyPlugin] });
+    expect(await rubocopConfigModule.detect(ctx)).toBe(true);
+  });
+
+  test("detect returns false when Ruby is not detected", async () => {
+    const ctx = makeCtx({ languages: [tsPlugin] });
+    expect(await rubocopConfigModule.detect(ctx)).toBe(false);
+  });
+
+  test("detect returns false for empty languages", async () => {
+    const ctx = makeCtx({ languages: [] });
+    expect(await rubocopConfigModule.detect(ctx)).toBe(false);
+  });
+
+  test("execute writes .rubocop.yml", async () => {
+    const fm = new FakeFileManager();
+    const ctx = makeCtx({ fileManager: fm, languages: [rubyPlugin] });
+
+    const result = await rubocopConfigModule.execute(ctx);
+
+    expect(result.status).toBe("ok");
+    const written = fm.written.find(([p]) => p.endsWith(".rubocop.yml"));
+    expect(written).toBeDefined();
+    expect(written?.[1]).toContain("NewCops: enable");
+    expect(written?.[1]).toMatch(/^# ai-guardrails:sha256=/);
+  });
+
+  test("execute skips when file exists and force is false", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.rubocop.yml", "existing content");
+    const ctx = makeCtx({ fileManager: fm, languages: [rubyPlugin] });
+
+    const result = await rubocopConfigModule.execute(ctx);
+
+    expect(result.status).toBe("skipped");
+  });
+
+  test("execute overwrites when force is true", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.rubocop.yml", "existing content");
+    const ctx = makeCtx({
+      fileManager: fm,
+      languages: [rubyPlugin],
+      flags: { force: true },
+    });
+
+    const result = await rubocopConfigModule.execute(ctx);
+
+    expect(result.status).toBe("ok");
+  });
+});
@@ -0,0 +1,132 @@
+import { describe, expect, test } from "bun:test";
+import {
+  buildResolvedConfig,
+  MachineConfigSchema,
+  ProjectConfigSchema,
+} from "@/config/schema";
+import { ruffConfigModule } from "@/init/modules/ruff-config";
+import type { InitContext } from "@/init/types";
+import type { LanguagePlugin } from "@/languages/types";
+import { computeHash, HASH_PREFIX } from "@/utils/hash";
+import { FakeCommandRunner } from "../../fakes/fake-command-runner";
+import { FakeConsole } from "../../fakes/fake-console";
+import { FakeFileManager } from "../../fakes/fake-file-manager";
+
+const pythonPlugin = { id: "python" } as LanguagePlugin;
+const USER_RUFF = 'line-length = 120\n[lint]\nselect = ["E"]\n';
+
+function makeCtx(overrides?: Partial<InitContext>): InitContext {
+  const config = buildResolvedConfig(
+    MachineConfigSchema.parse({}),
+    ProjectConfigSchema.parse({})
+  );
+  return {
+    projectDir: "/project",
+    fileManager: new FakeFileManager(),
+    commandRunner: new FakeCommandRunner(),
+    console: new FakeConsole(),
+    config,
+    languages: [pythonPlugin],
+    selections: new Map(),
+    isTTY: false,
+    createReadline: () => ({
+      question: (_q: string, cb: (a: string) => void) => cb(""),
+      close: () => {},
+    }),
+    flags: {},
+    ...overrides,
+  };
+}
+
+describe("ruffConfigModule", () => {
+  test("execute skips a user-owned ruff.toml without --merge", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/ruff.toml", USER_RUFF);
+
+    const result = await ruffConfigModule.execute(makeCtx({ fileManager: fm }));
+
+    expect(result.status).toBe("skipped");
+    expect(fm.written).toHaveLength(0);
+  });
+
+  test("execute merges into a user-owned ruff.toml with --merge", async () => {
+    const fm = new FakeFileManager();
+    const cons = new FakeConsole();
+    fm.seed("/project/ruff.toml", USER_RUFF);
+
+    const result = await ruffConfigModule.execute(
+      makeCtx({ fileManager: fm, console: cons, flags: { merge: true } })
+    );
+
+    expect(result.status).toBe("ok");
+    expect(result.message).toBe("ruff.toml merged");
+    const written = fm.written.find(([p]) => p === "/project/ruff.toml")?.[1] ?? "";
+    expect(written).toMatch(/^# ai-guardrails:sha256=/);
+    expect(written).toContain("line-length = 120");
+    expect(written).toContain("extend-select");
+    expect(cons.warnings.some((w) => w.includes("ruff.toml is user-owned"))).toBe(true);
+  });
+
+  test("--upgrade implies merge", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/ruff.toml", USER_RUFF);
+
+    const result = await ruffConfigModule.execute(
+      makeCtx({ fileManager: fm, flags: { upgrade: true } })
+    );
+
+    expect(result.status).toBe("ok");
+    expect(result.message).toBe("ruff.toml merged");
+  });
+
+  test("merging a managed file does not warn", async () => {
+    const fm = new FakeFileManager();
+    const cons = new FakeConsole();
+    fm.seed("/project/ruff.toml", `# ai-guardrails:sha256=abc\n${USER_RUFF}`);
+
+    await ruffConfigModule.execute(
+      makeCtx({ fileManager: fm, console: cons, flags: { merge: true } })
+    );
+
+    expect(cons.warnings).toEqual([]);
+  });
+
+  test("--upgrade regenerates an untouched file from an older template", async () => {
+    const fm = new FakeFileManager();
+    const legacy = `${HASH_PREFIX}${computeHash(USER_RUFF)}\n${USER_RUFF}`;
+    fm.seed("/project/ruff.toml", legacy);
+
+    const result = await ruffConfigModule.execute(
+      makeCtx({ fileManager: fm, flags: { upgrade: true } })
+    );
+
+    expect(result.message).toBe("ruff.toml written");
+    const written = fm.written.find(([p]) => p === "/project/ruff.toml")?.[1] ?? "";
+    expect(written).toMatch(/^# ai-guardrails:sha256=[0-9a-f]{64};template=v\d+\n/);
+    expect(written).not.toContain("line-length = 120");
+  });
+
+  test("--upgrade still merges an edited file from an older template", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/ruff.toml", `${HASH_PREFIX}${computeHash("")}\n${USER_RUFF}`);
+
+    const result = await ruffConfigModule.execute(
+      makeCtx({ fileManager: fm, flags: { upgrade: true } })
+    );
+
+    expect(result.message).toBe("ruff.toml merged");
+  });
+
+  test("--force replaces instead of merging", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/ruff.toml", USER_RUFF);
+
+    const result = await ruffConfigModule.execute(
+      makeCtx({ fileManager: fm, flags: { force: true, upgrade: true } })
+    );
+
+    expect(result.message).toBe("ruff.toml written");
+    const written = fm.written.find(([p]) => p === "/project/ruff.toml")?.[1] ?? "";
+    expect(written).not.toContain("line-length = 120");
+  });
+});
@@ -0,0 +1,118 @@
+import { describe, expect, test } from "bun:test";
+import {
+  buildResolvedConfig,
+  MachineConfigSchema,
+  ProjectConfigSchema,
+} from "@/config/schema";
+import { clippyConfigModule } from "@/init/modules/clippy-config";
+import { rustfmtConfigModule } from "@/init/modules/rustfmt-config";
+import type { InitContext } from "@/init/types";
+import type { LanguagePlugin } from "@/languages/types";
+import { FakeCommandRunner } from "../../fakes/fake-command-runner";
+import { FakeConsole } from "../../fakes/fake-console";
+import { FakeFileManager } from "../../fakes/fake-file-manager";
+
+const rustPlugin = { id: "rust" } as LanguagePlugin;
+const goPlugin = { id: "go" } as LanguagePlugin;
+
+function makeCtx(overrides?: Partial<InitContext>): InitContext {
+  const config = buildResolvedConfig(
+    MachineConfigSchema.parse({}),
+    ProjectConfigSchema.parse({})
+  );
+  return {
+    projectDir: "/project",
+    fileManager: new FakeFileManager(),
+    commandRunner: new FakeCommandRunner(),
+    console: new FakeConsole(),
+    config,
+    languages: [],
+    selections: new Map(),
+    isTTY: false,
+    createReadline: () => ({
+      question: (_q: string, cb: (a: string) => void) => cb(""),
+      close: () => {},
+    }),
+    flags: {},
+    ...overrides,
+  };
+}
+
+const MODULES = [
+  { module: rustfmtConfigModule, file: "rustfmt.toml", marker: "max_width = 88" },
+  {
+    module: clippyConfigModule,
+    file: "clippy.toml",
+    marker: "cognitive-complexity-threshold",
+  },
+] as const;
+
+for (const { module, file, marker } of MODULES) {
+  describe(module.id, () => {
+    test("detect returns true when Rust is detected", async () => {
+      const ctx = makeCtx({ languages: [rustPlugin] });
+      expect(await module.detect(ctx)).toBe(true);
+    });
+
+    test("detect returns false when Rust is not detected", async () => {
+      const ctx = makeCtx({ languages: [goPlugin] });
+      expect(await module.detect(ctx)).toBe(false);
+    });
+
+    test("execute writes nothing without --force or --upgrade", async () => {
+      const fm = new FakeFileManager();
+      const ctx = makeCtx({ fileManager: fm, languages: [rustPlugin] });
+
+      const result = await module.execute(ctx);
+
+      expect(result.status).toBe("skipped");
+      expect(result.message).toContain("--force");
+      expect(fm.written).toHaveLength(0);
+    });
+
+    test(`execute writes ${file} with --force`, async () => {
+      const fm = new FakeFileManager();
+      const ctx = makeCtx({
+        fileManager: fm,
+        languages: [rustPlugin],
+        flags: { force: true },
+      });
+
+      const result = await module.execute(ctx);
+
+      expect(result.status).toBe("ok");
+      const written = fm.written.find(([p]) => p === `/project/${file}`);
+      expect(written?.[1]).toMatch(/^# ai-guardrails:sha256=/);
+      expect(written?.[1]).toContain(marker);
+    });
+
+    test("execute skips a user-owned file on --upgrade", async () => {
+      const fm = new FakeFileManager();
+      fm.seed(`/project/${file}`, "existing content");
+      const ctx = makeCtx({
+        fileManager: fm,
+        languages: [rustPlugin],
+        flags: { upgrade: true },
+      });
+
+      const result = await module.execute(ctx);
+
+      expect(result.status).toBe("skipped");
+      expect(fm.written).toHaveLength(0);
+    });
+
+    test("execute overwrites a user-owned file when force is true", async () => {
+      const fm = new FakeFileManager();
+      fm.seed(`/project/${file}`, "existing content");
+      const ctx = makeCtx({
+        fileManager: fm,
+        languages: [rustPlugin],
+        flags: { force: true },
+      });
+
+      const result = await module.execute(ctx);
+
+      expect(result.status).toBe("ok");
+    });
+  });
+}
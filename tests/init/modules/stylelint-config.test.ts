@@ -0,0 +1,105 @@
+import { describe, expect, test } from "bun:test";
+import {
+  buildResolvedConfig,
+  MachineConfigSchema,
+  ProjectConfigSchema,
+} from "@/config/schema";
+import { stylelintGenerator } from "@/generators/stylelint";
+import { stylelintConfigModule } from "@/init/modules/stylelint-config";
+import type { InitContext } from "@/init/types";
+import type { LanguagePlugin } from "@/languages/types";
+import { FakeCommandRunner } from "../../fakes/fake-command-runner";
+import { FakeConsole } from "../../fakes/fake-console";
+import { FakeFileManager } from "../../fakes/fake-file-manager";
+
+const cssPlugin = { id: "css" } as LanguagePlugin;
+const tsPlugin = { id: "typescript" } as LanguagePlugin;
+
+function makeCtx(overrides?: Partial<InitContext>): InitContext {
+  const config = buildResolvedConfig(
+    MachineConfigSchema.parse({}),
+    ProjectConfigSchema.parse({})
+  );
+  return {
+    projectDir: "/project",
+    fileManager: new FakeFileManager(),
+    commandRunner: new FakeCommandRunner(),
+    console: new FakeConsole(),
+    config,
+    languages: [],
+    selections: new Map(),
+    isTTY: false,
+    createReadline: () => ({
+      question: (_q: string, cb: (a: string) => void) => cb(""),
+      close: () => {},
+    }),
+    flags: {},
+    ...overrides,
+  };
+}
+
+describe("stylelintConfigModule", () => {
+  test("detect returns true when CSS is detected", async () => {
+    const ctx = makeCtx({ languages: [cssPlugin] });
+    expect(await stylelintConfigModule.detect(ctx)).toBe(true);
+  });
+
+  test("detect returns false when CSS is not detected", async () => {
+    const ctx = makeCtx({ languages: [tsPlugin] });
+    expect(await stylelintConfigModule.detect(ctx)).toBe(false);
+  });
+
+  test("execute writes .stylelintrc.yaml", async () => {
+    const fm = new FakeFileManager();
+    const ctx = makeCtx({ fileManager: fm, languages: [cssPlugin] });
+
+    const result = await stylelintConfigModule.execute(ctx);
+
+    expect(result.status).toBe("ok");
+    const written = fm.written.find(([p]) => p.endsWith(".stylelintrc.yaml"));
+    expect(written?.[1]).toMatch(/^# ai-guardrails:sha256=/);
+  });
+
+  test("execute regenerates its own .stylelintrc.yaml", async () => {
+    const fm = new FakeFileManager();
+    const ctx = makeCtx({ fileManager: fm, languages: [cssPlugin] });
+    fm.seed("/project/.stylelintrc.yaml", stylelintGenerator.generate(ctx.config));
+
+    const result = await stylelintConfigModule.execute(ctx);
+
+    expect(result.status).toBe("ok");
+  });
+
+  test("execute keeps any existing config of the user's", async () => {
+    for (const [path, content] of [
+      ["/project/.stylelintrc.json", "{}"],
+      ["/project/.stylelintrc.yaml", "rules: {}"],
+      ["/project/package.json", '{"stylelint":{"rules":{}}}'],
+    ] as const) {
+      const fm = new FakeFileManager();
+      fm.seed(path, content);
+      const ctx = makeCtx({ fileManager: fm, languages: [cssPlugin] });
+
+      const result = await stylelintConfigModule.execute(ctx);
+
+      expect(result.status).toBe("skipped");
+      expect(fm.written).toHaveLength(0);
+    }
+  });
+
+  test("execute with force writes ours and names the config shadowing it", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.stylelintrc.json", "{}");
+    const ctx = makeCtx({
+      fileManager: fm,
+      languages: [cssPlugin],
+      flags: { force: true },
+    });
+
+    const result = await stylelintConfigModule.execute(ctx);
+
+    expect(result.status).toBe("ok");
+    expect(result.message).toContain("remove .stylelintrc.json");
+    expect(fm.written.some(([p]) => p.endsWith(".stylelintrc.yaml"))).toBe(true);
+  });
+});
@@ -0,0 +1,110 @@
+import { describe, expect, test } from "bun:test";
+import {
+  buildResolvedConfig,
+  MachineConfigSchema,
+  ProjectConfigSchema,
+} from "@/config/schema";
+import { yamllintConfigModule } from "@/init/modules/yamllint-config";
+import type { InitContext } from "@/init/types";
+import type { LanguagePlugin } from "@/languages/types";
+import { FakeCommandRunner } from "../../fakes/fake-command-runner";
+import { FakeConsole } from "../../fakes/fake-console";
+import { FakeFileManager } from "../../fakes/fake-file-manager";
+
+const yamlPlugin = { id: "yaml" } as LanguagePlugin;
+const tsPlugin = { id: "typescript" } as LanguagePlugin;
+
+function makeCtx(overrides?: Partial<InitContext>): InitContext {
+  const config = buildResolvedConfig(
+    MachineConfigSchema.parse({}),
+    ProjectConfigSchema.parse({})
+  );
+  return {
+    projectDir: "/project",
+    fileManager: new FakeFileManager(),
+    commandRunner: new FakeCommandRunner(),
+    console: new FakeConsole(),
+    config,
+    languages: [],
+    selections: new Map(),
+    isTTY: false,
+    createReadline: () => ({
+      question: (_q: string, cb: (a: string) => void) => cb(""),
+      close: () => {},
+    }),
+    flags: {},
+    ...overrides,
+  };
+}
+
+describe("yamllintConfigModule", () => {
+  test("detect returns true when YAML is detected", async () => {
+    const ctx = makeCtx({ languages: [yamlPlugin] });
+    expect(await yamllintConfigModule.detect(ctx)).toBe(true);
+  });
+
+  test("detect returns false when YAML is not detected", async () => {
+    const ctx = makeCtx({ languages: [tsPlugin] });
+    expect(await yamllintConfigModule.detect(ctx)).toBe(false);
+  });
+
+  test("detect returns false for empty languages", async () => {
+    const ctx = makeCtx({ languages: [] });
+    expect(await yamllintConfigModule.detect(ctx)).toBe(false);
+  });
+
+  test("execute writes nothing without --force or --upgrade", async () => {
+    const fm = new FakeFileManager();
+    const ctx = makeCtx({ fileManager: fm, languages: [yamlPlugin] });
+
+    const result = await yamllintConfigModule.execute(ctx);
+
+    expect(result.status).toBe("skipped");
+    expect(fm.written).toHaveLength(0);
+  });
+
+  test("execute writes .yamllint.yaml with --force", async () => {
+    const fm = new FakeFileManager();
+    const ctx = makeCtx({
+      fileManager: fm,
+      languages: [yamlPlugin],
+      flags: { force: true },
+    });
+
+    const result = await yamllintConfigModule.execute(ctx);
+
+    expect(result.status).toBe("ok");
+    const written = fm.written.find(([p]) => p.endsWith(".yamllint.yaml"));
+    expect(written).toBeDefined();
+    expect(written?.[1]).toContain("extends: default");
+    expect(written?.[1]).toContain("document-start: disable");
+  });
+
+  test("execute skips a user-owned file on --upgrade", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.yamllint.yaml", "existing content");
+    const ctx = makeCtx({
+      fileManager: fm,
+      languages: [yamlPlugin],
+      flags: { upgrade: true },
+    });
+
+    const result = await yamllintConfigModule.execute(ctx);
+
+    expect(result.status).toBe("skipped");
+  });
+
+  test("execute overwrites when force is true", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.yamllint.yaml", "existing content");
+    const ctx = makeCtx({
+      fileManager: fm,
+      languages: [yamlPlugin],
+      flags: { force: true },
+    });
+
+    const result = await yamllintConfigModule.execute(ctx);
+
+    expect(result.status).toBe("ok");
+  });
+});
@@ -0,0 +1,83 @@
+import { describe, expect, test } from "bun:test";
+import { DryRunFileManager } from "@/infra/dry-run-file-manager";
+import { formatInitPlan, planFileChanges } from "@/init/plan";
+import { HASH_PREFIX } from "@/utils/hash";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const GENERATED = `${HASH_PREFIX}${"0".repeat(64)}\nline-length = 88\n`;
+
+async function plannedDryRun() {
+  const disk = new FakeFileManager();
+  disk.seed("/project/ruff.toml", GENERATED);
+  disk.seed("/project/.claude/settings.json", '{"a": 1}\n');
+  disk.seed("/project/.editorconfig", "root = true\n");
+  disk.seed("/project/old.yml", `${HASH_PREFIX}${"0".repeat(64)}\n`);
+  const fm = new DryRunFileManager(disk);
+  const header = GENERATED.split("\n")[0];
+  await fm.writeText("/project/ruff.toml", `${header}\nline-length = 100\n`);
+  await fm.writeText("/project/.claude/settings.json", '{"a": 1, "b": 2}\n');
+  await fm.writeText("/project/.editorconfig", "root = true\n");
+  await fm.writeText("/project/lefthook.yml", "pre-commit:\n");
+  await fm.delete("/project/old.yml");
+  return planFileChanges(fm, disk, "/project");
+}
+
+describe("planFileChanges", () => {
+  test("classifies each recorded change against the file on disk", async () => {
+    const planned = await plannedDryRun();
+
+    expect(planned.map((p) => [p.path, p.change])).toEqual([
+      ["ruff.toml", "update"],
+      [".claude/settings.json", "modify"],
+      [".editorconfig", "unchanged"],
+      ["lefthook.yml", "create"],
+      ["old.yml", "remove"],
+    ]);
+    expect(planned[0]?.diff).toContain("-line-length = 88");
+    expect(planned[0]?.diff).toContain("+line-length = 100");
+    expect(planned[3]?.diff).toEqual([]);
+  });
+
+  test("ignores deleting a file that never existed", async () => {
+    const disk = new FakeFileManager();
+    const fm = new DryRunFileManager(disk);
+    await fm.delete("/project/missing.yml");
+
+    expect(await planFileChanges(fm, disk, "/project")).toEqual([]);
+  });
+});
+
+describe("formatInitPlan", () => {
+  test("marks generated, user-owned, stale and skipped files, then diffs", async () => {
+    const planned = await plannedDryRun();
+    const skipped = [
+      {
+        name: "Rubocop Config",
+        result: {
+          status: "skipped" as const,
+          message: ".rubocop.yml exists and is not managed by ai-guardrails",
+        },
+      },
+    ];
+
+    const lines = formatInitPlan(planned, skipped);
+
+    expect(lines.slice(0, 7)).toEqual([
+      "Dry run — nothing was written. init would:",
+      "  update     ruff.toml (generated)",
+      "  modify     .claude/settings.json (user-owned)",
+      "  unchanged  .editorconfig",
+      "  create     lefthook.yml",
+      "  remove     old.yml (stale)",
+      "  skip       [Rubocop Config] .rubocop.yml exists and is not managed by ai-guardrails",
+    ]);
+    expect(lines).toContain("--- ruff.toml");
+    expect(lines).toContain("--- .claude/settings.json");
+  });
+
+  test("says so when nothing would change", () => {
+    expect(formatInitPlan([], [])).toEqual([
+      "Dry run — nothing was written. init would change no files.",
+    ]);
+  });
+});
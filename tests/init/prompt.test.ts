@@ -51,7 +51,7 @@ describe("askYesNo", () => {
 });
 
 describe("askChoice", () => {
-  const choices = ["strict", "standard", "minimal"] as const;
+  const choices = ["strict", "standard", "lenient"] as const;
 
   test("returns valid choice", async () => {
     const result = await askChoice(
@@ -70,12 +70,12 @@ describe("askChoice", () => {
 
   test("retries on invalid input then accepts valid", async () => {
     const result = await askChoice(
-      fakeReadline(["bad", "minimal"]),
+      fakeReadline(["bad", "lenient"]),
       "Profile?",
       choices,
       "standard"
     );
-    expect(result).toBe("minimal");
+    expect(result).toBe("lenient");
   });
 });
 
@@ -262,3 +262,29 @@ describe("executeModules — console output", () => {
     expect(cons.infos.some((s) => s.includes("already configured"))).toBe(true);
   });
 });
+
+describe("executeModules — onResult", () => {
+  test("reports each module that ran, with its module", async () => {
+    const skipped: InitModuleResult = { status: "skipped", message: "file exists" };
+    const modules = [
+      makeModule("a"),
+      makeModule("b", { executeResult: skipped }),
+      makeModule("c"),
+    ];
+    const selections = new Map([
+      ["a", true],
+      ["b", true],
+      ["c", false],
+    ]);
+    const seen: Array<[string, string]> = [];
+
+    await executeModules(modules, makeCtx({ selections }), (mod, result) => {
+      seen.push([mod.id, result.status]);
+    });
+
+    expect(seen).toEqual([
+      ["a", "ok"],
+      ["b", "skipped"],
+    ]);
+  });
+});
@@ -0,0 +1,41 @@
+import { describe, expect, test } from "bun:test";
+import {
+  checkExitCode,
+  EXIT_CLEAN,
+  EXIT_FINDINGS,
+  EXIT_MISSING_TOOL,
+  EXIT_RUNNER_ERROR,
+} from "@/models/exit-code";
+import type { RunnerReport } from "@/models/runner-report";
+
+function report(overrides: Partial<RunnerReport> = {}): RunnerReport {
+  return { runnerId: "ruff", name: "Ruff", status: "ok", durationMs: 0, ...overrides };
+}
+
+describe("checkExitCode", () => {
+  test("is clean without failing findings or failed runners", () => {
+    const runners = [report(), report({ status: "skipped" })];
+    expect(checkExitCode(0, runners)).toBe(EXIT_CLEAN);
+  });
+
+  test("reports findings", () => {
+    expect(checkExitCode(3, [report()])).toBe(EXIT_FINDINGS);
+  });
+
+  test("a failed runner outranks findings", () => {
+    const failed = report({ runnerId: "pyright", status: "error" });
+    expect(checkExitCode(3, [report(), failed])).toBe(EXIT_RUNNER_ERROR);
+  });
+
+  test("a missing required tool outranks a failed runner", () => {
+    const runners = [
+      report({ status: "error", message: "crashed" }),
+      report({ runnerId: "pyright", status: "error", missing: true }),
+    ];
+    expect(checkExitCode(0, runners)).toBe(EXIT_MISSING_TOOL);
+  });
+
+  test("cancelled runners count for nothing", () => {
+    expect(checkExitCode(1, [report({ status: "cancelled" })])).toBe(EXIT_FINDINGS);
+  });
+});
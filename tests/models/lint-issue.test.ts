@@ -1,5 +1,5 @@
 import { describe, expect, test } from "bun:test";
-import { computeFingerprint } from "@/models/lint-issue";
+import { computeFingerprint, meetsSeverity } from "@/models/lint-issue";
 
 describe("computeFingerprint", () => {
   test("same input produces same hash", () => {
@@ -128,3 +128,16 @@ describe("computeFingerprint", () => {
     expect(fpAlice).not.toBe(fpBob);
   });
 });
+
+describe("meetsSeverity", () => {
+  test("a severity meets itself and every less severe threshold", () => {
+    expect(meetsSeverity("error", "error")).toBe(true);
+    expect(meetsSeverity("error", "info")).toBe(true);
+    expect(meetsSeverity("warning", "warning")).toBe(true);
+  });
+
+  test("a less severe finding does not meet a stricter threshold", () => {
+    expect(meetsSeverity("warning", "error")).toBe(false);
+    expect(meetsSeverity("info", "warning")).toBe(false);
+  });
+});
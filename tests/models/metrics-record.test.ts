@@ -0,0 +1,129 @@
+import { describe, expect, test } from "bun:test";
+import type { LintIssue } from "@/models/lint-issue";
+import {
+  appendMetricsRecord,
+  buildMetricsRecord,
+  type MetricsInput,
+  readHeadCommit,
+} from "@/models/metrics-record";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+function makeIssue(overrides: Partial<LintIssue> = {}): LintIssue {
+  return {
+    rule: "ruff/E501",
+    linter: "ruff",
+    file: "/project/src/a.py",
+    line: 1,
+    col: 1,
+    message: "Line too long",
+    severity: "error",
+    fingerprint: "fp-1",
+    ...overrides,
+  };
+}
+
+function makeInput(overrides: Partial<MetricsInput> = {}): MetricsInput {
+  return {
+    timestamp: "2026-10-14T09:00:00.000Z",
+    commit: "0123abc",
+    durationMs: 1500,
+    filesScanned: 42,
+    passed: false,
+    issues: [
+      makeIssue(),
+      makeIssue({ fingerprint: "fp-2", severity: "warning" }),
+      makeIssue({ linter: "pyright", rule: "pyright/x", fingerprint: "fp-3" }),
+    ],
+    baselined: new Set(["fp-2"]),
+    runners: [
+      { runnerId: "ruff", name: "Ruff", status: "ok", durationMs: 200, cached: true },
+      { runnerId: "pyright", name: "Pyright", status: "ok", durationMs: 1200 },
+      { runnerId: "codespell", name: "codespell", status: "skipped", durationMs: 3 },
+    ],
+    ...overrides,
+  };
+}
+
+describe("buildMetricsRecord", () => {
+  test("counts findings by severity, overall and per runner", () => {
+    const record = buildMetricsRecord(makeInput());
+    expect(record).toEqual({
+      schemaVersion: 1,
+      timestamp: "2026-10-14T09:00:00.000Z",
+      commit: "0123abc",
+      durationMs: 1500,
+      filesScanned: 42,
+      passed: false,
+      findings: { error: 2, warning: 1, info: 0 },
+      newFindings: { error: 2, warning: 0, info: 0 },
+      runners: [
+        {
+          id: "ruff",
+          status: "ok",
+          durationMs: 200,
+          cached: true,
+          findings: { error: 1, warning: 1, info: 0 },
+          newFindings: { error: 1, warning: 0, info: 0 },
+        },
+        {
+          id: "pyright",
+          status: "ok",
+          durationMs: 1200,
+          cached: false,
+          findings: { error: 1, warning: 0, info: 0 },
+          newFindings: { error: 1, warning: 0, info: 0 },
+        },
+        {
+          id: "codespell",
+          status: "skipped",
+          durationMs: 3,
+          cached: false,
+          findings: { error: 0, warning: 0, info: 0 },
+          newFindings: { error: 0, warning: 0, info: 0 },
+        },
+      ],
+    });
+  });
+
+  test("reports zero counts for a clean run", () => {
+    const record = buildMetricsRecord(makeInput({ issues: [], passed: true }));
+    expect(record.findings).toEqual({ error: 0, warning: 0, info: 0 });
+    expect(record.passed).toBe(true);
+  });
+});
+
+describe("readHeadCommit", () => {
+  test("returns the HEAD commit", async () => {
+    const cr = new FakeCommandRunner();
+    cr.register(["git", "rev-parse", "HEAD"], {
+      stdout: "0123abc\n",
+      stderr: "",
+      exitCode: 0,
+    });
+    expect(await readHeadCommit("/project", cr)).toBe("0123abc");
+  });
+
+  test("returns null outside a git repository", async () => {
+    const cr = new FakeCommandRunner();
+    cr.register(["git", "rev-parse", "HEAD"], {
+      stdout: "",
+      stderr: "fatal: not a git repository",
+      exitCode: 128,
+    });
+    expect(await readHeadCommit("/project", cr)).toBeNull();
+  });
+});
+
+describe("appendMetricsRecord", () => {
+  test("appends one JSON line per record", async () => {
+    const fm = new FakeFileManager();
+    const record = buildMetricsRecord(makeInput());
+    await appendMetricsRecord("/project/metrics.jsonl", record, fm);
+    await appendMetricsRecord("/project/metrics.jsonl", record, fm);
+    const lines = (await fm.readText("/project/metrics.jsonl")).split("\n");
+    expect(lines).toHaveLength(3);
+    expect(lines[2]).toBe("");
+    expect(JSON.parse(lines[1] ?? "")).toEqual(record);
+  });
+});
@@ -0,0 +1,174 @@
+import { describe, expect, test } from "bun:test";
+import type { LintIssue } from "@/models/lint-issue";
+import { BASELINE_PATH } from "@/models/paths";
+import {
+  changedFiles,
+  computeManifestKey,
+  loadRunManifest,
+  RUN_MANIFEST_PATH,
+  type RunManifest,
+  saveRunManifest,
+  scanProjectFiles,
+} from "@/models/run-manifest";
+import type { LinterRunner } from "@/runners/types";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const PROJECT_DIR = "/project";
+
+function makeRunner(overrides: Partial<LinterRunner> = {}): LinterRunner {
+  return {
+    id: "ruff",
+    name: "Ruff",
+    configFile: "ruff.toml",
+    installHint: { description: "Python linter" },
+    versionArgs: ["ruff", "--version"],
+    async isAvailable() {
+      return true;
+    },
+    async run(): Promise<LintIssue[]> {
+      return [];
+    },
+    ...overrides,
+  };
+}
+
+function setup() {
+  const fm = new FakeFileManager();
+  fm.seed("/project/src/a.py", "x = 1\n");
+  fm.seed("/project/src/b.py", "y = 2\n");
+  fm.seed("/project/ruff.toml", "line-length = 88\n");
+  const cr = new FakeCommandRunner();
+  cr.register(["ruff", "--version"], {
+    stdout: "ruff 0.8.0\n",
+    stderr: "",
+    exitCode: 0,
+  });
+  const key = (runners = [makeRunner()]) =>
+    computeManifestKey(runners, PROJECT_DIR, BASELINE_PATH, cr, fm);
+  const scan = (previous: RunManifest | null = null) =>
+    scanProjectFiles(PROJECT_DIR, previous, [], null, fm);
+  return { fm, cr, key, scan };
+}
+
+describe("computeManifestKey", () => {
+  test("is stable when nothing changed", async () => {
+    const { key } = setup();
+    expect(await key()).toBe(await key());
+  });
+
+  test("ignores changes to project files", async () => {
+    const { fm, key } = setup();
+    const before = await key();
+    fm.seed("/project/src/a.py", "x = 2\n");
+    expect(await key()).toBe(before);
+  });
+
+  test("changes when a tool version changes", async () => {
+    const { cr, key } = setup();
+    const before = await key();
+    cr.register(["ruff", "--version"], {
+      stdout: "ruff 0.9.0\n",
+      stderr: "",
+      exitCode: 0,
+    });
+    expect(await key()).not.toBe(before);
+  });
+
+  test("changes when the runner set changes", async () => {
+    const { key } = setup();
+    const before = await key();
+    const extra = makeRunner({ id: "pyright", configFile: null, versionArgs: [] });
+    expect(await key([makeRunner(), extra])).not.toBe(before);
+  });
+
+  test("changes when a runner config, nested config or baseline changes", async () => {
+    const { fm, key } = setup();
+    const edits = [
+      ["/project/ruff.toml", "line-length = 120\n"],
+      ["/project/api/.ai-guardrails/config.toml", 'profile = "strict"\n'],
+      ["/project/.ai-guardrails/baseline.json", "[]"],
+    ] as const;
+    for (const [path, content] of edits) {
+      const before = await key();
+      fm.seed(path, content);
+      expect(await key()).not.toBe(before);
+    }
+  });
+});
+
+describe("scanProjectFiles", () => {
+  test("records every project file outside ignored paths", async () => {
+    const { fm } = setup();
+    fm.seed("/project/node_modules/pkg/index.js", "");
+    fm.seed("/project/build/out.py", "");
+    fm.seed("/project/.ai-guardrails/config.toml", "");
+    fm.seed("/project/tmp/scratch.py", "");
+    const files = await scanProjectFiles(
+      PROJECT_DIR,
+      null,
+      ["build/**"],
+      (path) => path.startsWith("tmp/"),
+      fm
+    );
+    expect(Object.keys(files)).toEqual(["ruff.toml", "src/a.py", "src/b.py"]);
+  });
+
+  test("keeps the stored hash when mtime and size are unchanged", async () => {
+    const { scan } = setup();
+    const first = await scan();
+    const entry = first["src/a.py"];
+    if (entry === undefined) throw new Error("src/a.py not scanned");
+    const files = { ...first, "src/a.py": { ...entry, hash: "stored" } };
+    const second = await scan({ key: "k", files });
+    expect(second["src/a.py"]?.hash).toBe("stored");
+  });
+
+  test("re-hashes a file whose mtime moved", async () => {
+    const { fm, scan } = setup();
+    const first = await scan();
+    fm.seed("/project/src/a.py", "x = 1\n");
+    const second = await scan({ key: "k", files: first });
+    expect(second["src/a.py"]?.mtimeMs).not.toBe(first["src/a.py"]?.mtimeMs);
+    expect(second["src/a.py"]?.hash).toBe(first["src/a.py"]?.hash ?? "");
+  });
+});
+
+describe("changedFiles", () => {
+  test("lists new files and files whose content changed", async () => {
+    const { fm, scan } = setup();
+    const previous = { key: "k", files: await scan() };
+    fm.seed("/project/src/a.py", "x = 3\n");
+    fm.seed("/project/src/b.py", "y = 2\n"); // touched, same content
+    fm.seed("/project/src/c.py", "z = 4\n");
+    expect(changedFiles(previous, await scan(previous))).toEqual([
+      "src/a.py",
+      "src/c.py",
+    ]);
+  });
+
+  test("is empty when nothing changed", async () => {
+    const { scan } = setup();
+    const previous = { key: "k", files: await scan() };
+    expect(changedFiles(previous, await scan(previous))).toEqual([]);
+  });
+});
+
+describe("loadRunManifest / saveRunManifest", () => {
+  test("round-trips a manifest", async () => {
+    const { fm, scan } = setup();
+    const manifest = { key: "k", files: await scan() };
+    await saveRunManifest(PROJECT_DIR, manifest, fm);
+    expect(await loadRunManifest(PROJECT_DIR, fm)).toEqual(manifest);
+  });
+
+  test("returns null without a manifest", async () => {
+    expect(await loadRunManifest(PROJECT_DIR, new FakeFileManager())).toBeNull();
+  });
+
+  test("returns null for a malformed manifest", async () => {
+    const fm = new FakeFileManager();
+    fm.seed(`${PROJECT_DIR}/${RUN_MANIFEST_PATH}`, '{"files":[]}');
+    expect(await loadRunManifest(PROJECT_DIR, fm)).toBeNull();
+  });
+});
@@ -0,0 +1,201 @@
+import { describe, expect, test } from "bun:test";
+import type { ResolvedConfig } from "@/config/schema";
+import type { LintIssue } from "@/models/lint-issue";
+import {
+  clearRunnerCache,
+  computeCacheKey,
+  loadCachedIssues,
+  saveCachedIssues,
+} from "@/models/runner-cache";
+import type { LinterRunner } from "@/runners/types";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const PROJECT_DIR = "/project";
+
+function makeConfig(overrides: Partial<ResolvedConfig> = {}): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 88, indent_width: 4 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+function makeRunner(overrides: Partial<LinterRunner> = {}): LinterRunner {
+  return {
+    id: "ruff",
+    name: "Ruff",
+    configFile: "ruff.toml",
+    installHint: { description: "Python linter" },
+    versionArgs: ["ruff", "--version"],
+    cache: { inputs: ["**/*.py"] },
+    async isAvailable() {
+      return true;
+    },
+    async run(): Promise<LintIssue[]> {
+      return [];
+    },
+    ...overrides,
+  };
+}
+
+function makeIssue(): LintIssue {
+  return {
+    rule: "ruff/E501",
+    linter: "ruff",
+    file: "/project/src/a.py",
+    line: 1,
+    col: 1,
+    message: "Line too long",
+    severity: "error",
+    fingerprint: "fp-1",
+  };
+}
+
+function setup() {
+  const fm = new FakeFileManager();
+  fm.seed("/project/src/a.py", "x = 1\n");
+  fm.seed("/project/ruff.toml", "line-length = 88\n");
+  const cr = new FakeCommandRunner();
+  cr.register(["ruff", "--version"], {
+    stdout: "ruff 0.8.0\n",
+    stderr: "",
+    exitCode: 0,
+  });
+  const key = () => computeCacheKey(makeRunner(), PROJECT_DIR, makeConfig(), cr, fm);
+  return { fm, cr, key };
+}
+
+describe("computeCacheKey", () => {
+  test("is stable when nothing changed", async () => {
+    const { key } = setup();
+    expect(await key()).toBe(await key());
+  });
+
+  test("changes when an input file changes", async () => {
+    const { fm, key } = setup();
+    const before = await key();
+    fm.seed("/project/src/a.py", "x = 2\n");
+    expect(await key()).not.toBe(before);
+  });
+
+  test("changes when the runner config file changes", async () => {
+    const { fm, key } = setup();
+    const before = await key();
+    fm.seed("/project/ruff.toml", "line-length = 120\n");
+    expect(await key()).not.toBe(before);
+  });
+
+  test("changes when the resolved config changes", async () => {
+    const { fm, cr, key } = setup();
+    const strict = makeConfig({ profile: "strict" });
+    expect(await computeCacheKey(makeRunner(), PROJECT_DIR, strict, cr, fm)).not.toBe(
+      await key()
+    );
+  });
+
+  test("changes when a layer outside the project config ignores a rule", async () => {
+    const { fm, cr, key } = setup();
+    const globalRules = makeConfig({ ignoredRules: new Set(["ruff/E501"]) });
+    expect(
+      await computeCacheKey(makeRunner(), PROJECT_DIR, globalRules, cr, fm)
+    ).not.toBe(await key());
+  });
+
+  test("changes when the tool version changes", async () => {
+    const { cr, key } = setup();
+    const before = await key();
+    cr.register(["ruff", "--version"], {
+      stdout: "ruff 0.9.0\n",
+      stderr: "",
+      exitCode: 0,
+    });
+    expect(await key()).not.toBe(before);
+  });
+
+  test("ignores files outside the runner's inputs", async () => {
+    const { fm, key } = setup();
+    const before = await key();
+    fm.seed("/project/docs/readme.md", "# hi\n");
+    expect(await key()).toBe(before);
+  });
+
+  test("returns null for runners without a cache spec", async () => {
+    const { fm, cr } = setup();
+    const { cache: _cache, ...uncacheable } = makeRunner();
+    expect(
+      await computeCacheKey(uncacheable, PROJECT_DIR, makeConfig(), cr, fm)
+    ).toBeNull();
+  });
+
+  test("returns null when the version command fails", async () => {
+    const { fm, cr } = setup();
+    cr.register(["ruff", "--version"], { stdout: "", stderr: "", exitCode: 127 });
+    expect(
+      await computeCacheKey(makeRunner(), PROJECT_DIR, makeConfig(), cr, fm)
+    ).toBeNull();
+  });
+
+  test("keys an in-process check without running a version command", async () => {
+    const { fm } = setup();
+    const cr = new FakeCommandRunner();
+    const { versionArgs: _versionArgs, ...builtIn } = makeRunner({
+      cache: { inputs: ["**/*.py"], inProcess: true },
+    });
+
+    const key = await computeCacheKey(builtIn, PROJECT_DIR, makeConfig(), cr, fm);
+
+    expect(key).not.toBeNull();
+    expect(cr.calls).toEqual([]);
+  });
+});
+
+describe("loadCachedIssues / saveCachedIssues", () => {
+  test("round-trips issues under a matching key", async () => {
+    const fm = new FakeFileManager();
+    await saveCachedIssues(PROJECT_DIR, "ruff", "k1", [makeIssue()], fm);
+
+    const issues = await loadCachedIssues(PROJECT_DIR, "ruff", "k1", fm);
+    expect(issues).toEqual([makeIssue()]);
+  });
+
+  test("keeps the module of per-module issues", async () => {
+    const fm = new FakeFileManager();
+    const issue = { ...makeIssue(), module: "services/api" };
+    await saveCachedIssues(PROJECT_DIR, "ruff", "k1", [issue], fm);
+
+    expect(await loadCachedIssues(PROJECT_DIR, "ruff", "k1", fm)).toEqual([issue]);
+  });
+
+  test("misses when the key differs", async () => {
+    const fm = new FakeFileManager();
+    await saveCachedIssues(PROJECT_DIR, "ruff", "k1", [makeIssue()], fm);
+
+    expect(await loadCachedIssues(PROJECT_DIR, "ruff", "k2", fm)).toBeNull();
+  });
+
+  test("misses when the entry is corrupt", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.ai-guardrails/cache/ruff.json", "{not json");
+
+    expect(await loadCachedIssues(PROJECT_DIR, "ruff", "k1", fm)).toBeNull();
+  });
+});
+
+describe("clearRunnerCache", () => {
+  test("deletes every cache entry and returns the count", async () => {
+    const fm = new FakeFileManager();
+    await saveCachedIssues(PROJECT_DIR, "ruff", "k1", [], fm);
+    await saveCachedIssues(PROJECT_DIR, "shellcheck", "k2", [], fm);
+
+    expect(await clearRunnerCache(PROJECT_DIR, fm)).toBe(2);
+    expect(fm.deleted).toHaveLength(2);
+    expect(await loadCachedIssues(PROJECT_DIR, "ruff", "k1", fm)).toBeNull();
+  });
+});
@@ -0,0 +1,48 @@
+import { afterAll, describe, expect, test } from "bun:test";
+import { readFile, rm } from "node:fs/promises";
+import { join, resolve } from "node:path";
+import pkg from "../package.json";
+import { buildLibrary, rewriteAliases } from "../scripts/build-lib";
+
+const ROOT = resolve(import.meta.dir, "..");
+// Inside the project so the bundle's external dependencies resolve
+const OUTDIR = join(ROOT, "node_modules", ".cache", "ai-guardrails-lib-test");
+
+afterAll(async () => {
+  await rm(OUTDIR, { recursive: true, force: true });
+});
+
+describe("package entry", () => {
+  test("points main, types and exports at the shipped library build", () => {
+    const entry = pkg.exports["."];
+    expect(entry.import).toBe("./dist/lib/api.js");
+    expect(entry.types).toBe("./dist/lib/types/api.d.ts");
+    expect(pkg.main).toBe(entry.import);
+    expect(pkg.types).toBe(entry.types);
+    expect(pkg.files).toContain("dist/lib");
+  });
+
+  test("the built entry exports the API, with declarations", async () => {
+    await buildLibrary(OUTDIR);
+
+    const api = await import(join(OUTDIR, "api.js"));
+    expect(typeof api.createGuardrails).toBe("function");
+    expect(api.EXIT_USAGE).toBe(3);
+
+    const types = await readFile(join(OUTDIR, "types", "api.d.ts"), "utf8");
+    expect(types).toContain("createGuardrails");
+    expect(types).not.toContain('"@/');
+  }, 60_000);
+});
+
+describe("rewriteAliases", () => {
+  test("turns @/ specifiers into relative .js ones", () => {
+    const source = 'import type { Console } from "@/infra/console";';
+    expect(rewriteAliases(source, "/out/types/steps", "/out/types")).toBe(
+      'import type { Console } from "../infra/console.js";'
+    );
+    expect(rewriteAliases('export * from "@/models/exit-code";', "/t", "/t")).toBe(
+      'export * from "./models/exit-code.js";'
+    );
+  });
+});
@@ -0,0 +1,178 @@
+import { describe, expect, test } from "bun:test";
+import { resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import {
+  actionlintRunner,
+  findWorkflows,
+  parseActionlintOutput,
+} from "@/runners/actionlint";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const FIXTURE_PATH = resolve(import.meta.dir, "../fixtures/actionlint-output.json");
+const PROJECT_DIR = "/project";
+
+const FIXTURE_JSON = await Bun.file(FIXTURE_PATH).text();
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+function seedWorkflows(): FakeFileManager {
+  const fm = new FakeFileManager();
+  fm.seed("/project/.github/workflows/ci.yml", "on: push\n");
+  fm.seed("/project/.github/workflows/release.yaml", "on: release\n");
+  fm.seed("/project/deploy/values.yaml", "replicas: 2\n");
+  return fm;
+}
+
+describe("parseActionlintOutput", () => {
+  test("returns one issue per error, with the kind as the rule", () => {
+    const issues = parseActionlintOutput(FIXTURE_JSON, PROJECT_DIR);
+    expect(issues).toHaveLength(3);
+    expect(issues[0]).toEqual({
+      rule: "actionlint/expression",
+      linter: "actionlint",
+      file: "/project/.github/workflows/ci.yml",
+      line: 14,
+      col: 20,
+      message:
+        '"github.event.pull_request.title" is potentially untrusted. avoid using it directly in inline scripts. instead, pass it through an environment variable',
+      severity: "error",
+    });
+    expect(issues.map((i) => i.rule)).toEqual([
+      "actionlint/expression",
+      "actionlint/shellcheck",
+      "actionlint/runner-label",
+    ]);
+    expect(issues[2]?.file).toBe("/project/.github/workflows/release.yaml");
+  });
+
+  test("returns [] for an empty array", () => {
+    expect(parseActionlintOutput("[]", PROJECT_DIR)).toEqual([]);
+  });
+
+  test("returns [] for malformed JSON", () => {
+    expect(parseActionlintOutput("not valid json", PROJECT_DIR)).toEqual([]);
+    expect(parseActionlintOutput('[{"message":"x"}]', PROJECT_DIR)).toEqual([]);
+  });
+});
+
+describe("findWorkflows", () => {
+  test("globs .yml and .yaml workflows only", async () => {
+    expect(await findWorkflows(seedWorkflows(), PROJECT_DIR, [])).toEqual([
+      ".github/workflows/ci.yml",
+      ".github/workflows/release.yaml",
+    ]);
+  });
+
+  test("selects workflows from a changed-file list", async () => {
+    const files = await findWorkflows(new FakeFileManager(), PROJECT_DIR, [], [
+      ".github/workflows/ci.yml",
+      "deploy/values.yaml",
+    ]);
+    expect(files).toEqual([".github/workflows/ci.yml"]);
+  });
+});
+
+describe("actionlintRunner.isAvailable", () => {
+  test("returns true when actionlint runs", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["actionlint", "-version"], {
+      stdout: "1.7.1",
+      stderr: "",
+      exitCode: 0,
+    });
+    expect(await actionlintRunner.isAvailable(runner)).toBe(true);
+  });
+
+  test("returns false when actionlint is not installed", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["actionlint", "-version"], {
+      stdout: "",
+      stderr: "command not found",
+      exitCode: 127,
+    });
+    expect(await actionlintRunner.isAvailable(runner)).toBe(false);
+  });
+});
+
+describe("actionlintRunner.run", () => {
+  const lintArgs = [
+    "actionlint",
+    "-format",
+    "{{json .}}",
+    ".github/workflows/ci.yml",
+    ".github/workflows/release.yaml",
+  ];
+
+  test("returns [] without running when there are no workflows", async () => {
+    const runner = new FakeCommandRunner();
+    const issues = await actionlintRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    });
+    expect(issues).toEqual([]);
+    expect(runner.calls).toEqual([]);
+  });
+
+  test("lints every workflow and fingerprints the findings", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(lintArgs, { stdout: FIXTURE_JSON, stderr: "", exitCode: 1 });
+
+    const issues = await actionlintRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: seedWorkflows(),
+    });
+
+    expect(runner.calls).toEqual([lintArgs]);
+    expect(issues).toHaveLength(3);
+    expect(issues.every((i) => typeof i.fingerprint === "string")).toBe(true);
+  });
+
+  test("passes only changed workflows", async () => {
+    const runner = new FakeCommandRunner();
+    await actionlintRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: seedWorkflows(),
+      files: [".github/workflows/release.yaml", "README.md"],
+    });
+    expect(runner.calls).toEqual([
+      ["actionlint", "-format", "{{json .}}", ".github/workflows/release.yaml"],
+    ]);
+  });
+
+  test("throws when actionlint itself fails", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(lintArgs, {
+      stdout: "",
+      stderr: "could not parse config file .github/actionlint.yaml",
+      exitCode: 3,
+    });
+
+    await expect(
+      actionlintRunner.run({
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: runner,
+        fileManager: seedWorkflows(),
+      })
+    ).rejects.toThrow("actionlint failed: could not parse config file");
+  });
+});
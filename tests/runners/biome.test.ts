@@ -208,6 +208,27 @@ describe("biomeRunner.run", () => {
 
     expect(issues).toHaveLength(3);
   });
+
+  test("leaves formatting to prettier in a Prettier project", async () => {
+    const runner = new FakeCommandRunner();
+    const fm = new FakeFileManager();
+    fm.seed(`${PROJECT_DIR}/.prettierrc`, "{}");
+
+    await biomeRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(runner.calls).toContainEqual([
+      LOCAL_BIOME_PROJECT,
+      "ci",
+      "--reporter=rdjson",
+      "--formatter-enabled=false",
+      PROJECT_DIR,
+    ]);
+  });
 });
 
 const LOCAL_BIOME_CWD = "node_modules/.bin/biome";
@@ -0,0 +1,225 @@
+import { describe, expect, test } from "bun:test";
+import { resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import {
+  bufBreakingRunner,
+  bufRunner,
+  findProtoFiles,
+  hasBufConfig,
+  parseBufOutput,
+} from "@/runners/buf";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const FIXTURE_PATH = resolve(import.meta.dir, "../fixtures/buf-lint-output.ndjson");
+const PROJECT_DIR = "/project";
+
+const FIXTURE_NDJSON = await Bun.file(FIXTURE_PATH).text();
+
+function makeConfig(values: Partial<ResolvedConfig["values"]> = {}): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2, ...values },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+  };
+}
+
+function seedProtos(): FakeFileManager {
+  const fm = new FakeFileManager();
+  fm.seed("/project/buf.yaml", "version: v2\n");
+  fm.seed("/project/api/v1/orders.proto", 'syntax = "proto3";\n');
+  fm.seed("/project/api/v1/users.proto", 'syntax = "proto3";\n');
+  fm.seed("/project/README.md", "# API\n");
+  return fm;
+}
+
+describe("parseBufOutput", () => {
+  test("returns one issue per annotation, with the type as the rule", () => {
+    const issues = parseBufOutput(FIXTURE_NDJSON, PROJECT_DIR);
+    expect(issues).toHaveLength(3);
+    expect(issues[0]).toEqual({
+      rule: "buf/FIELD_LOWER_SNAKE_CASE",
+      linter: "buf",
+      file: "/project/api/v1/orders.proto",
+      line: 7,
+      col: 3,
+      message: 'Field name "orderId" should be lower_snake_case, such as "order_id".',
+      severity: "error",
+    });
+  });
+
+  test("reports a finding without a position at 1:1", () => {
+    const issue = parseBufOutput(FIXTURE_NDJSON, PROJECT_DIR)[2];
+    expect(issue?.rule).toBe("buf/PACKAGE_DEFINED");
+    expect([issue?.line, issue?.col]).toEqual([1, 1]);
+  });
+
+  test("prefixes rules with the given linter", () => {
+    const stdout =
+      '{"path":"a.proto","start_line":3,"start_column":1,"type":"FIELD_NO_DELETE","message":"Previously present field \\"2\\" was deleted."}\n';
+    const [issue] = parseBufOutput(stdout, PROJECT_DIR, "buf-breaking");
+    expect(issue?.rule).toBe("buf-breaking/FIELD_NO_DELETE");
+    expect(issue?.linter).toBe("buf-breaking");
+  });
+
+  test("skips lines that are not annotations", () => {
+    expect(parseBufOutput("not json\n{}\n", PROJECT_DIR)).toEqual([]);
+  });
+});
+
+describe("findProtoFiles", () => {
+  test("globs .proto files", async () => {
+    expect(await findProtoFiles(seedProtos(), PROJECT_DIR, [])).toEqual([
+      "api/v1/orders.proto",
+      "api/v1/users.proto",
+    ]);
+  });
+
+  test("selects protos from a changed-file list", async () => {
+    const files = await findProtoFiles(new FakeFileManager(), PROJECT_DIR, [], [
+      "api/v1/orders.proto",
+      "README.md",
+    ]);
+    expect(files).toEqual(["api/v1/orders.proto"]);
+  });
+});
+
+describe("hasBufConfig", () => {
+  test("finds buf.yaml or buf.work.yaml in the root", async () => {
+    expect(await hasBufConfig(new FakeFileManager(), PROJECT_DIR)).toBe(false);
+    const fm = new FakeFileManager();
+    fm.seed("/project/buf.work.yaml", "version: v1\n");
+    expect(await hasBufConfig(fm, PROJECT_DIR)).toBe(true);
+  });
+});
+
+describe("bufRunner.isAvailable", () => {
+  test("returns false when buf is not installed", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["buf", "--version"], {
+      stdout: "",
+      stderr: "command not found",
+      exitCode: 127,
+    });
+    expect(await bufRunner.isAvailable(runner)).toBe(false);
+  });
+});
+
+describe("bufRunner.run", () => {
+  const lintArgs = ["buf", "lint", "--error-format=json"];
+
+  test("returns [] without running when there are no protos", async () => {
+    const runner = new FakeCommandRunner();
+    const issues = await bufRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    });
+    expect(issues).toEqual([]);
+    expect(runner.calls).toEqual([]);
+  });
+
+  test("lints the module and fingerprints the findings", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(lintArgs, { stdout: FIXTURE_NDJSON, stderr: "", exitCode: 100 });
+
+    const issues = await bufRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: seedProtos(),
+    });
+
+    expect(runner.calls).toEqual([lintArgs]);
+    expect(issues).toHaveLength(3);
+    expect(issues.every((i) => typeof i.fingerprint === "string")).toBe(true);
+  });
+
+  test("narrows the lint to changed protos with --path", async () => {
+    const runner = new FakeCommandRunner();
+    await bufRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: seedProtos(),
+      files: ["api/v1/users.proto", "README.md"],
+    });
+    expect(runner.calls).toEqual([
+      ["buf", "lint", "--path", "api/v1/users.proto", "--error-format=json"],
+    ]);
+  });
+
+  test("throws when buf itself fails", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(lintArgs, {
+      stdout: "",
+      stderr: "Failure: decode buf.yaml: invalid version",
+      exitCode: 1,
+    });
+
+    await expect(
+      bufRunner.run({
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: runner,
+        fileManager: seedProtos(),
+      })
+    ).rejects.toThrow("buf failed: Failure: decode buf.yaml: invalid version");
+  });
+});
+
+describe("bufBreakingRunner", () => {
+  const against = ".git#branch=main";
+
+  test("applies only with [config] buf_breaking_against", async () => {
+    const appliesWith = (config: ResolvedConfig) =>
+      bufBreakingRunner.appliesTo?.({
+        projectDir: PROJECT_DIR,
+        config,
+        commandRunner: new FakeCommandRunner(),
+        fileManager: seedProtos(),
+      });
+    expect(await appliesWith(makeConfig())).toBe(false);
+    expect(await appliesWith(makeConfig({ buf_breaking_against: against }))).toBe(true);
+  });
+
+  test("compares against the configured input", async () => {
+    const runner = new FakeCommandRunner();
+    const args = ["buf", "breaking", "--against", against, "--error-format=json"];
+    runner.register(args, {
+      stdout:
+        '{"path":"api/v1/orders.proto","start_line":4,"start_column":1,"type":"FIELD_NO_DELETE","message":"Previously present field \\"2\\" with name \\"total\\" on message \\"Order\\" was deleted."}\n',
+      stderr: "",
+      exitCode: 100,
+    });
+
+    const issues = await bufBreakingRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig({ buf_breaking_against: against }),
+      commandRunner: runner,
+      fileManager: seedProtos(),
+    });
+
+    expect(runner.calls).toEqual([args]);
+    expect(issues.map((i) => [i.rule, i.line])).toEqual([
+      ["buf-breaking/FIELD_NO_DELETE", 4],
+    ]);
+  });
+
+  test("throws without a baseline to compare against", async () => {
+    await expect(
+      bufBreakingRunner.run({
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: new FakeCommandRunner(),
+        fileManager: seedProtos(),
+      })
+    ).rejects.toThrow("buf-breaking needs [config] buf_breaking_against");
+  });
+});
@@ -0,0 +1,131 @@
+import { describe, expect, test } from "bun:test";
+import { resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import { clangFormatRunner, parseClangFormatOutput } from "@/runners/clang-format";
+import type { RunOptions } from "@/runners/types";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const FIXTURE_PATH = resolve(import.meta.dir, "../fixtures/clang-format-output.txt");
+const PROJECT_DIR = "/project";
+
+const FIXTURE_TEXT = await Bun.file(FIXTURE_PATH).text();
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+function makeOpts(runner: FakeCommandRunner, fm: FakeFileManager): RunOptions {
+  return {
+    projectDir: PROJECT_DIR,
+    config: makeConfig(),
+    commandRunner: runner,
+    fileManager: fm,
+  };
+}
+
+describe("parseClangFormatOutput", () => {
+  test("returns one issue per violation, skipping source and caret lines", () => {
+    const issues = parseClangFormatOutput(FIXTURE_TEXT, PROJECT_DIR);
+
+    expect(issues.map((i) => [i.file, i.line, i.col])).toEqual([
+      ["/project/src/main.cpp", 3, 11],
+      ["/project/src/main.cpp", 7, 3],
+      ["/project/include/util.h", 2, 15],
+    ]);
+  });
+
+  test("maps fields correctly", () => {
+    const first = parseClangFormatOutput(FIXTURE_TEXT, PROJECT_DIR)[0];
+    expect(first).toBeDefined();
+    if (!first) return;
+    expect(first.rule).toBe("clang-format/format");
+    expect(first.linter).toBe("clang-format");
+    expect(first.severity).toBe("error");
+    expect(first.message).toBe(
+      "Code is not clang-formatted — run: clang-format -i src/main.cpp"
+    );
+  });
+
+  test("returns [] for empty input", () => {
+    expect(parseClangFormatOutput("", PROJECT_DIR)).toEqual([]);
+  });
+});
+
+describe("clangFormatRunner.run", () => {
+  test("checks every C/C++ file with --dry-run --Werror", async () => {
+    const runner = new FakeCommandRunner();
+    const fm = new FakeFileManager();
+    fm.seed("src/main.cpp", "int main(){}");
+    fm.seed("include/util.h", "int add(int a,int b);");
+    fm.seed("README.md", "# demo");
+    runner.register(
+      ["clang-format", "--dry-run", "--Werror", "src/main.cpp", "include/util.h"],
+      { stdout: "", stderr: FIXTURE_TEXT, exitCode: 1 }
+    );
+
+    const issues = await clangFormatRunner.run(makeOpts(runner, fm));
+
+    expect(issues).toHaveLength(3);
+    expect(issues[0]?.fingerprint).toBeTruthy();
+  });
+
+  test("checks only changed C/C++ files", async () => {
+    const runner = new FakeCommandRunner();
+
+    await clangFormatRunner.run({
+      ...makeOpts(runner, new FakeFileManager()),
+      files: ["src/engine.cc", "README.md"],
+    });
+
+    expect(runner.calls).toEqual([
+      ["clang-format", "--dry-run", "--Werror", "src/engine.cc"],
+    ]);
+  });
+
+  test("returns [] without running when there are no C/C++ files", async () => {
+    const runner = new FakeCommandRunner();
+
+    const issues = await clangFormatRunner.run(makeOpts(runner, new FakeFileManager()));
+
+    expect(issues).toEqual([]);
+    expect(runner.calls).toHaveLength(0);
+  });
+
+  test("throws when clang-format fails without reporting violations", async () => {
+    const runner = new FakeCommandRunner();
+    const fm = new FakeFileManager();
+    fm.seed("src/main.cpp", "int main() {}");
+    runner.register(["clang-format", "--dry-run", "--Werror", "src/main.cpp"], {
+      stdout: "",
+      stderr: "Error reading /project/.clang-format: Invalid argument",
+      exitCode: 1,
+    });
+
+    await expect(clangFormatRunner.run(makeOpts(runner, fm))).rejects.toThrow(
+      "clang-format failed: Error reading /project/.clang-format"
+    );
+  });
+});
+
+describe("clangFormatRunner.fix", () => {
+  test("formats the files in place with -i", async () => {
+    const runner = new FakeCommandRunner();
+    const fm = new FakeFileManager();
+    fm.seed("src/main.cpp", "int main(){}");
+
+    await clangFormatRunner.fix?.(makeOpts(runner, fm));
+
+    expect(runner.calls).toEqual([["clang-format", "-i", "src/main.cpp"]]);
+  });
+});
@@ -1,7 +1,11 @@
 import { describe, expect, test } from "bun:test";
 import { resolve } from "node:path";
 import type { ResolvedConfig } from "@/config/schema";
-import { clangTidyRunner, parseClangTidyOutput } from "@/runners/clang-tidy";
+import {
+  clangTidyRunner,
+  findCompileDb,
+  parseClangTidyOutput,
+} from "@/runners/clang-tidy";
 import { FakeCommandRunner } from "../fakes/fake-command-runner";
 import { FakeFileManager } from "../fakes/fake-file-manager";
 
@@ -182,3 +186,49 @@ describe("clangTidyRunner.isAvailable", () => {
     expect(result).toBe(false);
   });
 });
+
+describe("clangTidyRunner compilation database", () => {
+  test("applies only when compile_commands.json exists", async () => {
+    const appliesTo = (fileManager: FakeFileManager) =>
+      clangTidyRunner.appliesTo?.({
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: new FakeCommandRunner(),
+        fileManager,
+      });
+    const withDb = new FakeFileManager();
+    withDb.seed(`${PROJECT_DIR}/build/compile_commands.json`, "[]");
+
+    expect(await appliesTo(withDb)).toBe(true);
+    expect(await appliesTo(new FakeFileManager())).toBe(false);
+  });
+
+  test("finds the database at the root before build/", async () => {
+    const fm = new FakeFileManager();
+    fm.seed(`${PROJECT_DIR}/compile_commands.json`, "[]");
+    fm.seed(`${PROJECT_DIR}/build/compile_commands.json`, "[]");
+
+    expect(await findCompileDb(PROJECT_DIR, fm)).toBe(".");
+  });
+
+  test("passes the database directory with -p", async () => {
+    const runner = new FakeCommandRunner();
+    const fm = new FakeFileManager();
+    fm.seed(`${PROJECT_DIR}/build/compile_commands.json`, "[]");
+    fm.seed("src/main.cpp", "int main() {}");
+    runner.register(["clang-tidy", "--quiet", "-p", "build", "src/main.cpp"], {
+      stdout: "",
+      stderr: FIXTURE_TEXT,
+      exitCode: 1,
+    });
+
+    const issues = await clangTidyRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(issues).toHaveLength(3);
+  });
+});
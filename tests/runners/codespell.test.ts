@@ -1,7 +1,11 @@
 import { describe, expect, test } from "bun:test";
 import { resolve } from "node:path";
 import type { ResolvedConfig } from "@/config/schema";
-import { codespellRunner, parseCodespellOutput } from "@/runners/codespell";
+import {
+  codespellArgs,
+  codespellRunner,
+  parseCodespellOutput,
+} from "@/runners/codespell";
 import { FakeCommandRunner } from "../fakes/fake-command-runner";
 import { FakeFileManager } from "../fakes/fake-file-manager";
 
@@ -107,6 +111,111 @@ describe("codespellRunner.run", () => {
   });
 });
 
+describe("codespellRunner.run with a changed-file list", () => {
+  test("passes the changed files as arguments", async () => {
+    const runner = new FakeCommandRunner();
+
+    await codespellRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+      files: ["README.md", "src/a.py"],
+    });
+
+    expect(runner.calls).toEqual([
+      ["codespell", "--quiet-level=2", "README.md", "src/a.py"],
+    ]);
+  });
+
+  test("does not run for an empty change set", async () => {
+    const runner = new FakeCommandRunner();
+
+    const issues = await codespellRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+      files: [],
+    });
+
+    expect(issues).toEqual([]);
+    expect(runner.calls).toHaveLength(0);
+  });
+});
+
+describe("codespellArgs", () => {
+  const tuned = makeConfig({
+    values: {
+      line_length: 100,
+      indent_width: 2,
+      codespell_ignore_words: ["ba", "nd"],
+      codespell_dictionaries: ["docs/words.txt"],
+    },
+  });
+
+  test("is empty without tuning", () => {
+    expect(codespellArgs(makeConfig())).toEqual([]);
+  });
+
+  test("maps ignore words to -L and dictionaries after the built-in one", () => {
+    expect(codespellArgs(tuned)).toEqual([
+      "-L",
+      "ba,nd",
+      "--dictionary=-,docs/words.txt",
+    ]);
+  });
+
+  test("leaves out what a hand-written .codespellrc already sets", () => {
+    const rc = "[codespell]\nignore-words-list = foo\n";
+    expect(codespellArgs(tuned, rc)).toEqual(["--dictionary=-,docs/words.txt"]);
+  });
+
+  test("still passes both flags over a generated .codespellrc", () => {
+    const rc = "# ai-guardrails:sha256=abc\n[codespell]\nignore-words-list = foo\n";
+    expect(codespellArgs(tuned, rc)).toHaveLength(3);
+  });
+});
+
+describe("codespellRunner.run with tuning", () => {
+  test("passes -L before the files", async () => {
+    const runner = new FakeCommandRunner();
+    const config = makeConfig({
+      values: { line_length: 100, indent_width: 2, codespell_ignore_words: ["ba"] },
+    });
+
+    await codespellRunner.run({
+      projectDir: PROJECT_DIR,
+      config,
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+      files: ["README.md"],
+    });
+
+    expect(runner.calls).toEqual([
+      ["codespell", "--quiet-level=2", "-L", "ba", "README.md"],
+    ]);
+  });
+
+  test("respects ignore words set in a hand-written .codespellrc", async () => {
+    const runner = new FakeCommandRunner();
+    const fm = new FakeFileManager();
+    fm.seed(`${PROJECT_DIR}/.codespellrc`, "[codespell]\nignore-words = words.txt\n");
+    const config = makeConfig({
+      values: { line_length: 100, indent_width: 2, codespell_ignore_words: ["ba"] },
+    });
+
+    await codespellRunner.run({
+      projectDir: PROJECT_DIR,
+      config,
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(runner.calls).toEqual([["codespell", "--quiet-level=2"]]);
+  });
+});
+
 describe("codespellRunner.isAvailable", () => {
   test("returns true when codespell --version exits 0", async () => {
     const runner = new FakeCommandRunner();
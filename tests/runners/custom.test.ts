@@ -0,0 +1,182 @@
+import { describe, expect, test } from "bun:test";
+import type { CustomRunnerConfig, ResolvedConfig } from "@/config/schema";
+import { createCustomRunner, expandCommand, parseCustomOutput } from "@/runners/custom";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const PROJECT_DIR = "/project";
+
+const OUTPUT = [
+  "checking 2 files",
+  "src/a.acme:3:7: warning [naming] Identifier is too short",
+  "src/b.acme:12: error Unknown directive",
+].join("\n");
+
+function makeSpec(overrides: Partial<CustomRunnerConfig> = {}): CustomRunnerConfig {
+  return {
+    id: "acme-lint",
+    files: ["**/*.acme"],
+    command: ["acme-lint", "--root={projectDir}", "{files}"],
+    pattern:
+      "^(?<file>[^:]+):(?<line>\\d+)(?::(?<col>\\d+))?: (?<severity>\\w+)" +
+      "(?: \\[(?<rule>[\\w-]+)\\])? (?<message>.*)$",
+    ok_exit_codes: [0, 1],
+    ...overrides,
+  };
+}
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+describe("parseCustomOutput", () => {
+  test("reads the named groups of every matching line", () => {
+    const issues = parseCustomOutput(OUTPUT, makeSpec(), PROJECT_DIR);
+
+    expect(issues).toEqual([
+      {
+        rule: "acme-lint/naming",
+        linter: "acme-lint",
+        file: "/project/src/a.acme",
+        line: 3,
+        col: 7,
+        message: "Identifier is too short",
+        severity: "warning",
+      },
+      {
+        rule: "acme-lint/issue",
+        linter: "acme-lint",
+        file: "/project/src/b.acme",
+        line: 12,
+        col: 1,
+        message: "Unknown directive",
+        severity: "error",
+      },
+    ]);
+  });
+
+  test("treats a missing or unknown severity as an error", () => {
+    const pattern = "^(?<file>[^:]+):(?<line>\\d+): (?<message>.*)$";
+    const spec = makeSpec({ pattern });
+    const issues = parseCustomOutput("x.acme:1: broken", spec, PROJECT_DIR);
+    expect(issues[0]?.severity).toBe("error");
+  });
+});
+
+describe("expandCommand", () => {
+  test("expands {files} into arguments and substitutes {projectDir}", () => {
+    expect(
+      expandCommand(makeSpec().command, ["a.acme", "b.acme"], PROJECT_DIR)
+    ).toEqual(["acme-lint", "--root=/project", "a.acme", "b.acme"]);
+  });
+});
+
+describe("createCustomRunner", () => {
+  test("is file-scoped when the command takes {files}", () => {
+    expect(createCustomRunner(makeSpec()).fileScoped).toBe(true);
+    const wholeProject = makeSpec({ command: ["acme-lint", "."] });
+    expect(createCustomRunner(wholeProject).fileScoped).toBe(false);
+  });
+
+  test("caches results only when a version command is given", () => {
+    expect(createCustomRunner(makeSpec()).cache).toBeUndefined();
+    const versioned = createCustomRunner(makeSpec({ version: ["acme-lint", "-V"] }));
+    expect(versioned.cache?.inputs).toEqual(["**/*.acme"]);
+    expect(versioned.versionArgs).toEqual(["acme-lint", "-V"]);
+  });
+
+  test("checks availability with the version command or --version", async () => {
+    const runner = new FakeCommandRunner();
+    await createCustomRunner(makeSpec()).isAvailable(runner);
+    await createCustomRunner(makeSpec({ version: ["acme-lint", "-V"] })).isAvailable(
+      runner
+    );
+    expect(runner.calls).toEqual([
+      ["acme-lint", "--version"],
+      ["acme-lint", "-V"],
+    ]);
+  });
+});
+
+describe("custom runner run", () => {
+  test("runs on the matching files and returns fingerprinted issues", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(
+      ["acme-lint", "--root=/project", "src/a.acme", "src/b.acme"],
+      { stdout: OUTPUT, stderr: "", exitCode: 1 }
+    );
+    const fm = new FakeFileManager();
+    fm.seed("/project/src/a.acme", "");
+    fm.seed("/project/src/b.acme", "");
+    fm.seed("/project/src/c.txt", "");
+
+    const issues = await createCustomRunner(makeSpec()).run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(runner.cwds).toEqual(["/project"]);
+    expect(issues).toHaveLength(2);
+    expect(issues[0]?.fingerprint).toBeTruthy();
+  });
+
+  test("checks only the given files that match its globs", async () => {
+    const runner = new FakeCommandRunner();
+
+    await createCustomRunner(makeSpec()).run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+      files: ["src/a.acme", "README.md"],
+    });
+
+    expect(runner.calls).toEqual([["acme-lint", "--root=/project", "src/a.acme"]]);
+  });
+
+  test("does not run without matching files", async () => {
+    const runner = new FakeCommandRunner();
+
+    const issues = await createCustomRunner(makeSpec()).run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    });
+
+    expect(issues).toEqual([]);
+    expect(runner.calls).toHaveLength(0);
+  });
+
+  test("throws on an exit code outside ok_exit_codes with no findings", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["acme-lint", "--root=/project", "src/a.acme"], {
+      stdout: "",
+      stderr: "acme-lint: license expired",
+      exitCode: 2,
+    });
+    const fm = new FakeFileManager();
+    fm.seed("/project/src/a.acme", "");
+
+    await expect(
+      createCustomRunner(makeSpec()).run({
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: runner,
+        fileManager: fm,
+      })
+    ).rejects.toThrow("acme-lint failed: acme-lint: license expired");
+  });
+});
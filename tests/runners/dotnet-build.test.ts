@@ -80,11 +80,11 @@ describe("parseDotnetBuildOutput", () => {
     expect(issues[0]?.file).toBe("/absolute/path/Foo.cs");
   });
 
-  test("profile minimal filters out warnings", () => {
+  test("profile lenient filters out warnings", () => {
     const issues = parseDotnetBuildOutput(
       FIXTURE_TEXT,
       PROJECT_DIR,
-      makeConfig({ profile: "minimal" })
+      makeConfig({ profile: "lenient" })
     );
     expect(issues.every((i) => i.severity === "error")).toBe(true);
     expect(issues).toHaveLength(1);
@@ -194,7 +194,7 @@ describe("dotnetBuildRunner.run", () => {
     expect(issues).toHaveLength(0);
   });
 
-  test("filters warnings in minimal profile", async () => {
+  test("filters warnings in lenient profile", async () => {
     const runner = new FakeCommandRunner();
     runner.register(["dotnet", "build", "--no-restore", "-v:q"], {
       stdout: FIXTURE_TEXT,
@@ -204,7 +204,7 @@ describe("dotnetBuildRunner.run", () => {
 
     const issues = await dotnetBuildRunner.run({
       projectDir: PROJECT_DIR,
-      config: makeConfig({ profile: "minimal" }),
+      config: makeConfig({ profile: "lenient" }),
       commandRunner: runner,
       fileManager: new FakeFileManager(),
     });
@@ -0,0 +1,249 @@
+import { describe, expect, test } from "bun:test";
+import { resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import {
+  dotnetFormatRunner,
+  findDotnetWorkspaces,
+  parseDotnetFormatReport,
+} from "@/runners/dotnet-format";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const FIXTURE_PATH = resolve(import.meta.dir, "../fixtures/dotnet-format-report.json");
+const PROJECT_DIR = "/project";
+const REPORT = "/project/.ai-guardrails/cache/dotnet-format-0.json";
+
+const FIXTURE_TEXT = await Bun.file(FIXTURE_PATH).text();
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 4 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+function verifyArgs(workspace: string, report = REPORT): string[] {
+  return [
+    "dotnet",
+    "format",
+    workspace,
+    "--verify-no-changes",
+    "--no-restore",
+    "--report",
+    report,
+  ];
+}
+
+describe("parseDotnetFormatReport", () => {
+  test("returns one issue per change in the report", () => {
+    const issues = parseDotnetFormatReport(FIXTURE_TEXT, PROJECT_DIR);
+    expect(issues).toHaveLength(3);
+    expect(issues[0]).toEqual({
+      rule: "dotnet-format/WHITESPACE",
+      linter: "dotnet-format",
+      file: "/project/src/App/Program.cs",
+      line: 5,
+      col: 13,
+      message: "Fix whitespace formatting. Replace 1 characters with '\\n\\s\\s\\s\\s'.",
+      severity: "error",
+    });
+    expect(issues.map((i) => i.rule)).toEqual([
+      "dotnet-format/WHITESPACE",
+      "dotnet-format/IDE0055",
+      "dotnet-format/CA1822",
+    ]);
+  });
+
+  test("returns [] for an empty report", () => {
+    expect(parseDotnetFormatReport("[]", PROJECT_DIR)).toEqual([]);
+  });
+
+  test("returns [] for malformed input", () => {
+    expect(parseDotnetFormatReport("not json", PROJECT_DIR)).toEqual([]);
+    expect(parseDotnetFormatReport('{"FilePath":"a.cs"}', PROJECT_DIR)).toEqual([]);
+  });
+});
+
+describe("findDotnetWorkspaces", () => {
+  function seedSolution(): FakeFileManager {
+    const fm = new FakeFileManager();
+    fm.seed("/project/App.sln", "");
+    fm.seed("/project/src/App/App.csproj", "");
+    fm.seed("/project/src/App/Program.cs", "");
+    fm.seed("/project/src/App.Core/App.Core.csproj", "");
+    fm.seed("/project/src/App.Core/Service.cs", "");
+    return fm;
+  }
+
+  test("formats each project on its own rather than the solution", async () => {
+    const workspaces = await findDotnetWorkspaces(PROJECT_DIR, seedSolution(), []);
+    expect(workspaces).toEqual([
+      { path: "src/App.Core/App.Core.csproj" },
+      { path: "src/App/App.csproj" },
+    ]);
+  });
+
+  test("falls back to solutions when no project file is found", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/App.sln", "");
+    expect(await findDotnetWorkspaces(PROJECT_DIR, fm, [])).toEqual([
+      { path: "App.sln" },
+    ]);
+  });
+
+  test("loads only the projects owning changed C# files", async () => {
+    const workspaces = await findDotnetWorkspaces(PROJECT_DIR, seedSolution(), [], [
+      "src/App.Core/Service.cs",
+      "README.md",
+      "tools/Script.cs",
+    ]);
+    expect(workspaces).toEqual([
+      { path: "src/App.Core/App.Core.csproj", include: ["src/App.Core/Service.cs"] },
+    ]);
+  });
+
+  test("gives a file in a nested project to the nearest project", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/Root.csproj", "");
+    fm.seed("/project/tests/Root.Tests.csproj", "");
+    const workspaces = await findDotnetWorkspaces(PROJECT_DIR, fm, [], [
+      "tests/RootTests.cs",
+      "Root.cs",
+    ]);
+    expect(workspaces).toEqual([
+      { path: "tests/Root.Tests.csproj", include: ["tests/RootTests.cs"] },
+      { path: "Root.csproj", include: ["Root.cs"] },
+    ]);
+  });
+});
+
+describe("dotnetFormatRunner.isAvailable", () => {
+  test("returns true when dotnet format runs", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["dotnet", "format", "--version"], {
+      stdout: "8.0.100",
+      stderr: "",
+      exitCode: 0,
+    });
+    expect(await dotnetFormatRunner.isAvailable(runner, PROJECT_DIR)).toBe(true);
+  });
+
+  test("returns false without the .NET SDK", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["dotnet", "format", "--version"], {
+      stdout: "",
+      stderr: "command not found",
+      exitCode: 127,
+    });
+    expect(await dotnetFormatRunner.isAvailable(runner, PROJECT_DIR)).toBe(false);
+  });
+});
+
+describe("dotnetFormatRunner.run", () => {
+  test("verifies each project and parses its report", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/src/App/App.csproj", "");
+    fm.seed(REPORT, FIXTURE_TEXT);
+    const runner = new FakeCommandRunner();
+    runner.register(verifyArgs("src/App/App.csproj"), {
+      stdout: "",
+      stderr: "",
+      exitCode: 2,
+    });
+
+    const issues = await dotnetFormatRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(runner.calls).toContainEqual(verifyArgs("src/App/App.csproj"));
+    expect(issues).toHaveLength(3);
+    expect(issues.every((i) => typeof i.fingerprint === "string")).toBe(true);
+    expect(fm.deleted).toContain(REPORT);
+  });
+
+  test("passes changed files with --include", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/src/App/App.csproj", "");
+    const runner = new FakeCommandRunner();
+
+    await dotnetFormatRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+      files: ["src/App/Program.cs"],
+    });
+
+    expect(runner.calls).toEqual([
+      [...verifyArgs("src/App/App.csproj"), "--include", "src/App/Program.cs"],
+    ]);
+  });
+
+  test("returns [] without running when no changed file is C#", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/src/App/App.csproj", "");
+    const runner = new FakeCommandRunner();
+
+    const issues = await dotnetFormatRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+      files: ["README.md"],
+    });
+
+    expect(issues).toEqual([]);
+    expect(runner.calls).toEqual([]);
+  });
+
+  test("throws when dotnet format itself fails", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/src/App/App.csproj", "");
+    const runner = new FakeCommandRunner();
+    runner.register(verifyArgs("src/App/App.csproj"), {
+      stdout: "",
+      stderr: "Could not find a MSBuild project file",
+      exitCode: 1,
+    });
+
+    await expect(
+      dotnetFormatRunner.run({
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: runner,
+        fileManager: fm,
+      })
+    ).rejects.toThrow("dotnet format failed: Could not find a MSBuild project file");
+  });
+});
+
+describe("dotnetFormatRunner.fix", () => {
+  test("formats each project in place", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/src/App/App.csproj", "");
+    fm.seed("/project/src/Lib/Lib.csproj", "");
+    const runner = new FakeCommandRunner();
+
+    await dotnetFormatRunner.fix?.({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(runner.calls).toEqual([
+      ["dotnet", "format", "src/App/App.csproj", "--no-restore"],
+      ["dotnet", "format", "src/Lib/Lib.csproj", "--no-restore"],
+    ]);
+  });
+});
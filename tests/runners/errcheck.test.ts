@@ -0,0 +1,131 @@
+import { describe, expect, test } from "bun:test";
+import type { ResolvedConfig } from "@/config/schema";
+import { errcheckRunner, parseErrcheckOutput } from "@/runners/errcheck";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const PROJECT_DIR = "/project";
+
+const OUTPUT = [
+  "main.go:12:9:\tdefer f.Close()",
+  "internal/load.go:7:8:\tdata, _ := os.ReadFile(path)",
+  "",
+].join("\n");
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+describe("parseErrcheckOutput", () => {
+  test("reports each unchecked error at its position", () => {
+    const issues = parseErrcheckOutput(OUTPUT, PROJECT_DIR);
+
+    expect(issues).toHaveLength(2);
+    expect(issues[0]).toEqual({
+      rule: "errcheck/unchecked",
+      linter: "errcheck",
+      file: "/project/main.go",
+      line: 12,
+      col: 9,
+      message: "Error return value is not checked: defer f.Close()",
+      severity: "error",
+    });
+    expect(issues[1]?.file).toBe("/project/internal/load.go");
+    expect(issues[1]?.message).toContain("data, _ := os.ReadFile(path)");
+  });
+
+  test("returns [] for empty output", () => {
+    expect(parseErrcheckOutput("", PROJECT_DIR)).toHaveLength(0);
+  });
+});
+
+describe("errcheckRunner.run", () => {
+  test("runs once per go.mod, reporting blank assignments", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["errcheck", "-blank", "./..."], {
+      stdout: OUTPUT,
+      stderr: "",
+      exitCode: 1,
+    });
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/app");
+    fm.seed("/project/tools/go.mod", "module example.com/tools");
+
+    const issues = await errcheckRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(runner.cwds).toEqual(["/project", "/project/tools"]);
+    expect(issues.map((i) => i.module)).toEqual([".", ".", "tools", "tools"]);
+    expect(issues[0]?.fingerprint).toBeTruthy();
+  });
+
+  test("writes errcheck_exclude to an -exclude file", async () => {
+    const runner = new FakeCommandRunner();
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/app");
+    const values = {
+      line_length: 100,
+      indent_width: 2,
+      errcheck_exclude: ["fmt.Fprintf", "(*bytes.Buffer).Write"],
+    };
+
+    await errcheckRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig({ values }),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    const path = "/project/.ai-guardrails/cache/errcheck-exclude.txt";
+    expect(runner.calls).toEqual([["errcheck", "-blank", "-exclude", path, "./..."]]);
+    expect(fm.written).toContainEqual([path, "fmt.Fprintf\n(*bytes.Buffer).Write\n"]);
+  });
+
+  test("throws when packages fail to load", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["errcheck", "-blank", "./..."], {
+      stdout: "",
+      stderr: "error: failed to check packages: main.go:3:1: expected declaration",
+      exitCode: 2,
+    });
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/app");
+
+    await expect(
+      errcheckRunner.run({
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: runner,
+        fileManager: fm,
+      })
+    ).rejects.toThrow("errcheck failed: error: failed to check packages");
+  });
+});
+
+describe("errcheckRunner.isAvailable", () => {
+  test("returns false when errcheck is not installed", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["errcheck", "-h"], { stdout: "", stderr: "", exitCode: 127 });
+    expect(await errcheckRunner.isAvailable(runner)).toBe(false);
+  });
+
+  test("treats the -h usage exit as installed", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["errcheck", "-h"], { stdout: "", stderr: "Usage", exitCode: 2 });
+    expect(await errcheckRunner.isAvailable(runner)).toBe(true);
+  });
+});
@@ -0,0 +1,196 @@
+import { beforeEach, describe, expect, test } from "bun:test";
+import type { ResolvedConfig } from "@/config/schema";
+import {
+  eslintRunner,
+  isJsLinter,
+  parseEslintOutput,
+  usesEslint,
+} from "@/runners/eslint";
+import type { RunOptions } from "@/runners/types";
+import { clearResolveToolPathCache } from "@/utils/resolve-tool-path";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+beforeEach(() => {
+  clearResolveToolPathCache();
+});
+
+const PROJECT_DIR = "/project";
+const LOCAL_ESLINT = `${PROJECT_DIR}/node_modules/.bin/eslint`;
+
+const ESLINT_OUTPUT = JSON.stringify([
+  {
+    filePath: "/project/src/app.ts",
+    messages: [
+      {
+        ruleId: "no-unused-vars",
+        severity: 2,
+        message: "'x' is assigned a value but never used.",
+        line: 3,
+        column: 7,
+      },
+      {
+        ruleId: "eqeqeq",
+        severity: 1,
+        message: "Expected '==='.",
+        line: 9,
+        column: 12,
+      },
+    ],
+  },
+  {
+    filePath: "src/broken.js",
+    messages: [
+      {
+        ruleId: null,
+        fatal: true,
+        severity: 2,
+        message: "Parsing error: Unexpected token",
+        line: 1,
+        column: 5,
+      },
+    ],
+  },
+  { filePath: "/project/src/clean.ts", messages: [] },
+]);
+
+function makeConfig(values: Partial<ResolvedConfig["values"]> = {}): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2, ...values },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+  };
+}
+
+function makeOpts(fm: FakeFileManager, config = makeConfig()): RunOptions {
+  return {
+    projectDir: PROJECT_DIR,
+    config,
+    commandRunner: new FakeCommandRunner(),
+    fileManager: fm,
+  };
+}
+
+describe("parseEslintOutput", () => {
+  test("maps messages to issues with eslint/ rules and severities", () => {
+    const issues = parseEslintOutput(ESLINT_OUTPUT, PROJECT_DIR);
+
+    expect(issues.map((i) => [i.rule, i.file, i.line, i.col, i.severity])).toEqual([
+      ["eslint/no-unused-vars", "/project/src/app.ts", 3, 7, "error"],
+      ["eslint/eqeqeq", "/project/src/app.ts", 9, 12, "warning"],
+      ["eslint/syntax", "/project/src/broken.js", 1, 5, "error"],
+    ]);
+  });
+
+  test("returns [] for malformed JSON", () => {
+    expect(parseEslintOutput("Oops! Something went wrong", PROJECT_DIR)).toEqual([]);
+  });
+});
+
+describe("usesEslint", () => {
+  test("detects flat and legacy config files", async () => {
+    const flat = new FakeFileManager();
+    flat.seed("/project/eslint.config.mjs", "export default [];\n");
+    const legacy = new FakeFileManager();
+    legacy.seed("/project/.eslintrc.json", "{}");
+
+    expect(await usesEslint(PROJECT_DIR, flat)).toBe(true);
+    expect(await usesEslint(PROJECT_DIR, legacy)).toBe(true);
+  });
+
+  test("detects eslint in package.json", async () => {
+    const dep = new FakeFileManager();
+    dep.seed("/project/package.json", '{"devDependencies":{"eslint":"^9.0.0"}}');
+    const key = new FakeFileManager();
+    key.seed("/project/package.json", '{"eslintConfig":{"extends":"standard"}}');
+    const none = new FakeFileManager();
+    none.seed("/project/package.json", '{"devDependencies":{"typescript":"5.0.0"}}');
+
+    expect(await usesEslint(PROJECT_DIR, dep)).toBe(true);
+    expect(await usesEslint(PROJECT_DIR, key)).toBe(true);
+    expect(await usesEslint(PROJECT_DIR, none)).toBe(false);
+  });
+});
+
+describe("isJsLinter", () => {
+  test("defaults to eslint where configured and biome elsewhere", async () => {
+    const eslint = new FakeFileManager();
+    eslint.seed("/project/eslint.config.js", "export default [];\n");
+    const plain = new FakeFileManager();
+
+    expect(await isJsLinter("eslint", makeOpts(eslint))).toBe(true);
+    expect(await isJsLinter("biome", makeOpts(eslint))).toBe(false);
+    expect(await isJsLinter("eslint", makeOpts(plain))).toBe(false);
+    expect(await isJsLinter("biome", makeOpts(plain))).toBe(true);
+  });
+
+  test("follows js_linter when set", async () => {
+    const plain = new FakeFileManager();
+    const both = makeOpts(plain, makeConfig({ js_linter: "both" }));
+    const eslint = makeOpts(plain, makeConfig({ js_linter: "eslint" }));
+
+    expect(await isJsLinter("biome", both)).toBe(true);
+    expect(await isJsLinter("eslint", both)).toBe(true);
+    expect(await isJsLinter("biome", eslint)).toBe(false);
+    expect(await isJsLinter("eslint", eslint)).toBe(true);
+  });
+});
+
+describe("eslintRunner.run", () => {
+  function globalEslint(): FakeCommandRunner {
+    const runner = new FakeCommandRunner();
+    runner.register([LOCAL_ESLINT, "--version"], {
+      stdout: "",
+      stderr: "not found",
+      exitCode: 127,
+    });
+    return runner;
+  }
+
+  test("runs eslint --format json and parses findings", async () => {
+    const runner = globalEslint();
+    runner.register(["eslint", "--format", "json", "."], {
+      stdout: ESLINT_OUTPUT,
+      stderr: "",
+      exitCode: 1,
+    });
+
+    const issues = await eslintRunner.run({
+      ...makeOpts(new FakeFileManager()),
+      commandRunner: runner,
+    });
+
+    expect(issues).toHaveLength(3);
+    expect(issues[0]?.fingerprint).toBeTruthy();
+  });
+
+  test("lints only changed JS/TS files", async () => {
+    const runner = globalEslint();
+
+    await eslintRunner.run({
+      ...makeOpts(new FakeFileManager()),
+      commandRunner: runner,
+      files: ["src/app.ts", "README.md"],
+    });
+
+    expect(runner.calls).toContainEqual(["eslint", "--format", "json", "src/app.ts"]);
+  });
+
+  test("throws when eslint exits with 2", async () => {
+    const runner = globalEslint();
+    runner.register(["eslint", "--format", "json", "."], {
+      stdout: "",
+      stderr: "Oops! Something went wrong! :(\nNo config file found",
+      exitCode: 2,
+    });
+
+    await expect(
+      eslintRunner.run({ ...makeOpts(new FakeFileManager()), commandRunner: runner })
+    ).rejects.toThrow("eslint failed: Oops! Something went wrong!");
+  });
+});
@@ -0,0 +1,132 @@
+import { describe, expect, test } from "bun:test";
+import type { ResolvedConfig } from "@/config/schema";
+import { goBuildRunner, parseGoBuildOutput } from "@/runners/go-build";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const PROJECT_DIR = "/project";
+const BUILD = ["go", "build", "-o", "/dev/null", "./..."];
+
+const OUTPUT = [
+  "# example.com/app/internal/store",
+  "internal/store/db.go:12:9: undefined: sqlOpen",
+  "internal/store/db.go:20:2: cannot use n (variable of type int) as string value in return statement",
+  "# example.com/app",
+  './main.go:5:2: "os" imported and not used',
+  "",
+].join("\n");
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+describe("parseGoBuildOutput", () => {
+  test("reports each compiler error at its position", () => {
+    const issues = parseGoBuildOutput(OUTPUT, PROJECT_DIR);
+
+    expect(issues).toHaveLength(3);
+    expect(issues[0]).toEqual({
+      rule: "go-build/compile",
+      linter: "go-build",
+      file: "/project/internal/store/db.go",
+      line: 12,
+      col: 9,
+      message: "undefined: sqlOpen",
+      severity: "error",
+    });
+    expect(issues[2]?.file).toBe("/project/main.go");
+  });
+
+  test("skips package headers and indented detail lines", () => {
+    const output = [
+      "# example.com/app",
+      "./run.go:8:14: not enough arguments in call to start",
+      "\thave ()",
+      "\twant (int)",
+    ].join("\n");
+    expect(parseGoBuildOutput(output, PROJECT_DIR)).toHaveLength(1);
+  });
+
+  test("returns [] for empty output", () => {
+    expect(parseGoBuildOutput("", PROJECT_DIR)).toHaveLength(0);
+  });
+});
+
+describe("goBuildRunner.run", () => {
+  test("builds each module and reads compiler errors from stderr", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(BUILD, { stdout: "", stderr: OUTPUT, exitCode: 1 });
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/app");
+    fm.seed("/project/tools/go.mod", "module example.com/tools");
+
+    const issues = await goBuildRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(runner.cwds).toEqual(["/project", "/project/tools"]);
+    expect(issues.map((i) => i.module)).toEqual([
+      ".",
+      ".",
+      ".",
+      "tools",
+      "tools",
+      "tools",
+    ]);
+    expect(issues[0]?.fingerprint).toBeTruthy();
+  });
+
+  test("skips a module with nothing to build", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(BUILD, {
+      stdout: "",
+      stderr:
+        "package example.com/tools: build constraints exclude all Go files in /project\n",
+      exitCode: 1,
+    });
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/tools");
+
+    const issues = await goBuildRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(issues).toEqual([]);
+  });
+
+  test("throws when go build fails without compiler errors", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(BUILD, {
+      stdout: "",
+      stderr: "go: example.com/dep@v1.2.0: missing go.sum entry\n",
+      exitCode: 1,
+    });
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/app");
+
+    await expect(
+      goBuildRunner.run({
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: runner,
+        fileManager: fm,
+      })
+    ).rejects.toThrow("go build failed: go: example.com/dep@v1.2.0: missing go.sum");
+  });
+});
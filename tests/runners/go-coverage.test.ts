@@ -0,0 +1,200 @@
+import { describe, expect, test } from "bun:test";
+import type { ResolvedConfig } from "@/config/schema";
+import {
+  coverageIssues,
+  goCoverageRunner,
+  parseCoverProfile,
+  parseTestedPackages,
+} from "@/runners/go-coverage";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const PROJECT_DIR = "/project";
+const PROFILE = "/project/.ai-guardrails/cache/coverage-0.out";
+
+const GO_TEST_ARGS = [
+  "go",
+  "test",
+  "-covermode=set",
+  `-coverprofile=${PROFILE}`,
+  "./...",
+];
+
+// api: 3 of 4 statements covered; store: 1 of 4; cmd has no test files
+const PROFILE_CONTENT = [
+  "mode: set",
+  "example.com/m/api/handler.go:10.30,12.2 2 1",
+  "example.com/m/api/handler.go:14.30,16.2 1 1",
+  "example.com/m/api/handler.go:18.30,20.2 1 0",
+  "example.com/m/store/db.go:5.20,8.2 3 0",
+  "example.com/m/store/db.go:10.20,11.2 1 1",
+  "example.com/m/cmd/main.go:3.13,5.2 2 0",
+  "",
+].join("\n");
+
+const GO_TEST_STDOUT = [
+  "ok  \texample.com/m/api\t0.012s\tcoverage: 75.0% of statements",
+  "ok  \texample.com/m/store\t0.020s\tcoverage: 25.0% of statements",
+  "?   \texample.com/m/cmd\t[no test files]",
+  "",
+].join("\n");
+
+function makeConfig(values: Partial<ResolvedConfig["values"]> = {}): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2, ...values },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+  };
+}
+
+describe("parseCoverProfile", () => {
+  test("counts statements per package", () => {
+    const packages = parseCoverProfile(PROFILE_CONTENT);
+
+    expect(packages.get("example.com/m/api")).toEqual({ statements: 4, covered: 3 });
+    expect(packages.get("example.com/m/store")).toEqual({ statements: 4, covered: 1 });
+    expect(packages.get("example.com/m/cmd")).toEqual({ statements: 2, covered: 0 });
+  });
+
+  test("counts a repeated block once, covered if any entry covered it", () => {
+    const packages = parseCoverProfile(
+      [
+        "mode: set",
+        "example.com/m/a.go:1.1,2.2 2 1",
+        "example.com/m/a.go:1.1,2.2 2 0",
+      ].join("\n")
+    );
+
+    expect(packages.get("example.com/m")).toEqual({ statements: 2, covered: 2 });
+  });
+});
+
+describe("parseTestedPackages", () => {
+  test("skips packages without test files", () => {
+    expect([...parseTestedPackages(GO_TEST_STDOUT)]).toEqual([
+      "example.com/m/api",
+      "example.com/m/store",
+    ]);
+  });
+});
+
+describe("coverageIssues", () => {
+  const packages = parseCoverProfile(PROFILE_CONTENT);
+  const tested = parseTestedPackages(GO_TEST_STDOUT);
+
+  test("reports total coverage below coverage_min, ignoring untested packages", () => {
+    const issues = coverageIssues(
+      packages,
+      tested,
+      makeConfig({ coverage_min: 60 }),
+      PROJECT_DIR
+    );
+
+    expect(issues).toEqual([
+      {
+        rule: "go-coverage/total",
+        linter: "go-coverage",
+        file: "/project/go.mod",
+        line: 1,
+        col: 1,
+        message: "Coverage 50% is below the 60% minimum (coverage_min)",
+        severity: "error",
+      },
+    ]);
+  });
+
+  test("passes when total coverage meets coverage_min", () => {
+    const config = makeConfig({ coverage_min: 50 });
+    expect(coverageIssues(packages, tested, config, PROJECT_DIR)).toEqual([]);
+  });
+
+  test("applies per-package thresholds", () => {
+    const config = makeConfig({
+      coverage_packages: {
+        "example.com/m/api": 70,
+        "example.com/m/store": 30,
+        "example.com/m/cmd": 90,
+      },
+    });
+
+    const issues = coverageIssues(packages, tested, config, PROJECT_DIR);
+
+    expect(issues.map((i) => [i.rule, i.message])).toEqual([
+      [
+        "go-coverage/package",
+        "example.com/m/store coverage 25% is below its 30% minimum",
+      ],
+    ]);
+  });
+});
+
+describe("goCoverageRunner", () => {
+  test("is opt-in and marked slow", () => {
+    expect(goCoverageRunner.defaultEnabled).toBe(false);
+    expect(goCoverageRunner.slow).toBe(true);
+  });
+
+  test("runs go test per module and reports coverage below the minimum", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(GO_TEST_ARGS, { stdout: GO_TEST_STDOUT, stderr: "", exitCode: 0 });
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/m\n");
+    fm.seed(PROFILE, PROFILE_CONTENT);
+
+    const issues = await goCoverageRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig({ coverage_min: 80 }),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(runner.calls).toEqual([GO_TEST_ARGS]);
+    expect(issues).toHaveLength(1);
+    expect(issues[0]?.message).toBe(
+      "Coverage 50% is below the 80% minimum (coverage_min)"
+    );
+    expect(issues[0]?.module).toBe(".");
+    expect(issues[0]?.fingerprint).toBeTruthy();
+  });
+
+  test("does not run the tests without a threshold", async () => {
+    const runner = new FakeCommandRunner();
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/m\n");
+
+    const issues = await goCoverageRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(issues).toEqual([]);
+    expect(runner.calls).toEqual([]);
+  });
+
+  test("throws with the failing tests when go test fails", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(GO_TEST_ARGS, {
+      stdout: "--- FAIL: TestHandler (0.00s)\nFAIL\texample.com/m/api\t0.01s\n",
+      stderr: "",
+      exitCode: 1,
+    });
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/m\n");
+
+    await expect(
+      goCoverageRunner.run({
+        projectDir: PROJECT_DIR,
+        config: makeConfig({ coverage_min: 80 }),
+        commandRunner: runner,
+        fileManager: fm,
+      })
+    ).rejects.toThrow("go test failed: --- FAIL: TestHandler (0.00s)");
+  });
+});
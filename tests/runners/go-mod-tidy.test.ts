@@ -0,0 +1,164 @@
+import { describe, expect, test } from "bun:test";
+import type { ResolvedConfig } from "@/config/schema";
+import type { RunCommandOptions, RunResult } from "@/infra/command-runner";
+import {
+  firstDifferentLine,
+  goModTidyRunner,
+  tidyIssues,
+} from "@/runners/go-mod-tidy";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const PROJECT_DIR = "/project";
+const COPY = "/project/.ai-guardrails/cache/go-mod-tidy";
+
+const GO_MOD = "module example.com/m\n\ngo 1.22\n\nrequire example.com/dep v1.0.0\n";
+const TIDY_GO_MOD = "module example.com/m\n\ngo 1.22\n";
+
+function makeConfig(): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+  };
+}
+
+/** Stands in for `go mod tidy -modfile=<x>.mod`: rewrites the copy it is given */
+class TidyingCommandRunner extends FakeCommandRunner {
+  constructor(
+    private readonly fm: FakeFileManager,
+    private readonly tidied: { mod: string; sum?: string }
+  ) {
+    super();
+  }
+
+  override async run(args: string[], opts?: RunCommandOptions): Promise<RunResult> {
+    const result = await super.run(args, opts);
+    const modfile = args.find((arg) => arg.startsWith("-modfile="))?.slice(9);
+    if (modfile !== undefined) {
+      await this.fm.writeText(modfile, this.tidied.mod);
+      if (this.tidied.sum !== undefined) {
+        await this.fm.writeText(modfile.replace(/\.mod$/, ".sum"), this.tidied.sum);
+      }
+    }
+    return result;
+  }
+}
+
+describe("firstDifferentLine", () => {
+  test("finds the first line that changed", () => {
+    expect(firstDifferentLine(GO_MOD, TIDY_GO_MOD)).toBe(5);
+    expect(firstDifferentLine("a\nb", "a\nb\nc")).toBe(2);
+  });
+});
+
+describe("tidyIssues", () => {
+  test("reports each file that differs, treating a missing go.sum as empty", () => {
+    const issues = tidyIssues(
+      "/project/api",
+      { mod: GO_MOD, sum: null },
+      { mod: TIDY_GO_MOD, sum: "example.com/dep v1.0.0 h1:abc=\n" }
+    );
+
+    expect(issues).toEqual([
+      {
+        rule: "go-mod-tidy/untidy",
+        linter: "go-mod-tidy",
+        file: "/project/api/go.mod",
+        line: 5,
+        col: 1,
+        message: "go.mod is not tidy — run: go mod tidy",
+        severity: "error",
+      },
+      {
+        rule: "go-mod-tidy/untidy",
+        linter: "go-mod-tidy",
+        file: "/project/api/go.sum",
+        line: 1,
+        col: 1,
+        message: "go.sum is not tidy — run: go mod tidy",
+        severity: "error",
+      },
+    ]);
+  });
+
+  test("returns [] for tidy files", () => {
+    const files = { mod: TIDY_GO_MOD, sum: null };
+    expect(tidyIssues("/project", files, files)).toEqual([]);
+  });
+});
+
+describe("goModTidyRunner.run", () => {
+  test("tidies a copy per module and leaves the real files alone", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.work", "go 1.22\n\nuse (\n\t./api\n\t./web\n)\n");
+    fm.seed("/project/api/go.mod", GO_MOD);
+    fm.seed("/project/web/go.mod", TIDY_GO_MOD);
+    const runner = new TidyingCommandRunner(fm, { mod: TIDY_GO_MOD });
+
+    const issues = await goModTidyRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(runner.calls).toEqual([
+      ["go", "mod", "tidy", `-modfile=${COPY}-0.mod`],
+      ["go", "mod", "tidy", `-modfile=${COPY}-1.mod`],
+    ]);
+    expect(runner.cwds).toEqual(["/project/api", "/project/web"]);
+    expect(issues.map((i) => [i.file, i.module])).toEqual([
+      ["/project/api/go.mod", "api"],
+    ]);
+    expect(await fm.readText("/project/api/go.mod")).toBe(GO_MOD);
+    expect(await fm.exists(`${COPY}-0.mod`)).toBe(false);
+  });
+
+  test("throws when go mod tidy fails", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", GO_MOD);
+    const runner = new FakeCommandRunner();
+    runner.register(["go", "mod", "tidy", `-modfile=${COPY}-0.mod`], {
+      stdout: "",
+      stderr: "go: example.com/dep: no such host",
+      exitCode: 1,
+    });
+
+    await expect(
+      goModTidyRunner.run({
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: runner,
+        fileManager: fm,
+      })
+    ).rejects.toThrow("go mod tidy failed: go: example.com/dep: no such host");
+  });
+});
+
+describe("goModTidyRunner.fix", () => {
+  test("runs go mod tidy in place in each module", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", GO_MOD);
+    fm.seed("/project/tools/go.mod", GO_MOD);
+    const runner = new FakeCommandRunner();
+
+    await goModTidyRunner.fix?.({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(runner.calls).toEqual([
+      ["go", "mod", "tidy"],
+      ["go", "mod", "tidy"],
+    ]);
+    expect(runner.cwds).toEqual(["/project", "/project/tools"]);
+  });
+});
@@ -0,0 +1,142 @@
+import { describe, expect, test } from "bun:test";
+import type { ResolvedConfig } from "@/config/schema";
+import { gofumptRunner, parseGofumptOutput } from "@/runners/gofumpt";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const PROJECT_DIR = "/project";
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+describe("parseGofumptOutput", () => {
+  test("reports one finding per listed file", () => {
+    const issues = parseGofumptOutput("main.go\ninternal/db/query.go\n", PROJECT_DIR);
+
+    expect(issues).toHaveLength(2);
+    expect(issues[0]).toEqual({
+      rule: "gofumpt/format",
+      linter: "gofumpt",
+      file: "/project/main.go",
+      line: 1,
+      col: 1,
+      message: "File is not gofumpt-formatted — run: gofumpt -w main.go",
+      severity: "error",
+    });
+    expect(issues[1]?.file).toBe("/project/internal/db/query.go");
+  });
+
+  test("returns [] for empty output", () => {
+    expect(parseGofumptOutput("", PROJECT_DIR)).toHaveLength(0);
+  });
+});
+
+describe("gofumptRunner", () => {
+  test("is opt-in and supersedes golangci-lint's gofmt findings", () => {
+    expect(gofumptRunner.defaultEnabled).toBe(false);
+    expect(gofumptRunner.supersedes).toContain("golangci-lint/gofmt");
+  });
+
+  test("lists the whole tree and drops vendored and ignored files", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["gofumpt", "-l", "."], {
+      stdout: "main.go\nvendor/x/y.go\ngen/api.go\n",
+      stderr: "",
+      exitCode: 0,
+    });
+
+    const issues = await gofumptRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig({ ignorePaths: ["gen/**"] }),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    });
+
+    expect(runner.cwds).toEqual(["/project"]);
+    expect(issues.map((i) => i.file)).toEqual(["/project/main.go"]);
+  });
+
+  test("checks only the given Go files", async () => {
+    const runner = new FakeCommandRunner();
+
+    await gofumptRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+      files: ["cmd/main.go", "README.md"],
+    });
+
+    expect(runner.calls).toEqual([["gofumpt", "-l", "cmd/main.go"]]);
+  });
+
+  test("throws when gofumpt cannot parse a file", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["gofumpt", "-l", "."], {
+      stdout: "",
+      stderr: "broken.go:3:1: expected declaration, found '}'",
+      exitCode: 2,
+    });
+
+    await expect(
+      gofumptRunner.run({
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: runner,
+        fileManager: new FakeFileManager(),
+      })
+    ).rejects.toThrow("gofumpt failed: broken.go:3:1");
+  });
+
+  test("fix rewrites only the files that need it", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["gofumpt", "-l", "."], {
+      stdout: "main.go\nvendor/x/y.go\n",
+      stderr: "",
+      exitCode: 0,
+    });
+
+    await gofumptRunner.fix?.({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    });
+
+    expect(runner.calls).toEqual([
+      ["gofumpt", "-l", "."],
+      ["gofumpt", "-w", "main.go"],
+    ]);
+  });
+
+  test("previewFix pipes the file through gofumpt from its directory", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["gofumpt"], { stdout: "package db\n", stderr: "", exitCode: 0 });
+
+    const fixed = await gofumptRunner.previewFix?.(
+      {
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: runner,
+        fileManager: new FakeFileManager(),
+      },
+      "internal/db/query.go",
+      "package  db\n"
+    );
+
+    expect(fixed).toBe("package db\n");
+    expect(runner.stdins).toEqual(["package  db\n"]);
+    expect(runner.cwds).toEqual(["/project/internal/db"]);
+  });
+});
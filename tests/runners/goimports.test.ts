@@ -0,0 +1,142 @@
+import { describe, expect, test } from "bun:test";
+import type { ResolvedConfig } from "@/config/schema";
+import { goimportsRunner, parseGoimportsOutput } from "@/runners/goimports";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const PROJECT_DIR = "/project";
+const LOCAL_VALUES = {
+  line_length: 100,
+  indent_width: 2,
+  go_local_prefix: "example.com/acme",
+};
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+describe("parseGoimportsOutput", () => {
+  test("reports one finding per listed file", () => {
+    const issues = parseGoimportsOutput("main.go\ncmd/cli/run.go\n", PROJECT_DIR);
+
+    expect(issues).toHaveLength(2);
+    expect(issues[0]).toEqual({
+      rule: "goimports/imports",
+      linter: "goimports",
+      file: "/project/main.go",
+      line: 1,
+      col: 1,
+      message: "Imports are not organized — run: goimports -w main.go",
+      severity: "error",
+    });
+    expect(issues[1]?.file).toBe("/project/cmd/cli/run.go");
+  });
+
+  test("returns [] for empty output", () => {
+    expect(parseGoimportsOutput("", PROJECT_DIR)).toHaveLength(0);
+  });
+});
+
+describe("goimportsRunner.run", () => {
+  test("lists the whole tree and drops vendored files", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["goimports", "-l", "."], {
+      stdout: "main.go\nvendor/x/y.go\n",
+      stderr: "",
+      exitCode: 0,
+    });
+
+    const issues = await goimportsRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    });
+
+    expect(runner.cwds).toEqual(["/project"]);
+    expect(issues.map((i) => i.file)).toEqual(["/project/main.go"]);
+    expect(issues[0]?.fingerprint).toBeTruthy();
+  });
+
+  test("passes go_local_prefix as -local", async () => {
+    const runner = new FakeCommandRunner();
+
+    await goimportsRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig({ values: LOCAL_VALUES }),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+      files: ["internal/db/query.go", "go.sum"],
+    });
+
+    expect(runner.calls).toEqual([
+      ["goimports", "-l", "-local", "example.com/acme", "internal/db/query.go"],
+    ]);
+  });
+
+  test("throws when goimports cannot parse a file", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["goimports", "-l", "."], {
+      stdout: "",
+      stderr: "broken.go:3:1: expected declaration, found '}'",
+      exitCode: 2,
+    });
+
+    await expect(
+      goimportsRunner.run({
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: runner,
+        fileManager: new FakeFileManager(),
+      })
+    ).rejects.toThrow("goimports failed: broken.go:3:1");
+  });
+});
+
+describe("goimportsRunner.fix", () => {
+  test("rewrites only the listed files, keeping -local", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["goimports", "-l", "-local", "example.com/acme", "."], {
+      stdout: "main.go\n",
+      stderr: "",
+      exitCode: 0,
+    });
+
+    await goimportsRunner.fix?.({
+      projectDir: PROJECT_DIR,
+      config: makeConfig({ values: LOCAL_VALUES }),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    });
+
+    expect(runner.calls.at(-1)).toEqual([
+      "goimports",
+      "-w",
+      "-local",
+      "example.com/acme",
+      "main.go",
+    ]);
+  });
+});
+
+describe("goimportsRunner.isAvailable", () => {
+  test("returns false when goimports is not installed", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["goimports", "-h"], {
+      stdout: "",
+      stderr: "not found",
+      exitCode: 127,
+    });
+    expect(await goimportsRunner.isAvailable(runner)).toBe(false);
+  });
+});
@@ -14,6 +14,12 @@ const PROJECT_DIR = "/project";
 
 const FIXTURE_JSON = await Bun.file(FIXTURE_PATH).text();
 
+function goProject(): FakeFileManager {
+  const fm = new FakeFileManager();
+  fm.seed("/project/go.mod", "module example.com/app");
+  return fm;
+}
+
 function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
   return {
     profile: "standard",
@@ -95,7 +101,7 @@ describe("golangciLintRunner.run", () => {
       projectDir: PROJECT_DIR,
       config: makeConfig(),
       commandRunner: runner,
-      fileManager: new FakeFileManager(),
+      fileManager: goProject(),
     });
 
     expect(runner.calls[1]).toEqual([
@@ -124,7 +130,7 @@ describe("golangciLintRunner.run", () => {
       projectDir: PROJECT_DIR,
       config: makeConfig(),
       commandRunner: runner,
-      fileManager: new FakeFileManager(),
+      fileManager: goProject(),
     });
 
     expect(runner.calls[1]).toEqual([
@@ -135,6 +141,116 @@ describe("golangciLintRunner.run", () => {
     ]);
     expect(issues).toHaveLength(2);
   });
+
+  test("throws when golangci-lint exits with a failure code and no issues", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["golangci-lint", "--version"], {
+      stdout: "golangci-lint has version 1.64.0",
+      stderr: "",
+      exitCode: 0,
+    });
+    runner.register(["golangci-lint", "run", "--output.json.path=stdout", "./..."], {
+      stdout: "",
+      stderr: "can't load config: unknown linter",
+      exitCode: 3,
+    });
+
+    await expect(
+      golangciLintRunner.run({
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: runner,
+        fileManager: goProject(),
+      })
+    ).rejects.toThrow("golangci-lint failed: can't load config: unknown linter");
+  });
+
+  test("treats exit code 5 (no Go files) as a clean run", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["golangci-lint", "--version"], {
+      stdout: "golangci-lint has version 1.64.0",
+      stderr: "",
+      exitCode: 0,
+    });
+    runner.register(["golangci-lint", "run", "--output.json.path=stdout", "./..."], {
+      stdout: "",
+      stderr: "",
+      exitCode: 5,
+    });
+
+    const issues = await golangciLintRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: goProject(),
+    });
+
+    expect(issues).toHaveLength(0);
+  });
+});
+
+describe("golangciLintRunner per module", () => {
+  beforeEach(() => {
+    resetVersionFlagCache();
+  });
+
+  function workspace(): FakeFileManager {
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.work", "go 1.22\n\nuse (\n\t./api\n\t./worker\n)\n");
+    fm.seed("/project/api/go.mod", "module example.com/api");
+    fm.seed("/project/worker/go.mod", "module example.com/worker");
+    return fm;
+  }
+
+  test("runs once per go.work module and tags each issue", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["golangci-lint", "--version"], {
+      stdout: "golangci-lint has version 1.64.0",
+      stderr: "",
+      exitCode: 0,
+    });
+    runner.register(["golangci-lint", "run", "--output.json.path=stdout", "./..."], {
+      stdout: FIXTURE_JSON,
+      stderr: "",
+      exitCode: 1,
+    });
+
+    const issues = await golangciLintRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: workspace(),
+    });
+
+    expect(runner.cwds.slice(1)).toEqual(["/project/api", "/project/worker"]);
+    expect(issues.map((i) => i.module)).toEqual(["api", "api", "worker", "worker"]);
+    expect(issues[0]?.file.startsWith("/project/api/")).toBe(true);
+  });
+
+  test("runs only the modules under opts.module", async () => {
+    const runner = new FakeCommandRunner();
+
+    await golangciLintRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: workspace(),
+      module: "worker",
+    });
+
+    expect(runner.cwds.slice(1)).toEqual(["/project/worker"]);
+  });
+
+  test("fix runs in every module", async () => {
+    const runner = new FakeCommandRunner();
+    await golangciLintRunner.fix?.({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: workspace(),
+    });
+    expect(runner.cwds).toEqual(["/project/api", "/project/worker"]);
+  });
 });
 
 describe("golangciLintRunner.isAvailable", () => {
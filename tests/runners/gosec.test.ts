@@ -0,0 +1,161 @@
+import { describe, expect, test } from "bun:test";
+import { resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import { gosecExcludeArgs, gosecRunner, parseGosecOutput } from "@/runners/gosec";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const FIXTURE_PATH = resolve(import.meta.dir, "../fixtures/gosec-output.json");
+const PROJECT_DIR = "/project";
+
+const FIXTURE_JSON = await Bun.file(FIXTURE_PATH).text();
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+describe("parseGosecOutput", () => {
+  test("returns correct LintIssue[] from fixture", () => {
+    const issues = parseGosecOutput(FIXTURE_JSON, PROJECT_DIR);
+    expect(issues).toHaveLength(3);
+
+    const first = issues[0];
+    expect(first).toBeDefined();
+    if (!first) return;
+    expect(first.rule).toBe("gosec/G101");
+    expect(first.linter).toBe("gosec");
+    expect(first.file).toBe("/project/config/secrets.go");
+    expect(first.line).toBe(9);
+    expect(first.col).toBe(7);
+    expect(first.message).toBe("Potential hardcoded credentials (confidence: low)");
+  });
+
+  test("maps HIGH/MEDIUM/LOW to error/warning/info", () => {
+    const issues = parseGosecOutput(FIXTURE_JSON, PROJECT_DIR);
+    expect(issues.map((i) => i.severity)).toEqual(["error", "warning", "info"]);
+  });
+
+  test("uses the first line of a line range", () => {
+    const issues = parseGosecOutput(FIXTURE_JSON, PROJECT_DIR);
+    expect(issues[1]?.line).toBe(21);
+  });
+
+  test("returns [] for empty or malformed output", () => {
+    expect(parseGosecOutput("", PROJECT_DIR)).toHaveLength(0);
+    expect(parseGosecOutput("not json", PROJECT_DIR)).toHaveLength(0);
+  });
+});
+
+describe("gosecExcludeArgs", () => {
+  test("excludes gosec rules ignored in config", () => {
+    const config = makeConfig({
+      ignoredRules: new Set(["gosec/G104", "ruff/E501", "gosec/G401"]),
+    });
+    expect(gosecExcludeArgs(config)).toEqual(["-exclude=G104,G401"]);
+  });
+
+  test("returns no args when nothing is ignored", () => {
+    expect(gosecExcludeArgs(makeConfig())).toEqual([]);
+  });
+});
+
+describe("gosecRunner.run", () => {
+  test("runs once per go.mod, passing ignored rules to -exclude", async () => {
+    const runner = new FakeCommandRunner();
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/root");
+    fm.seed("/project/tools/go.mod", "module example.com/tools");
+
+    await gosecRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig({ ignoredRules: new Set(["gosec/G104"]) }),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(runner.calls).toEqual([
+      ["gosec", "-fmt=json", "-exclude=G104", "-exclude-generated", "./..."],
+      ["gosec", "-fmt=json", "-exclude=G104", "-exclude-generated", "./..."],
+    ]);
+    expect(runner.cwds).toEqual(["/project", "/project/tools"]);
+  });
+
+  test("omits -exclude-generated with includeGenerated", async () => {
+    const runner = new FakeCommandRunner();
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/root");
+
+    await gosecRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+      includeGenerated: true,
+    });
+
+    expect(runner.calls).toEqual([["gosec", "-fmt=json", "./..."]]);
+  });
+
+  test("returns fingerprinted issues when gosec exits 1", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["gosec", "-fmt=json", "-exclude-generated", "./..."], {
+      stdout: FIXTURE_JSON,
+      stderr: "",
+      exitCode: 1,
+    });
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/root");
+
+    const issues = await gosecRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(issues).toHaveLength(3);
+    expect(issues[0]?.fingerprint).toBeTruthy();
+  });
+
+  test("throws on a failed run with no parseable output", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["gosec", "-fmt=json", "-exclude-generated", "./..."], {
+      stdout: "",
+      stderr: "go: cannot find main module",
+      exitCode: 2,
+    });
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/root");
+
+    await expect(
+      gosecRunner.run({
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: runner,
+        fileManager: fm,
+      })
+    ).rejects.toThrow("gosec failed: go: cannot find main module");
+  });
+});
+
+describe("gosecRunner.isAvailable", () => {
+  test("returns false when gosec is not installed", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["gosec", "-version"], {
+      stdout: "",
+      stderr: "not found",
+      exitCode: 127,
+    });
+    expect(await gosecRunner.isAvailable(runner)).toBe(false);
+  });
+});
@@ -0,0 +1,140 @@
+import { describe, expect, test } from "bun:test";
+import { resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import { govulncheckRunner, parseGovulncheckOutput } from "@/runners/govulncheck";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const FIXTURE_PATH = resolve(import.meta.dir, "../fixtures/govulncheck-output.json");
+const PROJECT_DIR = "/project";
+
+const FIXTURE_STREAM = await Bun.file(FIXTURE_PATH).text();
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+describe("parseGovulncheckOutput", () => {
+  test("reports a called vulnerability at the call site", () => {
+    const issues = parseGovulncheckOutput(FIXTURE_STREAM, PROJECT_DIR);
+
+    const first = issues[0];
+    expect(first).toBeDefined();
+    if (!first) return;
+    expect(first.rule).toBe("govulncheck/GO-2023-2102");
+    expect(first.linter).toBe("govulncheck");
+    expect(first.file).toBe("/project/server/server.go");
+    expect(first.line).toBe(27);
+    expect(first.col).toBe(14);
+    expect(first.severity).toBe("error");
+    expect(first.message).toBe(
+      "HTTP/2 rapid reset can cause excessive work in net/http; " +
+        "calls golang.org/x/net/http2.serverConn.serve; fixed in v0.17.0"
+    );
+  });
+
+  test("drops findings that are only imported, and duplicate call sites", () => {
+    const issues = parseGovulncheckOutput(FIXTURE_STREAM, PROJECT_DIR);
+    expect(issues.map((i) => i.rule)).toEqual([
+      "govulncheck/GO-2023-2102",
+      "govulncheck/GO-2024-2687",
+    ]);
+  });
+
+  test("falls back to go.mod when the trace has no position", () => {
+    const issues = parseGovulncheckOutput(FIXTURE_STREAM, PROJECT_DIR);
+    const unplaced = issues[1];
+    expect(unplaced?.file).toBe("/project/go.mod");
+    expect(unplaced?.line).toBe(1);
+    expect(unplaced?.message).toContain("no fixed version yet");
+  });
+
+  test("returns [] for empty output", () => {
+    expect(parseGovulncheckOutput("", PROJECT_DIR)).toHaveLength(0);
+  });
+});
+
+describe("govulncheckRunner.run", () => {
+  test("runs once per discovered go.mod with the module as cwd", async () => {
+    const runner = new FakeCommandRunner();
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/app");
+    fm.seed("/project/tools/go.mod", "module example.com/tools");
+
+    await govulncheckRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(runner.calls).toEqual([
+      ["govulncheck", "-json", "./..."],
+      ["govulncheck", "-json", "./..."],
+    ]);
+    expect(runner.cwds).toEqual(["/project", "/project/tools"]);
+  });
+
+  test("returns fingerprinted issues from the stream", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["govulncheck", "-json", "./..."], {
+      stdout: FIXTURE_STREAM,
+      stderr: "",
+      exitCode: 0,
+    });
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/app");
+
+    const issues = await govulncheckRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(issues).toHaveLength(2);
+    expect(issues[0]?.fingerprint).toBeTruthy();
+  });
+
+  test("throws when the scan itself fails", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["govulncheck", "-json", "./..."], {
+      stdout: "",
+      stderr: "fetching vulnerabilities: dial tcp: lookup vuln.go.dev: no such host",
+      exitCode: 1,
+    });
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/app");
+
+    await expect(
+      govulncheckRunner.run({
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: runner,
+        fileManager: fm,
+      })
+    ).rejects.toThrow("govulncheck failed: fetching vulnerabilities");
+  });
+});
+
+describe("govulncheckRunner.isAvailable", () => {
+  test("returns false when govulncheck is not installed", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["govulncheck", "-version"], {
+      stdout: "",
+      stderr: "not found",
+      exitCode: 127,
+    });
+    expect(await govulncheckRunner.isAvailable(runner)).toBe(false);
+  });
+});
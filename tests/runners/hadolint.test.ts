@@ -0,0 +1,151 @@
+import { describe, expect, test } from "bun:test";
+import { resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import { hadolintRunner, parseHadolintOutput } from "@/runners/hadolint";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const FIXTURE_PATH = resolve(import.meta.dir, "../fixtures/hadolint-output.json");
+const PROJECT_DIR = "/project";
+
+const FIXTURE_JSON = await Bun.file(FIXTURE_PATH).text();
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+describe("parseHadolintOutput", () => {
+  test("returns correct LintIssue[] from fixture", () => {
+    const issues = parseHadolintOutput(FIXTURE_JSON, PROJECT_DIR);
+    expect(issues).toHaveLength(3);
+
+    const first = issues[0];
+    expect(first).toBeDefined();
+    if (!first) return;
+    expect(first.rule).toBe("hadolint/DL3006");
+    expect(first.linter).toBe("hadolint");
+    expect(first.file).toBe("/project/Dockerfile");
+    expect(first.line).toBe(1);
+    expect(first.col).toBe(1);
+    expect(first.message).toBe("Always tag the version of an image explicitly");
+    expect(first.severity).toBe("warning");
+  });
+
+  test("maps error level to error severity", () => {
+    const issues = parseHadolintOutput(FIXTURE_JSON, PROJECT_DIR);
+    const error = issues.find((i) => i.rule === "hadolint/DL3000");
+    expect(error?.severity).toBe("error");
+    expect(error?.file).toBe("/project/services/api/api.dockerfile");
+  });
+
+  test("maps info level to info severity", () => {
+    const issues = parseHadolintOutput(FIXTURE_JSON, PROJECT_DIR);
+    const info = issues.find((i) => i.rule === "hadolint/DL3059");
+    expect(info?.severity).toBe("info");
+  });
+
+  test("returns [] for an empty array", () => {
+    expect(parseHadolintOutput("[]", PROJECT_DIR)).toHaveLength(0);
+  });
+
+  test("returns [] for malformed JSON", () => {
+    expect(parseHadolintOutput("not valid json", PROJECT_DIR)).toHaveLength(0);
+  });
+});
+
+describe("hadolintRunner.run", () => {
+  test("returns [] when no Dockerfiles found", async () => {
+    const runner = new FakeCommandRunner();
+
+    const issues = await hadolintRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    });
+
+    expect(issues).toHaveLength(0);
+    expect(runner.calls).toHaveLength(0);
+  });
+
+  test("calls hadolint with every Dockerfile and Containerfile", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(
+      [
+        "hadolint",
+        "--format",
+        "json",
+        "Dockerfile",
+        "services/api/api.dockerfile",
+        "Containerfile",
+      ],
+      { stdout: FIXTURE_JSON, stderr: "", exitCode: 1 }
+    );
+
+    const fm = new FakeFileManager();
+    fm.seed("Dockerfile", "FROM ubuntu\n");
+    fm.seed("services/api/api.dockerfile", "FROM node:20\n");
+    fm.seed("Containerfile", "FROM alpine:3\n");
+    fm.seed("docs/README.md", "# docs\n");
+
+    const issues = await hadolintRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(runner.calls).toHaveLength(1);
+    expect(issues).toHaveLength(3);
+  });
+
+  test("checks only the changed Dockerfiles", async () => {
+    const runner = new FakeCommandRunner();
+    const fm = new FakeFileManager();
+    fm.seed("Dockerfile", "FROM ubuntu\n");
+
+    await hadolintRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+      files: ["deploy/Dockerfile", "src/a.py"],
+    });
+
+    expect(runner.calls).toEqual([
+      ["hadolint", "--format", "json", "deploy/Dockerfile"],
+    ]);
+  });
+});
+
+describe("hadolintRunner.isAvailable", () => {
+  test("returns true when hadolint --version exits 0", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["hadolint", "--version"], {
+      stdout: "Haskell Dockerfile Linter 2.12.0",
+      stderr: "",
+      exitCode: 0,
+    });
+    expect(await hadolintRunner.isAvailable(runner)).toBe(true);
+  });
+
+  test("returns false when hadolint is not installed", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["hadolint", "--version"], {
+      stdout: "",
+      stderr: "not found",
+      exitCode: 127,
+    });
+    expect(await hadolintRunner.isAvailable(runner)).toBe(false);
+  });
+});
@@ -0,0 +1,278 @@
+import { describe, expect, test } from "bun:test";
+import { resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import {
+  helmLintRunner,
+  helmTemplateRunner,
+  parseHelmLintOutput,
+  parseHelmTemplateError,
+  parseWrittenFiles,
+} from "@/runners/helm";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const FIXTURE_PATH = resolve(import.meta.dir, "../fixtures/helm-lint-output.txt");
+const PROJECT_DIR = "/project";
+const CHART_DIR = "/project/charts/api";
+const OUT_DIR = "/project/.ai-guardrails/cache/helm-template/charts/api";
+
+const FIXTURE_TEXT = await Bun.file(FIXTURE_PATH).text();
+
+function makeConfig(): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+  };
+}
+
+function seedCharts(): FakeFileManager {
+  const fm = new FakeFileManager();
+  fm.seed("/project/charts/api/Chart.yaml", "apiVersion: v2\nname: api\n");
+  fm.seed("/project/charts/api/templates/deployment.yaml", "kind: Deployment\n");
+  fm.seed("/project/charts/worker/Chart.yaml", "apiVersion: v2\nname: worker\n");
+  return fm;
+}
+
+describe("parseHelmLintOutput", () => {
+  test("returns one issue per finding line, on the chart's files", () => {
+    const issues = parseHelmLintOutput(FIXTURE_TEXT, CHART_DIR);
+    expect(issues).toHaveLength(4);
+    expect(issues[0]).toEqual({
+      rule: "helm-lint/chart",
+      linter: "helm-lint",
+      file: "/project/charts/api/Chart.yaml",
+      line: 1,
+      col: 1,
+      message: "icon is recommended",
+      severity: "info",
+    });
+    expect(issues.map((i) => i.rule)).toEqual([
+      "helm-lint/chart",
+      "helm-lint/template",
+      "helm-lint/template",
+      "helm-lint/values",
+    ]);
+    expect(issues.map((i) => i.severity)).toEqual([
+      "info",
+      "warning",
+      "error",
+      "error",
+    ]);
+  });
+
+  test("takes the line from a YAML parse error", () => {
+    const issues = parseHelmLintOutput(FIXTURE_TEXT, CHART_DIR);
+    expect(issues[2]?.file).toBe("/project/charts/api/templates/deployment.yaml");
+    expect(issues[2]?.line).toBe(12);
+  });
+
+  test("reports a finding without a path on Chart.yaml", () => {
+    const [issue] = parseHelmLintOutput("[ERROR] : unable to load chart\n", CHART_DIR);
+    expect(issue?.file).toBe("/project/charts/api/Chart.yaml");
+  });
+
+  test("returns [] for output without findings", () => {
+    const stdout = "==> Linting .\n\n1 chart(s) linted, 0 chart(s) failed\n";
+    expect(parseHelmLintOutput(stdout, CHART_DIR)).toEqual([]);
+  });
+});
+
+describe("parseHelmTemplateError", () => {
+  test("points at the template and position an execution error names", () => {
+    const stderr =
+      'Error: template: api/templates/deployment.yaml:14:22: executing "api/templates/deployment.yaml" at <.Values.image.tag>: nil pointer evaluating interface {}.tag\n';
+    expect(parseHelmTemplateError(stderr, CHART_DIR)).toEqual([
+      {
+        rule: "helm-template/render",
+        linter: "helm-template",
+        file: "/project/charts/api/templates/deployment.yaml",
+        line: 14,
+        col: 22,
+        message:
+          'template: api/templates/deployment.yaml:14:22: executing "api/templates/deployment.yaml" at <.Values.image.tag>: nil pointer evaluating interface {}.tag',
+        severity: "error",
+      },
+    ]);
+  });
+
+  test("takes the line of a rendered YAML parse error", () => {
+    const stderr =
+      "Error: YAML parse error on api/templates/service.yaml: error converting YAML to JSON: yaml: line 7: did not find expected key\n";
+    const [issue] = parseHelmTemplateError(stderr, CHART_DIR);
+    expect(issue?.file).toBe("/project/charts/api/templates/service.yaml");
+    expect(issue?.line).toBe(7);
+  });
+
+  test("reports an error naming no template on Chart.yaml", () => {
+    const stderr = "Error: found in Chart.yaml, but missing in charts/ directory: redis\n";
+    const [issue] = parseHelmTemplateError(stderr, CHART_DIR);
+    expect(issue?.file).toBe("/project/charts/api/Chart.yaml");
+    expect(issue?.line).toBe(1);
+  });
+
+  test("returns [] for empty stderr", () => {
+    expect(parseHelmTemplateError("", CHART_DIR)).toEqual([]);
+  });
+});
+
+describe("parseWrittenFiles", () => {
+  test("lists the files helm template wrote", () => {
+    const service = `${OUT_DIR}/api/templates/service.yaml`;
+    const deployment = `${OUT_DIR}/api/templates/deployment.yaml`;
+    const stdout = `wrote ${service}\nwrote ${deployment}\n`;
+    expect(parseWrittenFiles(stdout)).toEqual([service, deployment]);
+  });
+});
+
+describe("helmLintRunner.isAvailable", () => {
+  test("returns false when helm is not installed", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["helm", "version", "--short"], {
+      stdout: "",
+      stderr: "command not found",
+      exitCode: 127,
+    });
+    expect(await helmLintRunner.isAvailable(runner)).toBe(false);
+  });
+});
+
+describe("helmLintRunner.run", () => {
+  test("lints each chart in its own directory", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["helm", "lint", "."], {
+      stdout: FIXTURE_TEXT,
+      stderr: "",
+      exitCode: 1,
+    });
+
+    const issues = await helmLintRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: seedCharts(),
+    });
+
+    expect(runner.calls).toEqual([
+      ["helm", "lint", "."],
+      ["helm", "lint", "."],
+    ]);
+    expect(runner.cwds).toEqual([CHART_DIR, "/project/charts/worker"]);
+    expect(issues).toHaveLength(8);
+    expect(issues.every((i) => typeof i.fingerprint === "string")).toBe(true);
+  });
+
+  test("lints subcharts as part of their parent", async () => {
+    const runner = new FakeCommandRunner();
+    const fm = seedCharts();
+    fm.seed("/project/charts/api/charts/redis/Chart.yaml", "name: redis\n");
+    await helmLintRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+    expect(runner.cwds).toEqual([CHART_DIR, "/project/charts/worker"]);
+  });
+
+  test("lints only the charts holding a changed file", async () => {
+    const runner = new FakeCommandRunner();
+    await helmLintRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: seedCharts(),
+      files: ["charts/worker/values.yaml", "README.md"],
+    });
+    expect(runner.cwds).toEqual(["/project/charts/worker"]);
+  });
+
+  test("throws when helm fails without reporting an error", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["helm", "lint", "."], {
+      stdout: "",
+      stderr: "Error: chart metadata (Chart.yaml) missing",
+      exitCode: 1,
+    });
+    await expect(
+      helmLintRunner.run({
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: runner,
+        fileManager: seedCharts(),
+      })
+    ).rejects.toThrow("helm-lint failed: Error: chart metadata (Chart.yaml) missing");
+  });
+});
+
+describe("helmTemplateRunner", () => {
+  const templateArgs = ["helm", "template", ".", "--output-dir", OUT_DIR];
+
+  function apiOnly(): FakeFileManager {
+    const fm = new FakeFileManager();
+    fm.seed("/project/charts/api/Chart.yaml", "apiVersion: v2\nname: api\n");
+    return fm;
+  }
+
+  test("is opt-in", () => {
+    expect(helmTemplateRunner.defaultEnabled).toBe(false);
+  });
+
+  test("lints the rendered manifests, reported on their templates", async () => {
+    const runner = new FakeCommandRunner();
+    const rendered = `${OUT_DIR}/api/templates/service.yaml`;
+    runner.register(templateArgs, {
+      stdout: `wrote ${rendered}\n`,
+      stderr: "",
+      exitCode: 0,
+    });
+    runner.register(["yamllint", "-f", "parsable", rendered], {
+      stdout: `${rendered}:9:5: [error] duplication of key "port" in mapping (key-duplicates)\n`,
+      stderr: "",
+      exitCode: 1,
+    });
+
+    const issues = await helmTemplateRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: apiOnly(),
+    });
+
+    expect(issues).toHaveLength(1);
+    expect(issues[0]).toMatchObject({
+      rule: "helm-template/key-duplicates",
+      linter: "helm-template",
+      file: "/project/charts/api/templates/service.yaml",
+      line: 9,
+      message: 'duplication of key "port" in mapping (line 9 of the rendered manifest)',
+      severity: "error",
+    });
+    expect(runner.cwds.at(-1)).toBe(PROJECT_DIR);
+  });
+
+  test("reports a chart that fails to render", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(templateArgs, {
+      stdout: "",
+      stderr: "Error: template: api/templates/deployment.yaml:3:10: function \"tpl2\" not defined\n",
+      exitCode: 1,
+    });
+
+    const issues = await helmTemplateRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: apiOnly(),
+    });
+
+    expect(issues.map((i) => [i.rule, i.line])).toEqual([["helm-template/render", 3]]);
+    const lints = runner.calls.filter((args) => args.includes("parsable"));
+    expect(lints).toEqual([]);
+  });
+});
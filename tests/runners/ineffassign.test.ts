@@ -0,0 +1,122 @@
+import { describe, expect, test } from "bun:test";
+import type { ResolvedConfig } from "@/config/schema";
+import { ineffassignRunner, parseIneffassignOutput } from "@/runners/ineffassign";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const PROJECT_DIR = "/project";
+
+const OUTPUT = [
+  "main.go:14:2: ineffectual assignment to err",
+  "internal/load.go:7:3: ineffectual assignment to n",
+  "",
+].join("\n");
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+describe("parseIneffassignOutput", () => {
+  test("reports each ineffectual assignment at its position", () => {
+    const issues = parseIneffassignOutput(OUTPUT, PROJECT_DIR);
+
+    expect(issues).toHaveLength(2);
+    expect(issues[0]).toEqual({
+      rule: "ineffassign/ineffectual-assignment",
+      linter: "ineffassign",
+      file: "/project/main.go",
+      line: 14,
+      col: 2,
+      message: "ineffectual assignment to err",
+      severity: "error",
+    });
+    expect(issues[1]?.file).toBe("/project/internal/load.go");
+  });
+
+  test("keeps absolute paths as they are", () => {
+    const line = "/project/tools/gen.go:3:1: ineffectual assignment to ok";
+    const [issue] = parseIneffassignOutput(line, "/project/tools");
+    expect(issue?.file).toBe("/project/tools/gen.go");
+  });
+
+  test("returns [] for empty output", () => {
+    expect(parseIneffassignOutput("", PROJECT_DIR)).toHaveLength(0);
+  });
+});
+
+describe("ineffassignRunner.run", () => {
+  test("runs once per go.mod and reads findings from stderr", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["ineffassign", "./..."], {
+      stdout: "",
+      stderr: OUTPUT,
+      exitCode: 3,
+    });
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/app");
+    fm.seed("/project/tools/go.mod", "module example.com/tools");
+
+    const issues = await ineffassignRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(runner.cwds).toEqual(["/project", "/project/tools"]);
+    expect(issues.map((i) => i.module)).toEqual([".", ".", "tools", "tools"]);
+    expect(issues[0]?.fingerprint).toBeTruthy();
+  });
+
+  test("throws when packages fail to load", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["ineffassign", "./..."], {
+      stdout: "",
+      stderr: "ineffassign: main.go:3:1: expected declaration",
+      exitCode: 1,
+    });
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/app");
+
+    await expect(
+      ineffassignRunner.run({
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: runner,
+        fileManager: fm,
+      })
+    ).rejects.toThrow("ineffassign failed: ineffassign: main.go:3:1");
+  });
+
+  test("is dropped in favor of golangci-lint's ineffassign findings", () => {
+    expect(ineffassignRunner.supersedes).toEqual(["golangci-lint/ineffassign"]);
+  });
+});
+
+describe("ineffassignRunner.isAvailable", () => {
+  test("returns false when ineffassign is not installed", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["ineffassign", "-h"], { stdout: "", stderr: "", exitCode: 127 });
+    expect(await ineffassignRunner.isAvailable(runner)).toBe(false);
+  });
+
+  test("treats the -h usage exit as installed", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["ineffassign", "-h"], {
+      stdout: "",
+      stderr: "Usage",
+      exitCode: 2,
+    });
+    expect(await ineffassignRunner.isAvailable(runner)).toBe(true);
+  });
+});
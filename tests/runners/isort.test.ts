@@ -0,0 +1,124 @@
+import { describe, expect, test } from "bun:test";
+import type { ResolvedConfig } from "@/config/schema";
+import { isortRunner, parseIsortOutput, usesIsort } from "@/runners/isort";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const PROJECT_DIR = "/project";
+
+const DIFF = [
+  "--- /project/app/main.py:before\t2024-05-01 10:00:00.000000",
+  "+++ /project/app/main.py:after\t2024-05-01 10:00:01.000000",
+  "@@ -3,4 +3,4 @@",
+  "-import sys",
+  " import os",
+  "+import sys",
+  "",
+].join("\n");
+
+const ERRORS = [
+  "ERROR: /project/app/main.py Imports are incorrectly sorted and/or formatted.",
+  "ERROR: /project/app/util.py Imports are incorrectly sorted and/or formatted.",
+  "",
+].join("\n");
+
+function makeConfig(): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+  };
+}
+
+function makeOpts(runner: FakeCommandRunner, files?: string[]) {
+  return {
+    projectDir: PROJECT_DIR,
+    config: makeConfig(),
+    commandRunner: runner,
+    fileManager: new FakeFileManager(),
+    ...(files !== undefined && { files }),
+  };
+}
+
+describe("parseIsortOutput", () => {
+  test("reports each unsorted file at the first line its diff changes", () => {
+    const issues = parseIsortOutput(DIFF, ERRORS, PROJECT_DIR);
+
+    expect(issues.map((i) => [i.file, i.line])).toEqual([
+      ["/project/app/main.py", 3],
+      ["/project/app/util.py", 1],
+    ]);
+    expect(issues[0]).toMatchObject({
+      rule: "isort/unsorted",
+      linter: "isort",
+      severity: "error",
+      message: "Imports are not sorted — run: isort app/main.py",
+    });
+  });
+
+  test("returns [] when every file is sorted", () => {
+    expect(parseIsortOutput("", "", PROJECT_DIR)).toEqual([]);
+  });
+});
+
+describe("usesIsort", () => {
+  test("finds isort settings in pyproject.toml, setup.cfg or .isort.cfg", async () => {
+    const pyproject = new FakeFileManager();
+    pyproject.seed("/project/pyproject.toml", '[tool.isort]\nprofile = "black"\n');
+    const setupCfg = new FakeFileManager();
+    setupCfg.seed("/project/setup.cfg", "[isort]\nknown_first_party = app\n");
+    const ruffOnly = new FakeFileManager();
+    ruffOnly.seed("/project/pyproject.toml", "[tool.ruff]\nline-length = 100\n");
+
+    expect(await usesIsort(PROJECT_DIR, pyproject)).toBe(true);
+    expect(await usesIsort(PROJECT_DIR, setupCfg)).toBe(true);
+    expect(await usesIsort(PROJECT_DIR, ruffOnly)).toBe(false);
+  });
+});
+
+describe("isortRunner", () => {
+  test("checks the project from its root, where isort finds its settings", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["isort", "--check-only", "--diff", "--filter-files", "."], {
+      stdout: DIFF,
+      stderr: ERRORS,
+      exitCode: 1,
+    });
+
+    const issues = await isortRunner.run(makeOpts(runner));
+
+    expect(issues.map((i) => i.file)).toEqual([
+      "/project/app/main.py",
+      "/project/app/util.py",
+    ]);
+    expect(runner.cwds).toEqual([PROJECT_DIR]);
+  });
+
+  test("checks only the changed Python files", async () => {
+    const runner = new FakeCommandRunner();
+
+    await isortRunner.run(makeOpts(runner, ["app/main.py", "README.md"]));
+
+    expect(runner.calls).toEqual([
+      ["isort", "--check-only", "--diff", "--filter-files", "app/main.py"],
+    ]);
+  });
+
+  test("fails on an isort error instead of reporting nothing", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["isort", "--check-only", "--diff", "--filter-files", "."], {
+      stdout: "",
+      stderr: "isort: error: unrecognized arguments\n",
+      exitCode: 2,
+    });
+
+    await expect(isortRunner.run(makeOpts(runner))).rejects.toThrow(
+      "isort failed: isort: error: unrecognized arguments"
+    );
+  });
+});
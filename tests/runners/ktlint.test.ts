@@ -0,0 +1,179 @@
+import { beforeEach, describe, expect, test } from "bun:test";
+import { resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import { ktlintRunner, parseKtlintOutput } from "@/runners/ktlint";
+import { clearResolveToolPathCache } from "@/utils/resolve-tool-path";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+beforeEach(() => {
+  clearResolveToolPathCache();
+});
+
+const FIXTURE_PATH = resolve(import.meta.dir, "../fixtures/ktlint-output.json");
+const PROJECT_DIR = "/project";
+const ROOT_KTLINT = `${PROJECT_DIR}/ktlint`;
+const BIN_KTLINT = `${PROJECT_DIR}/bin/ktlint`;
+
+const FIXTURE_JSON = await Bun.file(FIXTURE_PATH).text();
+
+const NOT_FOUND = { stdout: "", stderr: "not found", exitCode: 127 };
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+/** A runner where only the global ktlint on PATH answers --version */
+function globalKtlint(): FakeCommandRunner {
+  const runner = new FakeCommandRunner();
+  runner.register([ROOT_KTLINT, "--version"], NOT_FOUND);
+  runner.register([BIN_KTLINT, "--version"], NOT_FOUND);
+  return runner;
+}
+
+describe("parseKtlintOutput", () => {
+  test("maps each error to an issue with a ktlint/ rule", () => {
+    const issues = parseKtlintOutput(FIXTURE_JSON, PROJECT_DIR);
+
+    expect(issues.map((i) => [i.rule, i.file, i.line, i.col, i.severity])).toEqual([
+      [
+        "ktlint/standard:no-wildcard-imports",
+        "/project/src/main/kotlin/App.kt",
+        3,
+        1,
+        "error",
+      ],
+      ["ktlint/standard:no-semi", "/project/src/main/kotlin/App.kt", 17, 24, "error"],
+      [
+        "ktlint/standard:no-consecutive-blank-lines",
+        "/project/build.gradle.kts",
+        8,
+        1,
+        "error",
+      ],
+    ]);
+    expect(issues[0]?.message).toBe("Wildcard import");
+  });
+
+  test("returns [] for an empty array or malformed JSON", () => {
+    expect(parseKtlintOutput("[]", PROJECT_DIR)).toEqual([]);
+    expect(parseKtlintOutput("not valid json", PROJECT_DIR)).toEqual([]);
+  });
+});
+
+describe("ktlintRunner", () => {
+  test("lints the project and parses violations despite exit code 1", async () => {
+    const runner = globalKtlint();
+    runner.register(["ktlint", "--reporter=json"], {
+      stdout: FIXTURE_JSON,
+      stderr: "",
+      exitCode: 1,
+    });
+
+    const issues = await ktlintRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    });
+
+    expect(issues).toHaveLength(3);
+    expect(issues[0]?.fingerprint).toBeTruthy();
+  });
+
+  test("prefers a project-local ktlint over the one on PATH", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register([ROOT_KTLINT, "--version"], NOT_FOUND);
+
+    await ktlintRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+      files: ["src/main/kotlin/App.kt", "README.md"],
+    });
+
+    expect(runner.calls).toContainEqual([
+      BIN_KTLINT,
+      "--reporter=json",
+      "src/main/kotlin/App.kt",
+    ]);
+  });
+
+  test("skips when no Kotlin file changed", async () => {
+    const runner = globalKtlint();
+
+    const issues = await ktlintRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+      files: ["main.go"],
+    });
+
+    expect(issues).toEqual([]);
+    expect(runner.calls).toEqual([]);
+  });
+
+  test("throws when ktlint fails without JSON output", async () => {
+    const runner = globalKtlint();
+    runner.register(["ktlint", "--reporter=json"], {
+      stdout: "",
+      stderr: "Error: Invalid value for --reporter",
+      exitCode: 1,
+    });
+
+    await expect(
+      ktlintRunner.run({
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: runner,
+        fileManager: new FakeFileManager(),
+      })
+    ).rejects.toThrow("ktlint failed: Error: Invalid value for --reporter");
+  });
+
+  test("is unavailable when neither a local nor a global ktlint exists", async () => {
+    const runner = globalKtlint();
+    runner.register(["ktlint", "--version"], NOT_FOUND);
+
+    expect(await ktlintRunner.isAvailable(runner, PROJECT_DIR)).toBe(false);
+  });
+
+  test("fix runs ktlint --format", async () => {
+    const runner = globalKtlint();
+
+    await ktlintRunner.fix?.({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    });
+
+    expect(runner.calls).toContainEqual(["ktlint", "--format"]);
+  });
+
+  test("fix formats only the Kotlin files it is given", async () => {
+    const runner = globalKtlint();
+
+    await ktlintRunner.fix?.({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+      files: ["src/Main.kt", "README.md"],
+    });
+
+    expect(runner.calls).toContainEqual(["ktlint", "--format", "src/Main.kt"]);
+  });
+});
@@ -0,0 +1,196 @@
+import { describe, expect, test } from "bun:test";
+import type { ResolvedConfig } from "@/config/schema";
+import {
+  applyHeader,
+  checkHeader,
+  isGeneratedFile,
+  licenseHeaderRunner,
+  renderHeader,
+} from "@/runners/license-header";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const PROJECT_DIR = "/project";
+
+const TEMPLATE = "Copyright {year} Acme Inc.\n\nSPDX-License-Identifier: Apache-2.0\n";
+const GO_HEADER = [
+  "// Copyright 2024 Acme Inc.",
+  "//",
+  "// SPDX-License-Identifier: Apache-2.0",
+];
+
+function makeConfig(
+  values: Partial<ResolvedConfig["values"]> = { license_header: TEMPLATE }
+): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2, ...values },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+  };
+}
+
+describe("renderHeader", () => {
+  test("comments each line and fills in the year", () => {
+    expect(renderHeader(TEMPLATE, "//", 2024)).toEqual(GO_HEADER);
+    expect(renderHeader(TEMPLATE, "#", 2024)[1]).toBe("#");
+  });
+});
+
+describe("checkHeader", () => {
+  test("accepts the header with any year or year range", () => {
+    const content = [...GO_HEADER, "", "package main"].join("\n");
+    const ranged = content.replace("2024", "2019-2024");
+
+    expect(checkHeader(content, TEMPLATE, "//").status).toBe("ok");
+    expect(checkHeader(ranged, TEMPLATE, "//").status).toBe("ok");
+  });
+
+  test("looks for the header after a shebang and Go build constraints", () => {
+    const script = ["#!/usr/bin/env bash", ...renderHeader(TEMPLATE, "#", 2024)];
+    const tagged = ["//go:build linux", "", ...GO_HEADER, "", "package main"];
+
+    expect(checkHeader(script.join("\n"), TEMPLATE, "#")).toEqual({
+      status: "ok",
+      line: 2,
+    });
+    expect(checkHeader(tagged.join("\n"), TEMPLATE, "//")).toEqual({
+      status: "ok",
+      line: 3,
+    });
+  });
+
+  test("tells a different license comment from a missing header", () => {
+    const other = "// Copyright 2020 Other Corp.\n\npackage main\n";
+    const docComment = "// Package main does things.\npackage main\n";
+
+    expect(checkHeader(other, TEMPLATE, "//").status).toBe("mismatch");
+    expect(checkHeader(docComment, TEMPLATE, "//").status).toBe("missing");
+  });
+});
+
+describe("applyHeader", () => {
+  test("inserts the header above a doc comment, keeping it attached", () => {
+    const content = "// Package main does things.\npackage main\n";
+
+    expect(applyHeader(content, TEMPLATE, "//", 2024)).toBe(
+      [...GO_HEADER, "", "// Package main does things.", "package main", ""].join("\n")
+    );
+  });
+
+  test("inserts after Go build constraints", () => {
+    const content = "//go:build linux\n\npackage main\n";
+
+    expect(applyHeader(content, TEMPLATE, "//", 2024)).toBe(
+      ["//go:build linux", "", ...GO_HEADER, "", "package main", ""].join("\n")
+    );
+  });
+
+  test("inserts after a shebang with a blank line between", () => {
+    const content = "#!/bin/sh\necho hi\n";
+
+    expect(applyHeader(content, TEMPLATE, "#", 2024)).toBe(
+      ["#!/bin/sh", "", ...renderHeader(TEMPLATE, "#", 2024), "", "echo hi", ""].join(
+        "\n"
+      )
+    );
+  });
+
+  test("replaces an outdated license comment", () => {
+    const content = "// Copyright 2020 Other Corp.\n// MIT License\n\npackage main\n";
+
+    expect(applyHeader(content, TEMPLATE, "//", 2024)).toBe(
+      [...GO_HEADER, "", "package main", ""].join("\n")
+    );
+  });
+
+  test("leaves a file with the header unchanged", () => {
+    const content = [...GO_HEADER, "", "package main"].join("\n");
+    expect(applyHeader(content, TEMPLATE, "//", 2030)).toBe(content);
+  });
+});
+
+describe("isGeneratedFile", () => {
+  test("recognizes DO NOT EDIT and @generated markers", () => {
+    expect(isGeneratedFile("// Code generated by protoc. DO NOT EDIT.\n")).toBe(true);
+    expect(isGeneratedFile("/* @generated */\nexport {};\n")).toBe(true);
+    expect(isGeneratedFile("package main\n")).toBe(false);
+  });
+});
+
+describe("licenseHeaderRunner", () => {
+  function seedProject(): FakeFileManager {
+    const fm = new FakeFileManager();
+    fm.seed("/project/main.go", [...GO_HEADER, "", "package main"].join("\n"));
+    fm.seed("/project/api/api.go", "package api\n");
+    fm.seed("/project/api/api.pb.go", "// Code generated by protoc. DO NOT EDIT.\n");
+    fm.seed("/project/tool.py", "# Copyright 2020 Other Corp.\n\nimport os\n");
+    fm.seed("/project/empty.py", "");
+    fm.seed("/project/README.md", "# Readme\n");
+    return fm;
+  }
+
+  test("reports missing and mismatched headers, skipping generated files", async () => {
+    const issues = await licenseHeaderRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: new FakeCommandRunner(),
+      fileManager: seedProject(),
+    });
+
+    expect(issues.map((i) => [i.rule, i.file, i.line])).toEqual([
+      ["license-header/missing", "/project/api/api.go", 1],
+      ["license-header/mismatch", "/project/tool.py", 1],
+    ]);
+  });
+
+  test("checks only the configured extensions", async () => {
+    const issues = await licenseHeaderRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig({
+        license_header: TEMPLATE,
+        license_header_extensions: ["py"],
+      }),
+      commandRunner: new FakeCommandRunner(),
+      fileManager: seedProject(),
+    });
+
+    expect(issues.map((i) => i.file)).toEqual(["/project/tool.py"]);
+  });
+
+  test("does nothing without a template", async () => {
+    const issues = await licenseHeaderRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig({}),
+      commandRunner: new FakeCommandRunner(),
+      fileManager: seedProject(),
+    });
+
+    expect(issues).toEqual([]);
+  });
+
+  test("fix inserts and updates headers in place", async () => {
+    const fm = seedProject();
+    const opts = {
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: new FakeCommandRunner(),
+      fileManager: fm,
+    };
+
+    await licenseHeaderRunner.fix?.(opts);
+
+    expect(await licenseHeaderRunner.run(opts)).toEqual([]);
+    expect(await fm.readText("/project/api/api.pb.go")).toBe(
+      "// Code generated by protoc. DO NOT EDIT.\n"
+    );
+    expect(fm.written.map(([path]) => path).sort()).toEqual([
+      "/project/api/api.go",
+      "/project/tool.py",
+    ]);
+  });
+});
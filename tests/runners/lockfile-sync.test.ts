@@ -0,0 +1,168 @@
+import { describe, expect, test } from "bun:test";
+import type { ResolvedConfig } from "@/config/schema";
+import {
+  LOCKFILE_CHECKS,
+  lockfileIssues,
+  lockfileSyncRunner,
+  requirementPins,
+} from "@/runners/lockfile-sync";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const PROJECT_DIR = "/project";
+
+function makeConfig(): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+  };
+}
+
+function checkFor(id: string) {
+  const check = LOCKFILE_CHECKS.find((c) => c.id === id);
+  if (check === undefined) throw new Error(`no ${id} check`);
+  return check;
+}
+
+const NPM_OUT_OF_SYNC =
+  "npm error code EUSAGE\nnpm error\nnpm error `npm ci` can only install packages when your package.json and package-lock.json or npm-shrinkwrap.json are in sync. Please update your lock file with `npm install` before continuing.\n";
+
+describe("requirementPins", () => {
+  test("collects normalized pins, skipping comments, hashes and options", () => {
+    const text = [
+      "--index-url https://pypi.org/simple",
+      "Flask_Login==0.6.3 \\",
+      "    --hash=sha256:abc",
+      "    # via app",
+      "requests[socks]==2.31.0 ; python_version >= '3.8'",
+    ].join("\n");
+    expect(requirementPins(text)).toEqual(["flask-login==0.6.3", "requests==2.31.0"]);
+  });
+});
+
+describe("lockfileIssues", () => {
+  const npm = checkFor("npm");
+
+  test("reports a failed run on the lockfile with the tool's complaint", () => {
+    const issues = lockfileIssues(
+      npm,
+      "/project/package-lock.json",
+      { stdout: "", stderr: NPM_OUT_OF_SYNC, exitCode: 1 },
+      ""
+    );
+    expect(issues).toHaveLength(1);
+    expect(issues[0]).toMatchObject({
+      rule: "lockfile-sync/npm",
+      linter: "lockfile-sync",
+      file: "/project/package-lock.json",
+      line: 1,
+      severity: "error",
+    });
+    expect(issues[0]?.message).toStartWith(
+      "package-lock.json is out of sync with package.json — run: npm install (`npm ci` can only"
+    );
+  });
+
+  test("returns [] for a passing run", () => {
+    const result = { stdout: "", stderr: "", exitCode: 0 };
+    expect(lockfileIssues(npm, "/project/package-lock.json", result, "")).toEqual([]);
+  });
+
+  test("compares pip-compile's dry-run output with requirements.txt", () => {
+    const pip = checkFor("pip-compile");
+    const lockfile = "/project/requirements.txt";
+    const result = {
+      stdout: "",
+      stderr: "requests==2.32.0\n    # via -r requirements.in\nDry-run, so nothing updated.\n",
+      exitCode: 0,
+    };
+    const stale = lockfileIssues(pip, lockfile, result, "requests==2.31.0\n");
+    expect(stale.map((i) => i.message)).toEqual([
+      "requirements.txt is out of sync with requirements.in — run: pip-compile requirements.in",
+    ]);
+    expect(lockfileIssues(pip, lockfile, result, "requests==2.32.0\n")).toEqual([]);
+  });
+});
+
+describe("lockfileSyncRunner", () => {
+  function seed(...files: string[]): FakeFileManager {
+    const fm = new FakeFileManager();
+    for (const file of files) fm.seed(`${PROJECT_DIR}/${file}`, "{}\n");
+    return fm;
+  }
+
+  function opts(fm: FakeFileManager, runner: FakeCommandRunner) {
+    return {
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    };
+  }
+
+  test("applies only with a manifest and its lockfile", async () => {
+    const runner = new FakeCommandRunner();
+    const unlocked = seed("package.json");
+    expect(await lockfileSyncRunner.appliesTo?.(opts(unlocked, runner))).toBe(false);
+    const locked = seed("package.json", "bun.lockb");
+    expect(await lockfileSyncRunner.appliesTo?.(opts(locked, runner))).toBe(true);
+  });
+
+  test("runs the check of each pair present, from the project root", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["npm", "ci", "--dry-run", "--ignore-scripts"], {
+      stdout: "",
+      stderr: NPM_OUT_OF_SYNC,
+      exitCode: 1,
+    });
+    const fm = seed("package.json", "package-lock.json", "go.mod", "go.sum");
+
+    const issues = await lockfileSyncRunner.run(opts(fm, runner));
+
+    expect(runner.calls).toContainEqual(["go", "mod", "verify"]);
+    expect(runner.cwds.every((cwd) => cwd === undefined || cwd === PROJECT_DIR)).toBe(
+      true
+    );
+    expect(issues.map((i) => i.rule)).toEqual(["lockfile-sync/npm"]);
+    expect(typeof issues[0]?.fingerprint).toBe("string");
+  });
+
+  test("skips a pair whose tool is not installed", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["poetry", "--version"], {
+      stdout: "",
+      stderr: "command not found",
+      exitCode: 127,
+    });
+    runner.register(["poetry", "check", "--lock"], {
+      stdout: "",
+      stderr: "pyproject.toml changed significantly since poetry.lock was last generated.",
+      exitCode: 1,
+    });
+
+    const issues = await lockfileSyncRunner.run(
+      opts(seed("pyproject.toml", "poetry.lock"), runner)
+    );
+
+    expect(issues).toEqual([]);
+    expect(runner.calls).not.toContainEqual(["poetry", "check", "--lock"]);
+  });
+
+  test("throws on a network failure so the run is retried", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["bun", "install", "--frozen-lockfile", "--dry-run"], {
+      stdout: "",
+      stderr: "error: ECONNRESET downloading package manifest react",
+      exitCode: 1,
+    });
+    await expect(
+      lockfileSyncRunner.run(opts(seed("package.json", "bun.lock"), runner))
+    ).rejects.toThrow("lockfile-sync failed: error: ECONNRESET");
+  });
+});
@@ -0,0 +1,234 @@
+import { describe, expect, test } from "bun:test";
+import type { ResolvedConfig } from "@/config/schema";
+import {
+  EXTERNAL_LINK_CACHE,
+  headingSlug,
+  markdownLinksRunner,
+  parseMarkdown,
+} from "@/runners/markdown-links";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const PROJECT_DIR = "/project";
+
+function makeConfig(): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+  };
+}
+
+function curlArgs(url: string, head: boolean): string[] {
+  return [
+    "curl",
+    "--silent",
+    "--location",
+    ...(head ? ["--head"] : []),
+    "--output",
+    "/dev/null",
+    "--write-out",
+    "%{http_code}",
+    "--max-time",
+    "10",
+    url,
+  ];
+}
+
+describe("headingSlug", () => {
+  test("follows GitHub's anchors", () => {
+    expect(headingSlug("Quick Start")).toBe("quick-start");
+    expect(headingSlug("Config & CLI flags")).toBe("config--cli-flags");
+    expect(headingSlug("The `check` command")).toBe("the-check-command");
+    expect(headingSlug("See [SPEC-004](docs/x.md)")).toBe("see-spec-004");
+  });
+});
+
+describe("parseMarkdown", () => {
+  test("finds inline, image, reference, auto and HTML links with positions", () => {
+    const doc = parseMarkdown(
+      [
+        "See [the docs](docs/guide.md) and ![logo](img/logo.png 'Logo').",
+        "[ref]: https://example.com/ref",
+        "<https://example.com/auto>",
+        '<img src="img/banner.png">',
+      ].join("\n")
+    );
+    expect(doc.links).toEqual([
+      { target: "docs/guide.md", line: 1, col: 5 },
+      { target: "img/logo.png", line: 1, col: 35 },
+      { target: "https://example.com/ref", line: 2, col: 1 },
+      { target: "https://example.com/auto", line: 3, col: 1 },
+      { target: "img/banner.png", line: 4, col: 1 },
+    ]);
+  });
+
+  test("finds both links of a badge", () => {
+    const doc = parseMarkdown("[![CI](badge.svg)](ci.md)");
+    expect(doc.links.map((l) => l.target)).toEqual(["ci.md", "badge.svg"]);
+  });
+
+  test("skips code blocks, code spans and front matter", () => {
+    const doc = parseMarkdown(
+      [
+        "---",
+        "link: [x](front.md)",
+        "---",
+        "```md",
+        "[in fence](fenced.md)",
+        "```",
+        "Use `[text](code.md)` to link.",
+      ].join("\n")
+    );
+    expect(doc.links).toEqual([]);
+  });
+
+  test("collects heading slugs, numbering duplicates, and HTML anchors", () => {
+    const doc = parseMarkdown(
+      ["# Usage", "## Usage", "Setup", "=====", '<a id="custom"></a>'].join("\n")
+    );
+    expect([...doc.anchors].sort()).toEqual(["custom", "setup", "usage", "usage-1"]);
+  });
+});
+
+describe("markdownLinksRunner.run", () => {
+  test("reports missing files and anchors at the link", async () => {
+    const fm = new FakeFileManager();
+    fm.seed(
+      "/project/docs/README.md",
+      [
+        "# Guide",
+        "[ok](../src/main.ts) [gone](missing.md) [root](/src/main.ts)",
+        "[top](#guide) [nowhere](#nope) [there](other.md#install) [not](other.md#x)",
+      ].join("\n")
+    );
+    fm.seed("/project/docs/other.md", "## Install\n");
+    fm.seed("/project/src/main.ts", "");
+
+    const issues = await markdownLinksRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: new FakeCommandRunner(),
+      fileManager: fm,
+    });
+
+    expect(issues.map((i) => [i.rule, i.line, i.col, i.message])).toEqual([
+      [
+        "markdown-links/missing-file",
+        2,
+        22,
+        "Broken link: docs/missing.md does not exist",
+      ],
+      ["markdown-links/missing-anchor", 3, 15, "Broken link: no heading for #nope"],
+      [
+        "markdown-links/missing-anchor",
+        3,
+        58,
+        "Broken link: no heading for #x in docs/other.md",
+      ],
+    ]);
+    expect(issues[0]?.file).toBe("/project/docs/README.md");
+    expect(issues[0]?.severity).toBe("error");
+  });
+
+  test("checks only the changed markdown files", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/a.md", "[x](missing.md)\n");
+    fm.seed("/project/b.md", "[x](missing.md)\n");
+
+    const issues = await markdownLinksRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: new FakeCommandRunner(),
+      fileManager: fm,
+      files: ["b.md", "src/a.py"],
+    });
+
+    expect(issues.map((i) => i.file)).toEqual(["/project/b.md"]);
+  });
+
+  test("leaves external links alone by default", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/README.md", "[x](https://example.com/gone)\n");
+    const runner = new FakeCommandRunner();
+
+    const issues = await markdownLinksRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(issues).toEqual([]);
+    expect(runner.calls).toEqual([]);
+  });
+});
+
+describe("markdownLinksRunner.run with checkExternal", () => {
+  const GONE = "https://example.com/gone";
+  const OK = "https://example.com/ok";
+  const OFFLINE = "https://unreachable.test/";
+
+  function makeRunner(): FakeCommandRunner {
+    const runner = new FakeCommandRunner();
+    runner.register(curlArgs(GONE, true), { stdout: "404", stderr: "", exitCode: 0 });
+    runner.register(curlArgs(GONE, false), { stdout: "404", stderr: "", exitCode: 0 });
+    runner.register(curlArgs(OK, true), { stdout: "200", stderr: "", exitCode: 0 });
+    // curl exit 6: could not resolve host
+    runner.register(curlArgs(OFFLINE, true), {
+      stdout: "000",
+      stderr: "",
+      exitCode: 6,
+    });
+    return runner;
+  }
+
+  test("reports HTTP errors, not unreachable hosts, and caches statuses", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/README.md", `[a](${GONE}) [b](${OK}) [c](${OFFLINE})\n`);
+
+    const issues = await markdownLinksRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: makeRunner(),
+      fileManager: fm,
+      checkExternal: true,
+    });
+
+    expect(issues.map((i) => [i.rule, i.severity, i.message])).toEqual([
+      [
+        "markdown-links/broken-external",
+        "warning",
+        `Broken link: ${GONE} returned HTTP 404`,
+      ],
+    ]);
+    const cache = JSON.parse(await fm.readText(`/project/${EXTERNAL_LINK_CACHE}`));
+    expect(Object.keys(cache).sort()).toEqual([GONE, OK]);
+  });
+
+  test("reuses a fresh cached status without a request", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/README.md", `[a](${GONE})\n`);
+    fm.seed(
+      `/project/${EXTERNAL_LINK_CACHE}`,
+      JSON.stringify({ [GONE]: { status: 410, checkedAt: Date.now() } })
+    );
+    const runner = new FakeCommandRunner();
+
+    const issues = await markdownLinksRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+      checkExternal: true,
+    });
+
+    expect(runner.calls).toEqual([]);
+    expect(issues[0]?.message).toBe(`Broken link: ${GONE} returned HTTP 410`);
+  });
+});
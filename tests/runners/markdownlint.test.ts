@@ -117,6 +117,21 @@ describe("markdownlintRunner.run", () => {
 
     expect(issues).toHaveLength(0);
   });
+
+  test("points --config at the project's own file when it keeps one", async () => {
+    const runner = new FakeCommandRunner();
+
+    await markdownlintRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig({
+        runners: { markdownlint: { user_config: ".markdownlint.yaml" } },
+      }),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    });
+
+    expect(runner.calls[0]?.slice(-2)).toEqual(["--config", ".markdownlint.yaml"]);
+  });
 });
 
 describe("markdownlintRunner.isAvailable", () => {
@@ -0,0 +1,192 @@
+import { beforeEach, describe, expect, test } from "bun:test";
+import { resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import { parsePhpcsOutput, phpcsRunner } from "@/runners/phpcs";
+import { clearResolveToolPathCache } from "@/utils/resolve-tool-path";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+beforeEach(() => {
+  clearResolveToolPathCache();
+});
+
+const FIXTURE_PATH = resolve(import.meta.dir, "../fixtures/phpcs-output.json");
+const PROJECT_DIR = "/project";
+const VENDOR_PHPCS = `${PROJECT_DIR}/vendor/bin/phpcs`;
+const VENDOR_PHPCBF = `${PROJECT_DIR}/vendor/bin/phpcbf`;
+const DEFAULT_STANDARD = [
+  "--standard=PSR12",
+  "--extensions=php",
+  "--ignore=*/vendor/*,*/node_modules/*",
+];
+
+const FIXTURE_JSON = await Bun.file(FIXTURE_PATH).text();
+
+const NOT_FOUND = { stdout: "", stderr: "not found", exitCode: 127 };
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+/** A runner where only the global phpcs and phpcbf on PATH answer --version */
+function globalPhpcs(): FakeCommandRunner {
+  const runner = new FakeCommandRunner();
+  runner.register([VENDOR_PHPCS, "--version"], NOT_FOUND);
+  runner.register([VENDOR_PHPCBF, "--version"], NOT_FOUND);
+  return runner;
+}
+
+describe("parsePhpcsOutput", () => {
+  test("maps each message to an issue with a phpcs/ sniff rule", () => {
+    const issues = parsePhpcsOutput(FIXTURE_JSON, PROJECT_DIR);
+
+    expect(issues.map((i) => [i.rule, i.file, i.line, i.col, i.severity])).toEqual([
+      [
+        "phpcs/PSR12.Files.FileHeader.SpacingAfterBlock",
+        "/project/src/Controller/Home.php",
+        2,
+        1,
+        "error",
+      ],
+      [
+        "phpcs/Squiz.ControlStructures.ControlSignature.SpaceAfterKeyword",
+        "/project/src/Controller/Home.php",
+        14,
+        9,
+        "error",
+      ],
+      [
+        "phpcs/Generic.Files.LineLength.TooLong",
+        "/project/src/Model/User.php",
+        27,
+        132,
+        "warning",
+      ],
+    ]);
+    expect(issues[1]?.message).toBe("Expected 1 space after IF keyword; 0 found");
+  });
+
+  test("returns [] for a report without files or malformed JSON", () => {
+    expect(parsePhpcsOutput('{"totals":{}}', PROJECT_DIR)).toEqual([]);
+    expect(parsePhpcsOutput("not valid json", PROJECT_DIR)).toEqual([]);
+  });
+});
+
+describe("phpcsRunner", () => {
+  test("checks the project with PSR-12 when it has no ruleset", async () => {
+    const runner = globalPhpcs();
+    runner.register(["phpcs", "--report=json", "-q", ...DEFAULT_STANDARD, "."], {
+      stdout: FIXTURE_JSON,
+      stderr: "",
+      exitCode: 2,
+    });
+
+    const issues = await phpcsRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    });
+
+    expect(issues).toHaveLength(3);
+    expect(issues[0]?.fingerprint).toBeTruthy();
+  });
+
+  test("leaves the standard to an existing phpcs.xml", async () => {
+    const runner = globalPhpcs();
+    const fm = new FakeFileManager();
+    fm.seed("/project/phpcs.xml", "<ruleset/>");
+
+    await phpcsRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(runner.calls).toContainEqual(["phpcs", "--report=json", "-q", "."]);
+  });
+
+  test("prefers vendor/bin/phpcs and passes only changed PHP files", async () => {
+    const runner = new FakeCommandRunner();
+
+    await phpcsRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+      files: ["src/Model/User.php", "README.md"],
+    });
+
+    expect(runner.calls).toContainEqual([
+      VENDOR_PHPCS,
+      "--report=json",
+      "-q",
+      ...DEFAULT_STANDARD,
+      "src/Model/User.php",
+    ]);
+  });
+
+  test("skips when no PHP file changed", async () => {
+    const runner = globalPhpcs();
+
+    const issues = await phpcsRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+      files: ["main.go"],
+    });
+
+    expect(issues).toEqual([]);
+    expect(runner.calls).toEqual([]);
+  });
+
+  test("throws when phpcs fails without a JSON report", async () => {
+    const runner = globalPhpcs();
+    runner.register(["phpcs", "--report=json", "-q", ...DEFAULT_STANDARD, "."], {
+      stdout: "",
+      stderr: 'ERROR: the "PSR12" coding standard is not installed.',
+      exitCode: 3,
+    });
+
+    await expect(
+      phpcsRunner.run({
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: runner,
+        fileManager: new FakeFileManager(),
+      })
+    ).rejects.toThrow('phpcs failed: ERROR: the "PSR12" coding standard');
+  });
+
+  test("is unavailable when neither vendor/bin nor PATH has phpcs", async () => {
+    const runner = globalPhpcs();
+    runner.register(["phpcs", "--version"], NOT_FOUND);
+
+    expect(await phpcsRunner.isAvailable(runner, PROJECT_DIR)).toBe(false);
+  });
+
+  test("fix runs phpcbf with the same standard", async () => {
+    const runner = globalPhpcs();
+
+    await phpcsRunner.fix?.({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    });
+
+    expect(runner.calls).toContainEqual(["phpcbf", "-q", ...DEFAULT_STANDARD, "."]);
+  });
+});
@@ -0,0 +1,133 @@
+import { beforeEach, describe, expect, test } from "bun:test";
+import { resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import { parsePhpstanOutput, phpstanRunner } from "@/runners/phpstan";
+import { clearResolveToolPathCache } from "@/utils/resolve-tool-path";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+beforeEach(() => {
+  clearResolveToolPathCache();
+});
+
+const FIXTURE_PATH = resolve(import.meta.dir, "../fixtures/phpstan-output.json");
+const PROJECT_DIR = "/project";
+const VENDOR_PHPSTAN = `${PROJECT_DIR}/vendor/bin/phpstan`;
+const ANALYSE = ["analyse", "--error-format=json", "--no-progress", "--no-interaction"];
+
+const FIXTURE_JSON = await Bun.file(FIXTURE_PATH).text();
+
+const NOT_FOUND = { stdout: "", stderr: "not found", exitCode: 127 };
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+/** A runner where only the global phpstan on PATH answers --version */
+function globalPhpstan(): FakeCommandRunner {
+  const runner = new FakeCommandRunner();
+  runner.register([VENDOR_PHPSTAN, "--version"], NOT_FOUND);
+  return runner;
+}
+
+function runOpts(runner: FakeCommandRunner, fm = new FakeFileManager()) {
+  return {
+    projectDir: PROJECT_DIR,
+    config: makeConfig(),
+    commandRunner: runner,
+    fileManager: fm,
+  };
+}
+
+describe("parsePhpstanOutput", () => {
+  test("maps each message to an error, keyed by its identifier", () => {
+    const issues = parsePhpstanOutput(FIXTURE_JSON, PROJECT_DIR);
+
+    expect(issues.map((i) => [i.rule, i.file, i.line, i.col, i.severity])).toEqual([
+      ["phpstan/return.type", "/project/src/Controller/Home.php", 21, 1, "error"],
+      ["phpstan/error", "/project/src/Model/User.php", 9, 1, "error"],
+    ]);
+  });
+
+  test("returns [] for a clean run or malformed JSON", () => {
+    expect(parsePhpstanOutput('{"files":[],"errors":[]}', PROJECT_DIR)).toEqual([]);
+    expect(parsePhpstanOutput("not valid json", PROJECT_DIR)).toEqual([]);
+  });
+});
+
+describe("phpstanRunner", () => {
+  test("applies only where a phpstan.neon config exists", async () => {
+    const runner = new FakeCommandRunner();
+    const fm = new FakeFileManager();
+
+    expect(await phpstanRunner.appliesTo?.(runOpts(runner, fm))).toBe(false);
+
+    fm.seed("/project/phpstan.neon.dist", "parameters:\n  level: 6\n");
+    expect(await phpstanRunner.appliesTo?.(runOpts(runner, fm))).toBe(true);
+  });
+
+  test("analyses the project and parses findings despite exit code 1", async () => {
+    const runner = globalPhpstan();
+    runner.register(["phpstan", ...ANALYSE], {
+      stdout: FIXTURE_JSON,
+      stderr: "",
+      exitCode: 1,
+    });
+
+    const issues = await phpstanRunner.run(runOpts(runner));
+
+    expect(issues).toHaveLength(2);
+    expect(issues[0]?.fingerprint).toBeTruthy();
+  });
+
+  test("prefers vendor/bin/phpstan over the one on PATH", async () => {
+    const runner = new FakeCommandRunner();
+
+    await phpstanRunner.run(runOpts(runner));
+
+    expect(runner.calls).toContainEqual([VENDOR_PHPSTAN, ...ANALYSE]);
+  });
+
+  test("throws on a top-level error with no file findings", async () => {
+    const runner = globalPhpstan();
+    runner.register(["phpstan", ...ANALYSE], {
+      stdout: '{"files":[],"errors":["Invalid configuration: level must be 0-9"]}',
+      stderr: "",
+      exitCode: 1,
+    });
+
+    await expect(phpstanRunner.run(runOpts(runner))).rejects.toThrow(
+      "phpstan failed: Invalid configuration: level must be 0-9"
+    );
+  });
+
+  test("throws when phpstan fails without JSON output", async () => {
+    const runner = globalPhpstan();
+    runner.register(["phpstan", ...ANALYSE], {
+      stdout: "",
+      stderr: "PHP Fatal error: Allowed memory size exhausted",
+      exitCode: 255,
+    });
+
+    await expect(phpstanRunner.run(runOpts(runner))).rejects.toThrow(
+      "phpstan failed: PHP Fatal error: Allowed memory size exhausted"
+    );
+  });
+
+  test("is unavailable when neither vendor/bin nor PATH has phpstan", async () => {
+    const runner = globalPhpstan();
+    runner.register(["phpstan", "--version"], NOT_FOUND);
+
+    expect(await phpstanRunner.isAvailable(runner, PROJECT_DIR)).toBe(false);
+  });
+});
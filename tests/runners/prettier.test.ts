@@ -0,0 +1,171 @@
+import { beforeEach, describe, expect, test } from "bun:test";
+import type { ResolvedConfig } from "@/config/schema";
+import {
+  isJsFormatter,
+  parsePrettierCheckOutput,
+  prettierRunner,
+  usesPrettier,
+} from "@/runners/prettier";
+import type { RunOptions } from "@/runners/types";
+import { clearResolveToolPathCache } from "@/utils/resolve-tool-path";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+beforeEach(() => {
+  clearResolveToolPathCache();
+});
+
+const PROJECT_DIR = "/project";
+const LOCAL_PRETTIER = `${PROJECT_DIR}/node_modules/.bin/prettier`;
+
+const CHECK_OUTPUT = [
+  "Checking formatting...",
+  "[warn] src/app.ts",
+  "[warn] README.md",
+  "[warn] Code style issues found in 2 files. Run Prettier with --write to fix.",
+  "",
+].join("\n");
+
+function makeConfig(values: Partial<ResolvedConfig["values"]> = {}): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2, ...values },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+  };
+}
+
+function makeOpts(fm: FakeFileManager, config = makeConfig()): RunOptions {
+  return {
+    projectDir: PROJECT_DIR,
+    config,
+    commandRunner: new FakeCommandRunner(),
+    fileManager: fm,
+  };
+}
+
+function globalPrettier(): FakeCommandRunner {
+  const runner = new FakeCommandRunner();
+  runner.register([LOCAL_PRETTIER, "--version"], {
+    stdout: "",
+    stderr: "not found",
+    exitCode: 127,
+  });
+  return runner;
+}
+
+describe("parsePrettierCheckOutput", () => {
+  test("reports each [warn] file and skips the summary", () => {
+    const issues = parsePrettierCheckOutput(CHECK_OUTPUT, PROJECT_DIR);
+
+    expect(issues.map((i) => [i.rule, i.file, i.line])).toEqual([
+      ["prettier/format", "/project/src/app.ts", 1],
+      ["prettier/format", "/project/README.md", 1],
+    ]);
+    expect(issues[0]?.message).toBe(
+      "File is not Prettier-formatted — run: prettier --write src/app.ts"
+    );
+  });
+
+  test("returns [] when everything is formatted", () => {
+    const output = "Checking formatting...\nAll matched files use Prettier code style!";
+    expect(parsePrettierCheckOutput(output, PROJECT_DIR)).toEqual([]);
+  });
+});
+
+describe("isJsFormatter", () => {
+  test("defaults to prettier where configured and biome elsewhere", async () => {
+    const rc = new FakeFileManager();
+    rc.seed("/project/.prettierrc", "{}");
+    const dep = new FakeFileManager();
+    dep.seed("/project/package.json", '{"devDependencies":{"prettier":"^3.0.0"}}');
+    const plain = new FakeFileManager();
+
+    expect(await usesPrettier(PROJECT_DIR, rc)).toBe(true);
+    expect(await isJsFormatter("prettier", makeOpts(dep))).toBe(true);
+    expect(await isJsFormatter("biome", makeOpts(dep))).toBe(false);
+    expect(await isJsFormatter("prettier", makeOpts(plain))).toBe(false);
+    expect(await isJsFormatter("biome", makeOpts(plain))).toBe(true);
+  });
+
+  test("follows js_formatter when set", async () => {
+    const rc = new FakeFileManager();
+    rc.seed("/project/.prettierrc", "{}");
+    const opts = makeOpts(rc, makeConfig({ js_formatter: "biome" }));
+
+    expect(await isJsFormatter("prettier", opts)).toBe(false);
+    expect(await isJsFormatter("biome", opts)).toBe(true);
+  });
+});
+
+describe("prettierRunner", () => {
+  test("runs prettier --check and reports unformatted files", async () => {
+    const runner = globalPrettier();
+    runner.register(["prettier", "--check", "--ignore-unknown", "."], {
+      stdout: "Checking formatting...\n",
+      stderr: CHECK_OUTPUT.replace("Checking formatting...\n", ""),
+      exitCode: 1,
+    });
+
+    const issues = await prettierRunner.run({
+      ...makeOpts(new FakeFileManager()),
+      commandRunner: runner,
+    });
+
+    expect(runner.cwds.at(-1)).toBe(PROJECT_DIR);
+    expect(issues.map((i) => i.file)).toEqual([
+      "/project/src/app.ts",
+      "/project/README.md",
+    ]);
+  });
+
+  test("checks only changed files prettier formats", async () => {
+    const runner = globalPrettier();
+
+    await prettierRunner.run({
+      ...makeOpts(new FakeFileManager()),
+      commandRunner: runner,
+      files: ["src/app.ts", "main.go"],
+    });
+
+    expect(runner.calls).toContainEqual([
+      "prettier",
+      "--check",
+      "--ignore-unknown",
+      "src/app.ts",
+    ]);
+  });
+
+  test("throws when prettier exits with 2", async () => {
+    const runner = globalPrettier();
+    runner.register(["prettier", "--check", "--ignore-unknown", "."], {
+      stdout: "",
+      stderr: "[error] src/broken.ts: SyntaxError: ';' expected. (3:7)",
+      exitCode: 2,
+    });
+
+    await expect(
+      prettierRunner.run({ ...makeOpts(new FakeFileManager()), commandRunner: runner })
+    ).rejects.toThrow("prettier failed: [error] src/broken.ts");
+  });
+
+  test("fix runs prettier --write over the project", async () => {
+    const runner = globalPrettier();
+
+    await prettierRunner.fix?.({
+      ...makeOpts(new FakeFileManager()),
+      commandRunner: runner,
+    });
+
+    expect(runner.calls).toContainEqual([
+      "prettier",
+      "--write",
+      "--ignore-unknown",
+      ".",
+    ]);
+  });
+});
@@ -30,10 +30,9 @@ function makeConfig(): ResolvedConfig {
 describe("parsePyrightOutput", () => {
   test("returns correct LintIssue[] from fixture", () => {
     // Fixture has 3 diagnostics: error, warning, information
-    // information is skipped, so we expect 2 results
     const issues = parsePyrightOutput(fixtureText, PROJECT_DIR);
 
-    expect(issues).toHaveLength(2);
+    expect(issues).toHaveLength(3);
 
     const errorIssue = issues[0];
     expect(errorIssue).toBeDefined();
@@ -53,9 +52,11 @@ describe("parsePyrightOutput", () => {
     expect(warningIssue.severity).toBe("warning");
     expect(warningIssue.line).toBe(10); // 0-indexed 9 → 1-indexed 10
     expect(warningIssue.col).toBe(5); // 0-indexed 4 → 1-indexed 5
+
+    expect(issues[2]?.severity).toBe("info");
   });
 
-  test("skips information-level diagnostics", () => {
+  test("maps information-level diagnostics to info severity", () => {
     const input = JSON.stringify({
       generalDiagnostics: [
         {
@@ -73,7 +74,8 @@ describe("parsePyrightOutput", () => {
     });
 
     const issues = parsePyrightOutput(input, "/project");
-    expect(issues).toHaveLength(0);
+    expect(issues).toHaveLength(1);
+    expect(issues[0]?.severity).toBe("info");
   });
 
   test("converts 0-indexed lines to 1-indexed", () => {
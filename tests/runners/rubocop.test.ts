@@ -0,0 +1,135 @@
+import { describe, expect, test } from "bun:test";
+import { resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import { parseRubocopOutput, rubocopRunner } from "@/runners/rubocop";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const FIXTURE_PATH = resolve(import.meta.dir, "../fixtures/rubocop-output.json");
+const PROJECT_DIR = "/project";
+const RUBOCOP_ARGS = ["rubocop", "--format", "json", "--force-exclusion"];
+
+const FIXTURE_JSON = await Bun.file(FIXTURE_PATH).text();
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+describe("parseRubocopOutput", () => {
+  test("maps offenses to issues with the cop as rule", () => {
+    const issues = parseRubocopOutput(FIXTURE_JSON, PROJECT_DIR);
+
+    expect(issues.map((i) => [i.rule, i.file, i.line, i.col])).toEqual([
+      ["rubocop/Style/StringLiterals", "/project/app/models/user.rb", 3, 11],
+      ["rubocop/Lint/UselessAssignment", "/project/app/models/user.rb", 8, 5],
+      ["rubocop/Lint/Syntax", "/project/lib/tasks/broken.rake", 12, 1],
+    ]);
+  });
+
+  test("drops the cop name prefix from messages", () => {
+    const [first] = parseRubocopOutput(FIXTURE_JSON, PROJECT_DIR);
+    expect(first?.message).toStartWith("Prefer single-quoted strings");
+  });
+
+  test("maps convention to warning and fatal to error", () => {
+    const issues = parseRubocopOutput(FIXTURE_JSON, PROJECT_DIR);
+    expect(issues.map((i) => i.severity)).toEqual(["warning", "warning", "error"]);
+  });
+
+  test("returns [] for malformed JSON", () => {
+    expect(parseRubocopOutput("not valid json", PROJECT_DIR)).toEqual([]);
+  });
+});
+
+describe("rubocopRunner", () => {
+  test("runs rubocop over the project and parses offenses", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register([...RUBOCOP_ARGS, "."], {
+      stdout: FIXTURE_JSON,
+      stderr: "",
+      exitCode: 1,
+    });
+
+    const issues = await rubocopRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    });
+
+    expect(runner.cwds).toEqual([PROJECT_DIR]);
+    expect(issues).toHaveLength(3);
+    expect(issues[0]?.fingerprint).toBeTruthy();
+  });
+
+  test("checks only changed Ruby files", async () => {
+    const runner = new FakeCommandRunner();
+
+    await rubocopRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+      files: ["app/models/user.rb", "Gemfile", "README.md"],
+    });
+
+    expect(runner.calls).toEqual([[...RUBOCOP_ARGS, "app/models/user.rb", "Gemfile"]]);
+  });
+
+  test("throws when rubocop exits with 2", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register([...RUBOCOP_ARGS, "."], {
+      stdout: "",
+      stderr: "Error: unrecognized cop or department Rails/Foo found in .rubocop.yml",
+      exitCode: 2,
+    });
+
+    await expect(
+      rubocopRunner.run({
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: runner,
+        fileManager: new FakeFileManager(),
+      })
+    ).rejects.toThrow("rubocop failed: Error: unrecognized cop");
+  });
+
+  test("fix applies safe corrections", async () => {
+    const runner = new FakeCommandRunner();
+
+    await rubocopRunner.fix?.({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    });
+
+    expect(runner.calls).toEqual([["rubocop", "-a"]]);
+  });
+
+  test("fix corrects only the Ruby files it is given", async () => {
+    const runner = new FakeCommandRunner();
+
+    await rubocopRunner.fix?.({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+      files: ["lib/app.rb", "README.md"],
+    });
+
+    expect(runner.calls).toEqual([
+      ["rubocop", "-a", "--force-exclusion", "lib/app.rb"],
+    ]);
+  });
+});
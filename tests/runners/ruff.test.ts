@@ -303,9 +303,103 @@ describe("ruffRunner", () => {
     expect(issues).toHaveLength(0);
   });
 
+  test("run checks only changed Python files when given a file list", async () => {
+    const commandRunner = new FakeCommandRunner();
+
+    await ruffRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner,
+      fileManager: new FakeFileManager(),
+      files: ["src/a.py", "README.md", "stubs/b.pyi"],
+    });
+
+    expect(commandRunner.calls).toEqual([
+      ["ruff", "check", "--output-format=json", "src/a.py", "stubs/b.pyi"],
+    ]);
+  });
+
+  test("run skips ruff when no changed file is Python", async () => {
+    const commandRunner = new FakeCommandRunner();
+
+    const issues = await ruffRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner,
+      fileManager: new FakeFileManager(),
+      files: ["README.md"],
+    });
+
+    expect(issues).toEqual([]);
+    expect(commandRunner.calls).toHaveLength(0);
+  });
+
+  test("fix invokes ruff check --fix on the project", async () => {
+    const commandRunner = new FakeCommandRunner();
+
+    await ruffRunner.fix?.({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner,
+      fileManager: new FakeFileManager(),
+    });
+
+    expect(commandRunner.calls).toEqual([["ruff", "check", "--fix", PROJECT_DIR]]);
+  });
+
+  test("fix names only the Python files it is given", async () => {
+    const commandRunner = new FakeCommandRunner();
+
+    await ruffRunner.fix?.({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner,
+      fileManager: new FakeFileManager(),
+      files: ["src/app.py", "README.md"],
+    });
+
+    expect(commandRunner.calls).toEqual([["ruff", "check", "--fix", "src/app.py"]]);
+  });
+
   test("id and name are correct", () => {
     expect(ruffRunner.id).toBe("ruff");
     expect(ruffRunner.name).toBe("Ruff");
     expect(ruffRunner.configFile).toBe("ruff.toml");
   });
+
+  test("previewFix prints the fixed source, even with findings left", async () => {
+    const runner = new FakeCommandRunner();
+    const args = ["ruff", "check", "--fix", "--stdin-filename", "app.py", "-"];
+    runner.register(args, {
+      stdout: "import os\n",
+      stderr: "app.py:1:8: F401 `os` imported but unused\n",
+      exitCode: 1,
+    });
+    const opts = {
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    };
+
+    expect(await ruffRunner.previewFix?.(opts, "app.py", "import os;\n")).toBe(
+      "import os\n"
+    );
+  });
+
+  test("previewFix throws when ruff errors", async () => {
+    const runner = new FakeCommandRunner();
+    const args = ["ruff", "check", "--fix", "--stdin-filename", "app.py", "-"];
+    runner.register(args, { stdout: "", stderr: "invalid config", exitCode: 2 });
+    const opts = {
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    };
+
+    await expect(ruffRunner.previewFix?.(opts, "app.py", "")).rejects.toThrow(
+      "ruff failed: invalid config"
+    );
+  });
 });
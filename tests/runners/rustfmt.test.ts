@@ -0,0 +1,101 @@
+import { describe, expect, test } from "bun:test";
+import { resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import { parseRustfmtOutput, rustfmtRunner } from "@/runners/rustfmt";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const FIXTURE_PATH = resolve(import.meta.dir, "../fixtures/rustfmt-output.txt");
+const PROJECT_DIR = "/project";
+
+const FIXTURE_TEXT = await Bun.file(FIXTURE_PATH).text();
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 4 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+describe("parseRustfmtOutput", () => {
+  test("returns one issue per listed file", () => {
+    const issues = parseRustfmtOutput(FIXTURE_TEXT, PROJECT_DIR);
+    expect(issues).toHaveLength(2);
+
+    const first = issues[0];
+    expect(first).toBeDefined();
+    if (!first) return;
+    expect(first.rule).toBe("rustfmt/format");
+    expect(first.linter).toBe("rustfmt");
+    expect(first.file).toBe("/project/src/main.rs");
+    expect(first.line).toBe(1);
+    expect(first.col).toBe(1);
+    expect(first.severity).toBe("error");
+  });
+
+  test("resolves relative paths against projectDir", () => {
+    const issues = parseRustfmtOutput("src/bin/cli.rs\n", PROJECT_DIR);
+    expect(issues[0]?.file).toBe("/project/src/bin/cli.rs");
+  });
+
+  test("returns [] for empty output", () => {
+    expect(parseRustfmtOutput("", PROJECT_DIR)).toHaveLength(0);
+    expect(parseRustfmtOutput("\n\n", PROJECT_DIR)).toHaveLength(0);
+  });
+});
+
+describe("rustfmtRunner.run", () => {
+  test("runs cargo fmt --check listing files with diffs", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["cargo", "fmt", "--check", "--", "--files-with-diff"], {
+      stdout: FIXTURE_TEXT,
+      stderr: "",
+      exitCode: 1,
+    });
+
+    const issues = await rustfmtRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    });
+
+    expect(runner.calls).toContainEqual([
+      "cargo",
+      "fmt",
+      "--check",
+      "--",
+      "--files-with-diff",
+    ]);
+    expect(issues).toHaveLength(2);
+  });
+});
+
+describe("rustfmtRunner.isAvailable", () => {
+  test("returns true when cargo fmt --version exits 0", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["cargo", "fmt", "--version"], {
+      stdout: "rustfmt 1.8.0-stable",
+      stderr: "",
+      exitCode: 0,
+    });
+    expect(await rustfmtRunner.isAvailable(runner)).toBe(true);
+  });
+
+  test("returns false when cargo is not installed", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["cargo", "fmt", "--version"], {
+      stdout: "",
+      stderr: "not found",
+      exitCode: 127,
+    });
+    expect(await rustfmtRunner.isAvailable(runner)).toBe(false);
+  });
+});
@@ -68,6 +68,18 @@ describe("parseShellcheckOutput", () => {
     expect(error.severity).toBe("error");
   });
 
+  test("maps style and info levels to info severity", () => {
+    const comment = { file: "a.sh", line: 1, column: 1, code: 2250, message: "m" };
+    const stdout = JSON.stringify({
+      comments: [
+        { ...comment, level: "style" },
+        { ...comment, level: "info" },
+      ],
+    });
+    const issues = parseShellcheckOutput(stdout, PROJECT_DIR);
+    expect(issues.map((i) => i.severity)).toEqual(["info", "info"]);
+  });
+
   test("returns [] for empty comments array", () => {
     const issues = parseShellcheckOutput('{"comments":[]}', PROJECT_DIR);
     expect(issues).toHaveLength(0);
@@ -131,6 +143,95 @@ describe("shellcheckRunner.run", () => {
   });
 });
 
+describe("shellcheckRunner.run with a changed-file list", () => {
+  test("checks only the changed shell files", async () => {
+    const runner = new FakeCommandRunner();
+    const fm = new FakeFileManager();
+    fm.seed("scripts/untouched.sh", "#!/bin/bash\n");
+
+    await shellcheckRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+      files: ["scripts/changed.sh", "src/a.py"],
+    });
+
+    expect(runner.calls).toEqual([
+      ["shellcheck", "--format=json1", "scripts/changed.sh"],
+    ]);
+  });
+});
+
+describe("shellcheckRunner.run in shards", () => {
+  test("runs shellcheck once per batch and merges the findings in order", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["shellcheck", "--format=json1", "a.sh", "b.sh"], {
+      stdout: FIXTURE_JSON,
+      stderr: "",
+      exitCode: 1,
+    });
+    runner.register(["shellcheck", "--format=json1", "c.sh"], {
+      stdout: FIXTURE_JSON,
+      stderr: "",
+      exitCode: 1,
+    });
+
+    const issues = await shellcheckRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+      files: ["a.sh", "b.sh", "c.sh"],
+      batchSize: 2,
+    });
+
+    expect(runner.calls).toEqual([
+      ["shellcheck", "--format=json1", "a.sh", "b.sh"],
+      ["shellcheck", "--format=json1", "c.sh"],
+    ]);
+    expect(issues).toHaveLength(4);
+  });
+});
+
+describe("shellcheckRunner.run with shebang scripts", () => {
+  test("checks extensionless executables with a shell shebang", async () => {
+    const runner = new FakeCommandRunner();
+    const fm = new FakeFileManager();
+    fm.seed("/project/scripts/setup.sh", "#!/bin/bash\n");
+    fm.seedExecutable("/project/bin/deploy", "#!/usr/bin/env bash\n");
+    fm.seedExecutable("/project/bin/legacy", "#!/bin/ksh\n");
+
+    await shellcheckRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(runner.calls).toEqual([
+      ["shellcheck", "--format=json1", "scripts/setup.sh", "bin/deploy"],
+    ]);
+  });
+
+  test("recognizes the shells of [config] shebang_shells", async () => {
+    const runner = new FakeCommandRunner();
+    const fm = new FakeFileManager();
+    fm.seedExecutable("/project/bin/legacy", "#!/bin/ksh\n");
+
+    await shellcheckRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig({
+        values: { line_length: 100, indent_width: 2, shebang_shells: ["ksh"] },
+      }),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(runner.calls).toEqual([["shellcheck", "--format=json1", "bin/legacy"]]);
+  });
+});
+
 describe("shellcheckRunner.isAvailable", () => {
   test("returns true when shellcheck --version exits 0", async () => {
     const runner = new FakeCommandRunner();
@@ -130,6 +130,36 @@ describe("shfmtRunner.run", () => {
   });
 });
 
+describe("shfmtRunner.fix", () => {
+  test("rewrites found shell files in place with shfmt -w", async () => {
+    const runner = new FakeCommandRunner();
+    const fm = new FakeFileManager();
+    fm.seed("scripts/script.sh", "#!/bin/bash\necho 'unformatted'");
+
+    await shfmtRunner.fix?.({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(runner.calls).toEqual([["shfmt", "-w", "scripts/script.sh"]]);
+  });
+
+  test("does nothing when no shell files found", async () => {
+    const runner = new FakeCommandRunner();
+
+    await shfmtRunner.fix?.({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    });
+
+    expect(runner.calls).toHaveLength(0);
+  });
+});
+
 describe("shfmtRunner.isAvailable", () => {
   test("returns true when shfmt --version exits 0", async () => {
     const runner = new FakeCommandRunner();
@@ -0,0 +1,143 @@
+import { beforeEach, describe, expect, test } from "bun:test";
+import { resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import {
+  findApiSpecs,
+  isApiSpec,
+  parseSpectralOutput,
+  spectralRunner,
+} from "@/runners/spectral";
+import { clearResolveToolPathCache } from "@/utils/resolve-tool-path";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+beforeEach(() => {
+  clearResolveToolPathCache();
+});
+
+const FIXTURE_PATH = resolve(import.meta.dir, "../fixtures/spectral-output.json");
+const PROJECT_DIR = "/project";
+const LOCAL_SPECTRAL = `${PROJECT_DIR}/node_modules/.bin/spectral`;
+const DEFAULT_RULESET = `${PROJECT_DIR}/.ai-guardrails/cache/spectral-ruleset.yaml`;
+
+const FIXTURE_JSON = await Bun.file(FIXTURE_PATH).text();
+
+const OPENAPI = 'openapi: "3.1.0"\ninfo:\n  title: Pets\n  version: "1"\npaths: {}\n';
+
+function makeConfig(): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+  };
+}
+
+function makeOpts(fm: FakeFileManager, runner: FakeCommandRunner, files?: string[]) {
+  return {
+    projectDir: PROJECT_DIR,
+    config: makeConfig(),
+    commandRunner: runner,
+    fileManager: fm,
+    ...(files !== undefined && { files }),
+  };
+}
+
+describe("parseSpectralOutput", () => {
+  test("maps each result to a 1-based spectral/ issue", () => {
+    const issues = parseSpectralOutput(FIXTURE_JSON, PROJECT_DIR);
+
+    expect(issues.map((i) => [i.rule, i.line, i.col, i.severity])).toEqual([
+      ["spectral/oas3-schema", 8, 9, "error"],
+      ["spectral/operation-operationId", 8, 9, "warning"],
+      ["spectral/info-contact", 2, 6, "info"],
+    ]);
+    expect(issues[0]?.file).toBe("/project/api/openapi.yaml");
+  });
+
+  test("returns [] on malformed input", () => {
+    expect(parseSpectralOutput("No results found!", PROJECT_DIR)).toEqual([]);
+  });
+});
+
+describe("isApiSpec", () => {
+  test("knows API descriptions by name or by their version key", () => {
+    expect(isApiSpec("swagger.json", "{}")).toBe(true);
+    expect(isApiSpec("api/pets.yaml", OPENAPI)).toBe(true);
+    expect(isApiSpec("api/pets.json", '{"openapi": "3.0.3", "paths": {}}')).toBe(true);
+    expect(isApiSpec("deploy/values.yaml", "replicas: 2\n")).toBe(false);
+    expect(isApiSpec("package.json", '{"name": "openapi-tools"}')).toBe(false);
+  });
+});
+
+describe("findApiSpecs", () => {
+  test("keeps only the changed files that are API descriptions", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/api/pets.yaml", OPENAPI);
+    fm.seed("/project/deploy/values.yaml", "replicas: 2\n");
+
+    const specs = await findApiSpecs(fm, PROJECT_DIR, [], [
+      "api/pets.yaml",
+      "deploy/values.yaml",
+      "README.md",
+    ]);
+
+    expect(specs).toEqual(["api/pets.yaml"]);
+  });
+});
+
+describe("spectralRunner", () => {
+  test("lints with the built-in OpenAPI ruleset without a .spectral.*", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/api/openapi.yaml", OPENAPI);
+    const runner = new FakeCommandRunner();
+    const args = [LOCAL_SPECTRAL, "lint", "--format", "json", "--quiet"];
+    runner.register([...args, "--ruleset", DEFAULT_RULESET, "api/openapi.yaml"], {
+      stdout: FIXTURE_JSON,
+      stderr: "",
+      exitCode: 1,
+    });
+
+    const issues = await spectralRunner.run(makeOpts(fm, runner, ["api/openapi.yaml"]));
+
+    expect(issues).toHaveLength(3);
+    expect(fm.written).toContainEqual([DEFAULT_RULESET, 'extends: ["spectral:oas"]\n']);
+  });
+
+  test("leaves the ruleset to spectral when .spectral.yaml exists", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/api/openapi.yaml", OPENAPI);
+    fm.seed("/project/.spectral.yaml", "extends: [spectral:oas, spectral:asyncapi]\n");
+    const runner = new FakeCommandRunner();
+
+    await spectralRunner.run(makeOpts(fm, runner, ["api/openapi.yaml"]));
+
+    expect(runner.calls.at(-1)).toEqual([
+      LOCAL_SPECTRAL,
+      "lint",
+      "--format",
+      "json",
+      "--quiet",
+      "api/openapi.yaml",
+    ]);
+  });
+
+  test("fails when spectral cannot run, instead of reporting nothing", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.spectral.yaml", "extends: [./missing.yaml]\n");
+    fm.seed("/project/api/openapi.yaml", OPENAPI);
+    const runner = new FakeCommandRunner();
+    runner.register(
+      [LOCAL_SPECTRAL, "lint", "--format", "json", "--quiet", "api/openapi.yaml"],
+      { stdout: "", stderr: "Could not read ruleset", exitCode: 2 }
+    );
+
+    await expect(
+      spectralRunner.run(makeOpts(fm, runner, ["api/openapi.yaml"]))
+    ).rejects.toThrow("spectral failed: Could not read ruleset");
+  });
+});
@@ -0,0 +1,250 @@
+import { describe, expect, test } from "bun:test";
+import { resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import {
+  findSqlFiles,
+  parseSqlfluffDialect,
+  parseSqlfluffOutput,
+  sqlfluffDialect,
+  sqlfluffRunner,
+} from "@/runners/sqlfluff";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const FIXTURE_PATH = resolve(import.meta.dir, "../fixtures/sqlfluff-output.json");
+const PROJECT_DIR = "/project";
+
+const FIXTURE_JSON = await Bun.file(FIXTURE_PATH).text();
+
+function makeConfig(values: Partial<ResolvedConfig["values"]> = {}): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2, ...values },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+  };
+}
+
+function seedSql(): FakeFileManager {
+  const fm = new FakeFileManager();
+  fm.seed("/project/models/orders.sql", "select  id as order_id from orders\n");
+  fm.seed("/project/reports/daily.sql", "SELEC id FROM users\n");
+  fm.seed("/project/README.md", "# Warehouse\n");
+  return fm;
+}
+
+describe("parseSqlfluffOutput", () => {
+  test("returns one issue per violation, with the code as the rule", () => {
+    const issues = parseSqlfluffOutput(FIXTURE_JSON, PROJECT_DIR);
+    expect(issues).toHaveLength(3);
+    expect(issues[0]).toEqual({
+      rule: "sqlfluff/LT01",
+      linter: "sqlfluff",
+      file: "/project/models/orders.sql",
+      line: 3,
+      col: 5,
+      message: "Expected only single space before 'AS' keyword. Found '  '.",
+      severity: "error",
+    });
+    expect(issues.map((i) => i.rule)).toEqual([
+      "sqlfluff/LT01",
+      "sqlfluff/AM04",
+      "sqlfluff/PRS",
+    ]);
+    expect(issues[2]?.file).toBe("/project/reports/daily.sql");
+  });
+
+  test("keeps violations sqlfluff marks as warnings as warnings", () => {
+    const issues = parseSqlfluffOutput(FIXTURE_JSON, PROJECT_DIR);
+    expect(issues[1]?.severity).toBe("warning");
+  });
+
+  test("reads line_no/line_pos from older sqlfluff versions", () => {
+    const stdout = JSON.stringify([
+      {
+        filepath: "a.sql",
+        violations: [{ line_no: 4, line_pos: 9, code: "L010", description: "x" }],
+      },
+    ]);
+    const [issue] = parseSqlfluffOutput(stdout, PROJECT_DIR);
+    expect(issue?.line).toBe(4);
+    expect(issue?.col).toBe(9);
+  });
+
+  test("returns [] for malformed JSON", () => {
+    expect(parseSqlfluffOutput("not valid json", PROJECT_DIR)).toEqual([]);
+    expect(parseSqlfluffOutput('[{"filepath":"a.sql"}]', PROJECT_DIR)).toEqual([]);
+  });
+});
+
+describe("parseSqlfluffDialect", () => {
+  test("reads dialect from the [sqlfluff] section", () => {
+    const text = "[sqlfluff]\ntemplater = jinja\ndialect = postgres\n";
+    expect(parseSqlfluffDialect(text)).toBe("postgres");
+  });
+
+  test("ignores dialect keys in other sections", () => {
+    const text = "[sqlfluff:rules]\ndialect = bigquery\n[sqlfluff]\nmax_line_length = 100\n";
+    expect(parseSqlfluffDialect(text)).toBeUndefined();
+  });
+});
+
+describe("sqlfluffDialect", () => {
+  test("defaults to ansi", async () => {
+    const dialect = await sqlfluffDialect(seedSql(), PROJECT_DIR, makeConfig());
+    expect(dialect).toBe("ansi");
+  });
+
+  test("leaves the dialect to a .sqlfluff that sets one", async () => {
+    const fm = seedSql();
+    fm.seed("/project/.sqlfluff", "[sqlfluff]\ndialect = snowflake\n");
+    expect(await sqlfluffDialect(fm, PROJECT_DIR, makeConfig())).toBeUndefined();
+  });
+
+  test("prefers [config] sqlfluff_dialect over .sqlfluff", async () => {
+    const fm = seedSql();
+    fm.seed("/project/.sqlfluff", "[sqlfluff]\ndialect = snowflake\n");
+    const config = makeConfig({ sqlfluff_dialect: "postgres" });
+    expect(await sqlfluffDialect(fm, PROJECT_DIR, config)).toBe("postgres");
+  });
+});
+
+describe("findSqlFiles", () => {
+  test("globs .sql files", async () => {
+    expect(await findSqlFiles(seedSql(), PROJECT_DIR, [])).toEqual([
+      "models/orders.sql",
+      "reports/daily.sql",
+    ]);
+  });
+
+  test("selects SQL files from a changed-file list", async () => {
+    const files = await findSqlFiles(new FakeFileManager(), PROJECT_DIR, [], [
+      "models/orders.sql",
+      "README.md",
+    ]);
+    expect(files).toEqual(["models/orders.sql"]);
+  });
+});
+
+describe("sqlfluffRunner.isAvailable", () => {
+  test("returns true when sqlfluff runs", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["sqlfluff", "--version"], {
+      stdout: "sqlfluff, version 3.2.5",
+      stderr: "",
+      exitCode: 0,
+    });
+    expect(await sqlfluffRunner.isAvailable(runner)).toBe(true);
+  });
+
+  test("returns false when sqlfluff is not installed", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["sqlfluff", "--version"], {
+      stdout: "",
+      stderr: "command not found",
+      exitCode: 127,
+    });
+    expect(await sqlfluffRunner.isAvailable(runner)).toBe(false);
+  });
+});
+
+describe("sqlfluffRunner.run", () => {
+  const lintArgs = [
+    "sqlfluff",
+    "lint",
+    "--format",
+    "json",
+    "--dialect",
+    "ansi",
+    "models/orders.sql",
+    "reports/daily.sql",
+  ];
+
+  test("returns [] without running when there are no SQL files", async () => {
+    const runner = new FakeCommandRunner();
+    const issues = await sqlfluffRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    });
+    expect(issues).toEqual([]);
+    expect(runner.calls).toEqual([]);
+  });
+
+  test("lints every SQL file and fingerprints the findings", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(lintArgs, { stdout: FIXTURE_JSON, stderr: "", exitCode: 1 });
+
+    const issues = await sqlfluffRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: seedSql(),
+    });
+
+    expect(runner.calls).toEqual([lintArgs]);
+    expect(issues).toHaveLength(3);
+    expect(issues.every((i) => typeof i.fingerprint === "string")).toBe(true);
+  });
+
+  test("passes no --dialect when .sqlfluff sets one", async () => {
+    const runner = new FakeCommandRunner();
+    const fm = seedSql();
+    fm.seed("/project/.sqlfluff", "[sqlfluff]\ndialect = postgres\n");
+    await sqlfluffRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+      files: ["reports/daily.sql", "README.md"],
+    });
+    expect(runner.calls).toEqual([
+      ["sqlfluff", "lint", "--format", "json", "reports/daily.sql"],
+    ]);
+  });
+
+  test("throws when sqlfluff itself fails", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(lintArgs, {
+      stdout: "",
+      stderr: "User Error: No dialect was specified.",
+      exitCode: 2,
+    });
+
+    await expect(
+      sqlfluffRunner.run({
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: runner,
+        fileManager: seedSql(),
+      })
+    ).rejects.toThrow("sqlfluff failed: User Error: No dialect was specified.");
+  });
+});
+
+describe("sqlfluffRunner.fix", () => {
+  test("runs sqlfluff fix with the configured dialect", async () => {
+    const runner = new FakeCommandRunner();
+    await sqlfluffRunner.fix?.({
+      projectDir: PROJECT_DIR,
+      config: makeConfig({ sqlfluff_dialect: "bigquery" }),
+      commandRunner: runner,
+      fileManager: seedSql(),
+    });
+    expect(runner.calls).toEqual([
+      [
+        "sqlfluff",
+        "fix",
+        "--dialect",
+        "bigquery",
+        "models/orders.sql",
+        "reports/daily.sql",
+      ],
+    ]);
+  });
+});
@@ -0,0 +1,172 @@
+import { describe, expect, test } from "bun:test";
+import { resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import { parseStaticcheckOutput, staticcheckRunner } from "@/runners/staticcheck";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const FIXTURE_PATH = resolve(import.meta.dir, "../fixtures/staticcheck-output.ndjson");
+const PROJECT_DIR = "/project";
+
+const FIXTURE_NDJSON = await Bun.file(FIXTURE_PATH).text();
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+describe("parseStaticcheckOutput", () => {
+  test("returns correct LintIssue[] from fixture", () => {
+    const issues = parseStaticcheckOutput(FIXTURE_NDJSON, PROJECT_DIR);
+    expect(issues).toHaveLength(2);
+
+    const first = issues[0];
+    expect(first).toBeDefined();
+    if (!first) return;
+    expect(first.rule).toBe("staticcheck/SA4006");
+    expect(first.linter).toBe("staticcheck");
+    expect(first.file).toBe("/project/main.go");
+    expect(first.line).toBe(12);
+    expect(first.col).toBe(2);
+    expect(first.message).toBe("this value of err is never used");
+    expect(first.severity).toBe("error");
+  });
+
+  test("maps non-error severity to warning", () => {
+    const issues = parseStaticcheckOutput(FIXTURE_NDJSON, PROJECT_DIR);
+    expect(issues[1]?.severity).toBe("warning");
+  });
+
+  test("skips ignored diagnostics", () => {
+    const issues = parseStaticcheckOutput(FIXTURE_NDJSON, PROJECT_DIR);
+    expect(issues.some((i) => i.rule === "staticcheck/U1000")).toBe(false);
+  });
+
+  test("resolves relative file paths against the module dir", () => {
+    const line = JSON.stringify({
+      code: "SA1019",
+      severity: "error",
+      location: { file: "api.go", line: 3, column: 1 },
+      message: "deprecated",
+    });
+    const issues = parseStaticcheckOutput(line, "/project/services/api");
+    expect(issues[0]?.file).toBe("/project/services/api/api.go");
+  });
+
+  test("returns [] for empty output", () => {
+    expect(parseStaticcheckOutput("", PROJECT_DIR)).toHaveLength(0);
+  });
+});
+
+describe("staticcheckRunner.run", () => {
+  test("runs once per discovered go.mod with the module as cwd", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["staticcheck", "-f", "json", "./..."], {
+      stdout: "",
+      stderr: "",
+      exitCode: 0,
+    });
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/root");
+    fm.seed("/project/tools/go.mod", "module example.com/tools");
+    fm.seed("/project/vendor/example.com/dep/go.mod", "module example.com/dep");
+
+    await staticcheckRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(runner.calls).toEqual([
+      ["staticcheck", "-f", "json", "./..."],
+      ["staticcheck", "-f", "json", "./..."],
+    ]);
+    expect(runner.cwds).toEqual(["/project", "/project/tools"]);
+  });
+
+  test("targets the Go version of each module's go directive", async () => {
+    const runner = new FakeCommandRunner();
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/root\n\ngo 1.21.5\n");
+
+    await staticcheckRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(runner.calls).toEqual([
+      ["staticcheck", "-f", "json", "-go", "1.21", "./..."],
+    ]);
+  });
+
+  test("skips modules under configured ignore_paths", async () => {
+    const runner = new FakeCommandRunner();
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/root");
+    fm.seed("/project/testdata/go.mod", "module example.com/testdata");
+
+    await staticcheckRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig({ ignorePaths: ["testdata/**"] }),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(runner.cwds).toEqual(["/project"]);
+  });
+
+  test("returns fingerprinted issues from staticcheck output", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["staticcheck", "-f", "json", "./..."], {
+      stdout: FIXTURE_NDJSON,
+      stderr: "",
+      exitCode: 1,
+    });
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/root");
+
+    const issues = await staticcheckRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(issues).toHaveLength(2);
+    expect(issues[0]?.fingerprint).toBeTruthy();
+  });
+});
+
+describe("staticcheckRunner.isAvailable", () => {
+  test("returns true when staticcheck -version exits 0", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["staticcheck", "-version"], {
+      stdout: "staticcheck 2024.1.1 (0.5.1)",
+      stderr: "",
+      exitCode: 0,
+    });
+    expect(await staticcheckRunner.isAvailable(runner)).toBe(true);
+  });
+
+  test("returns false when staticcheck is not installed", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["staticcheck", "-version"], {
+      stdout: "",
+      stderr: "not found",
+      exitCode: 127,
+    });
+    expect(await staticcheckRunner.isAvailable(runner)).toBe(false);
+  });
+});
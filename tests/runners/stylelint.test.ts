@@ -0,0 +1,221 @@
+import { beforeEach, describe, expect, test } from "bun:test";
+import { resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import {
+  findStylelintConfig,
+  parseStylelintOutput,
+  STYLE_GLOB,
+  stylelintRunner,
+} from "@/runners/stylelint";
+import { clearResolveToolPathCache } from "@/utils/resolve-tool-path";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+beforeEach(() => {
+  clearResolveToolPathCache();
+});
+
+const FIXTURE_PATH = resolve(import.meta.dir, "../fixtures/stylelint-output.json");
+const PROJECT_DIR = "/project";
+const LOCAL_STYLELINT = `${PROJECT_DIR}/node_modules/.bin/stylelint`;
+
+const FIXTURE_JSON = await Bun.file(FIXTURE_PATH).text();
+
+const NOT_FOUND = { stdout: "", stderr: "not found", exitCode: 127 };
+
+const LINT_ALL = [
+  "stylelint",
+  "--formatter",
+  "json",
+  "--allow-empty-input",
+  STYLE_GLOB,
+];
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+/** A runner where only the global stylelint on PATH answers --version */
+function globalStylelint(): FakeCommandRunner {
+  const runner = new FakeCommandRunner();
+  runner.register([LOCAL_STYLELINT, "--version"], NOT_FOUND);
+  return runner;
+}
+
+describe("parseStylelintOutput", () => {
+  test("maps each warning to an issue with a stylelint/ rule", () => {
+    const issues = parseStylelintOutput(FIXTURE_JSON, PROJECT_DIR);
+
+    expect(issues.map((i) => [i.rule, i.file, i.line, i.col, i.severity])).toEqual([
+      ["stylelint/color-no-invalid-hex", "/project/styles/app.css", 3, 10, "error"],
+      ["stylelint/property-no-unknown", "/project/styles/app.css", 8, 3, "warning"],
+      ["stylelint/CssSyntaxError", "/project/src/theme.scss", 12, 1, "error"],
+    ]);
+  });
+
+  test("drops the rule name stylelint appends to messages", () => {
+    const [issue] = parseStylelintOutput(FIXTURE_JSON, PROJECT_DIR);
+    expect(issue?.message).toBe('Unexpected invalid hex color "#ff"');
+  });
+
+  test("returns [] for malformed JSON or a non-array", () => {
+    expect(parseStylelintOutput("not valid json", PROJECT_DIR)).toEqual([]);
+    expect(parseStylelintOutput('{"results":[]}', PROJECT_DIR)).toEqual([]);
+  });
+});
+
+describe("findStylelintConfig", () => {
+  test("finds a config file", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.stylelintrc.json", "{}");
+    expect(await findStylelintConfig(PROJECT_DIR, fm)).toBe(".stylelintrc.json");
+  });
+
+  test("finds a stylelint key in package.json, but not a bare dependency", async () => {
+    const withKey = new FakeFileManager();
+    withKey.seed("/project/package.json", '{"stylelint":{"rules":{}}}');
+    expect(await findStylelintConfig(PROJECT_DIR, withKey)).toBe("package.json");
+
+    const withDep = new FakeFileManager();
+    withDep.seed("/project/package.json", '{"devDependencies":{"stylelint":"^16"}}');
+    expect(await findStylelintConfig(PROJECT_DIR, withDep)).toBeNull();
+  });
+});
+
+describe("stylelintRunner", () => {
+  test("appliesTo only a project with a stylelint config", async () => {
+    const opts = {
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: new FakeCommandRunner(),
+    };
+    const fm = new FakeFileManager();
+    expect(await stylelintRunner.appliesTo?.({ ...opts, fileManager: fm })).toBe(false);
+    fm.seed("/project/.stylelintrc.yaml", "rules: {}");
+    expect(await stylelintRunner.appliesTo?.({ ...opts, fileManager: fm })).toBe(true);
+  });
+
+  test("lints every style file through the glob", async () => {
+    const runner = globalStylelint();
+    runner.register(LINT_ALL, {
+      stdout: FIXTURE_JSON,
+      stderr: "",
+      exitCode: 2,
+    });
+
+    const issues = await stylelintRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    });
+
+    expect(issues).toHaveLength(3);
+    expect(issues[0]?.fingerprint).toBeTruthy();
+  });
+
+  test("reads the report from stderr, where stylelint 16 writes it", async () => {
+    const runner = globalStylelint();
+    runner.register(LINT_ALL, {
+      stdout: "",
+      stderr: FIXTURE_JSON,
+      exitCode: 2,
+    });
+
+    const issues = await stylelintRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    });
+
+    expect(issues).toHaveLength(3);
+  });
+
+  test("prefers node_modules/.bin and passes only changed style files", async () => {
+    const runner = new FakeCommandRunner();
+
+    await stylelintRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+      files: ["src/theme.scss", "src/app.ts"],
+    });
+
+    expect(runner.calls).toContainEqual([
+      LOCAL_STYLELINT,
+      "--formatter",
+      "json",
+      "--allow-empty-input",
+      "src/theme.scss",
+    ]);
+  });
+
+  test("skips when no style file changed", async () => {
+    const runner = globalStylelint();
+
+    const issues = await stylelintRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+      files: ["src/app.ts"],
+    });
+
+    expect(issues).toEqual([]);
+    expect(runner.calls.some((c) => c.includes("--formatter"))).toBe(false);
+  });
+
+  test("throws when stylelint itself fails", async () => {
+    const runner = globalStylelint();
+    runner.register(LINT_ALL, {
+      stdout: "",
+      stderr: "No configuration provided for /project/styles/app.css",
+      exitCode: 78,
+    });
+
+    await expect(
+      stylelintRunner.run({
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: runner,
+        fileManager: new FakeFileManager(),
+      })
+    ).rejects.toThrow("stylelint failed: No configuration provided");
+  });
+
+  test("fix runs stylelint --fix over the style files", async () => {
+    const runner = globalStylelint();
+
+    await stylelintRunner.fix?.({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    });
+
+    expect(runner.calls).toContainEqual([
+      "stylelint",
+      "--fix",
+      "--allow-empty-input",
+      STYLE_GLOB,
+    ]);
+  });
+
+  test("isAvailable is false when stylelint is not installed", async () => {
+    const runner = globalStylelint();
+    runner.register(["stylelint", "--version"], NOT_FOUND);
+    expect(await stylelintRunner.isAvailable(runner, PROJECT_DIR)).toBe(false);
+  });
+});
@@ -0,0 +1,126 @@
+import { describe, expect, test } from "bun:test";
+import { resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import { parseSwiftlintOutput, swiftlintRunner } from "@/runners/swiftlint";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const FIXTURE_PATH = resolve(import.meta.dir, "../fixtures/swiftlint-output.json");
+const PROJECT_DIR = "/project";
+const SWIFTLINT_ARGS = ["swiftlint", "lint", "--reporter", "json", "--quiet"];
+
+const FIXTURE_JSON = await Bun.file(FIXTURE_PATH).text();
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+describe("parseSwiftlintOutput", () => {
+  test("maps violations to issues", () => {
+    const issues = parseSwiftlintOutput(FIXTURE_JSON, PROJECT_DIR);
+
+    expect(issues.map((i) => [i.rule, i.file, i.line, i.col, i.severity])).toEqual([
+      [
+        "swiftlint/trailing_whitespace",
+        "/project/Sources/App/AppDelegate.swift",
+        12,
+        1,
+        "warning",
+      ],
+      ["swiftlint/force_cast", "/project/Sources/App/Model.swift", 4, 13, "error"],
+      ["swiftlint/line_length", "/project/Sources/App/Model.swift", 40, 1, "warning"],
+    ]);
+    expect(issues[1]?.message).toBe("Force casts should be avoided");
+  });
+
+  test("returns [] for an empty array or malformed JSON", () => {
+    expect(parseSwiftlintOutput("[]", PROJECT_DIR)).toEqual([]);
+    expect(parseSwiftlintOutput("not valid json", PROJECT_DIR)).toEqual([]);
+  });
+});
+
+describe("swiftlintRunner", () => {
+  test("lints the project and parses violations despite exit code 2", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(SWIFTLINT_ARGS, { stdout: FIXTURE_JSON, stderr: "", exitCode: 2 });
+
+    const issues = await swiftlintRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    });
+
+    expect(runner.cwds).toEqual([PROJECT_DIR]);
+    expect(issues).toHaveLength(3);
+    expect(issues[0]?.fingerprint).toBeTruthy();
+  });
+
+  test("lints only changed Swift files, keeping configured exclusions", async () => {
+    const runner = new FakeCommandRunner();
+
+    await swiftlintRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+      files: ["Sources/App/Model.swift", "main.go"],
+    });
+
+    expect(runner.calls).toEqual([
+      [...SWIFTLINT_ARGS, "--force-exclude", "Sources/App/Model.swift"],
+    ]);
+  });
+
+  test("throws when swiftlint fails without JSON output", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(SWIFTLINT_ARGS, {
+      stdout: "",
+      stderr: "Could not read configuration: .swiftlint.yml",
+      exitCode: 1,
+    });
+
+    await expect(
+      swiftlintRunner.run({
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: runner,
+        fileManager: new FakeFileManager(),
+      })
+    ).rejects.toThrow("swiftlint failed: Could not read configuration");
+  });
+
+  test("is unavailable when swiftlint is not installed", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["swiftlint", "version"], {
+      stdout: "",
+      stderr: "swiftlint: command not found",
+      exitCode: 127,
+    });
+
+    expect(await swiftlintRunner.isAvailable(runner)).toBe(false);
+  });
+
+  test("fix runs swiftlint --fix", async () => {
+    const runner = new FakeCommandRunner();
+
+    await swiftlintRunner.fix?.({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    });
+
+    expect(runner.calls).toEqual([["swiftlint", "--fix"]]);
+  });
+});
@@ -0,0 +1,140 @@
+import { describe, expect, test } from "bun:test";
+import type { ResolvedConfig } from "@/config/schema";
+import { parseTerraformFmtOutput, terraformFmtRunner } from "@/runners/terraform-fmt";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const PROJECT_DIR = "/project";
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+function seedModules(fm: FakeFileManager): void {
+  fm.seed("/project/infra/prod/main.tf", "");
+  fm.seed("/project/infra/prod/modules/vpc/main.tf", "");
+  fm.seed("/project/infra/staging/main.tf", "");
+  fm.seed("/project/infra/staging/terraform.tfvars", "");
+  fm.seed("/project/infra/prod/.terraform/modules/x/main.tf", "");
+}
+
+describe("parseTerraformFmtOutput", () => {
+  test("returns one issue per listed file, relative to the run directory", () => {
+    const issues = parseTerraformFmtOutput(
+      "main.tf\nmodules/vpc/main.tf\n",
+      "/project/infra/prod"
+    );
+    expect(issues).toHaveLength(2);
+    expect(issues[0]?.rule).toBe("terraform-fmt/format");
+    expect(issues[0]?.linter).toBe("terraform-fmt");
+    expect(issues[0]?.severity).toBe("error");
+    expect(issues[1]?.file).toBe("/project/infra/prod/modules/vpc/main.tf");
+  });
+
+  test("returns [] for empty output", () => {
+    expect(parseTerraformFmtOutput("", PROJECT_DIR)).toHaveLength(0);
+  });
+});
+
+describe("terraformFmtRunner.run", () => {
+  test("checks every Terraform file outside ignored paths by name", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(
+      [
+        "terraform",
+        "fmt",
+        "-check",
+        "infra/prod/main.tf",
+        "infra/staging/main.tf",
+        "infra/staging/terraform.tfvars",
+      ],
+      { stdout: "infra/prod/main.tf\n", stderr: "", exitCode: 3 }
+    );
+    const fm = new FakeFileManager();
+    seedModules(fm);
+
+    const issues = await terraformFmtRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig({ ignorePaths: ["infra/prod/modules/**"] }),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    // .terraform and ignore_paths are never passed; no -recursive to reach them
+    expect(runner.cwds).toEqual([PROJECT_DIR]);
+    expect(issues.map((i) => i.file)).toEqual(["/project/infra/prod/main.tf"]);
+  });
+
+  test("throws on a non-zero exit that lists no files", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["terraform", "fmt", "-check", "main.tf"], {
+      stdout: "",
+      stderr: "Error: Invalid block definition",
+      exitCode: 2,
+    });
+    const fm = new FakeFileManager();
+    fm.seed("/project/main.tf", "resource {");
+
+    await expect(
+      terraformFmtRunner.run({
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: runner,
+        fileManager: fm,
+      })
+    ).rejects.toThrow("terraform fmt failed: Error: Invalid block definition");
+  });
+
+  test("returns [] without Terraform files", async () => {
+    const runner = new FakeCommandRunner();
+    const issues = await terraformFmtRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    });
+    expect(issues).toHaveLength(0);
+    expect(runner.calls).toHaveLength(0);
+  });
+});
+
+describe("terraformFmtRunner.fix", () => {
+  test("formats the files outside ignored paths", async () => {
+    const runner = new FakeCommandRunner();
+    const fm = new FakeFileManager();
+    seedModules(fm);
+
+    await terraformFmtRunner.fix?.({
+      projectDir: PROJECT_DIR,
+      config: makeConfig({ ignorePaths: ["infra/staging/**"] }),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(runner.calls).toEqual([
+      ["terraform", "fmt", "infra/prod/main.tf", "infra/prod/modules/vpc/main.tf"],
+    ]);
+  });
+});
+
+describe("terraformFmtRunner.isAvailable", () => {
+  test("returns false when terraform is not installed", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["terraform", "version"], {
+      stdout: "",
+      stderr: "not found",
+      exitCode: 127,
+    });
+    expect(await terraformFmtRunner.isAvailable(runner)).toBe(false);
+  });
+});
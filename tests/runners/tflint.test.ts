@@ -0,0 +1,99 @@
+import { describe, expect, test } from "bun:test";
+import { resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import { parseTflintOutput, tflintRunner } from "@/runners/tflint";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const FIXTURE_PATH = resolve(import.meta.dir, "../fixtures/tflint-output.json");
+const PROJECT_DIR = "/project";
+
+const FIXTURE_JSON = await Bun.file(FIXTURE_PATH).text();
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+describe("parseTflintOutput", () => {
+  test("returns correct LintIssue[] from fixture", () => {
+    const issues = parseTflintOutput(FIXTURE_JSON, "/project/infra");
+    expect(issues).toHaveLength(2);
+
+    const first = issues[0];
+    expect(first).toBeDefined();
+    if (!first) return;
+    expect(first.rule).toBe("tflint/terraform_unused_declarations");
+    expect(first.linter).toBe("tflint");
+    expect(first.file).toBe("/project/infra/variables.tf");
+    expect(first.line).toBe(3);
+    expect(first.col).toBe(1);
+    expect(first.message).toBe('variable "region" is declared but not used');
+    expect(first.severity).toBe("warning");
+  });
+
+  test("maps error severity to error", () => {
+    const issues = parseTflintOutput(FIXTURE_JSON, PROJECT_DIR);
+    const error = issues.find((i) => i.rule === "tflint/aws_instance_invalid_type");
+    expect(error?.severity).toBe("error");
+    expect(error?.col).toBe(19);
+  });
+
+  test("returns [] for an empty issues array", () => {
+    expect(parseTflintOutput('{"issues":[],"errors":[]}', PROJECT_DIR)).toHaveLength(0);
+  });
+
+  test("returns [] for malformed JSON", () => {
+    expect(parseTflintOutput("not valid json", PROJECT_DIR)).toHaveLength(0);
+  });
+});
+
+describe("tflintRunner.run", () => {
+  test("runs tflint in every Terraform directory", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["tflint", "--format", "json"], {
+      stdout: FIXTURE_JSON,
+      stderr: "",
+      exitCode: 2,
+    });
+    const fm = new FakeFileManager();
+    fm.seed("/project/infra/prod/main.tf", "");
+    fm.seed("/project/infra/prod/modules/vpc/main.tf", "");
+    fm.seed("/project/legacy/main.tf", "");
+
+    const issues = await tflintRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig({ ignorePaths: ["legacy/**"] }),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(runner.cwds).toEqual([
+      "/project/infra/prod",
+      "/project/infra/prod/modules/vpc",
+    ]);
+    expect(issues).toHaveLength(4);
+    expect(issues[2]?.file).toBe("/project/infra/prod/modules/vpc/variables.tf");
+  });
+});
+
+describe("tflintRunner.isAvailable", () => {
+  test("returns false when tflint is not installed", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["tflint", "--version"], {
+      stdout: "",
+      stderr: "not found",
+      exitCode: 127,
+    });
+    expect(await tflintRunner.isAvailable(runner)).toBe(false);
+  });
+});
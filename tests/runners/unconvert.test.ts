@@ -0,0 +1,121 @@
+import { describe, expect, test } from "bun:test";
+import type { ResolvedConfig } from "@/config/schema";
+import { parseUnconvertOutput, unconvertRunner } from "@/runners/unconvert";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const PROJECT_DIR = "/project";
+
+const OUTPUT = [
+  "/project/internal/size.go:9:14: unnecessary conversion",
+  "/project/main.go:21:8: unnecessary conversion",
+  "",
+].join("\n");
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+function goModule(): FakeFileManager {
+  const fm = new FakeFileManager();
+  fm.seed("/project/go.mod", "module example.com/app");
+  return fm;
+}
+
+describe("parseUnconvertOutput", () => {
+  test("reports each unnecessary conversion as a warning", () => {
+    const issues = parseUnconvertOutput(OUTPUT, PROJECT_DIR);
+
+    expect(issues).toHaveLength(2);
+    expect(issues[0]).toEqual({
+      rule: "unconvert/unnecessary-conversion",
+      linter: "unconvert",
+      file: "/project/internal/size.go",
+      line: 9,
+      col: 14,
+      message: "unnecessary conversion",
+      severity: "warning",
+    });
+  });
+
+  test("returns [] for empty output", () => {
+    expect(parseUnconvertOutput("", PROJECT_DIR)).toHaveLength(0);
+  });
+});
+
+describe("unconvertRunner.run", () => {
+  test("accepts exit 1 when it reports conversions", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["unconvert", "./..."], {
+      stdout: OUTPUT,
+      stderr: "",
+      exitCode: 1,
+    });
+
+    const issues = await unconvertRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: goModule(),
+    });
+
+    expect(runner.cwds).toEqual(["/project"]);
+    expect(issues.map((i) => i.line)).toEqual([9, 21]);
+    expect(issues[0]?.module).toBe(".");
+  });
+
+  test("throws when it fails without reporting anything", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["unconvert", "./..."], {
+      stdout: "",
+      stderr: "main.go:3:1: expected declaration",
+      exitCode: 1,
+    });
+
+    await expect(
+      unconvertRunner.run({
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: runner,
+        fileManager: goModule(),
+      })
+    ).rejects.toThrow("unconvert failed: main.go:3:1: expected declaration");
+  });
+
+  test("fix rewrites each module with -apply", async () => {
+    const runner = new FakeCommandRunner();
+
+    await unconvertRunner.fix?.({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: goModule(),
+    });
+
+    expect(runner.calls).toEqual([["unconvert", "-apply", "./..."]]);
+  });
+});
+
+describe("unconvertRunner.isAvailable", () => {
+  test("returns false when unconvert is not installed", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["unconvert", "-h"], { stdout: "", stderr: "", exitCode: 127 });
+    expect(await unconvertRunner.isAvailable(runner)).toBe(false);
+  });
+
+  test("treats the -h usage exit as installed", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["unconvert", "-h"], { stdout: "", stderr: "Usage", exitCode: 2 });
+    expect(await unconvertRunner.isAvailable(runner)).toBe(true);
+  });
+});
@@ -0,0 +1,150 @@
+import { describe, expect, test } from "bun:test";
+import type { ResolvedConfig } from "@/config/schema";
+import { parseValeOutput, valeRunner } from "@/runners/vale";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const PROJECT_DIR = "/project";
+
+const OUTPUT = JSON.stringify({
+  "docs/guide.md": [
+    {
+      Check: "Vale.Spelling",
+      Severity: "error",
+      Line: 3,
+      Span: [5, 11],
+      Message: "Did you really mean 'recieve'?",
+    },
+    {
+      Check: "Google.Passive",
+      Severity: "suggestion",
+      Line: 8,
+      Span: [1, 9],
+      Message: "In general, use active voice instead of passive voice ('is run').",
+    },
+  ],
+  "README.rst": [
+    {
+      Check: "Google.We",
+      Severity: "warning",
+      Line: 1,
+      Span: [14, 15],
+      Message: "Try to avoid using first-person plural like 'we'.",
+    },
+  ],
+});
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+describe("parseValeOutput", () => {
+  test("maps each alert to a finding at its line and column", () => {
+    const issues = parseValeOutput(OUTPUT, PROJECT_DIR);
+
+    expect(issues).toHaveLength(3);
+    expect(issues[0]).toEqual({
+      rule: "vale/Vale.Spelling",
+      linter: "vale",
+      file: "/project/docs/guide.md",
+      line: 3,
+      col: 5,
+      message: "Did you really mean 'recieve'?",
+      severity: "error",
+    });
+    expect(issues.map((i) => i.severity)).toEqual(["error", "info", "warning"]);
+    expect(issues[2]?.file).toBe("/project/README.rst");
+  });
+
+  test("returns [] for empty output", () => {
+    expect(parseValeOutput("", PROJECT_DIR)).toEqual([]);
+    expect(parseValeOutput("{}", PROJECT_DIR)).toEqual([]);
+  });
+});
+
+describe("valeRunner", () => {
+  test("applies only where the project keeps a vale config", async () => {
+    const fm = new FakeFileManager();
+    const opts = {
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: new FakeCommandRunner(),
+      fileManager: fm,
+    };
+
+    expect(await valeRunner.appliesTo?.(opts)).toBe(false);
+    fm.seed("/project/.vale.ini", "StylesPath = styles\n");
+    expect(await valeRunner.appliesTo?.(opts)).toBe(true);
+  });
+
+  test("counts as not installed until the styles are synced", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["vale", "--version"], {
+      stdout: "vale version 3.9.1",
+      stderr: "",
+      exitCode: 0,
+    });
+    runner.register(["vale", "ls-config"], {
+      stdout: "",
+      stderr: "E100 [loading config] Runtime error: '/project/styles' does not exist",
+      exitCode: 2,
+    });
+
+    expect(await valeRunner.isAvailable(runner, PROJECT_DIR)).toBe(false);
+  });
+
+  test("lints the markdown and reStructuredText files", async () => {
+    const runner = new FakeCommandRunner();
+    const fm = new FakeFileManager();
+    fm.seed("/project/.vale.ini", "StylesPath = styles\n");
+    fm.seed("/project/docs/guide.md", "# Guide\n");
+    fm.seed("/project/README.rst", "Title\n=====\n");
+    fm.seed("/project/node_modules/pkg/README.md", "# Vendored\n");
+    runner.register(["vale", "--output=JSON", "README.rst", "docs/guide.md"], {
+      stdout: OUTPUT,
+      stderr: "",
+      exitCode: 1,
+    });
+
+    const issues = await valeRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(runner.cwds).toEqual([PROJECT_DIR]);
+    expect(issues).toHaveLength(3);
+    expect(issues[0]?.fingerprint).toBeTruthy();
+  });
+
+  test("throws on a runtime error", async () => {
+    const runner = new FakeCommandRunner();
+    const fm = new FakeFileManager();
+    fm.seed("/project/guide.md", "# Guide\n");
+    runner.register(["vale", "--output=JSON", "guide.md"], {
+      stdout: "",
+      stderr: "E100 style 'Google' does not exist on StylesPath",
+      exitCode: 2,
+    });
+
+    await expect(
+      valeRunner.run({
+        projectDir: PROJECT_DIR,
+        config: makeConfig(),
+        commandRunner: runner,
+        fileManager: fm,
+      })
+    ).rejects.toThrow("vale failed: E100 style 'Google' does not exist on StylesPath");
+  });
+});
@@ -0,0 +1,169 @@
+import { describe, expect, test } from "bun:test";
+import { resolve } from "node:path";
+import type { ResolvedConfig } from "@/config/schema";
+import { findYamlFiles, parseYamllintOutput, yamllintRunner } from "@/runners/yamllint";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const FIXTURE_PATH = resolve(import.meta.dir, "../fixtures/yamllint-output.txt");
+const PROJECT_DIR = "/project";
+
+const FIXTURE_TEXT = await Bun.file(FIXTURE_PATH).text();
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+describe("parseYamllintOutput", () => {
+  test("returns correct LintIssue[] from fixture", () => {
+    const issues = parseYamllintOutput(FIXTURE_TEXT, PROJECT_DIR);
+    expect(issues).toHaveLength(4);
+
+    const first = issues[0];
+    expect(first).toBeDefined();
+    if (!first) return;
+    expect(first.rule).toBe("yamllint/document-start");
+    expect(first.linter).toBe("yamllint");
+    expect(first.file).toBe("/project/.github/workflows/ci.yml");
+    expect(first.line).toBe(1);
+    expect(first.col).toBe(1);
+    expect(first.message).toBe('missing document start "---"');
+    expect(first.severity).toBe("warning");
+  });
+
+  test("maps error level to error severity", () => {
+    const issues = parseYamllintOutput(FIXTURE_TEXT, PROJECT_DIR);
+    const indentation = issues.find((i) => i.rule === "yamllint/indentation");
+    expect(indentation?.severity).toBe("error");
+    expect(indentation?.col).toBe(5);
+  });
+
+  test("keeps parentheses inside the message", () => {
+    const issues = parseYamllintOutput(FIXTURE_TEXT, PROJECT_DIR);
+    const long = issues.find((i) => i.rule === "yamllint/line-length");
+    expect(long?.message).toBe("line too long (134 > 120 characters)");
+  });
+
+  test("reports lines without a rule as syntax errors", () => {
+    const issues = parseYamllintOutput(
+      "a.yaml:2:1: [error] syntax error: mapping values are not allowed here",
+      PROJECT_DIR
+    );
+    expect(issues[0]?.rule).toBe("yamllint/syntax");
+  });
+
+  test("returns [] for empty output", () => {
+    expect(parseYamllintOutput("", PROJECT_DIR)).toHaveLength(0);
+  });
+});
+
+describe("findYamlFiles", () => {
+  test("includes CI config under hidden directories", async () => {
+    const fm = new FakeFileManager();
+    fm.seed(".github/workflows/ci.yml", "on: push\n");
+    fm.seed("deploy/values.yaml", "a: 1\n");
+
+    const files = await findYamlFiles(fm, PROJECT_DIR, []);
+
+    expect(files.sort()).toEqual([".github/workflows/ci.yml", "deploy/values.yaml"]);
+  });
+
+  test("skips vendored paths and ignore_paths entries", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("node_modules/pkg/config.yml", "a: 1\n");
+    fm.seed("charts/generated/manifest.yaml", "a: 1\n");
+    fm.seed("charts/app/values.yaml", "a: 1\n");
+
+    const files = await findYamlFiles(fm, PROJECT_DIR, ["charts/generated/**"]);
+
+    expect(files).toEqual(["charts/app/values.yaml"]);
+  });
+
+  test("skips Helm chart templates, which are not YAML until rendered", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("charts/app/Chart.yaml", "name: app\n");
+    fm.seed("charts/app/values.yaml", "a: 1\n");
+    fm.seed("charts/app/templates/deployment.yaml", "{{- if .Values.a }}\n");
+
+    const files = await findYamlFiles(fm, PROJECT_DIR, []);
+    expect(files.sort()).toEqual(["charts/app/Chart.yaml", "charts/app/values.yaml"]);
+
+    const changed = await findYamlFiles(fm, PROJECT_DIR, [], [
+      "charts/app/templates/deployment.yaml",
+    ]);
+    expect(changed).toEqual([]);
+  });
+
+  test("filters a changed-file list by YAML extension and ignore_paths", async () => {
+    const files = await findYamlFiles(
+      new FakeFileManager(),
+      PROJECT_DIR,
+      ["gen/**"],
+      [".github/workflows/ci.yml", "gen/manifest.yaml", "src/a.py"]
+    );
+
+    expect(files).toEqual([".github/workflows/ci.yml"]);
+  });
+});
+
+describe("yamllintRunner.run", () => {
+  test("returns [] when no YAML files found", async () => {
+    const runner = new FakeCommandRunner();
+
+    const issues = await yamllintRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: runner,
+      fileManager: new FakeFileManager(),
+    });
+
+    expect(issues).toHaveLength(0);
+    expect(runner.calls).toHaveLength(0);
+  });
+
+  test("calls yamllint -f parsable with the found files", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["yamllint", "-f", "parsable", "deploy/values.yaml"], {
+      stdout: FIXTURE_TEXT,
+      stderr: "",
+      exitCode: 1,
+    });
+    const fm = new FakeFileManager();
+    fm.seed("deploy/values.yaml", "a: 1\n");
+    fm.seed("out/generated.yaml", "a: 1\n");
+
+    const issues = await yamllintRunner.run({
+      projectDir: PROJECT_DIR,
+      config: makeConfig({ ignorePaths: ["out/**"] }),
+      commandRunner: runner,
+      fileManager: fm,
+    });
+
+    expect(runner.calls).toEqual([
+      ["yamllint", "-f", "parsable", "deploy/values.yaml"],
+    ]);
+    expect(issues).toHaveLength(4);
+  });
+});
+
+describe("yamllintRunner.isAvailable", () => {
+  test("returns false when yamllint is not installed", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["yamllint", "--version"], {
+      stdout: "",
+      stderr: "not found",
+      exitCode: 127,
+    });
+    expect(await yamllintRunner.isAvailable(runner)).toBe(false);
+  });
+});
@@ -1,8 +1,10 @@
 import { expect } from "bun:test";
 import { Given, Then, When } from "@questi0nm4rk/feats";
 import { checkPipeline } from "@/pipelines/check";
+import { clearResolveToolPathCache } from "@/utils/resolve-tool-path";
 import type { FakeCommandRunner } from "../fakes/fake-command-runner";
 import type { FakeConsole } from "../fakes/fake-console";
+import type { FakeFileManager } from "../fakes/fake-file-manager";
 import {
   checkExitCode,
   makeBaseCtx,
@@ -53,6 +55,408 @@ Given<PipelineWorld>(
   }
 );
 
+function seedReportFlags(
+  world: PipelineWorld,
+  count: number,
+  format: string,
+  output: string
+): void {
+  world.ctx = makeBaseCtx({ flags: { format, output } });
+  (world.ctx.commandRunner as FakeCommandRunner).register(
+    ["ruff", "check", "--output-format=json", "/project"],
+    { stdout: makeRuffIssues(count), stderr: "", exitCode: 1 }
+  );
+}
+
+Given<PipelineWorld>(
+  "a project with {int} lint issue and flags format {string} and output {string}",
+  async (world: PipelineWorld, count: unknown, format: unknown, output: unknown) => {
+    seedReportFlags(world, Number(count), String(format), String(output));
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with {int} lint issues and flags format {string} and output {string}",
+  async (world: PipelineWorld, count: unknown, format: unknown, output: unknown) => {
+    seedReportFlags(world, Number(count), String(format), String(output));
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with no lint issues and timeout flag {string}",
+  async (world: PipelineWorld, timeout: unknown) => {
+    world.ctx = makeBaseCtx({ flags: { timeout: String(timeout) } });
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with no lint issues and jobs flag {string}",
+  async (world: PipelineWorld, jobs: unknown) => {
+    world.ctx = makeBaseCtx({ flags: { jobs: String(jobs) } });
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with no lint issues and max-procs flag {string}",
+  async (world: PipelineWorld, procs: unknown) => {
+    world.ctx = makeBaseCtx({ flags: { maxProcs: String(procs) } });
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with no lint issues and fail-on flag {string}",
+  async (world: PipelineWorld, level: unknown) => {
+    world.ctx = makeBaseCtx({ flags: { failOn: String(level) } });
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with profile {string} and one ruff warning",
+  async (world: PipelineWorld, profile: unknown) => {
+    world.ctx = makeBaseCtx();
+    (world.ctx.fileManager as FakeFileManager).seed(
+      "/project/.ai-guardrails/config.toml",
+      `profile = "${String(profile)}"\n`
+    );
+    const warning = {
+      code: "B006",
+      filename: "/project/app.py",
+      location: { row: 1, column: 1 },
+      message: "Do not use mutable data structures for argument defaults",
+    };
+    (world.ctx.commandRunner as FakeCommandRunner).register(
+      ["ruff", "check", "--output-format=json", "/project"],
+      { stdout: JSON.stringify([warning]), stderr: "", exitCode: 1 }
+    );
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with a guardrails:ignore without a reason and the report-suppressions flag",
+  async (world: PipelineWorld) => {
+    world.ctx = makeBaseCtx({ flags: { reportSuppressions: true } });
+    (world.ctx.fileManager as FakeFileManager).seed(
+      "/project/app.py",
+      "print(1)  # guardrails:ignore ruff/T201\n"
+    );
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with 1 lint issue and disable flag {string}",
+  async (world: PipelineWorld, runners: unknown) => {
+    seedLintIssues(world, 1);
+    world.ctx.flags.disable = String(runners);
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with no lint issues and enable flag {string}",
+  async (world: PipelineWorld, runners: unknown) => {
+    world.ctx = makeBaseCtx({ flags: { enable: String(runners) } });
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with a cached runner result and the clear-cache flag",
+  async (world: PipelineWorld) => {
+    world.ctx = makeBaseCtx({ flags: { clearCache: true } });
+    (world.ctx.fileManager as FakeFileManager).seed(
+      "/project/.ai-guardrails/cache/ruff.json",
+      '{"key":"k","issues":[]}'
+    );
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with no changes since {string}",
+  async (world: PipelineWorld, ref: unknown) => {
+    world.ctx = makeBaseCtx({ flags: { changedSince: String(ref) } });
+    (world.ctx.commandRunner as FakeCommandRunner).register(
+      ["git", "diff", "--name-only", `${String(ref)}...HEAD`],
+      { stdout: "", stderr: "", exitCode: 0 }
+    );
+  }
+);
+
+Given<PipelineWorld>(
+  "a project where git cannot diff against {string}",
+  async (world: PipelineWorld, ref: unknown) => {
+    world.ctx = makeBaseCtx({ flags: { changedSince: String(ref) } });
+    (world.ctx.commandRunner as FakeCommandRunner).register(
+      ["git", "diff", "--name-only", `${String(ref)}...HEAD`],
+      { stdout: "", stderr: "fatal: bad revision", exitCode: 128 }
+    );
+  }
+);
+
+Given<PipelineWorld>(
+  "a Turborepo project where {string} changed since {string}",
+  async (world: PipelineWorld, packages: unknown, ref: unknown) => {
+    clearResolveToolPathCache();
+    world.ctx = makeBaseCtx({ flags: { changedSince: String(ref) } });
+    const fm = world.ctx.fileManager as FakeFileManager;
+    fm.seed("/project/turbo.json", "{}");
+    fm.seed("/project/apps/api/main.py", "import os\n");
+    fm.seed("/project/apps/web/main.py", "import os\n");
+    const items = String(packages)
+      .split(",")
+      .filter((path) => path !== "")
+      .map((path) => ({ name: path, path }));
+    const turbo = "/project/node_modules/.bin/turbo";
+    (world.ctx.commandRunner as FakeCommandRunner).register(
+      [turbo, "ls", `--filter=...[${String(ref)}]`, "--output=json"],
+      { stdout: JSON.stringify({ packages: { items } }), stderr: "", exitCode: 0 }
+    );
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with staged files {string}",
+  async (world: PipelineWorld, files: unknown) => {
+    world.ctx = makeBaseCtx({ flags: { staged: true } });
+    (world.ctx.commandRunner as FakeCommandRunner).register(
+      ["git", "diff", "--cached", "--name-only", "--diff-filter=ACM"],
+      { stdout: String(files).split(",").join("\n"), stderr: "", exitCode: 0 }
+    );
+  }
+);
+
+Given<PipelineWorld>(
+  "a project in staged mode where git fails with {string}",
+  async (world: PipelineWorld, stderr: unknown) => {
+    world.ctx = makeBaseCtx({ flags: { staged: true } });
+    (world.ctx.commandRunner as FakeCommandRunner).register(
+      ["git", "diff", "--cached", "--name-only", "--diff-filter=ACM"],
+      { stdout: "", stderr: String(stderr), exitCode: 128 }
+    );
+  }
+);
+
+/** `file` staged with other content than on disk, checked with `flags` */
+function seedPartiallyStaged(
+  world: PipelineWorld,
+  file: string,
+  flags: Record<string, unknown>
+): void {
+  const copy = `.ai-guardrails/cache/staged/${file}`;
+  world.ctx = makeBaseCtx({
+    flags: { staged: true, format: "json", output: "report.json", ...flags },
+  });
+  const cr = world.ctx.commandRunner as FakeCommandRunner;
+  const listed = { stdout: `${file}\n`, stderr: "", exitCode: 0 };
+  cr.register(
+    ["git", "diff", "--cached", "--name-only", "--diff-filter=ACM"],
+    listed
+  );
+  cr.register(["git", "diff", "--name-only"], listed);
+  cr.register(["git", "show", `:${file}`], {
+    stdout: "import os\n",
+    stderr: "",
+    exitCode: 0,
+  });
+  const issues = JSON.parse(makeRuffIssues(1)) as Array<{ filename: string }>;
+  for (const issue of issues) issue.filename = `/project/${copy}`;
+  cr.register(["ruff", "check", "--output-format=json", copy], {
+    stdout: JSON.stringify(issues),
+    stderr: "",
+    exitCode: 1,
+  });
+}
+
+Given<PipelineWorld>(
+  "a project where {string} is staged with other content than on disk",
+  async (world: PipelineWorld, path: unknown) => {
+    seedPartiallyStaged(world, String(path), {});
+  }
+);
+
+Given<PipelineWorld>(
+  "a project where {string} is staged with other content and the path flag {string}",
+  async (world: PipelineWorld, path: unknown, dir: unknown) => {
+    seedPartiallyStaged(world, String(path), { path: String(dir) });
+    (world.ctx.fileManager as FakeFileManager).seed(`/project/${String(dir)}/x`, "");
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with the staged and changed-since flags",
+  async (world: PipelineWorld) => {
+    world.ctx = makeBaseCtx({ flags: { staged: true, changedSince: "origin/main" } });
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with no lint issues and the since-last-run flag",
+  async (world: PipelineWorld) => {
+    world.ctx = makeBaseCtx({ flags: { sinceLastRun: true } });
+    (world.ctx.commandRunner as FakeCommandRunner).register(
+      ["ruff", "check", "--output-format=json", "/project"],
+      { stdout: "[]", stderr: "", exitCode: 0 }
+    );
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with no lint issues and the quiet and since-last-run flags",
+  async (world: PipelineWorld) => {
+    world.ctx = makeBaseCtx({ flags: { quiet: true, sinceLastRun: true } });
+    (world.ctx.commandRunner as FakeCommandRunner).register(
+      ["ruff", "check", "--output-format=json", "/project"],
+      { stdout: "[]", stderr: "", exitCode: 0 }
+    );
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with {int} lint issues and the metrics flag {string}",
+  async (world: PipelineWorld, count: unknown, path: unknown) => {
+    seedLintIssues(world, Number(count));
+    world.ctx.flags = { metrics: String(path) };
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with {int} lint issues and the max-findings flag {string}",
+  async (world: PipelineWorld, count: unknown, max: unknown) => {
+    seedLintIssues(world, Number(count));
+    world.ctx.flags = { maxFindings: String(max) };
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with {int} lint issues and the group-by flag {string}",
+  async (world: PipelineWorld, count: unknown, groupBy: unknown) => {
+    seedLintIssues(world, Number(count));
+    world.ctx.flags = { groupBy: String(groupBy) };
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with {int} lint issue and the require flag {string}",
+  async (world: PipelineWorld, count: unknown, ids: unknown) => {
+    seedLintIssues(world, Number(count));
+    world.ctx.flags = { require: String(ids) };
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with {int} lint issues and the repeat flag {string}",
+  async (world: PipelineWorld, count: unknown, repeat: unknown) => {
+    seedLintIssues(world, Number(count));
+    world.ctx.flags = { repeat: String(repeat) };
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with {int} lint issues and the repeat flag {string} with fix",
+  async (world: PipelineWorld, count: unknown, repeat: unknown) => {
+    seedLintIssues(world, Number(count));
+    world.ctx.flags = { repeat: String(repeat), fix: true };
+  }
+);
+
+Given<PipelineWorld>(
+  "a project without ruff installed and the require flag {string}",
+  async (world: PipelineWorld, ids: unknown) => {
+    world.ctx = makeBaseCtx({ flags: { require: String(ids) } });
+    (world.ctx.commandRunner as FakeCommandRunner).register(["ruff", "--version"], {
+      stdout: "",
+      stderr: "command not found",
+      exitCode: 127,
+    });
+  }
+);
+
+Given<PipelineWorld>(
+  "a project without ruff installed and the quiet-skips flag",
+  async (world: PipelineWorld) => {
+    world.ctx = makeBaseCtx({ flags: { quietSkips: true } });
+    (world.ctx.commandRunner as FakeCommandRunner).register(["ruff", "--version"], {
+      stdout: "",
+      stderr: "command not found",
+      exitCode: 127,
+    });
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with the since-last-run and staged flags",
+  async (world: PipelineWorld) => {
+    world.ctx = makeBaseCtx({ flags: { sinceLastRun: true, staged: true } });
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with a buffer for {string} on stdin with {int} lint issue",
+  async (world: PipelineWorld, path: unknown, count: unknown) => {
+    const copy = `.ai-guardrails/cache/stdin/${String(path)}`;
+    world.ctx = makeBaseCtx({
+      flags: {
+        stdin: true,
+        stdinFilename: String(path),
+        format: "json",
+        output: "report.json",
+      },
+      readStdin: async () => "x = 1\n",
+    });
+    const issues = JSON.parse(makeRuffIssues(Number(count))) as Array<{
+      filename: string;
+    }>;
+    for (const issue of issues) issue.filename = `/project/${copy}`;
+    (world.ctx.commandRunner as FakeCommandRunner).register(
+      ["ruff", "check", "--output-format=json", copy],
+      { stdout: JSON.stringify(issues), stderr: "", exitCode: 1 }
+    );
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with the stdin flag and no filename",
+  async (world: PipelineWorld) => {
+    world.ctx = makeBaseCtx({
+      flags: { stdin: true },
+      readStdin: async () => "x = 1\n",
+    });
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with the stdin and fix flags",
+  async (world: PipelineWorld) => {
+    world.ctx = makeBaseCtx({
+      flags: { stdin: true, stdinFilename: "app.py", fix: true },
+      readStdin: async () => "x = 1\n",
+    });
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with {int} lint issue and the fix flag",
+  async (world: PipelineWorld, count: unknown) => {
+    world.ctx = makeBaseCtx({ flags: { fix: true } });
+    (world.ctx.commandRunner as FakeCommandRunner).register(
+      ["ruff", "check", "--output-format=json", "/project"],
+      { stdout: makeRuffIssues(Number(count)), stderr: "", exitCode: 1 }
+    );
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with {int} lint issues and the update-baseline flag for {string}",
+  async (world: PipelineWorld, count: unknown, path: unknown) => {
+    seedLintIssues(world, Number(count));
+    world.ctx.flags = { updateBaseline: true, baseline: String(path) };
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with the update-baseline and staged flags",
+  async (world: PipelineWorld) => {
+    world.ctx = makeBaseCtx({ flags: { updateBaseline: true, staged: true } });
+  }
+);
+
 Given<PipelineWorld>(
   "a check pipeline result with status {string} and issue count {int}",
   async (world: PipelineWorld, status: unknown, count: unknown) => {
@@ -65,12 +469,99 @@ Given<PipelineWorld>(
   }
 );
 
+Given<PipelineWorld>(
+  "a Go workspace with modules {string} and {string} and the module flag {string}",
+  async (world: PipelineWorld, first: unknown, second: unknown, module: unknown) => {
+    world.ctx = makeBaseCtx({ flags: { module: String(module) } });
+    const fm = world.ctx.fileManager as FakeFileManager;
+    const dirs = [String(first), String(second)];
+    fm.seed("/project/go.work", `use (\n${dirs.map((d) => `\t./${d}\n`).join("")})\n`);
+    for (const dir of dirs) {
+      fm.seed(`/project/${dir}/go.mod`, `module example.com/${dir}`);
+    }
+  }
+);
+
+Given<PipelineWorld>(
+  "a Go workspace with modules {string} and {string} and the path flag {string}",
+  async (world: PipelineWorld, first: unknown, second: unknown, path: unknown) => {
+    world.ctx = makeBaseCtx({ flags: { path: String(path) } });
+    const fm = world.ctx.fileManager as FakeFileManager;
+    const dirs = [String(first), String(second)];
+    fm.seed("/project/go.work", `use (\n${dirs.map((d) => `\t./${d}\n`).join("")})\n`);
+    for (const dir of dirs) {
+      fm.seed(`/project/${dir}/go.mod`, `module example.com/${dir}`);
+    }
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with Python files {string} and the path flag {string}",
+  async (world: PipelineWorld, files: unknown, path: unknown) => {
+    world.ctx = makeBaseCtx({ flags: { path: String(path) } });
+    const fm = world.ctx.fileManager as FakeFileManager;
+    for (const file of String(files).split(",")) fm.seed(`/project/${file}`, "x = 1\n");
+  }
+);
+
+Given<PipelineWorld>(
+  "a project with {int} lint issues and the post_run command {string}",
+  async (world: PipelineWorld, count: unknown, command: unknown) => {
+    seedLintIssues(world, Number(count));
+    (world.ctx.fileManager as FakeFileManager).seed(
+      "/project/.ai-guardrails/config.toml",
+      `[post_run]\ncommand = ${JSON.stringify(String(command).split(" "))}\n`
+    );
+  }
+);
+
+Given<PipelineWorld>("the post_run hook is required", async (world: PipelineWorld) => {
+  const fm = world.ctx.fileManager as FakeFileManager;
+  const path = "/project/.ai-guardrails/config.toml";
+  fm.seed(path, `${await fm.readText(path)}required = true\n`);
+});
+
+Given<PipelineWorld>(
+  "the command {string} fails with {string}",
+  async (world: PipelineWorld, command: unknown, stderr: unknown) => {
+    const runner = world.ctx.commandRunner as FakeCommandRunner;
+    runner.register(String(command).split(" "), {
+      stdout: "",
+      stderr: String(stderr),
+      exitCode: 1,
+    });
+  }
+);
+
+Given<PipelineWorld>("the staged flag is set", async (world: PipelineWorld) => {
+  world.ctx.flags = { ...world.ctx.flags, staged: true };
+});
+
+Given<PipelineWorld>(
+  "the update-baseline flag is set",
+  async (world: PipelineWorld) => {
+    world.ctx.flags = { ...world.ctx.flags, updateBaseline: true };
+  }
+);
+
 // ── When steps ───────────────────────────────────────────────────────────────
 
 When<PipelineWorld>("the check pipeline runs", async (world: PipelineWorld) => {
   world.result = await checkPipeline.run(world.ctx);
 });
 
+When<PipelineWorld>("the check pipeline runs again", async (world: PipelineWorld) => {
+  world.result = await checkPipeline.run(world.ctx);
+});
+
+When<PipelineWorld>(
+  "the check pipeline runs again with baseline {string}",
+  async (world: PipelineWorld, path: unknown) => {
+    world.ctx.flags = { baseline: String(path) };
+    world.result = await checkPipeline.run(world.ctx);
+  }
+);
+
 // ── Then steps ────────────────────────────────────────────────────────────────
 
 Then<PipelineWorld>(
@@ -128,3 +619,211 @@ Then<PipelineWorld>(
     expect(checkExitCode(world.inlineResult)).toBe(Number(code));
   }
 );
+
+Then<PipelineWorld>(
+  "the SARIF file {string} should have a run for {string} with {int} result",
+  async (world: PipelineWorld, path: unknown, tool: unknown, count: unknown) => {
+    const fm = world.ctx.fileManager as FakeFileManager;
+    const written = fm.written.find(([p]) => p === String(path));
+    if (written === undefined) throw new Error(`${String(path)} was not written`);
+    const sarif = JSON.parse(written[1]) as {
+      runs: Array<{ tool: { driver: { name: string } }; results: unknown[] }>;
+    };
+    const run = sarif.runs.find((r) => r.tool.driver.name === String(tool));
+    expect(run?.results).toHaveLength(Number(count));
+  }
+);
+
+Then<PipelineWorld>(
+  "the summary file {string} should fail the check with {int} errors from {string}",
+  async (world: PipelineWorld, path: unknown, errors: unknown, id: unknown) => {
+    const fm = world.ctx.fileManager as FakeFileManager;
+    const written = fm.written.find(([p]) => p === String(path));
+    if (written === undefined) throw new Error(`${String(path)} was not written`);
+    const summary = JSON.parse(written[1]) as {
+      passed: boolean;
+      runners: Array<Record<string, unknown>>;
+      summary: { errors: number };
+    };
+    const runner = summary.runners.find((r) => r.id === String(id));
+    expect(summary.passed).toBe(false);
+    expect(summary.summary.errors).toBe(Number(errors));
+    expect(runner?.errors).toBe(Number(errors));
+    expect(runner).not.toHaveProperty("findings");
+  }
+);
+
+Then<PipelineWorld>(
+  "the metrics file {string} should have {int} records with {int} errors",
+  async (world: PipelineWorld, path: unknown, records: unknown, errors: unknown) => {
+    const fm = world.ctx.fileManager as FakeFileManager;
+    const lines = (await fm.readText(String(path))).trim().split("\n");
+    expect(lines).toHaveLength(Number(records));
+    for (const line of lines) {
+      const record = JSON.parse(line) as { findings: { error: number } };
+      expect(record.findings.error).toBe(Number(errors));
+    }
+  }
+);
+
+Then<PipelineWorld>(
+  "the JSON report {string} should list a finding in {string} at line {int}",
+  async (world: PipelineWorld, path: unknown, file: unknown, line: unknown) => {
+    const fm = world.ctx.fileManager as FakeFileManager;
+    const report = JSON.parse(await fm.readText(String(path))) as {
+      runners: Array<{ findings: Array<{ file: string; line: number }> }>;
+    };
+    const findings = report.runners.flatMap((r) => r.findings);
+    expect(findings).toHaveLength(1);
+    expect(findings[0]?.file).toMatch(new RegExp(`(^|/)${String(file)}$`));
+    expect(findings[0]?.line).toBe(Number(line));
+  }
+);
+
+Then<PipelineWorld>(
+  "the file {string} should have been deleted",
+  async (world: PipelineWorld, path: unknown) => {
+    const fm = world.ctx.fileManager as FakeFileManager;
+    expect(fm.deleted).toContain(`/project/${String(path)}`);
+  }
+);
+
+Then<PipelineWorld>(
+  "the command runner should have run {string}",
+  async (world: PipelineWorld, command: unknown) => {
+    const calls = (world.ctx.commandRunner as FakeCommandRunner).calls;
+    expect(calls.map((args) => args.join(" "))).toContain(String(command));
+  }
+);
+
+Then<PipelineWorld>(
+  "the command runner should have run {string} {int} times",
+  async (world: PipelineWorld, command: unknown, times: unknown) => {
+    const calls = (world.ctx.commandRunner as FakeCommandRunner).calls;
+    const runs = calls.filter((args) => args.join(" ") === String(command));
+    expect(runs).toHaveLength(Number(times));
+  }
+);
+
+Then<PipelineWorld>(
+  "the command runner should not have run {string}",
+  async (world: PipelineWorld, tool: unknown) => {
+    const calls = (world.ctx.commandRunner as FakeCommandRunner).calls;
+    expect(calls.map((args) => args[0])).not.toContain(String(tool));
+  }
+);
+
+Then<PipelineWorld>(
+  "{string} should have run only in {string}",
+  async (world: PipelineWorld, tool: unknown, cwd: unknown) => {
+    const runner = world.ctx.commandRunner as FakeCommandRunner;
+    const cwds = runner.calls
+      .map((args, i) => ({ tool: args[0], cwd: runner.cwds[i] }))
+      .filter((call) => call.tool === String(tool) && call.cwd !== undefined)
+      .map((call) => call.cwd);
+    expect(cwds).toEqual([String(cwd)]);
+  }
+);
+
+Then<PipelineWorld>(
+  "the result message should contain {string}",
+  async (world: PipelineWorld, text: unknown) => {
+    if (world.result === undefined) throw new Error("result not set");
+    expect(world.result.message ?? "").toContain(String(text));
+  }
+);
+
+Then<PipelineWorld>(
+  "the console should have printed {int} findings",
+  async (world: PipelineWorld, count: unknown) => {
+    const printed = (world.ctx.console as FakeConsole).errors
+      .flatMap((text) => text.split("\n"))
+      .filter((line) => line.includes("[ERROR]"));
+    expect(printed).toHaveLength(Number(count));
+  }
+);
+
+Then<PipelineWorld>(
+  "the console should have printed the line {string}",
+  async (world: PipelineWorld, line: unknown) => {
+    const lines = (world.ctx.console as FakeConsole).errors.flatMap((text) =>
+      text.split("\n")
+    );
+    expect(lines).toContain(String(line));
+  }
+);
+
+Then<PipelineWorld>(
+  "the console should have recorded error {string}",
+  async (world: PipelineWorld, message: unknown) => {
+    expect((world.ctx.console as FakeConsole).errors).toContain(String(message));
+  }
+);
+
+Then<PipelineWorld>(
+  "the console should have recorded warning {string}",
+  async (world: PipelineWorld, message: unknown) => {
+    expect((world.ctx.console as FakeConsole).warnings).toContain(String(message));
+  }
+);
+
+Then<PipelineWorld>(
+  "no console warning should contain {string}",
+  async (world: PipelineWorld, text: unknown) => {
+    const warnings = (world.ctx.console as FakeConsole).warnings;
+    expect(warnings.filter((line) => line.includes(String(text)))).toEqual([]);
+  }
+);
+
+Then<PipelineWorld>(
+  "a verbose console line should contain {string}",
+  async (world: PipelineWorld, text: unknown) => {
+    const verboses = (world.ctx.console as FakeConsole).verboses;
+    expect(verboses.some((line) => line.includes(String(text)))).toBe(true);
+  }
+);
+
+Then<PipelineWorld>(
+  "the console should have recorded note {string}",
+  async (world: PipelineWorld, message: unknown) => {
+    expect((world.ctx.console as FakeConsole).notes).toContain(String(message));
+  }
+);
+
+// info() is the console's stdout channel; everything else is status output
+Then<PipelineWorld>(
+  "nothing should be printed to stdout",
+  async (world: PipelineWorld) => {
+    expect((world.ctx.console as FakeConsole).infos).toEqual([]);
+  }
+);
+
+Then<PipelineWorld>(
+  "the console should have recorded info {string}",
+  async (world: PipelineWorld, message: unknown) => {
+    expect((world.ctx.console as FakeConsole).infos).toContain(String(message));
+  }
+);
+
+Then<PipelineWorld>(
+  "{string} should have received a report with {int} errors on stdin",
+  async (world: PipelineWorld, command: unknown, errors: unknown) => {
+    const runner = world.ctx.commandRunner as FakeCommandRunner;
+    const index = runner.calls.findIndex((args) => args.join(" ") === String(command));
+    const stdin = runner.stdins[index];
+    if (stdin === undefined) throw new Error(`${String(command)} got no stdin`);
+    const report = JSON.parse(stdin) as {
+      passed: boolean;
+      summary: { errors: number };
+    };
+    expect(report.summary.errors).toBe(Number(errors));
+    expect(report.passed).toBe(Number(errors) === 0);
+  }
+);
+
+Then<PipelineWorld>(
+  "the console should have recorded success {string}",
+  async (world: PipelineWorld, message: unknown) => {
+    expect((world.ctx.console as FakeConsole).successes).toContain(String(message));
+  }
+);
@@ -2,15 +2,24 @@ import { describe, expect, test } from "bun:test";
 import {
   buildResolvedConfig,
   MachineConfigSchema,
+  NestedConfigSchema,
   ProjectConfigSchema,
+  withNestedConfigs,
 } from "@/config/schema";
+import type {
+  CommandRunner,
+  RunCommandOptions,
+  RunResult,
+} from "@/infra/command-runner";
 import type { LanguagePlugin } from "@/languages/types";
 import type { BaselineEntry } from "@/models/baseline";
 import type { LintIssue } from "@/models/lint-issue";
 import { BASELINE_PATH } from "@/models/paths";
 import type { LinterRunner, RunOptions } from "@/runners/types";
 import { checkStep } from "@/steps/check-step";
+import { Tracer } from "@/utils/trace";
 import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeConsole } from "../fakes/fake-console";
 import { FakeFileManager } from "../fakes/fake-file-manager";
 
 function makeConfig() {
@@ -185,6 +194,145 @@ function makeBaselineEntry(overrides: Partial<BaselineEntry> = {}): BaselineEntr
   };
 }
 
+describe("checkStep — fail-on severity", () => {
+  const issues = [
+    makeIssue({ fingerprint: "fp-warn", severity: "warning" }),
+    makeIssue({ fingerprint: "fp-info", severity: "info" }),
+  ];
+
+  test("new warnings do not fail the default error threshold", async () => {
+    const { result, newIssueCount, failingIssueCount } = await checkStep(
+      "/project",
+      [makePlugin(issues)],
+      makeConfig(),
+      new FakeCommandRunner(),
+      new FakeFileManager()
+    );
+
+    expect(result.status).toBe("ok");
+    expect(result.message).toContain("0 at or above error");
+    expect(newIssueCount).toBe(2);
+    expect(failingIssueCount).toBe(0);
+  });
+
+  test("failOn warning fails on warnings but not info", async () => {
+    const { result, failingIssueCount } = await checkStep(
+      "/project",
+      [makePlugin(issues)],
+      makeConfig(),
+      new FakeCommandRunner(),
+      new FakeFileManager(),
+      undefined,
+      { failOn: "warning" }
+    );
+
+    expect(result.status).toBe("error");
+    expect(failingIssueCount).toBe(1);
+  });
+
+  test("failOn info fails on every new issue", async () => {
+    const { failingIssueCount } = await checkStep(
+      "/project",
+      [makePlugin(issues)],
+      makeConfig(),
+      new FakeCommandRunner(),
+      new FakeFileManager(),
+      undefined,
+      { failOn: "info" }
+    );
+
+    expect(failingIssueCount).toBe(2);
+  });
+});
+
+describe("checkStep — nested configs", () => {
+  const config = withNestedConfigs(makeConfig(), [
+    {
+      dir: "legacy",
+      project: NestedConfigSchema.parse({
+        profile: "lenient",
+        ignore: [{ rule: "ruff/F401", reason: "left as is" }],
+        runners: { "test-runner": { enabled: false } },
+      }),
+    },
+    { dir: "services", project: NestedConfigSchema.parse({ profile: "strict" }) },
+  ]);
+
+  test("drops findings a subtree's config ignores or turns off", async () => {
+    const { issues } = await checkStep(
+      "/project",
+      [
+        makePlugin([
+          makeIssue({ fingerprint: "fp-1", file: "/project/legacy/old.py" }),
+          makeIssue({ fingerprint: "fp-2", file: "/project/main.py" }),
+        ]),
+      ],
+      config,
+      new FakeCommandRunner(),
+      new FakeFileManager()
+    );
+
+    expect(issues.map((i) => i.file)).toEqual(["/project/main.py"]);
+  });
+
+  const warnings = ["/project/services/a.py", "/project/main.py"].map((file, i) =>
+    makeIssue({ fingerprint: `fp-${i}`, file, severity: "warning" })
+  );
+
+  test("fails warnings only where the nearest profile is strict", async () => {
+    const { result, failingIssueCount } = await checkStep(
+      "/project",
+      [makePlugin(warnings)],
+      config,
+      new FakeCommandRunner(),
+      new FakeFileManager()
+    );
+
+    expect(result.status).toBe("error");
+    expect(result.message).toContain("1 at or above their directory's fail-on");
+    expect(failingIssueCount).toBe(1);
+  });
+
+  test("an explicit failOn applies everywhere", async () => {
+    const { failingIssueCount } = await checkStep(
+      "/project",
+      [makePlugin(warnings)],
+      config,
+      new FakeCommandRunner(),
+      new FakeFileManager(),
+      undefined,
+      { failOn: "error" }
+    );
+
+    expect(failingIssueCount).toBe(0);
+  });
+});
+
+describe("checkStep — file classifications", () => {
+  test("drops findings in test files a classification turns off", async () => {
+    const config = buildResolvedConfig(
+      MachineConfigSchema.parse({}),
+      ProjectConfigSchema.parse({
+        classifications: { test: { runners: { "test-runner": { enabled: false } } } },
+      })
+    );
+    const { issues } = await checkStep(
+      "/project",
+      [
+        makePlugin([
+          makeIssue({ fingerprint: "fp-1", file: "/project/pkg/api_test.go" }),
+          makeIssue({ fingerprint: "fp-2", file: "/project/pkg/api.go" }),
+        ]),
+      ],
+      config,
+      new FakeCommandRunner(),
+      new FakeFileManager()
+    );
+
+    expect(issues.map((i) => i.file)).toEqual(["/project/pkg/api.go"]);
+  });
+});
+
 describe("checkStep — inline allow comments", () => {
   test("issue with inline allow comment is not counted as new", async () => {
     const fm = new FakeFileManager();
@@ -299,6 +447,35 @@ describe("checkStep — baseline", () => {
     expect(newIssueCount).toBe(1);
   });
 
+  test("reads the baseline from a custom baselinePath", async () => {
+    const fm = new FakeFileManager();
+    const cr = new FakeCommandRunner();
+    const config = makeConfig();
+
+    fm.seed(
+      "/project/custom/baseline.json",
+      JSON.stringify([makeBaselineEntry({ fingerprint: "fp-001" })])
+    );
+
+    const issues = [
+      makeIssue({ fingerprint: "fp-001" }),
+      makeIssue({ fingerprint: "fp-NEW", rule: "ruff/F401" }),
+    ];
+
+    const { newIssueCount, baselined } = await checkStep(
+      "/project",
+      [makePlugin(issues)],
+      config,
+      cr,
+      fm,
+      undefined,
+      { baselinePath: "custom/baseline.json" }
+    );
+
+    expect(newIssueCount).toBe(1);
+    expect([...baselined]).toEqual(["fp-001"]);
+  });
+
   test("treats all issues as new when no baseline file exists", async () => {
     const fm = new FakeFileManager(); // no baseline seeded
     const cr = new FakeCommandRunner();
@@ -337,4 +514,1085 @@ describe("checkStep — baseline", () => {
     expect(result.status).toBe("error");
     expect(newIssueCount).toBe(1);
   });
+
+  test("does not run runners disabled in config", async () => {
+    const fm = new FakeFileManager();
+    const cr = new FakeCommandRunner();
+    const config = buildResolvedConfig(
+      MachineConfigSchema.parse({}),
+      ProjectConfigSchema.parse({ runners: { "test-runner": { enabled: false } } })
+    );
+
+    const cons = new FakeConsole();
+    const { result, issues, skipped, runners } = await checkStep(
+      "/project",
+      [makePlugin([makeIssue()])],
+      config,
+      cr,
+      fm,
+      cons
+    );
+
+    expect(result.status).toBe("ok");
+    expect(issues).toHaveLength(0);
+    expect(skipped).toBe(0);
+    expect(runners.map((r) => r.status)).toEqual(["disabled"]);
+    expect(cons.notes).toEqual(["  Test Runner (disabled)"]);
+    expect(cons.infos).toEqual([]);
+  });
+
+  test("runs opt-in runners only when enabled in config", async () => {
+    const fm = new FakeFileManager();
+    const cr = new FakeCommandRunner();
+    const plugin: LanguagePlugin = {
+      ...makePlugin([]),
+      runners: () => [{ ...makeRunner([makeIssue()]), defaultEnabled: false }],
+    };
+    const optedIn = buildResolvedConfig(
+      MachineConfigSchema.parse({}),
+      ProjectConfigSchema.parse({ runners: { "test-runner": { enabled: true } } })
+    );
+
+    const cons = new FakeConsole();
+    const off = await checkStep("/project", [plugin], makeConfig(), cr, fm, cons);
+    const on = await checkStep("/project", [plugin], optedIn, cr, fm);
+
+    expect(off.runners.map((r) => r.status)).toEqual(["disabled"]);
+    // Off by default is not worth a line; only explicit disables are listed
+    expect(cons.notes).toEqual([]);
+    expect(on.issues).toHaveLength(1);
+  });
+
+  test("runs a runner the project does not use only when enabled in config", async () => {
+    const fm = new FakeFileManager();
+    const cr = new FakeCommandRunner();
+    const plugin: LanguagePlugin = {
+      ...makePlugin([]),
+      runners: () => [
+        { ...makeRunner([makeIssue()]), appliesTo: async () => false },
+      ],
+    };
+    const optedIn = buildResolvedConfig(
+      MachineConfigSchema.parse({}),
+      ProjectConfigSchema.parse({ runners: { "test-runner": { enabled: true } } })
+    );
+
+    const off = await checkStep("/project", [plugin], makeConfig(), cr, fm);
+    const on = await checkStep("/project", [plugin], optedIn, cr, fm);
+
+    expect(off.runners.map((r) => r.status)).toEqual(["disabled"]);
+    expect(on.issues).toHaveLength(1);
+  });
+
+  test("reports each enabled runner with its status", async () => {
+    const fm = new FakeFileManager();
+    const cr = new FakeCommandRunner();
+    const config = makeConfig();
+
+    const { runners, skipped } = await checkStep(
+      "/project",
+      [makePlugin([makeIssue()]), makePlugin([], false)],
+      config,
+      cr,
+      fm
+    );
+
+    expect(runners.map((r) => [r.runnerId, r.name, r.status])).toEqual([
+      ["test-runner", "Test Runner", "ok"],
+      ["test-runner", "Test Runner", "skipped"],
+    ]);
+    expect(runners.every((r) => r.durationMs >= 0)).toBe(true);
+    expect(skipped).toBe(1);
+  });
+
+  test("fails a required runner whose tool is missing, not skips it", async () => {
+    const fm = new FakeFileManager();
+    const cr = new FakeCommandRunner();
+
+    const { result, runners, skipped } = await checkStep(
+      "/project",
+      [makePlugin([], false)],
+      makeConfig(),
+      cr,
+      fm,
+      undefined,
+      { requireTools: new Set(["test-runner"]) }
+    );
+
+    expect(result.status).toBe("error");
+    expect(runners.map((r) => [r.status, r.message])).toEqual([
+      ["error", "required but not installed (Test tool)"],
+    ]);
+    expect(skipped).toBe(0);
+  });
+
+  test("requireTools all fails every missing tool, and others still skip", async () => {
+    const fm = new FakeFileManager();
+    const cr = new FakeCommandRunner();
+    const plugins = [makePlugin([], false)];
+
+    const config = makeConfig();
+
+    const all = await checkStep("/project", plugins, config, cr, fm, undefined, {
+      requireTools: "all",
+    });
+    const other = await checkStep("/project", plugins, config, cr, fm, undefined, {
+      requireTools: new Set(["ruff"]),
+    });
+
+    expect(all.runners.map((r) => r.status)).toEqual(["error"]);
+    expect(other.runners.map((r) => r.status)).toEqual(["skipped"]);
+    expect(other.result.status).toBe("ok");
+  });
+
+  test("quietSkips logs a missing tool at verbose level, still counted", async () => {
+    const cons = new FakeConsole();
+
+    const { skipped } = await checkStep(
+      "/project",
+      [makePlugin([], false)],
+      makeConfig(),
+      new FakeCommandRunner(),
+      new FakeFileManager(),
+      cons,
+      { quietSkips: true }
+    );
+
+    expect(cons.verboses).toContain("  Test Runner not found — skipping (Test tool)");
+    expect(cons.warnings).toEqual([
+      "1 runner(s) skipped — run `ai-guardrails init` to install missing tools",
+    ]);
+    expect(skipped).toBe(1);
+  });
+
+  test("keeps other runners' findings when one runner throws", async () => {
+    const fm = new FakeFileManager();
+    const cr = new FakeCommandRunner();
+    const config = makeConfig();
+    const failing: LanguagePlugin = {
+      ...makePlugin([]),
+      runners() {
+        return [
+          {
+            ...makeRunner([]),
+            id: "broken",
+            name: "Broken",
+            async run(): Promise<LintIssue[]> {
+              throw new Error("exit code 3");
+            },
+          },
+        ];
+      },
+    };
+
+    const { result, issues, runners } = await checkStep(
+      "/project",
+      [failing, makePlugin([makeIssue()])],
+      config,
+      cr,
+      fm
+    );
+
+    expect(issues).toHaveLength(1);
+    expect(result.status).toBe("error");
+    expect(result.message).toContain("1 runner(s) failed: Broken");
+    expect(runners[0]?.status).toBe("error");
+    expect(runners[0]?.message).toBe("exit code 3");
+    expect(runners[1]?.status).toBe("ok");
+  });
+
+  test("fails a runner that exceeds its timeout and keeps the others", async () => {
+    const fm = new FakeFileManager();
+    const cr = new FakeCommandRunner();
+    const hanging: LanguagePlugin = {
+      ...makePlugin([]),
+      runners() {
+        return [
+          {
+            ...makeRunner([]),
+            id: "hang",
+            name: "Hang",
+            run: () => new Promise<LintIssue[]>(() => {}),
+          },
+        ];
+      },
+    };
+
+    const { result, issues, runners } = await checkStep(
+      "/project",
+      [hanging, makePlugin([makeIssue()])],
+      makeConfig(),
+      cr,
+      fm,
+      undefined,
+      { timeout: 0.01 }
+    );
+
+    expect(issues).toHaveLength(1);
+    expect(result.status).toBe("error");
+    expect(result.message).toContain("1 runner(s) failed: Hang");
+    expect(runners[0]?.status).toBe("error");
+    expect(runners[0]?.message).toBe("timed out after 0.01s");
+  });
+
+  test("kills a runner's commands at its deadline", async () => {
+    const fm = new FakeFileManager();
+    const cr = new FakeCommandRunner();
+    cr.register(["slow-tool"], {
+      stdout: "",
+      stderr: "",
+      exitCode: 137,
+      timedOut: true,
+    });
+    const slow: LanguagePlugin = {
+      ...makePlugin([]),
+      runners() {
+        return [
+          {
+            ...makeRunner([]),
+            id: "slow",
+            name: "Slow",
+            async run({ commandRunner }: RunOptions): Promise<LintIssue[]> {
+              await commandRunner.run(["slow-tool"]);
+              return [];
+            },
+          },
+        ];
+      },
+    };
+
+    const { runners } = await checkStep(
+      "/project",
+      [slow],
+      makeConfig(),
+      cr,
+      fm,
+      undefined,
+      { timeout: 5 }
+    );
+
+    const timeout = cr.timeouts[cr.calls.findIndex((args) => args[0] === "slow-tool")];
+    expect(timeout).toBeGreaterThan(0);
+    expect(timeout).toBeLessThanOrEqual(5000);
+    expect(runners[0]?.status).toBe("error");
+    expect(runners[0]?.message).toBe("timed out after 5s");
+  });
+
+  test("does not count time spent waiting for a process slot", async () => {
+    const slow: CommandRunner = {
+      async run() {
+        await new Promise((resolve) => setTimeout(resolve, 60));
+        return { stdout: "", stderr: "", exitCode: 0 };
+      },
+    };
+    const runsTool = (id: string): LinterRunner => ({
+      ...makeRunner([]),
+      id,
+      name: id,
+      async run({ commandRunner }: RunOptions): Promise<LintIssue[]> {
+        await commandRunner.run(["tool", id]);
+        return [];
+      },
+    });
+    const plugin: LanguagePlugin = {
+      ...makePlugin([]),
+      runners: () => [runsTool("a"), runsTool("b")],
+    };
+
+    // b waits ~60ms for a's slot, then runs ~60ms itself: past 0.1s in total
+    const { runners } = await checkStep(
+      "/project",
+      [plugin],
+      makeConfig(),
+      slow,
+      new FakeFileManager(),
+      undefined,
+      { jobs: 2, maxProcs: 1, timeout: 0.1 }
+    );
+
+    expect(runners.map((r) => [r.name, r.status])).toEqual([
+      ["a", "ok"],
+      ["b", "ok"],
+    ]);
+  });
+
+  test("a [runners.<id>] timeout overrides the global one", async () => {
+    const fm = new FakeFileManager();
+    const cr = new FakeCommandRunner();
+    const config = buildResolvedConfig(
+      MachineConfigSchema.parse({}),
+      ProjectConfigSchema.parse({ runners: { "test-runner": { timeout: 0.01 } } })
+    );
+    const hanging: LanguagePlugin = {
+      ...makePlugin([]),
+      runners() {
+        return [{ ...makeRunner([]), run: () => new Promise<LintIssue[]>(() => {}) }];
+      },
+    };
+
+    const { runners } = await checkStep(
+      "/project",
+      [hanging],
+      config,
+      cr,
+      fm,
+      undefined,
+      { timeout: 60 }
+    );
+
+    expect(runners[0]?.message).toBe("timed out after 0.01s");
+  });
+
+  describe("retries", () => {
+    /** A runner failing with each message in turn, then finding `found` */
+    function flakyPlugin(
+      failures: string[],
+      found: LintIssue[] = []
+    ): LanguagePlugin {
+      return {
+        ...makePlugin([]),
+        runners() {
+          return [
+            {
+              ...makeRunner(found),
+              async run(): Promise<LintIssue[]> {
+                const failure = failures.shift();
+                if (failure !== undefined) throw new Error(failure);
+                return found;
+              },
+            },
+          ];
+        },
+      };
+    }
+
+    function retryConfig(retry: Record<string, unknown>) {
+      return buildResolvedConfig(
+        MachineConfigSchema.parse({}),
+        ProjectConfigSchema.parse({ runners: { "test-runner": { retry } } })
+      );
+    }
+
+    test("re-runs after a network error and counts the last run", async () => {
+      const cons = new FakeConsole();
+      const failures = ["tool failed: dial tcp: lookup vuln.go.dev: no such host"];
+
+      const { result, issues, runners } = await checkStep(
+        "/project",
+        [flakyPlugin(failures, [makeIssue()])],
+        retryConfig({ attempts: 3, backoff: 0 }),
+        new FakeCommandRunner(),
+        new FakeFileManager(),
+        cons
+      );
+
+      expect(issues).toHaveLength(1);
+      expect(result.message).toBe("Found 1 new issue(s)");
+      expect(runners[0]?.status).toBe("ok");
+      expect(runners[0]?.attempts).toBe(2);
+      expect(cons.verboses.some((line) => line.includes("attempt 2/3"))).toBe(true);
+    });
+
+    test("reports the error once the attempts run out", async () => {
+      const failures = ["tool failed: ECONNRESET", "tool failed: ECONNRESET"];
+
+      const { runners } = await checkStep(
+        "/project",
+        [flakyPlugin(failures)],
+        retryConfig({ attempts: 2, backoff: 0 }),
+        new FakeCommandRunner(),
+        new FakeFileManager()
+      );
+
+      expect(runners[0]?.status).toBe("error");
+      expect(runners[0]?.message).toBe("tool failed: ECONNRESET");
+      expect(runners[0]?.attempts).toBe(2);
+    });
+
+    test("does not retry an error that is not transient", async () => {
+      const failures = ["tool failed: syntax error", "unreachable"];
+
+      const { runners } = await checkStep(
+        "/project",
+        [flakyPlugin(failures)],
+        retryConfig({ attempts: 3, backoff: 0 }),
+        new FakeCommandRunner(),
+        new FakeFileManager()
+      );
+
+      expect(runners[0]?.message).toBe("tool failed: syntax error");
+      expect(runners[0]?.attempts).toBeUndefined();
+    });
+
+    test("retries a failure matched by a configured regex", async () => {
+      const failures = ["tool failed: registry is busy"];
+
+      const { runners } = await checkStep(
+        "/project",
+        [flakyPlugin(failures)],
+        retryConfig({ attempts: 2, backoff: 0, match: ["registry is busy"] }),
+        new FakeCommandRunner(),
+        new FakeFileManager()
+      );
+
+      expect(runners[0]?.status).toBe("ok");
+    });
+
+    test("runners without a retry policy fail on the first error", async () => {
+      const failures = ["tool failed: ECONNRESET"];
+
+      const { runners } = await checkStep(
+        "/project",
+        [flakyPlugin(failures)],
+        makeConfig(),
+        new FakeCommandRunner(),
+        new FakeFileManager()
+      );
+
+      expect(runners[0]?.status).toBe("error");
+    });
+  });
+
+  test("drops findings superseded by a runner that ran", async () => {
+    const fm = new FakeFileManager();
+    const cr = new FakeCommandRunner();
+    const wrapped = makeIssue({ rule: "meta/sec", linter: "meta", fingerprint: "m1" });
+    const direct = makeIssue({ rule: "sec/G101", linter: "sec", fingerprint: "s1" });
+    const plugin = (available: boolean): LanguagePlugin => ({
+      ...makePlugin([]),
+      runners() {
+        return [
+          { ...makeRunner([wrapped]), id: "meta", name: "Meta" },
+          {
+            ...makeRunner([direct], available),
+            id: "sec",
+            name: "Sec",
+            supersedes: ["meta/sec"],
+          },
+        ];
+      },
+    });
+
+    const both = await checkStep("/project", [plugin(true)], makeConfig(), cr, fm);
+    expect(both.issues.map((i) => i.rule)).toEqual(["sec/G101"]);
+
+    // Without the superseding tool, the wrapped findings are kept
+    const metaOnly = await checkStep("/project", [plugin(false)], makeConfig(), cr, fm);
+    expect(metaOnly.issues.map((i) => i.rule)).toEqual(["meta/sec"]);
+  });
+
+  test("collapses a finding two runners report, unless dedup is off", async () => {
+    const fm = new FakeFileManager();
+    const cr = new FakeCommandRunner();
+    const wrapped = makeIssue({ rule: "meta/E501", linter: "meta", fingerprint: "m1" });
+    const plugin: LanguagePlugin = {
+      ...makePlugin([]),
+      runners: () => [
+        { ...makeRunner([wrapped]), id: "meta", name: "Meta" },
+        { ...makeRunner([makeIssue()]), id: "ruff", name: "Ruff" },
+      ],
+    };
+    const config = makeConfig();
+
+    const deduped = await checkStep("/project", [plugin], config, cr, fm);
+    expect(deduped.issues).toHaveLength(1);
+    expect(deduped.issues[0]?.reportedBy).toEqual(["meta", "ruff"]);
+    expect(deduped.newIssueCount).toBe(1);
+
+    const raw = await checkStep("/project", [plugin], config, cr, fm, undefined, {
+      dedup: false,
+    });
+    expect(raw.issues.map((i) => i.linter)).toEqual(["meta", "ruff"]);
+  });
+
+  test("records each runner as a span on its own lane with a tracer", async () => {
+    const fm = new FakeFileManager();
+    const cr = new FakeCommandRunner();
+    const plugin: LanguagePlugin = {
+      ...makePlugin([]),
+      runners: () => [
+        { ...makeRunner([]), id: "meta", name: "Meta" },
+        { ...makeRunner([makeIssue()]), id: "ruff", name: "Ruff" },
+      ],
+    };
+    const tracer = new Tracer();
+
+    await checkStep("/project", [plugin], makeConfig(), cr, fm, undefined, { tracer });
+
+    const spans = tracer
+      .toJSON()
+      .traceEvents.filter((e) => e.ph === "X" && e.cat === "runner");
+    expect(spans.map((e) => e.name).sort()).toEqual([
+      "allow-comments",
+      "allow-comments",
+      "meta",
+      "ruff",
+    ]);
+  });
+
+  test("leaves a duplicate out of the later runner's streamed block", async () => {
+    const fm = new FakeFileManager();
+    const cr = new FakeCommandRunner();
+    const wrapped = makeIssue({ rule: "meta/E501", linter: "meta", fingerprint: "m1" });
+    const plugin: LanguagePlugin = {
+      ...makePlugin([]),
+      runners: () => [
+        { ...makeRunner([wrapped]), id: "meta", name: "Meta" },
+        { ...makeRunner([makeIssue()]), id: "ruff", name: "Ruff" },
+      ],
+    };
+    const streamed: string[][] = [];
+
+    await checkStep("/project", [plugin], makeConfig(), cr, fm, undefined, {
+      jobs: 1,
+      onRunnerDone: ({ issues }) => streamed.push(issues.map((i) => i.rule)),
+    });
+
+    expect(streamed).toEqual([["meta/E501"], []]);
+  });
+
+  test("hands each runner to onRunnerDone as it finishes, with a count", async () => {
+    const fm = new FakeFileManager();
+    const cr = new FakeCommandRunner();
+    const order: string[] = [];
+    const runner = (id: string): LinterRunner => ({
+      ...makeRunner([makeIssue({ linter: id, fingerprint: id })]),
+      id,
+      name: id,
+      async run(): Promise<LintIssue[]> {
+        order.push(id);
+        return [makeIssue({ linter: id, message: `${id} finding`, fingerprint: id })];
+      },
+    });
+    const plugin: LanguagePlugin = {
+      ...makePlugin([]),
+      runners: () => [runner("b"), runner("a")],
+    };
+    const streamed: string[] = [];
+
+    const { runners } = await checkStep(
+      "/project",
+      [plugin],
+      makeConfig(),
+      cr,
+      fm,
+      undefined,
+      {
+        jobs: 1,
+        onRunnerDone: ({ report, issues, done, total }) =>
+          streamed.push(`${done}/${total} ${report.name} ${issues.length}`),
+      }
+    );
+
+    // Streamed in run order; the final reports are still sorted by name
+    expect(streamed).toEqual(["1/2 b 1", "2/2 a 1"]);
+    expect(order).toEqual(["b", "a"]);
+    expect(runners.map((r) => r.name)).toEqual(["a", "b"]);
+  });
+
+  test("holds back a streamed result until its superseding runner is done", async () => {
+    const fm = new FakeFileManager();
+    const cr = new FakeCommandRunner();
+    const wrapped = makeIssue({ rule: "meta/sec", linter: "meta", fingerprint: "m1" });
+    const other = makeIssue({ rule: "meta/vet", linter: "meta", fingerprint: "m2" });
+    const direct = makeIssue({
+      rule: "sec/G101",
+      linter: "sec",
+      message: "Potential hardcoded credentials",
+      fingerprint: "s1",
+    });
+    let releaseSec = () => {};
+    const secGate = new Promise<void>((resolve) => {
+      releaseSec = resolve;
+    });
+    const plugin: LanguagePlugin = {
+      ...makePlugin([]),
+      runners() {
+        return [
+          { ...makeRunner([wrapped, other]), id: "meta", name: "Meta" },
+          {
+            ...makeRunner([]),
+            id: "sec",
+            name: "Sec",
+            supersedes: ["meta/sec"],
+            async run(): Promise<LintIssue[]> {
+              await secGate;
+              return [direct];
+            },
+          },
+        ];
+      },
+    };
+    const streamed: string[][] = [];
+
+    const checking = checkStep(
+      "/project",
+      [plugin],
+      makeConfig(),
+      cr,
+      fm,
+      undefined,
+      {
+        jobs: 2,
+        onRunnerDone: ({ issues }) => streamed.push(issues.map((i) => i.rule)),
+      }
+    );
+    await new Promise((resolve) => setTimeout(resolve, 5));
+    expect(streamed).toEqual([]);
+    releaseSec();
+    await checking;
+
+    // Meta finished first, so it is also shown first — minus the superseded rule
+    expect(streamed).toEqual([["meta/vet"], ["sec/G101"]]);
+  });
+
+  test("hides ignored paths from runners and drops their findings", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/src/main.py", "");
+    fm.seed("/project/src/gen/api.py", "");
+    const cr = new FakeCommandRunner();
+    const globbed: string[][] = [];
+    const seenFiles: (readonly string[] | undefined)[] = [];
+    const plugin: LanguagePlugin = {
+      ...makePlugin([]),
+      runners() {
+        return [
+          {
+            ...makeRunner([]),
+            async run(opts: RunOptions): Promise<LintIssue[]> {
+              globbed.push(await opts.fileManager.glob("**/*.py", "/project"));
+              seenFiles.push(opts.files);
+              return [
+                makeIssue({ file: "/project/src/main.py", fingerprint: "a" }),
+                makeIssue({ file: "/project/src/gen/api.py", fingerprint: "b" }),
+              ];
+            },
+          },
+        ];
+      },
+    };
+
+    const { issues } = await checkStep(
+      "/project",
+      [plugin],
+      makeConfig(),
+      cr,
+      fm,
+      undefined,
+      {
+        files: ["src/main.py", "src/gen/api.py"],
+        ignore: (rel) => rel.startsWith("src/gen/"),
+      }
+    );
+
+    expect(globbed).toEqual([["src/main.py"]]);
+    expect(seenFiles).toEqual([["src/main.py"]]);
+    expect(issues.map((i) => i.file)).toEqual(["/project/src/main.py"]);
+  });
+
+  describe("generated files", () => {
+    const GENERATED = "// Code generated by protoc-gen-go. DO NOT EDIT.\npackage api\n";
+
+    function makeGoPlugin(): LanguagePlugin {
+      return {
+        ...makePlugin([]),
+        runners() {
+          return [
+            makeRunner([
+              makeIssue({ file: "/project/main.go", fingerprint: "a" }),
+              makeIssue({ file: "/project/api/api.pb.go", fingerprint: "b" }),
+            ]),
+          ];
+        },
+      };
+    }
+
+    function seedGoFiles(): FakeFileManager {
+      const fm = new FakeFileManager();
+      fm.seed("/project/main.go", "package main\n");
+      fm.seed("/project/api/api.pb.go", GENERATED);
+      return fm;
+    }
+
+    test("drops findings in files marked DO NOT EDIT", async () => {
+      const cons = new FakeConsole();
+      const { issues } = await checkStep(
+        "/project",
+        [makeGoPlugin()],
+        makeConfig(),
+        new FakeCommandRunner(),
+        seedGoFiles(),
+        cons
+      );
+
+      expect(issues.map((i) => i.file)).toEqual(["/project/main.go"]);
+      expect(cons.verboses).toContain("Skipping 1 generated file(s)");
+    });
+
+    test("keeps them with includeGenerated", async () => {
+      const { issues } = await checkStep(
+        "/project",
+        [makeGoPlugin()],
+        makeConfig(),
+        new FakeCommandRunner(),
+        seedGoFiles(),
+        undefined,
+        { includeGenerated: true }
+      );
+
+      expect(issues.map((i) => i.file)).toEqual([
+        "/project/main.go",
+        "/project/api/api.pb.go",
+      ]);
+    });
+  });
+
+  test("with module, runs only module-scoped runners on it", async () => {
+    const fm = new FakeFileManager();
+    const cr = new FakeCommandRunner();
+    const seenModules: (string | undefined)[] = [];
+    const plugin: LanguagePlugin = {
+      ...makePlugin([]),
+      runners() {
+        return [
+          { ...makeRunner([makeIssue()]), id: "whole-project", name: "Whole" },
+          {
+            ...makeRunner([]),
+            moduleScoped: true,
+            async run(opts: RunOptions): Promise<LintIssue[]> {
+              seenModules.push(opts.module);
+              return [];
+            },
+          },
+        ];
+      },
+    };
+
+    const { runners } = await checkStep(
+      "/project",
+      [plugin],
+      makeConfig(),
+      cr,
+      fm,
+      undefined,
+      { module: "services/api" }
+    );
+
+    expect(runners.map((r) => r.runnerId)).toEqual(["test-runner"]);
+    expect(seenModules).toEqual(["services/api"]);
+  });
+
+  test("logs each runner's outcome and duration at verbose level", async () => {
+    const fm = new FakeFileManager();
+    const cr = new FakeCommandRunner();
+    const cons = new FakeConsole();
+    const languages = [makePlugin([makeIssue()])];
+
+    await checkStep("/project", languages, makeConfig(), cr, fm, cons);
+
+    expect(cons.verboses).toHaveLength(1);
+    expect(cons.verboses[0]).toMatch(/^Test Runner: ok in \d+ms, 1 issue\(s\)$/);
+  });
+
+  test("sorts runner reports by name regardless of completion order", async () => {
+    const fm = new FakeFileManager();
+    const cr = new FakeCommandRunner();
+    const slow: LanguagePlugin = {
+      ...makePlugin([]),
+      runners() {
+        return [
+          {
+            ...makeRunner([]),
+            id: "zeta",
+            name: "Zeta",
+            async run(): Promise<LintIssue[]> {
+              await new Promise((resolve) => setTimeout(resolve, 5));
+              return [];
+            },
+          },
+          { ...makeRunner([]), id: "alpha", name: "Alpha" },
+        ];
+      },
+    };
+
+    const { runners } = await checkStep("/project", [slow], makeConfig(), cr, fm);
+
+    expect(runners.map((r) => r.name)).toEqual(["Alpha", "Zeta"]);
+  });
+
+  test("jobs = 1 runs runners one at a time", async () => {
+    const fm = new FakeFileManager();
+    const cr = new FakeCommandRunner();
+    let inFlight = 0;
+    let peak = 0;
+    const tracked = (id: string): LinterRunner => ({
+      ...makeRunner([]),
+      id,
+      async run(): Promise<LintIssue[]> {
+        inFlight++;
+        peak = Math.max(peak, inFlight);
+        await new Promise((resolve) => setTimeout(resolve, 1));
+        inFlight--;
+        return [];
+      },
+    });
+    const plugin: LanguagePlugin = {
+      ...makePlugin([]),
+      runners() {
+        return [tracked("a"), tracked("b"), tracked("c")];
+      },
+    };
+
+    await checkStep("/project", [plugin], makeConfig(), cr, fm, undefined, { jobs: 1 });
+
+    expect(peak).toBe(1);
+  });
+
+  test("serves unchanged cacheable runners from the cache", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/foo.py", "x = 1\n");
+    const cr = new FakeCommandRunner();
+    let runs = 0;
+    const plugin: LanguagePlugin = {
+      ...makePlugin([]),
+      runners() {
+        return [
+          {
+            ...makeRunner([]),
+            versionArgs: ["tool", "--version"],
+            cache: { inputs: ["**/*.py"] },
+            async run(): Promise<LintIssue[]> {
+              runs++;
+              return [makeIssue()];
+            },
+          },
+        ];
+      },
+    };
+    const check = () =>
+      checkStep("/project", [plugin], makeConfig(), cr, fm, undefined, {
+        useCache: true,
+      });
+
+    const first = await check();
+    const second = await check();
+
+    expect(runs).toBe(1);
+    expect(first.runners[0]?.cached).toBeUndefined();
+    expect(second.runners[0]?.cached).toBe(true);
+    expect(second.issues).toEqual(first.issues);
+  });
+
+  test("re-runs cacheable runners when caching is off", async () => {
+    const fm = new FakeFileManager();
+    const cr = new FakeCommandRunner();
+    let runs = 0;
+    const plugin: LanguagePlugin = {
+      ...makePlugin([]),
+      runners() {
+        return [
+          {
+            ...makeRunner([]),
+            versionArgs: ["tool", "--version"],
+            cache: { inputs: ["**/*.py"] },
+            async run(): Promise<LintIssue[]> {
+              runs++;
+              return [];
+            },
+          },
+        ];
+      },
+    };
+
+    await checkStep("/project", [plugin], makeConfig(), cr, fm);
+    await checkStep("/project", [plugin], makeConfig(), cr, fm);
+
+    expect(runs).toBe(2);
+    expect(fm.written).toHaveLength(0);
+  });
+});
+
+describe("checkStep — fail-fast", () => {
+  /** A runner per id, failing with one new error when listed in `failing` */
+  function trackedPlugin(ids: string[], failing: string[], ran: string[]) {
+    const plugin: LanguagePlugin = {
+      ...makePlugin([]),
+      runners() {
+        return ids.map(
+          (id): LinterRunner => ({
+            ...makeRunner([]),
+            id,
+            name: id,
+            async run(): Promise<LintIssue[]> {
+              ran.push(id);
+              return failing.includes(id)
+                ? [makeIssue({ linter: id, fingerprint: `fp-${id}` })]
+                : [];
+            },
+          })
+        );
+      },
+    };
+    return plugin;
+  }
+
+  test("stops starting runners after the first failing one", async () => {
+    const ran: string[] = [];
+    const plugin = trackedPlugin(["a", "b", "c"], ["a"], ran);
+
+    const { result, issues, runners } = await checkStep(
+      "/project",
+      [plugin],
+      makeConfig(),
+      new FakeCommandRunner(),
+      new FakeFileManager(),
+      undefined,
+      { jobs: 1, failFast: true }
+    );
+
+    expect(ran).toEqual(["a"]);
+    expect(issues).toHaveLength(1);
+    expect(result.status).toBe("error");
+    expect(result.message).toContain("stopped early, 2 runner(s) cancelled");
+    expect(runners.map((r) => [r.name, r.status])).toEqual([
+      ["a", "ok"],
+      ["b", "cancelled"],
+      ["c", "cancelled"],
+    ]);
+    expect(runners[1]?.message).toBe("cancelled by --fail-fast");
+  });
+
+  test("finishes a prerequisite runner before starting the rest", async () => {
+    const ran: string[] = [];
+    const tracked = trackedPlugin(["lint", "build"], ["build"], ran);
+    const plugin: LanguagePlugin = {
+      ...tracked,
+      runners: () =>
+        tracked
+          .runners()
+          .map((runner) =>
+            runner.id === "build" ? { ...runner, runsFirst: true } : runner
+          ),
+    };
+
+    const { runners } = await checkStep(
+      "/project",
+      [plugin],
+      makeConfig(),
+      new FakeCommandRunner(),
+      new FakeFileManager(),
+      undefined,
+      { jobs: 2, failFast: true }
+    );
+
+    expect(ran).toEqual(["build"]);
+    expect(runners.map((r) => [r.name, r.status])).toEqual([
+      ["build", "ok"],
+      ["lint", "cancelled"],
+    ]);
+  });
+
+  test("collects every runner's results by default", async () => {
+    const ran: string[] = [];
+    const plugin = trackedPlugin(["a", "b", "c"], ["a"], ran);
+
+    const { runners } = await checkStep(
+      "/project",
+      [plugin],
+      makeConfig(),
+      new FakeCommandRunner(),
+      new FakeFileManager(),
+      undefined,
+      { jobs: 1 }
+    );
+
+    expect(ran).toEqual(["a", "b", "c"]);
+    expect(runners.every((r) => r.status === "ok")).toBe(true);
+  });
+
+  test("keeps going past findings that do not fail the check", async () => {
+    const ran: string[] = [];
+    const plugin = trackedPlugin(["a", "b"], ["a"], ran);
+    const fm = new FakeFileManager();
+    fm.seed(
+      `/project/${BASELINE_PATH}`,
+      JSON.stringify([makeBaselineEntry({ fingerprint: "fp-a" })])
+    );
+
+    const { result } = await checkStep(
+      "/project",
+      [plugin],
+      makeConfig(),
+      new FakeCommandRunner(),
+      fm,
+      undefined,
+      { jobs: 1, failFast: true }
+    );
+
+    expect(ran).toEqual(["a", "b"]);
+    expect(result.status).toBe("ok");
+  });
+
+  test("kills in-flight runners' commands through the signal", async () => {
+    /** `slow-tool` runs until its signal aborts */
+    class AbortableCommandRunner extends FakeCommandRunner {
+      override async run(
+        args: string[],
+        opts?: RunCommandOptions
+      ): Promise<RunResult> {
+        if (args[0] !== "slow-tool") return super.run(args, opts);
+        const { signal } = opts ?? {};
+        return new Promise((resolve) => {
+          signal?.addEventListener("abort", () =>
+            resolve({ stdout: "", stderr: "", exitCode: 137, cancelled: true })
+          );
+        });
+      }
+    }
+    const plugin: LanguagePlugin = {
+      ...makePlugin([]),
+      runners() {
+        return [
+          {
+            ...makeRunner([]),
+            id: "slow",
+            name: "Slow",
+            async run({ commandRunner }: RunOptions): Promise<LintIssue[]> {
+              await commandRunner.run(["slow-tool"]);
+              return [];
+            },
+          },
+          {
+            ...makeRunner([makeIssue()]),
+            async run(): Promise<LintIssue[]> {
+              await new Promise((resolve) => setTimeout(resolve, 1));
+              return [makeIssue()];
+            },
+          },
+        ];
+      },
+    };
+    const streamed: string[] = [];
+
+    const { issues, runners } = await checkStep(
+      "/project",
+      [plugin],
+      makeConfig(),
+      new AbortableCommandRunner(),
+      new FakeFileManager(),
+      undefined,
+      {
+        jobs: 2,
+        failFast: true,
+        onRunnerDone: ({ report }) => streamed.push(`${report.name}: ${report.status}`),
+      }
+    );
+
+    expect(issues).toHaveLength(1);
+    expect(runners.map((r) => [r.name, r.status])).toEqual([
+      ["Slow", "cancelled"],
+      ["Test Runner", "ok"],
+    ]);
+    expect(streamed).toEqual(["Test Runner: ok", "Slow: cancelled"]);
+  });
 });
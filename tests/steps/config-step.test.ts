@@ -0,0 +1,482 @@
+import { describe, expect, test } from "bun:test";
+import {
+  buildResolvedConfig,
+  GlobalConfigSchema,
+  MachineConfigSchema,
+  NestedConfigSchema,
+  ProjectConfigSchema,
+  withNestedConfigs,
+} from "@/config/schema";
+import type { LintIssue } from "@/models/lint-issue";
+import type { LinterRunner } from "@/runners/types";
+import {
+  checkGlob,
+  findConfigProblems,
+  formatConfigProblem,
+  formatEffectiveConfig,
+  locateTomlKey,
+  locateYamlKey,
+  validateConfigStep,
+} from "@/steps/config-step";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+function makeRunner(id: string, defaultEnabled?: boolean): LinterRunner {
+  return {
+    id,
+    name: id,
+    configFile: null,
+    installHint: { description: id },
+    ...(defaultEnabled !== undefined && { defaultEnabled }),
+    async isAvailable() {
+      return true;
+    },
+    async run(): Promise<LintIssue[]> {
+      return [];
+    },
+  };
+}
+
+describe("locateTomlKey", () => {
+  const text = [
+    'profile = "strict"',
+    "",
+    "[config]",
+    "line_length = 300",
+    "",
+    "[runners.ruff]",
+    "enabled = false",
+    "",
+    "[[custom_runners]]",
+    'id = "a"',
+    "",
+    "[[custom_runners]]",
+    'id = "b"',
+    'pattern = "x"',
+  ].join("\n");
+
+  test("finds a top-level key", () => {
+    expect(locateTomlKey(text, ["profile"])).toBe(1);
+  });
+
+  test("finds a key inside its table", () => {
+    expect(locateTomlKey(text, ["config", "line_length"])).toBe(4);
+    expect(locateTomlKey(text, ["runners", "ruff", "enabled"])).toBe(7);
+  });
+
+  test("counts array-of-tables entries", () => {
+    expect(locateTomlKey(text, ["custom_runners", 1, "pattern"])).toBe(14);
+  });
+
+  test("falls back to the table header", () => {
+    expect(locateTomlKey(text, ["runners", "ruff"])).toBe(6);
+    expect(locateTomlKey(text, ["custom_runners", 0, "files"])).toBe(9);
+  });
+
+  test("returns undefined for a key that is not in the file", () => {
+    expect(locateTomlKey(text, ["ignore_paths"])).toBeUndefined();
+  });
+});
+
+describe("locateYamlKey", () => {
+  const text = [
+    "# yaml-language-server: $schema=...",
+    "profile: strict",
+    "config:",
+    "  line_length: 100",
+    "runners:",
+    "  ruff:",
+    "    enabled: false",
+    "custom_runners:",
+    "- id: one",
+    "  files: ['*.py']",
+    "- id: two",
+    "  pattern: '('",
+    "",
+  ].join("\n");
+
+  test("finds top-level and nested keys", () => {
+    expect(locateYamlKey(text, ["profile"])).toBe(2);
+    expect(locateYamlKey(text, ["config", "line_length"])).toBe(4);
+    expect(locateYamlKey(text, ["runners", "ruff", "enabled"])).toBe(7);
+  });
+
+  test("counts sequence entries, the first key on the entry's own line", () => {
+    expect(locateYamlKey(text, ["custom_runners", 1, "pattern"])).toBe(12);
+    expect(locateYamlKey(text, ["custom_runners", 1, "id"])).toBe(11);
+  });
+
+  test("falls back to the deepest part it finds", () => {
+    expect(locateYamlKey(text, ["runners", "ruff", "timeout"])).toBe(6);
+    expect(locateYamlKey(text, ["custom_runners", 0, "files", 0])).toBe(10);
+    expect(locateYamlKey(text, ["hooks"])).toBeUndefined();
+  });
+});
+
+describe("checkGlob", () => {
+  test("accepts project-relative globs", () => {
+    expect(checkGlob("tests/fixtures/**")).toBeNull();
+    expect(checkGlob("src/*.{ts,tsx}")).toBeNull();
+  });
+
+  test("rejects rooted globs, which never match", () => {
+    expect(checkGlob("/dist/**")).toContain("relative to the project root");
+    expect(checkGlob("./dist/**")).toContain("relative to the project root");
+  });
+
+  test("rejects unbalanced brackets and braces", () => {
+    expect(checkGlob("src/[ab")).toContain("unbalanced []");
+    expect(checkGlob("src/*.{ts")).toContain("unbalanced {}");
+  });
+
+  test("rejects an empty pattern", () => {
+    expect(checkGlob("")).toBe("invalid glob pattern");
+  });
+});
+
+describe("findConfigProblems", () => {
+  test("returns nothing for a valid config", () => {
+    const text = [
+      'profile = "strict"',
+      'ignore_paths = ["dist/**"]',
+      "",
+      "[config]",
+      "line_length = 100",
+    ].join("\n");
+    expect(findConfigProblems(text)).toEqual([]);
+  });
+
+  test("reports out-of-range values at their line", () => {
+    const problems = findConfigProblems("[config]\nline_length = 300\n");
+    expect(problems).toHaveLength(1);
+    expect(problems[0]?.key).toBe("config.line_length");
+    expect(problems[0]?.line).toBe(2);
+  });
+
+  test("reports unknown top-level and runner keys", () => {
+    const text = 'ignore_path = ["dist/**"]\n\n[runners.ruff]\nenable = false\n';
+    const problems = findConfigProblems(text);
+    expect(problems.map((p) => [p.key, p.line])).toEqual([
+      ["ignore_path", 1],
+      ["runners.ruff.enable", 4],
+    ]);
+    expect(problems[0]?.message).toContain("unknown key");
+  });
+
+  test("reports unknown retry keys and invalid retry regexes", () => {
+    const text = [
+      "[runners.govulncheck.retry]",
+      "attempt = 3",
+      'match = ["(unclosed"]',
+    ].join("\n");
+    const problems = findConfigProblems(text);
+    expect(problems.map((p) => [p.key, p.line])).toEqual([
+      ["runners.govulncheck.retry.attempt", 2],
+      ["runners.govulncheck.retry.match[0]", 3],
+    ]);
+  });
+
+  test("reports runner tables for runners that do not exist", () => {
+    const problems = findConfigProblems("[runners.rufff]\nenabled = false\n");
+    expect(problems).toEqual([
+      {
+        key: "runners.rufff",
+        message: 'unknown runner "rufff" — see `ai-guardrails list`',
+        line: 1,
+      },
+    ]);
+  });
+
+  test("accepts runner tables for custom runners", () => {
+    const text = [
+      "[runners.lint-sql]",
+      "timeout = 30",
+      "",
+      "[[custom_runners]]",
+      'id = "lint-sql"',
+      'files = ["**/*.sql"]',
+      'command = ["lint-sql", "{files}"]',
+      'pattern = "^(?<file>[^:]+):(?<line>\\\\d+): (?<message>.+)$"',
+    ].join("\n");
+    expect(findConfigProblems(text)).toEqual([]);
+  });
+
+  test("reports globs that cannot match", () => {
+    const text = 'ignore_paths = ["ok/**", "/abs/**"]\n';
+    const problems = findConfigProblems(text);
+    expect(problems.map((p) => p.key)).toEqual(["ignore_paths[1]"]);
+    expect(problems[0]?.line).toBe(1);
+  });
+
+  test("reports a TOML syntax error", () => {
+    const problems = findConfigProblems("[config\nline_length = 1\n");
+    expect(problems).toHaveLength(1);
+    expect(problems[0]?.key).toBe("");
+    expect(problems[0]?.message).toStartWith("invalid TOML");
+  });
+});
+
+describe("formatConfigProblem", () => {
+  test("prefixes the file and line", () => {
+    const line = formatConfigProblem({
+      key: "config.line_length",
+      message: "too big",
+      line: 4,
+    });
+    expect(line).toBe(".ai-guardrails/config.toml:4: config.line_length: too big");
+  });
+
+  test("omits a missing line and key", () => {
+    expect(formatConfigProblem({ key: "", message: "invalid TOML" })).toBe(
+      ".ai-guardrails/config.toml: invalid TOML"
+    );
+  });
+});
+
+describe("validateConfigStep", () => {
+  test("is ok when there is no config file", async () => {
+    const { result, problems } = await validateConfigStep(
+      "/project",
+      new FakeFileManager()
+    );
+    expect(result.status).toBe("ok");
+    expect(result.message).toContain("defaults apply");
+    expect(problems).toEqual([]);
+  });
+
+  test("fails with the problems of an invalid file", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.ai-guardrails/config.toml", 'profile = "extreme"\n');
+
+    const { result, problems } = await validateConfigStep("/project", fm);
+
+    expect(result.status).toBe("error");
+    expect(result.message).toContain("1 problem(s)");
+    expect(problems[0]?.key).toBe("profile");
+  });
+
+  test("checks the --config file instead of the project's", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.ai-guardrails/config.toml", 'profile = "strict"\n');
+    fm.seed("/ci/shared.toml", 'profile = "extreme"\n');
+
+    const { result, problems } = await validateConfigStep(
+      "/project",
+      fm,
+      "/ci/shared.toml"
+    );
+
+    expect(result.message).toBe("/ci/shared.toml has 1 problem(s)");
+    expect(problems[0]?.file).toBe("/ci/shared.toml");
+  });
+
+  test("fails when the --config file does not exist", async () => {
+    const { result } = await validateConfigStep(
+      "/project",
+      new FakeFileManager(),
+      "/ci/missing.toml"
+    );
+
+    expect(result.status).toBe("error");
+    expect(result.message).toContain("--config /ci/missing.toml does not exist");
+  });
+
+  test("checks a YAML config, naming it in each problem", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.ai-guardrails/config.yaml", "profle: strict\n");
+
+    const { result, problems } = await validateConfigStep("/project", fm);
+
+    expect(result.message).toBe(".ai-guardrails/config.yaml has 1 problem(s)");
+    expect(problems.map(formatConfigProblem)).toEqual([
+      ".ai-guardrails/config.yaml:1: profle: unknown key — it is ignored",
+    ]);
+  });
+});
+
+describe("formatEffectiveConfig", () => {
+  const project = ProjectConfigSchema.parse({
+    runners: { ruff: { enabled: false }, pyright: { timeout: 300 } },
+  });
+  const config = buildResolvedConfig(MachineConfigSchema.parse({}), project);
+  const runners = [
+    makeRunner("ruff"),
+    makeRunner("pyright"),
+    makeRunner("gofumpt", false),
+  ];
+  const FILE = ".ai-guardrails/config.toml";
+
+  test("lists every runner with its resolved settings and their source", () => {
+    const lines = formatEffectiveConfig(config, runners, { enable: [], disable: [] });
+    const text = lines.join("\n");
+
+    expect(text).toContain(
+      `[runners.ruff]\nenabled = false  # ${FILE}\ntimeout = 120  # default`
+    );
+    expect(text).toContain(
+      `[runners.pyright]\nenabled = true  # default\ntimeout = 300  # ${FILE}`
+    );
+    expect(text).toContain("[runners.gofumpt]\nenabled = false  # default");
+  });
+
+  test("applies --enable/--disable on top of the file", () => {
+    const lines = formatEffectiveConfig(config, runners, {
+      enable: ["ruff"],
+      disable: ["pyright"],
+    });
+    expect(lines).toContain("enabled = true  # --enable");
+    expect(lines).toContain("enabled = false  # --disable");
+  });
+
+  test("credits GUARDRAILS_DISABLE when the disable list came from the env", () => {
+    const lines = formatEffectiveConfig(config, runners, {
+      enable: [],
+      disable: ["pyright"],
+      disableSource: "GUARDRAILS_DISABLE",
+    });
+    expect(lines).toContain("enabled = false  # GUARDRAILS_DISABLE");
+  });
+
+  test("with a path, applies the nested configs above it", () => {
+    const scoped = withNestedConfigs(config, [
+      {
+        dir: "legacy",
+        project: NestedConfigSchema.parse({
+          profile: "lenient",
+          runners: { pyright: { enabled: false } },
+        }),
+      },
+    ]);
+    const lines = formatEffectiveConfig(
+      scoped,
+      runners,
+      { enable: [], disable: [] },
+      "legacy/app"
+    );
+    const text = lines.join("\n");
+
+    expect(lines[0]).toBe(
+      "# Effective config for legacy/app: defaults < ~/.ai-guardrails/config.toml"
+    );
+    expect(lines[1]).toContain(`< ${FILE} < legacy/${FILE} < GUARDRAILS_DISABLE`);
+    expect(text).toContain('profile = "lenient"');
+    expect(text).toContain(`[runners.pyright]\nenabled = false  # legacy/${FILE}`);
+    expect(text).toContain(`[runners.ruff]\nenabled = false  # ${FILE}`);
+  });
+
+  test("with a classified path, applies its classification last", () => {
+    const classified = buildResolvedConfig(
+      MachineConfigSchema.parse({}),
+      ProjectConfigSchema.parse({
+        classifications: { test: { runners: { pyright: { enabled: false } } } },
+      })
+    );
+    const lines = formatEffectiveConfig(
+      classified,
+      runners,
+      { enable: [], disable: [] },
+      "tests/test_app.py"
+    );
+    const text = lines.join("\n");
+
+    expect(lines[1]).toContain(`< ${FILE} < [classifications.test] < GUARDRAILS`);
+    expect(text).toContain(
+      "[runners.pyright]\nenabled = false  # [classifications.test]"
+    );
+  });
+
+  test("credits the global config with what it set", () => {
+    const global = {
+      path: "/home/me/.config/ai-guardrails/config.yaml",
+      config: GlobalConfigSchema.parse({
+        profile: "strict",
+        runners: { ruff: { timeout: 60 }, pyright: { enabled: false } },
+      }),
+    };
+    const layered = buildResolvedConfig(MachineConfigSchema.parse({}), project, global);
+
+    const lines = formatEffectiveConfig(layered, runners, { enable: [], disable: [] });
+    const text = lines.join("\n");
+
+    expect(lines[1]).toBe(
+      `#   < ${global.path} < ${FILE} < GUARDRAILS_DISABLE < --enable/--disable`
+    );
+    expect(text).toContain(`profile = "strict"  # ${global.path}`);
+    expect(text).toContain(
+      `[runners.ruff]\nenabled = false  # ${FILE}\ntimeout = 60  # ${global.path}`
+    );
+    expect(text).toContain(`[runners.pyright]\nenabled = false  # ${global.path}`);
+  });
+
+  test("credits the --config file in place of the project's", () => {
+    const shared = { ...config, configPath: "/ci/shared.toml" };
+
+    const lines = formatEffectiveConfig(shared, runners, { enable: [], disable: [] });
+
+    expect(lines[1]).toContain("< /ci/shared.toml < GUARDRAILS_DISABLE");
+    expect(lines).toContain("enabled = false  # /ci/shared.toml");
+  });
+
+  test("credits every value to the layer that wrote it", () => {
+    const written = buildResolvedConfig(
+      MachineConfigSchema.parse({ ignore: [{ rule: "a/b", reason: "x" }] }),
+      ProjectConfigSchema.parse({
+        config: { line_length: 100 },
+        ignore_paths: ["vendor/**"],
+        ignore: [{ rule: "c/d", reason: "y" }],
+      })
+    );
+    const layered = {
+      ...written,
+      written: {
+        machine: { path: "/home/me/.ai-guardrails/config.toml", keys: ["ignore"] },
+        project: {
+          path: ".ai-guardrails/config.yaml",
+          keys: ["config.line_length", "ignore_paths", "ignore"],
+        },
+      },
+    };
+
+    const lines = formatEffectiveConfig(layered, [], { enable: [], disable: [] });
+
+    expect(lines[1]).toContain("< .ai-guardrails/config.yaml < GUARDRAILS_DISABLE");
+    expect(lines).toContain('profile = "standard"  # default');
+    expect(lines).toContain(
+      'ignore_paths = [ "vendor/**" ]  # .ai-guardrails/config.yaml'
+    );
+    expect(lines).toContain("line_length = 100  # .ai-guardrails/config.yaml");
+    expect(lines).toContain("indent_width = 2  # default");
+    expect(lines).toContain(
+      'rule = "c/d"  # ~/.ai-guardrails/config.toml + .ai-guardrails/config.yaml'
+    );
+  });
+
+  test("credits each nested config that adds to a list", () => {
+    const scoped = withNestedConfigs(
+      { ...config, written: { project: { path: FILE, keys: [] } } },
+      [
+        {
+          dir: "legacy",
+          project: NestedConfigSchema.parse({ ignore_paths: ["gen/**"] }),
+        },
+      ]
+    );
+
+    const lines = formatEffectiveConfig(
+      scoped,
+      [],
+      { enable: [], disable: [] },
+      "legacy/app"
+    );
+
+    const [listed] = lines.filter((line) => line.startsWith("ignore_paths = "));
+    expect(listed).toEndWith(`  # legacy/${FILE}`);
+  });
+
+  test("includes the resolved profile and config values", () => {
+    const lines = formatEffectiveConfig(config, [], { enable: [], disable: [] });
+    const text = lines.join("\n");
+    expect(text).toContain('profile = "standard"');
+    expect(text).toContain("[config]");
+    expect(text).toContain("line_length = 88");
+  });
+});
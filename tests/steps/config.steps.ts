@@ -76,6 +76,7 @@ Given<ConfigWorld>(
       ignore: [],
       allow: [],
       ignore_paths: [],
+      classifications: {},
     };
   }
 );
@@ -86,6 +87,7 @@ Given<ConfigWorld>("a project config with no profile", (world: ConfigWorld) => {
     ignore: [],
     allow: [],
     ignore_paths: [],
+    classifications: {},
   };
 });
 
@@ -107,6 +109,7 @@ Given<ConfigWorld>(
       ignore: [{ rule: String(rule), reason: String(reason) }],
       allow: [],
       ignore_paths: [],
+      classifications: {},
     };
   }
 );
@@ -129,6 +132,7 @@ Given<ConfigWorld>(
       ignore: [{ rule: String(rule), reason: "test" }],
       allow: [],
       ignore_paths: [],
+      classifications: {},
     };
   }
 );
@@ -145,6 +149,7 @@ Given<ConfigWorld>(
       ignore: [],
       allow: [],
       ignore_paths: [],
+      classifications: {},
     };
     world.resolvedConfig = resolveConfig(machine, project);
   }
@@ -157,6 +162,7 @@ Given<ConfigWorld>("a resolved config with no ignores", (world: ConfigWorld) =>
     ignore: [],
     allow: [],
     ignore_paths: [],
+    classifications: {},
   };
   world.resolvedConfig = resolveConfig(machine, project);
 });
@@ -170,6 +176,7 @@ Given<ConfigWorld>(
       ignore: [],
       allow: [{ rule: String(rule), glob: String(glob), reason: "test" }],
       ignore_paths: [],
+      classifications: {},
     };
     world.resolvedConfig = resolveConfig(machine, project);
   }
@@ -215,6 +222,7 @@ When<ConfigWorld>("I resolve the config", (world: ConfigWorld) => {
     ignore: [],
     allow: [],
     ignore_paths: [],
+    classifications: {},
   };
   world.resolvedConfig = resolveConfig(machine, project);
 });
@@ -281,6 +289,13 @@ Then<ConfigWorld>("it should throw an error", (world: ConfigWorld) => {
   expect(world.loadError).toBeDefined();
 });
 
+Then<ConfigWorld>(
+  "the error should mention {string}",
+  (world: ConfigWorld, text: unknown) => {
+    expect(String(world.loadError)).toContain(String(text));
+  }
+);
+
 Then<ConfigWorld>(
   "the resolved profile should be {string}",
   (world: ConfigWorld, profile: unknown) => {
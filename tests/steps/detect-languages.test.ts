@@ -1,8 +1,29 @@
 import { describe, expect, test } from "bun:test";
+import {
+  buildResolvedConfig,
+  MachineConfigSchema,
+  ProjectConfigSchema,
+} from "@/config/schema";
 import type { FileManager } from "@/infra/file-manager";
+import {
+  onlyRunners,
+  strictDetections,
+  validateOnlyRunners,
+  validateRunnerOverrides,
+  withCustomRunners,
+  withEnabledRunners,
+} from "@/languages/registry";
+import { pythonPlugin } from "@/languages/python";
 import { detectLanguagesStep } from "@/steps/detect-languages";
+import { FakeConsole } from "../fakes/fake-console";
 import { FakeFileManager } from "../fakes/fake-file-manager";
 
+function makeConfig() {
+  const machine = MachineConfigSchema.parse({});
+  const project = ProjectConfigSchema.parse({});
+  return buildResolvedConfig(machine, project);
+}
+
 describe("detectLanguagesStep", () => {
   test("returns detected languages with ok status", async () => {
     const fm = new FakeFileManager();
@@ -28,6 +49,38 @@ describe("detectLanguagesStep", () => {
     expect(ids).toContain("universal");
   });
 
+  test("logs the files each language was detected from at verbose level", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/pyproject.toml", "[project]");
+    const cons = new FakeConsole();
+
+    await detectLanguagesStep("/project", fm, undefined, cons);
+
+    expect(cons.verboses).toContain("Python: from pyproject.toml");
+    expect(cons.verboses).toContain("Universal: always on");
+    expect(cons.infos).toEqual([]);
+  });
+
+  test("caps the evidence listed per language", async () => {
+    const fm = new FakeFileManager();
+    for (const name of ["a", "b", "c", "d", "e"]) fm.seed(`/project/${name}.py`, "");
+    const cons = new FakeConsole();
+
+    await detectLanguagesStep("/project", fm, undefined, cons);
+
+    expect(cons.verboses).toContain("Python: from a.py, b.py, c.py and 2 more");
+  });
+
+  test("returns the evidence files keyed by plugin id", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/pyproject.toml", "[project]");
+
+    const { evidence } = await detectLanguagesStep("/project", fm);
+
+    expect(evidence.get("python")).toEqual(["pyproject.toml"]);
+    expect(evidence.get("universal")).toEqual([]);
+  });
+
   test("result message lists detected language names", async () => {
     const fm = new FakeFileManager();
     fm.seed("/project/main.py", "");
@@ -79,6 +132,8 @@ describe("detectLanguagesStep", () => {
         throw new Error("glob exploded");
       },
       isSymlink: (p: string) => innerFm.isSymlink(p),
+      isExecutable: (p: string) => innerFm.isExecutable(p),
+      stat: (p: string) => innerFm.stat(p),
       delete: (p: string) => innerFm.delete(p),
     };
 
@@ -114,3 +169,174 @@ describe("detectLanguagesStep — direct plugin delegation", () => {
     expect(languages[0]?.id).toBe("universal");
   });
 });
+
+describe("shell detection", () => {
+  test("detects an extensionless script by its shebang", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/README.md", "# hi\n");
+    fm.seedExecutable("/project/bin/deploy", "#!/usr/bin/env bash\n");
+
+    const { languages, evidence } = await detectLanguagesStep("/project", fm);
+
+    expect(languages.map((p) => p.id)).toContain("shell");
+    expect(evidence.get("shell")).toEqual(["bin/deploy"]);
+  });
+
+  test("ignores a shebang script that is not executable", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/bin/deploy", "#!/usr/bin/env bash\n");
+
+    const { languages } = await detectLanguagesStep("/project", fm);
+
+    expect(languages.map((p) => p.id)).not.toContain("shell");
+  });
+});
+
+describe("strict detection", () => {
+  test("warns about a language seen only in loose source files", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/scripts/gen.py", "");
+    const cons = new FakeConsole();
+
+    const { result, languages } = await detectLanguagesStep(
+      "/project",
+      fm,
+      undefined,
+      cons,
+      true
+    );
+
+    expect(result.status).toBe("ok");
+    expect(languages.map((p) => p.id)).not.toContain("python");
+    expect(cons.warnings).toEqual([
+      "Python detected only from scripts/gen.py — not acted on under " +
+        "--strict-detection; add pyproject.toml to confirm it",
+    ]);
+  });
+
+  test("confirms a language by any of its manifests", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/requirements.txt", "requests\n");
+    fm.seed("/project/app.py", "");
+
+    const { languages, evidence } = await detectLanguagesStep(
+      "/project",
+      fm,
+      undefined,
+      undefined,
+      true
+    );
+
+    expect(languages.map((p) => p.id)).toContain("python");
+    expect(evidence.get("python")).toEqual(["requirements.txt", "app.py"]);
+  });
+
+  test("keeps a language without manifests on its files alone", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/Dockerfile", "FROM alpine\n");
+
+    const { languages } = await detectLanguagesStep(
+      "/project",
+      fm,
+      undefined,
+      undefined,
+      true
+    );
+
+    expect(languages.map((p) => p.id)).toEqual(["docker", "universal"]);
+  });
+
+  test("never counts files under ignored directories", async () => {
+    const detected = [{ plugin: pythonPlugin, evidence: ["node_modules/x/setup.py"] }];
+
+    const split = await strictDetections(detected, "/project", new FakeFileManager());
+
+    expect(split).toEqual({ confirmed: [], ambiguous: [] });
+  });
+});
+
+describe("withCustomRunners", () => {
+  const spec = {
+    id: "acme-lint",
+    files: ["**/*.acme"],
+    command: ["acme-lint", "{files}"],
+    pattern: "^(?<file>[^:]+):(?<line>\\d+): (?<message>.*)$",
+    ok_exit_codes: [0, 1],
+  };
+
+  test("appends a Custom plugin with one runner per spec", () => {
+    const config = { ...makeConfig(), customRunners: [spec] };
+
+    const languages = withCustomRunners([], config);
+
+    expect(languages.map((p) => p.id)).toEqual(["custom"]);
+    expect(languages[0]?.runners().map((r) => r.id)).toEqual(["acme-lint"]);
+  });
+
+  test("adds nothing without custom runners", () => {
+    expect(withCustomRunners([], makeConfig())).toEqual([]);
+  });
+
+  test("lets --enable/--disable name custom runners", () => {
+    const config = { ...makeConfig(), customRunners: [spec] };
+    expect(validateRunnerOverrides([], ["acme-lint"], config)).toBeNull();
+    expect(validateRunnerOverrides([], ["acme-lint"])).toContain("Unknown runner");
+  });
+});
+
+describe("withEnabledRunners", () => {
+  test("adds an undetected runner through its plugin", async () => {
+    const fm = new FakeFileManager();
+    const { languages } = await detectLanguagesStep("/project", fm);
+
+    const extended = withEnabledRunners(languages, ["ruff"]);
+
+    const added = extended.slice(languages.length);
+    expect(added.map((p) => p.id)).toEqual(["python"]);
+    expect(added[0]?.runners().map((r) => r.id)).toEqual(["ruff"]);
+  });
+
+  test("leaves the list unchanged when the runner is already detected", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/main.py", "print('hello')");
+    const { languages } = await detectLanguagesStep("/project", fm);
+
+    expect(withEnabledRunners(languages, ["ruff"])).toEqual(languages);
+  });
+});
+
+describe("onlyRunners", () => {
+  test("keeps just the named runners, detected or not", () => {
+    const languages = onlyRunners(["ruff", "codespell"], makeConfig());
+
+    expect(languages.map((p) => p.id)).toEqual(["python", "universal"]);
+    expect(languages.flatMap((p) => p.runners().map((r) => r.id))).toEqual([
+      "ruff",
+      "codespell",
+    ]);
+  });
+
+  test("includes custom runners", () => {
+    const config = {
+      ...makeConfig(),
+      customRunners: [
+        {
+          id: "acme-lint",
+          files: ["**/*.acme"],
+          command: ["acme-lint", "{files}"],
+          pattern: "^(?<file>[^:]+):(?<line>\\d+): (?<message>.*)$",
+          ok_exit_codes: [0, 1],
+        },
+      ],
+    };
+    const languages = onlyRunners(["acme-lint"], config);
+    expect(languages.map((p) => p.id)).toEqual(["custom"]);
+  });
+
+  test("rejects unknown runner ids", () => {
+    expect(validateOnlyRunners(["ruff"])).toBeNull();
+    expect(validateOnlyRunners(["ruff", "nope"])).toBe(
+      "Unknown runner(s) in --only: nope"
+    );
+  });
+});
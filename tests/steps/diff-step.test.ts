@@ -0,0 +1,195 @@
+import { describe, expect, test } from "bun:test";
+import {
+  buildResolvedConfig,
+  MachineConfigSchema,
+  ProjectConfigSchema,
+} from "@/config/schema";
+import type { LanguagePlugin } from "@/languages/types";
+import type { LintIssue } from "@/models/lint-issue";
+import type { RunnerReport } from "@/models/runner-report";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { diffStep } from "@/steps/diff-step";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeConsole } from "../fakes/fake-console";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+function makeConfig() {
+  const machine = MachineConfigSchema.parse({});
+  return buildResolvedConfig(machine, ProjectConfigSchema.parse({}));
+}
+
+function makeIssue(linter: string, file: string): LintIssue {
+  return {
+    rule: `${linter}/format`,
+    linter,
+    file,
+    line: 1,
+    col: 1,
+    message: "File is not formatted",
+    severity: "error",
+    fingerprint: `${linter}-${file}`,
+  };
+}
+
+/** A fixer whose preview applies `edit`; without one it can only fix in place */
+function makeFixer(id: string, edit?: (content: string) => string): LinterRunner {
+  return {
+    id,
+    name: id.toUpperCase(),
+    configFile: null,
+    installHint: { description: "Test tool" },
+    async isAvailable() {
+      return true;
+    },
+    async run(): Promise<LintIssue[]> {
+      return [];
+    },
+    async fix(): Promise<void> {},
+    ...(edit !== undefined && {
+      async previewFix(
+        _opts: RunOptions,
+        _file: string,
+        content: string
+      ): Promise<string> {
+        return edit(content);
+      },
+    }),
+  };
+}
+
+function makePlugin(runners: LinterRunner[]): LanguagePlugin {
+  return {
+    id: "test",
+    name: "Test",
+    async detect() {
+      return true;
+    },
+    runners() {
+      return runners;
+    },
+  };
+}
+
+function okReport(runnerId: string): RunnerReport {
+  return { runnerId, name: runnerId, status: "ok", durationMs: 0 };
+}
+
+describe("diffStep", () => {
+  test("diffs each file against what every fixer would leave", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/a.txt", "one\ntwo\n");
+    const plugin = makePlugin([
+      makeFixer("fmt", (c) => c.replace("one", "ONE")),
+      makeFixer("lint", (c) => c.replace("two", "TWO")),
+    ]);
+
+    const { result, lines, changedFiles } = await diffStep(
+      "/project",
+      [plugin],
+      makeConfig(),
+      new FakeCommandRunner(),
+      fm,
+      [makeIssue("fmt", "/project/a.txt"), makeIssue("lint", "/project/a.txt")],
+      [okReport("fmt"), okReport("lint")]
+    );
+
+    expect(lines).toEqual([
+      "--- a.txt",
+      "+++ a.txt",
+      "@@ -1,2 +1,2 @@",
+      "-one",
+      "-two",
+      "+ONE",
+      "+TWO",
+    ]);
+    expect(changedFiles).toBe(1);
+    expect(result.message).toBe("--fix would change 1 file(s) across 2 runner(s)");
+    expect(fm.written).toEqual([]);
+  });
+
+  test("separates the diffs of several files with a blank line", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/a.txt", "x\n");
+    fm.seed("/project/b.txt", "x\n");
+    const plugin = makePlugin([makeFixer("fmt", () => "y\n")]);
+
+    const { lines } = await diffStep(
+      "/project",
+      [plugin],
+      makeConfig(),
+      new FakeCommandRunner(),
+      fm,
+      [makeIssue("fmt", "/project/b.txt"), makeIssue("fmt", "/project/a.txt")],
+      [okReport("fmt")]
+    );
+
+    expect(lines.filter((l) => l.startsWith("---") || l === "")).toEqual([
+      "--- a.txt",
+      "",
+      "--- b.txt",
+    ]);
+  });
+
+  test("warns about fixers that cannot preview or whose preview fails", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/a.txt", "x\n");
+    const broken = makeFixer("lint", () => {
+      throw new Error("lint failed: bad config");
+    });
+    const cons = new FakeConsole();
+
+    const { changedFiles } = await diffStep(
+      "/project",
+      [makePlugin([makeFixer("fmt"), broken])],
+      makeConfig(),
+      new FakeCommandRunner(),
+      fm,
+      [makeIssue("fmt", "/project/a.txt"), makeIssue("lint", "/project/a.txt")],
+      [okReport("fmt"), okReport("lint")],
+      cons
+    );
+
+    expect(changedFiles).toBe(0);
+    expect(cons.warnings).toEqual([
+      "  FMT cannot preview its fixes — --fix applies them",
+      "  LINT preview failed — lint failed: bad config",
+    ]);
+  });
+
+  test("skips runners that did not run", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/a.txt", "x\n");
+
+    const { lines } = await diffStep(
+      "/project",
+      [makePlugin([makeFixer("fmt", () => "y\n")])],
+      makeConfig(),
+      new FakeCommandRunner(),
+      fm,
+      [makeIssue("fmt", "/project/a.txt")],
+      [{ ...okReport("fmt"), status: "error" }]
+    );
+
+    expect(lines).toEqual([]);
+  });
+
+  test("previews only the files the check covered", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/a.txt", "x\n");
+    fm.seed("/project/b.txt", "x\n");
+
+    const { changedFiles } = await diffStep(
+      "/project",
+      [makePlugin([makeFixer("fmt", () => "y\n")])],
+      makeConfig(),
+      new FakeCommandRunner(),
+      fm,
+      [makeIssue("fmt", "/project/a.txt"), makeIssue("fmt", "/project/b.txt")],
+      [okReport("fmt")],
+      undefined,
+      { files: ["b.txt"] }
+    );
+
+    expect(changedFiles).toBe(1);
+  });
+});
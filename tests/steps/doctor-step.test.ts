@@ -0,0 +1,197 @@
+import { beforeEach, describe, expect, test } from "bun:test";
+import {
+  buildResolvedConfig,
+  MachineConfigSchema,
+  ProjectConfigSchema,
+} from "@/config/schema";
+import type { LanguagePlugin } from "@/languages/types";
+import type { LintIssue } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import {
+  doctorStep,
+  formatDoctorTable,
+  parseToolVersion,
+} from "@/steps/doctor-step";
+import { clearResolveToolPathCache } from "@/utils/resolve-tool-path";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+
+function makeConfig() {
+  return buildResolvedConfig(
+    MachineConfigSchema.parse({}),
+    ProjectConfigSchema.parse({})
+  );
+}
+
+function makeRunner(
+  id: string,
+  available: boolean,
+  overrides: Partial<LinterRunner> = {}
+): LinterRunner {
+  return {
+    id,
+    name: id,
+    configFile: null,
+    installHint: { description: `${id} tool`, pip: `pip install ${id}` },
+    versionArgs: [id, "--version"],
+    async isAvailable() {
+      return available;
+    },
+    async run(_opts: RunOptions): Promise<LintIssue[]> {
+      return [];
+    },
+    ...overrides,
+  };
+}
+
+function makePlugin(runners: LinterRunner[]): LanguagePlugin {
+  return {
+    id: "test",
+    name: "Test",
+    async detect() {
+      return true;
+    },
+    runners() {
+      return runners;
+    },
+  };
+}
+
+function registerVersion(cr: FakeCommandRunner, tool: string, stdout: string): void {
+  // resolveToolPath probes node_modules/.bin first; make that miss
+  cr.register([`/project/node_modules/.bin/${tool}`, "--version"], {
+    stdout: "",
+    stderr: "",
+    exitCode: 127,
+  });
+  cr.register([tool, "--version"], { stdout, stderr: "", exitCode: 0 });
+}
+
+beforeEach(() => {
+  clearResolveToolPathCache();
+});
+
+describe("parseToolVersion", () => {
+  test("extracts the first version number", () => {
+    expect(parseToolVersion("ruff 0.6.9\n")).toBe("0.6.9");
+    expect(parseToolVersion("ShellCheck\nversion: 0.9.0")).toBe("0.9.0");
+    expect(parseToolVersion("go1.22")).toBe("1.22");
+  });
+
+  test("returns null without a version", () => {
+    expect(parseToolVersion("unknown")).toBeNull();
+  });
+});
+
+describe("doctorStep", () => {
+  test("reports ok when tools are present and recent enough", async () => {
+    const cr = new FakeCommandRunner();
+    registerVersion(cr, "ruff", "ruff 0.6.9");
+    const plugin = makePlugin([makeRunner("ruff", true, { minVersion: "0.1.0" })]);
+
+    const { result, tools } = await doctorStep(
+      "/project",
+      [plugin],
+      makeConfig(),
+      cr
+    );
+
+    expect(result.status).toBe("ok");
+    expect(tools).toEqual([
+      {
+        runnerId: "ruff",
+        status: "ok",
+        version: "0.6.9",
+        minVersion: "0.1.0",
+        hint: "pip install ruff",
+      },
+    ]);
+  });
+
+  test("reports outdated tools below minVersion", async () => {
+    const cr = new FakeCommandRunner();
+    registerVersion(cr, "shellcheck", "version: 0.6.0");
+    const runner = makeRunner("shellcheck", true, { minVersion: "0.7.0" });
+    const plugin = makePlugin([runner]);
+
+    const { result, tools } = await doctorStep(
+      "/project",
+      [plugin],
+      makeConfig(),
+      cr
+    );
+
+    expect(result.status).toBe("error");
+    expect(tools[0]?.status).toBe("outdated");
+    expect(tools[0]?.version).toBe("0.6.0");
+  });
+
+  test("reports missing tools without probing their version", async () => {
+    const cr = new FakeCommandRunner();
+    const plugin = makePlugin([makeRunner("selene", false)]);
+
+    const { result, tools } = await doctorStep(
+      "/project",
+      [plugin],
+      makeConfig(),
+      cr
+    );
+
+    expect(result.status).toBe("error");
+    expect(result.message).toContain("0/1 tool(s) ready, 1 missing");
+    expect(tools[0]?.status).toBe("missing");
+    expect(cr.calls).toHaveLength(0);
+  });
+
+  test("skips runners disabled in config", async () => {
+    const cr = new FakeCommandRunner();
+    const machine = MachineConfigSchema.parse({});
+    const project = ProjectConfigSchema.parse({
+      runners: { selene: { enabled: false } },
+    });
+    const config = buildResolvedConfig(machine, project);
+    const plugin = makePlugin([makeRunner("selene", false)]);
+
+    const { tools } = await doctorStep("/project", [plugin], config, cr);
+
+    expect(tools).toHaveLength(0);
+  });
+
+  test("flags slow runners", async () => {
+    const cr = new FakeCommandRunner();
+    registerVersion(cr, "coverage", "coverage 1.0.0");
+    const plugin = makePlugin([makeRunner("coverage", true, { slow: true })]);
+
+    const { tools } = await doctorStep("/project", [plugin], makeConfig(), cr);
+
+    expect(tools[0]?.slow).toBe(true);
+    expect(formatDoctorTable(tools)[1]).toBe("coverage (slow)  ok      1.0.0");
+  });
+});
+
+describe("formatDoctorTable", () => {
+  test("aligns columns and shows hints only for problems", () => {
+    const lines = formatDoctorTable([
+      { runnerId: "ruff", status: "ok", version: "0.6.9", hint: "pip install ruff" },
+      {
+        runnerId: "shellcheck",
+        status: "outdated",
+        version: "0.6.0",
+        minVersion: "0.7.0",
+        hint: "brew install shellcheck",
+      },
+      {
+        runnerId: "selene",
+        status: "missing",
+        version: null,
+        hint: "cargo install selene",
+      },
+    ]);
+
+    expect(lines).toEqual([
+      "TOOL        STATUS    VERSION         INSTALL",
+      "ruff        ok        0.6.9",
+      "shellcheck  outdated  0.6.0 (<0.7.0)  brew install shellcheck",
+      "selene      missing   -               cargo install selene",
+    ]);
+  });
+});
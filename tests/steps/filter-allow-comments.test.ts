@@ -1,6 +1,9 @@
 import { describe, expect, test } from "bun:test";
 import type { LintIssue } from "@/models/lint-issue";
-import { filterAllowComments } from "@/steps/filter-allow-comments";
+import {
+  filterAllowComments,
+  MISSING_REASON_RULE,
+} from "@/steps/filter-allow-comments";
 import { FakeFileManager } from "../fakes/fake-file-manager";
 
 function makeIssue(overrides: Partial<LintIssue> = {}): LintIssue {
@@ -20,7 +23,7 @@ function makeIssue(overrides: Partial<LintIssue> = {}): LintIssue {
 describe("filterAllowComments", () => {
   test("returns empty array when no issues", async () => {
     const fm = new FakeFileManager();
-    const result = await filterAllowComments([], fm);
+    const result = await filterAllowComments([], fm, "/project");
     expect(result).toHaveLength(0);
   });
 
@@ -29,7 +32,7 @@ describe("filterAllowComments", () => {
     fm.seed("/project/src/foo.ts", "const x = 1;\nconsole.log(x);\n");
     const issues = [makeIssue({ line: 2 })];
 
-    const result = await filterAllowComments(issues, fm);
+    const result = await filterAllowComments(issues, fm, "/project");
     expect(result).toHaveLength(1);
   });
 
@@ -42,7 +45,7 @@ describe("filterAllowComments", () => {
     );
     const issues = [makeIssue({ line: 2 })];
 
-    const result = await filterAllowComments(issues, fm);
+    const result = await filterAllowComments(issues, fm, "/project");
     expect(result).toHaveLength(0);
   });
 
@@ -55,7 +58,7 @@ describe("filterAllowComments", () => {
     );
     const issues = [makeIssue({ line: 2 })];
 
-    const result = await filterAllowComments(issues, fm);
+    const result = await filterAllowComments(issues, fm, "/project");
     expect(result).toHaveLength(0);
   });
 
@@ -67,7 +70,7 @@ describe("filterAllowComments", () => {
     );
     const issues = [makeIssue({ rule: "biome/noConsole", line: 2 })];
 
-    const result = await filterAllowComments(issues, fm);
+    const result = await filterAllowComments(issues, fm, "/project");
     expect(result).toHaveLength(1);
   });
 
@@ -80,7 +83,7 @@ describe("filterAllowComments", () => {
     );
     const issues = [makeIssue({ line: 3 })];
 
-    const result = await filterAllowComments(issues, fm);
+    const result = await filterAllowComments(issues, fm, "/project");
     expect(result).toHaveLength(1);
   });
 
@@ -89,7 +92,7 @@ describe("filterAllowComments", () => {
     // /project/src/missing.ts is not seeded
     const issues = [makeIssue({ file: "/project/src/missing.ts", line: 1 })];
 
-    const result = await filterAllowComments(issues, fm);
+    const result = await filterAllowComments(issues, fm, "/project");
     expect(result).toHaveLength(1);
   });
 
@@ -110,7 +113,7 @@ describe("filterAllowComments", () => {
       makeIssue({ rule: "ruff/E501", line: 5, fingerprint: "fp-xyz" }),
     ];
 
-    const result = await filterAllowComments(issues, fm);
+    const result = await filterAllowComments(issues, fm, "/project");
     expect(result).toHaveLength(0);
   });
 
@@ -129,7 +132,7 @@ describe("filterAllowComments", () => {
       makeIssue({ rule: "ruff/E501", line: 3, fingerprint: "fp-2" }),
     ];
 
-    const result = await filterAllowComments(issues, fm);
+    const result = await filterAllowComments(issues, fm, "/project");
     expect(result).toHaveLength(1);
     expect(result[0]?.rule).toBe("ruff/E501");
   });
@@ -149,7 +152,7 @@ describe("filterAllowComments", () => {
       }),
     ];
 
-    const result = await filterAllowComments(issues, fm);
+    const result = await filterAllowComments(issues, fm, "/project");
     expect(result).toHaveLength(0);
   });
 
@@ -168,7 +171,7 @@ describe("filterAllowComments", () => {
       }),
     ];
 
-    const result = await filterAllowComments(issues, fm);
+    const result = await filterAllowComments(issues, fm, "/project");
     expect(result).toHaveLength(0);
   });
 
@@ -186,8 +189,64 @@ describe("filterAllowComments", () => {
       makeIssue({ file: "/project/src/b.ts", line: 1, fingerprint: "fp-b" }),
     ];
 
-    const result = await filterAllowComments(issues, fm);
+    const result = await filterAllowComments(issues, fm, "/project");
     expect(result).toHaveLength(1);
     expect(result[0]?.file).toBe("/project/src/b.ts");
   });
 });
+
+describe("filterAllowComments — guardrails:ignore", () => {
+  test("suppresses the rule on the next line with an unquoted reason", async () => {
+    const fm = new FakeFileManager();
+    fm.seed(
+      "/project/src/foo.ts",
+      "// guardrails:ignore biome/noConsole CLI entry point prints by design\nconsole.log(1);\n"
+    );
+
+    const result = await filterAllowComments([makeIssue({ line: 2 })], fm, "/project");
+
+    expect(result).toHaveLength(0);
+  });
+
+  test("suppresses on the same line, inside a block comment", async () => {
+    const fm = new FakeFileManager();
+    fm.seed(
+      "/project/src/foo.ts",
+      'console.log(1); /* guardrails:ignore biome/noConsole "debug build only" */\n'
+    );
+
+    const result = await filterAllowComments([makeIssue({ line: 1 })], fm, "/project");
+
+    expect(result).toHaveLength(0);
+  });
+
+  test("a directive without a reason keeps the issue and is reported", async () => {
+    const fm = new FakeFileManager();
+    fm.seed(
+      "/project/src/foo.ts",
+      "// guardrails:ignore biome/noConsole\nconsole.log(1);\n"
+    );
+
+    const result = await filterAllowComments([makeIssue({ line: 2 })], fm, "/project");
+
+    expect(result.map((i) => [i.rule, i.line])).toEqual([
+      ["biome/noConsole", 2],
+      [MISSING_REASON_RULE, 1],
+    ]);
+    const reported = result[1];
+    expect(reported?.severity).toBe("error");
+    expect(reported?.message).toBe(
+      "guardrails:ignore biome/noConsole has no reason, so it suppresses nothing"
+    );
+    expect(reported?.fingerprint).toHaveLength(64);
+  });
+
+  test("a reasonless directive that matches no issue is not reported", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/src/foo.ts", "// guardrails:ignore ruff/E501\nconsole.log(1);\n");
+
+    const result = await filterAllowComments([makeIssue({ line: 2 })], fm, "/project");
+
+    expect(result.map((i) => i.rule)).toEqual(["biome/noConsole"]);
+  });
+});
@@ -0,0 +1,318 @@
+import { describe, expect, test } from "bun:test";
+import {
+  buildResolvedConfig,
+  MachineConfigSchema,
+  NestedConfigSchema,
+  ProjectConfigSchema,
+  withNestedConfigs,
+} from "@/config/schema";
+import type { LanguagePlugin } from "@/languages/types";
+import type { LintIssue } from "@/models/lint-issue";
+import type { RunnerReport } from "@/models/runner-report";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { fixStep, selectFixers } from "@/steps/fix-step";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeConsole } from "../fakes/fake-console";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+function makeConfig(project: Record<string, unknown> = {}) {
+  const machine = MachineConfigSchema.parse({});
+  return buildResolvedConfig(machine, ProjectConfigSchema.parse(project));
+}
+
+function makeIssue(overrides: Partial<LintIssue> = {}): LintIssue {
+  return {
+    rule: "fmt/format",
+    linter: "fmt",
+    file: "/project/a.txt",
+    line: 1,
+    col: 1,
+    message: "File is not formatted",
+    severity: "error",
+    fingerprint: "fp-1",
+    ...overrides,
+  };
+}
+
+/** A runner whose fix rewrites the given files through the file manager */
+function makeFixer(id: string, rewrites: readonly string[]): LinterRunner {
+  return {
+    id,
+    name: id.toUpperCase(),
+    configFile: null,
+    installHint: { description: "Test tool" },
+    async isAvailable() {
+      return true;
+    },
+    async run(): Promise<LintIssue[]> {
+      return [];
+    },
+    async fix({ fileManager }: RunOptions): Promise<void> {
+      for (const file of rewrites) await fileManager.writeText(file, "fixed\n");
+    },
+  };
+}
+
+function makePlugin(runners: LinterRunner[]): LanguagePlugin {
+  return {
+    id: "test",
+    name: "Test",
+    async detect() {
+      return true;
+    },
+    runners() {
+      return runners;
+    },
+  };
+}
+
+function okReport(runnerId: string): RunnerReport {
+  return { runnerId, name: runnerId, status: "ok", durationMs: 0 };
+}
+
+describe("fixStep", () => {
+  test("counts distinct changed files across runners", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/a.txt", "messy\n");
+    fm.seed("/project/b.txt", "messy\n");
+    const plugin = makePlugin([
+      makeFixer("fmt", ["/project/a.txt", "/project/b.txt"]),
+      makeFixer("lint", ["/project/a.txt"]),
+    ]);
+    const issues = [
+      makeIssue(),
+      makeIssue({ file: "/project/b.txt", fingerprint: "fp-2" }),
+      makeIssue({ linter: "lint", rule: "lint/x", fingerprint: "fp-3" }),
+    ];
+
+    const { result, fixedFiles, fixedRunners } = await fixStep(
+      "/project",
+      [plugin],
+      makeConfig(),
+      new FakeCommandRunner(),
+      fm,
+      issues,
+      [okReport("fmt"), okReport("lint")]
+    );
+
+    expect(fixedFiles).toBe(2);
+    // "lint" ran second and found a.txt already rewritten — no further change
+    expect(fixedRunners).toBe(1);
+    expect(result.message).toBe("Fixed 2 file(s) across 1 runner(s)");
+  });
+
+  test("skips runners without fix support", async () => {
+    const fm = new FakeFileManager();
+    const { fix: _fix, ...noFix } = makeFixer("vet", ["/project/a.txt"]);
+
+    const { fixedFiles } = await fixStep(
+      "/project",
+      [makePlugin([noFix])],
+      makeConfig(),
+      new FakeCommandRunner(),
+      fm,
+      [makeIssue({ linter: "vet" })],
+      [okReport("vet")]
+    );
+
+    expect(fixedFiles).toBe(0);
+    expect(fm.written).toHaveLength(0);
+  });
+
+  test("skips runners that had no issues, did not run, or are disabled", async () => {
+    const fm = new FakeFileManager();
+    const plugin = makePlugin([
+      makeFixer("clean", ["/project/clean.txt"]),
+      makeFixer("missing", ["/project/missing.txt"]),
+      makeFixer("off", ["/project/off.txt"]),
+    ]);
+    const issues = [
+      makeIssue({ linter: "missing" }),
+      makeIssue({ linter: "off", fingerprint: "fp-2" }),
+    ];
+
+    await fixStep(
+      "/project",
+      [plugin],
+      makeConfig({ runners: { off: { enabled: false } } }),
+      new FakeCommandRunner(),
+      fm,
+      issues,
+      [
+        okReport("clean"),
+        { runnerId: "missing", name: "missing", status: "skipped", durationMs: 0 },
+        okReport("off"),
+      ]
+    );
+
+    expect(fm.written).toHaveLength(0);
+  });
+
+  test("warns and continues when a fixer throws", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/a.txt", "messy\n");
+    const broken: LinterRunner = {
+      ...makeFixer("broken", []),
+      async fix(): Promise<void> {
+        throw new Error("tool crashed");
+      },
+    };
+    const cons = new FakeConsole();
+
+    const { fixedFiles } = await fixStep(
+      "/project",
+      [makePlugin([broken, makeFixer("fmt", ["/project/a.txt"])])],
+      makeConfig(),
+      new FakeCommandRunner(),
+      fm,
+      [makeIssue({ linter: "broken" }), makeIssue({ fingerprint: "fp-2" })],
+      [okReport("broken"), okReport("fmt")],
+      cons
+    );
+
+    expect(fixedFiles).toBe(1);
+    expect(cons.warnings).toContain("  BROKEN fix failed — tool crashed");
+  });
+});
+
+describe("fixStep — scope", () => {
+  /** A runner recording the options each fix call got */
+  function recordingFixer(id: string, calls: RunOptions[], fileScoped: boolean) {
+    return {
+      ...makeFixer(id, []),
+      fileScoped,
+      async fix(opts: RunOptions): Promise<void> {
+        calls.push(opts);
+      },
+    };
+  }
+
+  test("gives a file-scoped runner only the files its issues are in", async () => {
+    const calls: RunOptions[] = [];
+
+    await fixStep(
+      "/project",
+      [makePlugin([recordingFixer("fmt", calls, true)])],
+      makeConfig(),
+      new FakeCommandRunner(),
+      new FakeFileManager(),
+      [makeIssue({ file: "/project/src/b.txt" }), makeIssue({ fingerprint: "fp-2" })],
+      [okReport("fmt")],
+      undefined,
+      { batchSize: 10 }
+    );
+
+    expect(calls.map((opts) => [opts.files, opts.batchSize])).toEqual([
+      [["a.txt", "src/b.txt"], 10],
+    ]);
+  });
+
+  test("skips a whole-project fixer when only some files were checked", async () => {
+    const calls: RunOptions[] = [];
+    const cons = new FakeConsole();
+
+    await fixStep(
+      "/project",
+      [makePlugin([recordingFixer("tidy", calls, false)])],
+      makeConfig(),
+      new FakeCommandRunner(),
+      new FakeFileManager(),
+      [makeIssue({ linter: "tidy" })],
+      [okReport("tidy")],
+      cons,
+      { files: ["a.txt"] }
+    );
+
+    expect(calls).toHaveLength(0);
+    expect(cons.warnings).toEqual([
+      "  TIDY can only fix the whole project — skipped for a partial check",
+    ]);
+  });
+});
+
+describe("fixStep — unreadable files", () => {
+  /** Fails every read of a.txt, as on a permission error */
+  class UnreadableFileManager extends FakeFileManager {
+    override async readText(path: string): Promise<string> {
+      if (path === "/project/a.txt") throw new Error("EACCES: permission denied");
+      return super.readText(path);
+    }
+  }
+
+  test("warns about a file it cannot read and does not count it as fixed", async () => {
+    const fm = new UnreadableFileManager();
+    fm.seed("/project/a.txt", "messy\n");
+    const cons = new FakeConsole();
+
+    const { fixedFiles } = await fixStep(
+      "/project",
+      [makePlugin([makeFixer("fmt", ["/project/a.txt"])])],
+      makeConfig(),
+      new FakeCommandRunner(),
+      fm,
+      [makeIssue()],
+      [okReport("fmt")],
+      cons
+    );
+
+    expect(fixedFiles).toBe(0);
+    expect(cons.warnings).toContain(
+      "  Could not read /project/a.txt — EACCES: permission denied"
+    );
+  });
+});
+
+describe("selectFixers", () => {
+  test("pairs each fix-capable runner that ran with its issues' files, sorted", () => {
+    const { fix: _fix, ...noFix } = makeFixer("vet", []);
+    const plugin = makePlugin([makeFixer("fmt", []), noFix, makeFixer("clean", [])]);
+    const issues = [
+      makeIssue({ file: "/project/b.txt" }),
+      makeIssue({ fingerprint: "fp-2" }),
+      makeIssue({ file: "/project/b.txt", fingerprint: "fp-3" }),
+      makeIssue({ linter: "vet", fingerprint: "fp-4" }),
+    ];
+    const reports = [okReport("fmt"), okReport("vet"), okReport("clean")];
+
+    const fixers = selectFixers("/project", [plugin], makeConfig(), issues, reports);
+
+    expect(fixers.map((f) => [f.runner.id, f.files])).toEqual([
+      ["fmt", ["/project/a.txt", "/project/b.txt"]],
+    ]);
+  });
+
+  test("leaves out files in a subtree whose config turns the runner off", () => {
+    const config = withNestedConfigs(makeConfig(), [
+      {
+        dir: "legacy",
+        project: NestedConfigSchema.parse({ runners: { fmt: { enabled: false } } }),
+      },
+    ]);
+    const issues = [makeIssue(), makeIssue({ file: "/project/legacy/b.txt" })];
+
+    const fixers = selectFixers(
+      "/project",
+      [makePlugin([makeFixer("fmt", [])])],
+      config,
+      issues,
+      [okReport("fmt")]
+    );
+
+    expect(fixers.map((f) => f.files)).toEqual([["/project/a.txt"]]);
+  });
+
+  test("leaves out files outside the checked ones", () => {
+    const issues = [makeIssue(), makeIssue({ file: "/project/b.txt" })];
+
+    const fixers = selectFixers(
+      "/project",
+      [makePlugin([makeFixer("fmt", [])])],
+      makeConfig(),
+      issues,
+      [okReport("fmt")],
+      { files: ["b.txt"] }
+    );
+
+    expect(fixers.map((f) => f.files)).toEqual([["/project/b.txt"]]);
+  });
+});
@@ -1,10 +1,12 @@
 import { describe, expect, test } from "bun:test";
+import { parse as parseToml } from "smol-toml";
 import {
   buildResolvedConfig,
   MachineConfigSchema,
   ProjectConfigSchema,
 } from "@/config/schema";
 import { generateConfigsStep } from "@/steps/generate-configs";
+import { computeHash, HASH_PREFIX } from "@/utils/hash";
 import { FakeFileManager } from "../fakes/fake-file-manager";
 import { makePlugin } from "../fakes/fake-language-plugin";
 
@@ -131,6 +133,55 @@ describe("applyStrategy — file exists + merge on TOML", () => {
   });
 });
 
+describe("applyStrategy — file exists + merge on user-owned ruff.toml", () => {
+  test("adds recommended rules the user does not select to extend-select", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/ruff.toml", '[lint]\nselect = ["E"]\n');
+
+    const result = await generateConfigsStep(
+      "/project",
+      PYTHON,
+      makeConfig(),
+      fm,
+      "merge"
+    );
+
+    expect(result.status).toBe("ok");
+    expect(result.message).toContain("Merged into user-owned file(s): ruff.toml");
+    const merged = fm.written.find(([p]) => p === "/project/ruff.toml")?.[1] ?? "";
+    expect(merged).toMatch(/^# ai-guardrails:sha256=/);
+    const lint = parseToml(merged).lint as Record<string, unknown>;
+    expect(lint.select).toEqual(["E"]);
+    expect(lint["extend-select"]).toContain("F");
+  });
+
+  test("does not flag a managed file as user-owned", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/ruff.toml", "# ai-guardrails:sha256=abc\nline-length = 100\n");
+
+    const result = await generateConfigsStep(
+      "/project",
+      PYTHON,
+      makeConfig(),
+      fm,
+      "merge"
+    );
+
+    expect(result.message).not.toContain("user-owned");
+  });
+
+  test("replaces an untouched file from an older template", async () => {
+    const fm = new FakeFileManager();
+    const body = "line-length = 120\n";
+    fm.seed("/project/ruff.toml", `${HASH_PREFIX}${computeHash(body)}\n${body}`);
+
+    await generateConfigsStep("/project", PYTHON, makeConfig(), fm, "merge");
+
+    const written = fm.written.find(([p]) => p === "/project/ruff.toml")?.[1] ?? "";
+    expect(written).not.toContain("line-length = 120");
+  });
+});
+
 // ---------------------------------------------------------------------------
 // Strategy: file exists + merge on JSON/JSONC — deep-merges
 // ---------------------------------------------------------------------------
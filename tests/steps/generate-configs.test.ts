@@ -33,14 +33,49 @@ describe("generateConfigsStep", () => {
   test("writes all generators when all gated languages are active", async () => {
     const fm = new FakeFileManager();
     const config = makeConfig();
-    const languages = [makePlugin("python"), makePlugin("typescript")];
+    const languages = ["python", "typescript", "go"].map((id) => makePlugin(id));
 
-    const result = await generateConfigsStep("/project", languages, config, fm);
+    const result = await generateConfigsStep(
+      "/project",
+      languages,
+      config,
+      fm,
+      "merge",
+      true
+    );
 
     expect(result.status).toBe("ok");
     expect(fm.written.length).toBe(ALL_GENERATORS.length);
   });
 
+  test("writes .golangci.yml only with --force", async () => {
+    const plain = new FakeFileManager();
+    const forced = new FakeFileManager();
+    const config = makeConfig();
+    const go = [makePlugin("go")];
+
+    await generateConfigsStep("/project", go, config, plain);
+    await generateConfigsStep("/project", go, config, forced, "merge", true);
+
+    expect(plain.written.some(([p]) => p.endsWith(".golangci.yml"))).toBe(false);
+    expect(forced.written.some(([p]) => p.endsWith(".golangci.yml"))).toBe(true);
+  });
+
+  test("golangci generator honours the project's own golangci-lint config", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.golangci.toml", "[linters]\n");
+    const fresh = new FakeFileManager();
+    const config = makeConfig();
+    const go = [makePlugin("go")];
+
+    const result = await generateConfigsStep("/project", go, config, fm, "merge", true);
+    await generateConfigsStep("/project", go, config, fresh, "merge", true);
+
+    expect(fm.written.some(([p]) => p.endsWith(".golangci.yml"))).toBe(false);
+    expect(result.message).toContain(".golangci.toml");
+    expect(fresh.written.some(([p]) => p.endsWith(".golangci.yml"))).toBe(true);
+  });
+
   test("ruff generator runs for python-only project", async () => {
     const fm = new FakeFileManager();
     const config = makeConfig();
@@ -106,6 +141,25 @@ describe("generateConfigsStep", () => {
     expect(result.message).toContain(String(UNIVERSAL_COUNT));
   });
 
+  test("skips a tool using the project's own config file", async () => {
+    const fm = new FakeFileManager();
+    const machine = MachineConfigSchema.parse({});
+    const project = ProjectConfigSchema.parse({
+      runners: {
+        ruff: { user_config: "pyproject.toml" },
+        markdownlint: { user_config: ".markdownlint.yaml" },
+      },
+    });
+    const config = buildResolvedConfig(machine, project);
+
+    await generateConfigsStep("/project", [makePlugin("python")], config, fm);
+
+    const paths = fm.written.map(([p]) => p);
+    expect(paths).not.toContain("/project/ruff.toml");
+    expect(paths).not.toContain("/project/.markdownlint.jsonc");
+    expect(paths).toContain("/project/.editorconfig");
+  });
+
   describe("stale config cleanup on replace strategy", () => {
     test("deletes biome.jsonc with hash header when python-only project uses replace", async () => {
       const fm = new FakeFileManager();
@@ -123,8 +123,8 @@ When<GeneratorWorld>(
   "I generate with profile {string}",
   (world: GeneratorWorld, profile: unknown) => {
     const p = String(profile);
-    if (p !== "strict" && p !== "standard" && p !== "minimal") {
-      throw new Error(`Unknown profile: "${p}". Expected: strict, standard, minimal`);
+    if (p !== "strict" && p !== "standard" && p !== "lenient") {
+      throw new Error(`Unknown profile: "${p}". Expected: strict, standard, lenient`);
     }
     world.generatorOutput = world.generator.generate(makeDefaultConfig({ profile: p }));
   }
@@ -312,7 +312,9 @@ Then<GeneratorWorld>(
 Then<GeneratorWorld>(
   "the output should start with a JSONC hash header",
   (world: GeneratorWorld) => {
-    expect(world.generatorOutput).toMatch(/^\/\/ ai-guardrails:sha256=[0-9a-f]{64}\n/);
+    expect(world.generatorOutput).toMatch(
+      /^\/\/ ai-guardrails:sha256=[0-9a-f]{64};template=v\d+\n/
+    );
   }
 );
 
@@ -0,0 +1,162 @@
+import { describe, expect, test } from "bun:test";
+import {
+  addHookBlock,
+  HOOK_BLOCK_BEGIN,
+  HOOK_BLOCK_END,
+  installGitHookStep,
+  removeHookBlock,
+  uninstallGitHookStep,
+} from "@/steps/git-hook";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const HOOK_PATH = "/project/.git/hooks/pre-commit";
+const LEFTHOOK_SCRIPT = '#!/bin/sh\n\ncall_lefthook run "pre-commit" "$@"\n';
+
+function makeRunner(gitPath = ".git/hooks/pre-commit"): FakeCommandRunner {
+  const cr = new FakeCommandRunner();
+  cr.register(["git", "rev-parse", "--git-path", "hooks/pre-commit"], {
+    stdout: `${gitPath}\n`,
+    stderr: "",
+    exitCode: 0,
+  });
+  return cr;
+}
+
+describe("addHookBlock", () => {
+  test("creates a shell script when there is no hook", () => {
+    const script = addHookBlock(null) ?? "";
+    expect(script.startsWith("#!/bin/sh\n")).toBe(true);
+    expect(script).toContain("ai-guardrails check --staged || exit $?");
+  });
+
+  test("inserts the block right after an existing shebang", () => {
+    const script = addHookBlock(LEFTHOOK_SCRIPT) ?? "";
+    expect(script.startsWith(`#!/bin/sh\n${HOOK_BLOCK_BEGIN}`)).toBe(true);
+    expect(script.endsWith('call_lefthook run "pre-commit" "$@"\n')).toBe(true);
+  });
+
+  test("returns null when the block is already present", () => {
+    const once = addHookBlock(LEFTHOOK_SCRIPT);
+    expect(addHookBlock(once)).toBeNull();
+  });
+
+  test("merges into bash hooks, shebang through env included", () => {
+    const script = addHookBlock("#!/usr/bin/env bash\nset -e\n") ?? "";
+    expect(script.startsWith(`#!/usr/bin/env bash\n${HOOK_BLOCK_BEGIN}`)).toBe(true);
+  });
+
+  test("refuses a hook written in another language", () => {
+    expect(() => addHookBlock("#!/usr/bin/env python3\nimport sys\n")).toThrow(
+      "the pre-commit hook is a python3 script"
+    );
+  });
+});
+
+describe("removeHookBlock", () => {
+  test("restores the original script", () => {
+    const installed = addHookBlock(LEFTHOOK_SCRIPT) ?? "";
+    expect(removeHookBlock(installed)).toBe(LEFTHOOK_SCRIPT);
+  });
+
+  test("returns null when only our block and a shebang remain", () => {
+    expect(removeHookBlock(addHookBlock(null) ?? "")).toBeNull();
+  });
+
+  test("leaves scripts without our block untouched", () => {
+    expect(removeHookBlock(LEFTHOOK_SCRIPT)).toBe(LEFTHOOK_SCRIPT);
+  });
+});
+
+describe("installGitHookStep", () => {
+  test("writes the hook at git's hooks path and makes it executable", async () => {
+    const cr = makeRunner();
+    const fm = new FakeFileManager();
+
+    const result = await installGitHookStep("/project", cr, fm);
+
+    expect(result.status).toBe("ok");
+    expect(await fm.readText(HOOK_PATH)).toContain(HOOK_BLOCK_END);
+    expect(cr.calls).toContainEqual(["chmod", "+x", HOOK_PATH]);
+  });
+
+  test("honours an absolute hooks path", async () => {
+    const cr = makeRunner("/shared/hooks/pre-commit");
+    const fm = new FakeFileManager();
+
+    await installGitHookStep("/project", cr, fm);
+
+    expect(await fm.exists("/shared/hooks/pre-commit")).toBe(true);
+  });
+
+  test("is idempotent", async () => {
+    const cr = makeRunner();
+    const fm = new FakeFileManager();
+
+    await installGitHookStep("/project", cr, fm);
+    const second = await installGitHookStep("/project", cr, fm);
+
+    expect(second.message).toContain("already installed");
+    expect(fm.written).toHaveLength(1);
+  });
+
+  test("leaves a non-shell hook untouched and says why", async () => {
+    const cr = makeRunner();
+    const fm = new FakeFileManager();
+    fm.seed(HOOK_PATH, "#!/usr/bin/env node\nprocess.exit(0);\n");
+
+    const result = await installGitHookStep("/project", cr, fm);
+
+    expect(result.status).toBe("error");
+    expect(result.message).toContain("is a node script");
+    expect(fm.written).toHaveLength(0);
+  });
+
+  test("returns error outside a git repository", async () => {
+    const cr = new FakeCommandRunner();
+    cr.register(["git", "rev-parse", "--git-path", "hooks/pre-commit"], {
+      stdout: "",
+      stderr: "fatal: not a git repository",
+      exitCode: 128,
+    });
+
+    const result = await installGitHookStep("/project", cr, new FakeFileManager());
+
+    expect(result.status).toBe("error");
+  });
+});
+
+describe("uninstallGitHookStep", () => {
+  test("deletes a hook that only contained our block", async () => {
+    const cr = makeRunner();
+    const fm = new FakeFileManager();
+    await installGitHookStep("/project", cr, fm);
+
+    const result = await uninstallGitHookStep("/project", cr, fm);
+
+    expect(result.status).toBe("ok");
+    expect(fm.deleted).toContain(HOOK_PATH);
+  });
+
+  test("keeps the rest of a shared hook", async () => {
+    const cr = makeRunner();
+    const fm = new FakeFileManager();
+    fm.seed(HOOK_PATH, LEFTHOOK_SCRIPT);
+    await installGitHookStep("/project", cr, fm);
+
+    await uninstallGitHookStep("/project", cr, fm);
+
+    expect(await fm.readText(HOOK_PATH)).toBe(LEFTHOOK_SCRIPT);
+  });
+
+  test("is a no-op when our block is absent", async () => {
+    const cr = makeRunner();
+    const fm = new FakeFileManager();
+    fm.seed(HOOK_PATH, LEFTHOOK_SCRIPT);
+
+    const result = await uninstallGitHookStep("/project", cr, fm);
+
+    expect(result.message).toContain("No ai-guardrails pre-commit hook");
+    expect(fm.written).toHaveLength(0);
+  });
+});
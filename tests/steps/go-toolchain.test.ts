@@ -0,0 +1,70 @@
+import { describe, expect, test } from "bun:test";
+import { goToolchainStep, parseGoToolchainVersion } from "@/steps/go-toolchain";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeConsole } from "../fakes/fake-console";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+function setup(toolchain: string, exitCode = 0) {
+  const fm = new FakeFileManager();
+  fm.seed("/project/go.work", "use (\n\t./api\n\t./worker\n)\n");
+  fm.seed("/project/api/go.mod", "module example.com/api\n\ngo 1.22\n");
+  fm.seed("/project/worker/go.mod", "module example.com/worker\n\ngo 1.21.5\n");
+  const cr = new FakeCommandRunner();
+  cr.register(["go", "env", "GOVERSION"], {
+    stdout: `${toolchain}\n`,
+    stderr: "",
+    exitCode,
+  });
+  return { fm, cr, cons: new FakeConsole() };
+}
+
+describe("parseGoToolchainVersion", () => {
+  test("strips the go prefix", () => {
+    expect(parseGoToolchainVersion("go1.22.3\n")).toBe("1.22.3");
+  });
+
+  test("returns null for devel builds", () => {
+    expect(parseGoToolchainVersion("devel go1.23-abcdef")).toBeNull();
+  });
+});
+
+describe("goToolchainStep", () => {
+  test("is ok when the toolchain is new enough for every module", async () => {
+    const { fm, cr, cons } = setup("go1.22.3");
+
+    const result = await goToolchainStep("/project", fm, cr, cons);
+
+    expect(result).toEqual({ status: "ok", message: "Go toolchain go1.22.3" });
+  });
+
+  test("warns about modules targeting a newer Go than installed", async () => {
+    const { fm, cr, cons } = setup("go1.21.8");
+
+    const result = await goToolchainStep("/project", fm, cr, cons);
+
+    expect(result).toEqual({
+      status: "warn",
+      message: "Go toolchain go1.21.8 is older than required by api (go 1.22)",
+    });
+  });
+
+  test("logs each module's go version and the toolchain at verbose level", async () => {
+    const { fm, cr, cons } = setup("go1.22.3");
+
+    await goToolchainStep("/project", fm, cr, cons);
+
+    expect(cons.verboses).toEqual([
+      "Go module api: go 1.22",
+      "Go module worker: go 1.21.5",
+      "Go toolchain: go1.22.3",
+    ]);
+  });
+
+  test("skips when go is not installed", async () => {
+    const { fm, cr, cons } = setup("", 127);
+
+    const result = await goToolchainStep("/project", fm, cr, cons);
+
+    expect(result.status).toBe("skip");
+  });
+});
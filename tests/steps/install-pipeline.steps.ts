@@ -26,6 +26,22 @@ Given<PipelineWorld>(
   }
 );
 
+Given<PipelineWorld>(
+  "a default install project with dryRun flag",
+  async (world: PipelineWorld) => {
+    world.ctx = makeBaseCtx({ flags: { dryRun: true } });
+  }
+);
+
+Given<PipelineWorld>(
+  "a default install project with an invalid project config",
+  async (world: PipelineWorld) => {
+    world.ctx = makeBaseCtx();
+    const fm = world.ctx.fileManager as FakeFileManager;
+    fm.seed("/project/.ai-guardrails/config.toml", 'profile = "nope"\n');
+  }
+);
+
 Given<PipelineWorld>(
   "an install result with status {string}",
   async (world: PipelineWorld, status: unknown) => {
@@ -71,6 +87,10 @@ Then<PipelineWorld>(
   }
 );
 
+Then<PipelineWorld>("no files should be written", async (world: PipelineWorld) => {
+  expect((world.ctx.fileManager as FakeFileManager).written).toEqual([]);
+});
+
 Then<PipelineWorld>(
   "a file containing {string} should be written",
   async (world: PipelineWorld, substr: unknown) => {
@@ -95,6 +115,14 @@ Then<PipelineWorld>(
   }
 );
 
+Then<PipelineWorld>(
+  "the install exit code for the run should be {int}",
+  async (world: PipelineWorld, code: unknown) => {
+    if (world.result === undefined) throw new Error("result not set");
+    expect(installExitCode(world.result)).toBe(Number(code));
+  }
+);
+
 Then<PipelineWorld>(
   "at least as many files as generators should be written",
   async (world: PipelineWorld) => {
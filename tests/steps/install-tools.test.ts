@@ -0,0 +1,257 @@
+import { describe, expect, test } from "bun:test";
+import {
+  buildResolvedConfig,
+  MachineConfigSchema,
+  ProjectConfigSchema,
+} from "@/config/schema";
+import type { LanguagePlugin } from "@/languages/types";
+import type { LintIssue } from "@/models/lint-issue";
+import type { InstallHint, LinterRunner, RunOptions } from "@/runners/types";
+import { installToolsStep, manualInstallHint } from "@/steps/install-tools";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeConsole } from "../fakes/fake-console";
+
+const MISSING = { stdout: "", stderr: "not found", exitCode: 127 };
+
+function makeConfig(project: Record<string, unknown> = {}) {
+  return buildResolvedConfig(
+    MachineConfigSchema.parse({}),
+    ProjectConfigSchema.parse(project)
+  );
+}
+
+function makeRunner(id: string, available: boolean, hint: InstallHint): LinterRunner {
+  return {
+    id,
+    name: id,
+    configFile: null,
+    installHint: hint,
+    async isAvailable() {
+      return available;
+    },
+    async run(_opts: RunOptions): Promise<LintIssue[]> {
+      return [];
+    },
+  };
+}
+
+function makePlugin(runners: LinterRunner[]): LanguagePlugin {
+  return {
+    id: "test",
+    name: "Test",
+    async detect() {
+      return true;
+    },
+    runners() {
+      return runners;
+    },
+  };
+}
+
+const ruff = makeRunner("ruff", false, {
+  description: "Python linter",
+  pip: "pip install ruff",
+});
+const staticcheck = makeRunner("staticcheck", false, {
+  description: "Go static analysis",
+  brew: "brew install staticcheck",
+  go: "go install honnef.co/go/tools/cmd/staticcheck@latest",
+});
+const typescript = makeRunner("tsc", false, {
+  description: "TypeScript compiler",
+  npm: "npm install -D typescript",
+});
+const shellcheck = makeRunner("shellcheck", false, {
+  description: "Shell script linter",
+  brew: "brew install shellcheck",
+  apt: "sudo apt install shellcheck",
+});
+
+function installCalls(cr: FakeCommandRunner): string[] {
+  return cr.calls
+    .filter((args) => args.includes("install"))
+    .map((args) => args.join(" "));
+}
+
+describe("manualInstallHint", () => {
+  const hint: InstallHint = shellcheck.installHint;
+
+  test("uses brew on macOS", () => {
+    expect(manualInstallHint(hint, "darwin")).toBe("brew install shellcheck");
+  });
+
+  test("uses apt on Linux", () => {
+    expect(manualInstallHint(hint, "linux")).toBe("sudo apt install shellcheck");
+  });
+
+  test("rewrites npm dev dependencies for the project's package manager", () => {
+    const hint = typescript.installHint;
+    expect(manualInstallHint(hint, "linux", "bun")).toBe("bun add -d typescript");
+    expect(manualInstallHint(hint, "linux")).toBe("npm install -D typescript");
+  });
+
+  test("falls back to the description without a system package", () => {
+    expect(manualInstallHint({ description: "Some tool" }, "win32")).toBe(
+      "Some tool"
+    );
+  });
+});
+
+describe("installToolsStep", () => {
+  test("does nothing when every tool is available", async () => {
+    const cr = new FakeCommandRunner();
+    const plugin = makePlugin([makeRunner("ruff", true, ruff.installHint)]);
+    const { result, tools } = await installToolsStep(
+      "/project",
+      [plugin],
+      makeConfig(),
+      cr,
+      new FakeConsole()
+    );
+    expect(result.status).toBe("ok");
+    expect(tools).toEqual([]);
+    expect(cr.calls).toEqual([]);
+  });
+
+  test("prints and runs the install command for each missing tool", async () => {
+    const cr = new FakeCommandRunner();
+    const cons = new FakeConsole();
+    const { result, tools } = await installToolsStep(
+      "/project",
+      [makePlugin([ruff, staticcheck])],
+      makeConfig(),
+      cr,
+      cons,
+      { platform: "linux" }
+    );
+    expect(result.status).toBe("ok");
+    expect(tools.map((t) => t.outcome)).toEqual(["installed", "installed"]);
+    // go install is preferred over brew for Go tools; pip hints go through pipx
+    expect(installCalls(cr)).toEqual([
+      "pipx install ruff",
+      "go install honnef.co/go/tools/cmd/staticcheck@latest",
+    ]);
+    expect(cons.infos).toContain("  $ pipx install ruff");
+  });
+
+  test("dry run prints the commands without running them", async () => {
+    const cr = new FakeCommandRunner();
+    const cons = new FakeConsole();
+    const { result, tools } = await installToolsStep(
+      "/project",
+      [makePlugin([ruff])],
+      makeConfig(),
+      cr,
+      cons,
+      { dryRun: true }
+    );
+    expect(result.status).toBe("ok");
+    expect(result.message).toContain("1 tool(s) would be installed");
+    expect(tools).toEqual([
+      { runnerId: "ruff", outcome: "planned", command: "pipx install ruff" },
+    ]);
+    expect(cons.infos).toContain("  $ pipx install ruff");
+    expect(installCalls(cr)).toEqual([]);
+  });
+
+  test("gives a platform hint for system-package-only tools", async () => {
+    const cr = new FakeCommandRunner();
+    const cons = new FakeConsole();
+    const { result, tools } = await installToolsStep(
+      "/project",
+      [makePlugin([shellcheck])],
+      makeConfig(),
+      cr,
+      cons,
+      { platform: "darwin" }
+    );
+    expect(result.status).toBe("ok");
+    expect(tools).toEqual([
+      { runnerId: "shellcheck", outcome: "manual", command: "brew install shellcheck" },
+    ]);
+    expect(installCalls(cr)).toEqual([]);
+    expect(cons.warnings.some((w) => w.includes("brew install shellcheck"))).toBe(true);
+  });
+
+  test("falls back to a manual hint when the package manager is absent", async () => {
+    const cr = new FakeCommandRunner();
+    cr.register(["go", "version"], MISSING);
+    const { tools } = await installToolsStep(
+      "/project",
+      [makePlugin([staticcheck])],
+      makeConfig(),
+      cr,
+      new FakeConsole(),
+      { platform: "linux" }
+    );
+    // staticcheck has no apt hint, so brew is the best manual suggestion
+    expect(tools).toEqual([
+      {
+        runnerId: "staticcheck",
+        outcome: "manual",
+        command: "brew install staticcheck",
+      },
+    ]);
+  });
+
+  test("never installs into the system Python without pipx", async () => {
+    const cr = new FakeCommandRunner();
+    cr.register(["pipx", "--version"], MISSING);
+    const { tools } = await installToolsStep(
+      "/project",
+      [makePlugin([ruff])],
+      makeConfig(),
+      cr,
+      new FakeConsole()
+    );
+    expect(tools).toEqual([
+      { runnerId: "ruff", outcome: "manual", command: "pip install ruff" },
+    ]);
+    expect(installCalls(cr)).toEqual([]);
+  });
+
+  test("prints npm dev dependencies instead of editing package.json", async () => {
+    const cr = new FakeCommandRunner();
+    const cons = new FakeConsole();
+    const { tools } = await installToolsStep(
+      "/project",
+      [makePlugin([typescript])],
+      makeConfig(),
+      cr,
+      cons,
+      { jsPackageManager: "pnpm" }
+    );
+    expect(tools).toEqual([
+      { runnerId: "tsc", outcome: "manual", command: "pnpm add -D typescript" },
+    ]);
+    expect(installCalls(cr)).toEqual([]);
+    expect(cons.warnings.some((w) => w.includes("pnpm add -D typescript"))).toBe(true);
+  });
+
+  test("reports an error when an install command fails", async () => {
+    const cr = new FakeCommandRunner();
+    cr.register(["pipx", "install", "ruff"], MISSING);
+    const { result, tools } = await installToolsStep(
+      "/project",
+      [makePlugin([ruff])],
+      makeConfig(),
+      cr,
+      new FakeConsole()
+    );
+    expect(result.status).toBe("error");
+    expect(result.message).toContain("1 failed");
+    expect(tools[0]?.outcome).toBe("failed");
+  });
+
+  test("skips disabled runners", async () => {
+    const cr = new FakeCommandRunner();
+    const { tools } = await installToolsStep(
+      "/project",
+      [makePlugin([ruff])],
+      makeConfig({ runners: { ruff: { enabled: false } } }),
+      cr,
+      new FakeConsole()
+    );
+    expect(tools).toEqual([]);
+  });
+});
@@ -0,0 +1,186 @@
+import { describe, expect, test } from "bun:test";
+import {
+  buildResolvedConfig,
+  MachineConfigSchema,
+  ProjectConfigSchema,
+} from "@/config/schema";
+import type { LanguagePlugin } from "@/languages/types";
+import type { LintIssue } from "@/models/lint-issue";
+import type { LinterRunner, RunOptions } from "@/runners/types";
+import { formatListText, listStep, listToJson } from "@/steps/list-step";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+
+function makeConfig(runners: Record<string, { enabled?: boolean }> = {}) {
+  return buildResolvedConfig(
+    MachineConfigSchema.parse({}),
+    ProjectConfigSchema.parse({ runners })
+  );
+}
+
+function makeRunner(
+  id: string,
+  available: boolean,
+  overrides: Partial<LinterRunner> = {}
+): LinterRunner {
+  return {
+    id,
+    name: id,
+    configFile: null,
+    installHint: { description: `${id} tool`, pip: `pip install ${id}` },
+    async isAvailable() {
+      return available;
+    },
+    async run(_opts: RunOptions): Promise<LintIssue[]> {
+      return [];
+    },
+    ...overrides,
+  };
+}
+
+function makePlugin(id: string, runners: LinterRunner[]): LanguagePlugin {
+  return {
+    id,
+    name: id === "python" ? "Python" : "Universal",
+    async detect() {
+      return true;
+    },
+    runners: () => runners,
+  };
+}
+
+describe("listStep", () => {
+  test("reports each runner as enabled, disabled or unavailable", async () => {
+    const probed: string[] = [];
+    const tracked = (id: string, available: boolean) =>
+      makeRunner(id, available, {
+        async isAvailable() {
+          probed.push(id);
+          return available;
+        },
+      });
+    const python = makePlugin("python", [
+      tracked("ruff", true),
+      tracked("pyright", false),
+      tracked("vulture", true),
+    ]);
+
+    const { result, languages } = await listStep(
+      "/project",
+      [python],
+      new Map([["python", ["pyproject.toml"]]]),
+      makeConfig({ vulture: { enabled: false } }),
+      new FakeCommandRunner()
+    );
+
+    expect(result.status).toBe("ok");
+    expect(result.message).toBe(
+      "1 language(s), 1 runner(s) enabled, 1 disabled, 1 unavailable"
+    );
+    expect(languages).toEqual([
+      {
+        id: "python",
+        name: "Python",
+        evidence: ["pyproject.toml"],
+        runners: [
+          { id: "ruff", name: "ruff", status: "enabled" },
+          {
+            id: "pyright",
+            name: "pyright",
+            status: "unavailable",
+            hint: "pip install pyright",
+          },
+          { id: "vulture", name: "vulture", status: "disabled" },
+        ],
+      },
+    ]);
+    // Disabled runners are not probed
+    expect(probed.toSorted()).toEqual(["pyright", "ruff"]);
+  });
+
+  test("opt-in runners are disabled unless config enables them", async () => {
+    const plugin = makePlugin("python", [
+      makeRunner("extra", true, { defaultEnabled: false }),
+    ]);
+
+    const off = await listStep(
+      "/p",
+      [plugin],
+      new Map(),
+      makeConfig(),
+      new FakeCommandRunner()
+    );
+    const on = await listStep(
+      "/p",
+      [plugin],
+      new Map(),
+      makeConfig({ extra: { enabled: true } }),
+      new FakeCommandRunner()
+    );
+
+    expect(off.languages[0]?.runners[0]?.status).toBe("disabled");
+    expect(on.languages[0]?.runners[0]?.status).toBe("enabled");
+  });
+
+  test("returns an error when a probe throws", async () => {
+    const plugin = makePlugin("python", [
+      makeRunner("ruff", true, {
+        async isAvailable(): Promise<boolean> {
+          throw new Error("boom");
+        },
+      }),
+    ]);
+
+    const { result } = await listStep(
+      "/p",
+      [plugin],
+      new Map(),
+      makeConfig(),
+      new FakeCommandRunner()
+    );
+
+    expect(result.status).toBe("error");
+    expect(result.message).toContain("boom");
+  });
+});
+
+describe("formatListText", () => {
+  test("lists each language with its evidence, then its runners", async () => {
+    const { languages } = await listStep(
+      "/project",
+      [
+        makePlugin("python", [makeRunner("ruff", true), makeRunner("pyright", false)]),
+        makePlugin("universal", [makeRunner("codespell", true)]),
+      ],
+      new Map([["python", ["pyproject.toml", "src/app.py"]]]),
+      makeConfig(),
+      new FakeCommandRunner()
+    );
+
+    expect(formatListText(languages)).toEqual([
+      "Python (from pyproject.toml, src/app.py)",
+      "  ✓ ruff       enabled",
+      "  ✗ pyright    unavailable — pip install pyright",
+      "Universal (always on)",
+      "  ✓ codespell  enabled",
+    ]);
+  });
+
+  test("marks slow runners", async () => {
+    const { languages } = await listStep(
+      "/project",
+      [makePlugin("universal", [makeRunner("coverage", true, { slow: true })])],
+      new Map(),
+      makeConfig(),
+      new FakeCommandRunner()
+    );
+
+    expect(languages[0]?.runners[0]?.slow).toBe(true);
+    expect(formatListText(languages)).toContain("  ✓ coverage  enabled (slow)");
+  });
+});
+
+describe("listToJson", () => {
+  test("wraps the plan in a versioned document", () => {
+    expect(listToJson([])).toEqual({ schemaVersion: 1, languages: [] });
+  });
+});
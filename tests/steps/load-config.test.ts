@@ -1,4 +1,5 @@
 import { describe, expect, test } from "bun:test";
+import { loadGlobalConfig } from "@/config/loader";
 import type { FileManager } from "@/infra/file-manager";
 import { loadConfigStep } from "@/steps/load-config";
 import { FakeFileManager } from "../fakes/fake-file-manager";
@@ -30,15 +31,58 @@ describe("loadConfigStep", () => {
 
   test("result message includes profile name", async () => {
     const fm = new FakeFileManager();
-    fm.seed("/project/.ai-guardrails/config.toml", `profile = "minimal"\n`);
+    fm.seed("/project/.ai-guardrails/config.toml", `profile = "lenient"\n`);
 
     const { result, config } = await loadConfigStep("/project", fm);
 
     expect(result.status).toBe("ok");
-    expect(result.message).toContain("profile=minimal");
+    expect(result.message).toContain("profile=lenient");
     expect(config).not.toBeNull();
   });
 
+  test("parses a project config written as YAML", async () => {
+    const fm = new FakeFileManager();
+    fm.seed(
+      "/project/.ai-guardrails/config.yaml",
+      "profile: strict\nconfig:\n  line_length: 120\n"
+    );
+
+    const { result, config } = await loadConfigStep("/project", fm);
+
+    expect(result.status).toBe("ok");
+    expect(config?.profile).toBe("strict");
+    expect(config?.values.line_length).toBe(120);
+  });
+
+  test("records the keys the project config wrote, under its path", async () => {
+    const fm = new FakeFileManager();
+    fm.seed(
+      "/project/.ai-guardrails/config.yaml",
+      "profile: strict\nconfig:\n  line_length: 120\nrunners:\n  ruff: {}\n"
+    );
+
+    const { config } = await loadConfigStep("/project", fm);
+
+    expect(config?.written?.project).toEqual({
+      path: ".ai-guardrails/config.yaml",
+      keys: ["profile", "config.line_length"],
+    });
+  });
+
+  test("returns error when both config.toml and config.yaml exist", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.ai-guardrails/config.toml", `profile = "strict"\n`);
+    fm.seed("/project/.ai-guardrails/config.yaml", "profile: lenient\n");
+
+    const { result, config } = await loadConfigStep("/project", fm);
+
+    expect(result.status).toBe("error");
+    expect(result.message).toContain(
+      "both .ai-guardrails/config.toml and .ai-guardrails/config.yaml exist"
+    );
+    expect(config).toBeNull();
+  });
+
   test("returns error and null config on malformed TOML", async () => {
     const fm = new FakeFileManager();
     fm.seed("/project/.ai-guardrails/config.toml", `this is [ not valid toml !!!\n`);
@@ -50,6 +94,26 @@ describe("loadConfigStep", () => {
     expect(config).toBeNull();
   });
 
+  test("returns error when a custom runner reuses a built-in runner id", async () => {
+    const fm = new FakeFileManager();
+    fm.seed(
+      "/project/.ai-guardrails/config.toml",
+      [
+        "[[custom_runners]]",
+        'id = "ruff"',
+        'files = ["**/*.py"]',
+        'command = ["ruff-wrapper", "{files}"]',
+        "pattern = '^(?<file>[^:]+):(?<line>\\d+): (?<message>.*)$'",
+      ].join("\n")
+    );
+
+    const { result, config } = await loadConfigStep("/project", fm);
+
+    expect(result.status).toBe("error");
+    expect(result.message).toContain("already used by built-in runners: ruff");
+    expect(config).toBeNull();
+  });
+
   test("returns error on Zod validation failure for invalid profile value", async () => {
     const fm = new FakeFileManager();
     fm.seed("/project/.ai-guardrails/config.toml", `profile = "ultra-strict"\n`);
@@ -59,6 +123,59 @@ describe("loadConfigStep", () => {
     expect(result.status).toBe("error");
     expect(config).toBeNull();
   });
+
+  test("attaches nested configs below the root, skipping dependencies", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.ai-guardrails/config.toml", `profile = "standard"\n`);
+    fm.seed("/project/legacy/.ai-guardrails/config.toml", `profile = "lenient"\n`);
+    fm.seed("/project/services/.ai-guardrails/config.toml", `profile = "strict"\n`);
+    fm.seed("/project/node_modules/p/.ai-guardrails/config.toml", `profile = "x"\n`);
+
+    const { result, config } = await loadConfigStep("/project", fm);
+
+    expect(result.message).toBe("Config loaded: profile=standard, 2 nested config(s)");
+    expect(config?.scopes?.map((s) => [s.dir, s.config.profile])).toEqual([
+      ["services", "strict"],
+      ["legacy", "lenient"],
+    ]);
+  });
+
+  test("attaches a nested config written as YAML", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/legacy/.ai-guardrails/config.yaml", "profile: lenient\n");
+
+    const { config } = await loadConfigStep("/project", fm);
+
+    expect(config?.scopes?.map((s) => [s.dir, s.config.profile])).toEqual([
+      ["legacy", "lenient"],
+    ]);
+  });
+
+  test("returns error when a nested directory holds both formats", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/legacy/.ai-guardrails/config.toml", `profile = "lenient"\n`);
+    fm.seed("/project/legacy/.ai-guardrails/config.yaml", "profile: strict\n");
+
+    const { result } = await loadConfigStep("/project", fm);
+
+    expect(result.status).toBe("error");
+    expect(result.message).toContain("both legacy/.ai-guardrails/config.toml and");
+  });
+
+  test("names the nested config that sets a project-wide key", async () => {
+    const fm = new FakeFileManager();
+    fm.seed(
+      "/project/legacy/.ai-guardrails/config.toml",
+      "[config]\nline_length = 120\n"
+    );
+
+    const { result, config } = await loadConfigStep("/project", fm);
+
+    expect(result.status).toBe("error");
+    expect(result.message).toContain("legacy/.ai-guardrails/config.toml:");
+    expect(result.message).toContain("'config'");
+    expect(config).toBeNull();
+  });
 });
 
 describe("loadConfigStep — FileManager error handling", () => {
@@ -74,11 +191,69 @@ describe("loadConfigStep — FileManager error handling", () => {
       mkdir: (p, o) => inner.mkdir(p, o),
       glob: (p, c, i) => inner.glob(p, c, i),
       isSymlink: (p) => inner.isSymlink(p),
+      isExecutable: (p) => inner.isExecutable(p),
+      stat: (p) => inner.stat(p),
       delete: (p) => inner.delete(p),
     };
 
-    // readTomlSafe catches all errors and returns {} — config falls back to defaults
+    // readConfigSafe catches all read errors and returns {}, so defaults apply
     const { result } = await loadConfigStep("/project", throwingFm);
     expect(result.status).toBe("ok");
   });
 });
+
+describe("loadConfigStep — --config", () => {
+  test("reads the given file instead of the project's config", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.ai-guardrails/config.toml", `profile = "lenient"\n`);
+    fm.seed("/ci/shared.yaml", "profile: strict\n");
+
+    const { result, config } = await loadConfigStep("/project", fm, "/ci/shared.yaml");
+
+    expect(result.status).toBe("ok");
+    expect(config?.profile).toBe("strict");
+    expect(config?.configPath).toBe("/ci/shared.yaml");
+  });
+
+  test("returns error when the file does not exist", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.ai-guardrails/config.toml", `profile = "lenient"\n`);
+
+    const { result, config } = await loadConfigStep("/project", fm, "/ci/nope.toml");
+
+    expect(result.message).toBe(
+      "Config load failed: --config /ci/nope.toml does not exist"
+    );
+    expect(config).toBeNull();
+  });
+});
+
+describe("loadGlobalConfig", () => {
+  const PATH = "/xdg/ai-guardrails/config.yaml";
+  const ENV = { XDG_CONFIG_HOME: "/xdg" };
+
+  test("reads the global config with the path it came from", async () => {
+    const fm = new FakeFileManager();
+    fm.seed(PATH, "color: never\njobs: 4\nrunners:\n  pyright:\n    enabled: false\n");
+
+    const global = await loadGlobalConfig(fm, ENV, "/home/me");
+
+    expect(global).toEqual({
+      path: PATH,
+      config: { color: "never", jobs: 4, runners: { pyright: { enabled: false } } },
+    });
+  });
+
+  test("returns undefined without one", async () => {
+    expect(await loadGlobalConfig(new FakeFileManager(), ENV, "/h")).toBeUndefined();
+  });
+
+  test("throws naming the file and the keys it may not set", async () => {
+    const fm = new FakeFileManager();
+    fm.seed(PATH, "ignore_paths: [vendor/**]\n");
+
+    await expect(loadGlobalConfig(fm, ENV, "/home/me")).rejects.toThrow(
+      `${PATH}: Unrecognized key(s) in object: 'ignore_paths'`
+    );
+  });
+});
@@ -45,13 +45,16 @@ export function makeBaseCtx(overrides: Partial<PipelineContext> = {}): PipelineC
   };
 }
 
+/** How `ai-guardrails check` exits for `result`, as src/commands/check.ts does */
 export function checkExitCode(result: PipelineResult): number {
   if (result.status === "ok") return 0;
-  return (result.issueCount ?? 0) > 0 ? 1 : 2;
+  return result.exitCode ?? 3;
 }
 
+/** How `ai-guardrails install` exits for `result`, as src/commands/install.ts does */
 export function installExitCode(result: PipelineResult): number {
-  return result.status === "error" ? 2 : 0;
+  if (result.status === "ok") return 0;
+  return result.exitCode ?? 3;
 }
 
 export function makeRuffIssues(count: number): string {
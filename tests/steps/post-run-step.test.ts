@@ -0,0 +1,125 @@
+import { describe, expect, test } from "bun:test";
+import type { PostRunConfig } from "@/config/schema";
+import type { LintIssue } from "@/models/lint-issue";
+import type { RunnerReport } from "@/models/runner-report";
+import {
+  type PostRunPayload,
+  postRunArgs,
+  postRunPayload,
+  postRunStep,
+} from "@/steps/post-run-step";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+
+const PAYLOAD: PostRunPayload = {
+  schemaVersion: 1,
+  runners: [],
+  summary: { errors: 3, warnings: 1, infos: 0, skipped: 0, disabled: 0, failed: 0 },
+  passed: false,
+};
+
+function hook(overrides: Partial<PostRunConfig>): PostRunConfig {
+  return { required: false, timeout: 30, ...overrides };
+}
+
+describe("postRunPayload", () => {
+  test("summarizes only the new findings and marks the baselined ones", () => {
+    const finding = (fingerprint: string): LintIssue => ({
+      rule: "ruff/E501",
+      linter: "ruff",
+      file: "/p/a.py",
+      line: 1,
+      col: 1,
+      message: "Line too long",
+      severity: "error",
+      fingerprint,
+    });
+    const runners: RunnerReport[] = [
+      { runnerId: "ruff", name: "Ruff", status: "ok", durationMs: 0 },
+    ];
+
+    const payload = postRunPayload(
+      [finding("new"), finding("old")],
+      runners,
+      new Set(["old"]),
+      false
+    );
+
+    expect(payload.summary.errors).toBe(1);
+    expect(payload.runners[0]?.findings.map((f) => f.baselined)).toEqual([false, true]);
+    expect(payload.passed).toBe(false);
+  });
+});
+
+describe("postRunArgs", () => {
+  test("substitutes the run's summary into the command", () => {
+    const command = ["notify", "--on={status}", "{errors}/{warnings}", "{projectDir}"];
+    expect(postRunArgs(hook({ command }), PAYLOAD, "/p")).toEqual([
+      "notify",
+      "--on=failed",
+      "3/1",
+      "/p",
+    ]);
+  });
+
+  test("POSTs the report from stdin to a url with curl", () => {
+    const args = postRunArgs(
+      hook({ url: "https://hooks.example.com/T0/B0/secret" }),
+      PAYLOAD,
+      "/project"
+    );
+    expect(args[0]).toBe("curl");
+    expect(args).toContain("--fail");
+    expect(args.slice(-3)).toEqual([
+      "--data-binary",
+      "@-",
+      "https://hooks.example.com/T0/B0/secret",
+    ]);
+  });
+});
+
+describe("postRunStep", () => {
+  test("pipes the report to the hook from the project root", async () => {
+    const runner = new FakeCommandRunner();
+
+    const result = await postRunStep(
+      hook({ command: ["notify"], timeout: 5 }),
+      PAYLOAD,
+      "/project",
+      runner
+    );
+
+    expect(result.status).toBe("ok");
+    expect(runner.cwds).toEqual(["/project"]);
+    expect(runner.timeouts).toEqual([5000]);
+    expect(JSON.parse(runner.stdins[0] ?? "")).toEqual(PAYLOAD);
+  });
+
+  test("reports a failing hook with its stderr", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(["notify"], { stdout: "", stderr: "bad token\n", exitCode: 2 });
+
+    const notify = hook({ command: ["notify"] });
+    const result = await postRunStep(notify, PAYLOAD, "/", runner);
+
+    expect(result).toEqual({
+      status: "error",
+      message: "post_run notify failed: bad token",
+    });
+  });
+
+  test("names only the webhook's host, not its secret path", async () => {
+    const runner = new FakeCommandRunner();
+    const url = "https://hooks.example.com/T0/B0/secret";
+    runner.register(postRunArgs(hook({ url }), PAYLOAD, "/"), {
+      stdout: "",
+      stderr: "curl: (22) The requested URL returned error: 403",
+      exitCode: 22,
+    });
+
+    const result = await postRunStep(hook({ url }), PAYLOAD, "/", runner);
+
+    expect(result.message).toBe(
+      "post_run hooks.example.com failed: curl: (22) The requested URL returned error: 403"
+    );
+  });
+});
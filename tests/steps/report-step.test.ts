@@ -1,7 +1,17 @@
 import { describe, expect, test } from "bun:test";
 import type { FileManager } from "@/infra/file-manager";
 import type { LintIssue } from "@/models/lint-issue";
-import { reportStep } from "@/steps/report-step";
+import type { RunnerReport } from "@/models/runner-report";
+import {
+  failingIssues,
+  parseReportFormat,
+  reportGroupedIssues,
+  reportQuietRunnerProgress,
+  reportRunnerProgress,
+  reportStep,
+  reportStreamedSummary,
+} from "@/steps/report-step";
+import { FindingCap } from "@/writers/text";
 import { FakeConsole } from "../fakes/fake-console";
 import { FakeFileManager } from "../fakes/fake-file-manager";
 
@@ -25,7 +35,12 @@ describe("reportStep — text format", () => {
     const fm = new FakeFileManager();
     const issues = [makeIssue()];
 
-    const result = await reportStep(issues, "text", console, fm);
+    const result = await reportStep({
+      issues,
+      format: "text",
+      console,
+      fileManager: fm,
+    });
 
     expect(result.status).toBe("ok");
     expect(console.errors).toHaveLength(1);
@@ -37,7 +52,7 @@ describe("reportStep — text format", () => {
     const fm = new FakeFileManager();
     const issues = [makeIssue()];
 
-    await reportStep(issues, "text", console, fm);
+    await reportStep({ issues, format: "text", console, fileManager: fm });
 
     expect(fm.written).toHaveLength(0);
   });
@@ -46,7 +61,12 @@ describe("reportStep — text format", () => {
     const console = new FakeConsole();
     const fm = new FakeFileManager();
 
-    const result = await reportStep([], "text", console, fm);
+    const result = await reportStep({
+      issues: [],
+      format: "text",
+      console,
+      fileManager: fm,
+    });
 
     expect(result.status).toBe("ok");
     // formatIssues returns empty string for no issues → no console.error call
@@ -61,7 +81,13 @@ describe("reportStep — sarif format", () => {
     const issues = [makeIssue()];
     const sarifPath = "/project/results.sarif";
 
-    const result = await reportStep(issues, "sarif", console, fm, sarifPath);
+    const result = await reportStep({
+      issues,
+      format: "sarif",
+      console,
+      fileManager: fm,
+      outputPath: sarifPath,
+    });
 
     expect(result.status).toBe("ok");
     expect(fm.written).toHaveLength(1);
@@ -77,7 +103,12 @@ describe("reportStep — sarif format", () => {
     const fm = new FakeFileManager();
     const issues = [makeIssue()];
 
-    const result = await reportStep(issues, "sarif", console, fm);
+    const result = await reportStep({
+      issues,
+      format: "sarif",
+      console,
+      fileManager: fm,
+    });
 
     expect(result.status).toBe("ok");
     expect(fm.written).toHaveLength(0);
@@ -93,13 +124,45 @@ describe("reportStep — sarif format", () => {
     const console = new FakeConsole();
     const fm = new FakeFileManager();
 
-    const result = await reportStep([], "sarif", console, fm, "/out.sarif");
+    const result = await reportStep({
+      issues: [],
+      format: "sarif",
+      console,
+      fileManager: fm,
+      outputPath: "/out.sarif",
+    });
 
     expect(result.status).toBe("ok");
     expect(fm.written).toHaveLength(1);
     const [, content] = fm.written[0] ?? ["", ""];
     const parsed = JSON.parse(content) as { runs: Array<{ results: unknown[] }> };
-    expect(parsed.runs[0]?.results).toHaveLength(0);
+    expect(parsed.runs).toHaveLength(0);
+  });
+
+  test("emits one SARIF run per runner report", async () => {
+    const console = new FakeConsole();
+    const fm = new FakeFileManager();
+    const runners: RunnerReport[] = [
+      { runnerId: "ruff", name: "Ruff", status: "ok", durationMs: 0 },
+      { runnerId: "pyright", name: "Pyright", status: "skipped", durationMs: 1 },
+    ];
+
+    await reportStep({
+      issues: [makeIssue()],
+      format: "sarif",
+      console,
+      fileManager: fm,
+      outputPath: "/out.sarif",
+      runners,
+    });
+
+    const [, content] = fm.written[0] ?? ["", ""];
+    const parsed = JSON.parse(content) as {
+      runs: Array<{ tool: { driver: { name: string } }; results: unknown[] }>;
+    };
+    expect(parsed.runs).toHaveLength(1);
+    expect(parsed.runs[0]?.tool.driver.name).toBe("Ruff");
+    expect(parsed.runs[0]?.results).toHaveLength(1);
   });
 
   test("result message includes issue count and format", async () => {
@@ -107,7 +170,12 @@ describe("reportStep — sarif format", () => {
     const fm = new FakeFileManager();
     const issues = [makeIssue(), makeIssue({ rule: "ruff/F401", fingerprint: "fp-2" })];
 
-    const result = await reportStep(issues, "sarif", console, fm);
+    const result = await reportStep({
+      issues,
+      format: "sarif",
+      console,
+      fileManager: fm,
+    });
 
     expect(result.status).toBe("ok");
     expect(result.message).toContain("2");
@@ -115,6 +183,217 @@ describe("reportStep — sarif format", () => {
   });
 });
 
+describe("reportStep — json format", () => {
+  test("writes a versioned JSON report to stdout without output path", async () => {
+    const console = new FakeConsole();
+    const fm = new FakeFileManager();
+    const runners: RunnerReport[] = [
+      { runnerId: "ruff", name: "Ruff", status: "ok", durationMs: 3 },
+    ];
+
+    const result = await reportStep({
+      issues: [makeIssue()],
+      format: "json",
+      console,
+      fileManager: fm,
+      runners,
+    });
+
+    expect(result.status).toBe("ok");
+    expect(fm.written).toHaveLength(0);
+    const parsed = JSON.parse(console.infos[0] ?? "{}") as {
+      schemaVersion: number;
+      runners: Array<{ id: string; findings: unknown[] }>;
+    };
+    expect(parsed.schemaVersion).toBe(1);
+    expect(parsed.runners.map((r) => r.id)).toEqual(["ruff"]);
+    expect(parsed.runners[0]?.findings).toHaveLength(1);
+  });
+
+  test("writes the JSON report to the output path when given", async () => {
+    const console = new FakeConsole();
+    const fm = new FakeFileManager();
+
+    await reportStep({
+      issues: [makeIssue()],
+      format: "json",
+      console,
+      fileManager: fm,
+      outputPath: "/out.json",
+    });
+
+    expect(console.infos).toHaveLength(0);
+    expect(fm.written[0]?.[0]).toBe("/out.json");
+  });
+});
+
+describe("reportStep — output file", () => {
+  /** Records the directories reportStep creates */
+  class DirRecordingFileManager extends FakeFileManager {
+    readonly dirs: string[] = [];
+    override async mkdir(path: string, opts?: { parents?: boolean }): Promise<void> {
+      if (opts?.parents === true) this.dirs.push(path);
+    }
+  }
+
+  test("creates missing parent directories", async () => {
+    const fm = new DirRecordingFileManager();
+
+    await reportStep({
+      issues: [makeIssue()],
+      format: "sarif",
+      console: new FakeConsole(),
+      fileManager: fm,
+      outputPath: "/ci/out/a.sarif",
+    });
+
+    expect(fm.dirs).toEqual(["/ci/out"]);
+    expect(fm.written[0]?.[0]).toBe("/ci/out/a.sarif");
+  });
+
+  test("with tee, also prints the report", async () => {
+    const console = new FakeConsole();
+    const fm = new FakeFileManager();
+    const issues = [makeIssue()];
+
+    await reportStep({
+      issues,
+      format: "json",
+      console,
+      fileManager: fm,
+      outputPath: "/out.json",
+      tee: true,
+    });
+
+    expect(console.infos).toHaveLength(1);
+    expect(fm.written[0]?.[1]).toBe(console.infos[0]);
+  });
+
+  test("writes text reports too, without printing them", async () => {
+    const console = new FakeConsole();
+    const fm = new FakeFileManager();
+
+    await reportStep({
+      issues: [makeIssue()],
+      format: "text",
+      console,
+      fileManager: fm,
+      outputPath: "/out.txt",
+    });
+
+    expect(console.errors).toHaveLength(0);
+    expect(fm.written[0]?.[1]).toContain("ruff/E501: Line too long");
+  });
+});
+
+describe("reportStep — junit format", () => {
+  test("writes a JUnit XML document to the output path", async () => {
+    const console = new FakeConsole();
+    const fm = new FakeFileManager();
+    const runners: RunnerReport[] = [
+      { runnerId: "ruff", name: "Ruff", status: "ok", durationMs: 3 },
+    ];
+
+    await reportStep({
+      issues: [makeIssue()],
+      format: "junit",
+      console,
+      fileManager: fm,
+      outputPath: "/junit.xml",
+      runners,
+    });
+
+    const [path, content] = fm.written[0] ?? ["", ""];
+    expect(path).toBe("/junit.xml");
+    expect(content).toStartWith('<?xml version="1.0" encoding="UTF-8"?>');
+    expect(content).toContain('<testsuite name="Ruff"');
+  });
+});
+
+describe("reportStep — summary format", () => {
+  test("writes the counts and the outcome, not the findings", async () => {
+    const fm = new FakeFileManager();
+    const outcome = { passed: false, durationMs: 25 };
+
+    await reportStep({
+      issues: [makeIssue()],
+      format: "summary",
+      console: new FakeConsole(),
+      fileManager: fm,
+      outputPath: "/status.json",
+      outcome,
+    });
+
+    const report = JSON.parse(fm.written[0]?.[1] ?? "{}") as Record<string, unknown>;
+    expect(report).toMatchObject({ passed: false, durationMs: 25 });
+    expect(JSON.stringify(report)).not.toContain("findings");
+  });
+});
+
+describe("parseReportFormat", () => {
+  test("accepts sarif, json, junit, gitlab and summary", () => {
+    expect(parseReportFormat("sarif")).toBe("sarif");
+    expect(parseReportFormat("json")).toBe("json");
+    expect(parseReportFormat("junit")).toBe("junit");
+    expect(parseReportFormat("gitlab")).toBe("gitlab");
+    expect(parseReportFormat("summary")).toBe("summary");
+  });
+
+  test("falls back to text for unknown or missing values", () => {
+    expect(parseReportFormat("xml")).toBe("text");
+    expect(parseReportFormat(undefined)).toBe("text");
+  });
+
+  test("auto picks github annotations only inside GitHub Actions", () => {
+    expect(parseReportFormat("github", {})).toBe("github");
+    expect(parseReportFormat("auto", { GITHUB_ACTIONS: "true" })).toBe("github");
+    expect(parseReportFormat("auto", {})).toBe("text");
+  });
+});
+
+describe("reportStep — github format", () => {
+  test("prints annotations to stdout, and nothing for a clean run", async () => {
+    const console = new FakeConsole();
+    const fm = new FakeFileManager();
+
+    await reportStep({
+      issues: [makeIssue()],
+      format: "github",
+      console,
+      fileManager: fm,
+    });
+    await reportStep({ issues: [], format: "github", console, fileManager: fm });
+
+    expect(console.infos).toEqual([
+      [
+        "::group::ruff",
+        "::error file=/project/src/foo.py,line=10,col=1,title=ruff/E501::Line too long",
+        "::endgroup::",
+      ].join("\n"),
+    ]);
+  });
+});
+
+describe("reportStep — gitlab format", () => {
+  test("writes project-relative paths to the output file", async () => {
+    const console = new FakeConsole();
+    const fm = new FakeFileManager();
+
+    await reportStep({
+      issues: [makeIssue()],
+      format: "gitlab",
+      console,
+      fileManager: fm,
+      outputPath: "/project/gl-code-quality.json",
+      projectDir: "/project",
+    });
+
+    const written = fm.written.find(([p]) => p === "/project/gl-code-quality.json");
+    expect(written?.[1]).toContain('"path": "src/foo.py"');
+    expect(console.infos).toEqual([]);
+  });
+});
+
 describe("reportStep — error handling", () => {
   test("propagates writeText error when writing sarif output", async () => {
     const console = new FakeConsole();
@@ -129,13 +408,162 @@ describe("reportStep — error handling", () => {
       mkdir: (p, o) => inner.mkdir(p, o),
       glob: (p, c, i) => inner.glob(p, c, i),
       isSymlink: (p) => inner.isSymlink(p),
+      isExecutable: (p) => inner.isExecutable(p),
+      stat: (p) => inner.stat(p),
       delete: (p) => inner.delete(p),
     };
 
     const issues = [makeIssue()];
     // reportStep has no try/catch — writeText throw propagates
     await expect(
-      reportStep(issues, "sarif", console, throwingFm, "/output.sarif")
+      reportStep({
+        issues,
+        format: "sarif",
+        console,
+        fileManager: throwingFm,
+        outputPath: "/output.sarif",
+      })
     ).rejects.toThrow("disk full");
   });
 });
+
+describe("reportRunnerProgress", () => {
+  const report: RunnerReport = {
+    runnerId: "ruff",
+    name: "Ruff",
+    status: "ok",
+    durationMs: 12,
+  };
+  const progress = { baselined: new Set<string>(), done: 1, total: 3 };
+
+  test("prints a runner with issues as an error block", () => {
+    const console = new FakeConsole();
+    reportRunnerProgress({ ...progress, report, issues: [makeIssue()] }, console);
+    expect(console.errors).toHaveLength(1);
+    expect(console.errors[0]).toContain("[1/3 complete] Ruff: 1 issue(s)");
+    expect(console.errors[0]).toContain("ruff/E501");
+  });
+
+  test("prints clean runners as success and skipped ones as warnings", () => {
+    const console = new FakeConsole();
+    reportRunnerProgress({ ...progress, report, issues: [] }, console);
+    reportRunnerProgress(
+      { ...progress, report: { ...report, status: "skipped" }, issues: [] },
+      console
+    );
+    expect(console.successes).toEqual(["[1/3 complete] Ruff: no issues in 12ms"]);
+    expect(console.warnings).toEqual(["[1/3 complete] Ruff: not installed — skipping"]);
+  });
+});
+
+describe("reportQuietRunnerProgress", () => {
+  const report: RunnerReport = {
+    runnerId: "ruff",
+    name: "Ruff",
+    status: "ok",
+    durationMs: 12,
+  };
+  const progress = { baselined: new Set<string>(), done: 1, total: 3 };
+
+  test("prints only the failing findings, without a status line", () => {
+    const console = new FakeConsole();
+    const issues = [
+      makeIssue(),
+      makeIssue({ severity: "warning", rule: "ruff/W291", fingerprint: "fp-w" }),
+    ];
+    reportQuietRunnerProgress({ ...progress, report, issues }, console, "error");
+    expect(console.errors).toEqual([
+      "/project/src/foo.py:10:1: [ERROR] ruff/E501: Line too long",
+    ]);
+  });
+
+  test("explains the failing findings with explain", () => {
+    const console = new FakeConsole();
+    const issues = [makeIssue()];
+    reportQuietRunnerProgress({ ...progress, report, issues }, console, "error", true);
+    expect(console.errors).toEqual([
+      [
+        "/project/src/foo.py:10:1: [ERROR] ruff/E501: Line too long",
+        "    why: Long lines are hard to read side by side and in review.",
+        "    fix: Break the expression over several lines, or raise line_length in the config.",
+      ].join("\n"),
+    ]);
+  });
+
+  test("includes lower severities when --fail-on asks for them", () => {
+    const console = new FakeConsole();
+    const issues = [makeIssue({ severity: "warning" })];
+    reportQuietRunnerProgress({ ...progress, report, issues }, console, "warning");
+    expect(console.errors).toHaveLength(1);
+  });
+
+  test("skips baselined findings and clean or skipped runners", () => {
+    const console = new FakeConsole();
+    const baselined = new Set(["fp-abc123"]);
+    const issues = [makeIssue()];
+    const old = { ...progress, baselined, report, issues };
+    reportQuietRunnerProgress(old, console, "error");
+    reportQuietRunnerProgress({ ...progress, report, issues: [] }, console, "error");
+    reportQuietRunnerProgress(
+      { ...progress, report: { ...report, status: "skipped" }, issues: [] },
+      console,
+      "error"
+    );
+    expect(console.errors).toHaveLength(0);
+    expect(console.successes).toHaveLength(0);
+    expect(console.warnings).toHaveLength(0);
+  });
+
+  test("prints a failed runner's status line", () => {
+    const console = new FakeConsole();
+    const failed: RunnerReport = { ...report, status: "error", message: "crashed" };
+    const crashed = { ...progress, report: failed, issues: [] };
+    reportQuietRunnerProgress(crashed, console, "error");
+    expect(console.errors).toEqual(["[1/3 complete] Ruff: failed — crashed"]);
+  });
+});
+
+describe("reportStreamedSummary", () => {
+  test("prints only the summary line", () => {
+    const console = new FakeConsole();
+    reportStreamedSummary([makeIssue()], console);
+    expect(console.errors).toEqual(["1 issue(s) found: 1 error, 0 warning, 0 info"]);
+  });
+
+  test("prints nothing without issues", () => {
+    const console = new FakeConsole();
+    reportStreamedSummary([], console);
+    expect(console.errors).toHaveLength(0);
+  });
+});
+
+describe("failingIssues", () => {
+  test("keeps new findings at or above the threshold", () => {
+    const issues = [
+      makeIssue(),
+      makeIssue({ fingerprint: "fp-old" }),
+      makeIssue({ severity: "warning", fingerprint: "fp-warn" }),
+    ];
+    const failing = failingIssues(issues, new Set(["fp-old"]), "error");
+    expect(failing.map((i) => i.fingerprint)).toEqual(["fp-abc123"]);
+  });
+});
+
+describe("reportGroupedIssues", () => {
+  test("prints the groups, then the note for findings the cap held back", () => {
+    const console = new FakeConsole();
+    const issues = [makeIssue(), makeIssue({ line: 20, fingerprint: "fp-2" })];
+    const cap = new FindingCap({ total: 1 });
+    reportGroupedIssues(issues, console, "file", new Set(), false, cap);
+    expect(console.errors).toEqual([
+      "/project/src/foo.py (2)\n/project/src/foo.py:10:1: [ERROR] ruff/E501: Line too long",
+      "... and 1 more findings (use --max-findings 0 for all)",
+    ]);
+  });
+
+  test("prints nothing without issues", () => {
+    const console = new FakeConsole();
+    reportGroupedIssues([], console, "severity");
+    expect(console.errors).toHaveLength(0);
+  });
+});
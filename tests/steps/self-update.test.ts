@@ -0,0 +1,217 @@
+import { describe, expect, test } from "bun:test";
+import {
+  checkForUpdateStep,
+  findChecksum,
+  isReleaseBinary,
+  packageManagerUpdate,
+  releaseAssetName,
+  selfUpdateStep,
+} from "@/steps/self-update";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const LATEST_URL =
+  "https://api.github.com/repos/Questi0nM4rk/ai-guardrails/releases/latest";
+const RELEASE_URL =
+  "https://github.com/Questi0nM4rk/ai-guardrails/releases/download/v3.1.0";
+const LATEST_CALL = [
+  "curl",
+  "-fsSL",
+  "--max-time",
+  "30",
+  "-H",
+  "Accept: application/vnd.github+json",
+  LATEST_URL,
+];
+const ASSET = "ai-guardrails-linux-x64";
+const TARGET = "/home/dev/.local/bin/ai-guardrails";
+const DOWNLOAD = `${TARGET}.download`;
+const DIGEST = "a".repeat(64);
+const CHECKSUMS = [
+  `${"b".repeat(64)}  ai-guardrails-darwin-arm64`,
+  `${DIGEST}  ${ASSET}`,
+  "",
+].join("\n");
+
+function releaseRunner(tag: string): FakeCommandRunner {
+  const runner = new FakeCommandRunner();
+  runner.register(LATEST_CALL, {
+    stdout: JSON.stringify({ tag_name: tag, name: tag }),
+    stderr: "",
+    exitCode: 0,
+  });
+  return runner;
+}
+
+describe("releaseAssetName", () => {
+  test("names the binary release.yml builds for this OS/arch", () => {
+    expect(releaseAssetName("linux", "x64")).toBe("ai-guardrails-linux-x64");
+    expect(releaseAssetName("darwin", "arm64")).toBe("ai-guardrails-darwin-arm64");
+  });
+
+  test("returns null where no binary is published", () => {
+    expect(releaseAssetName("win32", "x64")).toBeNull();
+    expect(releaseAssetName("linux", "ia32")).toBeNull();
+  });
+});
+
+describe("isReleaseBinary", () => {
+  test("accepts the compiled binary and rejects bun", () => {
+    expect(isReleaseBinary(TARGET)).toBe(true);
+    expect(isReleaseBinary("/usr/local/bin/ai-guardrails-linux-x64")).toBe(true);
+    expect(isReleaseBinary("/home/dev/.bun/bin/bun")).toBe(false);
+  });
+});
+
+describe("packageManagerUpdate", () => {
+  test("names the update command for package-manager installs", () => {
+    const npm = "/usr/lib/node_modules/ai-guardrails/dist/ai-guardrails";
+    const bun =
+      "/home/dev/.bun/install/global/node_modules/ai-guardrails/dist/ai-guardrails";
+    const brew = "/opt/homebrew/Cellar/ai-guardrails/1.4.0/bin/ai-guardrails";
+
+    expect(packageManagerUpdate(npm)).toBe("npm install -g ai-guardrails@latest");
+    expect(packageManagerUpdate(bun)).toBe("bun update -g ai-guardrails");
+    expect(packageManagerUpdate(brew)).toBe("brew upgrade ai-guardrails");
+  });
+
+  test("leaves a binary installed by hand to self-update", () => {
+    expect(packageManagerUpdate(TARGET)).toBeNull();
+    expect(packageManagerUpdate("/usr/local/bin/ai-guardrails-linux-x64")).toBeNull();
+  });
+});
+
+describe("findChecksum", () => {
+  test("picks the asset's line from sha256sum output", () => {
+    expect(findChecksum(CHECKSUMS, ASSET)).toBe(DIGEST);
+    expect(findChecksum(`${DIGEST} *${ASSET}`, ASSET)).toBe(DIGEST);
+  });
+
+  test("returns null when the asset is not listed", () => {
+    expect(findChecksum(CHECKSUMS, "ai-guardrails-linux-arm64")).toBeNull();
+  });
+});
+
+describe("checkForUpdateStep", () => {
+  test("reports a newer release", async () => {
+    const runner = releaseRunner("v3.1.0");
+    const { result, latest } = await checkForUpdateStep(runner, "3.0.0");
+
+    expect(result).toEqual({
+      status: "ok",
+      message: "ai-guardrails v3.1.0 is available (installed: 3.0.0)",
+    });
+    expect(latest).toEqual({ tag: "v3.1.0", version: "3.1.0" });
+  });
+
+  test("reports up to date when the latest is not newer", async () => {
+    const runner = releaseRunner("v3.0.0");
+    const { result, latest } = await checkForUpdateStep(runner, "3.0.0");
+
+    expect(result.message).toBe("ai-guardrails 3.0.0 is up to date");
+    expect(latest).toBeNull();
+  });
+
+  test("fails when GitHub cannot be reached", async () => {
+    const runner = new FakeCommandRunner();
+    runner.register(LATEST_CALL, {
+      stdout: "",
+      stderr: "curl: (6) Could not resolve host: api.github.com",
+      exitCode: 6,
+    });
+
+    const { result } = await checkForUpdateStep(runner, "3.0.0");
+
+    expect(result.status).toBe("error");
+    expect(result.message).toContain("Could not resolve host");
+  });
+
+  test("fails on a response without a release tag", async () => {
+    const { result } = await checkForUpdateStep(releaseRunner("nightly"), "3.0.0");
+
+    expect(result).toEqual({
+      status: "error",
+      message: "Unexpected response from GitHub releases",
+    });
+  });
+});
+
+describe("selfUpdateStep", () => {
+  const release = { tag: "v3.1.0", version: "3.1.0" };
+
+  function updateRunner(digest: string): FakeCommandRunner {
+    const runner = new FakeCommandRunner();
+    const checksumsUrl = `${RELEASE_URL}/checksums.sha256`;
+    runner.register(["curl", "-fsSL", "--max-time", "60", checksumsUrl], {
+      stdout: CHECKSUMS,
+      stderr: "",
+      exitCode: 0,
+    });
+    runner.register(["sha256sum", DOWNLOAD], {
+      stdout: `${digest}  ${DOWNLOAD}\n`,
+      stderr: "",
+      exitCode: 0,
+    });
+    return runner;
+  }
+
+  test("downloads, verifies and moves the binary into place", async () => {
+    const runner = updateRunner(DIGEST);
+
+    const result = await selfUpdateStep(
+      release,
+      ASSET,
+      TARGET,
+      runner,
+      new FakeFileManager()
+    );
+
+    expect(result).toEqual({
+      status: "ok",
+      message: "Updated ai-guardrails to v3.1.0",
+    });
+    expect(runner.calls).toContainEqual([
+      "curl",
+      "-fsSL",
+      "--max-time",
+      "300",
+      "-o",
+      DOWNLOAD,
+      `${RELEASE_URL}/${ASSET}`,
+    ]);
+    expect(runner.calls.slice(-2)).toEqual([
+      ["chmod", "755", DOWNLOAD],
+      ["mv", "-f", DOWNLOAD, TARGET],
+    ]);
+  });
+
+  test("keeps the old binary when the checksum does not match", async () => {
+    const runner = updateRunner("c".repeat(64));
+    const fm = new FakeFileManager();
+    fm.seed(DOWNLOAD, "tampered");
+
+    const result = await selfUpdateStep(release, ASSET, TARGET, runner, fm);
+
+    expect(result.status).toBe("error");
+    expect(result.message).toContain(`Checksum mismatch for ${ASSET}`);
+    expect(await fm.exists(DOWNLOAD)).toBe(false);
+    expect(runner.calls.some((call) => call[0] === "mv")).toBe(false);
+  });
+
+  test("fails when the asset has no checksum", async () => {
+    const runner = updateRunner(DIGEST);
+
+    const result = await selfUpdateStep(
+      release,
+      "ai-guardrails-linux-arm64",
+      TARGET,
+      runner,
+      new FakeFileManager()
+    );
+
+    expect(result).toEqual({
+      status: "error",
+      message: "ai-guardrails-linux-arm64 is not in checksums.sha256",
+    });
+  });
+});
@@ -152,6 +152,8 @@ describe("setupAgentInstructionsStep", () => {
       mkdir: (_p, _o) => Promise.resolve(),
       glob: (p, c, i) => inner.glob(p, c, i),
       isSymlink: (p) => inner.isSymlink(p),
+      isExecutable: (p) => inner.isExecutable(p),
+      stat: (p) => inner.stat(p),
       delete: (p) => inner.delete(p),
     };
 
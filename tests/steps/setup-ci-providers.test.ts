@@ -0,0 +1,86 @@
+import { describe, expect, test } from "bun:test";
+import { setupAzurePipelinesStep } from "@/steps/setup-azure-pipelines";
+import { setupCircleciStep } from "@/steps/setup-circleci";
+import { HASH_PREFIX } from "@/utils/hash";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const PY_SHELL = new Set(["python", "shell"]);
+
+function writtenContent(fm: FakeFileManager, dest: string): string {
+  const [, content] = fm.written.find(([p]) => p === dest) ?? ["", ""];
+  return content;
+}
+
+const PROVIDERS = [
+  {
+    name: "setupCircleciStep",
+    dest: "/project/.circleci/config.yml",
+    setup: setupCircleciStep,
+  },
+  {
+    name: "setupAzurePipelinesStep",
+    dest: "/project/azure-pipelines.yml",
+    setup: setupAzurePipelinesStep,
+  },
+];
+
+for (const { name, dest, setup } of PROVIDERS) {
+  describe(name, () => {
+    test("writes a hashed config that installs tools and runs check", async () => {
+      const fm = new FakeFileManager();
+      const result = await setup("/project", fm, PY_SHELL);
+
+      expect(result.status).toBe("ok");
+      const content = writtenContent(fm, dest);
+      expect(content.startsWith(HASH_PREFIX)).toBe(true);
+      expect(content).toContain("--break-system-packages ruff pyright codespell");
+      expect(content).toContain("python3-pip shellcheck shfmt");
+      expect(content).toContain("bunx ai-guardrails check --format junit");
+    });
+
+    test("regenerates a file carrying our hash header", async () => {
+      const fm = new FakeFileManager();
+      fm.seed(dest, `${HASH_PREFIX}stale\nsteps: []\n`);
+
+      const result = await setup("/project", fm, PY_SHELL);
+
+      expect(result.status).toBe("ok");
+      expect(writtenContent(fm, dest)).not.toContain("stale");
+    });
+
+    test("skips a hand-written file", async () => {
+      const fm = new FakeFileManager();
+      fm.seed(dest, "steps: []\n");
+
+      const result = await setup("/project", fm, PY_SHELL);
+
+      expect(result.status).toBe("skip");
+      expect(result.message).toContain("hand-written");
+      expect(fm.written).toEqual([]);
+    });
+  });
+}
+
+describe("CI provider configs", () => {
+  test("CircleCI runs the job in oven/bun:1 and stores the report", async () => {
+    const fm = new FakeFileManager();
+    await setupCircleciStep("/project", fm, new Set(["typescript"]));
+
+    const content = writtenContent(fm, "/project/.circleci/config.yml");
+    expect(content).toContain("version: 2.1");
+    expect(content).toContain("- image: oven/bun:1");
+    expect(content).toContain("path: ai-guardrails-junit.xml");
+    expect(content).toContain("bun install --frozen-lockfile");
+  });
+
+  test("Azure installs bun, uses sudo and publishes the report", async () => {
+    const fm = new FakeFileManager();
+    await setupAzurePipelinesStep("/project", fm, new Set(["typescript"]));
+
+    const content = writtenContent(fm, "/project/azure-pipelines.yml");
+    expect(content).toContain("vmImage: ubuntu-latest");
+    expect(content).toContain("https://bun.sh/install");
+    expect(content).toContain("sudo pip3 install --break-system-packages codespell");
+    expect(content).toContain("task: PublishTestResults@2");
+  });
+});
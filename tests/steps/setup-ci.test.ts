@@ -21,6 +21,8 @@ function makeFailingFileManager(): FileManager {
     mkdir: (p, o) => inner.mkdir(p, o),
     glob: (p, c, i) => inner.glob(p, c, i),
     isSymlink: (p) => inner.isSymlink(p),
+    isExecutable: (p) => inner.isExecutable(p),
+    stat: (p) => inner.stat(p),
     delete: (p) => inner.delete(p),
   };
 }
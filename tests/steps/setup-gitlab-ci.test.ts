@@ -0,0 +1,129 @@
+import { describe, expect, test } from "bun:test";
+import {
+  GITLAB_BLOCK_BEGIN,
+  GITLAB_BLOCK_END,
+  mergeGitlabCiConfig,
+  parseGitlabStages,
+  setupGitlabCiStep,
+} from "@/steps/setup-gitlab-ci";
+import { HASH_PREFIX } from "@/utils/hash";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const DEST = "/project/.gitlab-ci.yml";
+const TS_ONLY = new Set(["typescript"]);
+const PY_SHELL = new Set(["python", "shell"]);
+
+const HAND_WRITTEN = `stages:
+  - build
+  - deploy
+
+build:
+  stage: build
+  script:
+    - make
+`;
+
+function writtenContent(fm: FakeFileManager): string {
+  const [, content] = fm.written.find(([p]) => p === DEST) ?? ["", ""];
+  return content;
+}
+
+describe("setupGitlabCiStep — fresh file", () => {
+  test("writes .gitlab-ci.yml with hash header and test stage", async () => {
+    const fm = new FakeFileManager();
+    const result = await setupGitlabCiStep("/project", fm, TS_ONLY);
+
+    expect(result.status).toBe("ok");
+    const content = writtenContent(fm);
+    expect(content.startsWith(HASH_PREFIX)).toBe(true);
+    expect(content).toContain("stages:\n  - test");
+    expect(content).toContain("ai-guardrails:\n  stage: test");
+    expect(content).toContain("bunx ai-guardrails check --format junit");
+    expect(content).toContain("junit: ai-guardrails-junit.xml");
+  });
+
+  test("installs tools for the detected languages only", async () => {
+    const fm = new FakeFileManager();
+    await setupGitlabCiStep("/project", fm, PY_SHELL);
+
+    const content = writtenContent(fm);
+    expect(content).toContain("--break-system-packages ruff pyright codespell");
+    expect(content).toContain("python3-pip shellcheck shfmt");
+    expect(content).not.toContain("bun install");
+  });
+
+  test("typescript job matches snapshot", async () => {
+    const fm = new FakeFileManager();
+    await setupGitlabCiStep("/project", fm, TS_ONLY);
+
+    expect(writtenContent(fm)).toMatchSnapshot();
+  });
+
+  test("regenerates a file carrying our hash header", async () => {
+    const fm = new FakeFileManager();
+    fm.seed(DEST, `${HASH_PREFIX}stale\nstages:\n  - test\n`);
+
+    await setupGitlabCiStep("/project", fm, TS_ONLY);
+
+    const content = writtenContent(fm);
+    expect(content).not.toContain("stale");
+    expect(content).not.toContain(GITLAB_BLOCK_BEGIN);
+  });
+});
+
+describe("setupGitlabCiStep — hand-written file", () => {
+  test("appends the job as a managed block without touching user jobs", async () => {
+    const fm = new FakeFileManager();
+    fm.seed(DEST, HAND_WRITTEN);
+
+    const result = await setupGitlabCiStep("/project", fm, TS_ONLY);
+
+    expect(result.status).toBe("ok");
+    expect(result.message).toContain("merged");
+    const content = writtenContent(fm);
+    expect(content.startsWith(HAND_WRITTEN)).toBe(true);
+    expect(content).toContain(GITLAB_BLOCK_BEGIN);
+    expect(content).toContain(GITLAB_BLOCK_END);
+    expect(content.startsWith(HASH_PREFIX)).toBe(false);
+  });
+
+  test("re-running replaces the managed block instead of duplicating it", async () => {
+    const once = mergeGitlabCiConfig(HAND_WRITTEN, TS_ONLY);
+    const twice = mergeGitlabCiConfig(once, PY_SHELL);
+
+    expect(twice.split(GITLAB_BLOCK_BEGIN)).toHaveLength(2);
+    expect(twice).toContain("ruff pyright");
+    expect(twice).not.toContain("bun install");
+  });
+
+  test("keeps content that follows the managed block", async () => {
+    const once = mergeGitlabCiConfig(HAND_WRITTEN, TS_ONLY);
+    const withTail = `${once}\nlate:\n  script: [echo]\n`;
+
+    expect(mergeGitlabCiConfig(withTail, TS_ONLY)).toContain("late:\n  script: [echo]");
+  });
+
+  test("joins the last declared stage when the file has no test stage", () => {
+    const merged = mergeGitlabCiConfig(HAND_WRITTEN, TS_ONLY);
+    expect(merged).toContain("ai-guardrails:\n  stage: deploy");
+  });
+
+  test("uses the test stage when the file declares it", () => {
+    const merged = mergeGitlabCiConfig("stages: [build, test]\n", TS_ONLY);
+    expect(merged).toContain("ai-guardrails:\n  stage: test");
+  });
+});
+
+describe("parseGitlabStages", () => {
+  test("reads block-style stage lists", () => {
+    expect(parseGitlabStages(HAND_WRITTEN)).toEqual(["build", "deploy"]);
+  });
+
+  test("reads inline stage lists", () => {
+    expect(parseGitlabStages('stages: ["lint", test]\n')).toEqual(["lint", "test"]);
+  });
+
+  test("returns null when no stages are declared", () => {
+    expect(parseGitlabStages("job:\n  script: [make]\n")).toBeNull();
+  });
+});
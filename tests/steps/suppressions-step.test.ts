@@ -0,0 +1,77 @@
+import { describe, expect, test } from "bun:test";
+import {
+  buildResolvedConfig,
+  MachineConfigSchema,
+  ProjectConfigSchema,
+} from "@/config/schema";
+import { auditSuppressionsStep } from "@/steps/suppressions-step";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const PROJECT_DIR = "/project";
+
+function makeConfig(ignorePaths: string[] = []) {
+  return buildResolvedConfig(
+    MachineConfigSchema.parse({}),
+    ProjectConfigSchema.parse({ ignore_paths: ignorePaths })
+  );
+}
+
+describe("auditSuppressionsStep", () => {
+  test("lists both directive styles, sorted by file and line", async () => {
+    const fm = new FakeFileManager();
+    fm.seed(
+      "/project/src/b.py",
+      "x = 1\nrun(cmd)  # guardrails:ignore ruff/S603 fixed argument list\n"
+    );
+    fm.seed(
+      "/project/src/a.ts",
+      '// ai-guardrails-allow biome/noConsole "CLI output"\nconsole.log(1);\n'
+    );
+
+    const { result, suppressions } = await auditSuppressionsStep(
+      PROJECT_DIR,
+      makeConfig(),
+      fm,
+      null
+    );
+
+    expect(result.status).toBe("ok");
+    expect(result.message).toBe("2 active suppression(s)");
+    expect(suppressions.map((s) => [s.file, s.line, s.rule, s.reason])).toEqual([
+      ["src/a.ts", 1, "biome/noConsole", "CLI output"],
+      ["src/b.py", 2, "ruff/S603", "fixed argument list"],
+    ]);
+  });
+
+  test("errors when a guardrails:ignore gives no reason", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/src/a.ts", "// guardrails:ignore biome/noConsole\n");
+
+    const { result, suppressions } = await auditSuppressionsStep(
+      PROJECT_DIR,
+      makeConfig(),
+      fm,
+      null
+    );
+
+    expect(result.status).toBe("error");
+    expect(result.message).toBe("1 of 1 suppression(s) give no reason");
+    expect(suppressions[0]?.reason).toBe("");
+  });
+
+  test("skips ignore_paths and paths the ignore files exclude", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/vendor/lib.go", "// guardrails:ignore go-vet/printf\n");
+    fm.seed("/project/gen/api.ts", "// guardrails:ignore biome/noConsole\n");
+    fm.seed("/project/tmp/scratch.py", "# guardrails:ignore ruff/T201\n");
+
+    const { suppressions } = await auditSuppressionsStep(
+      PROJECT_DIR,
+      makeConfig(["gen/**"]),
+      fm,
+      (path) => path.startsWith("tmp/")
+    );
+
+    expect(suppressions).toEqual([]);
+  });
+});
@@ -8,6 +8,7 @@ import { generateLefthookConfig, LEFTHOOK_GENERATOR_ID } from "@/generators/left
 import { ALL_GENERATORS, applicableGenerators } from "@/generators/registry";
 import type { ConfigGenerator } from "@/generators/types";
 import { validateConfigsStep } from "@/steps/validate-configs";
+import { computeHash, HASH_PREFIX } from "@/utils/hash";
 import { FakeFileManager } from "../fakes/fake-file-manager";
 
 function makeConfig() {
@@ -143,6 +144,18 @@ describe("validateConfigsStep — single generator isolation", () => {
     expect(result.message).toContain(`tampered: ${first.configFile}`);
   });
 
+  test("intact config from an older template returns error with 'outdated'", async () => {
+    const fm = new FakeFileManager();
+    seedAllValid(fm, "/project");
+    const body = "[codespell]\n";
+    fm.seed("/project/.codespellrc", `${HASH_PREFIX}${computeHash(body)}\n${body}`);
+
+    const result = await validateConfigsStep("/project", fm);
+
+    expect(result.status).toBe("error");
+    expect(result.message).toContain("outdated: .codespellrc (template v0)");
+  });
+
   test("config with valid hash header passes tamper check", async () => {
     const fm = new FakeFileManager();
     // Use generateContent() to produce correctly-prefixed headers per file type
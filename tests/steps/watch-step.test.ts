@@ -0,0 +1,223 @@
+import { describe, expect, test } from "bun:test";
+import {
+  buildResolvedConfig,
+  MachineConfigSchema,
+  ProjectConfigSchema,
+} from "@/config/schema";
+import type { LanguagePlugin } from "@/languages/types";
+import type { LintIssue } from "@/models/lint-issue";
+import type { LinterRunner } from "@/runners/types";
+import { runnersForChanges, watchStep } from "@/steps/watch-step";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeConsole } from "../fakes/fake-console";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+import { FakeFileWatcher } from "../fakes/fake-file-watcher";
+
+function makeConfig() {
+  return buildResolvedConfig(
+    MachineConfigSchema.parse({}),
+    ProjectConfigSchema.parse({})
+  );
+}
+
+function sleep(ms: number): Promise<void> {
+  return new Promise((resolve) => setTimeout(resolve, ms));
+}
+
+/** A runner that counts its runs and reports `issues()` each time */
+function makeRunner(
+  id: string,
+  inputs: readonly string[] | undefined,
+  issues: () => LintIssue[] = () => []
+): LinterRunner & { runs: number } {
+  return {
+    id,
+    name: id,
+    configFile: null,
+    installHint: { description: id },
+    ...(inputs !== undefined && { watchInputs: inputs }),
+    runs: 0,
+    async isAvailable() {
+      return true;
+    },
+    async run() {
+      this.runs++;
+      return issues();
+    },
+  };
+}
+
+function makePlugin(runners: LinterRunner[]): LanguagePlugin {
+  return {
+    id: "test",
+    name: "Test",
+    async detect() {
+      return true;
+    },
+    runners() {
+      return runners;
+    },
+  };
+}
+
+function makeIssue(file: string): LintIssue {
+  return {
+    rule: "py/E1",
+    linter: "py",
+    file,
+    line: 3,
+    col: 1,
+    message: "Bad thing",
+    severity: "error",
+    fingerprint: `fp-${file}`,
+  };
+}
+
+interface Harness {
+  watcher: FakeFileWatcher;
+  cons: FakeConsole;
+  stop: () => Promise<void>;
+}
+
+function startWatch(
+  runners: LinterRunner[],
+  ignore?: (relPath: string) => boolean
+): Harness {
+  const watcher = new FakeFileWatcher();
+  const cons = new FakeConsole();
+  const controller = new AbortController();
+  const done = watchStep(
+    "/project",
+    [makePlugin(runners)],
+    makeConfig(),
+    new FakeCommandRunner(),
+    new FakeFileManager(),
+    cons,
+    watcher,
+    {
+      signal: controller.signal,
+      debounceMs: 5,
+      ...(ignore !== undefined && { ignore }),
+    }
+  );
+  return {
+    watcher,
+    cons,
+    stop: async () => {
+      controller.abort();
+      await done;
+    },
+  };
+}
+
+describe("runnersForChanges", () => {
+  const py = makeRunner("py", ["**/*.py"]);
+  const md = makeRunner("md", undefined);
+  const sh: LinterRunner = {
+    ...makeRunner("sh", undefined),
+    configFile: ".shellcheckrc",
+    cache: { inputs: ["**/*.sh"] },
+  };
+
+  test("matches changed paths against watchInputs, then cache inputs", () => {
+    const ids = (changed: string[]) =>
+      runnersForChanges([py, sh], changed).map((r) => r.id);
+    expect(ids(["src/app.py"])).toEqual(["py"]);
+    expect(ids(["scripts/build.sh"])).toEqual(["sh"]);
+    expect(ids(["README.md"])).toEqual([]);
+  });
+
+  test("a change to the runner's config file re-runs it", () => {
+    expect(runnersForChanges([py, sh], [".shellcheckrc"]).map((r) => r.id)).toEqual([
+      "sh",
+    ]);
+  });
+
+  test("a runner without declared inputs re-runs on any change", () => {
+    expect(runnersForChanges([py, md], ["notes.txt"]).map((r) => r.id)).toEqual([
+      "md",
+    ]);
+  });
+});
+
+describe("watchStep", () => {
+  test("runs every runner once up front and draws a status per runner", async () => {
+    const py = makeRunner("py", ["**/*.py"]);
+    const sh = makeRunner("sh", ["**/*.sh"]);
+    const { cons, stop } = startWatch([py, sh]);
+    await sleep(10);
+    await stop();
+
+    expect([py.runs, sh.runs]).toEqual([1, 1]);
+    expect(cons.infos.at(-1)).toContain("  ✓ py\n  ✓ sh");
+  });
+
+  test("re-runs only the runners affected by a change", async () => {
+    const py = makeRunner("py", ["**/*.py"]);
+    const sh = makeRunner("sh", ["**/*.sh"]);
+    const { watcher, cons, stop } = startWatch([py, sh]);
+    await sleep(10);
+
+    watcher.emit("src/app.py");
+    watcher.emit("src/app.py");
+    await sleep(20);
+    await stop();
+
+    expect([py.runs, sh.runs]).toEqual([2, 1]);
+    expect(cons.infos).toHaveLength(2);
+    expect(cons.infos[1]).toContain("changed: src/app.py");
+  });
+
+  test("keeps the last status of runners that were not re-run", async () => {
+    let broken = false;
+    const py = makeRunner("py", ["**/*.py"], () =>
+      broken ? [makeIssue("/project/src/app.py")] : []
+    );
+    const sh = makeRunner("sh", ["**/*.sh"]);
+    const { watcher, cons, stop } = startWatch([py, sh]);
+    await sleep(10);
+
+    broken = true;
+    watcher.emit("src/app.py");
+    await sleep(20);
+    await stop();
+
+    const status = cons.infos.at(-1) ?? "";
+    expect(status).toContain("  ✗ py  1 new issue(s)");
+    expect(status).toContain("src/app.py:3:1: [ERROR] py/E1: Bad thing");
+    expect(status).toContain("  ✓ sh");
+  });
+
+  test("skips changes under .git and .ai-guardrails", async () => {
+    const any = makeRunner("any", undefined);
+    const { watcher, stop } = startWatch([any]);
+    await sleep(10);
+
+    watcher.emit(".git/index");
+    watcher.emit(".ai-guardrails/cache/any.json");
+    await sleep(20);
+    await stop();
+
+    expect(any.runs).toBe(1);
+  });
+
+  test("skips changes to ignored paths", async () => {
+    const py = makeRunner("py", ["**/*.py"]);
+    const { watcher, stop } = startWatch([py], (rel) => rel.startsWith("gen/"));
+    await sleep(10);
+
+    watcher.emit("gen/schema_pb2.py");
+    await sleep(20);
+    await stop();
+
+    expect(py.runs).toBe(1);
+  });
+
+  test("closes the watcher when the signal aborts", async () => {
+    const { watcher, stop } = startWatch([makeRunner("py", ["**/*.py"])]);
+    await stop();
+
+    expect(watcher.watched).toEqual(["/project"]);
+    expect(watcher.closed).toBe(true);
+  });
+});
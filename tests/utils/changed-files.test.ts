@@ -0,0 +1,151 @@
+import { describe, expect, test } from "bun:test";
+import {
+  listChangedFiles,
+  listPartiallyStagedFiles,
+  listStagedFiles,
+  matchFiles,
+  readStagedContent,
+} from "@/utils/changed-files";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const DIFF_ARGS = ["git", "diff", "--name-only", "origin/main...HEAD"];
+const STAGED_ARGS = ["git", "diff", "--cached", "--name-only", "--diff-filter=ACM"];
+
+describe("listChangedFiles", () => {
+  test("returns changed files that still exist", async () => {
+    const cr = new FakeCommandRunner();
+    cr.register(DIFF_ARGS, {
+      stdout: "src/a.py\nscripts/deleted.sh\nREADME.md\n",
+      stderr: "",
+      exitCode: 0,
+    });
+    const fm = new FakeFileManager();
+    fm.seed("/project/src/a.py", "");
+    fm.seed("/project/README.md", "");
+
+    const files = await listChangedFiles("origin/main", "/project", cr, fm);
+
+    expect(files).toEqual(["src/a.py", "README.md"]);
+    expect(cr.cwds[0]).toBe("/project");
+  });
+
+  test("returns [] when nothing changed", async () => {
+    const cr = new FakeCommandRunner();
+    cr.register(DIFF_ARGS, { stdout: "\n", stderr: "", exitCode: 0 });
+
+    const files = await listChangedFiles(
+      "origin/main",
+      "/project",
+      cr,
+      new FakeFileManager()
+    );
+
+    expect(files).toEqual([]);
+  });
+
+  test("throws with git's stderr when the diff fails", async () => {
+    const cr = new FakeCommandRunner();
+    cr.register(DIFF_ARGS, {
+      stdout: "",
+      stderr: "fatal: bad revision 'origin/main...HEAD'",
+      exitCode: 128,
+    });
+
+    await expect(
+      listChangedFiles("origin/main", "/project", cr, new FakeFileManager())
+    ).rejects.toThrow("bad revision");
+  });
+});
+
+describe("listStagedFiles", () => {
+  test("returns the staged files git reports", async () => {
+    const cr = new FakeCommandRunner();
+    cr.register(STAGED_ARGS, {
+      stdout: "src/a.py\nscripts/run.sh\n",
+      stderr: "",
+      exitCode: 0,
+    });
+
+    const files = await listStagedFiles("/project", cr);
+
+    expect(files).toEqual(["src/a.py", "scripts/run.sh"]);
+    expect(cr.cwds[0]).toBe("/project");
+  });
+
+  test("throws when git fails", async () => {
+    const cr = new FakeCommandRunner();
+    cr.register(STAGED_ARGS, {
+      stdout: "",
+      stderr: "fatal: not a git repository",
+      exitCode: 128,
+    });
+
+    await expect(listStagedFiles("/project", cr)).rejects.toThrow(
+      "not a git repository"
+    );
+  });
+});
+
+describe("listPartiallyStagedFiles", () => {
+  test("keeps the staged files that also have unstaged changes", async () => {
+    const cr = new FakeCommandRunner();
+    cr.register(["git", "diff", "--name-only"], {
+      stdout: "src/a.py\nnotes.md\n",
+      stderr: "",
+      exitCode: 0,
+    });
+
+    const staged = ["src/a.py", "b.sh"];
+    const partial = await listPartiallyStagedFiles(staged, "/project", cr);
+
+    expect(partial).toEqual(["src/a.py"]);
+  });
+
+  test("does not run git when nothing is staged", async () => {
+    const cr = new FakeCommandRunner();
+
+    expect(await listPartiallyStagedFiles([], "/project", cr)).toEqual([]);
+    expect(cr.calls).toHaveLength(0);
+  });
+});
+
+describe("readStagedContent", () => {
+  test("returns the index content of the file", async () => {
+    const cr = new FakeCommandRunner();
+    cr.register(["git", "show", ":src/a.py"], {
+      stdout: "x = 1\n",
+      stderr: "",
+      exitCode: 0,
+    });
+
+    expect(await readStagedContent("src/a.py", "/project", cr)).toBe("x = 1\n");
+  });
+
+  test("throws when git show fails", async () => {
+    const cr = new FakeCommandRunner();
+    cr.register(["git", "show", ":gone.py"], {
+      stdout: "",
+      stderr: "fatal: path 'gone.py' does not exist",
+      exitCode: 128,
+    });
+
+    await expect(readStagedContent("gone.py", "/project", cr)).rejects.toThrow(
+      "git show :gone.py failed"
+    );
+  });
+});
+
+describe("matchFiles", () => {
+  test("keeps files matching the glob at any depth", () => {
+    const files = ["a.py", "src/b.py", "src/c.ts", ".hidden/d.py"];
+    expect(matchFiles(files, "**/*.py")).toEqual(["a.py", "src/b.py", ".hidden/d.py"]);
+  });
+
+  test("supports brace alternatives", () => {
+    expect(matchFiles(["x.sh", "y.bash", "z.txt"], "**/*.{sh,bash}")).toEqual([
+      "x.sh",
+      "y.bash",
+    ]);
+  });
+});
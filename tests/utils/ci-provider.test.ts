@@ -0,0 +1,48 @@
+import { describe, expect, test } from "bun:test";
+import { resolveCiProvider } from "@/utils/ci-provider";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+describe("resolveCiProvider", () => {
+  test("explicit --ci flag wins over detection", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.gitlab-ci.yml", "");
+
+    expect(await resolveCiProvider("/project", fm, "github")).toBe("github");
+    expect(await resolveCiProvider("/project", fm, "none")).toBe("none");
+  });
+
+  test("--no-ci resolves to none", async () => {
+    expect(await resolveCiProvider("/project", new FakeFileManager(), false)).toBe(
+      "none"
+    );
+  });
+
+  test("existing .gitlab-ci.yml selects gitlab", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.gitlab-ci.yml", "");
+
+    expect(await resolveCiProvider("/project", fm, undefined)).toBe("gitlab");
+  });
+
+  test("existing CircleCI or Azure config selects that provider", async () => {
+    const circle = new FakeFileManager();
+    circle.seed("/project/.circleci/config.yml", "");
+    const azure = new FakeFileManager();
+    azure.seed("/project/azure-pipelines.yml", "");
+
+    expect(await resolveCiProvider("/project", circle, undefined)).toBe("circleci");
+    expect(await resolveCiProvider("/project", azure, undefined)).toBe("azure");
+  });
+
+  test("defaults to github", async () => {
+    expect(await resolveCiProvider("/project", new FakeFileManager(), undefined)).toBe(
+      "github"
+    );
+  });
+
+  test("ignores unknown flag values", async () => {
+    expect(await resolveCiProvider("/project", new FakeFileManager(), "jenkins")).toBe(
+      "github"
+    );
+  });
+});
@@ -0,0 +1,43 @@
+import { describe, expect, test } from "bun:test";
+import { ciInstallCommands, ciToolPackages } from "@/utils/ci-tools";
+
+describe("ciToolPackages", () => {
+  test("installs codespell for every project", () => {
+    const packages = ciToolPackages(new Set());
+    expect(packages).toEqual({
+      apt: ["python3-pip"],
+      pip: ["codespell"],
+      bunInstall: false,
+    });
+  });
+
+  test("adds the tools of the detected languages", () => {
+    const packages = ciToolPackages(new Set(["python", "shell", "typescript"]));
+    expect(packages.apt).toEqual(["python3-pip", "shellcheck", "shfmt"]);
+    expect(packages.pip).toEqual(["ruff", "pyright", "codespell"]);
+    expect(packages.bunInstall).toBe(true);
+  });
+});
+
+describe("ciInstallCommands", () => {
+  test("runs apt and pip directly by default", () => {
+    const commands = ciInstallCommands(new Set(["python"]));
+    expect(commands).toEqual([
+      "apt-get update && apt-get install -y --no-install-recommends python3-pip",
+      "pip3 install --break-system-packages ruff pyright codespell",
+    ]);
+  });
+
+  test("prefixes sudo for non-root agents", () => {
+    const commands = ciInstallCommands(new Set(), { sudo: true });
+    expect(commands[0]).toStartWith("sudo apt-get update && sudo apt-get install");
+    expect(commands[1]).toStartWith("sudo pip3 install");
+  });
+
+  test("installs JS dependencies only for TypeScript projects", () => {
+    expect(ciInstallCommands(new Set(["typescript"])).at(-1)).toContain(
+      "bun install --frozen-lockfile"
+    );
+    expect(ciInstallCommands(new Set(["go"])).join("\n")).not.toContain("bun install");
+  });
+});
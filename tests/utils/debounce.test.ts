@@ -0,0 +1,57 @@
+import { describe, expect, test } from "bun:test";
+import { createBatcher } from "@/utils/debounce";
+
+function sleep(ms: number): Promise<void> {
+  return new Promise((resolve) => setTimeout(resolve, ms));
+}
+
+describe("createBatcher", () => {
+  test("flushes a burst of items once, deduplicated, in arrival order", async () => {
+    const flushed: string[][] = [];
+    const batcher = createBatcher<string>(20, (items) => flushed.push(items));
+
+    batcher.add("b.py");
+    batcher.add("a.py");
+    batcher.add("b.py");
+    await sleep(40);
+
+    expect(flushed).toEqual([["b.py", "a.py"]]);
+  });
+
+  test("each new item restarts the quiet period", async () => {
+    const flushed: string[][] = [];
+    const batcher = createBatcher<string>(30, (items) => flushed.push(items));
+
+    batcher.add("a.py");
+    await sleep(15);
+    batcher.add("b.py");
+    await sleep(15);
+    expect(flushed).toEqual([]);
+
+    await sleep(30);
+    expect(flushed).toEqual([["a.py", "b.py"]]);
+  });
+
+  test("separate bursts flush separately", async () => {
+    const flushed: string[][] = [];
+    const batcher = createBatcher<string>(10, (items) => flushed.push(items));
+
+    batcher.add("a.py");
+    await sleep(25);
+    batcher.add("b.py");
+    await sleep(25);
+
+    expect(flushed).toEqual([["a.py"], ["b.py"]]);
+  });
+
+  test("cancel drops pending items", async () => {
+    const flushed: string[][] = [];
+    const batcher = createBatcher<string>(10, (items) => flushed.push(items));
+
+    batcher.add("a.py");
+    batcher.cancel();
+    await sleep(25);
+
+    expect(flushed).toEqual([]);
+  });
+});
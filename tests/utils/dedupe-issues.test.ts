@@ -0,0 +1,67 @@
+import { describe, expect, test } from "bun:test";
+import type { LintIssue } from "@/models/lint-issue";
+import { dedupeIssues, duplicateKey, normalizeMessage } from "@/utils/dedupe-issues";
+
+function makeIssue(overrides: Partial<LintIssue> = {}): LintIssue {
+  return {
+    rule: "staticcheck/SA4006",
+    linter: "staticcheck",
+    file: "/project/pkg/api.go",
+    line: 12,
+    col: 2,
+    message: "this value of x is never used",
+    severity: "error",
+    fingerprint: "fp-staticcheck",
+    ...overrides,
+  };
+}
+
+const wrapped = makeIssue({
+  rule: "golangci-lint/staticcheck",
+  linter: "golangci-lint",
+  message: "SA4006: this value of x is never used.",
+  severity: "warning",
+  fingerprint: "fp-golangci",
+});
+
+describe("normalizeMessage", () => {
+  test("ignores case, whitespace, a trailing period and a leading code", () => {
+    expect(normalizeMessage("SA4006: This value  of x is never used.")).toBe(
+      "this value of x is never used"
+    );
+  });
+});
+
+describe("duplicateKey", () => {
+  test("matches the same problem across runners, not across lines", () => {
+    expect(duplicateKey(wrapped)).toBe(duplicateKey(makeIssue()));
+    expect(duplicateKey(makeIssue({ line: 13 }))).not.toBe(duplicateKey(makeIssue()));
+  });
+});
+
+describe("dedupeIssues", () => {
+  test("keeps the most severe report, listing every runner", () => {
+    const issues = dedupeIssues([wrapped, makeIssue()]);
+    expect(issues).toEqual([
+      { ...makeIssue(), reportedBy: ["staticcheck", "golangci-lint"] },
+    ]);
+  });
+
+  test("keeps the first report on a tie, so its fingerprint is stable", () => {
+    const first = { ...wrapped, severity: "error" as const };
+    const [issue] = dedupeIssues([first, makeIssue()]);
+    expect(issue?.fingerprint).toBe("fp-golangci");
+    expect(issue?.reportedBy).toEqual(["golangci-lint", "staticcheck"]);
+  });
+
+  test("leaves a runner repeating itself alone", () => {
+    const issues = dedupeIssues([makeIssue(), makeIssue({ col: 9, fingerprint: "x" })]);
+    expect(issues).toHaveLength(2);
+    expect(issues.every((issue) => issue.reportedBy === undefined)).toBe(true);
+  });
+
+  test("keeps distinct findings as they are", () => {
+    const other = makeIssue({ line: 20, message: "unused", fingerprint: "fp-2" });
+    expect(dedupeIssues([makeIssue(), other])).toEqual([makeIssue(), other]);
+  });
+});
@@ -0,0 +1,62 @@
+import { describe, expect, test } from "bun:test";
+import { findGeneratedFiles, isGeneratedSource } from "@/utils/generated-files";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const GENERATED = [
+  "// Code generated by mockgen. DO NOT EDIT.",
+  "// Source: store.go",
+  "",
+  "package mocks",
+  "",
+].join("\n");
+
+describe("isGeneratedSource", () => {
+  test("detects the marker in the header", () => {
+    expect(isGeneratedSource(GENERATED)).toBe(true);
+  });
+
+  test("accepts the marker after a build constraint", () => {
+    const content = "//go:build linux\n\n// Code generated by stringer. DO NOT EDIT.\n";
+    expect(isGeneratedSource(`${content}\npackage color\n`)).toBe(true);
+  });
+
+  test("ignores the marker after the package clause", () => {
+    const content = "package main\n\n// Code generated by hand. DO NOT EDIT.\n";
+    expect(isGeneratedSource(content)).toBe(false);
+  });
+
+  test("requires the exact marker form", () => {
+    const lowercase = "// Code generated by x, do not edit\npackage a\n";
+    const block = "/* Code generated by x. DO NOT EDIT. */\npackage a\n";
+    expect(isGeneratedSource(lowercase)).toBe(false);
+    expect(isGeneratedSource(block)).toBe(false);
+  });
+
+  test("returns false for ordinary source", () => {
+    expect(isGeneratedSource("package main\n\nfunc main() {}\n")).toBe(false);
+  });
+});
+
+describe("findGeneratedFiles", () => {
+  test("lists generated Go files, sorted and project-relative", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/main.go", "package main\n");
+    fm.seed("/project/store/mock_store.go", GENERATED);
+    fm.seed("/project/api/api.pb.go", GENERATED);
+
+    const files = await findGeneratedFiles(fm, "/project", []);
+
+    expect(files).toEqual(["api/api.pb.go", "store/mock_store.go"]);
+  });
+
+  test("skips vendored and ignore_paths files", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/vendor/dep/gen.go", GENERATED);
+    fm.seed("/project/third_party/gen.go", GENERATED);
+    fm.seed("/project/gen.go", GENERATED);
+
+    const files = await findGeneratedFiles(fm, "/project", ["third_party/**"]);
+
+    expect(files).toEqual(["gen.go"]);
+  });
+});
@@ -0,0 +1,177 @@
+import { describe, expect, test } from "bun:test";
+import type { ResolvedConfig } from "@/config/schema";
+import {
+  compareGoVersions,
+  findGoModules,
+  goLanguageVersion,
+  goModulesFor,
+  parseGoDirective,
+  parseGoWork,
+  readGoVersion,
+  withModule,
+} from "@/utils/go-modules";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+const PROJECT_DIR = "/project";
+
+function makeConfig(overrides?: Partial<ResolvedConfig>): ResolvedConfig {
+  return {
+    profile: "standard",
+    ignore: [],
+    allow: [],
+    values: { line_length: 100, indent_width: 2 },
+    ignoredRules: new Set(),
+    ignorePaths: [],
+    noConsoleLevel: "warn",
+    isAllowed: () => false,
+    ...overrides,
+  };
+}
+
+describe("parseGoWork", () => {
+  test("reads single-line and block use directives", () => {
+    const content = [
+      "go 1.22",
+      "",
+      "use ./tools",
+      "use (",
+      "\t./api // the HTTP service",
+      '\t"./worker"',
+      ")",
+    ].join("\n");
+    expect(parseGoWork(content)).toEqual(["./tools", "./api", "./worker"]);
+  });
+
+  test("ignores commented-out modules and other directives", () => {
+    const content = "go 1.22\ntoolchain go1.22.1\n// use ./old\nuse .\n";
+    expect(parseGoWork(content)).toEqual(["."]);
+  });
+});
+
+describe("parseGoDirective", () => {
+  test("reads the go directive, ignoring toolchain and comments", () => {
+    const content =
+      "module example.com/app\n\ngo 1.22 // minimum\n\ntoolchain go1.23.1\n";
+    expect(parseGoDirective(content)).toBe("1.22");
+    expect(parseGoDirective("module x\ngo 1.21.5\n")).toBe("1.21.5");
+  });
+
+  test("returns null without a go directive", () => {
+    expect(parseGoDirective("module example.com/app\n")).toBeNull();
+  });
+});
+
+describe("readGoVersion", () => {
+  test("reads the module's go.mod, or null when there is none", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/app\ngo 1.22\n");
+    expect(await readGoVersion(fm, "/project")).toBe("1.22");
+    expect(await readGoVersion(fm, "/project/missing")).toBeNull();
+  });
+});
+
+describe("Go versions", () => {
+  test("compares numerically, treating missing parts as 0", () => {
+    expect(compareGoVersions("1.9", "1.21")).toBeLessThan(0);
+    expect(compareGoVersions("1.22.1", "1.22")).toBeGreaterThan(0);
+    expect(compareGoVersions("1.22.0", "1.22")).toBe(0);
+  });
+
+  test("reduces a version to its language version", () => {
+    expect(goLanguageVersion("1.21.5")).toBe("1.21");
+    expect(goLanguageVersion("1.22")).toBe("1.22");
+  });
+});
+
+describe("findGoModules", () => {
+  test("finds every go.mod when there is no go.work", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/app");
+    fm.seed("/project/tools/go.mod", "module example.com/tools");
+
+    expect(await findGoModules(fm, PROJECT_DIR)).toEqual([
+      "/project",
+      "/project/tools",
+    ]);
+  });
+
+  test("uses the go.work modules, skipping go.mod files outside it", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.work", "go 1.22\nuse (\n\t./worker\n\t./api\n)\n");
+    fm.seed("/project/api/go.mod", "module example.com/api");
+    fm.seed("/project/worker/go.mod", "module example.com/worker");
+    fm.seed("/project/scratch/go.mod", "module example.com/scratch");
+
+    expect(await findGoModules(fm, PROJECT_DIR)).toEqual([
+      "/project/api",
+      "/project/worker",
+    ]);
+  });
+
+  test("applies ignore paths to go.work modules", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.work", "use ./api\nuse ./third_party/lib\n");
+
+    expect(await findGoModules(fm, PROJECT_DIR, ["third_party/**"])).toEqual([
+      "/project/api",
+    ]);
+  });
+});
+
+describe("goModulesFor", () => {
+  test("keeps only the modules at or under opts.module", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/app");
+    fm.seed("/project/services/api/go.mod", "module example.com/api");
+    fm.seed("/project/services/worker/go.mod", "module example.com/worker");
+    fm.seed("/project/servicesx/go.mod", "module example.com/x");
+
+    const modules = await goModulesFor({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: new FakeCommandRunner(),
+      fileManager: fm,
+      module: "services",
+    });
+
+    expect(modules).toEqual(["/project/services/api", "/project/services/worker"]);
+  });
+
+  test("with opts.paths, keeps the modules under or holding each path", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/go.mod", "module example.com/app");
+    fm.seed("/project/services/api/go.mod", "module example.com/api");
+    fm.seed("/project/services/worker/go.mod", "module example.com/worker");
+
+    const modules = await goModulesFor({
+      projectDir: PROJECT_DIR,
+      config: makeConfig(),
+      commandRunner: new FakeCommandRunner(),
+      fileManager: fm,
+      paths: ["services/api", "services/worker/internal", "cmd"],
+    });
+
+    expect(modules).toEqual([
+      "/project",
+      "/project/services/api",
+      "/project/services/worker",
+    ]);
+  });
+});
+
+describe("withModule", () => {
+  test("tags issues with the project-relative module dir", () => {
+    const issue = {
+      rule: "staticcheck/SA4006",
+      linter: "staticcheck",
+      file: "/project/api/main.go",
+      line: 1,
+      col: 1,
+      message: "unused value",
+      severity: "error" as const,
+    };
+    expect(withModule([issue], PROJECT_DIR, "/project/api")[0]?.module).toBe("api");
+    expect(withModule([issue], PROJECT_DIR, "/project")[0]?.module).toBe(".");
+  });
+});
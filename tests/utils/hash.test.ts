@@ -1,5 +1,14 @@
 import { describe, expect, test } from "bun:test";
-import { HASH_PREFIX, makeHashHeader, withHashHeader } from "@/utils/hash";
+import {
+  computeHash,
+  HASH_PREFIX,
+  isOutdatedTemplate,
+  makeHashHeader,
+  parseHashHeader,
+  TEMPLATE_VERSION,
+  withHashHeader,
+  withMarkdownHashHeader,
+} from "@/utils/hash";
 
 describe("HASH_PREFIX", () => {
   test("has correct value", () => {
@@ -13,10 +22,11 @@ describe("makeHashHeader", () => {
     expect(header).toStartWith(HASH_PREFIX);
   });
 
-  test("returns a 64-char hex hash after the prefix", () => {
+  test("returns a 64-char hex hash and the template version after the prefix", () => {
     const header = makeHashHeader("some content");
-    const hash = header.slice(HASH_PREFIX.length);
-    expect(hash).toMatch(/^[0-9a-f]{64}$/);
+    const rest = header.slice(HASH_PREFIX.length);
+    expect(rest).toMatch(/^[0-9a-f]{64};template=v\d+$/);
+    expect(rest).toEndWith(`;template=v${TEMPLATE_VERSION}`);
   });
 
   test("same content produces same header", () => {
@@ -61,3 +71,49 @@ describe("withHashHeader", () => {
     expect(result).toBe(`${expectedHeader}\n${body}`);
   });
 });
+
+describe("parseHashHeader", () => {
+  test("reads the hash, template version and body", () => {
+    const body = "key = 1\n";
+    expect(parseHashHeader(withHashHeader(body))).toEqual({
+      hash: computeHash(body),
+      templateVersion: TEMPLATE_VERSION,
+      body,
+    });
+  });
+
+  test("reads the Markdown header form", () => {
+    const header = parseHashHeader(withMarkdownHashHeader("# Rules\n"));
+    expect(header?.templateVersion).toBe(TEMPLATE_VERSION);
+    expect(header?.body).toBe("# Rules\n");
+  });
+
+  test("treats a header without a template version as version 0", () => {
+    const body = "key = 1\n";
+    const header = parseHashHeader(`${HASH_PREFIX}${computeHash(body)}\n${body}`);
+    expect(header?.templateVersion).toBe(0);
+  });
+
+  test("returns null without a header", () => {
+    expect(parseHashHeader("key = 1\n")).toBeNull();
+    expect(parseHashHeader(`${HASH_PREFIX}not-a-hash\nkey = 1\n`)).toBeNull();
+  });
+});
+
+describe("isOutdatedTemplate", () => {
+  const body = "key = 1\n";
+  const legacy = `${HASH_PREFIX}${computeHash(body)}\n${body}`;
+
+  test("is true for an untouched file from an older template", () => {
+    expect(isOutdatedTemplate(legacy)).toBe(true);
+  });
+
+  test("is false once the user has edited the file", () => {
+    expect(isOutdatedTemplate(`${legacy}extra = 2\n`)).toBe(false);
+  });
+
+  test("is false for the current template and for files without a header", () => {
+    expect(isOutdatedTemplate(withHashHeader(body))).toBe(false);
+    expect(isOutdatedTemplate(body)).toBe(false);
+  });
+});
@@ -0,0 +1,44 @@
+import { describe, expect, test } from "bun:test";
+import { findCharts, isChartTemplate } from "@/utils/helm-charts";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+describe("findCharts", () => {
+  test("returns each chart directory, without subcharts", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/deploy/api/Chart.yaml", "name: api\n");
+    fm.seed("/project/deploy/api/charts/redis/Chart.yaml", "name: redis\n");
+    fm.seed("/project/deploy/web/Chart.yaml", "name: web\n");
+    expect(await findCharts(fm, "/project", [])).toEqual(["deploy/api", "deploy/web"]);
+  });
+
+  test("returns the project root when it is a chart", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/Chart.yaml", "name: app\n");
+    fm.seed("/project/charts/db/Chart.yaml", "name: db\n");
+    expect(await findCharts(fm, "/project", [])).toEqual(["."]);
+  });
+
+  test("keeps the charts holding a changed file", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/deploy/api/Chart.yaml", "name: api\n");
+    fm.seed("/project/deploy/web/Chart.yaml", "name: web\n");
+    const files = ["deploy/web/templates/ingress.yaml"];
+    expect(await findCharts(fm, "/project", [], files)).toEqual(["deploy/web"]);
+  });
+});
+
+describe("isChartTemplate", () => {
+  const charts = ["deploy/api"];
+
+  test("is true for templates of a chart and of its subcharts", () => {
+    expect(isChartTemplate("deploy/api/templates/deployment.yaml", charts)).toBe(true);
+    expect(isChartTemplate("deploy/api/charts/redis/templates/svc.yaml", charts)).toBe(
+      true
+    );
+  });
+
+  test("is false for a chart's values and for YAML outside charts", () => {
+    expect(isChartTemplate("deploy/api/values.yaml", charts)).toBe(false);
+    expect(isChartTemplate("k8s/templates/job.yaml", charts)).toBe(false);
+  });
+});
@@ -0,0 +1,123 @@
+import { describe, expect, test } from "bun:test";
+import {
+  createIgnoreMatcher,
+  loadIgnoreMatcher,
+  parseIgnorePatterns,
+} from "@/utils/ignore-file";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+function matcherFor(content: string) {
+  return createIgnoreMatcher(parseIgnorePatterns(content));
+}
+
+describe("parseIgnorePatterns", () => {
+  test("skips blank lines and comments", () => {
+    expect(parseIgnorePatterns("\n# generated\n  \nvendor/\n")).toHaveLength(1);
+  });
+
+  test("treats a leading backslash as a literal character", () => {
+    const isIgnored = matcherFor("\\#notes.md\n\\!important.txt\n");
+    expect(isIgnored("#notes.md")).toBe(true);
+    expect(isIgnored("!important.txt")).toBe(true);
+  });
+});
+
+describe("createIgnoreMatcher", () => {
+  test("a pattern without a slash matches at any depth", () => {
+    const isIgnored = matcherFor("*.pb.go\n");
+    expect(isIgnored("api.pb.go")).toBe(true);
+    expect(isIgnored("internal/api/v1/api.pb.go")).toBe(true);
+    expect(isIgnored("internal/api/v1/api.go")).toBe(false);
+  });
+
+  test("a pattern with a slash is anchored to the project root", () => {
+    const isIgnored = matcherFor("/build\ndocs/gen\n");
+    expect(isIgnored("build/out.js")).toBe(true);
+    expect(isIgnored("web/build/out.js")).toBe(false);
+    expect(isIgnored("docs/gen/api.md")).toBe(true);
+    expect(isIgnored("web/docs/gen/api.md")).toBe(false);
+  });
+
+  test("a trailing slash matches directories only", () => {
+    const isIgnored = matcherFor("vendor/\n");
+    expect(isIgnored("vendor/lib/x.go")).toBe(true);
+    expect(isIgnored("pkg/vendor/x.go")).toBe(true);
+    expect(isIgnored("vendor")).toBe(false);
+  });
+
+  test("the last matching pattern wins, so ! re-includes", () => {
+    const isIgnored = matcherFor("generated/*\n!generated/keep.ts\n");
+    expect(isIgnored("generated/schema.ts")).toBe(true);
+    expect(isIgnored("generated/keep.ts")).toBe(false);
+  });
+
+  test("a file beneath an ignored directory cannot be re-included", () => {
+    const isIgnored = matcherFor("generated/\n!generated/keep.ts\n");
+    expect(isIgnored("generated/keep.ts")).toBe(true);
+  });
+
+  test("a pattern matching a directory ignores everything under it", () => {
+    const isIgnored = matcherFor("coverage\n");
+    expect(isIgnored("coverage/lcov/index.html")).toBe(true);
+    expect(isIgnored("coverage")).toBe(true);
+  });
+
+  test("matches dotfiles", () => {
+    expect(matcherFor("*.env\n")(".env")).toBe(true);
+    expect(matcherFor(".env*\n")("config/.env.local")).toBe(true);
+  });
+});
+
+describe("loadIgnoreMatcher", () => {
+  test("returns null when no ignore file exists", async () => {
+    const fm = new FakeFileManager();
+    expect(await loadIgnoreMatcher("/project", fm)).toBeNull();
+  });
+
+  test("layers .guardrailsignore on top of .gitignore", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.gitignore", "dist/\n*.log\n");
+    fm.seed("/project/.guardrailsignore", "testdata/\n!debug.log\n");
+
+    const isIgnored = await loadIgnoreMatcher("/project", fm);
+
+    expect(isIgnored?.("dist/app.js")).toBe(true);
+    expect(isIgnored?.("pkg/testdata/bad.go")).toBe(true);
+    expect(isIgnored?.("error.log")).toBe(true);
+    expect(isIgnored?.("debug.log")).toBe(false);
+    expect(isIgnored?.("src/main.ts")).toBe(false);
+  });
+
+  test("applies nested .gitignore files relative to their directory", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.gitignore", "*.log\n");
+    fm.seed("/project/web/.gitignore", "/build\n!keep.log\n");
+
+    const isIgnored = await loadIgnoreMatcher("/project", fm);
+
+    expect(isIgnored?.("web/build/app.js")).toBe(true);
+    expect(isIgnored?.("web/src/build/app.js")).toBe(false);
+    expect(isIgnored?.("build.js")).toBe(false);
+    expect(isIgnored?.("web/keep.log")).toBe(false);
+    expect(isIgnored?.("keep.log")).toBe(true);
+  });
+
+  test("skips .gitignore files inside ignored directories", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.gitignore", "out/\n");
+    fm.seed("/project/out/.gitignore", "!*\n");
+
+    const isIgnored = await loadIgnoreMatcher("/project", fm);
+
+    expect(isIgnored?.("out/app.js")).toBe(true);
+  });
+
+  test("works with only a .guardrailsignore", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/.guardrailsignore", "fixtures/\n");
+
+    const isIgnored = await loadIgnoreMatcher("/project", fm);
+
+    expect(isIgnored?.("tests/fixtures/x.py")).toBe(true);
+  });
+});
@@ -0,0 +1,45 @@
+import { describe, expect, test } from "bun:test";
+import { unifiedDiff } from "@/utils/line-diff";
+
+describe("unifiedDiff", () => {
+  test("returns [] for equal texts", () => {
+    expect(unifiedDiff("a\nb\n", "a\nb\n", "f.txt")).toEqual([]);
+  });
+
+  test("shows a change with surrounding context", () => {
+    const before = "1\n2\n3\n4\n5\n6\n7\n8\n";
+    const after = "1\n2\n3\n4\nfive\n6\n7\n8\n";
+
+    expect(unifiedDiff(before, after, "f.txt")).toEqual([
+      "--- f.txt",
+      "+++ f.txt",
+      "@@ -2,7 +2,7 @@",
+      " 2",
+      " 3",
+      " 4",
+      "-5",
+      "+five",
+      " 6",
+      " 7",
+      " 8",
+    ]);
+  });
+
+  test("splits distant changes into separate hunks", () => {
+    const lines = Array.from({ length: 20 }, (_, i) => `${i + 1}`);
+    const before = `${lines.join("\n")}\n`;
+    const after = `${["one", ...lines.slice(1, 19), "twenty"].join("\n")}\n`;
+
+    const hunks = unifiedDiff(before, after, "f.txt").filter((l) => l.startsWith("@@"));
+    expect(hunks).toEqual(["@@ -1,4 +1,4 @@", "@@ -17,4 +17,4 @@"]);
+  });
+
+  test("diffs a new file against empty content", () => {
+    expect(unifiedDiff("", "x\n", "f.txt")).toEqual([
+      "--- f.txt",
+      "+++ f.txt",
+      "@@ -0,0 +1,1 @@",
+      "+x",
+    ]);
+  });
+});
@@ -0,0 +1,115 @@
+import { beforeEach, describe, expect, test } from "bun:test";
+import {
+  detectMonorepoTool,
+  listAffectedPackages,
+  NX,
+  parseTurboPackages,
+  TURBOREPO,
+} from "@/utils/monorepo";
+import { clearResolveToolPathCache } from "@/utils/resolve-tool-path";
+import { FakeCommandRunner } from "../fakes/fake-command-runner";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+beforeEach(() => {
+  clearResolveToolPathCache();
+});
+
+const PROJECT_DIR = "/project";
+const TURBO_BIN = `${PROJECT_DIR}/node_modules/.bin/turbo`;
+const NX_BIN = `${PROJECT_DIR}/node_modules/.bin/nx`;
+
+const TURBO_LS = JSON.stringify({
+  packageManager: "pnpm",
+  packages: {
+    count: 2,
+    items: [
+      { name: "web", path: "apps/web" },
+      { name: "@acme/ui", path: "packages/ui/" },
+    ],
+  },
+});
+
+describe("detectMonorepoTool", () => {
+  test("prefers turbo.json, then nx.json, else none", async () => {
+    const both = new FakeFileManager();
+    both.seed("/project/turbo.json", "{}");
+    both.seed("/project/nx.json", "{}");
+    const nxOnly = new FakeFileManager();
+    nxOnly.seed("/project/nx.json", "{}");
+
+    expect((await detectMonorepoTool(PROJECT_DIR, both))?.id).toBe("turbo");
+    expect((await detectMonorepoTool(PROJECT_DIR, nxOnly))?.id).toBe("nx");
+    expect(await detectMonorepoTool(PROJECT_DIR, new FakeFileManager())).toBeNull();
+  });
+});
+
+describe("parseTurboPackages", () => {
+  test("returns each package's dir, without a trailing slash", () => {
+    expect(parseTurboPackages(TURBO_LS)).toEqual(["apps/web", "packages/ui"]);
+  });
+
+  test("returns null for output that is not a package list", () => {
+    expect(parseTurboPackages("turbo 1.13.0")).toBeNull();
+  });
+});
+
+describe("listAffectedPackages", () => {
+  test("asks turbo for the changed packages and their dependents", async () => {
+    const cr = new FakeCommandRunner();
+    cr.register([TURBO_BIN, "ls", "--filter=...[origin/main]", "--output=json"], {
+      stdout: TURBO_LS,
+      stderr: "",
+      exitCode: 0,
+    });
+
+    const dirs = await listAffectedPackages(TURBOREPO, "origin/main", PROJECT_DIR, cr);
+
+    expect(dirs).toEqual(["apps/web", "packages/ui"]);
+  });
+
+  test("looks up the root of each project nx finds affected", async () => {
+    const cr = new FakeCommandRunner();
+    const affected = ["show", "projects", "--affected", "--base=main", "--head=HEAD"];
+    cr.register([NX_BIN, ...affected, "--json"], {
+      stdout: '["api","shared"]',
+      stderr: "",
+      exitCode: 0,
+    });
+    cr.register([NX_BIN, "show", "project", "api", "--json"], {
+      stdout: '{"name":"api","root":"apps/api"}',
+      stderr: "",
+      exitCode: 0,
+    });
+    cr.register([NX_BIN, "show", "project", "shared", "--json"], {
+      stdout: '{"name":"shared","root":"libs/shared"}',
+      stderr: "",
+      exitCode: 0,
+    });
+
+    const dirs = await listAffectedPackages(NX, "main", PROJECT_DIR, cr);
+
+    expect(dirs).toEqual(["apps/api", "libs/shared"]);
+  });
+
+  test("returns null when the tool is not installed", async () => {
+    const cr = new FakeCommandRunner();
+    const missing = { stdout: "", stderr: "not found", exitCode: 127 };
+    cr.register([TURBO_BIN, "--version"], missing);
+    cr.register(["turbo", "--version"], missing);
+
+    expect(await listAffectedPackages(TURBOREPO, "main", PROJECT_DIR, cr)).toBeNull();
+  });
+
+  test("throws when the tool fails, e.g. for an unknown ref", async () => {
+    const cr = new FakeCommandRunner();
+    cr.register([TURBO_BIN, "ls", "--filter=...[nope]", "--output=json"], {
+      stdout: "",
+      stderr: "invalid commit range",
+      exitCode: 1,
+    });
+
+    const listing = listAffectedPackages(TURBOREPO, "nope", PROJECT_DIR, cr);
+
+    await expect(listing).rejects.toThrow("failed: invalid commit range");
+  });
+});
@@ -0,0 +1,27 @@
+import { describe, expect, test } from "bun:test";
+import { parseJsonStream, parseNdjson } from "@/utils/ndjson";
+
+describe("parseNdjson", () => {
+  test("parses one object per line and skips malformed lines", () => {
+    expect(parseNdjson('{"a":1}\nnot json\n\n{"b":2}\n')).toEqual([{ a: 1 }, { b: 2 }]);
+  });
+});
+
+describe("parseJsonStream", () => {
+  test("parses concatenated multi-line objects", () => {
+    const stream = '{\n  "a": 1\n}\n{\n  "b": {\n    "c": [1, 2]\n  }\n}\n';
+    expect(parseJsonStream(stream)).toEqual([{ a: 1 }, { b: { c: [1, 2] } }]);
+  });
+
+  test("ignores braces inside strings", () => {
+    const stream = '{"msg": "a } brace and \\" quote {"}{"n": 2}';
+    expect(parseJsonStream(stream)).toEqual([
+      { msg: 'a } brace and " quote {' },
+      { n: 2 },
+    ]);
+  });
+
+  test("returns [] for empty input", () => {
+    expect(parseJsonStream("")).toEqual([]);
+  });
+});
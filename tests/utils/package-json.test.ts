@@ -0,0 +1,36 @@
+import { describe, expect, test } from "bun:test";
+import { detectJsPackageManager, devDependencyCommand } from "@/utils/package-json";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+describe("detectJsPackageManager", () => {
+  test("picks the manager from the lockfile", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/pnpm-lock.yaml", "");
+    expect(await detectJsPackageManager("/project", fm)).toBe("pnpm");
+  });
+
+  test("prefers bun when several lockfiles exist", async () => {
+    const fm = new FakeFileManager();
+    fm.seed("/project/yarn.lock", "");
+    fm.seed("/project/bun.lockb", "");
+    expect(await detectJsPackageManager("/project", fm)).toBe("bun");
+  });
+
+  test("defaults to npm without a lockfile", async () => {
+    expect(await detectJsPackageManager("/project", new FakeFileManager())).toBe("npm");
+  });
+});
+
+describe("devDependencyCommand", () => {
+  test("rewrites npm install -D for the manager", () => {
+    expect(devDependencyCommand("npm install -D eslint", "yarn")).toBe(
+      "yarn add -D eslint"
+    );
+  });
+
+  test("leaves other commands unchanged", () => {
+    expect(devDependencyCommand("npm install -g markdownlint-cli2", "bun")).toBe(
+      "npm install -g markdownlint-cli2"
+    );
+  });
+});
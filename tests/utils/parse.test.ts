@@ -0,0 +1,17 @@
+import { describe, expect, test } from "bun:test";
+import { parseRunnerList } from "@/utils/parse";
+
+describe("parseRunnerList", () => {
+  test("splits on commas, trimming ids and dropping empty ones", () => {
+    expect(parseRunnerList("ruff, biome,,shellcheck ")).toEqual([
+      "ruff",
+      "biome",
+      "shellcheck",
+    ]);
+  });
+
+  test("is empty for a missing or non-string flag", () => {
+    expect(parseRunnerList(undefined)).toEqual([]);
+    expect(parseRunnerList(true)).toEqual([]);
+  });
+});
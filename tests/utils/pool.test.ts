@@ -0,0 +1,108 @@
+import { describe, expect, test } from "bun:test";
+import { createLimiter, defaultJobs, defaultMaxProcs, mapPool } from "@/utils/pool";
+
+function tick(): Promise<void> {
+  return new Promise((resolve) => setTimeout(resolve, 1));
+}
+
+describe("mapPool", () => {
+  test("returns results in input order", async () => {
+    // Later items finish first
+    const result = await mapPool([3, 2, 1], 3, async (n) => {
+      for (let i = 0; i < n; i++) await tick();
+      return n * 10;
+    });
+    expect(result).toEqual([30, 20, 10]);
+  });
+
+  test("never exceeds the concurrency limit", async () => {
+    let inFlight = 0;
+    let peak = 0;
+    await mapPool([1, 2, 3, 4, 5, 6], 2, async () => {
+      inFlight++;
+      peak = Math.max(peak, inFlight);
+      await tick();
+      inFlight--;
+    });
+    expect(peak).toBe(2);
+  });
+
+  test("limit 1 runs calls strictly in sequence", async () => {
+    const events: string[] = [];
+    await mapPool(["a", "b", "c"], 1, async (item) => {
+      events.push(`start ${item}`);
+      await tick();
+      events.push(`end ${item}`);
+    });
+    expect(events).toEqual(["start a", "end a", "start b", "end b", "start c", "end c"]);
+  });
+
+  test("returns [] for no items", async () => {
+    expect(await mapPool([], 4, async (n: number) => n)).toEqual([]);
+  });
+});
+
+describe("defaultJobs", () => {
+  test("is at least 1", () => {
+    expect(defaultJobs()).toBeGreaterThanOrEqual(1);
+  });
+});
+
+describe("createLimiter", () => {
+  test("never runs more than max calls at once", async () => {
+    const limit = createLimiter(2);
+    let inFlight = 0;
+    let peak = 0;
+    await Promise.all(
+      [1, 2, 3, 4, 5].map(() =>
+        limit(async () => {
+          inFlight++;
+          peak = Math.max(peak, inFlight);
+          await tick();
+          inFlight--;
+        })
+      )
+    );
+    expect(peak).toBe(2);
+  });
+
+  test("frees the slot when a call rejects", async () => {
+    const limit = createLimiter(1);
+    await expect(limit(() => Promise.reject(new Error("boom")))).rejects.toThrow(
+      "boom"
+    );
+    expect(await limit(async () => "next")).toBe("next");
+  });
+
+  test("rejects a queued call once its signal aborts", async () => {
+    const limit = createLimiter(1);
+    const controller = new AbortController();
+    const waits: boolean[] = [];
+    let release = () => {};
+    const first = limit(() => new Promise<void>((resolve) => (release = resolve)));
+    const queued = limit(async () => "ran", {
+      signal: controller.signal,
+      onWait: (waiting) => waits.push(waiting),
+    });
+
+    controller.abort();
+
+    await expect(queued).rejects.toThrow("aborted while waiting for a slot");
+    expect(waits).toEqual([true, false]);
+    release();
+    await first;
+    expect(await limit(async () => "next")).toBe("next");
+  });
+});
+
+describe("defaultMaxProcs", () => {
+  test("uses GOMAXPROCS when it is a positive integer", () => {
+    expect(defaultMaxProcs({ GOMAXPROCS: "3" })).toBe(3);
+  });
+
+  test("falls back to the CPU count otherwise", () => {
+    expect(defaultMaxProcs({})).toBe(defaultJobs());
+    expect(defaultMaxProcs({ GOMAXPROCS: "0" })).toBe(defaultJobs());
+    expect(defaultMaxProcs({ GOMAXPROCS: "many" })).toBe(defaultJobs());
+  });
+});
@@ -0,0 +1,42 @@
+import { describe, expect, test } from "bun:test";
+import { isTransientError, retryDelayMs, sleep } from "@/utils/retry";
+
+describe("isTransientError", () => {
+  test("recognizes network failures from Go, Node and curl", () => {
+    expect(isTransientError("govulncheck failed: dial tcp: i/o timeout")).toBe(true);
+    expect(isTransientError("fetch failed: ECONNRESET")).toBe(true);
+    expect(isTransientError("curl: (6) Could not resolve host: x.org")).toBe(true);
+    expect(isTransientError("unexpected status 503 Service Unavailable")).toBe(true);
+  });
+
+  test("leaves tool and code errors alone", () => {
+    expect(isTransientError("govulncheck failed: package foo is not in std")).toBe(
+      false
+    );
+    expect(isTransientError("timed out after 120s")).toBe(false);
+  });
+
+  test("also matches the extra regexes", () => {
+    expect(isTransientError("rate limit exceeded", ["rate limit"])).toBe(true);
+    expect(isTransientError("rate limit exceeded", ["quota"])).toBe(false);
+  });
+});
+
+describe("retryDelayMs", () => {
+  test("doubles the backoff after each retry", () => {
+    expect([1, 2, 3].map((retry) => retryDelayMs(2, retry))).toEqual([
+      2000, 4000, 8000,
+    ]);
+  });
+});
+
+describe("sleep", () => {
+  test("returns early once the signal aborts", async () => {
+    const controller = new AbortController();
+    const start = performance.now();
+    const waiting = sleep(60_000, controller.signal);
+    controller.abort();
+    await waiting;
+    expect(performance.now() - start).toBeLessThan(1000);
+  });
+});
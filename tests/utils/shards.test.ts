@@ -0,0 +1,56 @@
+import { describe, expect, test } from "bun:test";
+import { DEFAULT_BATCH_SIZE, forEachShard, mapShards, toShards } from "@/utils/shards";
+
+const files = (count: number) => Array.from({ length: count }, (_, i) => `f${i}.sh`);
+
+describe("toShards", () => {
+  test("splits into consecutive shards of at most size items", () => {
+    expect(toShards(["a", "b", "c", "d", "e"], 2)).toEqual([
+      ["a", "b"],
+      ["c", "d"],
+      ["e"],
+    ]);
+  });
+
+  test("returns no shards for no items", () => {
+    expect(toShards([], 10)).toEqual([]);
+  });
+});
+
+describe("mapShards", () => {
+  test("runs one shard at a time and keeps the input order", async () => {
+    let inFlight = 0;
+    let peak = 0;
+    const result = await mapShards(files(5), 2, async (shard) => {
+      inFlight++;
+      peak = Math.max(peak, inFlight);
+      // Later shards finish first if they overlap
+      await new Promise((resolve) => setTimeout(resolve, 5 - shard.length));
+      inFlight--;
+      return shard.map((file) => file.toUpperCase());
+    });
+
+    expect(peak).toBe(1);
+    expect(result).toEqual(["F0.SH", "F1.SH", "F2.SH", "F3.SH", "F4.SH"]);
+  });
+
+  test("defaults to DEFAULT_BATCH_SIZE files per shard", async () => {
+    const sizes: number[] = [];
+    await mapShards(files(DEFAULT_BATCH_SIZE + 1), undefined, async (shard) => {
+      sizes.push(shard.length);
+      return [];
+    });
+
+    expect(sizes).toEqual([DEFAULT_BATCH_SIZE, 1]);
+  });
+});
+
+describe("forEachShard", () => {
+  test("is not called without files", async () => {
+    let calls = 0;
+    await forEachShard([], 10, async () => {
+      calls++;
+    });
+    expect(calls).toBe(0);
+  });
+});
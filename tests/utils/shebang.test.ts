@@ -0,0 +1,87 @@
+import { describe, expect, test } from "bun:test";
+import {
+  findShebangScripts,
+  SHEBANG_SHELLS,
+  shebangInterpreter,
+} from "@/utils/shebang";
+import { FakeFileManager } from "../fakes/fake-file-manager";
+
+class CountingFileManager extends FakeFileManager {
+  reads = 0;
+
+  override async readText(path: string): Promise<string> {
+    this.reads++;
+    return super.readText(path);
+  }
+}
+
+describe("shebangInterpreter", () => {
+  test("names the interpreter of a direct path", () => {
+    expect(shebangInterpreter("#!/bin/bash\necho hi\n")).toBe("bash");
+    expect(shebangInterpreter("#! /bin/sh -eu\n")).toBe("sh");
+  });
+
+  test("looks through env, its options and assignments", () => {
+    expect(shebangInterpreter("#!/usr/bin/env bash\n")).toBe("bash");
+    expect(shebangInterpreter("#!/usr/bin/env -S zsh -f\n")).toBe("zsh");
+    expect(shebangInterpreter("#!/usr/bin/env LC_ALL=C dash\n")).toBe("dash");
+  });
+
+  test("returns null without a shebang", () => {
+    expect(shebangInterpreter("echo hi\n")).toBeNull();
+    expect(shebangInterpreter("")).toBeNull();
+    expect(shebangInterpreter("#!\n")).toBeNull();
+    expect(shebangInterpreter("#!/usr/bin/env\n")).toBeNull();
+  });
+});
+
+describe("findShebangScripts", () => {
+  function makeProject(): CountingFileManager {
+    const fm = new CountingFileManager();
+    fm.seedExecutable("/project/bin/deploy", "#!/usr/bin/env bash\nset -e\n");
+    fm.seedExecutable("/project/bin/serve", "#!/usr/bin/env python3\n");
+    fm.seedExecutable("/project/bin/legacy", "#!/bin/ksh\n");
+    fm.seed("/project/bin/notes", "#!/bin/sh\n"); // not executable
+    fm.seedExecutable("/project/bin/run.py", "#!/bin/sh\n"); // has an extension
+    fm.seedExecutable("/project/node_modules/x/bin/cli", "#!/bin/sh\n");
+    return fm;
+  }
+
+  test("finds executable extensionless files with a shell shebang", async () => {
+    const scripts = await findShebangScripts(makeProject(), "/project", SHEBANG_SHELLS);
+    expect(scripts).toEqual(["bin/deploy"]);
+  });
+
+  test("recognizes extra shells", async () => {
+    const shells = [...SHEBANG_SHELLS, "ksh"];
+    const scripts = await findShebangScripts(makeProject(), "/project", shells);
+    expect(scripts).toEqual(["bin/deploy", "bin/legacy"]);
+  });
+
+  test("selects from a file list when given", async () => {
+    const fm = makeProject();
+    const scripts = await findShebangScripts(fm, "/project", SHEBANG_SHELLS, {
+      files: ["bin/serve", "bin/deploy", "README.md"],
+    });
+    expect(scripts).toEqual(["bin/deploy"]);
+  });
+
+  test("skips ignored paths", async () => {
+    const fm = makeProject();
+    const scripts = await findShebangScripts(fm, "/project", SHEBANG_SHELLS, {
+      ignore: ["bin/**"],
+    });
+    expect(scripts).toEqual([]);
+  });
+
+  test("reads each file once per file manager", async () => {
+    const fm = makeProject();
+
+    await findShebangScripts(fm, "/project", SHEBANG_SHELLS);
+    const first = fm.reads;
+    await findShebangScripts(fm, "/project", SHEBANG_SHELLS);
+
+    expect(first).toBeGreaterThan(0);
+    expect(fm.reads).toBe(first);
+  });
+});
@@ -0,0 +1,70 @@
+import { describe, expect, test } from "bun:test";
+import { Tracer, traced } from "@/utils/trace";
+
+/** A clock that reads `times` in order, in milliseconds */
+function fakeClock(...times: number[]): () => number {
+  let i = 0;
+  return () => times[i++] ?? 0;
+}
+
+describe("Tracer", () => {
+  test("records a span in microseconds from the tracer's creation", async () => {
+    const tracer = new Tracer(fakeClock(100, 102, 105.5));
+    const value = await tracer.span("load-config", async () => 42);
+    expect(value).toBe(42);
+    expect(tracer.toJSON().traceEvents).toContainEqual({
+      name: "load-config",
+      cat: "check",
+      ph: "X",
+      ts: 2000,
+      dur: 3500,
+      pid: 1,
+      tid: 1,
+    });
+  });
+
+  test("records a span whose work throws, and rethrows", async () => {
+    const tracer = new Tracer(fakeClock(0, 1, 2));
+    const fail = tracer.span("check", async () => {
+      throw new Error("boom");
+    });
+    await expect(fail).rejects.toThrow("boom");
+    const names = tracer.toJSON().traceEvents.map((e) => e.name);
+    expect(names).toContain("check");
+  });
+
+  test("names each lane and puts spans in start order", async () => {
+    const tracer = new Tracer(fakeClock(0, 1, 2, 3, 4));
+    const pyright = () =>
+      tracer.span("pyright", async () => {}, { cat: "runner", lane: "pyright" });
+    await tracer.span("ruff", pyright, { cat: "runner", lane: "ruff" });
+
+    const { traceEvents } = tracer.toJSON();
+    expect(traceEvents).toEqual([
+      { name: "thread_name", ph: "M", pid: 1, tid: 1, args: { name: "pyright" } },
+      { name: "thread_name", ph: "M", pid: 1, tid: 2, args: { name: "ruff" } },
+      { name: "ruff", cat: "runner", ph: "X", ts: 1000, dur: 3000, pid: 1, tid: 2 },
+      { name: "pyright", cat: "runner", ph: "X", ts: 2000, dur: 1000, pid: 1, tid: 1 },
+    ]);
+  });
+
+  test("serializes as a trace file", async () => {
+    const tracer = new Tracer(fakeClock(0, 0, 1));
+    await tracer.span("fix", async () => {});
+    const parsed: unknown = JSON.parse(JSON.stringify(tracer));
+    expect(parsed).toMatchObject({ displayTimeUnit: "ms" });
+  });
+});
+
+describe("traced", () => {
+  test("just runs the work without a tracer", async () => {
+    expect(await traced(undefined, "check", async () => "done")).toBe("done");
+  });
+
+  test("records a span with a tracer", async () => {
+    const tracer = new Tracer(fakeClock(0, 0, 1));
+    await traced(tracer, "detect-languages", async () => {});
+    const names = tracer.toJSON().traceEvents.map((e) => e.name);
+    expect(names).toEqual(["thread_name", "detect-languages"]);
+  });
+});
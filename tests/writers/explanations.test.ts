@@ -0,0 +1,29 @@
+import { describe, expect, test } from "bun:test";
+import { explainIssue, RULE_EXPLANATIONS } from "@/writers/explanations";
+
+describe("explainIssue", () => {
+  test("returns the rationale and fix hint for a known rule", () => {
+    expect(explainIssue({ rule: "gosec/G104" })).toEqual([
+      "    why: An error return is ignored, so a failure goes unnoticed.",
+      "    fix: Handle the error, or assign it to `_` with a comment saying why it is safe.",
+    ]);
+  });
+
+  test("returns nothing for an unknown rule", () => {
+    expect(explainIssue({ rule: "gosec/G999" })).toEqual([]);
+  });
+
+  test("shares one explanation across the format-only rules", () => {
+    expect(explainIssue({ rule: "gofumpt/format" })).toEqual(
+      explainIssue({ rule: "rustfmt/format" })
+    );
+    expect(explainIssue({ rule: "gofumpt/format" })).toHaveLength(2);
+  });
+
+  test("keys every entry by a full runner/code rule id", () => {
+    for (const [rule, explanation] of Object.entries(RULE_EXPLANATIONS)) {
+      expect(rule).toMatch(/^[\w-]+\/\S+$/);
+      expect(explanation.why).not.toBe("");
+    }
+  });
+});
@@ -0,0 +1,94 @@
+import { describe, expect, test } from "bun:test";
+import type { LintIssue } from "@/models/lint-issue";
+import type { RunnerReport } from "@/models/runner-report";
+import { issuesToGithub } from "@/writers/github";
+
+function makeIssue(overrides: Partial<LintIssue> = {}): LintIssue {
+  return {
+    rule: "ruff/E501",
+    linter: "ruff",
+    file: "src/foo.py",
+    line: 10,
+    col: 1,
+    message: "Line too long",
+    severity: "error",
+    fingerprint: "abc123",
+    ...overrides,
+  };
+}
+
+describe("issuesToGithub", () => {
+  test("prints nothing when there are no findings", () => {
+    const runners: RunnerReport[] = [
+      { runnerId: "ruff", name: "Ruff", status: "ok", durationMs: 10 },
+    ];
+    expect(issuesToGithub([], runners)).toBe("");
+  });
+
+  test("groups annotations per runner, by severity", () => {
+    const runners: RunnerReport[] = [
+      { runnerId: "ruff", name: "Ruff", status: "ok", durationMs: 10 },
+      { runnerId: "codespell", name: "codespell", status: "ok", durationMs: 5 },
+    ];
+    const issues = [
+      makeIssue(),
+      makeIssue({
+        rule: "ruff/D100",
+        severity: "warning",
+        message: "Missing docstring",
+      }),
+      makeIssue({
+        linter: "codespell",
+        rule: "codespell/typo",
+        severity: "info",
+        file: "README.md",
+        line: 3,
+        col: 7,
+        message: "teh ==> the",
+      }),
+    ];
+
+    expect(issuesToGithub(issues, runners).split("\n")).toEqual([
+      "::group::Ruff",
+      "::error file=src/foo.py,line=10,col=1,title=ruff/E501::Line too long",
+      "::warning file=src/foo.py,line=10,col=1,title=ruff/D100::Missing docstring",
+      "::endgroup::",
+      "::group::codespell",
+      "::notice file=README.md,line=3,col=7,title=codespell/typo::teh ==> the",
+      "::endgroup::",
+    ]);
+  });
+
+  test("escapes newlines, percent signs and property separators", () => {
+    const issue = makeIssue({
+      file: "src/a,b:c.py",
+      message: "50% done\nsecond line",
+    });
+
+    expect(issuesToGithub([issue])).toContain(
+      "::error file=src/a%2Cb%3Ac.py,line=10,col=1,title=ruff/E501::" +
+        "50%25 done%0Asecond line"
+    );
+  });
+
+  test("reports a failed runner and leaves baselined findings out", () => {
+    const runners: RunnerReport[] = [
+      {
+        runnerId: "pyright",
+        name: "Pyright",
+        status: "error",
+        durationMs: 10,
+        message: "exit code 3",
+      },
+      { runnerId: "ruff", name: "Ruff", status: "ok", durationMs: 10 },
+    ];
+
+    const output = issuesToGithub([makeIssue()], runners, new Set(["abc123"]));
+
+    expect(output.split("\n")).toEqual([
+      "::group::Pyright",
+      "::error title=Pyright failed::exit code 3",
+      "::endgroup::",
+    ]);
+  });
+});
@@ -0,0 +1,81 @@
+import { describe, expect, test } from "bun:test";
+import type { LintIssue } from "@/models/lint-issue";
+import { issuesToGitlab } from "@/writers/gitlab";
+
+function makeIssue(overrides: Partial<LintIssue> = {}): LintIssue {
+  return {
+    rule: "ruff/E501",
+    linter: "ruff",
+    file: "/project/src/foo.py",
+    line: 10,
+    col: 1,
+    message: "Line too long (93 > 88)",
+    severity: "error",
+    fingerprint: "abc123",
+    ...overrides,
+  };
+}
+
+describe("issuesToGitlab", () => {
+  test("returns an empty array when there are no findings", () => {
+    expect(issuesToGitlab([], "/project")).toEqual([]);
+  });
+
+  test("maps each finding to a Code Quality entry", () => {
+    const [entry] = issuesToGitlab([makeIssue()], "/project");
+    expect(entry?.fingerprint).toMatch(/^[0-9a-f]{64}$/);
+    expect(entry).toEqual({
+      description: "Line too long (93 > 88)",
+      check_name: "ruff/E501",
+      fingerprint: entry?.fingerprint ?? "",
+      severity: "major",
+      location: { path: "src/foo.py", lines: { begin: 10 } },
+    });
+  });
+
+  test("maps warnings to minor and infos to info", () => {
+    const entries = issuesToGitlab(
+      [makeIssue({ severity: "warning" }), makeIssue({ severity: "info", line: 20 })],
+      "/project"
+    );
+    expect(entries.map((e) => e.severity)).toEqual(["minor", "info"]);
+  });
+
+  test("keeps the fingerprint when the line or the message's numbers change", () => {
+    const [before] = issuesToGitlab([makeIssue()], "/project");
+    const [after] = issuesToGitlab(
+      [makeIssue({ line: 14, message: "Line too long  (95 > 88)" })],
+      "/project"
+    );
+    expect(after?.fingerprint).toBe(before?.fingerprint ?? "");
+  });
+
+  test("gives different files, rules and messages different fingerprints", () => {
+    const entries = issuesToGitlab(
+      [
+        makeIssue(),
+        makeIssue({ file: "/project/src/bar.py" }),
+        makeIssue({ rule: "ruff/W291" }),
+        makeIssue({ message: "Trailing whitespace" }),
+      ],
+      "/project"
+    );
+    expect(new Set(entries.map((e) => e.fingerprint)).size).toBe(4);
+  });
+
+  test("numbers repeats of one finding in a file so they stay distinct", () => {
+    const entries = issuesToGitlab(
+      [makeIssue({ line: 3 }), makeIssue({ line: 7 })],
+      "/project"
+    );
+    const [first, second] = entries;
+    expect(first?.fingerprint).not.toBe(second?.fingerprint ?? "");
+    const [alone] = issuesToGitlab([makeIssue({ line: 3 })], "/project");
+    expect(alone?.fingerprint).toBe(first?.fingerprint ?? "");
+  });
+
+  test("leaves paths as they are without a project dir", () => {
+    const [entry] = issuesToGitlab([makeIssue({ file: "src/foo.py" })]);
+    expect(entry?.location.path).toBe("src/foo.py");
+  });
+});
@@ -0,0 +1,118 @@
+import { describe, expect, test } from "bun:test";
+import type { LintIssue } from "@/models/lint-issue";
+import type { RunnerReport } from "@/models/runner-report";
+import { issuesToJson, JSON_REPORT_SCHEMA_VERSION } from "@/writers/json";
+
+function makeIssue(overrides: Partial<LintIssue> = {}): LintIssue {
+  return {
+    rule: "ruff/E501",
+    linter: "ruff",
+    file: "/project/foo.py",
+    line: 10,
+    col: 1,
+    message: "Line too long",
+    severity: "error",
+    fingerprint: "abc123",
+    ...overrides,
+  };
+}
+
+describe("issuesToJson", () => {
+  test("stamps the report with the schema version", () => {
+    expect(issuesToJson([]).schemaVersion).toBe(JSON_REPORT_SCHEMA_VERSION);
+  });
+
+  test("produces one entry per runner with its findings", () => {
+    const runners: RunnerReport[] = [
+      { runnerId: "ruff", name: "Ruff", status: "ok", durationMs: 42 },
+      { runnerId: "shellcheck", name: "ShellCheck", status: "ok", durationMs: 7 },
+    ];
+    const report = issuesToJson([makeIssue()], runners);
+
+    expect(report.runners).toHaveLength(2);
+    expect(report.runners[0]).toEqual({
+      id: "ruff",
+      name: "Ruff",
+      status: "ok",
+      skipped: false,
+      cached: false,
+      durationMs: 42,
+      findings: [
+        {
+          file: "/project/foo.py",
+          line: 10,
+          col: 1,
+          rule: "ruff/E501",
+          severity: "error",
+          message: "Line too long",
+          fingerprint: "abc123",
+          baselined: false,
+        },
+      ],
+    });
+    expect(report.runners[1]?.findings).toHaveLength(0);
+  });
+
+  test("flags skipped runners and reports failed runners' errors", () => {
+    const runners: RunnerReport[] = [
+      { runnerId: "pyright", name: "Pyright", status: "skipped", durationMs: 1 },
+      {
+        runnerId: "clippy",
+        name: "Clippy",
+        status: "error",
+        durationMs: 9,
+        message: "cargo exited 101",
+      },
+    ];
+    const report = issuesToJson([], runners);
+
+    expect(report.runners[0]?.skipped).toBe(true);
+    expect(report.runners[1]?.status).toBe("error");
+    expect(report.runners[1]?.error).toBe("cargo exited 101");
+    expect(report.summary.skipped).toBe(1);
+    expect(report.summary.disabled).toBe(0);
+    expect(report.summary.failed).toBe(1);
+  });
+
+  test("includes the module of findings from per-module runners", () => {
+    const issue = makeIssue({ linter: "staticcheck", module: "services/api" });
+    const [runner] = issuesToJson([issue]).runners;
+    expect(runner?.findings[0]?.module).toBe("services/api");
+    expect(issuesToJson([makeIssue()]).runners[0]?.findings[0]).not.toHaveProperty(
+      "module"
+    );
+  });
+
+  test("marks findings in the baseline", () => {
+    const issues = [makeIssue(), makeIssue({ fingerprint: "new1" })];
+    const [runner] = issuesToJson(issues, [], new Set(["abc123"])).runners;
+    expect(runner?.findings.map((f) => f.baselined)).toEqual([true, false]);
+  });
+
+  test("summarises findings per severity", () => {
+    const issues = [
+      makeIssue(),
+      makeIssue({ severity: "warning", fingerprint: "w1" }),
+      makeIssue({ severity: "warning", fingerprint: "w2" }),
+      makeIssue({ severity: "info", fingerprint: "i1" }),
+    ];
+    const { summary } = issuesToJson(issues);
+
+    expect(summary).toEqual({
+      errors: 1,
+      warnings: 2,
+      infos: 1,
+      skipped: 0,
+      disabled: 0,
+      failed: 0,
+    });
+  });
+
+  test("gives issues without a runner report an entry named after the linter", () => {
+    const report = issuesToJson([makeIssue({ linter: "ruff" })]);
+
+    expect(report.runners).toHaveLength(1);
+    expect(report.runners[0]?.id).toBe("ruff");
+    expect(report.runners[0]?.findings).toHaveLength(1);
+  });
+});
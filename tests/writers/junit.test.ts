@@ -0,0 +1,136 @@
+import { describe, expect, test } from "bun:test";
+import type { LintIssue } from "@/models/lint-issue";
+import type { RunnerReport } from "@/models/runner-report";
+import { issuesToJunit } from "@/writers/junit";
+
+function makeIssue(overrides: Partial<LintIssue> = {}): LintIssue {
+  return {
+    rule: "ruff/E501",
+    linter: "ruff",
+    file: "src/foo.py",
+    line: 10,
+    col: 1,
+    message: "Line too long",
+    severity: "error",
+    fingerprint: "abc123",
+    ...overrides,
+  };
+}
+
+describe("issuesToJunit", () => {
+  test("produces an empty testsuites document when nothing ran", () => {
+    const xml = issuesToJunit([]);
+    expect(xml).toStartWith('<?xml version="1.0" encoding="UTF-8"?>');
+    expect(xml).toContain('<testsuites name="ai-guardrails" tests="0" failures="0"');
+    expect(xml).toEndWith("</testsuites>");
+  });
+
+  test("renders each finding as a failing testcase named by location and rule", () => {
+    const runners: RunnerReport[] = [
+      { runnerId: "ruff", name: "Ruff", status: "ok", durationMs: 1500 },
+    ];
+    const xml = issuesToJunit([makeIssue()], runners);
+
+    expect(xml).toContain(
+      '<testsuite name="Ruff" tests="1" failures="1" errors="0" skipped="0" time="1.500">'
+    );
+    expect(xml).toContain(
+      '<testcase name="src/foo.py:10:1 ruff/E501" classname="ruff"'
+    );
+    expect(xml).toContain(
+      '<failure message="Line too long" type="error">Line too long</failure>'
+    );
+  });
+
+  test("renders a baselined finding as a skipped testcase, not a failure", () => {
+    const runners: RunnerReport[] = [
+      { runnerId: "ruff", name: "Ruff", status: "ok", durationMs: 0 },
+    ];
+    const issues = [makeIssue(), makeIssue({ line: 20, fingerprint: "new1" })];
+    const xml = issuesToJunit(issues, runners, new Set(["abc123"]));
+
+    expect(xml).toContain('<testsuites name="ai-guardrails" tests="2" failures="1"');
+    expect(xml).toContain('tests="2" failures="1" errors="0" skipped="1"');
+    expect(xml).toContain('<skipped message="baselined: Line too long"/>');
+    expect(xml).toContain('<testcase name="src/foo.py:20:1 ruff/E501"');
+  });
+
+  test("gives a clean runner one passing testcase", () => {
+    const runners: RunnerReport[] = [
+      { runnerId: "shellcheck", name: "ShellCheck", status: "ok", durationMs: 5 },
+    ];
+    const xml = issuesToJunit([], runners);
+
+    expect(xml).toContain('tests="1" failures="0" errors="0" skipped="0"');
+    expect(xml).toContain(
+      '<testcase name="ShellCheck" classname="shellcheck" time="0"/>'
+    );
+  });
+
+  test("renders a skipped runner as a single skipped testcase", () => {
+    const runners: RunnerReport[] = [
+      { runnerId: "pyright", name: "Pyright", status: "skipped", durationMs: 0 },
+    ];
+    const xml = issuesToJunit([], runners);
+
+    expect(xml).toContain('tests="1" failures="0" errors="0" skipped="1"');
+    expect(xml).toContain('<skipped message="Pyright not installed"/>');
+  });
+
+  test("renders a disabled runner as a skipped testcase", () => {
+    const runners: RunnerReport[] = [
+      { runnerId: "codespell", name: "codespell", status: "disabled", durationMs: 0 },
+    ];
+    const xml = issuesToJunit([], runners);
+
+    expect(xml).toContain('tests="1" failures="0" errors="0" skipped="1"');
+    expect(xml).toContain('<skipped message="codespell disabled"/>');
+  });
+
+  test("renders a runner cancelled by --fail-fast as a skipped testcase", () => {
+    const runners: RunnerReport[] = [
+      { runnerId: "clippy", name: "Clippy", status: "cancelled", durationMs: 0 },
+    ];
+    const xml = issuesToJunit([], runners);
+
+    expect(xml).toContain('tests="1" failures="0" errors="0" skipped="1"');
+    expect(xml).toContain('<skipped message="Clippy cancelled (--fail-fast)"/>');
+  });
+
+  test("renders a failed runner as an errored testcase", () => {
+    const runners: RunnerReport[] = [
+      {
+        runnerId: "clippy",
+        name: "Clippy",
+        status: "error",
+        durationMs: 20,
+        message: "cargo exited 101",
+      },
+    ];
+    const xml = issuesToJunit([], runners);
+
+    expect(xml).toContain('errors="1"');
+    expect(xml).toContain('<error message="cargo exited 101">cargo exited 101</error>');
+  });
+
+  test("escapes XML special characters in names and messages", () => {
+    const issue = makeIssue({ message: `Use "<T>" & 'x'` });
+    const xml = issuesToJunit([issue]);
+
+    expect(xml).toContain("Use &quot;&lt;T&gt;&quot; &amp; &apos;x&apos;");
+    expect(xml).not.toContain("<T>");
+  });
+
+  test("totals counts across all suites", () => {
+    const runners: RunnerReport[] = [
+      { runnerId: "ruff", name: "Ruff", status: "ok", durationMs: 1000 },
+      { runnerId: "pyright", name: "Pyright", status: "skipped", durationMs: 0 },
+    ];
+    const issues = [makeIssue(), makeIssue({ line: 20, fingerprint: "def456" })];
+    const xml = issuesToJunit(issues, runners);
+
+    expect(xml).toContain(
+      '<testsuites name="ai-guardrails" tests="3" failures="2" errors="0" skipped="1" time="1.000">'
+    );
+  });
+});
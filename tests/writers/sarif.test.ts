@@ -1,5 +1,7 @@
 import { describe, expect, test } from "bun:test";
 import type { LintIssue } from "@/models/lint-issue";
+import type { RunnerReport } from "@/models/runner-report";
+import { getVersion } from "@/utils/version";
 import { issuesToSarif } from "@/writers/sarif";
 
 function makeIssue(overrides: Partial<LintIssue> = {}): LintIssue {
@@ -27,19 +29,88 @@ describe("issuesToSarif", () => {
     expect(sarif.$schema).toContain("sarif-schema-2.1.0");
   });
 
-  test("includes one run", () => {
+  test("produces no runs when nothing ran and no issues exist", () => {
     const sarif = issuesToSarif([]);
-    expect(sarif.runs).toHaveLength(1);
+    expect(sarif.runs).toHaveLength(0);
   });
 
-  test("tool name is ai-guardrails", () => {
-    const sarif = issuesToSarif([]);
-    expect(sarif.runs[0]?.tool.driver.name).toBe("ai-guardrails");
+  test("produces one run per runner that ran", () => {
+    const runners: RunnerReport[] = [
+      { runnerId: "ruff", name: "Ruff", status: "ok", durationMs: 0 },
+      { runnerId: "shellcheck", name: "ShellCheck", status: "ok", durationMs: 12 },
+    ];
+    const sarif = issuesToSarif([makeIssue()], runners);
+    expect(sarif.runs).toHaveLength(2);
+    expect(sarif.runs[0]?.tool.driver.name).toBe("Ruff");
+    expect(sarif.runs[0]?.results).toHaveLength(1);
+    expect(sarif.runs[1]?.tool.driver.name).toBe("ShellCheck");
+    expect(sarif.runs[1]?.results).toHaveLength(0);
   });
 
-  test("produces empty results for no issues", () => {
-    const sarif = issuesToSarif([]);
-    expect(sarif.runs[0]?.results).toHaveLength(0);
+  test("skipped and disabled runners produce no run", () => {
+    const runners: RunnerReport[] = [
+      { runnerId: "ruff", name: "Ruff", status: "ok", durationMs: 0 },
+      { runnerId: "shellcheck", name: "ShellCheck", status: "skipped", durationMs: 1 },
+      { runnerId: "codespell", name: "codespell", status: "disabled", durationMs: 0 },
+    ];
+    const sarif = issuesToSarif([], runners);
+    expect(sarif.runs.map((r) => r.tool.driver.name)).toEqual(["Ruff"]);
+  });
+
+  test("gives each run a distinct automation category", () => {
+    const runners: RunnerReport[] = [
+      { runnerId: "ruff", name: "Ruff", status: "ok", durationMs: 0 },
+      { runnerId: "pyright", name: "Pyright", status: "ok", durationMs: 0 },
+    ];
+    const sarif = issuesToSarif([], runners);
+    expect(sarif.runs.map((r) => r.automationDetails.id)).toEqual([
+      "ai-guardrails/ruff/",
+      "ai-guardrails/pyright/",
+    ]);
+  });
+
+  test("marks a failed runner's invocation as unsuccessful", () => {
+    const runners: RunnerReport[] = [
+      { runnerId: "ruff", name: "Ruff", status: "ok", durationMs: 5 },
+      {
+        runnerId: "pyright",
+        name: "Pyright",
+        status: "error",
+        durationMs: 7,
+        message: "pyright crashed",
+      },
+    ];
+    const sarif = issuesToSarif([], runners);
+    expect(sarif.runs[0]?.invocations).toEqual([{ executionSuccessful: true }]);
+    expect(sarif.runs[1]?.invocations).toEqual([
+      {
+        executionSuccessful: false,
+        toolExecutionNotifications: [
+          { level: "error", message: { text: "pyright crashed" } },
+        ],
+      },
+    ]);
+  });
+
+  test("groups issues without a runner report into a run named after the linter", () => {
+    const sarif = issuesToSarif([makeIssue({ linter: "ruff" })]);
+    expect(sarif.runs).toHaveLength(1);
+    expect(sarif.runs[0]?.tool.driver.name).toBe("ruff");
+  });
+
+  test("lists each distinct rule once in driver rules", () => {
+    const issues = [
+      makeIssue({ rule: "ruff/E501" }),
+      makeIssue({ rule: "ruff/F401", severity: "warning" }),
+      makeIssue({ rule: "ruff/E501", line: 20 }),
+    ];
+    const sarif = issuesToSarif(issues);
+    const run = sarif.runs[0];
+    expect(run?.tool.driver.rules).toEqual([
+      { id: "ruff/E501", defaultConfiguration: { level: "error" } },
+      { id: "ruff/F401", defaultConfiguration: { level: "warning" } },
+    ]);
+    expect(run?.results.map((r) => r.ruleIndex)).toEqual([0, 1, 0]);
   });
 
   test("maps issue to SARIF result with correct ruleId", () => {
@@ -61,21 +132,62 @@ describe("issuesToSarif", () => {
     expect(sarif.runs[0]?.results[0]?.level).toBe("warning");
   });
 
-  test("includes file URI and line/col in location", () => {
+  test("maps severity info to SARIF level note", () => {
+    const issue = makeIssue({ severity: "info" });
+    const sarif = issuesToSarif([issue]);
+    expect(sarif.runs[0]?.results[0]?.level).toBe("note");
+  });
+
+  test("includes a file URI and line/col in location", () => {
     const issue = makeIssue({ file: "/project/foo.py", line: 42, col: 7 });
     const sarif = issuesToSarif([issue]);
     const location = sarif.runs[0]?.results[0]?.locations[0];
-    expect(location?.physicalLocation.artifactLocation.uri).toBe("/project/foo.py");
+    expect(location?.physicalLocation.artifactLocation).toEqual({
+      uri: "file:///project/foo.py",
+    });
     expect(location?.physicalLocation.region.startLine).toBe(42);
     expect(location?.physicalLocation.region.startColumn).toBe(7);
   });
 
+  test("locates files relative to %SRCROOT%, the project dir", () => {
+    const issues = [
+      makeIssue({ file: "/project/src/my file.py" }),
+      makeIssue({ file: "/elsewhere/lib.py", fingerprint: "out1" }),
+    ];
+    const sarif = issuesToSarif(issues, [], new Set(), "/project");
+    const run = sarif.runs[0];
+    const locations = run?.results.map(
+      (r) => r.locations[0]?.physicalLocation.artifactLocation
+    );
+    expect(locations).toEqual([
+      { uri: "src/my%20file.py", uriBaseId: "%SRCROOT%" },
+      { uri: "file:///elsewhere/lib.py" },
+    ]);
+    expect(run?.originalUriBaseIds).toEqual({
+      "%SRCROOT%": { uri: "file:///project/" },
+    });
+  });
+
+  test("stamps each driver with the ai-guardrails version", () => {
+    const sarif = issuesToSarif([makeIssue()]);
+    expect(sarif.runs[0]?.tool.driver.version).toBe(getVersion());
+  });
+
   test("includes message text", () => {
     const issue = makeIssue({ message: "Line too long (120 > 88)" });
     const sarif = issuesToSarif([issue]);
     expect(sarif.runs[0]?.results[0]?.message.text).toBe("Line too long (120 > 88)");
   });
 
+  test("sets baselineState from the baseline", () => {
+    const issues = [makeIssue(), makeIssue({ fingerprint: "new1" })];
+    const sarif = issuesToSarif(issues, [], new Set(["abc123"]));
+    expect(sarif.runs[0]?.results.map((r) => r.baselineState)).toEqual([
+      "unchanged",
+      "new",
+    ]);
+  });
+
   test("handles multiple issues", () => {
     const issues = [
       makeIssue(),
@@ -0,0 +1,120 @@
+import { describe, expect, test } from "bun:test";
+import type { LintIssue } from "@/models/lint-issue";
+import type { RunnerReport } from "@/models/runner-report";
+import { issuesToSummary, SUMMARY_REPORT_SCHEMA_VERSION } from "@/writers/summary";
+
+function makeIssue(overrides: Partial<LintIssue> = {}): LintIssue {
+  return {
+    rule: "ruff/E501",
+    linter: "ruff",
+    file: "/project/foo.py",
+    line: 10,
+    col: 1,
+    message: "Line too long",
+    severity: "error",
+    fingerprint: "abc123",
+    ...overrides,
+  };
+}
+
+const PASSED = { passed: true, durationMs: 0 };
+
+describe("issuesToSummary", () => {
+  test("stamps the report with the schema version and the check's outcome", () => {
+    const report = issuesToSummary([], [], { passed: false, durationMs: 1830 });
+
+    expect(report.schemaVersion).toBe(SUMMARY_REPORT_SCHEMA_VERSION);
+    expect(report.passed).toBe(false);
+    expect(report.durationMs).toBe(1830);
+  });
+
+  test("counts each runner's findings by severity, without listing them", () => {
+    const runners: RunnerReport[] = [
+      { runnerId: "ruff", name: "Ruff", status: "ok", durationMs: 42, cached: true },
+      { runnerId: "shellcheck", name: "ShellCheck", status: "ok", durationMs: 7 },
+    ];
+    const issues = [
+      makeIssue(),
+      makeIssue({ severity: "warning", fingerprint: "def456" }),
+      makeIssue({ severity: "warning", fingerprint: "ghi789" }),
+    ];
+
+    const report = issuesToSummary(issues, runners, PASSED);
+
+    expect(report.runners).toEqual([
+      {
+        id: "ruff",
+        name: "Ruff",
+        status: "ok",
+        cached: true,
+        durationMs: 42,
+        errors: 1,
+        warnings: 2,
+        infos: 0,
+        baselined: 0,
+      },
+      {
+        id: "shellcheck",
+        name: "ShellCheck",
+        status: "ok",
+        cached: false,
+        durationMs: 7,
+        errors: 0,
+        warnings: 0,
+        infos: 0,
+        baselined: 0,
+      },
+    ]);
+    expect(report.summary).toEqual({
+      errors: 1,
+      warnings: 2,
+      infos: 0,
+      skipped: 0,
+      disabled: 0,
+      failed: 0,
+      baselined: 0,
+    });
+  });
+
+  test("counts only new findings, with baselined ones counted apart", () => {
+    const runners: RunnerReport[] = [
+      { runnerId: "ruff", name: "Ruff", status: "ok", durationMs: 42 },
+    ];
+    const issues = [
+      makeIssue(),
+      makeIssue({ fingerprint: "def456" }),
+      makeIssue({ severity: "warning", fingerprint: "ghi789" }),
+    ];
+
+    const report = issuesToSummary(issues, runners, PASSED, new Set(["abc123"]));
+
+    expect(report.runners[0]).toMatchObject({ errors: 1, warnings: 1, baselined: 1 });
+    expect(report.summary).toMatchObject({ errors: 1, warnings: 1, baselined: 1 });
+  });
+
+  test("keeps a failed runner's error and counts it as failed", () => {
+    const runners: RunnerReport[] = [
+      {
+        runnerId: "clippy",
+        name: "Clippy",
+        status: "error",
+        durationMs: 3,
+        message: "cargo not found",
+      },
+      { runnerId: "pyright", name: "Pyright", status: "skipped", durationMs: 1 },
+    ];
+
+    const report = issuesToSummary([], runners, PASSED);
+
+    expect(report.runners[0]?.error).toBe("cargo not found");
+    expect(report.summary.failed).toBe(1);
+    expect(report.summary.skipped).toBe(1);
+  });
+
+  test("gives a linter with findings but no report an ok entry", () => {
+    const report = issuesToSummary([makeIssue({ linter: "codespell" })], [], PASSED);
+
+    expect(report.runners).toHaveLength(1);
+    expect(report.runners[0]).toMatchObject({ id: "codespell", errors: 1 });
+  });
+});
@@ -1,6 +1,18 @@
 import { describe, expect, test } from "bun:test";
 import type { LintIssue } from "@/models/lint-issue";
-import { formatIssue, formatIssues } from "@/writers/text";
+import type { RunnerReport } from "@/models/runner-report";
+import {
+  FindingCap,
+  formatGroupedIssues,
+  formatIssue,
+  formatIssueSummary,
+  formatIssues,
+  formatRepeatTimings,
+  formatRunnerProgress,
+  formatRunnerTimings,
+  groupByFromFlags,
+  groupIssues,
+} from "@/writers/text";
 
 function makeIssue(overrides: Partial<LintIssue> = {}): LintIssue {
   return {
@@ -51,6 +63,17 @@ describe("formatIssues", () => {
     expect(output).toContain("2 issue(s)");
   });
 
+  test("summary counts issues per severity", () => {
+    const issues = [
+      makeIssue(),
+      makeIssue({ severity: "warning" }),
+      makeIssue({ severity: "warning" }),
+      makeIssue({ severity: "info" }),
+    ];
+    const lines = formatIssues(issues).split("\n");
+    expect(lines.at(-1)).toBe("4 issue(s) found: 1 error, 2 warning, 1 info");
+  });
+
   test("formats multiple issues as separate lines", () => {
     const issues = [
       makeIssue({ file: "/a.py", line: 1 }),
@@ -60,6 +83,33 @@ describe("formatIssues", () => {
     expect(output).toContain("/a.py");
     expect(output).toContain("/b.py");
   });
+
+  test("marks baselined issues and counts them in the summary", () => {
+    const issues = [
+      makeIssue({ fingerprint: "fp-old", line: 1 }),
+      makeIssue({ fingerprint: "fp-new", line: 2 }),
+    ];
+    const lines = formatIssues(issues, new Set(["fp-old"])).split("\n");
+    expect(lines[0]).toEndWith("(baselined)");
+    expect(lines[1]).not.toContain("(baselined)");
+    expect(lines.at(-1)).toBe(
+      "2 issue(s) found: 2 error, 0 warning, 0 info (1 baselined)"
+    );
+  });
+
+  test("with explain, follows each finding with its rule's rationale", () => {
+    const issues = [
+      makeIssue({ rule: "staticcheck/SA4006", line: 1 }),
+      makeIssue({ rule: "staticcheck/SA9999", line: 2 }),
+    ];
+    const lines = formatIssues(issues, new Set(), true).split("\n");
+    expect(lines.slice(0, 4)).toEqual([
+      "/project/foo.py:1:1: [ERROR] staticcheck/SA4006: Line too long",
+      "    why: A value is assigned and then overwritten or dropped before anything reads it.",
+      "    fix: Remove the assignment, or use the value — often a missed error check.",
+      "/project/foo.py:2:1: [ERROR] staticcheck/SA9999: Line too long",
+    ]);
+  });
 });
 
 describe("formatIssue", () => {
@@ -75,4 +125,279 @@ describe("formatIssue", () => {
     expect(output).toContain("/foo.py:5:3");
     expect(output).toContain("ruff/E501");
   });
+
+  test("names the other runners behind a collapsed duplicate", () => {
+    const issue = makeIssue({ linter: "ruff", reportedBy: ["ruff", "pylint"] });
+    expect(formatIssue(issue)).toBe(
+      "/project/foo.py:10:1: [ERROR] E501: Line too long (also reported by pylint)"
+    );
+  });
+});
+
+describe("formatIssueSummary", () => {
+  test("counts issues per severity without listing them", () => {
+    const issues = [makeIssue(), makeIssue({ severity: "info", fingerprint: "b" })];
+    expect(formatIssueSummary(issues, new Set(["b"]))).toBe(
+      "2 issue(s) found: 1 error, 0 warning, 1 info (1 baselined)"
+    );
+  });
+
+  test("returns empty string for no issues", () => {
+    expect(formatIssueSummary([])).toBe("");
+  });
+});
+
+describe("formatRunnerProgress", () => {
+  const report: RunnerReport = {
+    runnerId: "ruff",
+    name: "Ruff",
+    status: "ok",
+    durationMs: 340,
+  };
+
+  test("heads the runner's issues with a live count", () => {
+    const text = formatRunnerProgress({
+      report,
+      issues: [makeIssue()],
+      baselined: new Set(["abc123"]),
+      done: 3,
+      total: 7,
+    });
+    expect(text.split("\n")).toEqual([
+      "[3/7 complete] Ruff: 1 issue(s) in 340ms",
+      "/project/foo.py:10:1: [ERROR] E501: Line too long (baselined)",
+    ]);
+  });
+
+  test("explains the runner's issues with explain", () => {
+    const text = formatRunnerProgress(
+      {
+        report,
+        issues: [makeIssue({ rule: "ruff/F401" })],
+        baselined: new Set(),
+        done: 1,
+        total: 1,
+      },
+      true
+    );
+    expect(text.split("\n")).toHaveLength(4);
+    expect(text).toContain("    why: An unused import");
+  });
+
+  test("prints only the issues the cap lets through, but counts them all", () => {
+    const text = formatRunnerProgress(
+      {
+        report,
+        issues: [makeIssue(), makeIssue({ line: 11 }), makeIssue({ line: 12 })],
+        baselined: new Set(),
+        done: 1,
+        total: 1,
+      },
+      false,
+      new FindingCap({ total: 1 })
+    );
+    expect(text.split("\n")).toEqual([
+      "[1/1 complete] Ruff: 3 issue(s) in 340ms",
+      "/project/foo.py:10:1: [ERROR] E501: Line too long",
+    ]);
+  });
+
+  test("prints the status line alone without findings", () => {
+    const text = formatRunnerProgress(
+      { report, issues: [makeIssue()], baselined: new Set(), done: 1, total: 1 },
+      false,
+      undefined,
+      false
+    );
+    expect(text).toBe("[1/1 complete] Ruff: 1 issue(s) in 340ms");
+  });
+
+  test("describes clean, cached, skipped, failed and cancelled runners", () => {
+    const progress = { issues: [], baselined: new Set<string>(), done: 1, total: 2 };
+    const line = (overrides: Partial<RunnerReport>) =>
+      formatRunnerProgress({ ...progress, report: { ...report, ...overrides } });
+
+    expect(line({ cached: true })).toBe(
+      "[1/2 complete] Ruff: no issues in 340ms (cached)"
+    );
+    expect(line({ status: "skipped" })).toBe(
+      "[1/2 complete] Ruff: not installed — skipping"
+    );
+    expect(line({ status: "error", message: "timed out after 5s" })).toBe(
+      "[1/2 complete] Ruff: failed — timed out after 5s"
+    );
+    expect(line({ status: "cancelled", message: "cancelled by --fail-fast" })).toBe(
+      "[1/2 complete] Ruff: cancelled by --fail-fast"
+    );
+  });
+});
+
+describe("groupByFromFlags", () => {
+  test("reads --group-by, defaulting to runner", () => {
+    expect(groupByFromFlags({ groupBy: "file" })).toBe("file");
+    expect(groupByFromFlags({ groupBy: "linter" })).toBe("runner");
+    expect(groupByFromFlags({})).toBe("runner");
+  });
+});
+
+describe("groupIssues", () => {
+  const issues = [
+    makeIssue({ file: "/project/b.py", line: 3, severity: "warning" }),
+    makeIssue({ file: "/project/a.py", line: 9, severity: "info", linter: "pyright" }),
+    makeIssue({ file: "/project/b.py", line: 1 }),
+    makeIssue({ file: "/project/a.py", line: 2, severity: "warning" }),
+  ];
+
+  test("groups by file in path order, each file in line order", () => {
+    const groups = groupIssues(issues, "file");
+    expect(groups.map((g) => g.heading)).toEqual([
+      "/project/a.py (2)",
+      "/project/b.py (2)",
+    ]);
+    expect(groups[0]?.issues.map((i) => i.line)).toEqual([2, 9]);
+    expect(groups[1]?.issues.map((i) => i.line)).toEqual([1, 3]);
+  });
+
+  test("groups by severity, most severe first, leaving out empty ones", () => {
+    const groups = groupIssues(issues.slice(0, 2), "severity");
+    expect(groups.map((g) => g.heading)).toEqual(["WARNING (1)", "INFO (1)"]);
+  });
+});
+
+describe("formatGroupedIssues", () => {
+  test("heads each group, with a blank line between groups", () => {
+    const text = formatGroupedIssues(
+      [makeIssue({ file: "/project/b.py" }), makeIssue({ severity: "warning" })],
+      "severity",
+      new Set(["abc123"])
+    );
+    expect(text.split("\n")).toEqual([
+      "ERROR (1)",
+      "/project/b.py:10:1: [ERROR] E501: Line too long (baselined)",
+      "",
+      "WARNING (1)",
+      "/project/foo.py:10:1: [WARNING] E501: Line too long (baselined)",
+    ]);
+  });
+
+  test("prints only the issues the cap lets through, in group order", () => {
+    const text = formatGroupedIssues(
+      [makeIssue({ file: "/project/b.py" }), makeIssue({ file: "/project/a.py" })],
+      "file",
+      new Set(),
+      false,
+      new FindingCap({ total: 1 })
+    );
+    expect(text.split("\n")).toEqual([
+      "/project/a.py (1)",
+      "/project/a.py:10:1: [ERROR] E501: Line too long",
+    ]);
+  });
+
+  test("returns an empty string for no issues", () => {
+    expect(formatGroupedIssues([], "file")).toBe("");
+  });
+});
+
+describe("FindingCap", () => {
+  const issues = (linter: string, count: number) =>
+    Array.from({ length: count }, (_, i) =>
+      makeIssue({ linter, fingerprint: `${linter}-${i}` })
+    );
+
+  test("lets through at most the total across calls", () => {
+    const cap = new FindingCap({ total: 3 });
+    expect(cap.take(issues("ruff", 2))).toHaveLength(2);
+    expect(cap.take(issues("pyright", 4))).toHaveLength(1);
+    expect(cap.note()).toBe("... and 3 more findings (use --max-findings 0 for all)");
+  });
+
+  test("limits each runner separately", () => {
+    const cap = new FindingCap({ perRunner: 2 });
+    expect(cap.take(issues("ruff", 5))).toHaveLength(2);
+    expect(cap.take(issues("pyright", 1))).toHaveLength(1);
+    expect(cap.note()).toBe("... and 3 more findings (use --max-findings 0 for all)");
+  });
+
+  test("has no note when nothing was held back", () => {
+    const cap = new FindingCap({ total: 5 });
+    cap.take(issues("ruff", 5));
+    expect(cap.note()).toBe("");
+  });
+});
+
+describe("formatRunnerTimings", () => {
+  const runner = (overrides: Partial<RunnerReport>): RunnerReport => ({
+    runnerId: "ruff",
+    name: "Ruff",
+    status: "ok",
+    durationMs: 340,
+    ...overrides,
+  });
+
+  test("lists runners slowest first with status, findings and duration", () => {
+    const lines = formatRunnerTimings(
+      [
+        runner({}),
+        runner({ runnerId: "pyright", name: "Pyright", durationMs: 4210 }),
+        runner({ runnerId: "codespell", name: "codespell", status: "skipped" }),
+      ],
+      [makeIssue(), makeIssue({ fingerprint: "def456" })]
+    );
+    expect(lines).toEqual([
+      "RUNNER     STATUS   FINDINGS  DURATION",
+      "Pyright    ok              0    4210ms",
+      "Ruff       ok              2     340ms",
+      "codespell  skipped         0     340ms",
+    ]);
+  });
+
+  test("marks cached runners and leaves out disabled ones", () => {
+    const lines = formatRunnerTimings(
+      [runner({ cached: true }), runner({ runnerId: "tsc", status: "disabled" })],
+      []
+    );
+    expect(lines).toHaveLength(2);
+    expect(lines[1]).toContain("ok (cached)");
+  });
+
+  test("returns nothing without runners", () => {
+    expect(formatRunnerTimings([], [])).toEqual([]);
+  });
+});
+
+describe("formatRepeatTimings", () => {
+  const runner = (name: string, durationMs: number): RunnerReport => ({
+    runnerId: name.toLowerCase(),
+    name,
+    status: "ok",
+    durationMs,
+  });
+
+  test("gives each runner's min, median and max, slowest first, then the total", () => {
+    const lines = formatRepeatTimings([
+      { runners: [runner("Ruff", 300), runner("Pyright", 4100)], durationMs: 4200 },
+      { runners: [runner("Ruff", 340), runner("Pyright", 3900)], durationMs: 4000 },
+      { runners: [runner("Ruff", 320), runner("Pyright", 4500)], durationMs: 4600 },
+    ]);
+    expect(lines).toEqual([
+      "Timings over 3 run(s), cache off:",
+      "RUNNER      MIN  MEDIAN     MAX",
+      "Pyright  3900ms  4100ms  4500ms",
+      "Ruff      300ms   320ms   340ms",
+      "total    4000ms  4200ms  4600ms",
+    ]);
+  });
+
+  test("leaves out runners that never ran", () => {
+    const skipped: RunnerReport = { ...runner("tsc", 0), status: "skipped" };
+    const lines = formatRepeatTimings([
+      { runners: [runner("Ruff", 300), skipped], durationMs: 310 },
+      { runners: [runner("Ruff", 200), skipped], durationMs: 210 },
+    ]);
+    expect(lines.slice(2)).toEqual([
+      "Ruff    200ms   250ms  300ms",
+      "total   210ms   260ms  310ms",
+    ]);
+  });
 });